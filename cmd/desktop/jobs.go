@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"shien-system/internal/adapter/db"
+	"shien-system/internal/adapter/scheduler"
+	"shien-system/internal/config"
+	"shien-system/internal/usecase"
+)
+
+// maintenanceJobs holds the dependencies of the periodic maintenance jobs
+type maintenanceJobs struct {
+	rateLimitSvc        *usecase.RateLimitService
+	sessionManager      usecase.SessionManager
+	lockoutRepo         *db.AccountLockoutRepository
+	notificationUseCase usecase.NotificationUseCase
+	logger              scheduler.Logger
+}
+
+// registerMaintenanceJobs registers the periodic maintenance jobs with the scheduler.
+// Schedules can be overridden per job name in the jobs.schedules configuration.
+func registerMaintenanceJobs(jobScheduler *scheduler.Scheduler, cfg *config.Config, deps maintenanceJobs) error {
+	jobs := []scheduler.Job{
+		{
+			Name:        "rate_limit_cleanup",
+			Description: "古いログイン試行記録と期限切れロックアウトの削除",
+			Schedule:    "0 3 * * *",
+			Run:         deps.rateLimitSvc.CleanupOldRecords,
+		},
+		{
+			Name:        "attack_pattern_detection",
+			Description: "不正ログインパターンの検出",
+			Schedule:    "*/15 * * * *",
+			Run:         deps.rateLimitSvc.DetectAttackPatterns,
+		},
+		{
+			Name:        "session_cleanup",
+			Description: "期限切れセッションの削除",
+			Schedule:    fmt.Sprintf("@every %dm", cfg.Security.SessionConfig.CleanupIntervalMinutes),
+			Run:         deps.sessionManager.CleanupExpiredSessions,
+		},
+		{
+			Name:        "lockout_cleanup",
+			Description: "期限切れアカウントロックアウトの削除",
+			Schedule:    "*/30 * * * *",
+			Run:         deps.lockoutRepo.CleanupExpiredLockouts,
+		},
+		{
+			Name:        "notification_refresh",
+			Description: "通知の更新（受給者証の期限・退所者の担当・アカウントロック）",
+			Schedule:    fmt.Sprintf("@every %dm", cfg.Notifications.RefreshIntervalMinutes),
+			Run: func(ctx context.Context) error {
+				result, err := deps.notificationUseCase.RefreshNotifications(ctx)
+				if err != nil {
+					return err
+				}
+				if result.Created > 0 || result.Resolved > 0 {
+					deps.logger.Info("Notifications refreshed", "created", result.Created, "resolved", result.Resolved)
+				}
+				return nil
+			},
+		},
+	}
+
+	backoff := time.Duration(cfg.Jobs.RetryBackoffSec) * time.Second
+	for _, job := range jobs {
+		if spec, ok := cfg.Jobs.Schedules[job.Name]; ok && spec != "" {
+			job.Schedule = spec
+		}
+		job.MaxRetries = cfg.Jobs.MaxRetries
+		job.Backoff = backoff
+
+		if err := jobScheduler.Register(job); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -2,53 +2,65 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
+	"log/slog"
+	"os"
+	"path/filepath"
 	"time"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/app"
 	"fyne.io/fyne/v2/container"
 	"fyne.io/fyne/v2/driver/desktop"
+	fynetheme "fyne.io/fyne/v2/theme"
 	"fyne.io/fyne/v2/widget"
 
 	"shien-system/internal/adapter/backup"
 	"shien-system/internal/adapter/crypto"
 	"shien-system/internal/adapter/db"
+	"shien-system/internal/adapter/logging"
+	"shien-system/internal/adapter/masterdata"
 	"shien-system/internal/adapter/pdf"
+	"shien-system/internal/adapter/postal"
+	"shien-system/internal/adapter/scheduler"
 	"shien-system/internal/adapter/session"
+	"shien-system/internal/adapter/spreadsheet"
 	"shien-system/internal/config"
+	"shien-system/internal/domain"
 	"shien-system/internal/ui/theme"
 	"shien-system/internal/ui/widgets"
 	"shien-system/internal/usecase"
 )
 
-// consoleLogger implements backup.Logger interface for console output
-type consoleLogger struct{}
-
-func (c *consoleLogger) Info(msg string, fields ...interface{}) {
-	fmt.Printf("[INFO] "+msg+"\n", fields...)
-}
-
-func (c *consoleLogger) Error(msg string, fields ...interface{}) {
-	fmt.Printf("[ERROR] "+msg+"\n", fields...)
-}
-
-func (c *consoleLogger) Warn(msg string, fields ...interface{}) {
-	fmt.Printf("[WARN] "+msg+"\n", fields...)
-}
-
 // Dependencies holds all initialized dependencies
 type Dependencies struct {
-	config             *config.Config
-	database           *db.Database
-	authUseCase        usecase.AuthUseCase
-	recipientUseCase   usecase.RecipientUseCase
-	certificateUseCase usecase.CertificateUseCase
-	staffUseCase       usecase.StaffUseCase
-	setupUseCase       usecase.SetupUseCase
-	backupUseCase      *usecase.BackupUseCase
-	pdfService         *pdf.PDFService
+	config                 *config.Config
+	database               *db.Database
+	authUseCase            usecase.AuthUseCase
+	recipientUseCase       usecase.RecipientUseCase
+	certificateUseCase     usecase.CertificateUseCase
+	staffUseCase           usecase.StaffUseCase
+	setupUseCase           usecase.SetupUseCase
+	backupUseCase          *usecase.BackupUseCase
+	disclosureUseCase      usecase.DisclosureUseCase
+	exportUseCase          usecase.ExportUseCase
+	contactUseCase         usecase.EmergencyContactUseCase
+	medicalUseCase         usecase.MedicalRecordUseCase
+	incidentUseCase        usecase.IncidentUseCase
+	notificationUseCase    usecase.NotificationUseCase
+	securityUseCase        usecase.SecurityUseCase
+	rateLimitPolicyUseCase usecase.RateLimitPolicyUseCase
+	sessionUseCase         usecase.SessionUseCase
+	logUseCase             usecase.LogUseCase
+	integrityUseCase       usecase.IntegrityUseCase
+	postalCodeUseCase      usecase.PostalCodeUseCase
+	importUseCase          usecase.ImportUseCase
+	recipientMergeUseCase  usecase.RecipientMergeUseCase
+	masterDataUseCase      usecase.MasterDataUseCase
+	pdfService             *pdf.PDFService
+	jobScheduler           *scheduler.Scheduler
 
 	// Repositories for direct access
 	auditRepo *db.AuditLogRepository
@@ -73,6 +85,16 @@ func main() {
 		log.Fatalf("Failed to load configuration: %v", err)
 	}
 
+	// Structured logging; the standard log package is routed through it as well
+	logger, logCloser, err := logging.New(cfg.Logging)
+	if err != nil {
+		log.Printf("Failed to open log file, logging to console only: %v", err)
+		logger = slog.New(logging.NewRedactHandler(slog.NewTextHandler(os.Stdout, nil)))
+	} else {
+		defer logCloser.Close()
+	}
+	slog.SetDefault(logger)
+
 	myApp := app.New()
 	myApp.Settings().SetTheme(theme.NewJapaneseTheme())
 
@@ -80,14 +102,43 @@ func main() {
 	myWindow.Resize(fyne.NewSize(1200, 800))
 
 	// Initialize database and repositories with configuration
-	dependencies, err := initializeDependencies(cfg)
+	dependencies, err := initializeDependencies(cfg, logger)
 	if err != nil {
 		log.Fatalf("Failed to initialize dependencies: %v", err)
 	}
 	defer dependencies.database.Close()
 
+	// Start background maintenance jobs; they are stopped before the database is closed
+	if cfg.Jobs.Enabled {
+		if err := dependencies.jobScheduler.Start(context.Background()); err != nil {
+			log.Fatalf("Failed to start job scheduler: %v", err)
+		}
+		defer stopJobScheduler(dependencies.jobScheduler, cfg)
+
+		// Populate the notification center right away instead of waiting for the first interval
+		if err := dependencies.jobScheduler.RunNow("notification_refresh"); err != nil {
+			slog.Warn("Failed to refresh notifications", "error", err)
+		}
+	}
+
 	// Create main app state with authentication
 	appState := widgets.NewAppState(dependencies.authUseCase, dependencies.recipientUseCase, dependencies.certificateUseCase, dependencies.staffUseCase, dependencies.setupUseCase, dependencies.backupUseCase, dependencies.auditRepo, dependencies.staffRepo, dependencies.pdfService, cfg)
+	appState.SetDisclosureUseCase(dependencies.disclosureUseCase)
+	appState.SetExportUseCase(dependencies.exportUseCase)
+	appState.SetEmergencyContactUseCase(dependencies.contactUseCase)
+	appState.SetMedicalRecordUseCase(dependencies.medicalUseCase)
+	appState.SetIncidentUseCase(dependencies.incidentUseCase)
+	appState.SetNotificationUseCase(dependencies.notificationUseCase)
+	appState.SetSecurityUseCase(dependencies.securityUseCase)
+	appState.SetRateLimitPolicyUseCase(dependencies.rateLimitPolicyUseCase)
+	appState.SetSessionUseCase(dependencies.sessionUseCase)
+	appState.SetLogUseCase(dependencies.logUseCase)
+	appState.SetIntegrityUseCase(dependencies.integrityUseCase)
+	appState.SetPostalCodeUseCase(dependencies.postalCodeUseCase)
+	appState.SetImportUseCase(dependencies.importUseCase)
+	appState.SetRecipientMergeUseCase(dependencies.recipientMergeUseCase)
+	appState.SetMasterDataUseCase(dependencies.masterDataUseCase)
+	appState.SetJobScheduler(dependencies.jobScheduler)
 
 	// Create main window with reactive content
 	mainWindow := createMainWindow(myWindow, appState)
@@ -96,16 +147,60 @@ func main() {
 	myWindow.ShowAndRun()
 }
 
+// stopJobScheduler waits for running jobs to finish within the configured timeout
+func stopJobScheduler(jobScheduler *scheduler.Scheduler, cfg *config.Config) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(cfg.Jobs.ShutdownTimeoutSec)*time.Second)
+	defer cancel()
+
+	if err := jobScheduler.Stop(ctx); err != nil {
+		slog.Warn("Job scheduler did not stop cleanly", "error", err)
+	}
+}
+
+// logRateLimitSync reports how the rate limit policy was reconciled with config.yaml
+func logRateLimitSync(report *usecase.RateLimitSyncReport) {
+	if report.Applied {
+		for _, change := range report.Changes {
+			slog.Info("Rate limit policy changed by config.yaml", "field", change.Field, "from", change.Current, "to", change.Proposed)
+		}
+	}
+
+	if len(report.Conflicts) == 0 {
+		return
+	}
+
+	if report.FileChanged {
+		slog.Warn("config.yaml changed but the rate limit policy edited by an administrator is kept")
+	}
+	for _, conflict := range report.Conflicts {
+		slog.Warn("Rate limit policy differs from config.yaml", "field", conflict.Field, "current", conflict.Current, "config_file", conflict.Proposed)
+	}
+}
+
 // initializeDependencies initializes database and use cases
-func initializeDependencies(cfg *config.Config) (*Dependencies, error) {
+func initializeDependencies(cfg *config.Config, logger *slog.Logger) (*Dependencies, error) {
 	// Initialize database with secure configuration
 	dbConfig := db.Config{
 		Path:         cfg.Database.Path,
 		MigrationDir: config.GetMigrationDir(),
+		BackupDir:    filepath.Join(cfg.Database.BackupDir, "pre-migration"),
+	}
+	if dbConfig.MigrationDir != "" {
+		logger.Warn("Using migrations from directory instead of the embedded ones", "dir", dbConfig.MigrationDir)
+	}
+	if cfg.Database.Encrypted {
+		key, err := crypto.DatabaseKey(crypto.NewOSKeyManager())
+		if err != nil {
+			return nil, err
+		}
+		dbConfig.EncryptionKey = key
 	}
 
 	database, err := db.NewDatabase(dbConfig)
 	if err != nil {
+		if errors.Is(err, db.ErrDatabaseEncrypted) || errors.Is(err, db.ErrDatabaseNotEncrypted) {
+			return nil, fmt.Errorf("%w; check database.encrypted in config.yaml (see docs/SECURITY.md)", err)
+		}
 		return nil, err
 	}
 
@@ -113,8 +208,14 @@ func initializeDependencies(cfg *config.Config) (*Dependencies, error) {
 	ctx := context.Background()
 	if err := database.RunMigrations(ctx); err != nil {
 		database.Close()
+		if errors.Is(err, db.ErrMigrationDrift) {
+			return nil, fmt.Errorf("%w; restore a matching build or the pre-migration backup (see docs/MIGRATIONS.md)", err)
+		}
 		return nil, err
 	}
+	if path := database.LastBackupPath(); path != "" {
+		logger.Info("Database backed up before migration", "path", path)
+	}
 
 	// Initialize repositories
 	recipientRepo, err := db.NewRecipientRepository(database)
@@ -125,6 +226,7 @@ func initializeDependencies(cfg *config.Config) (*Dependencies, error) {
 	
 	staffRepo := db.NewStaffRepository(database)
 	assignmentRepo := db.NewStaffAssignmentRepository(database)
+	enrollmentPeriodRepo := db.NewEnrollmentPeriodRepository(database)
 	
 	certificateRepo, err := db.NewBenefitCertificateRepository(database)
 	if err != nil {
@@ -132,7 +234,40 @@ func initializeDependencies(cfg *config.Config) (*Dependencies, error) {
 		return nil, fmt.Errorf("failed to create certificate repository: %w", err)
 	}
 	
+	consentRepo, err := db.NewConsentRepository(database)
+	if err != nil {
+		database.Close()
+		return nil, fmt.Errorf("failed to create consent repository: %w", err)
+	}
+
+	contactRepo, err := db.NewEmergencyContactRepository(database)
+	if err != nil {
+		database.Close()
+		return nil, fmt.Errorf("failed to create emergency contact repository: %w", err)
+	}
+
+	medicalRepo, err := db.NewMedicalRecordRepository(database)
+	if err != nil {
+		database.Close()
+		return nil, fmt.Errorf("failed to create medical record repository: %w", err)
+	}
+
+	incidentRepo, err := db.NewIncidentReportRepository(database)
+	if err != nil {
+		database.Close()
+		return nil, fmt.Errorf("failed to create incident report repository: %w", err)
+	}
+
+	notificationRepo, err := db.NewNotificationRepository(database)
+	if err != nil {
+		database.Close()
+		return nil, fmt.Errorf("failed to create notification repository: %w", err)
+	}
+	
 	auditRepo := db.NewAuditLogRepository(database)
+	postalRepo := db.NewPostalCodeRepository(database)
+	municipalityRepo := db.NewMunicipalityRepository(database)
+	serviceTypeRepo := db.NewServiceTypeRepository(database)
 
 	// Initialize crypto components
 	passwordHasher := crypto.NewBcryptPasswordHasher()
@@ -144,23 +279,38 @@ func initializeDependencies(cfg *config.Config) (*Dependencies, error) {
 	attemptRepo := db.NewLoginAttemptRepository(database)
 	lockoutRepo := db.NewAccountLockoutRepository(database)
 	configRepo := db.NewRateLimitConfigRepository(database)
+	patternRepo := db.NewAttackPatternRepository(database)
 	
 	// Initialize rate limit service
 	rateLimitSvc := usecase.NewRateLimitService(
 		attemptRepo,
 		lockoutRepo,
 		configRepo,
+		patternRepo,
 		auditRepo,
 	)
 
+	// Keep the stored rate limit policy in step with config.yaml
+	rateLimitPolicyUseCase := usecase.NewRateLimitPolicyUseCase(configRepo, staffRepo, auditRepo)
+	syncReport, err := rateLimitPolicyUseCase.SyncFromConfig(context.Background(), cfg.Security.RateLimit.ToDomain())
+	if err != nil {
+		database.Close()
+		return nil, fmt.Errorf("failed to sync rate limit policy: %w", err)
+	}
+	logRateLimitSync(syncReport)
+
 	// Initialize PDF service with font path and cipher for encrypted fields
-	fontPath := "assets/fonts"  // Default font path
 	fieldCipher, err := crypto.NewFieldCipher()
 	if err != nil {
 		database.Close()
 		return nil, fmt.Errorf("failed to create field cipher: %w", err)
 	}
-	pdfService := pdf.NewPDFService(fontPath, fieldCipher)
+	pdfService := pdf.NewPDFService(cfg.Reports.FontDir, fieldCipher)
+	// A broken office template must not keep the application from starting;
+	// the built-in templates are used instead
+	if err := pdfService.LoadTemplates(cfg.Reports.TemplateDir, cfg.Reports.Templates, cfg.Reports.DateStyles); err != nil {
+		slog.Warn("Some report templates could not be loaded", "dir", cfg.Reports.TemplateDir, "error", err)
+	}
 
 	// Initialize use cases
 	authUseCase := usecase.NewAuthUseCase(
@@ -172,17 +322,40 @@ func initializeDependencies(cfg *config.Config) (*Dependencies, error) {
 	)
 
 	recipientUseCase := usecase.NewRecipientUseCase(
+		database,
 		recipientRepo,
 		staffRepo,
 		assignmentRepo,
+		enrollmentPeriodRepo,
 		auditRepo,
+		postalRepo,
 	)
 
+	// Offline address lookup from Japan Post's KEN_ALL.CSV
+	postalCodeUseCase := usecase.NewPostalCodeUseCase(postalRepo, postal.NewKenAllParser(), staffRepo, auditRepo)
+
+	// Municipality and service type masters for coding benefit certificates
+	masterDataUseCase := usecase.NewMasterDataUseCase(municipalityRepo, serviceTypeRepo, masterdata.NewCSVParser(), staffRepo, auditRepo)
+
 	certificateUseCase := usecase.NewCertificateUseCase(
 		certificateRepo,
 		recipientRepo,
 		staffRepo,
 		auditRepo,
+		municipalityRepo,
+		serviceTypeRepo,
+	)
+
+	// Bulk import of recipients and certificates from CSV and Excel files
+	importUseCase := usecase.NewImportUseCase(
+		database,
+		spreadsheet.NewReader(),
+		recipientRepo,
+		enrollmentPeriodRepo,
+		certificateRepo,
+		postalRepo,
+		staffRepo,
+		auditRepo,
 	)
 
 	staffUseCase := usecase.NewStaffUseCase(
@@ -197,29 +370,152 @@ func initializeDependencies(cfg *config.Config) (*Dependencies, error) {
 		passwordHasher,
 	)
 
-	// Initialize backup service with proper logger
-	backupLogger := &consoleLogger{}
-	
-	backupService := backup.NewService(database.DB(), fieldCipher, &cfg.Backup, backupLogger)
+	// The merge history keeps each removed duplicate encrypted; it is disclosed
+	// with the surviving recipient
+	recipientMergeRepo := db.NewRecipientMergeRepository(database, fieldCipher)
+
+	disclosureUseCase := usecase.NewDisclosureUseCase(
+		recipientRepo,
+		enrollmentPeriodRepo,
+		certificateRepo,
+		assignmentRepo,
+		consentRepo,
+		contactRepo,
+		medicalRepo,
+		incidentRepo,
+		recipientMergeRepo,
+		staffRepo,
+		auditRepo,
+		pdfService,
+	)
+
+	// Every PDF of personal data is recorded with the SHA-256 of the file
+	exportUseCase := usecase.NewExportUseCase(staffRepo, auditRepo)
+
+	emergencyContactUseCase := usecase.NewEmergencyContactUseCase(
+		contactRepo,
+		recipientRepo,
+		staffRepo,
+		auditRepo,
+	)
+
+	medicalRecordUseCase := usecase.NewMedicalRecordUseCase(
+		medicalRepo,
+		recipientRepo,
+		staffRepo,
+		auditRepo,
+	)
+
+	// Duplicate recipients are merged by administrators; the removed record is kept encrypted in the history
+	recipientMergeUseCase := usecase.NewRecipientMergeUseCase(
+		database,
+		recipientRepo,
+		recipientMergeRepo,
+		enrollmentPeriodRepo,
+		medicalRepo,
+		staffRepo,
+		auditRepo,
+	)
+
+	incidentUseCase := usecase.NewIncidentUseCase(
+		incidentRepo,
+		recipientRepo,
+		staffRepo,
+		auditRepo,
+	)
+
+	notificationUseCase := usecase.NewNotificationUseCase(
+		notificationRepo,
+		certificateRepo,
+		recipientRepo,
+		assignmentRepo,
+		lockoutRepo,
+		staffRepo,
+		auditRepo,
+		usecase.NotificationSettings{
+			CertificateExpiryDays: cfg.Notifications.CertificateExpiryDays,
+		},
+	)
+
+	securityUseCase := usecase.NewSecurityUseCase(lockoutRepo, attemptRepo, patternRepo, staffRepo, auditRepo)
+
+	// Session management needs a session manager that can list other users' sessions
+	var sessionUseCase usecase.SessionUseCase
+	if registry, ok := sessionManager.(usecase.SessionRegistry); ok {
+		sessionUseCase = usecase.NewSessionUseCase(registry, staffRepo, auditRepo)
+	}
+
+	// Administrators can read the application log back in the log viewer
+	logUseCase := usecase.NewLogUseCase(logging.NewReader(cfg.Logging.FilePath), staffRepo)
+
+	// Hand the application logger to every usecase that logs
+	for _, uc := range []interface{}{
+		rateLimitSvc, rateLimitPolicyUseCase, authUseCase, recipientUseCase, certificateUseCase,
+		staffUseCase, setupUseCase, disclosureUseCase, emergencyContactUseCase, medicalRecordUseCase,
+		incidentUseCase, notificationUseCase, securityUseCase, sessionUseCase, postalCodeUseCase,
+		recipientMergeUseCase, masterDataUseCase,
+	} {
+		if setter, ok := uc.(usecase.LoggerSetter); ok {
+			setter.SetLogger(logger)
+		}
+	}
+
+	// Initialize backup service
+	backupService := backup.NewService(database.DB(), fieldCipher, &cfg.Backup, logger)
 	
 	// Initialize backup scheduler
-	backupScheduler := backup.NewScheduler(backupService, &cfg.Backup, backupLogger)
+	backupScheduler := backup.NewScheduler(backupService, &cfg.Backup, logger)
 	
 	// Initialize backup use case
-	backupUseCase := usecase.NewBackupUseCase(backupService, backupScheduler, auditRepo, backupLogger)
+	backupUseCase := usecase.NewBackupUseCase(backupService, backupScheduler, auditRepo, logger)
+
+	// Database check and guided repairs for administrators
+	integrityUseCase := usecase.NewIntegrityUseCase(db.NewIntegrityChecker(database, fieldCipher), backupUseCase, staffRepo, auditRepo)
+	if setter, ok := integrityUseCase.(usecase.LoggerSetter); ok {
+		setter.SetLogger(logger)
+	}
+
+	// Initialize background job scheduler
+	jobScheduler := scheduler.New(db.NewJobStateRepository(database), logger)
+	if err := registerMaintenanceJobs(jobScheduler, cfg, maintenanceJobs{
+		rateLimitSvc:        rateLimitSvc,
+		sessionManager:      sessionManager,
+		lockoutRepo:         lockoutRepo,
+		notificationUseCase: notificationUseCase,
+		logger:              logger,
+	}); err != nil {
+		database.Close()
+		return nil, fmt.Errorf("failed to register maintenance jobs: %w", err)
+	}
 
 	return &Dependencies{
-		config:             cfg,
-		database:           database,
-		authUseCase:        authUseCase,
-		recipientUseCase:   recipientUseCase,
-		certificateUseCase: certificateUseCase,
-		staffUseCase:       staffUseCase,
-		setupUseCase:       setupUseCase,
-		backupUseCase:      backupUseCase,
-		pdfService:         pdfService,
-		auditRepo:          auditRepo,
-		staffRepo:          staffRepo,
+		config:                 cfg,
+		database:               database,
+		authUseCase:            authUseCase,
+		recipientUseCase:       recipientUseCase,
+		certificateUseCase:     certificateUseCase,
+		staffUseCase:           staffUseCase,
+		setupUseCase:           setupUseCase,
+		backupUseCase:          backupUseCase,
+		disclosureUseCase:      disclosureUseCase,
+		exportUseCase:          exportUseCase,
+		contactUseCase:         emergencyContactUseCase,
+		medicalUseCase:         medicalRecordUseCase,
+		incidentUseCase:        incidentUseCase,
+		notificationUseCase:    notificationUseCase,
+		securityUseCase:        securityUseCase,
+		rateLimitPolicyUseCase: rateLimitPolicyUseCase,
+		sessionUseCase:         sessionUseCase,
+		logUseCase:             logUseCase,
+		integrityUseCase:       integrityUseCase,
+		postalCodeUseCase:      postalCodeUseCase,
+		importUseCase:          importUseCase,
+		recipientMergeUseCase:  recipientMergeUseCase,
+		masterDataUseCase:      masterDataUseCase,
+		pdfService:             pdfService,
+		jobScheduler:           jobScheduler,
+		auditRepo:              auditRepo,
+		staffRepo:              staffRepo,
 	}, nil
 }
 
@@ -331,30 +627,48 @@ func createHeader(appState *widgets.AppState, logoutHandler *widgets.LogoutHandl
 		userInfo = user.Name + " (" + string(user.Role) + ")"
 	}
 
+	rightItems := []fyne.CanvasObject{}
+	if unread := appState.UnreadNotificationCount(); unread >= 0 {
+		// Fyne has no bell icon; the info icon with the unread count stands in for it
+		label := "通知"
+		if unread > 0 {
+			label = fmt.Sprintf("通知 (%d)", unread)
+		}
+		notificationBtn := widget.NewButtonWithIcon(label, fynetheme.InfoIcon(), func() {
+			appState.SetCurrentView("notifications")
+		})
+		if unread > 0 {
+			notificationBtn.Importance = widget.HighImportance
+		}
+		rightItems = append(rightItems, notificationBtn)
+	}
+
+	rightItems = append(rightItems,
+		widget.NewLabel(userInfo),
+		widget.NewButton("ログアウト", func() {
+			// Show confirmation dialog before logout
+			errorDialog.ShowConfirmation(
+				"ログアウト確認",
+				logoutHandler.GetConfirmationMessage(),
+				func() {
+					// Perform proper logout with session invalidation
+					if err := logoutHandler.PerformLogout(); err != nil {
+						slog.Warn("Logout failed", "error", err)
+						errorDialog.ShowError("ログアウトエラー", err)
+					}
+					// UI will automatically refresh via reactive container
+				},
+				func() {
+					// Cancel logout - do nothing
+				},
+			)
+		}),
+	)
+
 	return container.NewBorder(
 		nil, nil,
 		widget.NewLabel("障害者サービス管理システム"),
-		container.NewHBox(
-			widget.NewLabel(userInfo),
-			widget.NewButton("ログアウト", func() {
-				// Show confirmation dialog before logout
-				errorDialog.ShowConfirmation(
-					"ログアウト確認",
-					logoutHandler.GetConfirmationMessage(),
-					func() {
-						// Perform proper logout with session invalidation
-						if err := logoutHandler.PerformLogout(); err != nil {
-							log.Printf("Logout warning: %v", err)
-							errorDialog.ShowError("ログアウトエラー", err)
-						}
-						// UI will automatically refresh via reactive container
-					},
-					func() {
-						// Cancel logout - do nothing
-					},
-				)
-			}),
-		),
+		container.NewHBox(rightItems...),
 		nil,
 	)
 }
@@ -390,6 +704,20 @@ func createSidebar(appState *widgets.AppState, feedbackManager *widgets.Feedback
 	auditBtn.SetShortcut("Alt+4")
 	accessibilityManager.RegisterFocusable(auditBtn)
 
+	incidentsBtn := widgets.NewAccessibleButton("事故・ヒヤリハット", "事故・ヒヤリハット報告の一覧を表示します", func() {
+		feedbackManager.ShowInfo("事故・ヒヤリハット報告を表示中...")
+		appState.SetCurrentView("incidents")
+	})
+	incidentsBtn.SetShortcut("Alt+6")
+	accessibilityManager.RegisterFocusable(incidentsBtn)
+
+	sessionsBtn := widgets.NewAccessibleButton("ログイン中の端末", "ログイン中のセッションを表示し、終了できます", func() {
+		feedbackManager.ShowInfo("ログイン中の端末を表示中...")
+		appState.SetCurrentView("sessions")
+	})
+	sessionsBtn.SetShortcut("Alt+9")
+	accessibilityManager.RegisterFocusable(sessionsBtn)
+
 	settingsBtn := widgets.NewAccessibleButton("設定", "システム設定画面を表示します", func() {
 		feedbackManager.ShowInfo("設定を表示中...")
 		appState.SetCurrentView("settings")
@@ -397,14 +725,69 @@ func createSidebar(appState *widgets.AppState, feedbackManager *widgets.Feedback
 	settingsBtn.SetShortcut("Alt+5")
 	accessibilityManager.RegisterFocusable(settingsBtn)
 
-	return container.NewVBox(
+	items := []fyne.CanvasObject{
 		recipientsBtn,
 		staffBtn,
 		certificatesBtn,
 		auditBtn,
-		widget.NewSeparator(),
-		settingsBtn,
-	)
+		incidentsBtn,
+		sessionsBtn,
+	}
+
+	// Background job status, the security dashboard, the log and the database check are only available to administrators
+	if user := appState.GetCurrentUser(); user != nil && user.Role == domain.RoleAdmin {
+		jobsBtn := widgets.NewAccessibleButton("ジョブ状況", "バックグラウンドジョブの実行状況を表示します", func() {
+			feedbackManager.ShowInfo("ジョブ状況を表示中...")
+			appState.SetCurrentView("jobs")
+		})
+		jobsBtn.SetShortcut("Alt+7")
+		accessibilityManager.RegisterFocusable(jobsBtn)
+		items = append(items, jobsBtn)
+
+		securityBtn := widgets.NewAccessibleButton("セキュリティ", "ロックアウトと不正ログインの状況を表示します", func() {
+			feedbackManager.ShowInfo("セキュリティを表示中...")
+			appState.SetCurrentView("security")
+		})
+		securityBtn.SetShortcut("Alt+8")
+		accessibilityManager.RegisterFocusable(securityBtn)
+		items = append(items, securityBtn)
+
+		logsBtn := widgets.NewAccessibleButton("システムログ", "アプリケーションのログを表示します", func() {
+			feedbackManager.ShowInfo("システムログを表示中...")
+			appState.SetCurrentView("logs")
+		})
+		logsBtn.SetShortcut("Alt+0")
+		accessibilityManager.RegisterFocusable(logsBtn)
+		items = append(items, logsBtn)
+
+		integrityBtn := widgets.NewAccessibleButton("データベース点検", "データベースの整合性をチェックし、修復します", func() {
+			feedbackManager.ShowInfo("データベース点検を表示中...")
+			appState.SetCurrentView("integrity")
+		})
+		integrityBtn.SetShortcut("Alt+D")
+		accessibilityManager.RegisterFocusable(integrityBtn)
+		items = append(items, integrityBtn)
+
+		importBtn := widgets.NewAccessibleButton("一括取込", "CSV・Excelファイルから利用者と受給者証を取り込みます", func() {
+			feedbackManager.ShowInfo("一括取込を表示中...")
+			appState.SetCurrentView("import")
+		})
+		importBtn.SetShortcut("Alt+I")
+		accessibilityManager.RegisterFocusable(importBtn)
+		items = append(items, importBtn)
+
+		duplicatesBtn := widgets.NewAccessibleButton("重複利用者の統合", "二重登録された利用者を探して統合します", func() {
+			feedbackManager.ShowInfo("重複利用者の統合を表示中...")
+			appState.SetCurrentView("duplicates")
+		})
+		duplicatesBtn.SetShortcut("Alt+U")
+		accessibilityManager.RegisterFocusable(duplicatesBtn)
+		items = append(items, duplicatesBtn)
+	}
+
+	items = append(items, widget.NewSeparator(), settingsBtn)
+
+	return container.NewVBox(items...)
 }
 
 func createFooter() *fyne.Container {
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"shien-system/internal/adapter/db"
+	"shien-system/internal/domain"
+	"shien-system/internal/validation"
+)
+
+// splitAddresses fills the postal code, prefecture, city and street of
+// recipients saved before migration 0015, which only have the full address.
+// The municipality comes from the postal code dictionary when the address
+// has a postal code, otherwise from the municipality master; without either
+// the rest of the address stays in the street. With dryRun it only counts
+// the rows that would change.
+func splitAddresses(ctx context.Context, database *db.Database, dryRun bool) error {
+	recipientRepo, err := db.NewRecipientRepository(database)
+	if err != nil {
+		return err
+	}
+	postalRepo := db.NewPostalCodeRepository(database)
+
+	municipalities, err := db.NewMunicipalityRepository(database).List(ctx)
+	if err != nil {
+		return err
+	}
+	citiesByPrefecture := make(map[string][]string)
+	for _, municipality := range municipalities {
+		citiesByPrefecture[municipality.Prefecture] = append(citiesByPrefecture[municipality.Prefecture], municipality.Name)
+	}
+
+	if !dryRun {
+		if _, err := database.Backup(ctx, "pre-split-addresses"); err != nil {
+			return err
+		}
+		reportBackup(database)
+	}
+
+	var split, withoutCity, unrecognized int
+	err = database.WithTransaction(ctx, func(ctx context.Context) error {
+		for offset := 0; ; offset += normalizePageSize {
+			page, err := recipientRepo.List(ctx, normalizePageSize, offset)
+			if err != nil {
+				return err
+			}
+			for _, recipient := range page {
+				if recipient.Address == "" || recipient.PostalCode != "" ||
+					recipient.Prefecture != "" || recipient.City != "" || recipient.Street != "" {
+					continue
+				}
+
+				parts := validation.SplitAddress(recipient.Address)
+				cities, err := addressCities(ctx, postalRepo, parts, citiesByPrefecture)
+				if err != nil {
+					return err
+				}
+				hasCity := parts.SplitCity(cities)
+				if parts.PostalCode == "" && parts.Prefecture == "" && !hasCity {
+					unrecognized++
+					continue
+				}
+
+				split++
+				if !hasCity {
+					withoutCity++
+				}
+				if !dryRun {
+					recipient.PostalCode = parts.PostalCode
+					recipient.Prefecture = parts.Prefecture
+					recipient.City = parts.City
+					recipient.Street = parts.Street
+					recipient.Address = recipient.ComposeAddress()
+					recipient.UpdatedAt = time.Now()
+					if err := recipientRepo.Update(ctx, recipient); err != nil {
+						return fmt.Errorf("failed to update recipient %s: %w", recipient.ID, err)
+					}
+				}
+			}
+			if len(page) < normalizePageSize {
+				break
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	verb := "split"
+	if dryRun {
+		verb = "would split"
+	}
+	fmt.Printf("%s %d addresses (%d without a known municipality), left %d unrecognized\n",
+		verb, split, withoutCity, unrecognized)
+	return nil
+}
+
+// addressCities returns the municipality names that may follow the
+// prefecture of the address: those of its postal code in the dictionary,
+// or the prefecture's municipalities from the master
+func addressCities(ctx context.Context, postalRepo domain.PostalCodeRepository, parts validation.AddressParts, citiesByPrefecture map[string][]string) ([]string, error) {
+	if digits := validation.PostalCodeDigits(parts.PostalCode); digits != "" {
+		addresses, err := postalRepo.FindByCode(ctx, digits)
+		if err != nil {
+			return nil, err
+		}
+		var cities []string
+		for _, address := range addresses {
+			if parts.Prefecture == "" || address.Prefecture == parts.Prefecture {
+				cities = append(cities, address.City)
+			}
+		}
+		if len(cities) > 0 {
+			return cities, nil
+		}
+	}
+	return citiesByPrefecture[parts.Prefecture], nil
+}
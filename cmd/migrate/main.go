@@ -0,0 +1,201 @@
+// Command migrate inspects and changes the database schema outside the
+// desktop application. The application must not be running.
+//
+//	migrate status                      list applied migrations
+//	migrate up                          apply pending migrations
+//	migrate rollback -to 0009           revert migrations newer than 0009
+//	migrate encrypt                     convert a plaintext database to SQLCipher
+//	migrate normalize [-dry-run]        normalize text stored before input normalization
+//	migrate split-addresses [-dry-run]  split addresses stored before migration 0015
+//
+// The database path and backup directory come from config.yaml, the same as
+// the desktop application. Every change is preceded by a backup.
+//
+// encrypt needs a build with -tags sqlcipher. The key is derived from the key
+// in the OS keyring. The plaintext original is moved to the pre-encryption
+// backup directory and should be deleted once the encrypted database opens.
+//
+// normalize applies the input normalization rules (full-width digits,
+// half-width kana, spaces, hyphens) to recipients, emergency contacts and
+// staff names saved by older versions. -dry-run only counts the rows.
+//
+// split-addresses fills the postal code, prefecture, city and street of
+// recipients that only have the full address, using the postal code
+// dictionary and municipality master when they are imported. -dry-run only
+// counts the rows.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"shien-system/internal/adapter/crypto"
+	"shien-system/internal/adapter/db"
+	"shien-system/internal/config"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	if err := run(os.Args[1], os.Args[2:]); err != nil {
+		fmt.Fprintf(os.Stderr, "migrate: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: migrate status | up | rollback -to VERSION | encrypt | normalize [-dry-run] | split-addresses [-dry-run]")
+}
+
+func run(command string, args []string) error {
+	flags := flag.NewFlagSet(command, flag.ContinueOnError)
+	target := flags.String("to", "", "version to roll back to (0000 reverts everything)")
+	dryRun := flags.Bool("dry-run", false, "normalize, split-addresses: count the rows that would change without writing")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	ctx := context.Background()
+
+	if command == "encrypt" {
+		return encrypt(ctx, cfg)
+	}
+
+	dbConfig := db.Config{
+		Path:         cfg.Database.Path,
+		MigrationDir: config.GetMigrationDir(),
+		BackupDir:    filepath.Join(cfg.Database.BackupDir, "pre-migration"),
+	}
+	if cfg.Database.Encrypted {
+		key, err := crypto.DatabaseKey(crypto.NewOSKeyManager())
+		if err != nil {
+			return err
+		}
+		dbConfig.EncryptionKey = key
+	}
+
+	database, err := db.NewDatabase(dbConfig)
+	if err != nil {
+		return err
+	}
+	defer database.Close()
+
+	switch command {
+	case "status":
+		status, err := database.GetMigrationStatus(ctx)
+		if err != nil {
+			return err
+		}
+		for _, migration := range status {
+			fmt.Printf("%s  %-40s  %s\n", migration.Version, migration.Name, migration.AppliedAt.Local().Format("2006/01/02 15:04:05"))
+		}
+
+	case "up":
+		if err := database.RunMigrations(ctx); err != nil {
+			return err
+		}
+		reportBackup(database)
+
+	case "rollback":
+		if *target == "" {
+			return fmt.Errorf("rollback requires -to VERSION")
+		}
+		reverted, err := database.RollbackTo(ctx, *target)
+		reportBackup(database)
+		for _, version := range reverted {
+			fmt.Printf("reverted %s\n", version)
+		}
+		if err != nil {
+			return err
+		}
+		if len(reverted) == 0 {
+			fmt.Println("nothing to roll back")
+		}
+
+	case "normalize":
+		return normalize(ctx, database, *dryRun)
+
+	case "split-addresses":
+		return splitAddresses(ctx, database, *dryRun)
+
+	default:
+		usage()
+		return fmt.Errorf("unknown command %q", command)
+	}
+
+	return nil
+}
+
+// encrypt replaces the plaintext database with an encrypted copy. The
+// plaintext original is kept in the pre-encryption backup directory.
+func encrypt(ctx context.Context, cfg *config.Config) error {
+	if !db.EncryptionSupported {
+		return db.ErrEncryptionUnsupported
+	}
+
+	path := cfg.Database.Path
+	if _, err := os.Stat(path); err != nil {
+		return fmt.Errorf("database not found: %w", err)
+	}
+	encrypted, err := db.IsEncryptedFile(path)
+	if err != nil {
+		return err
+	}
+	if encrypted {
+		return fmt.Errorf("%s is already encrypted", path)
+	}
+
+	key, err := crypto.DatabaseKey(crypto.NewOSKeyManager())
+	if err != nil {
+		return err
+	}
+
+	tmpPath := path + ".encrypting"
+	if err := db.EncryptDatabase(ctx, path, tmpPath, key); err != nil {
+		return err
+	}
+
+	backupDir := filepath.Join(cfg.Database.BackupDir, "pre-encryption")
+	if err := os.MkdirAll(backupDir, 0700); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to create backup directory: %w", err)
+	}
+	backupPath := filepath.Join(backupDir, fmt.Sprintf("plaintext-%s.db", time.Now().Format("20060102-150405")))
+	if err := os.Rename(path, backupPath); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to move plaintext database: %w", err)
+	}
+	if err := os.Chmod(backupPath, 0600); err != nil {
+		return fmt.Errorf("failed to restrict backup permissions: %w", err)
+	}
+	// The WAL and shared memory files belong to the plaintext database
+	os.Remove(path + "-wal")
+	os.Remove(path + "-shm")
+
+	if err := os.Rename(tmpPath, path); err != nil {
+		return fmt.Errorf("failed to move encrypted database into place (plaintext original: %s): %w", backupPath, err)
+	}
+
+	fmt.Printf("encrypted: %s\n", path)
+	fmt.Printf("plaintext original: %s\n", backupPath)
+	fmt.Println("set database.encrypted: true in config.yaml, start the application, then delete the plaintext original")
+	return nil
+}
+
+func reportBackup(database *db.Database) {
+	if path := database.LastBackupPath(); path != "" {
+		fmt.Printf("backup: %s\n", path)
+	}
+}
@@ -0,0 +1,154 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"shien-system/internal/adapter/db"
+	"shien-system/internal/domain"
+	"shien-system/internal/validation"
+)
+
+// normalizePageSize is how many rows are read per page
+const normalizePageSize = 100
+
+// normalize rewrites recipients, their emergency contacts and staff names
+// stored before input normalization, using the same rules as the use cases.
+// With dryRun it only counts the rows that would change.
+func normalize(ctx context.Context, database *db.Database, dryRun bool) error {
+	recipientRepo, err := db.NewRecipientRepository(database)
+	if err != nil {
+		return err
+	}
+	contactRepo, err := db.NewEmergencyContactRepository(database)
+	if err != nil {
+		return err
+	}
+	staffRepo := db.NewStaffRepository(database)
+
+	if !dryRun {
+		if _, err := database.Backup(ctx, "pre-normalize"); err != nil {
+			return err
+		}
+		reportBackup(database)
+	}
+
+	var recipients, contacts, staff int
+	err = database.WithTransaction(ctx, func(ctx context.Context) error {
+		for offset := 0; ; offset += normalizePageSize {
+			page, err := recipientRepo.List(ctx, normalizePageSize, offset)
+			if err != nil {
+				return err
+			}
+			for _, recipient := range page {
+				if normalizeRecipient(recipient) {
+					recipients++
+					if !dryRun {
+						recipient.UpdatedAt = time.Now()
+						if err := recipientRepo.Update(ctx, recipient); err != nil {
+							return fmt.Errorf("failed to update recipient %s: %w", recipient.ID, err)
+						}
+					}
+				}
+
+				list, err := contactRepo.GetByRecipientID(ctx, recipient.ID)
+				if err != nil {
+					return err
+				}
+				for _, contact := range list {
+					if normalizeEmergencyContact(contact) {
+						contacts++
+						if !dryRun {
+							contact.UpdatedAt = time.Now()
+							if err := contactRepo.Update(ctx, contact); err != nil {
+								return fmt.Errorf("failed to update emergency contact %s: %w", contact.ID, err)
+							}
+						}
+					}
+				}
+			}
+			if len(page) < normalizePageSize {
+				break
+			}
+		}
+
+		for offset := 0; ; offset += normalizePageSize {
+			page, err := staffRepo.List(ctx, normalizePageSize, offset)
+			if err != nil {
+				return err
+			}
+			for _, member := range page {
+				name := validation.NormalizeText(member.Name)
+				if name == member.Name {
+					continue
+				}
+				staff++
+				if !dryRun {
+					member.Name = name
+					member.UpdatedAt = time.Now()
+					if err := staffRepo.Update(ctx, member); err != nil {
+						return fmt.Errorf("failed to update staff %s: %w", member.ID, err)
+					}
+				}
+			}
+			if len(page) < normalizePageSize {
+				break
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	verb := "normalized"
+	if dryRun {
+		verb = "would normalize"
+	}
+	fmt.Printf("%s %d recipients, %d emergency contacts, %d staff\n", verb, recipients, contacts, staff)
+	return nil
+}
+
+// normalizeRecipient normalizes the fields RecipientUseCase normalizes on
+// create and update and reports whether anything changed
+func normalizeRecipient(r *domain.Recipient) bool {
+	return normalizeFields(
+		normalizeField{&r.Name, validation.NormalizeText},
+		normalizeField{&r.Kana, validation.NormalizeKana},
+		normalizeField{&r.DisabilityName, validation.NormalizeText},
+		normalizeField{&r.Grade, validation.NormalizeText},
+		normalizeField{&r.Address, validation.NormalizeText},
+		normalizeField{&r.Phone, validation.NormalizePhone},
+		normalizeField{&r.Email, validation.NormalizeText},
+	)
+}
+
+// normalizeEmergencyContact mirrors EmergencyContactUseCase
+func normalizeEmergencyContact(c *domain.EmergencyContact) bool {
+	return normalizeFields(
+		normalizeField{&c.Name, validation.NormalizeText},
+		normalizeField{&c.Relationship, validation.NormalizeText},
+		normalizeField{&c.Phone, validation.NormalizePhone},
+		normalizeField{&c.MobilePhone, validation.NormalizePhone},
+		normalizeField{&c.WorkPhone, validation.NormalizePhone},
+		normalizeField{&c.Email, validation.NormalizeText},
+		normalizeField{&c.Notes, validation.NormalizeText},
+	)
+}
+
+type normalizeField struct {
+	value     *string
+	normalize func(string) string
+}
+
+func normalizeFields(fields ...normalizeField) bool {
+	changed := false
+	for _, field := range fields {
+		if normalized := field.normalize(*field.value); normalized != *field.value {
+			*field.value = normalized
+			changed = true
+		}
+	}
+	return changed
+}
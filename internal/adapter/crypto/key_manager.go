@@ -2,15 +2,22 @@ package crypto
 
 import (
 	"crypto/rand"
+	"crypto/sha256"
 	"encoding/base64"
 	"fmt"
+	"io"
+
 	"github.com/zalando/go-keyring"
+	"golang.org/x/crypto/hkdf"
 )
 
 const (
 	ServiceName   = "shien-system"
 	KeyIdentifier = "encryption-key"
 	KeySize       = 32 // AES-256 requires 32 bytes
+
+	// databaseKeyInfo separates the whole-database key from the field key
+	databaseKeyInfo = "shien-system database encryption v1"
 )
 
 // KeyManager handles secure storage and retrieval of encryption keys
@@ -79,6 +86,31 @@ func (km *OSKeyManager) DeleteKey() error {
 	return nil
 }
 
+// DeriveDatabaseKey derives the whole-database encryption key from the key
+// held by the KeyManager. The field cipher and the database file never share
+// a key, so exposing one does not expose the other.
+func DeriveDatabaseKey(masterKey []byte) ([]byte, error) {
+	if len(masterKey) != KeySize {
+		return nil, fmt.Errorf("invalid key size: expected %d, got %d", KeySize, len(masterKey))
+	}
+
+	key := make([]byte, KeySize)
+	reader := hkdf.New(sha256.New, masterKey, nil, []byte(databaseKeyInfo))
+	if _, err := io.ReadFull(reader, key); err != nil {
+		return nil, fmt.Errorf("failed to derive database key: %w", err)
+	}
+	return key, nil
+}
+
+// DatabaseKey returns the whole-database encryption key for the key held by km
+func DatabaseKey(km KeyManager) ([]byte, error) {
+	masterKey, err := km.GetOrCreateKey()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get encryption key: %w", err)
+	}
+	return DeriveDatabaseKey(masterKey)
+}
+
 // encodeKey converts byte array to base64 string for storage
 func encodeKey(key []byte) string {
 	// Using standard base64 URL encoding
@@ -110,6 +110,36 @@ func TestEncodeDecodeKey(t *testing.T) {
 	}
 }
 
+func TestDeriveDatabaseKey(t *testing.T) {
+	master := make([]byte, KeySize)
+	for i := range master {
+		master[i] = byte(i)
+	}
+
+	key, err := DeriveDatabaseKey(master)
+	if err != nil {
+		t.Fatalf("DeriveDatabaseKey() error = %v", err)
+	}
+	if len(key) != KeySize {
+		t.Errorf("derived key length = %d, want %d", len(key), KeySize)
+	}
+	if string(key) == string(master) {
+		t.Error("database key must differ from the field encryption key")
+	}
+
+	again, err := DeriveDatabaseKey(master)
+	if err != nil {
+		t.Fatalf("DeriveDatabaseKey() error = %v", err)
+	}
+	if string(key) != string(again) {
+		t.Error("derivation must be deterministic")
+	}
+
+	if _, err := DeriveDatabaseKey(master[:16]); err == nil {
+		t.Error("expected an error for a short master key")
+	}
+}
+
 func TestDecodeKey_ErrorCases(t *testing.T) {
 	tests := []struct {
 		name    string
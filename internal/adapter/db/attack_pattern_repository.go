@@ -0,0 +1,304 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"shien-system/internal/domain"
+)
+
+// attackPatternColumns lists the attack pattern columns in scan order
+const attackPatternColumns = `id, pattern_type, source_ip, target_usernames, attempts_count,
+		       first_detected_at, last_detected_at, severity, status, details`
+
+// AttackPatternRepository implements domain.AttackPatternRepository
+type AttackPatternRepository struct {
+	db *Database
+}
+
+// NewAttackPatternRepository creates a new AttackPatternRepository instance
+func NewAttackPatternRepository(db *Database) *AttackPatternRepository {
+	return &AttackPatternRepository{
+		db: db,
+	}
+}
+
+// Create creates a new attack pattern record
+func (r *AttackPatternRepository) Create(ctx context.Context, pattern *AttackPattern) error {
+	if pattern.ID == "" {
+		pattern.ID = uuid.New().String()
+	}
+
+	targetUsernames, err := r.marshalUsernames(pattern.TargetUsernames)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO attack_patterns (
+			id, pattern_type, source_ip, target_usernames, attempts_count,
+			first_detected_at, last_detected_at, severity, status, details
+		)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	_, err = r.getExecutor(ctx).ExecContext(ctx, query,
+		pattern.ID,
+		pattern.PatternType,
+		pattern.SourceIP,
+		targetUsernames,
+		pattern.AttemptsCount,
+		pattern.FirstDetectedAt.Format(time.RFC3339),
+		pattern.LastDetectedAt.Format(time.RFC3339),
+		pattern.Severity,
+		pattern.Status,
+		pattern.Details,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to create attack pattern: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves an attack pattern by ID
+func (r *AttackPatternRepository) GetByID(ctx context.Context, id domain.ID) (*AttackPattern, error) {
+	query := `
+		SELECT ` + attackPatternColumns + `
+		FROM attack_patterns
+		WHERE id = ?
+	`
+
+	row := r.getExecutor(ctx).QueryRowContext(ctx, query, id)
+	pattern, err := r.scanAttackPattern(row)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get attack pattern by ID: %w", err)
+	}
+
+	return pattern, nil
+}
+
+// GetActiveByIP retrieves active attack patterns from a source IP
+func (r *AttackPatternRepository) GetActiveByIP(ctx context.Context, sourceIP string) ([]*AttackPattern, error) {
+	query := `
+		SELECT ` + attackPatternColumns + `
+		FROM attack_patterns
+		WHERE source_ip = ? AND status = ?
+		ORDER BY last_detected_at DESC
+	`
+
+	return r.queryAttackPatterns(ctx, "get active attack patterns by IP", query, sourceIP, domain.AttackPatternStatusActive)
+}
+
+// GetByTimeRange retrieves attack patterns first detected within a time range
+func (r *AttackPatternRepository) GetByTimeRange(ctx context.Context, start, end time.Time) ([]*AttackPattern, error) {
+	query := `
+		SELECT ` + attackPatternColumns + `
+		FROM attack_patterns
+		WHERE first_detected_at >= ? AND first_detected_at <= ?
+		ORDER BY first_detected_at DESC
+	`
+
+	return r.queryAttackPatterns(ctx, "get attack patterns by time range", query, start.Format(time.RFC3339), end.Format(time.RFC3339))
+}
+
+// GetUnresolved retrieves active and blocked attack patterns
+func (r *AttackPatternRepository) GetUnresolved(ctx context.Context) ([]*AttackPattern, error) {
+	query := `
+		SELECT ` + attackPatternColumns + `
+		FROM attack_patterns
+		WHERE status != ?
+		ORDER BY last_detected_at DESC
+	`
+
+	return r.queryAttackPatterns(ctx, "get unresolved attack patterns", query, domain.AttackPatternStatusResolved)
+}
+
+// Update updates the detection details and status of an attack pattern
+func (r *AttackPatternRepository) Update(ctx context.Context, pattern *AttackPattern) error {
+	targetUsernames, err := r.marshalUsernames(pattern.TargetUsernames)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE attack_patterns
+		SET source_ip = ?, target_usernames = ?, attempts_count = ?, last_detected_at = ?,
+		    severity = ?, status = ?, details = ?
+		WHERE id = ?
+	`
+
+	result, err := r.getExecutor(ctx).ExecContext(ctx, query,
+		pattern.SourceIP,
+		targetUsernames,
+		pattern.AttemptsCount,
+		pattern.LastDetectedAt.Format(time.RFC3339),
+		pattern.Severity,
+		pattern.Status,
+		pattern.Details,
+		pattern.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update attack pattern: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
+// UpdateStatus changes the status of an attack pattern
+func (r *AttackPatternRepository) UpdateStatus(ctx context.Context, id domain.ID, status string) error {
+	query := `UPDATE attack_patterns SET status = ? WHERE id = ?`
+
+	result, err := r.getExecutor(ctx).ExecContext(ctx, query, status, id)
+	if err != nil {
+		return fmt.Errorf("failed to update attack pattern status: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
+// List retrieves attack patterns with pagination, most recently detected first
+func (r *AttackPatternRepository) List(ctx context.Context, limit, offset int) ([]*AttackPattern, error) {
+	query := `
+		SELECT ` + attackPatternColumns + `
+		FROM attack_patterns
+		ORDER BY last_detected_at DESC
+		LIMIT ? OFFSET ?
+	`
+
+	return r.queryAttackPatterns(ctx, "list attack patterns", query, limit, offset)
+}
+
+// Count returns the total number of attack patterns
+func (r *AttackPatternRepository) Count(ctx context.Context) (int, error) {
+	query := `SELECT COUNT(*) FROM attack_patterns`
+
+	var count int
+	err := r.getExecutor(ctx).QueryRowContext(ctx, query).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count attack patterns: %w", err)
+	}
+
+	return count, nil
+}
+
+// Helper methods
+
+func (r *AttackPatternRepository) getExecutor(ctx context.Context) executor {
+	if tx, ok := ctx.Value("tx").(*sql.Tx); ok {
+		return tx
+	}
+	return r.db.DB()
+}
+
+func (r *AttackPatternRepository) queryAttackPatterns(ctx context.Context, op, query string, args ...interface{}) ([]*AttackPattern, error) {
+	rows, err := r.getExecutor(ctx).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to %s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var patterns []*AttackPattern
+	for rows.Next() {
+		pattern, err := r.scanAttackPattern(rows)
+		if err != nil {
+			return nil, err
+		}
+		patterns = append(patterns, pattern)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to %s: %w", op, err)
+	}
+
+	return patterns, nil
+}
+
+func (r *AttackPatternRepository) marshalUsernames(usernames []string) (string, error) {
+	if usernames == nil {
+		usernames = []string{}
+	}
+
+	data, err := json.Marshal(usernames)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal target usernames: %w", err)
+	}
+
+	return string(data), nil
+}
+
+func (r *AttackPatternRepository) scanAttackPattern(scanner scanner) (*AttackPattern, error) {
+	var pattern AttackPattern
+	var targetUsernames string
+	var firstDetectedAtStr, lastDetectedAtStr string
+	var details sql.NullString
+
+	err := scanner.Scan(
+		&pattern.ID,
+		&pattern.PatternType,
+		&pattern.SourceIP,
+		&targetUsernames,
+		&pattern.AttemptsCount,
+		&firstDetectedAtStr,
+		&lastDetectedAtStr,
+		&pattern.Severity,
+		&pattern.Status,
+		&details,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, err // Return sql.ErrNoRows as-is
+		}
+		return nil, fmt.Errorf("failed to scan attack pattern: %w", err)
+	}
+
+	if err := json.Unmarshal([]byte(targetUsernames), &pattern.TargetUsernames); err != nil {
+		return nil, fmt.Errorf("failed to parse target usernames: %w", err)
+	}
+
+	pattern.FirstDetectedAt, err = time.Parse(time.RFC3339, firstDetectedAtStr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse first_detected_at: %w", err)
+	}
+
+	pattern.LastDetectedAt, err = time.Parse(time.RFC3339, lastDetectedAtStr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse last_detected_at: %w", err)
+	}
+
+	if details.Valid {
+		pattern.Details = details.String
+	}
+
+	return &pattern, nil
+}
+
+// Type alias for domain model to avoid import cycles
+type AttackPattern = domain.AttackPattern
@@ -0,0 +1,92 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"shien-system/internal/domain"
+)
+
+func TestAttackPatternRepository_CreateUpdateAndResolve(t *testing.T) {
+	db := setupTestDatabase(t)
+	defer db.Close()
+
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Second)
+	repo := NewAttackPatternRepository(db)
+
+	pattern := &domain.AttackPattern{
+		PatternType:     domain.AttackPatternTypeCredentialStuffing,
+		SourceIP:        "203.0.113.10",
+		TargetUsernames: []string{"tanaka", "suzuki", "sato"},
+		AttemptsCount:   12,
+		FirstDetectedAt: now,
+		LastDetectedAt:  now,
+		Severity:        domain.AttackSeverityMedium,
+		Status:          domain.AttackPatternStatusActive,
+		Details:         "12 failed attempts against 3 usernames",
+	}
+	if err := repo.Create(ctx, pattern); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if pattern.ID == "" {
+		t.Fatal("Create() should assign an ID")
+	}
+
+	stored, err := repo.GetByID(ctx, pattern.ID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if len(stored.TargetUsernames) != 3 || stored.TargetUsernames[1] != "suzuki" {
+		t.Errorf("TargetUsernames = %v", stored.TargetUsernames)
+	}
+	if !stored.FirstDetectedAt.Equal(now) || stored.Severity != domain.AttackSeverityMedium {
+		t.Errorf("GetByID() = %+v", stored)
+	}
+
+	active, err := repo.GetActiveByIP(ctx, "203.0.113.10")
+	if err != nil || len(active) != 1 {
+		t.Fatalf("GetActiveByIP() = %d, %v", len(active), err)
+	}
+
+	stored.AttemptsCount = 40
+	stored.LastDetectedAt = now.Add(15 * time.Minute)
+	stored.Severity = domain.AttackSeverityHigh
+	if err := repo.Update(ctx, stored); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	if err := repo.UpdateStatus(ctx, pattern.ID, domain.AttackPatternStatusBlocked); err != nil {
+		t.Fatalf("UpdateStatus() error = %v", err)
+	}
+	unresolved, err := repo.GetUnresolved(ctx)
+	if err != nil || len(unresolved) != 1 {
+		t.Fatalf("GetUnresolved() = %d, %v", len(unresolved), err)
+	}
+	if unresolved[0].AttemptsCount != 40 || unresolved[0].Status != domain.AttackPatternStatusBlocked {
+		t.Errorf("GetUnresolved()[0] = %+v", unresolved[0])
+	}
+
+	// Blocked patterns are no longer active
+	active, _ = repo.GetActiveByIP(ctx, "203.0.113.10")
+	if len(active) != 0 {
+		t.Errorf("GetActiveByIP() after block = %d, want 0", len(active))
+	}
+
+	if err := repo.UpdateStatus(ctx, pattern.ID, domain.AttackPatternStatusResolved); err != nil {
+		t.Fatalf("UpdateStatus() error = %v", err)
+	}
+	unresolved, _ = repo.GetUnresolved(ctx)
+	if len(unresolved) != 0 {
+		t.Errorf("GetUnresolved() after resolve = %d, want 0", len(unresolved))
+	}
+
+	if count, err := repo.Count(ctx); err != nil || count != 1 {
+		t.Errorf("Count() = %d, %v", count, err)
+	}
+
+	if err := repo.UpdateStatus(ctx, "missing", domain.AttackPatternStatusResolved); err != domain.ErrNotFound {
+		t.Errorf("UpdateStatus() missing = %v, want ErrNotFound", err)
+	}
+}
@@ -0,0 +1,96 @@
+package db
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"shien-system/internal/adapter/crypto"
+	"shien-system/internal/domain"
+)
+
+func TestBenefitCertificateRepository_Codes(t *testing.T) {
+	database, err := NewDatabase(Config{Path: filepath.Join(t.TempDir(), "test.db")})
+	if err != nil {
+		t.Fatalf("failed to create database: %v", err)
+	}
+	defer database.Close()
+
+	ctx := context.Background()
+	if err := database.RunMigrations(ctx); err != nil {
+		t.Fatalf("RunMigrations() error = %v", err)
+	}
+
+	cipher, err := crypto.NewFieldCipherWithKey(bytes.Repeat([]byte{1}, 32))
+	if err != nil {
+		t.Fatal(err)
+	}
+	recipients := &RecipientRepository{db: database, cipher: cipher}
+	repo := &BenefitCertificateRepository{db: database, cipher: cipher}
+
+	now := time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)
+	recipient := &domain.Recipient{ID: "r-1", Name: "山田太郎", Sex: domain.SexMale,
+		BirthDate: time.Date(1980, 1, 1, 0, 0, 0, 0, time.UTC), CreatedAt: now, UpdatedAt: now}
+	if err := recipients.Create(ctx, recipient); err != nil {
+		t.Fatalf("Create recipient error = %v", err)
+	}
+
+	certificate := &domain.BenefitCertificate{
+		ID:                     "c-1",
+		RecipientID:            recipient.ID,
+		CertificateNumber:      "1234567890",
+		StartDate:              time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC),
+		EndDate:                time.Date(2027, 3, 31, 0, 0, 0, 0, time.UTC),
+		MunicipalityCode:       "141003",
+		Issuer:                 "横浜市",
+		ServiceCode:            "46",
+		ServiceType:            "就労継続支援B型",
+		MaxBenefitDaysPerMonth: 22,
+		CreatedAt:              now,
+		UpdatedAt:              now,
+	}
+	if err := repo.Create(ctx, certificate); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	// The certificate number is personal data and must not be stored in plain text
+	var stored []byte
+	if err := database.DB().QueryRowContext(ctx,
+		`SELECT certificate_number_cipher FROM benefit_certificates WHERE id = 'c-1'`).Scan(&stored); err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(stored, []byte("1234567890")) {
+		t.Error("certificate number stored in plain text")
+	}
+
+	got, err := repo.GetByID(ctx, "c-1")
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if got.CertificateNumber != "1234567890" || got.MunicipalityCode != "141003" || got.ServiceCode != "46" {
+		t.Errorf("GetByID() codes = %q, %q, %q", got.CertificateNumber, got.MunicipalityCode, got.ServiceCode)
+	}
+
+	got.MunicipalityCode, got.Issuer = "131016", "千代田区"
+	if err := repo.Update(ctx, got); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if updated, err := repo.GetByID(ctx, "c-1"); err != nil || updated.MunicipalityCode != "131016" {
+		t.Errorf("after Update() municipality code = %+v, %v", updated, err)
+	}
+
+	// Certificates saved before the codes were introduced have NULL columns
+	if _, err := database.DB().ExecContext(ctx, `UPDATE benefit_certificates
+		SET certificate_number_cipher = NULL, municipality_code_cipher = NULL, service_code_cipher = NULL`); err != nil {
+		t.Fatal(err)
+	}
+	legacy, err := repo.GetByID(ctx, "c-1")
+	if err != nil {
+		t.Fatalf("GetByID() legacy error = %v", err)
+	}
+	if legacy.CertificateNumber != "" || legacy.MunicipalityCode != "" || legacy.ServiceCode != "" || legacy.Issuer != "千代田区" {
+		t.Errorf("legacy certificate = %+v", legacy)
+	}
+}
@@ -36,8 +36,9 @@ func (r *BenefitCertificateRepository) Create(ctx context.Context, certificate *
 		INSERT INTO benefit_certificates (
 			id, recipient_id, start_date, end_date, issuer_cipher, 
 			service_type_cipher, max_benefit_days_per_month_cipher, 
-			benefit_details_cipher, created_at, updated_at
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+			benefit_details_cipher, created_at, updated_at,
+			certificate_number_cipher, municipality_code_cipher, service_code_cipher
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
 
 	// Encrypt fields
 	issuerCipher, err := r.cipher.Encrypt(certificate.Issuer)
@@ -60,6 +61,12 @@ func (r *BenefitCertificateRepository) Create(ctx context.Context, certificate *
 		return &domain.RepositoryError{Op: "encrypt benefit_details", Err: err}
 	}
 
+	codes, err := r.encryptCodes(certificate)
+	if err != nil {
+		return err
+	}
+	defer codes.clear()
+
 	// Execute the query
 	executor := r.getExecutor(ctx)
 	_, err = executor.ExecContext(ctx, query,
@@ -73,11 +80,15 @@ func (r *BenefitCertificateRepository) Create(ctx context.Context, certificate *
 		benefitDetailsCipher,
 		certificate.CreatedAt.Format(time.RFC3339),
 		certificate.UpdatedAt.Format(time.RFC3339),
+		codes.certificateNumber,
+		codes.municipalityCode,
+		codes.serviceCode,
 	)
 
 	if err != nil {
 		return &domain.RepositoryError{Op: "create benefit certificate", Err: err}
 	}
+	certificate.Version = 1
 
 	return nil
 }
@@ -87,7 +98,8 @@ func (r *BenefitCertificateRepository) GetByID(ctx context.Context, id domain.ID
 	query := `
 		SELECT id, recipient_id, start_date, end_date, issuer_cipher, 
 			   service_type_cipher, max_benefit_days_per_month_cipher, 
-			   benefit_details_cipher, created_at, updated_at
+			   benefit_details_cipher, created_at, updated_at, version,
+			   certificate_number_cipher, municipality_code_cipher, service_code_cipher
 		FROM benefit_certificates 
 		WHERE id = ?`
 
@@ -103,8 +115,10 @@ func (r *BenefitCertificateRepository) Update(ctx context.Context, certificate *
 		UPDATE benefit_certificates 
 		SET recipient_id = ?, start_date = ?, end_date = ?, issuer_cipher = ?, 
 			service_type_cipher = ?, max_benefit_days_per_month_cipher = ?, 
-			benefit_details_cipher = ?, updated_at = ?
-		WHERE id = ?`
+			benefit_details_cipher = ?, certificate_number_cipher = ?,
+			municipality_code_cipher = ?, service_code_cipher = ?,
+			updated_at = ?, version = version + 1
+		WHERE id = ? AND version = ?`
 
 	// Encrypt fields
 	issuerCipher, err := r.cipher.Encrypt(certificate.Issuer)
@@ -127,6 +141,12 @@ func (r *BenefitCertificateRepository) Update(ctx context.Context, certificate *
 		return &domain.RepositoryError{Op: "encrypt benefit_details", Err: err}
 	}
 
+	codes, err := r.encryptCodes(certificate)
+	if err != nil {
+		return err
+	}
+	defer codes.clear()
+
 	executor := r.getExecutor(ctx)
 	result, err := executor.ExecContext(ctx, query,
 		certificate.RecipientID,
@@ -136,8 +156,12 @@ func (r *BenefitCertificateRepository) Update(ctx context.Context, certificate *
 		serviceTypeCipher,
 		maxBenefitDaysCipher,
 		benefitDetailsCipher,
+		codes.certificateNumber,
+		codes.municipalityCode,
+		codes.serviceCode,
 		certificate.UpdatedAt.Format(time.RFC3339),
 		certificate.ID,
+		certificate.Version,
 	)
 
 	if err != nil {
@@ -150,8 +174,9 @@ func (r *BenefitCertificateRepository) Update(ctx context.Context, certificate *
 	}
 
 	if rowsAffected == 0 {
-		return domain.ErrNotFound
+		return versionMismatchError(ctx, executor, "benefit_certificates", certificate.ID)
 	}
+	certificate.Version++
 
 	return nil
 }
@@ -183,7 +208,8 @@ func (r *BenefitCertificateRepository) GetByRecipientID(ctx context.Context, rec
 	query := `
 		SELECT id, recipient_id, start_date, end_date, issuer_cipher, 
 			   service_type_cipher, max_benefit_days_per_month_cipher, 
-			   benefit_details_cipher, created_at, updated_at
+			   benefit_details_cipher, created_at, updated_at, version,
+			   certificate_number_cipher, municipality_code_cipher, service_code_cipher
 		FROM benefit_certificates 
 		WHERE recipient_id = ?
 		ORDER BY start_date DESC`
@@ -218,7 +244,8 @@ func (r *BenefitCertificateRepository) GetExpiringSoon(ctx context.Context, with
 	query := `
 		SELECT id, recipient_id, start_date, end_date, issuer_cipher, 
 			   service_type_cipher, max_benefit_days_per_month_cipher, 
-			   benefit_details_cipher, created_at, updated_at
+			   benefit_details_cipher, created_at, updated_at, version,
+			   certificate_number_cipher, municipality_code_cipher, service_code_cipher
 		FROM benefit_certificates 
 		WHERE end_date <= ?
 		ORDER BY end_date ASC`
@@ -251,7 +278,8 @@ func (r *BenefitCertificateRepository) GetActiveByRecipientID(ctx context.Contex
 	query := `
 		SELECT id, recipient_id, start_date, end_date, issuer_cipher, 
 			   service_type_cipher, max_benefit_days_per_month_cipher, 
-			   benefit_details_cipher, created_at, updated_at
+			   benefit_details_cipher, created_at, updated_at, version,
+			   certificate_number_cipher, municipality_code_cipher, service_code_cipher
 		FROM benefit_certificates 
 		WHERE recipient_id = ? AND start_date <= ? AND end_date >= ?
 		ORDER BY start_date DESC
@@ -268,7 +296,8 @@ func (r *BenefitCertificateRepository) List(ctx context.Context, limit, offset i
 	query := `
 		SELECT id, recipient_id, start_date, end_date, issuer_cipher, 
 			   service_type_cipher, max_benefit_days_per_month_cipher, 
-			   benefit_details_cipher, created_at, updated_at
+			   benefit_details_cipher, created_at, updated_at, version,
+			   certificate_number_cipher, municipality_code_cipher, service_code_cipher
 		FROM benefit_certificates 
 		ORDER BY created_at DESC
 		LIMIT ? OFFSET ?`
@@ -322,6 +351,7 @@ func (r *BenefitCertificateRepository) getExecutor(ctx context.Context) executor
 func (r *BenefitCertificateRepository) scanBenefitCertificate(row scanner) (*domain.BenefitCertificate, error) {
 	var certificate domain.BenefitCertificate
 	var issuerCipher, serviceTypeCipher, maxBenefitDaysCipher, benefitDetailsCipher []byte
+	var codes certificateCodeCiphers
 	var startDateStr, endDateStr, createdAtStr, updatedAtStr string
 
 	err := row.Scan(
@@ -335,6 +365,10 @@ func (r *BenefitCertificateRepository) scanBenefitCertificate(row scanner) (*dom
 		&benefitDetailsCipher,
 		&createdAtStr,
 		&updatedAtStr,
+		&certificate.Version,
+		&codes.certificateNumber,
+		&codes.municipalityCode,
+		&codes.serviceCode,
 	)
 
 	if err != nil {
@@ -390,5 +424,68 @@ func (r *BenefitCertificateRepository) scanBenefitCertificate(row scanner) (*dom
 		return nil, &domain.RepositoryError{Op: "decrypt benefit_details", Err: err}
 	}
 
+	if err := r.decryptCodes(&certificate, &codes); err != nil {
+		return nil, err
+	}
+
 	return &certificate, nil
 }
+
+// certificateCodeCiphers holds the encrypted certificate number and codes
+type certificateCodeCiphers struct {
+	certificateNumber, municipalityCode, serviceCode []byte
+}
+
+// clear wipes the ciphertexts from memory
+func (c *certificateCodeCiphers) clear() {
+	crypto.ClearBytes(c.certificateNumber)
+	crypto.ClearBytes(c.municipalityCode)
+	crypto.ClearBytes(c.serviceCode)
+}
+
+// encryptCodes encrypts the certificate number, municipality code and service code
+func (r *BenefitCertificateRepository) encryptCodes(certificate *domain.BenefitCertificate) (*certificateCodeCiphers, error) {
+	var ciphers certificateCodeCiphers
+
+	fields := []struct {
+		op     string
+		value  string
+		target *[]byte
+	}{
+		{"encrypt certificate_number", certificate.CertificateNumber, &ciphers.certificateNumber},
+		{"encrypt municipality_code", certificate.MunicipalityCode, &ciphers.municipalityCode},
+		{"encrypt service_code", certificate.ServiceCode, &ciphers.serviceCode},
+	}
+	for _, field := range fields {
+		encrypted, err := r.cipher.Encrypt(field.value)
+		if err != nil {
+			return nil, &domain.RepositoryError{Op: field.op, Err: err}
+		}
+		*field.target = encrypted
+	}
+
+	return &ciphers, nil
+}
+
+// decryptCodes decrypts the certificate number and codes; they are NULL for
+// certificates saved before the municipality and service type masters
+func (r *BenefitCertificateRepository) decryptCodes(certificate *domain.BenefitCertificate, ciphers *certificateCodeCiphers) error {
+	fields := []struct {
+		op     string
+		value  []byte
+		target *string
+	}{
+		{"decrypt certificate_number", ciphers.certificateNumber, &certificate.CertificateNumber},
+		{"decrypt municipality_code", ciphers.municipalityCode, &certificate.MunicipalityCode},
+		{"decrypt service_code", ciphers.serviceCode, &certificate.ServiceCode},
+	}
+	for _, field := range fields {
+		decrypted, err := r.cipher.Decrypt(field.value)
+		if err != nil {
+			return &domain.RepositoryError{Op: field.op, Err: err}
+		}
+		*field.target = decrypted
+	}
+
+	return nil
+}
@@ -6,6 +6,8 @@ import (
 	"time"
 
 	"shien-system/internal/domain"
+
+	"github.com/stretchr/testify/require"
 )
 
 func TestBenefitCertificateRepository_Create(t *testing.T) {
@@ -29,7 +31,7 @@ func TestBenefitCertificateRepository_Create(t *testing.T) {
 		UpdatedAt:        now,
 	}
 
-	err := recipientRepo.Create(ctx, recipient)
+	err = recipientRepo.Create(ctx, recipient)
 	if err != nil {
 		t.Fatalf("Create recipient error = %v", err)
 	}
@@ -117,7 +119,7 @@ func TestBenefitCertificateRepository_GetByRecipientID(t *testing.T) {
 		UpdatedAt:        now,
 	}
 
-	err := recipientRepo.Create(ctx, recipient)
+	err = recipientRepo.Create(ctx, recipient)
 	if err != nil {
 		t.Fatalf("Create recipient error = %v", err)
 	}
@@ -199,7 +201,7 @@ func TestBenefitCertificateRepository_GetExpiringSoon(t *testing.T) {
 		UpdatedAt:        now,
 	}
 
-	err := recipientRepo.Create(ctx, recipient)
+	err = recipientRepo.Create(ctx, recipient)
 	if err != nil {
 		t.Fatalf("Create recipient error = %v", err)
 	}
@@ -309,7 +311,7 @@ func TestBenefitCertificateRepository_GetActiveByRecipientID(t *testing.T) {
 		UpdatedAt:        now,
 	}
 
-	err := recipientRepo.Create(ctx, recipient)
+	err = recipientRepo.Create(ctx, recipient)
 	if err != nil {
 		t.Fatalf("Create recipient error = %v", err)
 	}
@@ -411,7 +413,7 @@ func TestBenefitCertificateRepository_Update(t *testing.T) {
 		UpdatedAt:        now,
 	}
 
-	err := recipientRepo.Create(ctx, recipient)
+	err = recipientRepo.Create(ctx, recipient)
 	if err != nil {
 		t.Fatalf("Create recipient error = %v", err)
 	}
@@ -499,7 +501,7 @@ func TestBenefitCertificateRepository_Delete(t *testing.T) {
 		UpdatedAt:        now,
 	}
 
-	err := recipientRepo.Create(ctx, recipient)
+	err = recipientRepo.Create(ctx, recipient)
 	if err != nil {
 		t.Fatalf("Create recipient error = %v", err)
 	}
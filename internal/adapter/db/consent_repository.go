@@ -0,0 +1,312 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"shien-system/internal/adapter/crypto"
+	"shien-system/internal/domain"
+)
+
+// ConsentRepository implements domain.ConsentRepository
+type ConsentRepository struct {
+	db     *Database
+	cipher *crypto.FieldCipher
+}
+
+// NewConsentRepository creates a new consent repository
+func NewConsentRepository(db *Database) (*ConsentRepository, error) {
+	cipher, err := crypto.NewFieldCipher()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+
+	return &ConsentRepository{
+		db:     db,
+		cipher: cipher,
+	}, nil
+}
+
+// Create creates a new consent record
+func (r *ConsentRepository) Create(ctx context.Context, consent *domain.Consent) error {
+	query := `
+		INSERT INTO consents (
+			id, recipient_id, staff_id, consent_type, content_cipher,
+			method_cipher, obtained_at, revoked_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`
+
+	// Encrypt fields
+	contentCipher, err := r.cipher.Encrypt(consent.Content)
+	if err != nil {
+		return &domain.RepositoryError{Op: "encrypt content", Err: err}
+	}
+
+	methodCipher, err := r.cipher.Encrypt(consent.Method)
+	if err != nil {
+		return &domain.RepositoryError{Op: "encrypt method", Err: err}
+	}
+
+	executor := r.getExecutor(ctx)
+	_, err = executor.ExecContext(ctx, query,
+		consent.ID,
+		consent.RecipientID,
+		consent.StaffID,
+		consent.ConsentType,
+		contentCipher,
+		methodCipher,
+		consent.ObtainedAt.Format(time.RFC3339),
+		formatOptionalTime(consent.RevokedAt),
+	)
+
+	if err != nil {
+		return &domain.RepositoryError{Op: "create consent", Err: err}
+	}
+
+	return nil
+}
+
+// GetByID retrieves a consent by ID
+func (r *ConsentRepository) GetByID(ctx context.Context, id domain.ID) (*domain.Consent, error) {
+	query := `
+		SELECT id, recipient_id, staff_id, consent_type, content_cipher,
+			   method_cipher, obtained_at, revoked_at
+		FROM consents
+		WHERE id = ?`
+
+	executor := r.getExecutor(ctx)
+	row := executor.QueryRowContext(ctx, query, id)
+
+	return r.scanConsent(row)
+}
+
+// Update updates an existing consent
+func (r *ConsentRepository) Update(ctx context.Context, consent *domain.Consent) error {
+	query := `
+		UPDATE consents
+		SET staff_id = ?, consent_type = ?, content_cipher = ?, method_cipher = ?,
+			obtained_at = ?, revoked_at = ?
+		WHERE id = ?`
+
+	contentCipher, err := r.cipher.Encrypt(consent.Content)
+	if err != nil {
+		return &domain.RepositoryError{Op: "encrypt content", Err: err}
+	}
+
+	methodCipher, err := r.cipher.Encrypt(consent.Method)
+	if err != nil {
+		return &domain.RepositoryError{Op: "encrypt method", Err: err}
+	}
+
+	executor := r.getExecutor(ctx)
+	result, err := executor.ExecContext(ctx, query,
+		consent.StaffID,
+		consent.ConsentType,
+		contentCipher,
+		methodCipher,
+		consent.ObtainedAt.Format(time.RFC3339),
+		formatOptionalTime(consent.RevokedAt),
+		consent.ID,
+	)
+
+	if err != nil {
+		return &domain.RepositoryError{Op: "update consent", Err: err}
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return &domain.RepositoryError{Op: "check rows affected", Err: err}
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
+// Delete deletes a consent by ID
+func (r *ConsentRepository) Delete(ctx context.Context, id domain.ID) error {
+	query := `DELETE FROM consents WHERE id = ?`
+
+	executor := r.getExecutor(ctx)
+	result, err := executor.ExecContext(ctx, query, id)
+	if err != nil {
+		return &domain.RepositoryError{Op: "delete consent", Err: err}
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return &domain.RepositoryError{Op: "check rows affected", Err: err}
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
+// GetByRecipientID retrieves all consents for a recipient
+func (r *ConsentRepository) GetByRecipientID(ctx context.Context, recipientID domain.ID) ([]*domain.Consent, error) {
+	query := `
+		SELECT id, recipient_id, staff_id, consent_type, content_cipher,
+			   method_cipher, obtained_at, revoked_at
+		FROM consents
+		WHERE recipient_id = ?
+		ORDER BY obtained_at DESC`
+
+	return r.queryConsents(ctx, "get consents by recipient", query, recipientID)
+}
+
+// GetByType retrieves all consents of the given type
+func (r *ConsentRepository) GetByType(ctx context.Context, consentType string) ([]*domain.Consent, error) {
+	query := `
+		SELECT id, recipient_id, staff_id, consent_type, content_cipher,
+			   method_cipher, obtained_at, revoked_at
+		FROM consents
+		WHERE consent_type = ?
+		ORDER BY obtained_at DESC`
+
+	return r.queryConsents(ctx, "get consents by type", query, consentType)
+}
+
+// GetActiveByRecipientID retrieves consents of a recipient that have not been revoked
+func (r *ConsentRepository) GetActiveByRecipientID(ctx context.Context, recipientID domain.ID) ([]*domain.Consent, error) {
+	query := `
+		SELECT id, recipient_id, staff_id, consent_type, content_cipher,
+			   method_cipher, obtained_at, revoked_at
+		FROM consents
+		WHERE recipient_id = ? AND revoked_at IS NULL
+		ORDER BY obtained_at DESC`
+
+	return r.queryConsents(ctx, "get active consents by recipient", query, recipientID)
+}
+
+// RevokeAllByRecipientID revokes every active consent of a recipient
+func (r *ConsentRepository) RevokeAllByRecipientID(ctx context.Context, recipientID domain.ID, revokedAt time.Time) error {
+	query := `
+		UPDATE consents
+		SET revoked_at = ?
+		WHERE recipient_id = ? AND revoked_at IS NULL`
+
+	executor := r.getExecutor(ctx)
+	_, err := executor.ExecContext(ctx, query, revokedAt.Format(time.RFC3339), recipientID)
+	if err != nil {
+		return &domain.RepositoryError{Op: "revoke all consents", Err: err}
+	}
+
+	return nil
+}
+
+// List retrieves consents with pagination
+func (r *ConsentRepository) List(ctx context.Context, limit, offset int) ([]*domain.Consent, error) {
+	query := `
+		SELECT id, recipient_id, staff_id, consent_type, content_cipher,
+			   method_cipher, obtained_at, revoked_at
+		FROM consents
+		ORDER BY obtained_at DESC
+		LIMIT ? OFFSET ?`
+
+	return r.queryConsents(ctx, "list consents", query, limit, offset)
+}
+
+// Count returns the total number of consents
+func (r *ConsentRepository) Count(ctx context.Context) (int, error) {
+	query := `SELECT COUNT(*) FROM consents`
+
+	executor := r.getExecutor(ctx)
+	var count int
+	err := executor.QueryRowContext(ctx, query).Scan(&count)
+	if err != nil {
+		return 0, &domain.RepositoryError{Op: "count consents", Err: err}
+	}
+
+	return count, nil
+}
+
+// queryConsents runs a multi-row consent query
+func (r *ConsentRepository) queryConsents(ctx context.Context, op, query string, args ...interface{}) ([]*domain.Consent, error) {
+	executor := r.getExecutor(ctx)
+	rows, err := executor.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, &domain.RepositoryError{Op: op, Err: err}
+	}
+	defer rows.Close()
+
+	var consents []*domain.Consent
+	for rows.Next() {
+		consent, err := r.scanConsent(rows)
+		if err != nil {
+			return nil, err
+		}
+		consents = append(consents, consent)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, &domain.RepositoryError{Op: "rows iteration", Err: err}
+	}
+
+	return consents, nil
+}
+
+// getExecutor returns either a transaction or the database connection
+func (r *ConsentRepository) getExecutor(ctx context.Context) executor {
+	if tx := ctx.Value("tx"); tx != nil {
+		return tx.(*sql.Tx)
+	}
+	return r.db.DB()
+}
+
+// scanConsent scans a consent from a database row
+func (r *ConsentRepository) scanConsent(row scanner) (*domain.Consent, error) {
+	var consent domain.Consent
+	var contentCipher, methodCipher []byte
+	var obtainedAtStr string
+	var revokedAtStr *string
+
+	err := row.Scan(
+		&consent.ID,
+		&consent.RecipientID,
+		&consent.StaffID,
+		&consent.ConsentType,
+		&contentCipher,
+		&methodCipher,
+		&obtainedAtStr,
+		&revokedAtStr,
+	)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrNotFound
+		}
+		return nil, &domain.RepositoryError{Op: "scan consent", Err: err}
+	}
+
+	consent.ObtainedAt, err = time.Parse(time.RFC3339, obtainedAtStr)
+	if err != nil {
+		return nil, &domain.RepositoryError{Op: "parse obtained_at", Err: err}
+	}
+
+	if revokedAtStr != nil {
+		revokedAt, err := time.Parse(time.RFC3339, *revokedAtStr)
+		if err != nil {
+			return nil, &domain.RepositoryError{Op: "parse revoked_at", Err: err}
+		}
+		consent.RevokedAt = &revokedAt
+	}
+
+	// Decrypt fields
+	consent.Content, err = r.cipher.Decrypt(contentCipher)
+	if err != nil {
+		return nil, &domain.RepositoryError{Op: "decrypt content", Err: err}
+	}
+
+	consent.Method, err = r.cipher.Decrypt(methodCipher)
+	if err != nil {
+		return nil, &domain.RepositoryError{Op: "decrypt method", Err: err}
+	}
+
+	return &consent, nil
+}
@@ -0,0 +1,174 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"shien-system/internal/domain"
+)
+
+func setupConsentTestData(t *testing.T, db *Database) (context.Context, *domain.Staff, *domain.Recipient) {
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Second)
+
+	staffRepo := NewStaffRepository(db)
+	staff := &domain.Staff{
+		ID:        "consent-staff-001",
+		Name:      "同意取得担当",
+		Role:      domain.RoleStaff,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if err := staffRepo.Create(ctx, staff); err != nil {
+		t.Fatalf("Create staff error = %v", err)
+	}
+
+	recipientRepo, err := NewRecipientRepository(db)
+	if err != nil {
+		t.Fatalf("NewRecipientRepository() error = %v", err)
+	}
+	recipient := &domain.Recipient{
+		ID:        "consent-recipient-001",
+		Name:      "同意テスト太郎",
+		Sex:       domain.SexMale,
+		BirthDate: time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if err := recipientRepo.Create(ctx, recipient); err != nil {
+		t.Fatalf("Create recipient error = %v", err)
+	}
+
+	return ctx, staff, recipient
+}
+
+func TestConsentRepository_CreateAndGet(t *testing.T) {
+	db := setupTestDatabase(t)
+	defer db.Close()
+
+	ctx, staff, recipient := setupConsentTestData(t, db)
+
+	consentRepo, err := NewConsentRepository(db)
+	if err != nil {
+		t.Fatalf("NewConsentRepository() error = %v", err)
+	}
+
+	obtainedAt := time.Now().UTC().Truncate(time.Second)
+	consent := &domain.Consent{
+		ID:          "consent-001",
+		RecipientID: recipient.ID,
+		StaffID:     staff.ID,
+		ConsentType: "個人情報利用",
+		Content:     "個別支援計画作成のための個人情報利用に同意",
+		Method:      "書面",
+		ObtainedAt:  obtainedAt,
+	}
+
+	if err := consentRepo.Create(ctx, consent); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	retrieved, err := consentRepo.GetByID(ctx, consent.ID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+
+	if retrieved.Content != consent.Content {
+		t.Errorf("Content = %v, want %v", retrieved.Content, consent.Content)
+	}
+	if retrieved.Method != consent.Method {
+		t.Errorf("Method = %v, want %v", retrieved.Method, consent.Method)
+	}
+	if !retrieved.ObtainedAt.Equal(obtainedAt) {
+		t.Errorf("ObtainedAt = %v, want %v", retrieved.ObtainedAt, obtainedAt)
+	}
+	if retrieved.RevokedAt != nil {
+		t.Errorf("RevokedAt = %v, want nil", retrieved.RevokedAt)
+	}
+
+	// Content must not be stored in plain text
+	var contentCipher []byte
+	err = db.DB().QueryRowContext(ctx, `SELECT content_cipher FROM consents WHERE id = ?`, consent.ID).Scan(&contentCipher)
+	if err != nil {
+		t.Fatalf("failed to read content_cipher: %v", err)
+	}
+	if string(contentCipher) == consent.Content {
+		t.Error("content should be encrypted at rest")
+	}
+
+	if _, err := consentRepo.GetByID(ctx, "nonexistent"); err != domain.ErrNotFound {
+		t.Errorf("GetByID() error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestConsentRepository_RevokeAllByRecipientID(t *testing.T) {
+	db := setupTestDatabase(t)
+	defer db.Close()
+
+	ctx, staff, recipient := setupConsentTestData(t, db)
+
+	consentRepo, err := NewConsentRepository(db)
+	if err != nil {
+		t.Fatalf("NewConsentRepository() error = %v", err)
+	}
+
+	now := time.Now().UTC().Truncate(time.Second)
+	for i, consentType := range []string{"個人情報利用", "第三者提供"} {
+		consent := &domain.Consent{
+			ID:          domain.ID("consent-revoke-" + consentType),
+			RecipientID: recipient.ID,
+			StaffID:     staff.ID,
+			ConsentType: consentType,
+			Content:     "同意内容",
+			Method:      "口頭",
+			ObtainedAt:  now.Add(time.Duration(i) * time.Hour),
+		}
+		if err := consentRepo.Create(ctx, consent); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+
+	active, err := consentRepo.GetActiveByRecipientID(ctx, recipient.ID)
+	if err != nil {
+		t.Fatalf("GetActiveByRecipientID() error = %v", err)
+	}
+	if len(active) != 2 {
+		t.Fatalf("GetActiveByRecipientID() returned %d consents, want 2", len(active))
+	}
+
+	if err := consentRepo.RevokeAllByRecipientID(ctx, recipient.ID, now.Add(2*time.Hour)); err != nil {
+		t.Fatalf("RevokeAllByRecipientID() error = %v", err)
+	}
+
+	active, err = consentRepo.GetActiveByRecipientID(ctx, recipient.ID)
+	if err != nil {
+		t.Fatalf("GetActiveByRecipientID() error = %v", err)
+	}
+	if len(active) != 0 {
+		t.Errorf("GetActiveByRecipientID() after revoke returned %d consents, want 0", len(active))
+	}
+
+	all, err := consentRepo.GetByRecipientID(ctx, recipient.ID)
+	if err != nil {
+		t.Fatalf("GetByRecipientID() error = %v", err)
+	}
+	if len(all) != 2 {
+		t.Fatalf("GetByRecipientID() returned %d consents, want 2", len(all))
+	}
+	for _, consent := range all {
+		if consent.RevokedAt == nil {
+			t.Errorf("consent %s should be revoked", consent.ID)
+		}
+	}
+
+	count, err := consentRepo.Count(ctx)
+	if err != nil {
+		t.Fatalf("Count() error = %v", err)
+	}
+	if count != 2 {
+		t.Errorf("Count() = %d, want 2", count)
+	}
+}
@@ -186,6 +186,42 @@ func TestDatabase_WithTransaction(t *testing.T) {
 			t.Errorf("WithTransaction() error = %v, want %v", err, testErr)
 		}
 	})
+
+	t.Run("nested call joins the outer transaction", func(t *testing.T) {
+		if _, err := db.DB().ExecContext(ctx, "CREATE TABLE test_nested (id INTEGER)"); err != nil {
+			t.Fatalf("failed to create table: %v", err)
+		}
+
+		testErr := sql.ErrNoRows
+		err := db.WithTransaction(ctx, func(ctx context.Context) error {
+			outer := ctx.Value("tx").(*sql.Tx)
+
+			err := db.WithTransaction(ctx, func(ctx context.Context) error {
+				if ctx.Value("tx").(*sql.Tx) != outer {
+					t.Error("nested call began a new transaction")
+				}
+				_, err := outer.ExecContext(ctx, "INSERT INTO test_nested (id) VALUES (1)")
+				return err
+			})
+			if err != nil {
+				return err
+			}
+
+			// The outer failure must also roll back the nested write
+			return testErr
+		})
+		if err != testErr {
+			t.Errorf("WithTransaction() error = %v, want %v", err, testErr)
+		}
+
+		var count int
+		if err := db.DB().QueryRowContext(ctx, "SELECT COUNT(*) FROM test_nested").Scan(&count); err != nil {
+			t.Fatalf("failed to count rows: %v", err)
+		}
+		if count != 0 {
+			t.Errorf("nested write survived the outer rollback: %d rows", count)
+		}
+	})
 }
 
 func TestParseMigrationFilename(t *testing.T) {
@@ -0,0 +1,58 @@
+//go:build sqlcipher
+
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+
+	_ "github.com/mutecomm/go-sqlcipher/v4"
+)
+
+// EncryptionSupported reports whether this build can open encrypted
+// databases. Build with -tags sqlcipher to enable it.
+const EncryptionSupported = true
+
+// rawKey formats the key so SQLCipher uses it directly instead of running it
+// through its passphrase KDF
+func rawKey(key []byte) string {
+	return fmt.Sprintf("x'%s'", hex.EncodeToString(key))
+}
+
+func keyParams(key []byte) (string, error) {
+	if len(key) != 32 {
+		return "", fmt.Errorf("invalid database key size: expected 32, got %d", len(key))
+	}
+	return fmt.Sprintf("&_pragma_key=%s&_pragma_cipher_page_size=4096", rawKey(key)), nil
+}
+
+func exportEncrypted(ctx context.Context, plain *sql.DB, encryptedPath string, key []byte) error {
+	if _, err := keyParams(key); err != nil {
+		return err
+	}
+
+	// ATTACH only applies to one connection, so keep all three statements on it
+	conn, err := plain.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to open connection: %w", err)
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, `ATTACH DATABASE ? AS encrypted KEY ?`, encryptedPath, rawKey(key)); err != nil {
+		return fmt.Errorf("failed to create encrypted database: %w", err)
+	}
+	if _, err := conn.ExecContext(ctx, `PRAGMA encrypted.cipher_page_size = 4096`); err != nil {
+		conn.ExecContext(ctx, `DETACH DATABASE encrypted`)
+		return fmt.Errorf("failed to set page size: %w", err)
+	}
+	if _, err := conn.ExecContext(ctx, `SELECT sqlcipher_export('encrypted')`); err != nil {
+		conn.ExecContext(ctx, `DETACH DATABASE encrypted`)
+		return fmt.Errorf("failed to export database: %w", err)
+	}
+	if _, err := conn.ExecContext(ctx, `DETACH DATABASE encrypted`); err != nil {
+		return fmt.Errorf("failed to detach encrypted database: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,22 @@
+//go:build !sqlcipher
+
+package db
+
+import (
+	"context"
+	"database/sql"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// EncryptionSupported reports whether this build can open encrypted
+// databases. Build with -tags sqlcipher to enable it.
+const EncryptionSupported = false
+
+func keyParams(key []byte) (string, error) {
+	return "", ErrEncryptionUnsupported
+}
+
+func exportEncrypted(ctx context.Context, plain *sql.DB, encryptedPath string, key []byte) error {
+	return ErrEncryptionUnsupported
+}
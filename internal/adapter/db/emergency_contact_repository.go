@@ -0,0 +1,289 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"shien-system/internal/adapter/crypto"
+	"shien-system/internal/domain"
+)
+
+// EmergencyContactRepository implements domain.EmergencyContactRepository
+type EmergencyContactRepository struct {
+	db     *Database
+	cipher *crypto.FieldCipher
+}
+
+// NewEmergencyContactRepository creates a new emergency contact repository
+func NewEmergencyContactRepository(db *Database) (*EmergencyContactRepository, error) {
+	cipher, err := crypto.NewFieldCipher()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+
+	return &EmergencyContactRepository{
+		db:     db,
+		cipher: cipher,
+	}, nil
+}
+
+// Create creates a new emergency contact
+func (r *EmergencyContactRepository) Create(ctx context.Context, contact *domain.EmergencyContact) error {
+	query := `
+		INSERT INTO emergency_contacts (
+			id, recipient_id, priority, name_cipher, relationship_cipher, is_guardian,
+			phone_cipher, mobile_phone_cipher, work_phone_cipher, email_cipher, notes_cipher,
+			created_at, updated_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+	ciphers, err := r.encryptFields(contact)
+	if err != nil {
+		return err
+	}
+
+	executor := r.getExecutor(ctx)
+	_, err = executor.ExecContext(ctx, query,
+		contact.ID,
+		contact.RecipientID,
+		contact.Priority,
+		ciphers.name,
+		ciphers.relationship,
+		contact.IsGuardian,
+		ciphers.phone,
+		ciphers.mobilePhone,
+		ciphers.workPhone,
+		ciphers.email,
+		ciphers.notes,
+		contact.CreatedAt.Format(time.RFC3339),
+		contact.UpdatedAt.Format(time.RFC3339),
+	)
+
+	if err != nil {
+		return &domain.RepositoryError{Op: "create emergency contact", Err: err}
+	}
+
+	return nil
+}
+
+// GetByID retrieves an emergency contact by ID
+func (r *EmergencyContactRepository) GetByID(ctx context.Context, id domain.ID) (*domain.EmergencyContact, error) {
+	query := `
+		SELECT id, recipient_id, priority, name_cipher, relationship_cipher, is_guardian,
+			   phone_cipher, mobile_phone_cipher, work_phone_cipher, email_cipher, notes_cipher,
+			   created_at, updated_at
+		FROM emergency_contacts
+		WHERE id = ?`
+
+	executor := r.getExecutor(ctx)
+	row := executor.QueryRowContext(ctx, query, id)
+
+	return r.scanEmergencyContact(row)
+}
+
+// Update updates an existing emergency contact
+func (r *EmergencyContactRepository) Update(ctx context.Context, contact *domain.EmergencyContact) error {
+	query := `
+		UPDATE emergency_contacts
+		SET priority = ?, name_cipher = ?, relationship_cipher = ?, is_guardian = ?,
+			phone_cipher = ?, mobile_phone_cipher = ?, work_phone_cipher = ?,
+			email_cipher = ?, notes_cipher = ?, updated_at = ?
+		WHERE id = ?`
+
+	ciphers, err := r.encryptFields(contact)
+	if err != nil {
+		return err
+	}
+
+	executor := r.getExecutor(ctx)
+	result, err := executor.ExecContext(ctx, query,
+		contact.Priority,
+		ciphers.name,
+		ciphers.relationship,
+		contact.IsGuardian,
+		ciphers.phone,
+		ciphers.mobilePhone,
+		ciphers.workPhone,
+		ciphers.email,
+		ciphers.notes,
+		contact.UpdatedAt.Format(time.RFC3339),
+		contact.ID,
+	)
+
+	if err != nil {
+		return &domain.RepositoryError{Op: "update emergency contact", Err: err}
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return &domain.RepositoryError{Op: "check rows affected", Err: err}
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
+// Delete deletes an emergency contact by ID
+func (r *EmergencyContactRepository) Delete(ctx context.Context, id domain.ID) error {
+	query := `DELETE FROM emergency_contacts WHERE id = ?`
+
+	executor := r.getExecutor(ctx)
+	result, err := executor.ExecContext(ctx, query, id)
+	if err != nil {
+		return &domain.RepositoryError{Op: "delete emergency contact", Err: err}
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return &domain.RepositoryError{Op: "check rows affected", Err: err}
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
+// GetByRecipientID retrieves all emergency contacts of a recipient in calling order
+func (r *EmergencyContactRepository) GetByRecipientID(ctx context.Context, recipientID domain.ID) ([]*domain.EmergencyContact, error) {
+	query := `
+		SELECT id, recipient_id, priority, name_cipher, relationship_cipher, is_guardian,
+			   phone_cipher, mobile_phone_cipher, work_phone_cipher, email_cipher, notes_cipher,
+			   created_at, updated_at
+		FROM emergency_contacts
+		WHERE recipient_id = ?
+		ORDER BY priority ASC, created_at ASC`
+
+	executor := r.getExecutor(ctx)
+	rows, err := executor.QueryContext(ctx, query, recipientID)
+	if err != nil {
+		return nil, &domain.RepositoryError{Op: "get emergency contacts by recipient", Err: err}
+	}
+	defer rows.Close()
+
+	var contacts []*domain.EmergencyContact
+	for rows.Next() {
+		contact, err := r.scanEmergencyContact(rows)
+		if err != nil {
+			return nil, err
+		}
+		contacts = append(contacts, contact)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, &domain.RepositoryError{Op: "rows iteration", Err: err}
+	}
+
+	return contacts, nil
+}
+
+// emergencyContactCiphers holds the encrypted columns of an emergency contact
+type emergencyContactCiphers struct {
+	name, relationship, phone, mobilePhone, workPhone, email, notes []byte
+}
+
+// encryptFields encrypts every personal field of an emergency contact
+func (r *EmergencyContactRepository) encryptFields(contact *domain.EmergencyContact) (*emergencyContactCiphers, error) {
+	var ciphers emergencyContactCiphers
+
+	fields := []struct {
+		op     string
+		value  string
+		target *[]byte
+	}{
+		{"encrypt name", contact.Name, &ciphers.name},
+		{"encrypt relationship", contact.Relationship, &ciphers.relationship},
+		{"encrypt phone", contact.Phone, &ciphers.phone},
+		{"encrypt mobile phone", contact.MobilePhone, &ciphers.mobilePhone},
+		{"encrypt work phone", contact.WorkPhone, &ciphers.workPhone},
+		{"encrypt email", contact.Email, &ciphers.email},
+		{"encrypt notes", contact.Notes, &ciphers.notes},
+	}
+
+	for _, field := range fields {
+		cipher, err := r.cipher.Encrypt(field.value)
+		if err != nil {
+			return nil, &domain.RepositoryError{Op: field.op, Err: err}
+		}
+		*field.target = cipher
+	}
+
+	return &ciphers, nil
+}
+
+// getExecutor returns either a transaction or the database connection
+func (r *EmergencyContactRepository) getExecutor(ctx context.Context) executor {
+	if tx := ctx.Value("tx"); tx != nil {
+		return tx.(*sql.Tx)
+	}
+	return r.db.DB()
+}
+
+// scanEmergencyContact scans an emergency contact from a database row
+func (r *EmergencyContactRepository) scanEmergencyContact(row scanner) (*domain.EmergencyContact, error) {
+	var contact domain.EmergencyContact
+	var ciphers emergencyContactCiphers
+	var createdAtStr, updatedAtStr string
+
+	err := row.Scan(
+		&contact.ID,
+		&contact.RecipientID,
+		&contact.Priority,
+		&ciphers.name,
+		&ciphers.relationship,
+		&contact.IsGuardian,
+		&ciphers.phone,
+		&ciphers.mobilePhone,
+		&ciphers.workPhone,
+		&ciphers.email,
+		&ciphers.notes,
+		&createdAtStr,
+		&updatedAtStr,
+	)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrNotFound
+		}
+		return nil, &domain.RepositoryError{Op: "scan emergency contact", Err: err}
+	}
+
+	contact.CreatedAt, err = time.Parse(time.RFC3339, createdAtStr)
+	if err != nil {
+		return nil, &domain.RepositoryError{Op: "parse created_at", Err: err}
+	}
+
+	contact.UpdatedAt, err = time.Parse(time.RFC3339, updatedAtStr)
+	if err != nil {
+		return nil, &domain.RepositoryError{Op: "parse updated_at", Err: err}
+	}
+
+	// Decrypt fields
+	fields := []struct {
+		op     string
+		cipher []byte
+		target *string
+	}{
+		{"decrypt name", ciphers.name, &contact.Name},
+		{"decrypt relationship", ciphers.relationship, &contact.Relationship},
+		{"decrypt phone", ciphers.phone, &contact.Phone},
+		{"decrypt mobile phone", ciphers.mobilePhone, &contact.MobilePhone},
+		{"decrypt work phone", ciphers.workPhone, &contact.WorkPhone},
+		{"decrypt email", ciphers.email, &contact.Email},
+		{"decrypt notes", ciphers.notes, &contact.Notes},
+	}
+
+	for _, field := range fields {
+		*field.target, err = r.cipher.Decrypt(field.cipher)
+		if err != nil {
+			return nil, &domain.RepositoryError{Op: field.op, Err: err}
+		}
+	}
+
+	return &contact, nil
+}
@@ -0,0 +1,168 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"shien-system/internal/domain"
+)
+
+func setupEmergencyContactTestRecipient(t *testing.T, db *Database) (context.Context, *domain.Recipient) {
+	recipientRepo, err := NewRecipientRepository(db)
+	if err != nil {
+		t.Fatalf("NewRecipientRepository() error = %v", err)
+	}
+
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Second)
+
+	recipient := &domain.Recipient{
+		ID:        "contact-recipient-001",
+		Name:      "連絡先テスト太郎",
+		Sex:       domain.SexMale,
+		BirthDate: time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if err := recipientRepo.Create(ctx, recipient); err != nil {
+		t.Fatalf("Create recipient error = %v", err)
+	}
+
+	return ctx, recipient
+}
+
+func TestEmergencyContactRepository_CRUD(t *testing.T) {
+	db := setupTestDatabase(t)
+	defer db.Close()
+
+	ctx, recipient := setupEmergencyContactTestRecipient(t, db)
+
+	contactRepo, err := NewEmergencyContactRepository(db)
+	if err != nil {
+		t.Fatalf("NewEmergencyContactRepository() error = %v", err)
+	}
+
+	now := time.Now().UTC().Truncate(time.Second)
+	contact := &domain.EmergencyContact{
+		ID:           "contact-001",
+		RecipientID:  recipient.ID,
+		Priority:     1,
+		Name:         "連絡先花子",
+		Relationship: "母",
+		IsGuardian:   true,
+		Phone:        "03-1234-5678",
+		MobilePhone:  "090-1234-5678",
+		Notes:        "体調不良時は最初に連絡",
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+
+	if err := contactRepo.Create(ctx, contact); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	retrieved, err := contactRepo.GetByID(ctx, contact.ID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+
+	if retrieved.Name != contact.Name || retrieved.Relationship != contact.Relationship {
+		t.Errorf("GetByID() = %s (%s), want %s (%s)", retrieved.Name, retrieved.Relationship, contact.Name, contact.Relationship)
+	}
+	if !retrieved.IsGuardian {
+		t.Error("IsGuardian should be true")
+	}
+	if retrieved.MobilePhone != contact.MobilePhone || retrieved.WorkPhone != "" {
+		t.Errorf("phones = %q/%q, want %q/\"\"", retrieved.MobilePhone, retrieved.WorkPhone, contact.MobilePhone)
+	}
+	if retrieved.Notes != contact.Notes {
+		t.Errorf("Notes = %v, want %v", retrieved.Notes, contact.Notes)
+	}
+
+	// Personal fields must not be stored in plain text
+	var nameCipher, phoneCipher []byte
+	err = db.DB().QueryRowContext(ctx, `SELECT name_cipher, phone_cipher FROM emergency_contacts WHERE id = ?`, contact.ID).Scan(&nameCipher, &phoneCipher)
+	if err != nil {
+		t.Fatalf("failed to read ciphers: %v", err)
+	}
+	if string(nameCipher) == contact.Name || string(phoneCipher) == contact.Phone {
+		t.Error("contact fields should be encrypted at rest")
+	}
+
+	contact.Priority = 2
+	contact.Phone = ""
+	contact.UpdatedAt = now.Add(time.Minute)
+	if err := contactRepo.Update(ctx, contact); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	updated, err := contactRepo.GetByID(ctx, contact.ID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if updated.Priority != 2 || updated.Phone != "" {
+		t.Errorf("Update() not applied: priority=%d phone=%q", updated.Priority, updated.Phone)
+	}
+
+	if err := contactRepo.Delete(ctx, contact.ID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := contactRepo.GetByID(ctx, contact.ID); err != domain.ErrNotFound {
+		t.Errorf("GetByID() after delete error = %v, want ErrNotFound", err)
+	}
+	if err := contactRepo.Update(ctx, contact); err != domain.ErrNotFound {
+		t.Errorf("Update() of missing contact error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestEmergencyContactRepository_GetByRecipientID_OrderedByPriority(t *testing.T) {
+	db := setupTestDatabase(t)
+	defer db.Close()
+
+	ctx, recipient := setupEmergencyContactTestRecipient(t, db)
+
+	contactRepo, err := NewEmergencyContactRepository(db)
+	if err != nil {
+		t.Fatalf("NewEmergencyContactRepository() error = %v", err)
+	}
+
+	now := time.Now().UTC().Truncate(time.Second)
+	for _, c := range []struct {
+		id       domain.ID
+		priority int
+		name     string
+	}{
+		{"contact-c", 3, "三番目"},
+		{"contact-a", 1, "一番目"},
+		{"contact-b", 2, "二番目"},
+	} {
+		contact := &domain.EmergencyContact{
+			ID:           c.id,
+			RecipientID:  recipient.ID,
+			Priority:     c.priority,
+			Name:         c.name,
+			Relationship: "家族",
+			CreatedAt:    now,
+			UpdatedAt:    now,
+		}
+		if err := contactRepo.Create(ctx, contact); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+
+	contacts, err := contactRepo.GetByRecipientID(ctx, recipient.ID)
+	if err != nil {
+		t.Fatalf("GetByRecipientID() error = %v", err)
+	}
+
+	if len(contacts) != 3 {
+		t.Fatalf("GetByRecipientID() returned %d contacts, want 3", len(contacts))
+	}
+	for i, want := range []domain.ID{"contact-a", "contact-b", "contact-c"} {
+		if contacts[i].ID != want {
+			t.Errorf("contacts[%d] = %s, want %s", i, contacts[i].ID, want)
+		}
+	}
+}
@@ -0,0 +1,183 @@
+package db
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// ErrEncryptionUnsupported is returned when an encrypted database is requested
+// from a build without SQLCipher. Rebuild with -tags sqlcipher.
+var ErrEncryptionUnsupported = errors.New("database encryption requires a build with -tags sqlcipher")
+
+// ErrDatabaseEncrypted is returned when an encrypted database file is opened
+// without a key
+var ErrDatabaseEncrypted = errors.New("database file is encrypted but no key was given")
+
+// ErrDatabaseNotEncrypted is returned when a key is given for a plaintext
+// database file. Run "migrate encrypt" to convert it first.
+var ErrDatabaseNotEncrypted = errors.New("database file is not encrypted")
+
+// ErrWrongDatabaseKey is returned when the key does not decrypt the database
+var ErrWrongDatabaseKey = errors.New("database key is wrong or the file is not a database")
+
+// sqliteHeader starts every plaintext SQLite database file. SQLCipher
+// encrypts the header too, so an encrypted file never starts with it.
+var sqliteHeader = []byte("SQLite format 3\x00")
+
+// IsEncryptedFile reports whether the database file at path is encrypted.
+// A missing or empty file is reported as not encrypted.
+func IsEncryptedFile(path string) (bool, error) {
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to open database file: %w", err)
+	}
+	defer file.Close()
+
+	header := make([]byte, len(sqliteHeader))
+	n, err := io.ReadFull(file, header)
+	if n == 0 && (err == io.EOF || err == io.ErrUnexpectedEOF) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to read database header: %w", err)
+	}
+	return !bytes.Equal(header, sqliteHeader), nil
+}
+
+// checkEncryptionState refuses to open a file whose encryption does not match
+// the key, instead of letting SQLite fail later with "file is not a database"
+func checkEncryptionState(path string, key []byte) error {
+	encrypted, err := IsEncryptedFile(path)
+	if err != nil {
+		return err
+	}
+	if encrypted && len(key) == 0 {
+		return ErrDatabaseEncrypted
+	}
+	if !encrypted && len(key) > 0 {
+		if info, err := os.Stat(path); err == nil && info.Size() > 0 {
+			return ErrDatabaseNotEncrypted
+		}
+	}
+	return nil
+}
+
+// dataSourceName builds the DSN for the database file, adding the key when
+// the database is encrypted
+func dataSourceName(path string, key []byte) (string, error) {
+	dsn := fmt.Sprintf("file:%s?cache=shared&mode=rwc&_journal_mode=WAL&_foreign_keys=1&_busy_timeout=30000", path)
+	if len(key) == 0 {
+		return dsn, nil
+	}
+	params, err := keyParams(key)
+	if err != nil {
+		return "", err
+	}
+	return dsn + params, nil
+}
+
+// EncryptDatabase writes an encrypted copy of the plaintext database at
+// plainPath to encryptedPath and verifies that every table was copied. The
+// plaintext file is left untouched; replacing it is up to the caller.
+func EncryptDatabase(ctx context.Context, plainPath, encryptedPath string, key []byte) error {
+	if !EncryptionSupported {
+		return ErrEncryptionUnsupported
+	}
+	if len(key) == 0 {
+		return fmt.Errorf("database key is required")
+	}
+
+	encrypted, err := IsEncryptedFile(plainPath)
+	if err != nil {
+		return err
+	}
+	if encrypted {
+		return fmt.Errorf("%s is already encrypted", plainPath)
+	}
+	if _, err := os.Stat(encryptedPath); err == nil {
+		return fmt.Errorf("%s already exists", encryptedPath)
+	}
+	if err := os.MkdirAll(filepath.Dir(encryptedPath), 0755); err != nil {
+		return fmt.Errorf("failed to create database directory: %w", err)
+	}
+
+	plainDSN, err := dataSourceName(plainPath, nil)
+	if err != nil {
+		return err
+	}
+	plain, err := sql.Open("sqlite3", plainDSN)
+	if err != nil {
+		return fmt.Errorf("failed to open plaintext database: %w", err)
+	}
+	defer plain.Close()
+
+	if err := exportEncrypted(ctx, plain, encryptedPath, key); err != nil {
+		os.Remove(encryptedPath)
+		return err
+	}
+	if err := os.Chmod(encryptedPath, 0600); err != nil {
+		return fmt.Errorf("failed to restrict database permissions: %w", err)
+	}
+
+	if err := verifyEncryptedCopy(ctx, plain, encryptedPath, key); err != nil {
+		os.Remove(encryptedPath)
+		return err
+	}
+	return nil
+}
+
+// verifyEncryptedCopy compares the row count of every table in the plaintext
+// database with the encrypted copy
+func verifyEncryptedCopy(ctx context.Context, plain *sql.DB, encryptedPath string, key []byte) error {
+	encryptedDSN, err := dataSourceName(encryptedPath, key)
+	if err != nil {
+		return err
+	}
+	encrypted, err := sql.Open("sqlite3", encryptedDSN)
+	if err != nil {
+		return fmt.Errorf("failed to open encrypted database: %w", err)
+	}
+	defer encrypted.Close()
+
+	rows, err := plain.QueryContext(ctx, `SELECT name FROM sqlite_master WHERE type = 'table' AND name NOT LIKE 'sqlite_%'`)
+	if err != nil {
+		return fmt.Errorf("failed to list tables: %w", err)
+	}
+	var tables []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to scan table name: %w", err)
+		}
+		tables = append(tables, name)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to list tables: %w", err)
+	}
+
+	for _, table := range tables {
+		query := fmt.Sprintf(`SELECT COUNT(*) FROM "%s"`, table)
+		var want, got int
+		if err := plain.QueryRowContext(ctx, query).Scan(&want); err != nil {
+			return fmt.Errorf("failed to count %s: %w", table, err)
+		}
+		if err := encrypted.QueryRowContext(ctx, query).Scan(&got); err != nil {
+			return fmt.Errorf("encrypted copy is unreadable at %s: %w", table, err)
+		}
+		if want != got {
+			return fmt.Errorf("encrypted copy of %s has %d rows, want %d", table, got, want)
+		}
+	}
+	return nil
+}
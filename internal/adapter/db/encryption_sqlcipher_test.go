@@ -0,0 +1,148 @@
+//go:build sqlcipher
+
+package db
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// secretMarker is written into plaintext columns that SQLCipher must hide
+const secretMarker = "ENCRYPTION-TEST-MARKER"
+
+func writeMarkerRows(t *testing.T, database *Database) {
+	t.Helper()
+	ctx := context.Background()
+	if err := database.RunMigrations(ctx); err != nil {
+		t.Fatalf("RunMigrations() error = %v", err)
+	}
+	// Staff names and audit actions are stored as plain text
+	_, err := database.DB().Exec(`INSERT INTO staff (id, name, role, password_hash, created_at, updated_at) VALUES ('staff-1', ?, 'admin', 'hash', '2026-10-18T09:00:00Z', '2026-10-18T09:00:00Z')`, secretMarker)
+	if err != nil {
+		t.Fatalf("failed to insert staff: %v", err)
+	}
+}
+
+// assertUnreadable checks the file and its WAL for the marker and the SQLite header
+func assertUnreadable(t *testing.T, path string) {
+	t.Helper()
+	for _, file := range []string{path, path + "-wal"} {
+		content, err := os.ReadFile(file)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if bytes.Contains(content, []byte(secretMarker)) {
+			t.Errorf("%s contains plaintext data", filepath.Base(file))
+		}
+		if bytes.HasPrefix(content, sqliteHeader) {
+			t.Errorf("%s has a plaintext SQLite header", filepath.Base(file))
+		}
+	}
+}
+
+func TestEncryptedDatabase_UnreadableWithoutKey(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "encrypted.db")
+	key := bytes.Repeat([]byte{7}, 32)
+
+	database, err := NewDatabase(Config{Path: path, EncryptionKey: key})
+	if err != nil {
+		t.Fatalf("failed to create encrypted database: %v", err)
+	}
+	writeMarkerRows(t, database)
+	assertUnreadable(t, path)
+	database.Close()
+	assertUnreadable(t, path)
+
+	if encrypted, err := IsEncryptedFile(path); err != nil || !encrypted {
+		t.Errorf("IsEncryptedFile() = %v, %v; want true, nil", encrypted, err)
+	}
+
+	if _, err := NewDatabase(Config{Path: path}); !errors.Is(err, ErrDatabaseEncrypted) {
+		t.Errorf("open without key: error = %v, want ErrDatabaseEncrypted", err)
+	}
+	if _, err := NewDatabase(Config{Path: path, EncryptionKey: bytes.Repeat([]byte{8}, 32)}); !errors.Is(err, ErrWrongDatabaseKey) {
+		t.Errorf("open with wrong key: error = %v, want ErrWrongDatabaseKey", err)
+	}
+
+	reopened, err := NewDatabase(Config{Path: path, EncryptionKey: key})
+	if err != nil {
+		t.Fatalf("open with key: %v", err)
+	}
+	defer reopened.Close()
+
+	var name string
+	if err := reopened.DB().QueryRow(`SELECT name FROM staff WHERE id = 'staff-1'`).Scan(&name); err != nil {
+		t.Fatalf("failed to read staff: %v", err)
+	}
+	if name != secretMarker {
+		t.Errorf("staff name = %q, want %q", name, secretMarker)
+	}
+}
+
+func TestEncryptedDatabase_BackupIsEncrypted(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "encrypted.db")
+	key := bytes.Repeat([]byte{7}, 32)
+
+	database, err := NewDatabase(Config{Path: path, EncryptionKey: key})
+	if err != nil {
+		t.Fatalf("failed to create encrypted database: %v", err)
+	}
+	defer database.Close()
+	writeMarkerRows(t, database)
+
+	backup, err := database.backup(context.Background(), "test")
+	if err != nil {
+		t.Fatalf("backup() error = %v", err)
+	}
+	assertUnreadable(t, backup)
+}
+
+func TestEncryptDatabase_MigratesPlaintext(t *testing.T) {
+	dir := t.TempDir()
+	plainPath := filepath.Join(dir, "plain.db")
+	encryptedPath := filepath.Join(dir, "encrypted.db")
+	key := bytes.Repeat([]byte{7}, 32)
+
+	plain, err := NewDatabase(Config{Path: plainPath})
+	if err != nil {
+		t.Fatalf("failed to create plaintext database: %v", err)
+	}
+	writeMarkerRows(t, plain)
+	plain.Close()
+
+	ctx := context.Background()
+	if err := EncryptDatabase(ctx, plainPath, encryptedPath, key); err != nil {
+		t.Fatalf("EncryptDatabase() error = %v", err)
+	}
+	assertUnreadable(t, encryptedPath)
+
+	if err := EncryptDatabase(ctx, encryptedPath, filepath.Join(dir, "again.db"), key); err == nil {
+		t.Error("encrypting an encrypted database should fail")
+	}
+
+	encrypted, err := NewDatabase(Config{Path: encryptedPath, EncryptionKey: key})
+	if err != nil {
+		t.Fatalf("failed to open migrated database: %v", err)
+	}
+	defer encrypted.Close()
+
+	// The migrated database must carry its migration history over
+	if err := encrypted.RunMigrations(ctx); err != nil {
+		t.Fatalf("RunMigrations() error = %v", err)
+	}
+	if encrypted.LastBackupPath() != "" {
+		t.Error("no migrations should be pending after encryption")
+	}
+	var name string
+	if err := encrypted.DB().QueryRow(`SELECT name FROM staff WHERE id = 'staff-1'`).Scan(&name); err != nil || name != secretMarker {
+		t.Errorf("staff name = %q, %v; want %q", name, err, secretMarker)
+	}
+}
@@ -0,0 +1,58 @@
+package db
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestIsEncryptedFile_Plaintext(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "plain.db")
+
+	encrypted, err := IsEncryptedFile(path)
+	if err != nil || encrypted {
+		t.Fatalf("IsEncryptedFile(missing) = %v, %v; want false, nil", encrypted, err)
+	}
+
+	database, err := NewDatabase(Config{Path: path})
+	if err != nil {
+		t.Fatalf("failed to create database: %v", err)
+	}
+	database.Close()
+
+	encrypted, err = IsEncryptedFile(path)
+	if err != nil || encrypted {
+		t.Errorf("IsEncryptedFile(plaintext) = %v, %v; want false, nil", encrypted, err)
+	}
+
+	if _, err := NewDatabase(Config{Path: path, EncryptionKey: bytes.Repeat([]byte{7}, 32)}); err == nil {
+		t.Error("opening a plaintext database with a key should fail")
+	}
+}
+
+func TestIsEncryptedFile_RefusesWithoutKey(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "random.db")
+	if err := os.WriteFile(path, bytes.Repeat([]byte{0xA5}, 4096), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := NewDatabase(Config{Path: path})
+	if !errors.Is(err, ErrDatabaseEncrypted) {
+		t.Errorf("NewDatabase() error = %v, want ErrDatabaseEncrypted", err)
+	}
+}
+
+func TestEncryptDatabase_Unsupported(t *testing.T) {
+	if EncryptionSupported {
+		t.Skip("built with SQLCipher")
+	}
+
+	dir := t.TempDir()
+	err := EncryptDatabase(context.Background(), filepath.Join(dir, "plain.db"), filepath.Join(dir, "encrypted.db"), bytes.Repeat([]byte{7}, 32))
+	if !errors.Is(err, ErrEncryptionUnsupported) {
+		t.Errorf("EncryptDatabase() error = %v, want ErrEncryptionUnsupported", err)
+	}
+}
@@ -0,0 +1,236 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"shien-system/internal/domain"
+)
+
+// EnrollmentPeriodRepository implements domain.EnrollmentPeriodRepository
+type EnrollmentPeriodRepository struct {
+	db *Database
+}
+
+// NewEnrollmentPeriodRepository creates a new enrollment period repository
+func NewEnrollmentPeriodRepository(db *Database) *EnrollmentPeriodRepository {
+	return &EnrollmentPeriodRepository{
+		db: db,
+	}
+}
+
+// Create creates a new enrollment period
+func (r *EnrollmentPeriodRepository) Create(ctx context.Context, period *domain.EnrollmentPeriod) error {
+	query := `
+		INSERT INTO enrollment_periods (id, recipient_id, admission_date, discharge_date, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?)`
+
+	executor := r.getExecutor(ctx)
+	_, err := executor.ExecContext(ctx, query,
+		period.ID,
+		period.RecipientID,
+		period.AdmissionDate.Format(time.RFC3339),
+		formatOptionalTime(period.DischargeDate),
+		period.CreatedAt.Format(time.RFC3339),
+		period.UpdatedAt.Format(time.RFC3339),
+	)
+
+	if err != nil {
+		return &domain.RepositoryError{Op: "create enrollment period", Err: err}
+	}
+
+	return nil
+}
+
+// GetByID retrieves an enrollment period by ID
+func (r *EnrollmentPeriodRepository) GetByID(ctx context.Context, id domain.ID) (*domain.EnrollmentPeriod, error) {
+	query := `
+		SELECT id, recipient_id, admission_date, discharge_date, created_at, updated_at
+		FROM enrollment_periods
+		WHERE id = ?`
+
+	executor := r.getExecutor(ctx)
+	row := executor.QueryRowContext(ctx, query, id)
+
+	return r.scanEnrollmentPeriod(row)
+}
+
+// Update updates an existing enrollment period
+func (r *EnrollmentPeriodRepository) Update(ctx context.Context, period *domain.EnrollmentPeriod) error {
+	query := `
+		UPDATE enrollment_periods
+		SET admission_date = ?, discharge_date = ?, updated_at = ?
+		WHERE id = ?`
+
+	executor := r.getExecutor(ctx)
+	result, err := executor.ExecContext(ctx, query,
+		period.AdmissionDate.Format(time.RFC3339),
+		formatOptionalTime(period.DischargeDate),
+		period.UpdatedAt.Format(time.RFC3339),
+		period.ID,
+	)
+
+	if err != nil {
+		return &domain.RepositoryError{Op: "update enrollment period", Err: err}
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return &domain.RepositoryError{Op: "check rows affected", Err: err}
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
+// Delete deletes an enrollment period by ID
+func (r *EnrollmentPeriodRepository) Delete(ctx context.Context, id domain.ID) error {
+	query := `DELETE FROM enrollment_periods WHERE id = ?`
+
+	executor := r.getExecutor(ctx)
+	result, err := executor.ExecContext(ctx, query, id)
+	if err != nil {
+		return &domain.RepositoryError{Op: "delete enrollment period", Err: err}
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return &domain.RepositoryError{Op: "check rows affected", Err: err}
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
+// GetByRecipientID retrieves all enrollment periods for a recipient, oldest first
+func (r *EnrollmentPeriodRepository) GetByRecipientID(ctx context.Context, recipientID domain.ID) ([]*domain.EnrollmentPeriod, error) {
+	query := `
+		SELECT id, recipient_id, admission_date, discharge_date, created_at, updated_at
+		FROM enrollment_periods
+		WHERE recipient_id = ?
+		ORDER BY admission_date ASC`
+
+	executor := r.getExecutor(ctx)
+	rows, err := executor.QueryContext(ctx, query, recipientID)
+	if err != nil {
+		return nil, &domain.RepositoryError{Op: "get enrollment periods by recipient", Err: err}
+	}
+	defer rows.Close()
+
+	var periods []*domain.EnrollmentPeriod
+	for rows.Next() {
+		period, err := r.scanEnrollmentPeriod(rows)
+		if err != nil {
+			return nil, err
+		}
+		periods = append(periods, period)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, &domain.RepositoryError{Op: "rows iteration", Err: err}
+	}
+
+	return periods, nil
+}
+
+// GetOpenByRecipientID retrieves the enrollment period that has not been closed yet
+func (r *EnrollmentPeriodRepository) GetOpenByRecipientID(ctx context.Context, recipientID domain.ID) (*domain.EnrollmentPeriod, error) {
+	query := `
+		SELECT id, recipient_id, admission_date, discharge_date, created_at, updated_at
+		FROM enrollment_periods
+		WHERE recipient_id = ? AND discharge_date IS NULL
+		ORDER BY admission_date DESC
+		LIMIT 1`
+
+	executor := r.getExecutor(ctx)
+	row := executor.QueryRowContext(ctx, query, recipientID)
+
+	return r.scanEnrollmentPeriod(row)
+}
+
+// GetLatestByRecipientID retrieves the most recent enrollment period of a recipient
+func (r *EnrollmentPeriodRepository) GetLatestByRecipientID(ctx context.Context, recipientID domain.ID) (*domain.EnrollmentPeriod, error) {
+	query := `
+		SELECT id, recipient_id, admission_date, discharge_date, created_at, updated_at
+		FROM enrollment_periods
+		WHERE recipient_id = ?
+		ORDER BY admission_date DESC
+		LIMIT 1`
+
+	executor := r.getExecutor(ctx)
+	row := executor.QueryRowContext(ctx, query, recipientID)
+
+	return r.scanEnrollmentPeriod(row)
+}
+
+// getExecutor returns either a transaction or the database connection
+func (r *EnrollmentPeriodRepository) getExecutor(ctx context.Context) executor {
+	if tx := ctx.Value("tx"); tx != nil {
+		return tx.(*sql.Tx)
+	}
+	return r.db.DB()
+}
+
+// scanEnrollmentPeriod scans an enrollment period from a database row
+func (r *EnrollmentPeriodRepository) scanEnrollmentPeriod(row scanner) (*domain.EnrollmentPeriod, error) {
+	var period domain.EnrollmentPeriod
+	var admissionDateStr, createdAtStr, updatedAtStr string
+	var dischargeDateStr *string
+
+	err := row.Scan(
+		&period.ID,
+		&period.RecipientID,
+		&admissionDateStr,
+		&dischargeDateStr,
+		&createdAtStr,
+		&updatedAtStr,
+	)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrNotFound
+		}
+		return nil, &domain.RepositoryError{Op: "scan enrollment period", Err: err}
+	}
+
+	period.AdmissionDate, err = time.Parse(time.RFC3339, admissionDateStr)
+	if err != nil {
+		return nil, &domain.RepositoryError{Op: "parse admission_date", Err: err}
+	}
+
+	if dischargeDateStr != nil {
+		dischargeDate, err := time.Parse(time.RFC3339, *dischargeDateStr)
+		if err != nil {
+			return nil, &domain.RepositoryError{Op: "parse discharge_date", Err: err}
+		}
+		period.DischargeDate = &dischargeDate
+	}
+
+	period.CreatedAt, err = time.Parse(time.RFC3339, createdAtStr)
+	if err != nil {
+		return nil, &domain.RepositoryError{Op: "parse created_at", Err: err}
+	}
+
+	period.UpdatedAt, err = time.Parse(time.RFC3339, updatedAtStr)
+	if err != nil {
+		return nil, &domain.RepositoryError{Op: "parse updated_at", Err: err}
+	}
+
+	return &period, nil
+}
+
+// formatOptionalTime formats a nullable timestamp for storage
+func formatOptionalTime(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+	str := t.Format(time.RFC3339)
+	return &str
+}
@@ -0,0 +1,216 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"shien-system/internal/domain"
+)
+
+func setupEnrollmentTestRecipient(t *testing.T, db *Database) (context.Context, *domain.Recipient) {
+	recipientRepo, err := NewRecipientRepository(db)
+	if err != nil {
+		t.Fatalf("NewRecipientRepository() error = %v", err)
+	}
+
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Second)
+
+	recipient := &domain.Recipient{
+		ID:        "enrollment-recipient-001",
+		Name:      "在籍テスト太郎",
+		Sex:       domain.SexMale,
+		BirthDate: time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if err := recipientRepo.Create(ctx, recipient); err != nil {
+		t.Fatalf("Create recipient error = %v", err)
+	}
+
+	return ctx, recipient
+}
+
+func TestEnrollmentPeriodRepository_CreateAndGetByRecipientID(t *testing.T) {
+	db := setupTestDatabase(t)
+	defer db.Close()
+
+	ctx, recipient := setupEnrollmentTestRecipient(t, db)
+	periodRepo := NewEnrollmentPeriodRepository(db)
+
+	now := time.Now().UTC().Truncate(time.Second)
+	firstDischarge := time.Date(2021, 3, 31, 0, 0, 0, 0, time.UTC)
+
+	// Insert the newer period first to verify ordering
+	periods := []*domain.EnrollmentPeriod{
+		{
+			ID:            "period-002",
+			RecipientID:   recipient.ID,
+			AdmissionDate: time.Date(2022, 4, 1, 0, 0, 0, 0, time.UTC),
+			CreatedAt:     now,
+			UpdatedAt:     now,
+		},
+		{
+			ID:            "period-001",
+			RecipientID:   recipient.ID,
+			AdmissionDate: time.Date(2020, 4, 1, 0, 0, 0, 0, time.UTC),
+			DischargeDate: &firstDischarge,
+			CreatedAt:     now,
+			UpdatedAt:     now,
+		},
+	}
+
+	for _, period := range periods {
+		if err := periodRepo.Create(ctx, period); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+
+	retrieved, err := periodRepo.GetByRecipientID(ctx, recipient.ID)
+	if err != nil {
+		t.Fatalf("GetByRecipientID() error = %v", err)
+	}
+
+	if len(retrieved) != 2 {
+		t.Fatalf("GetByRecipientID() returned %d periods, want 2", len(retrieved))
+	}
+	if retrieved[0].ID != "period-001" || retrieved[1].ID != "period-002" {
+		t.Errorf("GetByRecipientID() order = [%s, %s], want [period-001, period-002]", retrieved[0].ID, retrieved[1].ID)
+	}
+	if retrieved[0].DischargeDate == nil || !retrieved[0].DischargeDate.Equal(firstDischarge) {
+		t.Errorf("DischargeDate = %v, want %v", retrieved[0].DischargeDate, firstDischarge)
+	}
+	if retrieved[1].DischargeDate != nil {
+		t.Errorf("DischargeDate = %v, want nil", retrieved[1].DischargeDate)
+	}
+
+	open, err := periodRepo.GetOpenByRecipientID(ctx, recipient.ID)
+	if err != nil {
+		t.Fatalf("GetOpenByRecipientID() error = %v", err)
+	}
+	if open.ID != "period-002" {
+		t.Errorf("GetOpenByRecipientID() = %s, want period-002", open.ID)
+	}
+
+	latest, err := periodRepo.GetLatestByRecipientID(ctx, recipient.ID)
+	if err != nil {
+		t.Fatalf("GetLatestByRecipientID() error = %v", err)
+	}
+	if latest.ID != "period-002" {
+		t.Errorf("GetLatestByRecipientID() = %s, want period-002", latest.ID)
+	}
+}
+
+func TestEnrollmentPeriodRepository_UpdateAndDelete(t *testing.T) {
+	db := setupTestDatabase(t)
+	defer db.Close()
+
+	ctx, recipient := setupEnrollmentTestRecipient(t, db)
+	periodRepo := NewEnrollmentPeriodRepository(db)
+
+	now := time.Now().UTC().Truncate(time.Second)
+	period := &domain.EnrollmentPeriod{
+		ID:            "period-update-001",
+		RecipientID:   recipient.ID,
+		AdmissionDate: time.Date(2023, 4, 1, 0, 0, 0, 0, time.UTC),
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+
+	if err := periodRepo.Create(ctx, period); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	// Close the period
+	discharge := time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC)
+	period.DischargeDate = &discharge
+	if err := periodRepo.Update(ctx, period); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	if _, err := periodRepo.GetOpenByRecipientID(ctx, recipient.ID); err != domain.ErrNotFound {
+		t.Errorf("GetOpenByRecipientID() error = %v, want ErrNotFound", err)
+	}
+
+	// Discharge before admission violates the CHECK constraint
+	invalid := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	period.DischargeDate = &invalid
+	if err := periodRepo.Update(ctx, period); err == nil {
+		t.Error("Update() should reject discharge date before admission date")
+	}
+
+	if err := periodRepo.Delete(ctx, period.ID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+
+	if _, err := periodRepo.GetByID(ctx, period.ID); err != domain.ErrNotFound {
+		t.Errorf("GetByID() after delete error = %v, want ErrNotFound", err)
+	}
+
+	if err := periodRepo.Delete(ctx, period.ID); err != domain.ErrNotFound {
+		t.Errorf("Delete() of missing period error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestRecipientRepository_GetActive_EnrolledOnDate(t *testing.T) {
+	db := setupTestDatabase(t)
+	defer db.Close()
+
+	ctx, recipient := setupEnrollmentTestRecipient(t, db)
+	recipientRepo, err := NewRecipientRepository(db)
+	if err != nil {
+		t.Fatalf("NewRecipientRepository() error = %v", err)
+	}
+	periodRepo := NewEnrollmentPeriodRepository(db)
+
+	// Enrolled 2020/04/01-2021/03/31, then re-admitted from 2022/04/01
+	now := time.Now().UTC().Truncate(time.Second)
+	firstDischarge := time.Date(2021, 3, 31, 0, 0, 0, 0, time.UTC)
+	for _, period := range []*domain.EnrollmentPeriod{
+		{ID: "period-a", RecipientID: recipient.ID, AdmissionDate: time.Date(2020, 4, 1, 0, 0, 0, 0, time.UTC), DischargeDate: &firstDischarge, CreatedAt: now, UpdatedAt: now},
+		{ID: "period-b", RecipientID: recipient.ID, AdmissionDate: time.Date(2022, 4, 1, 0, 0, 0, 0, time.UTC), CreatedAt: now, UpdatedAt: now},
+	} {
+		if err := periodRepo.Create(ctx, period); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+
+	testCases := []struct {
+		name     string
+		asOf     time.Time
+		enrolled bool
+	}{
+		{"before first admission", time.Date(2020, 3, 31, 0, 0, 0, 0, time.UTC), false},
+		{"on first admission day", time.Date(2020, 4, 1, 0, 0, 0, 0, time.UTC), true},
+		{"on discharge day", time.Date(2021, 3, 31, 23, 0, 0, 0, time.UTC), true},
+		{"between periods", time.Date(2021, 10, 1, 0, 0, 0, 0, time.UTC), false},
+		{"after re-admission", time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC), true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			count, err := recipientRepo.CountActive(ctx, tc.asOf)
+			if err != nil {
+				t.Fatalf("CountActive() error = %v", err)
+			}
+
+			want := 0
+			if tc.enrolled {
+				want = 1
+			}
+			if count != want {
+				t.Errorf("CountActive(%s) = %d, want %d", tc.asOf.Format("2006-01-02"), count, want)
+			}
+
+			recipients, err := recipientRepo.GetActive(ctx, tc.asOf, 10, 0)
+			if err != nil {
+				t.Fatalf("GetActive() error = %v", err)
+			}
+			if len(recipients) != want {
+				t.Errorf("GetActive(%s) returned %d recipients, want %d", tc.asOf.Format("2006-01-02"), len(recipients), want)
+			}
+		})
+	}
+}
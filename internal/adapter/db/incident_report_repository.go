@@ -0,0 +1,451 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"shien-system/internal/adapter/crypto"
+	"shien-system/internal/domain"
+)
+
+// IncidentReportRepository implements domain.IncidentReportRepository
+type IncidentReportRepository struct {
+	db     *Database
+	cipher *crypto.FieldCipher
+}
+
+// NewIncidentReportRepository creates a new incident report repository
+func NewIncidentReportRepository(db *Database) (*IncidentReportRepository, error) {
+	cipher, err := crypto.NewFieldCipher()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+
+	return &IncidentReportRepository{
+		db:     db,
+		cipher: cipher,
+	}, nil
+}
+
+const incidentReportColumns = `
+	id, occurred_at, location_cipher, category, severity,
+	description_cipher, response_cipher, prevention_cipher, status,
+	reported_by, submitted_at, reviewed_by, reviewed_at, review_comment_cipher,
+	approved_by, approved_at, created_at, updated_at`
+
+// Create creates a new incident report together with the involved recipients and staff
+func (r *IncidentReportRepository) Create(ctx context.Context, report *domain.IncidentReport) error {
+	query := `
+		INSERT INTO incident_reports (` + incidentReportColumns + `
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+	ciphers, err := r.encryptFields(report)
+	if err != nil {
+		return err
+	}
+
+	return r.db.WithTransaction(ctx, func(ctx context.Context) error {
+		executor := r.getExecutor(ctx)
+		_, err := executor.ExecContext(ctx, query,
+			report.ID,
+			report.OccurredAt.UTC().Format(time.RFC3339), // UTC so range filters compare correctly
+			ciphers.location,
+			string(report.Category),
+			string(report.Severity),
+			ciphers.description,
+			ciphers.response,
+			ciphers.prevention,
+			string(report.Status),
+			report.ReportedBy,
+			formatOptionalTime(report.SubmittedAt),
+			report.ReviewedBy,
+			formatOptionalTime(report.ReviewedAt),
+			ciphers.reviewComment,
+			report.ApprovedBy,
+			formatOptionalTime(report.ApprovedAt),
+			report.CreatedAt.Format(time.RFC3339),
+			report.UpdatedAt.Format(time.RFC3339),
+		)
+		if err != nil {
+			return &domain.RepositoryError{Op: "create incident report", Err: err}
+		}
+
+		return r.saveParticipants(ctx, report)
+	})
+}
+
+// GetByID retrieves an incident report by ID
+func (r *IncidentReportRepository) GetByID(ctx context.Context, id domain.ID) (*domain.IncidentReport, error) {
+	query := `SELECT ` + incidentReportColumns + ` FROM incident_reports WHERE id = ?`
+
+	executor := r.getExecutor(ctx)
+	row := executor.QueryRowContext(ctx, query, id)
+
+	report, err := r.scanIncidentReport(row)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.loadParticipants(ctx, report); err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+// Update updates an existing incident report and replaces its participants
+func (r *IncidentReportRepository) Update(ctx context.Context, report *domain.IncidentReport) error {
+	query := `
+		UPDATE incident_reports
+		SET occurred_at = ?, location_cipher = ?, category = ?, severity = ?,
+			description_cipher = ?, response_cipher = ?, prevention_cipher = ?, status = ?,
+			submitted_at = ?, reviewed_by = ?, reviewed_at = ?, review_comment_cipher = ?,
+			approved_by = ?, approved_at = ?, updated_at = ?
+		WHERE id = ?`
+
+	ciphers, err := r.encryptFields(report)
+	if err != nil {
+		return err
+	}
+
+	return r.db.WithTransaction(ctx, func(ctx context.Context) error {
+		executor := r.getExecutor(ctx)
+		result, err := executor.ExecContext(ctx, query,
+			report.OccurredAt.UTC().Format(time.RFC3339), // UTC so range filters compare correctly
+			ciphers.location,
+			string(report.Category),
+			string(report.Severity),
+			ciphers.description,
+			ciphers.response,
+			ciphers.prevention,
+			string(report.Status),
+			formatOptionalTime(report.SubmittedAt),
+			report.ReviewedBy,
+			formatOptionalTime(report.ReviewedAt),
+			ciphers.reviewComment,
+			report.ApprovedBy,
+			formatOptionalTime(report.ApprovedAt),
+			report.UpdatedAt.Format(time.RFC3339),
+			report.ID,
+		)
+		if err != nil {
+			return &domain.RepositoryError{Op: "update incident report", Err: err}
+		}
+
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return &domain.RepositoryError{Op: "check rows affected", Err: err}
+		}
+
+		if rowsAffected == 0 {
+			return domain.ErrNotFound
+		}
+
+		return r.saveParticipants(ctx, report)
+	})
+}
+
+// List retrieves incident reports matching the filter, newest first
+func (r *IncidentReportRepository) List(ctx context.Context, filter domain.IncidentFilter, limit, offset int) ([]*domain.IncidentReport, error) {
+	whereClause, args := r.buildFilterQuery(filter)
+
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM incident_reports
+		%s
+		ORDER BY occurred_at DESC, id
+		LIMIT ? OFFSET ?`, incidentReportColumns, whereClause)
+
+	args = append(args, limit, offset)
+
+	executor := r.getExecutor(ctx)
+	rows, err := executor.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, &domain.RepositoryError{Op: "list incident reports", Err: err}
+	}
+
+	var reports []*domain.IncidentReport
+	for rows.Next() {
+		report, err := r.scanIncidentReport(rows)
+		if err != nil {
+			rows.Close()
+			return nil, err
+		}
+		reports = append(reports, report)
+	}
+
+	if err := rows.Err(); err != nil {
+		rows.Close()
+		return nil, &domain.RepositoryError{Op: "rows iteration", Err: err}
+	}
+	rows.Close()
+
+	// Participants are loaded after the cursor is closed so a transaction's
+	// single connection is free for the follow-up queries
+	for _, report := range reports {
+		if err := r.loadParticipants(ctx, report); err != nil {
+			return nil, err
+		}
+	}
+
+	return reports, nil
+}
+
+// buildFilterQuery builds WHERE clause and arguments for an incident filter
+func (r *IncidentReportRepository) buildFilterQuery(filter domain.IncidentFilter) (string, []interface{}) {
+	var conditions []string
+	var args []interface{}
+
+	if filter.OccurredFrom != nil {
+		conditions = append(conditions, "occurred_at >= ?")
+		args = append(args, filter.OccurredFrom.UTC().Format(time.RFC3339))
+	}
+
+	if filter.OccurredTo != nil {
+		conditions = append(conditions, "occurred_at < ?")
+		args = append(args, filter.OccurredTo.UTC().Format(time.RFC3339))
+	}
+
+	if filter.Status != nil {
+		conditions = append(conditions, "status = ?")
+		args = append(args, string(*filter.Status))
+	}
+
+	if filter.Category != nil {
+		conditions = append(conditions, "category = ?")
+		args = append(args, string(*filter.Category))
+	}
+
+	if filter.RecipientID != nil {
+		conditions = append(conditions, "id IN (SELECT incident_id FROM incident_recipients WHERE recipient_id = ?)")
+		args = append(args, *filter.RecipientID)
+	}
+
+	if len(conditions) == 0 {
+		return "", args
+	}
+
+	return "WHERE " + strings.Join(conditions, " AND "), args
+}
+
+// saveParticipants replaces the recipients and staff linked to a report
+func (r *IncidentReportRepository) saveParticipants(ctx context.Context, report *domain.IncidentReport) error {
+	executor := r.getExecutor(ctx)
+
+	if _, err := executor.ExecContext(ctx, `DELETE FROM incident_recipients WHERE incident_id = ?`, report.ID); err != nil {
+		return &domain.RepositoryError{Op: "clear incident recipients", Err: err}
+	}
+	for _, recipientID := range report.RecipientIDs {
+		_, err := executor.ExecContext(ctx,
+			`INSERT OR IGNORE INTO incident_recipients (incident_id, recipient_id) VALUES (?, ?)`,
+			report.ID, recipientID)
+		if err != nil {
+			return &domain.RepositoryError{Op: "add incident recipient", Err: err}
+		}
+	}
+
+	if _, err := executor.ExecContext(ctx, `DELETE FROM incident_staff WHERE incident_id = ?`, report.ID); err != nil {
+		return &domain.RepositoryError{Op: "clear incident staff", Err: err}
+	}
+	for _, staffID := range report.StaffIDs {
+		_, err := executor.ExecContext(ctx,
+			`INSERT OR IGNORE INTO incident_staff (incident_id, staff_id) VALUES (?, ?)`,
+			report.ID, staffID)
+		if err != nil {
+			return &domain.RepositoryError{Op: "add incident staff", Err: err}
+		}
+	}
+
+	return nil
+}
+
+// loadParticipants fills in the recipients and staff linked to a report
+func (r *IncidentReportRepository) loadParticipants(ctx context.Context, report *domain.IncidentReport) error {
+	recipientIDs, err := r.queryIDs(ctx, "get incident recipients",
+		`SELECT recipient_id FROM incident_recipients WHERE incident_id = ? ORDER BY recipient_id`, report.ID)
+	if err != nil {
+		return err
+	}
+
+	staffIDs, err := r.queryIDs(ctx, "get incident staff",
+		`SELECT staff_id FROM incident_staff WHERE incident_id = ? ORDER BY staff_id`, report.ID)
+	if err != nil {
+		return err
+	}
+
+	report.RecipientIDs = recipientIDs
+	report.StaffIDs = staffIDs
+	return nil
+}
+
+// queryIDs runs a single-column ID query
+func (r *IncidentReportRepository) queryIDs(ctx context.Context, op, query string, args ...interface{}) ([]domain.ID, error) {
+	executor := r.getExecutor(ctx)
+	rows, err := executor.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, &domain.RepositoryError{Op: op, Err: err}
+	}
+	defer rows.Close()
+
+	var ids []domain.ID
+	for rows.Next() {
+		var id domain.ID
+		if err := rows.Scan(&id); err != nil {
+			return nil, &domain.RepositoryError{Op: op, Err: err}
+		}
+		ids = append(ids, id)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, &domain.RepositoryError{Op: "rows iteration", Err: err}
+	}
+
+	return ids, nil
+}
+
+// incidentReportCiphers holds the encrypted columns of an incident report
+type incidentReportCiphers struct {
+	location, description, response, prevention, reviewComment []byte
+}
+
+// encryptFields encrypts the free-text fields of an incident report
+func (r *IncidentReportRepository) encryptFields(report *domain.IncidentReport) (*incidentReportCiphers, error) {
+	var ciphers incidentReportCiphers
+
+	fields := []struct {
+		op     string
+		value  string
+		target *[]byte
+	}{
+		{"encrypt location", report.Location, &ciphers.location},
+		{"encrypt description", report.Description, &ciphers.description},
+		{"encrypt response", report.Response, &ciphers.response},
+		{"encrypt prevention", report.Prevention, &ciphers.prevention},
+		{"encrypt review comment", report.ReviewComment, &ciphers.reviewComment},
+	}
+
+	for _, field := range fields {
+		cipher, err := r.cipher.Encrypt(field.value)
+		if err != nil {
+			return nil, &domain.RepositoryError{Op: field.op, Err: err}
+		}
+		*field.target = cipher
+	}
+
+	return &ciphers, nil
+}
+
+// getExecutor returns either a transaction or the database connection
+func (r *IncidentReportRepository) getExecutor(ctx context.Context) executor {
+	if tx := ctx.Value("tx"); tx != nil {
+		return tx.(*sql.Tx)
+	}
+	return r.db.DB()
+}
+
+// scanIncidentReport scans an incident report from a database row
+func (r *IncidentReportRepository) scanIncidentReport(row scanner) (*domain.IncidentReport, error) {
+	var report domain.IncidentReport
+	var ciphers incidentReportCiphers
+	var category, severity, status string
+	var occurredAtStr, createdAtStr, updatedAtStr string
+	var submittedAtStr, reviewedAtStr, approvedAtStr *string
+	var reviewedBy, approvedBy *string
+
+	err := row.Scan(
+		&report.ID,
+		&occurredAtStr,
+		&ciphers.location,
+		&category,
+		&severity,
+		&ciphers.description,
+		&ciphers.response,
+		&ciphers.prevention,
+		&status,
+		&report.ReportedBy,
+		&submittedAtStr,
+		&reviewedBy,
+		&reviewedAtStr,
+		&ciphers.reviewComment,
+		&approvedBy,
+		&approvedAtStr,
+		&createdAtStr,
+		&updatedAtStr,
+	)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrNotFound
+		}
+		return nil, &domain.RepositoryError{Op: "scan incident report", Err: err}
+	}
+
+	report.Category = domain.IncidentCategory(category)
+	report.Severity = domain.IncidentSeverity(severity)
+	report.Status = domain.IncidentStatus(status)
+	report.ReviewedBy = reviewedBy
+	report.ApprovedBy = approvedBy
+
+	times := []struct {
+		op     string
+		value  string
+		target *time.Time
+	}{
+		{"parse occurred_at", occurredAtStr, &report.OccurredAt},
+		{"parse created_at", createdAtStr, &report.CreatedAt},
+		{"parse updated_at", updatedAtStr, &report.UpdatedAt},
+	}
+
+	for _, field := range times {
+		*field.target, err = time.Parse(time.RFC3339, field.value)
+		if err != nil {
+			return nil, &domain.RepositoryError{Op: field.op, Err: err}
+		}
+	}
+
+	optionalTimes := []struct {
+		op     string
+		value  *string
+		target **time.Time
+	}{
+		{"parse submitted_at", submittedAtStr, &report.SubmittedAt},
+		{"parse reviewed_at", reviewedAtStr, &report.ReviewedAt},
+		{"parse approved_at", approvedAtStr, &report.ApprovedAt},
+	}
+
+	for _, field := range optionalTimes {
+		if field.value == nil {
+			continue
+		}
+		parsed, err := time.Parse(time.RFC3339, *field.value)
+		if err != nil {
+			return nil, &domain.RepositoryError{Op: field.op, Err: err}
+		}
+		*field.target = &parsed
+	}
+
+	// Decrypt fields
+	fields := []struct {
+		op     string
+		cipher []byte
+		target *string
+	}{
+		{"decrypt location", ciphers.location, &report.Location},
+		{"decrypt description", ciphers.description, &report.Description},
+		{"decrypt response", ciphers.response, &report.Response},
+		{"decrypt prevention", ciphers.prevention, &report.Prevention},
+		{"decrypt review comment", ciphers.reviewComment, &report.ReviewComment},
+	}
+
+	for _, field := range fields {
+		*field.target, err = r.cipher.Decrypt(field.cipher)
+		if err != nil {
+			return nil, &domain.RepositoryError{Op: field.op, Err: err}
+		}
+	}
+
+	return &report, nil
+}
@@ -0,0 +1,148 @@
+package db
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"shien-system/internal/domain"
+)
+
+func TestIncidentReportRepository_CreateUpdateList(t *testing.T) {
+	db := setupTestDatabase(t)
+	defer db.Close()
+
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Second)
+
+	staffRepo := NewStaffRepository(db)
+	for _, staff := range []*domain.Staff{
+		{ID: "incident-staff-001", Name: "報告職員", Role: domain.RoleStaff, CreatedAt: now, UpdatedAt: now},
+		{ID: "incident-admin-001", Name: "管理者", Role: domain.RoleAdmin, CreatedAt: now, UpdatedAt: now},
+	} {
+		if err := staffRepo.Create(ctx, staff); err != nil {
+			t.Fatalf("Create staff error = %v", err)
+		}
+	}
+
+	recipientRepo, err := NewRecipientRepository(db)
+	if err != nil {
+		t.Fatalf("NewRecipientRepository() error = %v", err)
+	}
+	for _, id := range []domain.ID{"incident-recipient-001", "incident-recipient-002"} {
+		recipient := &domain.Recipient{
+			ID:        id,
+			Name:      "事故テスト利用者",
+			Sex:       domain.SexFemale,
+			BirthDate: time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC),
+			CreatedAt: now,
+			UpdatedAt: now,
+		}
+		if err := recipientRepo.Create(ctx, recipient); err != nil {
+			t.Fatalf("Create recipient error = %v", err)
+		}
+	}
+
+	incidentRepo, err := NewIncidentReportRepository(db)
+	if err != nil {
+		t.Fatalf("NewIncidentReportRepository() error = %v", err)
+	}
+
+	report := &domain.IncidentReport{
+		ID:           "incident-001",
+		OccurredAt:   time.Date(2025, 4, 10, 14, 30, 0, 0, time.UTC),
+		Location:     "作業室",
+		RecipientIDs: []domain.ID{"incident-recipient-001", "incident-recipient-002"},
+		StaffIDs:     []domain.ID{"incident-staff-001"},
+		Category:     domain.IncidentCategoryFall,
+		Severity:     domain.IncidentSeverityNearMiss,
+		Description:  "椅子から立ち上がる際にふらついた",
+		Response:     "職員が支えて転倒を防いだ",
+		Prevention:   "立ち上がり時の見守りを徹底する",
+		Status:       domain.IncidentStatusDraft,
+		ReportedBy:   "incident-staff-001",
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+
+	if err := incidentRepo.Create(ctx, report); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	retrieved, err := incidentRepo.GetByID(ctx, report.ID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if retrieved.Description != report.Description || retrieved.Location != report.Location {
+		t.Errorf("GetByID() = %+v", retrieved)
+	}
+	if len(retrieved.RecipientIDs) != 2 || len(retrieved.StaffIDs) != 1 {
+		t.Errorf("participants = %v / %v", retrieved.RecipientIDs, retrieved.StaffIDs)
+	}
+	if retrieved.SubmittedAt != nil || retrieved.ReviewedBy != nil {
+		t.Error("workflow fields should be empty for a draft")
+	}
+
+	// Free text must not be stored in plain text
+	var descriptionCipher []byte
+	err = db.DB().QueryRowContext(ctx, `SELECT description_cipher FROM incident_reports WHERE id = ?`, report.ID).Scan(&descriptionCipher)
+	if err != nil {
+		t.Fatalf("failed to read cipher: %v", err)
+	}
+	if bytes.Contains(descriptionCipher, []byte(report.Description)) {
+		t.Error("description stored in plain text")
+	}
+
+	reviewer := domain.ID("incident-admin-001")
+	submittedAt := now.Add(time.Hour)
+	retrieved.Status = domain.IncidentStatusReviewed
+	retrieved.SubmittedAt = &submittedAt
+	retrieved.ReviewedBy = &reviewer
+	retrieved.ReviewedAt = &submittedAt
+	retrieved.ReviewComment = "確認しました"
+	retrieved.RecipientIDs = []domain.ID{"incident-recipient-002"}
+	retrieved.UpdatedAt = submittedAt
+
+	if err := incidentRepo.Update(ctx, retrieved); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	updated, err := incidentRepo.GetByID(ctx, report.ID)
+	if err != nil {
+		t.Fatalf("GetByID() after update error = %v", err)
+	}
+	if updated.Status != domain.IncidentStatusReviewed || updated.ReviewedBy == nil || *updated.ReviewedBy != reviewer {
+		t.Errorf("workflow fields not updated: %+v", updated)
+	}
+	if updated.ReviewComment != "確認しました" {
+		t.Errorf("ReviewComment = %q", updated.ReviewComment)
+	}
+	if len(updated.RecipientIDs) != 1 || updated.RecipientIDs[0] != "incident-recipient-002" {
+		t.Errorf("RecipientIDs = %v, want [incident-recipient-002]", updated.RecipientIDs)
+	}
+
+	// List filters
+	from := time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)
+	reports, err := incidentRepo.List(ctx, domain.IncidentFilter{OccurredFrom: &from, OccurredTo: &to}, 10, 0)
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(reports) != 1 || len(reports[0].StaffIDs) != 1 {
+		t.Errorf("List() in April = %d reports", len(reports))
+	}
+
+	otherRecipient := domain.ID("incident-recipient-001")
+	reports, err = incidentRepo.List(ctx, domain.IncidentFilter{RecipientID: &otherRecipient}, 10, 0)
+	if err != nil {
+		t.Fatalf("List() by recipient error = %v", err)
+	}
+	if len(reports) != 0 {
+		t.Errorf("List() by removed recipient = %d reports, want 0", len(reports))
+	}
+
+	if err := incidentRepo.Update(ctx, &domain.IncidentReport{ID: "missing", Category: domain.IncidentCategoryOther, Severity: domain.IncidentSeverityMinor, Status: domain.IncidentStatusDraft}); err != domain.ErrNotFound {
+		t.Errorf("Update() missing error = %v, want ErrNotFound", err)
+	}
+}
@@ -40,6 +40,15 @@ func TestDatabase_RunActualMigrations(t *testing.T) {
 		"staff_assignments",
 		"consents",
 		"audit_logs",
+		"enrollment_periods",
+		"emergency_contacts",
+		"medical_records",
+		"incident_reports",
+		"incident_recipients",
+		"incident_staff",
+		"job_states",
+		"notifications",
+		"notification_receipts",
 		"migrations", // Migration tracking table
 	}
 
@@ -63,6 +72,8 @@ func TestDatabase_RunActualMigrations(t *testing.T) {
 		"idx_consents_recipient",
 		"idx_audit_actor",
 		"idx_audit_at",
+		"idx_enrollment_periods_recipient",
+		"idx_emergency_contacts_recipient",
 	}
 
 	for _, index := range expectedIndexes {
@@ -0,0 +1,477 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"shien-system/internal/adapter/crypto"
+	"shien-system/internal/domain"
+	"shien-system/internal/usecase"
+)
+
+// integrityCheckMaxErrors caps the messages returned by PRAGMA integrity_check
+const integrityCheckMaxErrors = 100
+
+// orphanChecks find rows whose owner is gone. Foreign keys prevent most of
+// these, but not for rows written while enforcement was off or for loose
+// references such as notification targets.
+var orphanChecks = []struct {
+	check string
+	table string
+	query string
+}{
+	{"削除された職員への担当割当", "staff_assignments",
+		`SELECT COUNT(*) FROM staff_assignments a WHERE NOT EXISTS (SELECT 1 FROM staff s WHERE s.id = a.staff_id)`},
+	{"削除された利用者への担当割当", "staff_assignments",
+		`SELECT COUNT(*) FROM staff_assignments a WHERE NOT EXISTS (SELECT 1 FROM recipients r WHERE r.id = a.recipient_id)`},
+	{"削除された利用者の受給者証", "benefit_certificates",
+		`SELECT COUNT(*) FROM benefit_certificates c WHERE NOT EXISTS (SELECT 1 FROM recipients r WHERE r.id = c.recipient_id)`},
+	{"削除された利用者の同意記録", "consents",
+		`SELECT COUNT(*) FROM consents c WHERE NOT EXISTS (SELECT 1 FROM recipients r WHERE r.id = c.recipient_id)`},
+	{"削除された利用者の入退所期間", "enrollment_periods",
+		`SELECT COUNT(*) FROM enrollment_periods e WHERE NOT EXISTS (SELECT 1 FROM recipients r WHERE r.id = e.recipient_id)`},
+	{"削除された利用者の緊急連絡先", "emergency_contacts",
+		`SELECT COUNT(*) FROM emergency_contacts e WHERE NOT EXISTS (SELECT 1 FROM recipients r WHERE r.id = e.recipient_id)`},
+	{"削除された利用者の医療情報", "medical_records",
+		`SELECT COUNT(*) FROM medical_records m WHERE NOT EXISTS (SELECT 1 FROM recipients r WHERE r.id = m.recipient_id)`},
+	{"削除された職員のセッション", "sessions",
+		`SELECT COUNT(*) FROM sessions x WHERE is_active = 1 AND NOT EXISTS (SELECT 1 FROM staff s WHERE s.id = x.user_id)`},
+	{"削除された職員の通知既読情報", "notification_receipts",
+		`SELECT COUNT(*) FROM notification_receipts n WHERE NOT EXISTS (SELECT 1 FROM staff s WHERE s.id = n.staff_id)`},
+	{"削除された利用者に関する未解決の通知", "notifications",
+		`SELECT COUNT(*) FROM notifications n WHERE n.target_type = 'recipient' AND n.resolved_at IS NULL
+		 AND NOT EXISTS (SELECT 1 FROM recipients r WHERE r.id = n.target_id)`},
+	{"削除された受給者証に関する未解決の通知", "notifications",
+		`SELECT COUNT(*) FROM notifications n WHERE n.target_type = 'certificate' AND n.resolved_at IS NULL
+		 AND NOT EXISTS (SELECT 1 FROM benefit_certificates c WHERE c.id = n.target_id)`},
+}
+
+// IntegrityChecker implements usecase.IntegrityInspector for the SQLite database
+type IntegrityChecker struct {
+	db     *Database
+	cipher *crypto.FieldCipher
+}
+
+// NewIntegrityChecker creates a checker that verifies encrypted columns with cipher
+func NewIntegrityChecker(db *Database, cipher *crypto.FieldCipher) *IntegrityChecker {
+	return &IntegrityChecker{db: db, cipher: cipher}
+}
+
+// encryptedTable is a table with at least one *_cipher column
+type encryptedTable struct {
+	name      string
+	keyColumn string // Single-column primary key, empty when there is none
+	columns   []string
+}
+
+// foreignKeyRef is a single-column foreign key from child to parent
+type foreignKeyRef struct {
+	parent   string
+	child    string
+	from     string
+	to       string
+	onDelete string
+}
+
+// IntegrityCheck runs PRAGMA integrity_check
+func (c *IntegrityChecker) IntegrityCheck(ctx context.Context) ([]string, error) {
+	rows, err := c.db.DB().QueryContext(ctx, fmt.Sprintf("PRAGMA integrity_check(%d)", integrityCheckMaxErrors))
+	if err != nil {
+		return nil, &domain.RepositoryError{Op: "integrity check", Err: err}
+	}
+	defer rows.Close()
+
+	var problems []string
+	for rows.Next() {
+		var message string
+		if err := rows.Scan(&message); err != nil {
+			return nil, &domain.RepositoryError{Op: "scan integrity check", Err: err}
+		}
+		if message != "ok" {
+			problems = append(problems, message)
+		}
+	}
+
+	return problems, rows.Err()
+}
+
+// ForeignKeyCheck runs PRAGMA foreign_key_check
+func (c *IntegrityChecker) ForeignKeyCheck(ctx context.Context) ([]usecase.ForeignKeyViolation, error) {
+	rows, err := c.db.DB().QueryContext(ctx, "PRAGMA foreign_key_check")
+	if err != nil {
+		return nil, &domain.RepositoryError{Op: "foreign key check", Err: err}
+	}
+	defer rows.Close()
+
+	var violations []usecase.ForeignKeyViolation
+	for rows.Next() {
+		var table, parent string
+		var rowID sql.NullInt64
+		var fkID int
+		if err := rows.Scan(&table, &rowID, &parent, &fkID); err != nil {
+			return nil, &domain.RepositoryError{Op: "scan foreign key check", Err: err}
+		}
+		violations = append(violations, usecase.ForeignKeyViolation{Table: table, RowID: rowID.Int64, Parent: parent})
+	}
+
+	return violations, rows.Err()
+}
+
+// FindUndecryptableRows decrypts every *_cipher value with the current key
+func (c *IntegrityChecker) FindUndecryptableRows(ctx context.Context) ([]usecase.UndecryptableRow, int, error) {
+	tables, err := c.encryptedTables(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var found []usecase.UndecryptableRow
+	checked := 0
+	for _, table := range tables {
+		keyExpr := "''"
+		if table.keyColumn != "" {
+			keyExpr = "CAST(" + quoteIdent(table.keyColumn) + " AS TEXT)"
+		}
+		quoted := make([]string, len(table.columns))
+		for i, column := range table.columns {
+			quoted[i] = quoteIdent(column)
+		}
+		query := fmt.Sprintf("SELECT rowid, %s, %s FROM %s", keyExpr, strings.Join(quoted, ", "), quoteIdent(table.name))
+
+		tableRows, tableChecked, err := c.checkTable(ctx, table, query)
+		if err != nil {
+			return nil, 0, err
+		}
+		found = append(found, tableRows...)
+		checked += tableChecked
+	}
+
+	return found, checked, nil
+}
+
+func (c *IntegrityChecker) checkTable(ctx context.Context, table encryptedTable, query string) ([]usecase.UndecryptableRow, int, error) {
+	rows, err := c.db.DB().QueryContext(ctx, query)
+	if err != nil {
+		return nil, 0, &domain.RepositoryError{Op: "read " + table.name, Err: err}
+	}
+	defer rows.Close()
+
+	var found []usecase.UndecryptableRow
+	checked := 0
+	for rows.Next() {
+		var rowID int64
+		var key sql.NullString
+		values := make([][]byte, len(table.columns))
+		dest := []interface{}{&rowID, &key}
+		for i := range values {
+			dest = append(dest, &values[i])
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return nil, 0, &domain.RepositoryError{Op: "scan " + table.name, Err: err}
+		}
+
+		var failed []string
+		for i, value := range values {
+			if len(value) == 0 {
+				continue
+			}
+			checked++
+			plaintext, err := c.cipher.DecryptSecure(value)
+			if err != nil {
+				failed = append(failed, table.columns[i])
+				continue
+			}
+			plaintext.Clear()
+		}
+
+		if len(failed) > 0 {
+			found = append(found, usecase.UndecryptableRow{
+				Table:   table.name,
+				RowID:   rowID,
+				Key:     key.String,
+				Columns: failed,
+			})
+		}
+	}
+
+	return found, checked, rows.Err()
+}
+
+// FindOrphanedRows runs the orphan checks and returns those that found rows
+func (c *IntegrityChecker) FindOrphanedRows(ctx context.Context) ([]usecase.OrphanedRows, error) {
+	var orphans []usecase.OrphanedRows
+	for _, check := range orphanChecks {
+		var count int
+		if err := c.db.DB().QueryRowContext(ctx, check.query).Scan(&count); err != nil {
+			return nil, &domain.RepositoryError{Op: "orphan check " + check.table, Err: err}
+		}
+		if count > 0 {
+			orphans = append(orphans, usecase.OrphanedRows{Check: check.check, Table: check.table, Count: count})
+		}
+	}
+
+	return orphans, nil
+}
+
+// QuarantineRows copies rows into quarantined_rows and deletes them in one
+// transaction. Rows that would be removed by ON DELETE CASCADE are
+// quarantined with them; any other reference makes the whole move fail.
+func (c *IntegrityChecker) QuarantineRows(ctx context.Context, rows []usecase.UndecryptableRow, reason string, actorID domain.ID) (int, error) {
+	moved := 0
+	err := c.db.WithTransaction(ctx, func(ctx context.Context) error {
+		tx := ctx.Value("tx").(*sql.Tx)
+
+		refs, err := c.foreignKeyRefs(ctx, tx)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now().UTC().Format(time.RFC3339)
+		for _, row := range rows {
+			count, err := c.quarantineRow(ctx, tx, refs, row.Table, row.RowID, "", reason, actorID, now)
+			if err != nil {
+				return err
+			}
+			moved += count
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return moved, nil
+}
+
+// quarantineRow moves one row and its cascading dependents, returning the number moved
+func (c *IntegrityChecker) quarantineRow(ctx context.Context, tx *sql.Tx, refs map[string][]foreignKeyRef,
+	table string, rowID int64, parentID, reason string, actorID domain.ID, now string) (int, error) {
+
+	snapshot, err := snapshotRow(ctx, tx, table, rowID)
+	if err != nil {
+		return 0, err
+	}
+	if snapshot == nil {
+		return 0, nil // Already moved as a dependent of another row
+	}
+
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return 0, fmt.Errorf("failed to encode %s row %d: %w", table, rowID, err)
+	}
+
+	id := uuid.New().String()
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO quarantined_rows (id, source_table, source_rowid, row_data, reason, parent_id, quarantined_by, quarantined_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		id, table, rowID, string(data), reason, nullIfEmpty(parentID), string(actorID), now)
+	if err != nil {
+		return 0, &domain.RepositoryError{Op: "quarantine " + table, Err: err}
+	}
+
+	moved := 1
+	for _, ref := range refs[table] {
+		if ref.onDelete != "CASCADE" {
+			continue
+		}
+		value, ok := snapshot[ref.to]
+		if !ok || value == nil {
+			continue
+		}
+
+		childIDs, err := queryRowIDs(ctx, tx,
+			fmt.Sprintf("SELECT rowid FROM %s WHERE %s = ?", quoteIdent(ref.child), quoteIdent(ref.from)), value)
+		if err != nil {
+			return 0, err
+		}
+		for _, childID := range childIDs {
+			count, err := c.quarantineRow(ctx, tx, refs, ref.child, childID, id, reason, actorID, now)
+			if err != nil {
+				return 0, err
+			}
+			moved += count
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, fmt.Sprintf("DELETE FROM %s WHERE rowid = ?", quoteIdent(table)), rowID); err != nil {
+		return 0, &domain.RepositoryError{Op: fmt.Sprintf("remove %s row %d (still referenced?)", table, rowID), Err: err}
+	}
+
+	return moved, nil
+}
+
+// encryptedTables lists the tables with *_cipher columns
+func (c *IntegrityChecker) encryptedTables(ctx context.Context) ([]encryptedTable, error) {
+	names, err := userTables(ctx, c.db.DB())
+	if err != nil {
+		return nil, err
+	}
+
+	var tables []encryptedTable
+	for _, name := range names {
+		rows, err := c.db.DB().QueryContext(ctx, "SELECT name, pk FROM pragma_table_info(?) ORDER BY cid", name)
+		if err != nil {
+			return nil, &domain.RepositoryError{Op: "inspect " + name, Err: err}
+		}
+
+		table := encryptedTable{name: name}
+		var keyColumns []string
+		for rows.Next() {
+			var column string
+			var pk int
+			if err := rows.Scan(&column, &pk); err != nil {
+				rows.Close()
+				return nil, &domain.RepositoryError{Op: "scan columns of " + name, Err: err}
+			}
+			if pk > 0 {
+				keyColumns = append(keyColumns, column)
+			}
+			if strings.HasSuffix(column, "_cipher") {
+				table.columns = append(table.columns, column)
+			}
+		}
+		rows.Close()
+
+		if len(keyColumns) == 1 {
+			table.keyColumn = keyColumns[0]
+		}
+		if len(table.columns) > 0 {
+			tables = append(tables, table)
+		}
+	}
+
+	return tables, nil
+}
+
+// foreignKeyRefs maps each parent table to the single-column foreign keys that reference it
+func (c *IntegrityChecker) foreignKeyRefs(ctx context.Context, tx *sql.Tx) (map[string][]foreignKeyRef, error) {
+	names, err := userTables(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	refs := make(map[string][]foreignKeyRef)
+	for _, child := range names {
+		rows, err := tx.QueryContext(ctx,
+			`SELECT id, "table", "from", COALESCE("to", ''), on_delete FROM pragma_foreign_key_list(?)`, child)
+		if err != nil {
+			return nil, &domain.RepositoryError{Op: "inspect foreign keys of " + child, Err: err}
+		}
+
+		columnsPerKey := make(map[int]int)
+		var keyIDs []int
+		var found []foreignKeyRef
+		for rows.Next() {
+			var id int
+			ref := foreignKeyRef{child: child}
+			if err := rows.Scan(&id, &ref.parent, &ref.from, &ref.to, &ref.onDelete); err != nil {
+				rows.Close()
+				return nil, &domain.RepositoryError{Op: "scan foreign keys of " + child, Err: err}
+			}
+			columnsPerKey[id]++
+			keyIDs = append(keyIDs, id)
+			found = append(found, ref)
+		}
+		rows.Close()
+
+		for i, ref := range found {
+			if columnsPerKey[keyIDs[i]] != 1 {
+				continue // Composite keys are not used by the schema
+			}
+			if ref.to == "" {
+				ref.to = "id"
+			}
+			refs[ref.parent] = append(refs[ref.parent], ref)
+		}
+	}
+
+	return refs, nil
+}
+
+// userTables lists the application tables
+func userTables(ctx context.Context, exec executor) ([]string, error) {
+	rows, err := exec.QueryContext(ctx,
+		`SELECT name FROM sqlite_master WHERE type = 'table' AND name NOT LIKE 'sqlite_%' ORDER BY name`)
+	if err != nil {
+		return nil, &domain.RepositoryError{Op: "list tables", Err: err}
+	}
+	defer rows.Close()
+
+	var names []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, &domain.RepositoryError{Op: "scan table name", Err: err}
+		}
+		names = append(names, name)
+	}
+
+	return names, rows.Err()
+}
+
+// snapshotRow reads a row as column → value, or nil when it no longer exists
+func snapshotRow(ctx context.Context, tx *sql.Tx, table string, rowID int64) (map[string]interface{}, error) {
+	rows, err := tx.QueryContext(ctx, fmt.Sprintf("SELECT * FROM %s WHERE rowid = ?", quoteIdent(table)), rowID)
+	if err != nil {
+		return nil, &domain.RepositoryError{Op: "read " + table, Err: err}
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		return nil, rows.Err()
+	}
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, &domain.RepositoryError{Op: "read columns of " + table, Err: err}
+	}
+	values := make([]interface{}, len(columns))
+	dest := make([]interface{}, len(columns))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+	if err := rows.Scan(dest...); err != nil {
+		return nil, &domain.RepositoryError{Op: "scan " + table, Err: err}
+	}
+
+	snapshot := make(map[string]interface{}, len(columns))
+	for i, column := range columns {
+		snapshot[column] = values[i]
+	}
+	return snapshot, nil
+}
+
+func queryRowIDs(ctx context.Context, tx *sql.Tx, query string, args ...interface{}) ([]int64, error) {
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, &domain.RepositoryError{Op: "find dependent rows", Err: err}
+	}
+	defer rows.Close()
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, &domain.RepositoryError{Op: "scan dependent rows", Err: err}
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
+
+// quoteIdent quotes a table or column name read from the schema
+func quoteIdent(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+func nullIfEmpty(value string) interface{} {
+	if value == "" {
+		return nil
+	}
+	return value
+}
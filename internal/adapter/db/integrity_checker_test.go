@@ -0,0 +1,143 @@
+package db
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"testing"
+
+	"shien-system/internal/adapter/crypto"
+)
+
+func TestIntegrityChecker(t *testing.T) {
+	database, err := NewDatabase(Config{Path: filepath.Join(t.TempDir(), "test.db")})
+	if err != nil {
+		t.Fatalf("failed to create database: %v", err)
+	}
+	defer database.Close()
+
+	ctx := context.Background()
+	if err := database.RunMigrations(ctx); err != nil {
+		t.Fatalf("RunMigrations() error = %v", err)
+	}
+
+	current, err := crypto.NewFieldCipherWithKey(bytes.Repeat([]byte{1}, 32))
+	if err != nil {
+		t.Fatal(err)
+	}
+	lost, err := crypto.NewFieldCipherWithKey(bytes.Repeat([]byte{2}, 32))
+	if err != nil {
+		t.Fatal(err)
+	}
+	encrypt := func(c *crypto.FieldCipher, value string) []byte {
+		ciphertext, err := c.Encrypt(value)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return ciphertext
+	}
+
+	now := "2026-10-18T09:00:00Z"
+	exec := func(query string, args ...interface{}) {
+		t.Helper()
+		if _, err := database.DB().ExecContext(ctx, query, args...); err != nil {
+			t.Fatalf("exec %q: %v", query, err)
+		}
+	}
+	insertRecipient := func(id string, nameCipher *crypto.FieldCipher) {
+		exec(`INSERT INTO recipients (id, name_cipher, sex_cipher, birth_date_cipher, has_disability_id_cipher,
+			public_assistance_cipher, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+			id, encrypt(nameCipher, "山田太郎"), encrypt(current, "male"), encrypt(current, "1980-01-01T00:00:00Z"),
+			encrypt(current, "true"), encrypt(current, "false"), now, now)
+	}
+
+	exec(`INSERT INTO staff (id, name, role, password_hash, created_at, updated_at) VALUES ('s-1', '職員', 'staff', 'x', ?, ?)`, now, now)
+	insertRecipient("r-ok", current)
+	insertRecipient("r-lost", lost)
+	exec(`INSERT INTO benefit_certificates (id, recipient_id, start_date, end_date, issuer_cipher, created_at, updated_at)
+		VALUES ('c-1', 'r-lost', '2026-04-01', '2027-03-31', ?, ?, ?)`, encrypt(current, "横浜市"), now, now)
+	exec(`INSERT INTO emergency_contacts (id, recipient_id, priority, name_cipher, relationship_cipher, created_at, updated_at)
+		VALUES ('e-1', 'r-lost', 1, ?, ?, ?, ?)`, encrypt(current, "山田花子"), encrypt(current, "母"), now, now)
+	exec(`INSERT INTO staff_assignments (id, recipient_id, staff_id, assigned_at) VALUES ('a-1', 'r-lost', 's-1', ?)`, now)
+
+	// An assignment to staff that no longer exists, written with enforcement off
+	conn, err := database.DB().Conn(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, stmt := range []string{
+		`PRAGMA foreign_keys = OFF`,
+		`INSERT INTO staff_assignments (id, recipient_id, staff_id, assigned_at) VALUES ('a-2', 'r-ok', 's-deleted', '` + now + `')`,
+		`PRAGMA foreign_keys = ON`,
+	} {
+		if _, err := conn.ExecContext(ctx, stmt); err != nil {
+			t.Fatalf("exec %q: %v", stmt, err)
+		}
+	}
+	conn.Close()
+
+	checker := NewIntegrityChecker(database, current)
+
+	problems, err := checker.IntegrityCheck(ctx)
+	if err != nil || len(problems) != 0 {
+		t.Errorf("IntegrityCheck() = %v, %v; want no problems", problems, err)
+	}
+
+	violations, err := checker.ForeignKeyCheck(ctx)
+	if err != nil {
+		t.Fatalf("ForeignKeyCheck() error = %v", err)
+	}
+	if len(violations) != 1 || violations[0].Table != "staff_assignments" || violations[0].Parent != "staff" {
+		t.Errorf("ForeignKeyCheck() = %+v", violations)
+	}
+
+	orphans, err := checker.FindOrphanedRows(ctx)
+	if err != nil {
+		t.Fatalf("FindOrphanedRows() error = %v", err)
+	}
+	if len(orphans) != 1 || orphans[0].Table != "staff_assignments" || orphans[0].Count != 1 {
+		t.Errorf("FindOrphanedRows() = %+v", orphans)
+	}
+
+	rows, checked, err := checker.FindUndecryptableRows(ctx)
+	if err != nil {
+		t.Fatalf("FindUndecryptableRows() error = %v", err)
+	}
+	if checked != 13 {
+		t.Errorf("checked %d encrypted values, want 13", checked)
+	}
+	if len(rows) != 1 || rows[0].Table != "recipients" || rows[0].Key != "r-lost" ||
+		len(rows[0].Columns) != 1 || rows[0].Columns[0] != "name_cipher" {
+		t.Fatalf("FindUndecryptableRows() = %+v", rows)
+	}
+
+	// The recipient is moved with its certificate, contact and assignment
+	moved, err := checker.QuarantineRows(ctx, rows, "鍵の紛失", "admin-001")
+	if err != nil {
+		t.Fatalf("QuarantineRows() error = %v", err)
+	}
+	if moved != 4 {
+		t.Errorf("QuarantineRows() moved %d rows, want 4", moved)
+	}
+
+	var quarantined, dependents int
+	if err := database.DB().QueryRow(`SELECT COUNT(*), COUNT(parent_id) FROM quarantined_rows`).Scan(&quarantined, &dependents); err != nil {
+		t.Fatal(err)
+	}
+	if quarantined != 4 || dependents != 3 {
+		t.Errorf("quarantined_rows has %d rows, %d dependents", quarantined, dependents)
+	}
+
+	var remaining int
+	if err := database.DB().QueryRow(`SELECT COUNT(*) FROM benefit_certificates`).Scan(&remaining); err != nil {
+		t.Fatal(err)
+	}
+	if remaining != 0 {
+		t.Errorf("%d certificates left behind", remaining)
+	}
+
+	rows, _, err = checker.FindUndecryptableRows(ctx)
+	if err != nil || len(rows) != 0 {
+		t.Errorf("FindUndecryptableRows() after quarantine = %+v, %v", rows, err)
+	}
+}
@@ -0,0 +1,165 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"shien-system/internal/domain"
+)
+
+// JobStateRepository implements domain.JobStateRepository
+type JobStateRepository struct {
+	db *Database
+}
+
+// NewJobStateRepository creates a new job state repository
+func NewJobStateRepository(db *Database) *JobStateRepository {
+	return &JobStateRepository{
+		db: db,
+	}
+}
+
+// GetByName retrieves the state of a job
+func (r *JobStateRepository) GetByName(ctx context.Context, name string) (*domain.JobState, error) {
+	query := `
+		SELECT name, last_run_at, last_success_at, last_duration_ms, last_error,
+			   consecutive_failures, next_run_at, updated_at
+		FROM job_states
+		WHERE name = ?`
+
+	executor := r.getExecutor(ctx)
+	row := executor.QueryRowContext(ctx, query, name)
+
+	return r.scanJobState(row)
+}
+
+// Save creates the job state or replaces the existing one
+func (r *JobStateRepository) Save(ctx context.Context, state *domain.JobState) error {
+	query := `
+		INSERT INTO job_states (
+			name, last_run_at, last_success_at, last_duration_ms, last_error,
+			consecutive_failures, next_run_at, updated_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(name) DO UPDATE SET
+			last_run_at = excluded.last_run_at,
+			last_success_at = excluded.last_success_at,
+			last_duration_ms = excluded.last_duration_ms,
+			last_error = excluded.last_error,
+			consecutive_failures = excluded.consecutive_failures,
+			next_run_at = excluded.next_run_at,
+			updated_at = excluded.updated_at`
+
+	executor := r.getExecutor(ctx)
+	_, err := executor.ExecContext(ctx, query,
+		state.Name,
+		formatOptionalTime(state.LastRunAt),
+		formatOptionalTime(state.LastSuccessAt),
+		state.LastDuration.Milliseconds(),
+		state.LastError,
+		state.ConsecutiveFailures,
+		formatOptionalTime(state.NextRunAt),
+		state.UpdatedAt.Format(time.RFC3339),
+	)
+
+	if err != nil {
+		return &domain.RepositoryError{Op: "save job state", Err: err}
+	}
+
+	return nil
+}
+
+// List retrieves the states of all jobs ordered by name
+func (r *JobStateRepository) List(ctx context.Context) ([]*domain.JobState, error) {
+	query := `
+		SELECT name, last_run_at, last_success_at, last_duration_ms, last_error,
+			   consecutive_failures, next_run_at, updated_at
+		FROM job_states
+		ORDER BY name`
+
+	executor := r.getExecutor(ctx)
+	rows, err := executor.QueryContext(ctx, query)
+	if err != nil {
+		return nil, &domain.RepositoryError{Op: "list job states", Err: err}
+	}
+	defer rows.Close()
+
+	var states []*domain.JobState
+	for rows.Next() {
+		state, err := r.scanJobState(rows)
+		if err != nil {
+			return nil, err
+		}
+		states = append(states, state)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, &domain.RepositoryError{Op: "rows iteration", Err: err}
+	}
+
+	return states, nil
+}
+
+// getExecutor returns either a transaction or the database connection
+func (r *JobStateRepository) getExecutor(ctx context.Context) executor {
+	if tx := ctx.Value("tx"); tx != nil {
+		return tx.(*sql.Tx)
+	}
+	return r.db.DB()
+}
+
+// scanJobState scans a job state from a database row
+func (r *JobStateRepository) scanJobState(row scanner) (*domain.JobState, error) {
+	var state domain.JobState
+	var lastRunAtStr, lastSuccessAtStr, nextRunAtStr *string
+	var durationMs int64
+	var updatedAtStr string
+
+	err := row.Scan(
+		&state.Name,
+		&lastRunAtStr,
+		&lastSuccessAtStr,
+		&durationMs,
+		&state.LastError,
+		&state.ConsecutiveFailures,
+		&nextRunAtStr,
+		&updatedAtStr,
+	)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrNotFound
+		}
+		return nil, &domain.RepositoryError{Op: "scan job state", Err: err}
+	}
+
+	state.LastDuration = time.Duration(durationMs) * time.Millisecond
+
+	optionalTimes := []struct {
+		op     string
+		value  *string
+		target **time.Time
+	}{
+		{"parse last_run_at", lastRunAtStr, &state.LastRunAt},
+		{"parse last_success_at", lastSuccessAtStr, &state.LastSuccessAt},
+		{"parse next_run_at", nextRunAtStr, &state.NextRunAt},
+	}
+
+	for _, field := range optionalTimes {
+		if field.value == nil {
+			continue
+		}
+		parsed, err := time.Parse(time.RFC3339, *field.value)
+		if err != nil {
+			return nil, &domain.RepositoryError{Op: field.op, Err: err}
+		}
+		*field.target = &parsed
+	}
+
+	state.UpdatedAt, err = time.Parse(time.RFC3339, updatedAtStr)
+	if err != nil {
+		return nil, &domain.RepositoryError{Op: "parse updated_at", Err: err}
+	}
+
+	return &state, nil
+}
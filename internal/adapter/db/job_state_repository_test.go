@@ -0,0 +1,73 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"shien-system/internal/domain"
+)
+
+func TestJobStateRepository_SaveAndList(t *testing.T) {
+	db := setupTestDatabase(t)
+	defer db.Close()
+
+	ctx := context.Background()
+	repo := NewJobStateRepository(db)
+
+	if _, err := repo.GetByName(ctx, "session_cleanup"); err != domain.ErrNotFound {
+		t.Fatalf("GetByName() before save error = %v, want ErrNotFound", err)
+	}
+
+	now := time.Now().UTC().Truncate(time.Second)
+	next := now.Add(time.Hour)
+	state := &domain.JobState{
+		Name:                "session_cleanup",
+		LastRunAt:           &now,
+		LastDuration:        1500 * time.Millisecond,
+		LastError:           "database is locked",
+		ConsecutiveFailures: 2,
+		NextRunAt:           &next,
+		UpdatedAt:           now,
+	}
+
+	if err := repo.Save(ctx, state); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	retrieved, err := repo.GetByName(ctx, "session_cleanup")
+	if err != nil {
+		t.Fatalf("GetByName() error = %v", err)
+	}
+	if retrieved.LastDuration != state.LastDuration || retrieved.ConsecutiveFailures != 2 {
+		t.Errorf("GetByName() = %+v", retrieved)
+	}
+	if retrieved.LastSuccessAt != nil {
+		t.Error("LastSuccessAt should be nil")
+	}
+	if retrieved.NextRunAt == nil || !retrieved.NextRunAt.Equal(next) {
+		t.Errorf("NextRunAt = %v, want %v", retrieved.NextRunAt, next)
+	}
+
+	// Saving again replaces the state
+	state.LastSuccessAt = &now
+	state.LastError = ""
+	state.ConsecutiveFailures = 0
+	if err := repo.Save(ctx, state); err != nil {
+		t.Fatalf("Save() replace error = %v", err)
+	}
+	if err := repo.Save(ctx, &domain.JobState{Name: "lockout_cleanup", UpdatedAt: now}); err != nil {
+		t.Fatalf("Save() second job error = %v", err)
+	}
+
+	states, err := repo.List(ctx)
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(states) != 2 || states[0].Name != "lockout_cleanup" {
+		t.Fatalf("List() = %d states", len(states))
+	}
+	if states[1].LastError != "" || states[1].LastSuccessAt == nil {
+		t.Errorf("replaced state = %+v", states[1])
+	}
+}
@@ -155,6 +155,34 @@ func (r *LoginAttemptRepository) GetFailedAttempts(ctx context.Context, ipAddres
 	return attempts, nil
 }
 
+// GetRecentFailures retrieves the most recent failed login attempts across all IPs and usernames
+func (r *LoginAttemptRepository) GetRecentFailures(ctx context.Context, since time.Time, limit int) ([]*LoginAttempt, error) {
+	query := `
+		SELECT id, ip_address, username, success, attempted_at, user_agent
+		FROM login_attempts
+		WHERE success = FALSE AND attempted_at >= ?
+		ORDER BY attempted_at DESC
+		LIMIT ?
+	`
+
+	rows, err := r.getExecutor(ctx).QueryContext(ctx, query, since.Format(time.RFC3339), limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recent failed login attempts: %w", err)
+	}
+	defer rows.Close()
+
+	var attempts []*LoginAttempt
+	for rows.Next() {
+		attempt, err := r.scanLoginAttempt(rows)
+		if err != nil {
+			return nil, err
+		}
+		attempts = append(attempts, attempt)
+	}
+
+	return attempts, nil
+}
+
 // CountRecentFailures counts recent failed login attempts
 func (r *LoginAttemptRepository) CountRecentFailures(ctx context.Context, ipAddress, username string, since time.Time) (int, error) {
 	var query string
@@ -0,0 +1,16 @@
+package db
+
+import (
+	"os"
+	"testing"
+
+	"github.com/zalando/go-keyring"
+)
+
+// TestMain replaces the OS keyring with an in-memory one, so the repositories
+// that create their field cipher from the keyring also run on CI machines
+// without a keyring service
+func TestMain(m *testing.M) {
+	keyring.MockInit()
+	os.Exit(m.Run())
+}
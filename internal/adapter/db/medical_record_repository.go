@@ -0,0 +1,297 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"shien-system/internal/adapter/crypto"
+	"shien-system/internal/domain"
+)
+
+// MedicalRecordRepository implements domain.MedicalRecordRepository
+type MedicalRecordRepository struct {
+	db     *Database
+	cipher *crypto.FieldCipher
+}
+
+// NewMedicalRecordRepository creates a new medical record repository
+func NewMedicalRecordRepository(db *Database) (*MedicalRecordRepository, error) {
+	cipher, err := crypto.NewFieldCipher()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+
+	return &MedicalRecordRepository{
+		db:     db,
+		cipher: cipher,
+	}, nil
+}
+
+// GetByRecipientID retrieves the medical record of a recipient
+func (r *MedicalRecordRepository) GetByRecipientID(ctx context.Context, recipientID domain.ID) (*domain.MedicalRecord, error) {
+	query := `
+		SELECT recipient_id, medications_cipher, allergies_cipher, has_epilepsy, seizure_protocol_cipher,
+			   hospital_name_cipher, hospital_phone_cipher, doctor_name_cipher,
+			   insurance_type_cipher, insurer_number_cipher, insured_symbol_cipher, insured_number_cipher,
+			   insurance_valid_until, notes_cipher, updated_by, created_at, updated_at
+		FROM medical_records
+		WHERE recipient_id = ?`
+
+	executor := r.getExecutor(ctx)
+	row := executor.QueryRowContext(ctx, query, recipientID)
+
+	return r.scanMedicalRecord(row)
+}
+
+// Save creates the recipient's medical record or replaces the existing one.
+// The original created_at is kept when the record already exists.
+func (r *MedicalRecordRepository) Save(ctx context.Context, record *domain.MedicalRecord) error {
+	query := `
+		INSERT INTO medical_records (
+			recipient_id, medications_cipher, allergies_cipher, has_epilepsy, seizure_protocol_cipher,
+			hospital_name_cipher, hospital_phone_cipher, doctor_name_cipher,
+			insurance_type_cipher, insurer_number_cipher, insured_symbol_cipher, insured_number_cipher,
+			insurance_valid_until, notes_cipher, updated_by, created_at, updated_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(recipient_id) DO UPDATE SET
+			medications_cipher = excluded.medications_cipher,
+			allergies_cipher = excluded.allergies_cipher,
+			has_epilepsy = excluded.has_epilepsy,
+			seizure_protocol_cipher = excluded.seizure_protocol_cipher,
+			hospital_name_cipher = excluded.hospital_name_cipher,
+			hospital_phone_cipher = excluded.hospital_phone_cipher,
+			doctor_name_cipher = excluded.doctor_name_cipher,
+			insurance_type_cipher = excluded.insurance_type_cipher,
+			insurer_number_cipher = excluded.insurer_number_cipher,
+			insured_symbol_cipher = excluded.insured_symbol_cipher,
+			insured_number_cipher = excluded.insured_number_cipher,
+			insurance_valid_until = excluded.insurance_valid_until,
+			notes_cipher = excluded.notes_cipher,
+			updated_by = excluded.updated_by,
+			updated_at = excluded.updated_at`
+
+	ciphers, err := r.encryptFields(record)
+	if err != nil {
+		return err
+	}
+
+	var updatedBy *string
+	if record.UpdatedBy != "" {
+		updatedBy = &record.UpdatedBy
+	}
+
+	executor := r.getExecutor(ctx)
+	_, err = executor.ExecContext(ctx, query,
+		record.RecipientID,
+		ciphers.medications,
+		ciphers.allergies,
+		record.HasEpilepsy,
+		ciphers.seizureProtocol,
+		ciphers.hospitalName,
+		ciphers.hospitalPhone,
+		ciphers.doctorName,
+		ciphers.insuranceType,
+		ciphers.insurerNumber,
+		ciphers.insuredSymbol,
+		ciphers.insuredNumber,
+		formatOptionalTime(record.InsuranceValidUntil),
+		ciphers.notes,
+		updatedBy,
+		record.CreatedAt.Format(time.RFC3339),
+		record.UpdatedAt.Format(time.RFC3339),
+	)
+
+	if err != nil {
+		return &domain.RepositoryError{Op: "save medical record", Err: err}
+	}
+
+	return nil
+}
+
+// Delete deletes the medical record of a recipient
+func (r *MedicalRecordRepository) Delete(ctx context.Context, recipientID domain.ID) error {
+	query := `DELETE FROM medical_records WHERE recipient_id = ?`
+
+	executor := r.getExecutor(ctx)
+	result, err := executor.ExecContext(ctx, query, recipientID)
+	if err != nil {
+		return &domain.RepositoryError{Op: "delete medical record", Err: err}
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return &domain.RepositoryError{Op: "check rows affected", Err: err}
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
+// medicalRecordCiphers holds the encrypted columns of a medical record
+type medicalRecordCiphers struct {
+	medications, allergies, seizureProtocol                    []byte
+	hospitalName, hospitalPhone, doctorName                    []byte
+	insuranceType, insurerNumber, insuredSymbol, insuredNumber []byte
+	notes                                                      []byte
+}
+
+// encryptFields encrypts every field of a medical record; the medication and
+// allergy lists are stored as encrypted JSON
+func (r *MedicalRecordRepository) encryptFields(record *domain.MedicalRecord) (*medicalRecordCiphers, error) {
+	var ciphers medicalRecordCiphers
+
+	var medicationsJSON, allergiesJSON string
+	if len(record.Medications) > 0 {
+		data, err := json.Marshal(record.Medications)
+		if err != nil {
+			return nil, &domain.RepositoryError{Op: "marshal medications", Err: err}
+		}
+		medicationsJSON = string(data)
+	}
+	if len(record.Allergies) > 0 {
+		data, err := json.Marshal(record.Allergies)
+		if err != nil {
+			return nil, &domain.RepositoryError{Op: "marshal allergies", Err: err}
+		}
+		allergiesJSON = string(data)
+	}
+
+	fields := []struct {
+		op     string
+		value  string
+		target *[]byte
+	}{
+		{"encrypt medications", medicationsJSON, &ciphers.medications},
+		{"encrypt allergies", allergiesJSON, &ciphers.allergies},
+		{"encrypt seizure protocol", record.SeizureProtocol, &ciphers.seizureProtocol},
+		{"encrypt hospital name", record.HospitalName, &ciphers.hospitalName},
+		{"encrypt hospital phone", record.HospitalPhone, &ciphers.hospitalPhone},
+		{"encrypt doctor name", record.DoctorName, &ciphers.doctorName},
+		{"encrypt insurance type", record.InsuranceType, &ciphers.insuranceType},
+		{"encrypt insurer number", record.InsurerNumber, &ciphers.insurerNumber},
+		{"encrypt insured symbol", record.InsuredSymbol, &ciphers.insuredSymbol},
+		{"encrypt insured number", record.InsuredNumber, &ciphers.insuredNumber},
+		{"encrypt notes", record.Notes, &ciphers.notes},
+	}
+
+	for _, field := range fields {
+		cipher, err := r.cipher.Encrypt(field.value)
+		if err != nil {
+			return nil, &domain.RepositoryError{Op: field.op, Err: err}
+		}
+		*field.target = cipher
+	}
+
+	return &ciphers, nil
+}
+
+// getExecutor returns either a transaction or the database connection
+func (r *MedicalRecordRepository) getExecutor(ctx context.Context) executor {
+	if tx := ctx.Value("tx"); tx != nil {
+		return tx.(*sql.Tx)
+	}
+	return r.db.DB()
+}
+
+// scanMedicalRecord scans a medical record from a database row
+func (r *MedicalRecordRepository) scanMedicalRecord(row scanner) (*domain.MedicalRecord, error) {
+	var record domain.MedicalRecord
+	var ciphers medicalRecordCiphers
+	var insuranceValidUntilStr, updatedBy *string
+	var createdAtStr, updatedAtStr string
+
+	err := row.Scan(
+		&record.RecipientID,
+		&ciphers.medications,
+		&ciphers.allergies,
+		&record.HasEpilepsy,
+		&ciphers.seizureProtocol,
+		&ciphers.hospitalName,
+		&ciphers.hospitalPhone,
+		&ciphers.doctorName,
+		&ciphers.insuranceType,
+		&ciphers.insurerNumber,
+		&ciphers.insuredSymbol,
+		&ciphers.insuredNumber,
+		&insuranceValidUntilStr,
+		&ciphers.notes,
+		&updatedBy,
+		&createdAtStr,
+		&updatedAtStr,
+	)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrNotFound
+		}
+		return nil, &domain.RepositoryError{Op: "scan medical record", Err: err}
+	}
+
+	if insuranceValidUntilStr != nil {
+		validUntil, err := time.Parse(time.RFC3339, *insuranceValidUntilStr)
+		if err != nil {
+			return nil, &domain.RepositoryError{Op: "parse insurance_valid_until", Err: err}
+		}
+		record.InsuranceValidUntil = &validUntil
+	}
+
+	if updatedBy != nil {
+		record.UpdatedBy = *updatedBy
+	}
+
+	record.CreatedAt, err = time.Parse(time.RFC3339, createdAtStr)
+	if err != nil {
+		return nil, &domain.RepositoryError{Op: "parse created_at", Err: err}
+	}
+
+	record.UpdatedAt, err = time.Parse(time.RFC3339, updatedAtStr)
+	if err != nil {
+		return nil, &domain.RepositoryError{Op: "parse updated_at", Err: err}
+	}
+
+	// Decrypt fields
+	var medicationsJSON, allergiesJSON string
+	fields := []struct {
+		op     string
+		cipher []byte
+		target *string
+	}{
+		{"decrypt medications", ciphers.medications, &medicationsJSON},
+		{"decrypt allergies", ciphers.allergies, &allergiesJSON},
+		{"decrypt seizure protocol", ciphers.seizureProtocol, &record.SeizureProtocol},
+		{"decrypt hospital name", ciphers.hospitalName, &record.HospitalName},
+		{"decrypt hospital phone", ciphers.hospitalPhone, &record.HospitalPhone},
+		{"decrypt doctor name", ciphers.doctorName, &record.DoctorName},
+		{"decrypt insurance type", ciphers.insuranceType, &record.InsuranceType},
+		{"decrypt insurer number", ciphers.insurerNumber, &record.InsurerNumber},
+		{"decrypt insured symbol", ciphers.insuredSymbol, &record.InsuredSymbol},
+		{"decrypt insured number", ciphers.insuredNumber, &record.InsuredNumber},
+		{"decrypt notes", ciphers.notes, &record.Notes},
+	}
+
+	for _, field := range fields {
+		*field.target, err = r.cipher.Decrypt(field.cipher)
+		if err != nil {
+			return nil, &domain.RepositoryError{Op: field.op, Err: err}
+		}
+	}
+
+	if medicationsJSON != "" {
+		if err := json.Unmarshal([]byte(medicationsJSON), &record.Medications); err != nil {
+			return nil, &domain.RepositoryError{Op: "unmarshal medications", Err: err}
+		}
+	}
+	if allergiesJSON != "" {
+		if err := json.Unmarshal([]byte(allergiesJSON), &record.Allergies); err != nil {
+			return nil, &domain.RepositoryError{Op: "unmarshal allergies", Err: err}
+		}
+	}
+
+	return &record, nil
+}
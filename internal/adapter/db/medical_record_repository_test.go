@@ -0,0 +1,128 @@
+package db
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"shien-system/internal/domain"
+)
+
+func TestMedicalRecordRepository_SaveAndGet(t *testing.T) {
+	db := setupTestDatabase(t)
+	defer db.Close()
+
+	recipientRepo, err := NewRecipientRepository(db)
+	if err != nil {
+		t.Fatalf("NewRecipientRepository() error = %v", err)
+	}
+
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Second)
+
+	recipient := &domain.Recipient{
+		ID:        "medical-recipient-001",
+		Name:      "医療テスト太郎",
+		Sex:       domain.SexMale,
+		BirthDate: time.Date(1985, 5, 5, 0, 0, 0, 0, time.UTC),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := recipientRepo.Create(ctx, recipient); err != nil {
+		t.Fatalf("Create recipient error = %v", err)
+	}
+
+	medicalRepo, err := NewMedicalRecordRepository(db)
+	if err != nil {
+		t.Fatalf("NewMedicalRecordRepository() error = %v", err)
+	}
+
+	if _, err := medicalRepo.GetByRecipientID(ctx, recipient.ID); err != domain.ErrNotFound {
+		t.Fatalf("GetByRecipientID() before save error = %v, want ErrNotFound", err)
+	}
+
+	validUntil := time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC)
+	record := &domain.MedicalRecord{
+		RecipientID: recipient.ID,
+		Medications: []domain.Medication{
+			{Name: "デパケンR", Dose: "200mg", Timing: "朝夕食後"},
+		},
+		Allergies: []domain.Allergy{
+			{Allergen: "そば", Reaction: "アナフィラキシー", Critical: true},
+		},
+		HasEpilepsy:         true,
+		SeizureProtocol:     "5分以上続く場合は救急要請",
+		HospitalName:        "支援中央病院",
+		HospitalPhone:       "03-1111-2222",
+		DoctorName:          "山田医師",
+		InsuranceType:       "国民健康保険",
+		InsurerNumber:       "138123",
+		InsuredNumber:       "1234567",
+		InsuranceValidUntil: &validUntil,
+		CreatedAt:           now,
+		UpdatedAt:           now,
+	}
+
+	if err := medicalRepo.Save(ctx, record); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	retrieved, err := medicalRepo.GetByRecipientID(ctx, recipient.ID)
+	if err != nil {
+		t.Fatalf("GetByRecipientID() error = %v", err)
+	}
+
+	if len(retrieved.Medications) != 1 || retrieved.Medications[0].Timing != "朝夕食後" {
+		t.Errorf("Medications = %+v", retrieved.Medications)
+	}
+	if len(retrieved.CriticalAllergies()) != 1 {
+		t.Errorf("Allergies = %+v, want one critical allergy", retrieved.Allergies)
+	}
+	if !retrieved.HasEpilepsy || retrieved.SeizureProtocol != record.SeizureProtocol {
+		t.Errorf("seizure info = %v/%q", retrieved.HasEpilepsy, retrieved.SeizureProtocol)
+	}
+	if retrieved.InsuranceValidUntil == nil || !retrieved.InsuranceValidUntil.Equal(validUntil) {
+		t.Errorf("InsuranceValidUntil = %v, want %v", retrieved.InsuranceValidUntil, validUntil)
+	}
+	if retrieved.InsuredSymbol != "" || retrieved.UpdatedBy != "" {
+		t.Errorf("empty fields should stay empty: symbol=%q updatedBy=%q", retrieved.InsuredSymbol, retrieved.UpdatedBy)
+	}
+
+	// Health information must not be stored in plain text
+	var allergiesCipher, insuredNumberCipher []byte
+	err = db.DB().QueryRowContext(ctx, `SELECT allergies_cipher, insured_number_cipher FROM medical_records WHERE recipient_id = ?`, recipient.ID).Scan(&allergiesCipher, &insuredNumberCipher)
+	if err != nil {
+		t.Fatalf("failed to read ciphers: %v", err)
+	}
+	if bytes.Contains(allergiesCipher, []byte("そば")) || string(insuredNumberCipher) == record.InsuredNumber {
+		t.Error("medical fields should be encrypted at rest")
+	}
+
+	// Saving again replaces the record but keeps the original creation time
+	record.Allergies = nil
+	record.InsuranceValidUntil = nil
+	record.CreatedAt = now.Add(time.Hour)
+	record.UpdatedAt = now.Add(time.Hour)
+	if err := medicalRepo.Save(ctx, record); err != nil {
+		t.Fatalf("Save() replace error = %v", err)
+	}
+
+	replaced, err := medicalRepo.GetByRecipientID(ctx, recipient.ID)
+	if err != nil {
+		t.Fatalf("GetByRecipientID() error = %v", err)
+	}
+	if len(replaced.Allergies) != 0 || replaced.InsuranceValidUntil != nil {
+		t.Errorf("Save() did not replace record: %+v", replaced)
+	}
+	if !replaced.CreatedAt.Equal(now) || !replaced.UpdatedAt.Equal(now.Add(time.Hour)) {
+		t.Errorf("timestamps = %v/%v, want created %v updated %v", replaced.CreatedAt, replaced.UpdatedAt, now, now.Add(time.Hour))
+	}
+
+	if err := medicalRepo.Delete(ctx, recipient.ID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if err := medicalRepo.Delete(ctx, recipient.ID); err != domain.ErrNotFound {
+		t.Errorf("Delete() of missing record error = %v, want ErrNotFound", err)
+	}
+}
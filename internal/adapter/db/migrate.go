@@ -0,0 +1,501 @@
+package db
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"shien-system/internal/domain"
+)
+
+// downSuffix marks the file that reverts the migration of the same version
+const downSuffix = ".down.sql"
+
+// ErrMigrationDrift is returned when migrations recorded in the database no
+// longer match the migration files. The application must not start on top of
+// a schema it does not know.
+var ErrMigrationDrift = errors.New("applied migrations do not match the migration files")
+
+// ErrNoDownMigration is returned when a rollback needs a migration that has no
+// down file. Restore the pre-migration backup instead.
+var ErrNoDownMigration = errors.New("migration has no down file")
+
+// MigrationDriftError lists the applied migrations that no longer match
+type MigrationDriftError struct {
+	// Modified migrations were changed after they were applied
+	Modified []string
+	// Missing migrations are recorded in the database but have no file,
+	// usually because an older build is opening a newer database
+	Missing []string
+}
+
+func (e *MigrationDriftError) Error() string {
+	var parts []string
+	if len(e.Modified) > 0 {
+		parts = append(parts, "modified: "+strings.Join(e.Modified, ", "))
+	}
+	if len(e.Missing) > 0 {
+		parts = append(parts, "unknown: "+strings.Join(e.Missing, ", "))
+	}
+	return fmt.Sprintf("%s (%s)", ErrMigrationDrift.Error(), strings.Join(parts, "; "))
+}
+
+func (e *MigrationDriftError) Unwrap() error {
+	return ErrMigrationDrift
+}
+
+// migrationFile is one migration version with its optional down file
+type migrationFile struct {
+	version  string
+	name     string
+	upFile   string
+	downFile string
+}
+
+// initMigrationTable creates the migration tracking table
+func (d *Database) initMigrationTable() error {
+	query := `
+		CREATE TABLE IF NOT EXISTS migrations (
+			version TEXT PRIMARY KEY,
+			name TEXT NOT NULL,
+			applied_at TEXT NOT NULL,
+			checksum TEXT NOT NULL DEFAULT ''
+		)`
+
+	_, err := d.db.Exec(query)
+	if err != nil {
+		return fmt.Errorf("failed to create migrations table: %w", err)
+	}
+
+	// Databases created before checksums were recorded lack the column
+	var count int
+	err = d.db.QueryRow(`SELECT COUNT(*) FROM pragma_table_info('migrations') WHERE name = 'checksum'`).Scan(&count)
+	if err != nil {
+		return fmt.Errorf("failed to inspect migrations table: %w", err)
+	}
+	if count == 0 {
+		if _, err := d.db.Exec(`ALTER TABLE migrations ADD COLUMN checksum TEXT NOT NULL DEFAULT ''`); err != nil {
+			return fmt.Errorf("failed to add checksum column: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// RunMigrations runs all pending migrations. It refuses to run when an applied
+// migration has changed, and backs up an existing database before changing it.
+func (d *Database) RunMigrations(ctx context.Context) error {
+	// Get all migration files
+	files, err := d.getMigrationFiles()
+	if err != nil {
+		return fmt.Errorf("failed to get migration files: %w", err)
+	}
+
+	// Get applied migrations
+	appliedMigrations, err := d.getAppliedMigrations(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get applied migrations: %w", err)
+	}
+
+	if err := d.verifyAppliedMigrations(ctx, files, appliedMigrations); err != nil {
+		return err
+	}
+
+	var pending []migrationFile
+	for _, file := range files {
+		if _, applied := appliedMigrations[file.version]; !applied {
+			pending = append(pending, file)
+		}
+	}
+	if len(pending) == 0 {
+		return nil
+	}
+
+	// A fresh database has nothing worth backing up
+	if len(appliedMigrations) > 0 {
+		if _, err := d.backup(ctx, "pre-migration"); err != nil {
+			return fmt.Errorf("pre-migration backup failed: %w", err)
+		}
+	}
+
+	// Run pending migrations
+	for _, file := range pending {
+		if err := d.runMigration(ctx, file); err != nil {
+			return fmt.Errorf("failed to run migration %s: %w", file.version, err)
+		}
+	}
+
+	return nil
+}
+
+// RollbackTo reverts every applied migration newer than version, newest first,
+// using the down files. Nothing is reverted unless all of them have a down
+// file. A backup is taken before the first change. Pass "0000" to revert all.
+func (d *Database) RollbackTo(ctx context.Context, version string) ([]string, error) {
+	files, err := d.getMigrationFiles()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get migration files: %w", err)
+	}
+
+	appliedMigrations, err := d.getAppliedMigrations(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get applied migrations: %w", err)
+	}
+
+	if err := d.verifyAppliedMigrations(ctx, files, appliedMigrations); err != nil {
+		return nil, err
+	}
+
+	var targets []migrationFile
+	for i := len(files) - 1; i >= 0; i-- {
+		file := files[i]
+		if _, applied := appliedMigrations[file.version]; !applied || file.version <= version {
+			continue
+		}
+		if file.downFile == "" {
+			return nil, fmt.Errorf("%w: %s_%s", ErrNoDownMigration, file.version, file.name)
+		}
+		targets = append(targets, file)
+	}
+	if len(targets) == 0 {
+		return nil, nil
+	}
+
+	if _, err := d.backup(ctx, "pre-rollback"); err != nil {
+		return nil, fmt.Errorf("pre-rollback backup failed: %w", err)
+	}
+
+	var reverted []string
+	for _, file := range targets {
+		if err := d.revertMigration(ctx, file); err != nil {
+			return reverted, fmt.Errorf("failed to revert migration %s: %w", file.version, err)
+		}
+		reverted = append(reverted, file.version)
+	}
+
+	return reverted, nil
+}
+
+// LastBackupPath returns the backup taken by the last migration or rollback,
+// or an empty string when none was needed
+func (d *Database) LastBackupPath() string {
+	return d.lastBackup
+}
+
+// Backup writes a copy of the database into the backup directory before a
+// data change made outside a migration, such as migrate normalize
+func (d *Database) Backup(ctx context.Context, prefix string) (string, error) {
+	return d.backup(ctx, prefix)
+}
+
+// GetMigrationStatus returns the status of all migrations
+func (d *Database) GetMigrationStatus(ctx context.Context) ([]domain.MigrationStatus, error) {
+	query := `SELECT version, name, applied_at FROM migrations ORDER BY version`
+
+	rows, err := d.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query migrations: %w", err)
+	}
+	defer rows.Close()
+
+	var migrations []domain.MigrationStatus
+	for rows.Next() {
+		var migration domain.MigrationStatus
+		var appliedAtStr string
+
+		if err := rows.Scan(&migration.Version, &migration.Name, &appliedAtStr); err != nil {
+			return nil, fmt.Errorf("failed to scan migration row: %w", err)
+		}
+
+		appliedAt, err := time.Parse(time.RFC3339, appliedAtStr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse applied_at time: %w", err)
+		}
+		migration.AppliedAt = appliedAt
+
+		migrations = append(migrations, migration)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return migrations, nil
+}
+
+// getMigrationFiles returns all migrations sorted by version
+func (d *Database) getMigrationFiles() ([]migrationFile, error) {
+	entries, err := fs.ReadDir(d.migrations, ".")
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil // No migration directory is fine
+		}
+		return nil, fmt.Errorf("failed to read migration directory: %w", err)
+	}
+
+	byVersion := make(map[string]*migrationFile)
+	downFiles := make(map[string]string)
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		if strings.HasSuffix(entry.Name(), downSuffix) {
+			version, _ := parseMigrationFilename(strings.TrimSuffix(entry.Name(), downSuffix) + ".sql")
+			if version != "" {
+				downFiles[version] = entry.Name()
+			}
+			continue
+		}
+
+		version, name := parseMigrationFilename(entry.Name())
+		if version == "" {
+			continue // Skip invalid filenames
+		}
+		if existing, ok := byVersion[version]; ok {
+			return nil, fmt.Errorf("duplicate migration version %s: %s and %s", version, existing.upFile, entry.Name())
+		}
+		byVersion[version] = &migrationFile{version: version, name: name, upFile: entry.Name()}
+	}
+
+	files := make([]migrationFile, 0, len(byVersion))
+	for version, file := range byVersion {
+		file.downFile = downFiles[version]
+		files = append(files, *file)
+	}
+
+	// Sort by version
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].version < files[j].version
+	})
+
+	return files, nil
+}
+
+// getAppliedMigrations returns the checksum of each applied migration version
+func (d *Database) getAppliedMigrations(ctx context.Context) (map[string]string, error) {
+	query := `SELECT version, checksum FROM migrations`
+
+	rows, err := d.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query applied migrations: %w", err)
+	}
+	defer rows.Close()
+
+	applied := make(map[string]string)
+	for rows.Next() {
+		var version, checksum string
+		if err := rows.Scan(&version, &checksum); err != nil {
+			return nil, fmt.Errorf("failed to scan migration version: %w", err)
+		}
+		applied[version] = checksum
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return applied, nil
+}
+
+// verifyAppliedMigrations compares the recorded checksums with the migration
+// files. Migrations applied before checksums were recorded are trusted once
+// and get the current checksum.
+func (d *Database) verifyAppliedMigrations(ctx context.Context, files []migrationFile, applied map[string]string) error {
+	known := make(map[string]migrationFile, len(files))
+	for _, file := range files {
+		known[file.version] = file
+	}
+
+	drift := &MigrationDriftError{}
+	for version, recorded := range applied {
+		file, ok := known[version]
+		if !ok {
+			drift.Missing = append(drift.Missing, version)
+			continue
+		}
+
+		checksum, err := d.migrationChecksum(file.upFile)
+		if err != nil {
+			return err
+		}
+
+		if recorded == "" {
+			if _, err := d.db.ExecContext(ctx, `UPDATE migrations SET checksum = ? WHERE version = ?`, checksum, version); err != nil {
+				return fmt.Errorf("failed to record checksum of migration %s: %w", version, err)
+			}
+			applied[version] = checksum
+			continue
+		}
+
+		if recorded != checksum {
+			drift.Modified = append(drift.Modified, version)
+		}
+	}
+
+	if len(drift.Modified) == 0 && len(drift.Missing) == 0 {
+		return nil
+	}
+
+	sort.Strings(drift.Modified)
+	sort.Strings(drift.Missing)
+	return drift
+}
+
+// migrationChecksum returns the SHA-256 of a migration file
+func (d *Database) migrationChecksum(filename string) (string, error) {
+	content, err := fs.ReadFile(d.migrations, filename)
+	if err != nil {
+		return "", fmt.Errorf("failed to read migration file: %w", err)
+	}
+
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// runMigration executes a single migration file
+func (d *Database) runMigration(ctx context.Context, file migrationFile) error {
+	content, err := fs.ReadFile(d.migrations, file.upFile)
+	if err != nil {
+		return fmt.Errorf("failed to read migration file: %w", err)
+	}
+	sum := sha256.Sum256(content)
+
+	// Execute migration within a transaction
+	return d.WithTransaction(ctx, func(ctx context.Context) error {
+		tx := ctx.Value("tx").(*sql.Tx)
+
+		// Execute the migration SQL
+		if _, err := tx.ExecContext(ctx, string(content)); err != nil {
+			return fmt.Errorf("failed to execute migration SQL: %w", err)
+		}
+
+		// Record the migration as applied
+		recordQuery := `INSERT INTO migrations (version, name, applied_at, checksum) VALUES (?, ?, ?, ?)`
+		if _, err := tx.ExecContext(ctx, recordQuery, file.version, file.name,
+			time.Now().UTC().Format(time.RFC3339), hex.EncodeToString(sum[:])); err != nil {
+			return fmt.Errorf("failed to record migration: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// revertMigration executes the down file of a migration. Down files drop
+// columns by rebuilding the table, because the SQLite bundled with SQLCipher
+// has no DROP COLUMN. Dropping a parent table would delete its children
+// through ON DELETE CASCADE, so foreign keys are turned off on the connection
+// while the down file runs and the references are checked before committing.
+func (d *Database) revertMigration(ctx context.Context, file migrationFile) error {
+	content, err := fs.ReadFile(d.migrations, file.downFile)
+	if err != nil {
+		return fmt.Errorf("failed to read down migration file: %w", err)
+	}
+
+	// PRAGMA foreign_keys only applies to one connection and not inside a transaction
+	conn, err := d.db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to open connection: %w", err)
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, `PRAGMA foreign_keys = OFF`); err != nil {
+		return fmt.Errorf("failed to disable foreign keys: %w", err)
+	}
+	defer conn.ExecContext(context.Background(), `PRAGMA foreign_keys = ON`)
+
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, string(content)); err != nil {
+		return fmt.Errorf("failed to execute down migration SQL: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM migrations WHERE version = ?`, file.version); err != nil {
+		return fmt.Errorf("failed to remove migration record: %w", err)
+	}
+
+	if err := checkForeignKeys(ctx, tx); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// checkForeignKeys fails when a row references a row that no longer exists
+func checkForeignKeys(ctx context.Context, tx *sql.Tx) error {
+	rows, err := tx.QueryContext(ctx, `PRAGMA foreign_key_check`)
+	if err != nil {
+		return fmt.Errorf("failed to check foreign keys: %w", err)
+	}
+	defer rows.Close()
+
+	if rows.Next() {
+		var table, parent string
+		var rowID sql.NullInt64
+		var index int
+		if err := rows.Scan(&table, &rowID, &parent, &index); err != nil {
+			return fmt.Errorf("failed to read foreign key check: %w", err)
+		}
+		return fmt.Errorf("down migration left a row of %s referencing a missing row of %s", table, parent)
+	}
+	return rows.Err()
+}
+
+// backup writes a consistent copy of the database into the backup directory
+func (d *Database) backup(ctx context.Context, prefix string) (string, error) {
+	if err := os.MkdirAll(d.backupDir, 0700); err != nil {
+		return "", fmt.Errorf("failed to create backup directory: %w", err)
+	}
+
+	var version string
+	if err := d.db.QueryRowContext(ctx, `SELECT COALESCE(MAX(version), '0000') FROM migrations`).Scan(&version); err != nil {
+		return "", fmt.Errorf("failed to read schema version: %w", err)
+	}
+
+	name := fmt.Sprintf("%s-%s-%s.db", prefix, version, time.Now().Format("20060102-150405"))
+	path := filepath.Join(d.backupDir, name)
+
+	// VACUUM INTO produces a consistent snapshot even with WAL enabled
+	if _, err := d.db.ExecContext(ctx, `VACUUM INTO ?`, path); err != nil {
+		return "", fmt.Errorf("failed to write backup: %w", err)
+	}
+	if err := os.Chmod(path, 0600); err != nil {
+		return "", fmt.Errorf("failed to restrict backup permissions: %w", err)
+	}
+
+	d.lastBackup = path
+	return path, nil
+}
+
+// parseMigrationFilename extracts version and name from a migration filename
+// Expected format: NNNN_name.sql (e.g., "0001_init.sql")
+func parseMigrationFilename(filename string) (version, name string) {
+	if !strings.HasSuffix(filename, ".sql") {
+		return "", ""
+	}
+
+	nameWithoutExt := strings.TrimSuffix(filename, ".sql")
+	parts := strings.SplitN(nameWithoutExt, "_", 2)
+
+	if len(parts) < 2 {
+		return "", ""
+	}
+
+	return parts[0], parts[1]
+}
@@ -0,0 +1,252 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func writeMigration(t *testing.T, dir, name, content string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write migration file: %v", err)
+	}
+}
+
+func tableExists(t *testing.T, d *Database, table string) bool {
+	t.Helper()
+	var count int
+	err := d.DB().QueryRow(`SELECT COUNT(*) FROM sqlite_master WHERE type='table' AND name=?`, table).Scan(&count)
+	if err != nil {
+		t.Fatalf("failed to check table %s: %v", table, err)
+	}
+	return count == 1
+}
+
+func countRows(t *testing.T, d *Database, table string) int {
+	t.Helper()
+	var count int
+	if err := d.DB().QueryRow(`SELECT COUNT(*) FROM ` + table).Scan(&count); err != nil {
+		t.Fatalf("failed to count rows of %s: %v", table, err)
+	}
+	return count
+}
+
+// linkedTables get rows referencing each other, which must survive rolling
+// migrations back and applying them again
+var linkedTables = []string{"staff", "recipients", "benefit_certificates", "staff_assignments"}
+
+func seedLinkedRows(t *testing.T, d *Database) {
+	t.Helper()
+	statements := []string{
+		`INSERT INTO staff (id, name, role, password_hash, created_at, updated_at)
+			VALUES ('staff-1', '職員', 'staff', 'hash', '2026-01-01T00:00:00Z', '2026-01-01T00:00:00Z')`,
+		`INSERT INTO recipients (id, name_cipher, sex_cipher, birth_date_cipher, has_disability_id_cipher,
+			public_assistance_cipher, created_at, updated_at)
+			VALUES ('recipient-1', x'00', x'00', x'00', x'00', x'00', '2026-01-01T00:00:00Z', '2026-01-01T00:00:00Z')`,
+		`INSERT INTO benefit_certificates (id, recipient_id, start_date, end_date, created_at, updated_at)
+			VALUES ('certificate-1', 'recipient-1', '2026-04-01', '2027-03-31', '2026-01-01T00:00:00Z', '2026-01-01T00:00:00Z')`,
+		`INSERT INTO staff_assignments (id, recipient_id, staff_id, assigned_at)
+			VALUES ('assignment-1', 'recipient-1', 'staff-1', '2026-01-01T00:00:00Z')`,
+	}
+	for _, statement := range statements {
+		if _, err := d.DB().Exec(statement); err != nil {
+			t.Fatalf("failed to seed rows: %v", err)
+		}
+	}
+}
+
+func TestDatabase_EmbeddedMigrationsRoundTrip(t *testing.T) {
+	tmpDir := t.TempDir()
+	database, err := NewDatabase(Config{Path: filepath.Join(tmpDir, "test.db")})
+	if err != nil {
+		t.Fatalf("failed to create database: %v", err)
+	}
+	defer database.Close()
+
+	ctx := context.Background()
+	if err := database.RunMigrations(ctx); err != nil {
+		t.Fatalf("RunMigrations() error = %v", err)
+	}
+	if !tableExists(t, database, "notifications") {
+		t.Fatal("embedded migrations were not applied")
+	}
+	if database.LastBackupPath() != "" {
+		t.Error("a fresh database should not be backed up")
+	}
+	seedLinkedRows(t, database)
+	seeded := make(map[string]int)
+	for _, table := range linkedTables {
+		seeded[table] = countRows(t, database, table)
+	}
+
+	reverted, err := database.RollbackTo(ctx, "0004")
+	if err != nil {
+		t.Fatalf("RollbackTo() error = %v", err)
+	}
+	if len(reverted) != 14 || reverted[0] != "0018" || reverted[13] != "0005" {
+		t.Errorf("RollbackTo() reverted %v, want 0018 down to 0005", reverted)
+	}
+	if tableExists(t, database, "enrollment_periods") || !tableExists(t, database, "login_attempts") {
+		t.Error("rollback did not restore the 0004 schema")
+	}
+	// Rebuilding a parent table must not cascade to its children
+	for _, table := range linkedTables {
+		if count := countRows(t, database, table); count != seeded[table] {
+			t.Errorf("%s has %d rows after rollback, want %d", table, count, seeded[table])
+		}
+	}
+	if _, err := os.Stat(database.LastBackupPath()); err != nil {
+		t.Errorf("pre-rollback backup missing: %v", err)
+	}
+
+	// 0004 has no down file, so nothing is reverted
+	if _, err := database.RollbackTo(ctx, "0000"); !errors.Is(err, ErrNoDownMigration) {
+		t.Errorf("RollbackTo(0000) error = %v, want ErrNoDownMigration", err)
+	}
+	if !tableExists(t, database, "login_attempts") {
+		t.Error("a refused rollback must not change the schema")
+	}
+
+	// The down files leave a schema the up files can be applied to again
+	if err := database.RunMigrations(ctx); err != nil {
+		t.Fatalf("RunMigrations() after rollback error = %v", err)
+	}
+	if !tableExists(t, database, "enrollment_periods") {
+		t.Error("migrations were not reapplied")
+	}
+	for _, table := range linkedTables {
+		if count := countRows(t, database, table); count != seeded[table] {
+			t.Errorf("%s has %d rows after reapplying, want %d", table, count, seeded[table])
+		}
+	}
+
+	// 0005 enrolls recipients without an admission date from their registration
+	var periodID, admissionDate, recipientAdmission string
+	err = database.DB().QueryRow(`
+		SELECT ep.id, ep.admission_date, r.admission_date
+		FROM enrollment_periods ep JOIN recipients r ON r.id = ep.recipient_id
+		WHERE ep.recipient_id = 'recipient-1'`).Scan(&periodID, &admissionDate, &recipientAdmission)
+	if err != nil {
+		t.Fatalf("failed to read the migrated enrollment period: %v", err)
+	}
+	if admissionDate != "2026-01-01T00:00:00Z" || recipientAdmission != admissionDate {
+		t.Errorf("admission dates = %q / %q, want the registration date", admissionDate, recipientAdmission)
+	}
+	if _, err := uuid.Parse(periodID); err != nil || len(periodID) != 36 {
+		t.Errorf("enrollment period id %q is not a UUID", periodID)
+	}
+}
+
+func TestDatabase_MigrationDrift(t *testing.T) {
+	tmpDir := t.TempDir()
+	migrationDir := filepath.Join(tmpDir, "migrations")
+	if err := os.MkdirAll(migrationDir, 0755); err != nil {
+		t.Fatalf("failed to create migration directory: %v", err)
+	}
+	writeMigration(t, migrationDir, "0001_first.sql", `CREATE TABLE first (id INTEGER PRIMARY KEY);`)
+	writeMigration(t, migrationDir, "0002_second.sql", `CREATE TABLE second (id INTEGER PRIMARY KEY);`)
+
+	config := Config{Path: filepath.Join(tmpDir, "test.db"), MigrationDir: migrationDir}
+	database, err := NewDatabase(config)
+	if err != nil {
+		t.Fatalf("failed to create database: %v", err)
+	}
+	defer database.Close()
+
+	ctx := context.Background()
+	if err := database.RunMigrations(ctx); err != nil {
+		t.Fatalf("RunMigrations() error = %v", err)
+	}
+
+	// An applied migration edited afterwards
+	writeMigration(t, migrationDir, "0001_first.sql", `CREATE TABLE first (id INTEGER PRIMARY KEY, name TEXT);`)
+	// A migration only a newer build knows about
+	if err := os.Remove(filepath.Join(migrationDir, "0002_second.sql")); err != nil {
+		t.Fatal(err)
+	}
+	writeMigration(t, migrationDir, "0003_third.sql", `CREATE TABLE third (id INTEGER PRIMARY KEY);`)
+
+	err = database.RunMigrations(ctx)
+	var drift *MigrationDriftError
+	if !errors.As(err, &drift) || !errors.Is(err, ErrMigrationDrift) {
+		t.Fatalf("RunMigrations() error = %v, want MigrationDriftError", err)
+	}
+	if len(drift.Modified) != 1 || drift.Modified[0] != "0001" {
+		t.Errorf("Modified = %v, want [0001]", drift.Modified)
+	}
+	if len(drift.Missing) != 1 || drift.Missing[0] != "0002" {
+		t.Errorf("Missing = %v, want [0002]", drift.Missing)
+	}
+	if tableExists(t, database, "third") {
+		t.Error("pending migrations must not run on drift")
+	}
+}
+
+func TestDatabase_LegacyMigrationTableGetsChecksums(t *testing.T) {
+	tmpDir := t.TempDir()
+	migrationDir := filepath.Join(tmpDir, "migrations")
+	if err := os.MkdirAll(migrationDir, 0755); err != nil {
+		t.Fatalf("failed to create migration directory: %v", err)
+	}
+	writeMigration(t, migrationDir, "0001_first.sql", `CREATE TABLE first (id INTEGER PRIMARY KEY);`)
+	writeMigration(t, migrationDir, "0002_second.sql", `CREATE TABLE second (id INTEGER PRIMARY KEY);`)
+
+	// Simulate a database migrated by a build that did not record checksums
+	dbPath := filepath.Join(tmpDir, "test.db")
+	legacy, err := NewDatabase(Config{Path: dbPath, MigrationDir: filepath.Join(tmpDir, "none")})
+	if err != nil {
+		t.Fatalf("failed to create database: %v", err)
+	}
+	for _, stmt := range []string{
+		`DROP TABLE migrations`,
+		`CREATE TABLE migrations (version TEXT PRIMARY KEY, name TEXT NOT NULL, applied_at TEXT NOT NULL)`,
+		`CREATE TABLE first (id INTEGER PRIMARY KEY)`,
+		`INSERT INTO migrations VALUES ('0001', 'first', '2026-01-01T00:00:00Z')`,
+	} {
+		if _, err := legacy.DB().Exec(stmt); err != nil {
+			t.Fatalf("failed to prepare legacy database: %v", err)
+		}
+	}
+	legacy.Close()
+
+	database, err := NewDatabase(Config{Path: dbPath, MigrationDir: migrationDir})
+	if err != nil {
+		t.Fatalf("failed to reopen database: %v", err)
+	}
+	defer database.Close()
+
+	ctx := context.Background()
+	if err := database.RunMigrations(ctx); err != nil {
+		t.Fatalf("RunMigrations() error = %v", err)
+	}
+
+	var checksum string
+	if err := database.DB().QueryRow(`SELECT checksum FROM migrations WHERE version = '0001'`).Scan(&checksum); err != nil {
+		t.Fatal(err)
+	}
+	if len(checksum) != 64 {
+		t.Errorf("legacy migration checksum = %q, want a SHA-256", checksum)
+	}
+	if !tableExists(t, database, "second") {
+		t.Error("pending migration was not applied")
+	}
+
+	// The database had data, so it was backed up before 0002 ran
+	backup := database.LastBackupPath()
+	if filepath.Dir(backup) != filepath.Join(tmpDir, "backups") {
+		t.Fatalf("backup written to %q", backup)
+	}
+	snapshot, err := NewDatabase(Config{Path: backup, MigrationDir: migrationDir})
+	if err != nil {
+		t.Fatalf("failed to open backup: %v", err)
+	}
+	defer snapshot.Close()
+	if !tableExists(t, snapshot, "first") || tableExists(t, snapshot, "second") {
+		t.Error("backup should hold the schema before the migration")
+	}
+}
@@ -0,0 +1,101 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+
+	"shien-system/internal/domain"
+)
+
+// MunicipalityRepository implements domain.MunicipalityRepository
+type MunicipalityRepository struct {
+	db *Database
+}
+
+// NewMunicipalityRepository creates a new municipality master repository
+func NewMunicipalityRepository(db *Database) *MunicipalityRepository {
+	return &MunicipalityRepository{
+		db: db,
+	}
+}
+
+// List returns every municipality ordered by code
+func (r *MunicipalityRepository) List(ctx context.Context) ([]*domain.Municipality, error) {
+	executor := r.getExecutor(ctx)
+	rows, err := executor.QueryContext(ctx, `SELECT code, prefecture, name FROM municipalities ORDER BY code`)
+	if err != nil {
+		return nil, &domain.RepositoryError{Op: "list municipalities", Err: err}
+	}
+	defer rows.Close()
+
+	var municipalities []*domain.Municipality
+	for rows.Next() {
+		var municipality domain.Municipality
+		if err := rows.Scan(&municipality.Code, &municipality.Prefecture, &municipality.Name); err != nil {
+			return nil, &domain.RepositoryError{Op: "scan municipality", Err: err}
+		}
+		municipalities = append(municipalities, &municipality)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, &domain.RepositoryError{Op: "rows iteration", Err: err}
+	}
+
+	return municipalities, nil
+}
+
+// FindByCode returns the municipality with a six-digit 市町村番号
+func (r *MunicipalityRepository) FindByCode(ctx context.Context, code string) (*domain.Municipality, error) {
+	executor := r.getExecutor(ctx)
+	var municipality domain.Municipality
+	err := executor.QueryRowContext(ctx, `SELECT code, prefecture, name FROM municipalities WHERE code = ?`, code).
+		Scan(&municipality.Code, &municipality.Prefecture, &municipality.Name)
+	if err == sql.ErrNoRows {
+		return nil, domain.ErrNotFound
+	}
+	if err != nil {
+		return nil, &domain.RepositoryError{Op: "find municipality", Err: err}
+	}
+	return &municipality, nil
+}
+
+// ReplaceAll deletes the master and inserts municipalities in one transaction
+func (r *MunicipalityRepository) ReplaceAll(ctx context.Context, municipalities []*domain.Municipality) error {
+	return r.db.WithTransaction(ctx, func(ctx context.Context) error {
+		executor := r.getExecutor(ctx)
+		if _, err := executor.ExecContext(ctx, `DELETE FROM municipalities`); err != nil {
+			return &domain.RepositoryError{Op: "clear municipalities", Err: err}
+		}
+
+		stmt, err := executor.PrepareContext(ctx, `INSERT INTO municipalities (code, prefecture, name) VALUES (?, ?, ?)`)
+		if err != nil {
+			return &domain.RepositoryError{Op: "prepare insert municipality", Err: err}
+		}
+		defer stmt.Close()
+
+		for _, municipality := range municipalities {
+			if _, err := stmt.ExecContext(ctx, municipality.Code, municipality.Prefecture, municipality.Name); err != nil {
+				return &domain.RepositoryError{Op: "insert municipality " + municipality.Code, Err: err}
+			}
+		}
+		return nil
+	})
+}
+
+// Count returns the number of municipalities
+func (r *MunicipalityRepository) Count(ctx context.Context) (int, error) {
+	executor := r.getExecutor(ctx)
+	var count int
+	if err := executor.QueryRowContext(ctx, `SELECT COUNT(*) FROM municipalities`).Scan(&count); err != nil {
+		return 0, &domain.RepositoryError{Op: "count municipalities", Err: err}
+	}
+	return count, nil
+}
+
+// getExecutor returns either a transaction or the database connection
+func (r *MunicipalityRepository) getExecutor(ctx context.Context) executor {
+	if tx := ctx.Value("tx"); tx != nil {
+		return tx.(*sql.Tx)
+	}
+	return r.db.DB()
+}
@@ -0,0 +1,64 @@
+package db
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"shien-system/internal/domain"
+)
+
+func TestMunicipalityRepository_ReplaceAllAndFind(t *testing.T) {
+	database, err := NewDatabase(Config{Path: filepath.Join(t.TempDir(), "test.db")})
+	if err != nil {
+		t.Fatalf("failed to create database: %v", err)
+	}
+	defer database.Close()
+
+	ctx := context.Background()
+	if err := database.RunMigrations(ctx); err != nil {
+		t.Fatalf("RunMigrations() error = %v", err)
+	}
+
+	repo := NewMunicipalityRepository(database)
+	first := []*domain.Municipality{
+		{Code: "141003", Prefecture: "神奈川県", Name: "横浜市"},
+		{Code: "131016", Prefecture: "東京都", Name: "千代田区"},
+	}
+	if err := repo.ReplaceAll(ctx, first); err != nil {
+		t.Fatalf("ReplaceAll() error = %v", err)
+	}
+
+	list, err := repo.List(ctx)
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(list) != 2 || list[0].Code != "131016" || list[1].Name != "横浜市" {
+		t.Errorf("List() = %+v, want both municipalities ordered by code", list)
+	}
+
+	found, err := repo.FindByCode(ctx, "131016")
+	if err != nil || found.Prefecture != "東京都" || found.Name != "千代田区" {
+		t.Errorf("FindByCode() = %+v, %v", found, err)
+	}
+	if _, err := repo.FindByCode(ctx, "011002"); err != domain.ErrNotFound {
+		t.Errorf("FindByCode() unknown code error = %v, want ErrNotFound", err)
+	}
+
+	// A second import replaces the master instead of adding to it
+	if err := repo.ReplaceAll(ctx, first[:1]); err != nil {
+		t.Fatalf("ReplaceAll() error = %v", err)
+	}
+	if count, err := repo.Count(ctx); err != nil || count != 1 {
+		t.Errorf("Count() = %d, %v, want 1", count, err)
+	}
+
+	// Duplicate codes fail the import and keep the previous master
+	duplicate := []*domain.Municipality{first[1], first[1]}
+	if err := repo.ReplaceAll(ctx, duplicate); err == nil {
+		t.Error("ReplaceAll() with duplicate codes succeeded")
+	}
+	if found, err := repo.FindByCode(ctx, "141003"); err != nil || found.Name != "横浜市" {
+		t.Errorf("master changed by a failed import: %+v, %v", found, err)
+	}
+}
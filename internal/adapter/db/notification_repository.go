@@ -0,0 +1,332 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"shien-system/internal/adapter/crypto"
+	"shien-system/internal/domain"
+)
+
+// notificationColumns lists the notification columns in scan order
+const notificationColumns = `n.id, n.kind, n.severity, n.audience, n.title, n.message_cipher,
+			   n.target_type, n.target_id, n.dedup_key, n.created_at, n.resolved_at`
+
+// NotificationRepository implements domain.NotificationRepository
+type NotificationRepository struct {
+	db     *Database
+	cipher *crypto.FieldCipher
+}
+
+// NewNotificationRepository creates a new notification repository
+func NewNotificationRepository(db *Database) (*NotificationRepository, error) {
+	cipher, err := crypto.NewFieldCipher()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+
+	return &NotificationRepository{
+		db:     db,
+		cipher: cipher,
+	}, nil
+}
+
+// CreateIfAbsent stores the notification unless an open notification with the same dedup key exists
+func (r *NotificationRepository) CreateIfAbsent(ctx context.Context, notification *domain.Notification) (bool, error) {
+	query := `
+		INSERT OR IGNORE INTO notifications (
+			id, kind, severity, audience, title, message_cipher,
+			target_type, target_id, dedup_key, created_at, resolved_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+	messageCipher, err := r.cipher.Encrypt(notification.Message)
+	if err != nil {
+		return false, &domain.RepositoryError{Op: "encrypt message", Err: err}
+	}
+
+	executor := r.getExecutor(ctx)
+	result, err := executor.ExecContext(ctx, query,
+		notification.ID,
+		string(notification.Kind),
+		string(notification.Severity),
+		string(notification.Audience),
+		notification.Title,
+		messageCipher,
+		notification.TargetType,
+		notification.TargetID,
+		notification.DedupKey,
+		notification.CreatedAt.Format(time.RFC3339),
+		formatOptionalTime(notification.ResolvedAt),
+	)
+
+	if err != nil {
+		return false, &domain.RepositoryError{Op: "create notification", Err: err}
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, &domain.RepositoryError{Op: "check rows affected", Err: err}
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// GetByID retrieves a notification by ID without per-user state
+func (r *NotificationRepository) GetByID(ctx context.Context, id domain.ID) (*domain.Notification, error) {
+	query := `
+		SELECT ` + notificationColumns + `, NULL, NULL
+		FROM notifications n
+		WHERE n.id = ?`
+
+	executor := r.getExecutor(ctx)
+	row := executor.QueryRowContext(ctx, query, id)
+
+	return r.scanNotification(row)
+}
+
+// ResolveStale resolves the open notifications of a kind whose condition no longer holds
+func (r *NotificationRepository) ResolveStale(ctx context.Context, kind domain.NotificationKind, activeKeys []string, resolvedAt time.Time) (int, error) {
+	query := `
+		UPDATE notifications
+		SET resolved_at = ?
+		WHERE kind = ? AND resolved_at IS NULL`
+	args := []interface{}{resolvedAt.Format(time.RFC3339), string(kind)}
+
+	if len(activeKeys) > 0 {
+		query += ` AND dedup_key NOT IN (` + placeholders(len(activeKeys)) + `)`
+		for _, key := range activeKeys {
+			args = append(args, key)
+		}
+	}
+
+	executor := r.getExecutor(ctx)
+	result, err := executor.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, &domain.RepositoryError{Op: "resolve stale notifications", Err: err}
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, &domain.RepositoryError{Op: "check rows affected", Err: err}
+	}
+
+	return int(rowsAffected), nil
+}
+
+// ListForUser retrieves the open notifications visible to a staff member, newest first
+func (r *NotificationRepository) ListForUser(ctx context.Context, userID domain.ID, audiences []domain.NotificationAudience, unreadOnly bool, limit, offset int) ([]*domain.Notification, error) {
+	if len(audiences) == 0 {
+		return nil, nil
+	}
+
+	query := `
+		SELECT ` + notificationColumns + `, nr.read_at, nr.acknowledged_at
+		FROM notifications n
+		LEFT JOIN notification_receipts nr ON nr.notification_id = n.id AND nr.staff_id = ?
+		WHERE n.resolved_at IS NULL AND n.audience IN (` + placeholders(len(audiences)) + `)`
+	args := []interface{}{userID}
+	for _, audience := range audiences {
+		args = append(args, string(audience))
+	}
+
+	if unreadOnly {
+		query += ` AND nr.read_at IS NULL`
+	}
+
+	query += `
+		ORDER BY n.created_at DESC, n.id
+		LIMIT ? OFFSET ?`
+	args = append(args, limit, offset)
+
+	executor := r.getExecutor(ctx)
+	rows, err := executor.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, &domain.RepositoryError{Op: "list notifications", Err: err}
+	}
+	defer rows.Close()
+
+	var notifications []*domain.Notification
+	for rows.Next() {
+		notification, err := r.scanNotification(rows)
+		if err != nil {
+			return nil, err
+		}
+		notifications = append(notifications, notification)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, &domain.RepositoryError{Op: "rows iteration", Err: err}
+	}
+
+	return notifications, nil
+}
+
+// CountUnread counts the open notifications a staff member has not read
+func (r *NotificationRepository) CountUnread(ctx context.Context, userID domain.ID, audiences []domain.NotificationAudience) (int, error) {
+	if len(audiences) == 0 {
+		return 0, nil
+	}
+
+	query := `
+		SELECT COUNT(*)
+		FROM notifications n
+		LEFT JOIN notification_receipts nr ON nr.notification_id = n.id AND nr.staff_id = ?
+		WHERE n.resolved_at IS NULL AND nr.read_at IS NULL
+		  AND n.audience IN (` + placeholders(len(audiences)) + `)`
+	args := []interface{}{userID}
+	for _, audience := range audiences {
+		args = append(args, string(audience))
+	}
+
+	var count int
+	executor := r.getExecutor(ctx)
+	if err := executor.QueryRowContext(ctx, query, args...).Scan(&count); err != nil {
+		return 0, &domain.RepositoryError{Op: "count unread notifications", Err: err}
+	}
+
+	return count, nil
+}
+
+// MarkRead records that a staff member has read a notification
+func (r *NotificationRepository) MarkRead(ctx context.Context, id domain.ID, userID domain.ID, readAt time.Time) error {
+	query := `
+		INSERT INTO notification_receipts (notification_id, staff_id, read_at)
+		VALUES (?, ?, ?)
+		ON CONFLICT(notification_id, staff_id) DO UPDATE SET
+			read_at = COALESCE(notification_receipts.read_at, excluded.read_at)`
+
+	executor := r.getExecutor(ctx)
+	if _, err := executor.ExecContext(ctx, query, id, userID, readAt.Format(time.RFC3339)); err != nil {
+		return &domain.RepositoryError{Op: "mark notification read", Err: err}
+	}
+
+	return nil
+}
+
+// MarkAllRead marks every open notification visible to a staff member as read
+func (r *NotificationRepository) MarkAllRead(ctx context.Context, userID domain.ID, audiences []domain.NotificationAudience, readAt time.Time) error {
+	if len(audiences) == 0 {
+		return nil
+	}
+
+	query := `
+		INSERT INTO notification_receipts (notification_id, staff_id, read_at)
+		SELECT id, ?, ? FROM notifications
+		WHERE resolved_at IS NULL AND audience IN (` + placeholders(len(audiences)) + `)
+		ON CONFLICT(notification_id, staff_id) DO UPDATE SET
+			read_at = COALESCE(notification_receipts.read_at, excluded.read_at)`
+	args := []interface{}{userID, readAt.Format(time.RFC3339)}
+	for _, audience := range audiences {
+		args = append(args, string(audience))
+	}
+
+	executor := r.getExecutor(ctx)
+	if _, err := executor.ExecContext(ctx, query, args...); err != nil {
+		return &domain.RepositoryError{Op: "mark all notifications read", Err: err}
+	}
+
+	return nil
+}
+
+// Acknowledge records that a staff member has dealt with a notification; it also marks it read
+func (r *NotificationRepository) Acknowledge(ctx context.Context, id domain.ID, userID domain.ID, acknowledgedAt time.Time) error {
+	query := `
+		INSERT INTO notification_receipts (notification_id, staff_id, read_at, acknowledged_at)
+		VALUES (?, ?, ?, ?)
+		ON CONFLICT(notification_id, staff_id) DO UPDATE SET
+			read_at = COALESCE(notification_receipts.read_at, excluded.read_at),
+			acknowledged_at = COALESCE(notification_receipts.acknowledged_at, excluded.acknowledged_at)`
+
+	at := acknowledgedAt.Format(time.RFC3339)
+	executor := r.getExecutor(ctx)
+	if _, err := executor.ExecContext(ctx, query, id, userID, at, at); err != nil {
+		return &domain.RepositoryError{Op: "acknowledge notification", Err: err}
+	}
+
+	return nil
+}
+
+// getExecutor returns either a transaction or the database connection
+func (r *NotificationRepository) getExecutor(ctx context.Context) executor {
+	if tx := ctx.Value("tx"); tx != nil {
+		return tx.(*sql.Tx)
+	}
+	return r.db.DB()
+}
+
+// scanNotification scans a notification and the per-user receipt columns from a database row
+func (r *NotificationRepository) scanNotification(row scanner) (*domain.Notification, error) {
+	var notification domain.Notification
+	var kind, severity, audience string
+	var messageCipher []byte
+	var createdAtStr string
+	var resolvedAtStr, readAtStr, acknowledgedAtStr *string
+
+	err := row.Scan(
+		&notification.ID,
+		&kind,
+		&severity,
+		&audience,
+		&notification.Title,
+		&messageCipher,
+		&notification.TargetType,
+		&notification.TargetID,
+		&notification.DedupKey,
+		&createdAtStr,
+		&resolvedAtStr,
+		&readAtStr,
+		&acknowledgedAtStr,
+	)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrNotFound
+		}
+		return nil, &domain.RepositoryError{Op: "scan notification", Err: err}
+	}
+
+	notification.Kind = domain.NotificationKind(kind)
+	notification.Severity = domain.NotificationSeverity(severity)
+	notification.Audience = domain.NotificationAudience(audience)
+
+	notification.CreatedAt, err = time.Parse(time.RFC3339, createdAtStr)
+	if err != nil {
+		return nil, &domain.RepositoryError{Op: "parse created_at", Err: err}
+	}
+
+	optionalTimes := []struct {
+		op     string
+		value  *string
+		target **time.Time
+	}{
+		{"parse resolved_at", resolvedAtStr, &notification.ResolvedAt},
+		{"parse read_at", readAtStr, &notification.ReadAt},
+		{"parse acknowledged_at", acknowledgedAtStr, &notification.AcknowledgedAt},
+	}
+
+	for _, field := range optionalTimes {
+		if field.value == nil {
+			continue
+		}
+		parsed, err := time.Parse(time.RFC3339, *field.value)
+		if err != nil {
+			return nil, &domain.RepositoryError{Op: field.op, Err: err}
+		}
+		*field.target = &parsed
+	}
+
+	notification.Message, err = r.cipher.Decrypt(messageCipher)
+	if err != nil {
+		return nil, &domain.RepositoryError{Op: "decrypt message", Err: err}
+	}
+
+	return &notification, nil
+}
+
+// placeholders returns n comma separated SQL parameter placeholders
+func placeholders(n int) string {
+	return strings.TrimSuffix(strings.Repeat("?, ", n), ", ")
+}
@@ -0,0 +1,140 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"shien-system/internal/domain"
+)
+
+func TestNotificationRepository_DedupReadAndResolve(t *testing.T) {
+	db := setupTestDatabase(t)
+	defer db.Close()
+
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Second)
+
+	staffRepo := NewStaffRepository(db)
+	for _, staff := range []*domain.Staff{
+		{ID: "notify-staff-001", Name: "職員", Role: domain.RoleStaff, CreatedAt: now, UpdatedAt: now},
+		{ID: "notify-admin-001", Name: "管理者", Role: domain.RoleAdmin, CreatedAt: now, UpdatedAt: now},
+	} {
+		if err := staffRepo.Create(ctx, staff); err != nil {
+			t.Fatalf("Create staff error = %v", err)
+		}
+	}
+
+	repo, err := NewNotificationRepository(db)
+	if err != nil {
+		t.Fatalf("NewNotificationRepository() error = %v", err)
+	}
+
+	certNotification := &domain.Notification{
+		ID:         "notification-001",
+		Kind:       domain.NotificationKindCertificateExpiring,
+		Severity:   domain.NotificationSeverityWarning,
+		Audience:   domain.NotificationAudienceAll,
+		Title:      "受給者証の有効期限が近づいています",
+		Message:    "山田太郎さんの受給者証が60日以内に期限切れになります",
+		TargetType: "certificate",
+		TargetID:   "cert-001",
+		DedupKey:   "certificate_expiring:cert-001:60",
+		CreatedAt:  now,
+	}
+	created, err := repo.CreateIfAbsent(ctx, certNotification)
+	if err != nil || !created {
+		t.Fatalf("CreateIfAbsent() = %v, %v", created, err)
+	}
+
+	// The same open condition is not raised twice
+	duplicate := *certNotification
+	duplicate.ID = "notification-002"
+	created, err = repo.CreateIfAbsent(ctx, &duplicate)
+	if err != nil || created {
+		t.Fatalf("CreateIfAbsent() duplicate = %v, %v", created, err)
+	}
+
+	lockNotification := &domain.Notification{
+		ID:        "notification-003",
+		Kind:      domain.NotificationKindAccountLocked,
+		Severity:  domain.NotificationSeverityCritical,
+		Audience:  domain.NotificationAudienceAdmin,
+		Title:     "アカウントがロックされています",
+		Message:   "tanaka",
+		DedupKey:  "account_locked:lockout-001",
+		CreatedAt: now.Add(time.Minute),
+	}
+	if _, err := repo.CreateIfAbsent(ctx, lockNotification); err != nil {
+		t.Fatalf("CreateIfAbsent() lockout error = %v", err)
+	}
+
+	staffAudiences := []domain.NotificationAudience{domain.NotificationAudienceAll}
+	adminAudiences := []domain.NotificationAudience{domain.NotificationAudienceAll, domain.NotificationAudienceAdmin}
+
+	staffList, err := repo.ListForUser(ctx, "notify-staff-001", staffAudiences, false, 50, 0)
+	if err != nil {
+		t.Fatalf("ListForUser() error = %v", err)
+	}
+	if len(staffList) != 1 || staffList[0].Message != certNotification.Message || staffList[0].IsRead() {
+		t.Fatalf("ListForUser() staff = %+v", staffList)
+	}
+
+	adminList, err := repo.ListForUser(ctx, "notify-admin-001", adminAudiences, false, 50, 0)
+	if err != nil || len(adminList) != 2 || adminList[0].ID != "notification-003" {
+		t.Fatalf("ListForUser() admin = %d, %v", len(adminList), err)
+	}
+
+	// Read state is kept per user
+	if err := repo.MarkRead(ctx, "notification-001", "notify-staff-001", now); err != nil {
+		t.Fatalf("MarkRead() error = %v", err)
+	}
+	if count, _ := repo.CountUnread(ctx, "notify-staff-001", staffAudiences); count != 0 {
+		t.Errorf("CountUnread() staff = %d, want 0", count)
+	}
+	if count, _ := repo.CountUnread(ctx, "notify-admin-001", adminAudiences); count != 2 {
+		t.Errorf("CountUnread() admin = %d, want 2", count)
+	}
+
+	if err := repo.Acknowledge(ctx, "notification-003", "notify-admin-001", now); err != nil {
+		t.Fatalf("Acknowledge() error = %v", err)
+	}
+	unread, err := repo.ListForUser(ctx, "notify-admin-001", adminAudiences, true, 50, 0)
+	if err != nil || len(unread) != 1 || unread[0].ID != "notification-001" {
+		t.Fatalf("ListForUser() unread = %d, %v", len(unread), err)
+	}
+
+	if err := repo.MarkAllRead(ctx, "notify-admin-001", adminAudiences, now); err != nil {
+		t.Fatalf("MarkAllRead() error = %v", err)
+	}
+	adminList, _ = repo.ListForUser(ctx, "notify-admin-001", adminAudiences, false, 50, 0)
+	for _, notification := range adminList {
+		if !notification.IsRead() {
+			t.Errorf("notification %s should be read", notification.ID)
+		}
+		if notification.ID == "notification-003" && notification.AcknowledgedAt == nil {
+			t.Error("acknowledgement should be kept")
+		}
+	}
+
+	// Conditions that no longer hold are resolved and hidden
+	resolved, err := repo.ResolveStale(ctx, domain.NotificationKindCertificateExpiring, []string{"certificate_expiring:cert-002:30"}, now)
+	if err != nil || resolved != 1 {
+		t.Fatalf("ResolveStale() = %d, %v", resolved, err)
+	}
+	staffList, _ = repo.ListForUser(ctx, "notify-staff-001", staffAudiences, false, 50, 0)
+	if len(staffList) != 0 {
+		t.Errorf("resolved notification still listed")
+	}
+
+	stored, err := repo.GetByID(ctx, "notification-001")
+	if err != nil || stored.ResolvedAt == nil {
+		t.Fatalf("GetByID() = %+v, %v", stored, err)
+	}
+
+	// A resolved condition can be raised again
+	created, err = repo.CreateIfAbsent(ctx, &duplicate)
+	if err != nil || !created {
+		t.Fatalf("CreateIfAbsent() after resolve = %v, %v", created, err)
+	}
+}
@@ -7,6 +7,8 @@ import (
 	"time"
 
 	"shien-system/internal/domain"
+
+	"github.com/stretchr/testify/require"
 )
 
 func TestPhase2Integration_CompleteWorkflow(t *testing.T) {
@@ -34,7 +36,7 @@ func TestPhase2Integration_CompleteWorkflow(t *testing.T) {
 		UpdatedAt: now,
 	}
 
-	err := staffRepo.Create(ctx, staff)
+	err = staffRepo.Create(ctx, staff)
 	if err != nil {
 		t.Fatalf("Create staff error = %v", err)
 	}
@@ -261,7 +263,7 @@ func TestPhase2Integration_TransactionConsistency(t *testing.T) {
 	now := time.Now().UTC().Truncate(time.Second)
 
 	// Test transaction rollback scenario
-	err := db.WithTransaction(ctx, func(ctx context.Context) error {
+	err = db.WithTransaction(ctx, func(ctx context.Context) error {
 		// Create staff
 		staff := &domain.Staff{
 			ID:        "transaction-staff-001",
@@ -380,7 +382,7 @@ func TestPhase2Integration_ForeignKeyConstraints(t *testing.T) {
 		UnassignedAt: nil,
 	}
 
-	err := assignmentRepo.Create(ctx, assignment)
+	err = assignmentRepo.Create(ctx, assignment)
 	if err == nil {
 		t.Error("Assignment creation with non-existent staff/recipient should fail")
 	}
@@ -515,7 +517,7 @@ func TestPhase2Integration_EncryptionConsistency(t *testing.T) {
 		UpdatedAt:        now,
 	}
 
-	err := recipientRepo.Create(ctx, recipient)
+	err = recipientRepo.Create(ctx, recipient)
 	if err != nil {
 		t.Fatalf("Create encrypted recipient error = %v", err)
 	}
@@ -599,10 +601,10 @@ func BenchmarkPhase2Integration_CompleteWorkflow(b *testing.B) {
 
 	staffRepo := NewStaffRepository(db)
 	recipientRepo, err := NewRecipientRepository(db)
-	require.NoError(t, err)
+	require.NoError(b, err)
 	assignmentRepo := NewStaffAssignmentRepository(db)
 	certRepo, err := NewBenefitCertificateRepository(db)
-	require.NoError(t, err)
+	require.NoError(b, err)
 	auditRepo := NewAuditLogRepository(db)
 
 	b.ResetTimer()
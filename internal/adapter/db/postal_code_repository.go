@@ -0,0 +1,101 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+
+	"shien-system/internal/domain"
+)
+
+// PostalCodeRepository implements domain.PostalCodeRepository
+type PostalCodeRepository struct {
+	db *Database
+}
+
+// NewPostalCodeRepository creates a new postal code repository
+func NewPostalCodeRepository(db *Database) *PostalCodeRepository {
+	return &PostalCodeRepository{
+		db: db,
+	}
+}
+
+// FindByCode returns the dictionary entries for a seven-digit postal code
+func (r *PostalCodeRepository) FindByCode(ctx context.Context, code string) ([]*domain.PostalAddress, error) {
+	query := `
+		SELECT code, prefecture, city, town, prefecture_kana, city_kana, town_kana
+		FROM postal_codes
+		WHERE code = ?
+		ORDER BY rowid`
+
+	executor := r.getExecutor(ctx)
+	rows, err := executor.QueryContext(ctx, query, code)
+	if err != nil {
+		return nil, &domain.RepositoryError{Op: "find postal code", Err: err}
+	}
+	defer rows.Close()
+
+	var addresses []*domain.PostalAddress
+	for rows.Next() {
+		var address domain.PostalAddress
+		if err := rows.Scan(
+			&address.Code, &address.Prefecture, &address.City, &address.Town,
+			&address.PrefectureKana, &address.CityKana, &address.TownKana,
+		); err != nil {
+			return nil, &domain.RepositoryError{Op: "scan postal code", Err: err}
+		}
+		addresses = append(addresses, &address)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, &domain.RepositoryError{Op: "rows iteration", Err: err}
+	}
+
+	return addresses, nil
+}
+
+// ReplaceAll deletes the dictionary and inserts addresses in one transaction,
+// so lookups never see a half-imported dictionary
+func (r *PostalCodeRepository) ReplaceAll(ctx context.Context, addresses []*domain.PostalAddress) error {
+	return r.db.WithTransaction(ctx, func(ctx context.Context) error {
+		executor := r.getExecutor(ctx)
+		if _, err := executor.ExecContext(ctx, `DELETE FROM postal_codes`); err != nil {
+			return &domain.RepositoryError{Op: "clear postal codes", Err: err}
+		}
+
+		stmt, err := executor.PrepareContext(ctx, `
+			INSERT INTO postal_codes (code, prefecture, city, town, prefecture_kana, city_kana, town_kana)
+			VALUES (?, ?, ?, ?, ?, ?, ?)`)
+		if err != nil {
+			return &domain.RepositoryError{Op: "prepare insert postal code", Err: err}
+		}
+		defer stmt.Close()
+
+		for _, address := range addresses {
+			if _, err := stmt.ExecContext(ctx,
+				address.Code, address.Prefecture, address.City, address.Town,
+				address.PrefectureKana, address.CityKana, address.TownKana,
+			); err != nil {
+				return &domain.RepositoryError{Op: "insert postal code " + address.Code, Err: err}
+			}
+		}
+		return nil
+	})
+}
+
+// Count returns the number of dictionary entries
+func (r *PostalCodeRepository) Count(ctx context.Context) (int, error) {
+	executor := r.getExecutor(ctx)
+	var count int
+	if err := executor.QueryRowContext(ctx, `SELECT COUNT(*) FROM postal_codes`).Scan(&count); err != nil {
+		return 0, &domain.RepositoryError{Op: "count postal codes", Err: err}
+	}
+	return count, nil
+}
+
+// getExecutor returns either a transaction or the database connection
+func (r *PostalCodeRepository) getExecutor(ctx context.Context) executor {
+	if tx := ctx.Value("tx"); tx != nil {
+		return tx.(*sql.Tx)
+	}
+	return r.db.DB()
+}
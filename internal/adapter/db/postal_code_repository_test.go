@@ -0,0 +1,51 @@
+package db
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"shien-system/internal/domain"
+)
+
+func TestPostalCodeRepository_ReplaceAllAndFind(t *testing.T) {
+	database, err := NewDatabase(Config{Path: filepath.Join(t.TempDir(), "test.db")})
+	if err != nil {
+		t.Fatalf("failed to create database: %v", err)
+	}
+	defer database.Close()
+
+	ctx := context.Background()
+	if err := database.RunMigrations(ctx); err != nil {
+		t.Fatalf("RunMigrations() error = %v", err)
+	}
+
+	repo := NewPostalCodeRepository(database)
+	first := []*domain.PostalAddress{
+		{Code: "1000001", Prefecture: "東京都", City: "千代田区", Town: "千代田"},
+		{Code: "0600042", Prefecture: "北海道", City: "札幌市中央区", Town: "大通西"},
+		{Code: "0600042", Prefecture: "北海道", City: "札幌市中央区", Town: "大通東"},
+	}
+	if err := repo.ReplaceAll(ctx, first); err != nil {
+		t.Fatalf("ReplaceAll() error = %v", err)
+	}
+
+	found, err := repo.FindByCode(ctx, "0600042")
+	if err != nil {
+		t.Fatalf("FindByCode() error = %v", err)
+	}
+	if len(found) != 2 || found[0].Town != "大通西" || found[1].Town != "大通東" {
+		t.Errorf("FindByCode() = %+v, want both 大通 entries in file order", found)
+	}
+
+	// A second import replaces the dictionary instead of adding to it
+	if err := repo.ReplaceAll(ctx, first[:1]); err != nil {
+		t.Fatalf("ReplaceAll() error = %v", err)
+	}
+	if count, err := repo.Count(ctx); err != nil || count != 1 {
+		t.Errorf("Count() = %d, %v, want 1", count, err)
+	}
+	if found, err := repo.FindByCode(ctx, "0600042"); err != nil || len(found) != 0 {
+		t.Errorf("FindByCode() after replace = %v, %v, want none", found, err)
+	}
+}
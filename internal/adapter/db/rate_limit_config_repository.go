@@ -25,9 +25,10 @@ func NewRateLimitConfigRepository(db *Database) *RateLimitConfigRepository {
 // Get retrieves the current rate limit configuration
 func (r *RateLimitConfigRepository) Get(ctx context.Context) (*RateLimitConfig, error) {
 	query := `
-		SELECT id, max_attempts_per_ip, max_attempts_per_user, window_size_minutes,
+		SELECT id, enabled, max_attempts_per_ip, max_attempts_per_user, window_size_minutes,
 		       lockout_duration_minutes, backoff_multiplier, max_lockout_hours,
-		       whitelist_ips, enable_progressive_lockout, created_at, updated_at
+		       whitelist_ips, enable_progressive_lockout, source, config_hash, updated_by,
+		       created_at, updated_at
 		FROM rate_limit_config
 		ORDER BY updated_at DESC
 		LIMIT 1
@@ -53,6 +54,10 @@ func (r *RateLimitConfigRepository) Update(ctx context.Context, config *RateLimi
 		config.ID = "default-config"
 	}
 
+	if config.Source == "" {
+		config.Source = domain.RateLimitSourceDefault
+	}
+
 	config.UpdatedAt = time.Now()
 
 	// Serialize whitelist IPs to JSON
@@ -61,9 +66,16 @@ func (r *RateLimitConfigRepository) Update(ctx context.Context, config *RateLimi
 		return fmt.Errorf("failed to marshal whitelist IPs: %w", err)
 	}
 
+	// updated_by stays NULL for changes not made by a staff member
+	var updatedBy interface{}
+	if config.UpdatedBy != "" {
+		updatedBy = config.UpdatedBy
+	}
+
 	// Try to update first
 	updateQuery := `
 		UPDATE rate_limit_config SET
+			enabled = ?,
 			max_attempts_per_ip = ?,
 			max_attempts_per_user = ?,
 			window_size_minutes = ?,
@@ -72,11 +84,15 @@ func (r *RateLimitConfigRepository) Update(ctx context.Context, config *RateLimi
 			max_lockout_hours = ?,
 			whitelist_ips = ?,
 			enable_progressive_lockout = ?,
+			source = ?,
+			config_hash = ?,
+			updated_by = ?,
 			updated_at = ?
 		WHERE id = ?
 	`
 
 	result, err := r.getExecutor(ctx).ExecContext(ctx, updateQuery,
+		config.Enabled,
 		config.MaxAttemptsPerIP,
 		config.MaxAttemptsPerUser,
 		config.WindowSizeMinutes,
@@ -85,6 +101,9 @@ func (r *RateLimitConfigRepository) Update(ctx context.Context, config *RateLimi
 		config.MaxLockoutHours,
 		string(whitelistJSON),
 		config.EnableProgressiveLockout,
+		config.Source,
+		config.ConfigHash,
+		updatedBy,
 		config.UpdatedAt.Format(time.RFC3339),
 		config.ID,
 	)
@@ -104,15 +123,17 @@ func (r *RateLimitConfigRepository) Update(ctx context.Context, config *RateLimi
 
 		insertQuery := `
 			INSERT INTO rate_limit_config (
-				id, max_attempts_per_ip, max_attempts_per_user, window_size_minutes,
+				id, enabled, max_attempts_per_ip, max_attempts_per_user, window_size_minutes,
 				lockout_duration_minutes, backoff_multiplier, max_lockout_hours,
-				whitelist_ips, enable_progressive_lockout, created_at, updated_at
+				whitelist_ips, enable_progressive_lockout, source, config_hash, updated_by,
+				created_at, updated_at
 			)
-			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 		`
 
 		_, err = r.getExecutor(ctx).ExecContext(ctx, insertQuery,
 			config.ID,
+			config.Enabled,
 			config.MaxAttemptsPerIP,
 			config.MaxAttemptsPerUser,
 			config.WindowSizeMinutes,
@@ -121,6 +142,9 @@ func (r *RateLimitConfigRepository) Update(ctx context.Context, config *RateLimi
 			config.MaxLockoutHours,
 			string(whitelistJSON),
 			config.EnableProgressiveLockout,
+			config.Source,
+			config.ConfigHash,
+			updatedBy,
 			config.CreatedAt.Format(time.RFC3339),
 			config.UpdatedAt.Format(time.RFC3339),
 		)
@@ -137,6 +161,7 @@ func (r *RateLimitConfigRepository) Update(ctx context.Context, config *RateLimi
 func (r *RateLimitConfigRepository) GetDefault() *RateLimitConfig {
 	return &RateLimitConfig{
 		ID:                       "default-config",
+		Enabled:                  true,
 		MaxAttemptsPerIP:         5,
 		MaxAttemptsPerUser:       3,
 		WindowSizeMinutes:        15,
@@ -145,6 +170,7 @@ func (r *RateLimitConfigRepository) GetDefault() *RateLimitConfig {
 		MaxLockoutHours:          24,
 		WhitelistIPs:             []string{},
 		EnableProgressiveLockout: true,
+		Source:                   domain.RateLimitSourceDefault,
 		CreatedAt:                time.Now(),
 		UpdatedAt:                time.Now(),
 	}
@@ -163,9 +189,11 @@ func (r *RateLimitConfigRepository) scanRateLimitConfig(scanner scanner) (*RateL
 	var config RateLimitConfig
 	var createdAtStr, updatedAtStr string
 	var whitelistJSON string
+	var updatedBy sql.NullString
 
 	err := scanner.Scan(
 		&config.ID,
+		&config.Enabled,
 		&config.MaxAttemptsPerIP,
 		&config.MaxAttemptsPerUser,
 		&config.WindowSizeMinutes,
@@ -174,13 +202,23 @@ func (r *RateLimitConfigRepository) scanRateLimitConfig(scanner scanner) (*RateL
 		&config.MaxLockoutHours,
 		&whitelistJSON,
 		&config.EnableProgressiveLockout,
+		&config.Source,
+		&config.ConfigHash,
+		&updatedBy,
 		&createdAtStr,
 		&updatedAtStr,
 	)
 	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, err // Return sql.ErrNoRows as-is
+		}
 		return nil, fmt.Errorf("failed to scan rate limit config: %w", err)
 	}
 
+	if updatedBy.Valid {
+		config.UpdatedBy = updatedBy.String
+	}
+
 	// Parse whitelist IPs from JSON
 	err = json.Unmarshal([]byte(whitelistJSON), &config.WhitelistIPs)
 	if err != nil {
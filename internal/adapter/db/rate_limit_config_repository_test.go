@@ -0,0 +1,46 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"shien-system/internal/domain"
+)
+
+func TestRateLimitConfigRepository_GetAndUpdate(t *testing.T) {
+	db := setupTestDatabase(t)
+	defer db.Close()
+
+	ctx := context.Background()
+	repo := NewRateLimitConfigRepository(db)
+
+	// The migration seeds the default policy
+	config, err := repo.Get(ctx)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if !config.Enabled || config.Source != domain.RateLimitSourceDefault || config.UpdatedBy != "" {
+		t.Errorf("Get() seeded config = %+v", config)
+	}
+
+	config.Enabled = false
+	config.MaxAttemptsPerIP = 20
+	config.WhitelistIPs = []string{"10.0.0.0/8"}
+	config.Source = domain.RateLimitSourceAdmin
+	config.ConfigHash = "abc123"
+	config.UpdatedBy = "admin-001"
+	if err := repo.Update(ctx, config); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	stored, err := repo.Get(ctx)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if stored.Enabled || stored.MaxAttemptsPerIP != 20 || len(stored.WhitelistIPs) != 1 {
+		t.Errorf("Get() after update = %+v", stored)
+	}
+	if stored.Source != domain.RateLimitSourceAdmin || stored.ConfigHash != "abc123" || stored.UpdatedBy != "admin-001" {
+		t.Errorf("Get() provenance = %s/%s/%s", stored.Source, stored.ConfigHash, stored.UpdatedBy)
+	}
+}
@@ -0,0 +1,111 @@
+package db
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"shien-system/internal/adapter/crypto"
+	"shien-system/internal/domain"
+)
+
+func TestRecipientRepository_DisabilityClassification(t *testing.T) {
+	database, err := NewDatabase(Config{Path: filepath.Join(t.TempDir(), "test.db")})
+	if err != nil {
+		t.Fatalf("failed to create database: %v", err)
+	}
+	defer database.Close()
+
+	ctx := context.Background()
+	if err := database.RunMigrations(ctx); err != nil {
+		t.Fatalf("RunMigrations() error = %v", err)
+	}
+
+	cipher, err := crypto.NewFieldCipherWithKey(bytes.Repeat([]byte{1}, 32))
+	if err != nil {
+		t.Fatal(err)
+	}
+	repo := &RecipientRepository{db: database, cipher: cipher}
+
+	now := time.Now().UTC().Truncate(time.Second)
+	renewal := time.Date(2028, 6, 30, 0, 0, 0, 0, time.UTC)
+	recipient := &domain.Recipient{
+		ID:              "recipient-001",
+		Name:            "山田太郎",
+		Sex:             domain.SexMale,
+		BirthDate:       time.Date(1990, 5, 15, 0, 0, 0, 0, time.UTC),
+		HasDisabilityID: true,
+		Handbooks: []domain.DisabilityHandbook{
+			{Type: domain.HandbookTypeIntellectual, Number: "第12345号", Grade: "B1", RenewalDate: &renewal},
+			{Type: domain.HandbookTypePhysical, Number: "横第678号", Grade: "2級"},
+		},
+		SupportCategory: &domain.SupportCategory{
+			Level:      3,
+			ValidFrom:  time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC),
+			ValidUntil: time.Date(2028, 3, 31, 0, 0, 0, 0, time.UTC),
+		},
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := repo.Create(ctx, recipient); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	// The structured data is never stored in plain text
+	var handbooks, disease []byte
+	if err := database.DB().QueryRowContext(ctx,
+		`SELECT handbooks_cipher, intractable_disease_cipher FROM recipients WHERE id = ?`, recipient.ID,
+	).Scan(&handbooks, &disease); err != nil {
+		t.Fatalf("query ciphers: %v", err)
+	}
+	if len(handbooks) == 0 || bytes.Contains(handbooks, []byte("第12345号")) {
+		t.Errorf("handbooks_cipher = %q, want ciphertext", handbooks)
+	}
+	if disease != nil {
+		t.Errorf("intractable_disease_cipher = %q, want NULL when not set", disease)
+	}
+
+	stored, err := repo.GetByID(ctx, recipient.ID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if !reflect.DeepEqual(stored.Handbooks, recipient.Handbooks) {
+		t.Errorf("Handbooks = %+v, want %+v", stored.Handbooks, recipient.Handbooks)
+	}
+	if !reflect.DeepEqual(stored.SupportCategory, recipient.SupportCategory) {
+		t.Errorf("SupportCategory = %+v, want %+v", stored.SupportCategory, recipient.SupportCategory)
+	}
+	if stored.IntractableDisease != nil {
+		t.Errorf("IntractableDisease = %+v, want nil", stored.IntractableDisease)
+	}
+
+	// Clearing the support category and adding a designated disease
+	stored.SupportCategory = nil
+	stored.IntractableDisease = &domain.IntractableDisease{Name: "パーキンソン病", ValidUntil: &renewal}
+	stored.UpdatedAt = now
+	if err := repo.Update(ctx, stored); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	listed, err := repo.List(ctx, 10, 0)
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(listed) != 1 {
+		t.Fatalf("List() = %d recipients, want 1", len(listed))
+	}
+	got := listed[0]
+	if got.SupportCategory != nil {
+		t.Errorf("SupportCategory = %+v, want nil after clearing", got.SupportCategory)
+	}
+	if got.IntractableDisease == nil || got.IntractableDisease.Name != "パーキンソン病" ||
+		!got.IntractableDisease.ValidUntil.Equal(renewal) {
+		t.Errorf("IntractableDisease = %+v", got.IntractableDisease)
+	}
+	if len(got.Handbooks) != 2 {
+		t.Errorf("Handbooks = %+v, want both handbooks kept", got.Handbooks)
+	}
+}
@@ -0,0 +1,250 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"shien-system/internal/adapter/crypto"
+	"shien-system/internal/domain"
+)
+
+// RecipientMergeRepository implements domain.RecipientMergeRepository
+type RecipientMergeRepository struct {
+	db     *Database
+	cipher *crypto.FieldCipher
+}
+
+// NewRecipientMergeRepository creates a new recipient merge repository
+func NewRecipientMergeRepository(db *Database, cipher *crypto.FieldCipher) *RecipientMergeRepository {
+	return &RecipientMergeRepository{
+		db:     db,
+		cipher: cipher,
+	}
+}
+
+// mergedRecord is the encrypted snapshot of the removed duplicate
+type mergedRecord struct {
+	Recipient domain.Recipient      `json:"recipient"`
+	Medical   *domain.MedicalRecord `json:"medical,omitempty"`
+}
+
+// Merge moves the certificates, assignments, consents, enrollment periods,
+// emergency contacts, medical record and incident links of the merged
+// recipient to the survivor, stores the history entry and deletes the
+// merged recipient, all in one transaction. Records the survivor already
+// has (its medical record, an active assignment to the same staff member)
+// are not moved; they stay in the history snapshot.
+func (r *RecipientMergeRepository) Merge(ctx context.Context, merge *domain.RecipientMerge) error {
+	mergedID, survivorID := merge.Merged.ID, merge.SurvivorID
+	if mergedID == survivorID {
+		return &domain.RepositoryError{Op: "merge recipients", Err: domain.ErrInvalidInput}
+	}
+
+	snapshot, err := json.Marshal(mergedRecord{Recipient: merge.Merged, Medical: merge.MergedMedical})
+	if err != nil {
+		return &domain.RepositoryError{Op: "marshal merged recipient", Err: err}
+	}
+	dataCipher, err := r.cipher.Encrypt(string(snapshot))
+	if err != nil {
+		return &domain.RepositoryError{Op: "encrypt merged recipient", Err: err}
+	}
+	reasonCipher, err := r.cipher.Encrypt(merge.Reason)
+	if err != nil {
+		return &domain.RepositoryError{Op: "encrypt merge reason", Err: err}
+	}
+	mergedAt := merge.MergedAt.Format(time.RFC3339)
+
+	return r.db.WithTransaction(ctx, func(ctx context.Context) error {
+		executor := r.getExecutor(ctx)
+
+		var found int
+		if err := executor.QueryRowContext(ctx, `SELECT COUNT(*) FROM recipients WHERE id IN (?, ?)`,
+			survivorID, mergedID).Scan(&found); err != nil {
+			return &domain.RepositoryError{Op: "check recipients", Err: err}
+		}
+		if found != 2 {
+			return domain.ErrNotFound
+		}
+
+		var moved domain.RecipientMergeCounts
+		move := func(op string, count *int, query string, args ...interface{}) error {
+			result, err := executor.ExecContext(ctx, query, args...)
+			if err != nil {
+				return &domain.RepositoryError{Op: op, Err: err}
+			}
+			if count != nil {
+				affected, err := result.RowsAffected()
+				if err != nil {
+					return &domain.RepositoryError{Op: "check rows affected", Err: err}
+				}
+				*count = int(affected)
+			}
+			return nil
+		}
+
+		// Versioned rows are bumped so that an edit opened before the merge
+		// cannot move a certificate back to the deleted recipient
+		if err := move("move certificates", &moved.Certificates,
+			`UPDATE benefit_certificates SET recipient_id = ?, version = version + 1 WHERE recipient_id = ?`,
+			survivorID, mergedID); err != nil {
+			return err
+		}
+
+		// One active assignment per staff member: close the duplicate first
+		if err := move("close duplicate assignments", nil, `
+			UPDATE staff_assignments SET unassigned_at = ?
+			WHERE recipient_id = ? AND unassigned_at IS NULL AND staff_id IN (
+				SELECT staff_id FROM staff_assignments WHERE recipient_id = ? AND unassigned_at IS NULL)`,
+			mergedAt, mergedID, survivorID); err != nil {
+			return err
+		}
+		if err := move("move assignments", &moved.Assignments,
+			`UPDATE OR IGNORE staff_assignments SET recipient_id = ? WHERE recipient_id = ?`,
+			survivorID, mergedID); err != nil {
+			return err
+		}
+
+		if err := move("move consents", &moved.Consents,
+			`UPDATE consents SET recipient_id = ? WHERE recipient_id = ?`,
+			survivorID, mergedID); err != nil {
+			return err
+		}
+		if err := move("move enrollment periods", &moved.EnrollmentPeriods,
+			`UPDATE enrollment_periods SET recipient_id = ? WHERE recipient_id = ?`,
+			survivorID, mergedID); err != nil {
+			return err
+		}
+		if err := move("move emergency contacts", &moved.EmergencyContacts,
+			`UPDATE emergency_contacts SET recipient_id = ? WHERE recipient_id = ?`,
+			survivorID, mergedID); err != nil {
+			return err
+		}
+
+		var medicalMoved int
+		if err := move("move medical record", &medicalMoved,
+			`UPDATE OR IGNORE medical_records SET recipient_id = ? WHERE recipient_id = ?`,
+			survivorID, mergedID); err != nil {
+			return err
+		}
+		moved.MedicalRecord = medicalMoved > 0
+
+		// incident_recipients has no ON DELETE CASCADE
+		if err := move("move incidents", &moved.Incidents, `
+			INSERT OR IGNORE INTO incident_recipients (incident_id, recipient_id)
+			SELECT incident_id, ? FROM incident_recipients WHERE recipient_id = ?`,
+			survivorID, mergedID); err != nil {
+			return err
+		}
+		if err := move("unlink incidents", nil,
+			`DELETE FROM incident_recipients WHERE recipient_id = ?`, mergedID); err != nil {
+			return err
+		}
+
+		// Notifications about the removed record no longer apply
+		if err := move("resolve notifications", nil, `
+			UPDATE notifications SET resolved_at = ?
+			WHERE target_type = 'recipient' AND target_id = ? AND resolved_at IS NULL`,
+			mergedAt, mergedID); err != nil {
+			return err
+		}
+
+		movedJSON, err := json.Marshal(moved)
+		if err != nil {
+			return &domain.RepositoryError{Op: "marshal moved counts", Err: err}
+		}
+		if err := move("create recipient merge", nil, `
+			INSERT INTO recipient_merges (
+				id, survivor_id, merged_id, merged_data_cipher, moved_counts, reason_cipher, merged_by, merged_at
+			) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+			merge.ID, survivorID, mergedID, dataCipher, string(movedJSON), reasonCipher, merge.MergedBy, mergedAt); err != nil {
+			return err
+		}
+
+		// Whatever could not be moved is deleted with the recipient
+		if err := move("delete merged recipient", nil,
+			`DELETE FROM recipients WHERE id = ?`, mergedID); err != nil {
+			return err
+		}
+
+		merge.Moved = moved
+		return nil
+	})
+}
+
+// GetBySurvivorID returns the merges into a recipient, newest first
+func (r *RecipientMergeRepository) GetBySurvivorID(ctx context.Context, survivorID domain.ID) ([]*domain.RecipientMerge, error) {
+	query := `
+		SELECT id, survivor_id, merged_data_cipher, moved_counts, reason_cipher, merged_by, merged_at
+		FROM recipient_merges
+		WHERE survivor_id = ?
+		ORDER BY merged_at DESC`
+
+	executor := r.getExecutor(ctx)
+	rows, err := executor.QueryContext(ctx, query, survivorID)
+	if err != nil {
+		return nil, &domain.RepositoryError{Op: "get recipient merges", Err: err}
+	}
+	defer rows.Close()
+
+	var merges []*domain.RecipientMerge
+	for rows.Next() {
+		merge, err := r.scanMerge(rows)
+		if err != nil {
+			return nil, err
+		}
+		merges = append(merges, merge)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, &domain.RepositoryError{Op: "rows iteration", Err: err}
+	}
+
+	return merges, nil
+}
+
+// scanMerge scans and decrypts a merge history entry
+func (r *RecipientMergeRepository) scanMerge(row scanner) (*domain.RecipientMerge, error) {
+	var merge domain.RecipientMerge
+	var dataCipher, reasonCipher []byte
+	var movedJSON, mergedAtStr string
+
+	if err := row.Scan(&merge.ID, &merge.SurvivorID, &dataCipher, &movedJSON, &reasonCipher,
+		&merge.MergedBy, &mergedAtStr); err != nil {
+		return nil, &domain.RepositoryError{Op: "scan recipient merge", Err: err}
+	}
+
+	data, err := r.cipher.Decrypt(dataCipher)
+	if err != nil {
+		return nil, &domain.RepositoryError{Op: "decrypt merged recipient", Err: err}
+	}
+	var snapshot mergedRecord
+	if err := json.Unmarshal([]byte(data), &snapshot); err != nil {
+		return nil, &domain.RepositoryError{Op: "unmarshal merged recipient", Err: err}
+	}
+	merge.Merged = snapshot.Recipient
+	merge.MergedMedical = snapshot.Medical
+
+	if len(reasonCipher) > 0 {
+		if merge.Reason, err = r.cipher.Decrypt(reasonCipher); err != nil {
+			return nil, &domain.RepositoryError{Op: "decrypt merge reason", Err: err}
+		}
+	}
+	if err := json.Unmarshal([]byte(movedJSON), &merge.Moved); err != nil {
+		return nil, &domain.RepositoryError{Op: "unmarshal moved counts", Err: err}
+	}
+	if merge.MergedAt, err = time.Parse(time.RFC3339, mergedAtStr); err != nil {
+		return nil, &domain.RepositoryError{Op: "parse merged_at", Err: err}
+	}
+
+	return &merge, nil
+}
+
+// getExecutor returns either a transaction or the database connection
+func (r *RecipientMergeRepository) getExecutor(ctx context.Context) executor {
+	if tx := ctx.Value("tx"); tx != nil {
+		return tx.(*sql.Tx)
+	}
+	return r.db.DB()
+}
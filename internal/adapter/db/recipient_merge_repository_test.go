@@ -0,0 +1,149 @@
+package db
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"shien-system/internal/adapter/crypto"
+	"shien-system/internal/domain"
+)
+
+func TestRecipientMergeRepository_Merge(t *testing.T) {
+	database, err := NewDatabase(Config{Path: filepath.Join(t.TempDir(), "test.db")})
+	if err != nil {
+		t.Fatalf("failed to create database: %v", err)
+	}
+	defer database.Close()
+
+	ctx := context.Background()
+	if err := database.RunMigrations(ctx); err != nil {
+		t.Fatalf("RunMigrations() error = %v", err)
+	}
+
+	cipher, err := crypto.NewFieldCipherWithKey(bytes.Repeat([]byte{1}, 32))
+	if err != nil {
+		t.Fatal(err)
+	}
+	encrypt := func(value string) []byte {
+		ciphertext, err := cipher.Encrypt(value)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return ciphertext
+	}
+
+	now := "2026-10-18T09:00:00Z"
+	exec := func(query string, args ...interface{}) {
+		t.Helper()
+		if _, err := database.DB().ExecContext(ctx, query, args...); err != nil {
+			t.Fatalf("exec %q: %v", query, err)
+		}
+	}
+	count := func(query string, args ...interface{}) int {
+		t.Helper()
+		var n int
+		if err := database.DB().QueryRowContext(ctx, query, args...).Scan(&n); err != nil {
+			t.Fatalf("query %q: %v", query, err)
+		}
+		return n
+	}
+	insertRecipient := func(id string) {
+		exec(`INSERT INTO recipients (id, name_cipher, sex_cipher, birth_date_cipher, has_disability_id_cipher,
+			public_assistance_cipher, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+			id, encrypt("山田太郎"), encrypt("male"), encrypt("1980-01-01T00:00:00Z"),
+			encrypt("true"), encrypt("false"), now, now)
+	}
+
+	exec(`INSERT INTO staff (id, name, role, password_hash, created_at, updated_at) VALUES ('s-1', '職員', 'staff', 'x', ?, ?)`, now, now)
+	exec(`INSERT INTO staff (id, name, role, password_hash, created_at, updated_at) VALUES ('s-2', '職員2', 'staff', 'x', ?, ?)`, now, now)
+	insertRecipient("r-keep")
+	insertRecipient("r-dup")
+	exec(`INSERT INTO benefit_certificates (id, recipient_id, start_date, end_date, issuer_cipher, created_at, updated_at)
+		VALUES ('c-1', 'r-dup', '2026-04-01', '2027-03-31', ?, ?, ?)`, encrypt("横浜市"), now, now)
+	// s-1 is assigned to both records, s-2 only to the duplicate
+	exec(`INSERT INTO staff_assignments (id, recipient_id, staff_id, assigned_at) VALUES ('a-1', 'r-keep', 's-1', ?)`, now)
+	exec(`INSERT INTO staff_assignments (id, recipient_id, staff_id, assigned_at) VALUES ('a-2', 'r-dup', 's-1', ?)`, now)
+	exec(`INSERT INTO staff_assignments (id, recipient_id, staff_id, assigned_at) VALUES ('a-3', 'r-dup', 's-2', ?)`, now)
+	exec(`INSERT INTO consents (id, recipient_id, staff_id, consent_type, content_cipher, method_cipher, obtained_at)
+		VALUES ('k-1', 'r-dup', 's-1', 'personal_info', ?, ?, ?)`, encrypt("同意"), encrypt("書面"), now)
+	exec(`INSERT INTO medical_records (recipient_id, has_epilepsy, created_at, updated_at) VALUES ('r-keep', 0, ?, ?)`, now, now)
+	exec(`INSERT INTO medical_records (recipient_id, has_epilepsy, created_at, updated_at) VALUES ('r-dup', 1, ?, ?)`, now, now)
+	exec(`INSERT INTO incident_reports (id, occurred_at, category, severity, reported_by, created_at, updated_at)
+		VALUES ('i-1', ?, 'fall', 'minor', 's-1', ?, ?)`, now, now, now)
+	exec(`INSERT INTO incident_recipients (incident_id, recipient_id) VALUES ('i-1', 'r-dup')`)
+	exec(`INSERT INTO notifications (id, kind, severity, title, target_type, target_id, dedup_key, created_at)
+		VALUES ('n-1', 'discharged_assigned', 'warning', '退所済み', 'recipient', 'r-dup', 'discharged:r-dup', ?)`, now)
+
+	repo := NewRecipientMergeRepository(database, cipher)
+	mergedAt := time.Date(2026, 10, 18, 10, 0, 0, 0, time.UTC)
+	merge := &domain.RecipientMerge{
+		ID:            "m-1",
+		SurvivorID:    "r-keep",
+		Merged:        domain.Recipient{ID: "r-dup", Name: "山田太郎", Kana: "ヤマダタロウ"},
+		MergedMedical: &domain.MedicalRecord{RecipientID: "r-dup", HasEpilepsy: true},
+		Reason:        "二重登録",
+		MergedBy:      "s-1",
+		MergedAt:      mergedAt,
+	}
+	if err := repo.Merge(ctx, merge); err != nil {
+		t.Fatalf("Merge() error = %v", err)
+	}
+
+	want := domain.RecipientMergeCounts{Certificates: 1, Assignments: 2, Consents: 1, Incidents: 1}
+	if merge.Moved != want {
+		t.Errorf("Moved = %+v, want %+v", merge.Moved, want)
+	}
+
+	if n := count(`SELECT COUNT(*) FROM recipients WHERE id = 'r-dup'`); n != 0 {
+		t.Errorf("merged recipient still exists")
+	}
+	if n := count(`SELECT COUNT(*) FROM benefit_certificates WHERE recipient_id = 'r-keep' AND version = 2`); n != 1 {
+		t.Errorf("certificates on survivor = %d, want 1 with bumped version", n)
+	}
+	if n := count(`SELECT COUNT(*) FROM staff_assignments WHERE recipient_id = 'r-keep' AND unassigned_at IS NULL`); n != 2 {
+		t.Errorf("active assignments on survivor = %d, want 2", n)
+	}
+	if n := count(`SELECT COUNT(*) FROM staff_assignments WHERE id = 'a-2' AND recipient_id = 'r-keep' AND unassigned_at IS NOT NULL`); n != 1 {
+		t.Errorf("duplicate assignment was not closed and moved")
+	}
+	if n := count(`SELECT COUNT(*) FROM consents WHERE recipient_id = 'r-keep'`); n != 1 {
+		t.Errorf("consents on survivor = %d, want 1", n)
+	}
+	if n := count(`SELECT COUNT(*) FROM medical_records WHERE recipient_id = 'r-keep' AND has_epilepsy = 0`); n != 1 {
+		t.Errorf("survivor's medical record was overwritten")
+	}
+	if n := count(`SELECT COUNT(*) FROM incident_recipients WHERE recipient_id = 'r-keep'`); n != 1 {
+		t.Errorf("incident link was not moved")
+	}
+	if n := count(`SELECT COUNT(*) FROM notifications WHERE id = 'n-1' AND resolved_at IS NOT NULL`); n != 1 {
+		t.Errorf("notification about the merged recipient is still open")
+	}
+
+	history, err := repo.GetBySurvivorID(ctx, "r-keep")
+	if err != nil {
+		t.Fatalf("GetBySurvivorID() error = %v", err)
+	}
+	if len(history) != 1 {
+		t.Fatalf("history = %d entries, want 1", len(history))
+	}
+	got := history[0]
+	if got.Merged.ID != "r-dup" || got.Merged.Kana != "ヤマダタロウ" || got.Reason != "二重登録" || !got.MergedAt.Equal(mergedAt) {
+		t.Errorf("history = %+v", got)
+	}
+	if got.MergedMedical == nil || !got.MergedMedical.HasEpilepsy {
+		t.Errorf("merged medical record was not kept in history")
+	}
+	if got.Moved != want {
+		t.Errorf("history Moved = %+v, want %+v", got.Moved, want)
+	}
+
+	// The merged recipient is gone, so merging it again fails
+	again := *merge
+	again.ID = "m-2"
+	if err := repo.Merge(ctx, &again); err != domain.ErrNotFound {
+		t.Errorf("second Merge() error = %v, want ErrNotFound", err)
+	}
+}
@@ -2,9 +2,8 @@ package db
 
 import (
 	"context"
-	"crypto/sha256"
 	"database/sql"
-	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"strings"
 	"strconv"
@@ -12,8 +11,12 @@ import (
 
 	"shien-system/internal/adapter/crypto"
 	"shien-system/internal/domain"
+	"shien-system/internal/validation"
 )
 
+// recipientSearchPageSize is the number of recipients decrypted per page by Search
+const recipientSearchPageSize = 500
+
 // RecipientRepository implements domain.RecipientRepository
 type RecipientRepository struct {
 	db     *Database
@@ -40,8 +43,10 @@ func (r *RecipientRepository) Create(ctx context.Context, recipient *domain.Reci
 			id, name_cipher, kana_cipher, sex_cipher, birth_date_cipher,
 			disability_name_cipher, has_disability_id_cipher, grade_cipher,
 			address_cipher, phone_cipher, email_cipher, public_assistance_cipher,
-			admission_date, discharge_date, created_at, updated_at
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+			admission_date, discharge_date, created_at, updated_at,
+			postal_code_cipher, prefecture_cipher, city_cipher, street_cipher,
+			handbooks_cipher, support_category_cipher, intractable_disease_cipher
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
 
 	// Encrypt fields
 	nameCipher, err := r.cipher.Encrypt(recipient.Name)
@@ -99,6 +104,16 @@ func (r *RecipientRepository) Create(ctx context.Context, recipient *domain.Reci
 		return &domain.RepositoryError{Op: "encrypt public_assistance", Err: err}
 	}
 
+	addressCiphers, err := r.encryptAddress(recipient)
+	if err != nil {
+		return err
+	}
+
+	disabilityCiphers, err := r.encryptDisability(recipient)
+	if err != nil {
+		return err
+	}
+
 	// Handle optional dates
 	var admissionDate, dischargeDate *string
 	if recipient.AdmissionDate != nil {
@@ -119,11 +134,14 @@ func (r *RecipientRepository) Create(ctx context.Context, recipient *domain.Reci
 		admissionDate, dischargeDate,
 		recipient.CreatedAt.Format(time.RFC3339),
 		recipient.UpdatedAt.Format(time.RFC3339),
+		addressCiphers.postalCode, addressCiphers.prefecture, addressCiphers.city, addressCiphers.street,
+		disabilityCiphers.handbooks, disabilityCiphers.supportCategory, disabilityCiphers.intractableDisease,
 	)
 
 	if err != nil {
 		return &domain.RepositoryError{Op: "create recipient", Err: err}
 	}
+	recipient.Version = 1
 
 	// Clear sensitive encrypted data from memory
 	crypto.ClearBytes(nameCipher)
@@ -137,6 +155,8 @@ func (r *RecipientRepository) Create(ctx context.Context, recipient *domain.Reci
 	crypto.ClearBytes(phoneCipher)
 	crypto.ClearBytes(emailCipher)
 	crypto.ClearBytes(publicAssistanceCipher)
+	addressCiphers.clear()
+	disabilityCiphers.clear()
 
 	return nil
 }
@@ -147,7 +167,9 @@ func (r *RecipientRepository) GetByID(ctx context.Context, id domain.ID) (*domai
 		SELECT id, name_cipher, kana_cipher, sex_cipher, birth_date_cipher,
 			   disability_name_cipher, has_disability_id_cipher, grade_cipher,
 			   address_cipher, phone_cipher, email_cipher, public_assistance_cipher,
-			   admission_date, discharge_date, created_at, updated_at
+			   admission_date, discharge_date, created_at, updated_at, version,
+			   postal_code_cipher, prefecture_cipher, city_cipher, street_cipher,
+			   handbooks_cipher, support_category_cipher, intractable_disease_cipher
 		FROM recipients 
 		WHERE id = ?`
 
@@ -164,8 +186,11 @@ func (r *RecipientRepository) Update(ctx context.Context, recipient *domain.Reci
 		SET name_cipher = ?, kana_cipher = ?, sex_cipher = ?, birth_date_cipher = ?,
 			disability_name_cipher = ?, has_disability_id_cipher = ?, grade_cipher = ?,
 			address_cipher = ?, phone_cipher = ?, email_cipher = ?, public_assistance_cipher = ?,
-			admission_date = ?, discharge_date = ?, updated_at = ?
-		WHERE id = ?`
+			admission_date = ?, discharge_date = ?, updated_at = ?,
+			postal_code_cipher = ?, prefecture_cipher = ?, city_cipher = ?, street_cipher = ?,
+			handbooks_cipher = ?, support_category_cipher = ?, intractable_disease_cipher = ?,
+			version = version + 1
+		WHERE id = ? AND version = ?`
 
 	// Encrypt fields
 	nameCipher, err := r.cipher.Encrypt(recipient.Name)
@@ -223,6 +248,16 @@ func (r *RecipientRepository) Update(ctx context.Context, recipient *domain.Reci
 		return &domain.RepositoryError{Op: "encrypt public_assistance", Err: err}
 	}
 
+	addressCiphers, err := r.encryptAddress(recipient)
+	if err != nil {
+		return err
+	}
+
+	disabilityCiphers, err := r.encryptDisability(recipient)
+	if err != nil {
+		return err
+	}
+
 	// Handle optional dates
 	var admissionDate, dischargeDate *string
 	if recipient.AdmissionDate != nil {
@@ -241,7 +276,9 @@ func (r *RecipientRepository) Update(ctx context.Context, recipient *domain.Reci
 		addressCipher, phoneCipher, emailCipher, publicAssistanceCipher,
 		admissionDate, dischargeDate,
 		recipient.UpdatedAt.Format(time.RFC3339),
-		recipient.ID,
+		addressCiphers.postalCode, addressCiphers.prefecture, addressCiphers.city, addressCiphers.street,
+		disabilityCiphers.handbooks, disabilityCiphers.supportCategory, disabilityCiphers.intractableDisease,
+		recipient.ID, recipient.Version,
 	)
 
 	if err != nil {
@@ -254,8 +291,9 @@ func (r *RecipientRepository) Update(ctx context.Context, recipient *domain.Reci
 	}
 
 	if rowsAffected == 0 {
-		return domain.ErrNotFound
+		return versionMismatchError(ctx, executor, "recipients", recipient.ID)
 	}
+	recipient.Version++
 
 	// Clear sensitive encrypted data from memory
 	crypto.ClearBytes(nameCipher)
@@ -269,6 +307,8 @@ func (r *RecipientRepository) Update(ctx context.Context, recipient *domain.Reci
 	crypto.ClearBytes(phoneCipher)
 	crypto.ClearBytes(emailCipher)
 	crypto.ClearBytes(publicAssistanceCipher)
+	addressCiphers.clear()
+	disabilityCiphers.clear()
 
 	return nil
 }
@@ -301,7 +341,9 @@ func (r *RecipientRepository) List(ctx context.Context, limit, offset int) ([]*d
 		SELECT id, name_cipher, kana_cipher, sex_cipher, birth_date_cipher,
 			   disability_name_cipher, has_disability_id_cipher, grade_cipher,
 			   address_cipher, phone_cipher, email_cipher, public_assistance_cipher,
-			   admission_date, discharge_date, created_at, updated_at
+			   admission_date, discharge_date, created_at, updated_at, version,
+			   postal_code_cipher, prefecture_cipher, city_cipher, street_cipher,
+			   handbooks_cipher, support_category_cipher, intractable_disease_cipher
 		FROM recipients 
 		ORDER BY created_at DESC
 		LIMIT ? OFFSET ?`
@@ -338,102 +380,46 @@ func (r *RecipientRepository) List(ctx context.Context, limit, offset int) ([]*d
 	return recipients, nil
 }
 
-// Search searches recipients by query (placeholder implementation)
+// Search searches recipients whose name or kana contains query. Both sides
+// are compared as validation.NormalizeSearch keys, so やまだ, ﾔﾏﾀﾞ and ヤマダ
+// match each other and rows stored before normalization are still found.
 func (r *RecipientRepository) Search(ctx context.Context, query string, limit, offset int) ([]*domain.Recipient, error) {
-	if query == "" {
+	search := validation.NormalizeSearch(query)
+	if search == "" {
 		return r.List(ctx, limit, offset)
 	}
 
-	// Use cached hash-based search for better performance
-	// This assumes search hashes are stored for encrypted fields
-	sqlQuery := `
-		SELECT id, name_cipher, kana_cipher, sex_cipher, birth_date_cipher,
-			   disability_name_cipher, has_disability_id_cipher, grade_cipher,
-			   address_cipher, phone_cipher, email_cipher, public_assistance_cipher,
-			   admission_date, discharge_date, created_at, updated_at
-		FROM recipients 
-		WHERE id IN (
-			SELECT DISTINCT recipient_id FROM search_index 
-			WHERE search_hash = ?
-		)
-		ORDER BY created_at DESC
-		LIMIT ? OFFSET ?`
-
-	// Create a search hash from the query (simplified implementation)
-	searchHash := r.createSearchHash(query)
-	
-	executor := r.getExecutor(ctx)
-	stmt, err := executor.PrepareContext(ctx, sqlQuery)
-	if err != nil {
-		// Fallback to full scan if search index is not available
-		return r.searchFullScan(ctx, query, limit, offset)
-	}
-	defer stmt.Close()
-	
-	rows, err := stmt.QueryContext(ctx, searchHash, limit, offset)
-	if err != nil {
-		return nil, &domain.RepositoryError{Op: "search recipients", Err: err}
-	}
-	defer rows.Close()
-
-	recipients := make([]*domain.Recipient, 0, limit)
-	for rows.Next() {
-		recipient, err := r.scanRecipient(rows)
+	// Names are encrypted, so decrypt and search in memory, one page of
+	// recipients at a time until enough matches are found
+	var matched []*domain.Recipient
+	for page := 0; len(matched) < offset+limit; page += recipientSearchPageSize {
+		recipients, err := r.List(ctx, recipientSearchPageSize, page)
 		if err != nil {
 			return nil, err
 		}
-		recipients = append(recipients, recipient)
-	}
-
-	if err := rows.Err(); err != nil {
-		return nil, &domain.RepositoryError{Op: "search rows iteration", Err: err}
-	}
 
-	return recipients, nil
-}
-
-// createSearchHash creates a hash for search indexing
-func (r *RecipientRepository) createSearchHash(query string) string {
-	// Normalize the search query for consistent hashing
-	normalized := strings.ToLower(strings.TrimSpace(query))
-	// Use SHA-256 for search hash (not for security, but for consistency)
-	hash := sha256.Sum256([]byte(normalized))
-	return hex.EncodeToString(hash[:])
-}
-
-// searchFullScan performs a full table scan when search index is not available
-func (r *RecipientRepository) searchFullScan(ctx context.Context, query string, limit, offset int) ([]*domain.Recipient, error) {
-	// This is a fallback method - decrypt and search in memory
-	// Not optimal for large datasets, but works when search index is unavailable
-	allRecipients, err := r.List(ctx, 1000, 0) // Limit full scan to 1000 records
-	if err != nil {
-		return nil, err
-	}
-
-	var matched []*domain.Recipient
-	searchLower := strings.ToLower(query)
-	
-	for _, recipient := range allRecipients {
-		// Simple text matching on name and kana
-		if strings.Contains(strings.ToLower(recipient.Name), searchLower) ||
-		   strings.Contains(strings.ToLower(recipient.Kana), searchLower) {
-			matched = append(matched, recipient)
-			if len(matched) >= limit {
-				break
+		for _, recipient := range recipients {
+			if strings.Contains(validation.NormalizeSearch(recipient.Name), search) ||
+				strings.Contains(validation.NormalizeSearch(recipient.Kana), search) {
+				matched = append(matched, recipient)
 			}
 		}
+
+		if len(recipients) < recipientSearchPageSize {
+			break
+		}
 	}
 
 	// Apply offset
 	if offset >= len(matched) {
 		return []*domain.Recipient{}, nil
 	}
-	
+
 	end := offset + limit
 	if end > len(matched) {
 		end = len(matched)
 	}
-	
+
 	return matched[offset:end], nil
 }
 
@@ -443,7 +429,9 @@ func (r *RecipientRepository) GetByStaffID(ctx context.Context, staffID domain.I
 		SELECT r.id, r.name_cipher, r.kana_cipher, r.sex_cipher, r.birth_date_cipher,
 			   r.disability_name_cipher, r.has_disability_id_cipher, r.grade_cipher,
 			   r.address_cipher, r.phone_cipher, r.email_cipher, r.public_assistance_cipher,
-			   r.admission_date, r.discharge_date, r.created_at, r.updated_at
+			   r.admission_date, r.discharge_date, r.created_at, r.updated_at, r.version,
+			   r.postal_code_cipher, r.prefecture_cipher, r.city_cipher, r.street_cipher,
+			   r.handbooks_cipher, r.support_category_cipher, r.intractable_disease_cipher
 		FROM recipients r
 		INNER JOIN staff_assignments sa ON r.id = sa.recipient_id
 		WHERE sa.staff_id = ? AND sa.unassigned_at IS NULL
@@ -472,20 +460,24 @@ func (r *RecipientRepository) GetByStaffID(ctx context.Context, staffID domain.I
 	return recipients, nil
 }
 
-// GetActive retrieves active recipients (not discharged)
-func (r *RecipientRepository) GetActive(ctx context.Context, limit, offset int) ([]*domain.Recipient, error) {
+// GetActive retrieves recipients with an enrollment period covering asOf
+func (r *RecipientRepository) GetActive(ctx context.Context, asOf time.Time, limit, offset int) ([]*domain.Recipient, error) {
 	query := `
 		SELECT id, name_cipher, kana_cipher, sex_cipher, birth_date_cipher,
 			   disability_name_cipher, has_disability_id_cipher, grade_cipher,
 			   address_cipher, phone_cipher, email_cipher, public_assistance_cipher,
-			   admission_date, discharge_date, created_at, updated_at
+			   admission_date, discharge_date, created_at, updated_at, version,
+			   postal_code_cipher, prefecture_cipher, city_cipher, street_cipher,
+			   handbooks_cipher, support_category_cipher, intractable_disease_cipher
 		FROM recipients 
-		WHERE discharge_date IS NULL
-		ORDER BY created_at DESC
+		WHERE ` + enrolledOnCondition + `
+		ORDER BY created_at DESC, id
 		LIMIT ? OFFSET ?`
 
+	asOfStr := asOf.Format(enrolledOnLayout)
+
 	executor := r.getExecutor(ctx)
-	rows, err := executor.QueryContext(ctx, query, limit, offset)
+	rows, err := executor.QueryContext(ctx, query, asOfStr, asOfStr, limit, offset)
 	if err != nil {
 		return nil, &domain.RepositoryError{Op: "get active recipients", Err: err}
 	}
@@ -521,13 +513,15 @@ func (r *RecipientRepository) Count(ctx context.Context) (int, error) {
 	return count, nil
 }
 
-// CountActive returns the number of active recipients
-func (r *RecipientRepository) CountActive(ctx context.Context) (int, error) {
-	query := `SELECT COUNT(*) FROM recipients WHERE discharge_date IS NULL`
+// CountActive returns the number of recipients enrolled on asOf
+func (r *RecipientRepository) CountActive(ctx context.Context, asOf time.Time) (int, error) {
+	query := `SELECT COUNT(*) FROM recipients WHERE ` + enrolledOnCondition
+
+	asOfStr := asOf.Format(enrolledOnLayout)
 
 	executor := r.getExecutor(ctx)
 	var count int
-	err := executor.QueryRowContext(ctx, query).Scan(&count)
+	err := executor.QueryRowContext(ctx, query, asOfStr, asOfStr).Scan(&count)
 	if err != nil {
 		return 0, &domain.RepositoryError{Op: "count active recipients", Err: err}
 	}
@@ -535,6 +529,157 @@ func (r *RecipientRepository) CountActive(ctx context.Context) (int, error) {
 	return count, nil
 }
 
+// enrolledOnLayout formats the date bound to enrolledOnCondition. asOf is
+// formatted in its own location, so a date picked in JST stays on that day
+const enrolledOnLayout = "2006-01-02"
+
+// enrolledOnCondition matches recipients having an enrollment period that
+// covers the bound date (入所日・退所日ともに在籍日として数える). The dates are
+// stored as RFC 3339 in the local offset, so the first 10 characters are the
+// local calendar date; SQLite's date() would convert them to UTC instead
+const enrolledOnCondition = `EXISTS (
+			SELECT 1 FROM enrollment_periods ep
+			WHERE ep.recipient_id = recipients.id
+			  AND substr(ep.admission_date, 1, 10) <= ?
+			  AND (ep.discharge_date IS NULL OR substr(ep.discharge_date, 1, 10) >= ?)
+		)`
+
+// recipientAddressCiphers holds the encrypted address components
+type recipientAddressCiphers struct {
+	postalCode, prefecture, city, street []byte
+}
+
+// clear wipes the ciphertexts from memory
+func (c *recipientAddressCiphers) clear() {
+	crypto.ClearBytes(c.postalCode)
+	crypto.ClearBytes(c.prefecture)
+	crypto.ClearBytes(c.city)
+	crypto.ClearBytes(c.street)
+}
+
+// encryptAddress encrypts the postal code and address components
+func (r *RecipientRepository) encryptAddress(recipient *domain.Recipient) (*recipientAddressCiphers, error) {
+	var ciphers recipientAddressCiphers
+
+	fields := []struct {
+		op     string
+		value  string
+		target *[]byte
+	}{
+		{"encrypt postal_code", recipient.PostalCode, &ciphers.postalCode},
+		{"encrypt prefecture", recipient.Prefecture, &ciphers.prefecture},
+		{"encrypt city", recipient.City, &ciphers.city},
+		{"encrypt street", recipient.Street, &ciphers.street},
+	}
+	for _, field := range fields {
+		encrypted, err := r.cipher.Encrypt(field.value)
+		if err != nil {
+			return nil, &domain.RepositoryError{Op: field.op, Err: err}
+		}
+		*field.target = encrypted
+	}
+
+	return &ciphers, nil
+}
+
+// decryptAddress decrypts the address components; they are NULL for
+// recipients saved before postal codes were introduced
+func (r *RecipientRepository) decryptAddress(recipient *domain.Recipient, ciphers *recipientAddressCiphers) error {
+	fields := []struct {
+		op     string
+		value  []byte
+		target *string
+	}{
+		{"decrypt postal_code", ciphers.postalCode, &recipient.PostalCode},
+		{"decrypt prefecture", ciphers.prefecture, &recipient.Prefecture},
+		{"decrypt city", ciphers.city, &recipient.City},
+		{"decrypt street", ciphers.street, &recipient.Street},
+	}
+	for _, field := range fields {
+		decrypted, err := r.cipher.Decrypt(field.value)
+		if err != nil {
+			return &domain.RepositoryError{Op: field.op, Err: err}
+		}
+		*field.target = decrypted
+	}
+
+	return nil
+}
+
+// recipientDisabilityCiphers holds the encrypted handbooks, support category
+// and intractable disease, each stored as JSON
+type recipientDisabilityCiphers struct {
+	handbooks, supportCategory, intractableDisease []byte
+}
+
+// clear wipes the ciphertexts from memory
+func (c *recipientDisabilityCiphers) clear() {
+	crypto.ClearBytes(c.handbooks)
+	crypto.ClearBytes(c.supportCategory)
+	crypto.ClearBytes(c.intractableDisease)
+}
+
+// encryptDisability encrypts the structured disability data. Data the
+// recipient does not have is stored as NULL.
+func (r *RecipientRepository) encryptDisability(recipient *domain.Recipient) (*recipientDisabilityCiphers, error) {
+	var ciphers recipientDisabilityCiphers
+
+	fields := []struct {
+		op      string
+		present bool
+		value   interface{}
+		target  *[]byte
+	}{
+		{"handbooks", len(recipient.Handbooks) > 0, recipient.Handbooks, &ciphers.handbooks},
+		{"support_category", recipient.SupportCategory != nil, recipient.SupportCategory, &ciphers.supportCategory},
+		{"intractable_disease", recipient.IntractableDisease != nil, recipient.IntractableDisease, &ciphers.intractableDisease},
+	}
+	for _, field := range fields {
+		if !field.present {
+			continue
+		}
+		data, err := json.Marshal(field.value)
+		if err != nil {
+			return nil, &domain.RepositoryError{Op: "marshal " + field.op, Err: err}
+		}
+		encrypted, err := r.cipher.Encrypt(string(data))
+		if err != nil {
+			return nil, &domain.RepositoryError{Op: "encrypt " + field.op, Err: err}
+		}
+		*field.target = encrypted
+	}
+
+	return &ciphers, nil
+}
+
+// decryptDisability decrypts the structured disability data; the columns
+// are NULL when the recipient has none
+func (r *RecipientRepository) decryptDisability(recipient *domain.Recipient, ciphers *recipientDisabilityCiphers) error {
+	fields := []struct {
+		op     string
+		value  []byte
+		target interface{}
+	}{
+		{"handbooks", ciphers.handbooks, &recipient.Handbooks},
+		{"support_category", ciphers.supportCategory, &recipient.SupportCategory},
+		{"intractable_disease", ciphers.intractableDisease, &recipient.IntractableDisease},
+	}
+	for _, field := range fields {
+		if len(field.value) == 0 {
+			continue
+		}
+		decrypted, err := r.cipher.Decrypt(field.value)
+		if err != nil {
+			return &domain.RepositoryError{Op: "decrypt " + field.op, Err: err}
+		}
+		if err := json.Unmarshal([]byte(decrypted), field.target); err != nil {
+			return &domain.RepositoryError{Op: "unmarshal " + field.op, Err: err}
+		}
+	}
+
+	return nil
+}
+
 // getExecutor returns either a transaction or the database connection
 func (r *RecipientRepository) getExecutor(ctx context.Context) executor {
 	if tx := ctx.Value("tx"); tx != nil {
@@ -563,12 +708,17 @@ func (r *RecipientRepository) scanRecipient(row scanner) (*domain.Recipient, err
 	var disabilityNameCipher, hasDisabilityIDCipher, gradeCipher []byte
 	var addressCipher, phoneCipher, emailCipher, publicAssistanceCipher []byte
 	var admissionDateStr, dischargeDateStr, createdAtStr, updatedAtStr *string
+	var addressCiphers recipientAddressCiphers
+	var disabilityCiphers recipientDisabilityCiphers
 
 	err := row.Scan(
 		&recipient.ID, &nameCipher, &kanaCipher, &sexCipher, &birthDateCipher,
 		&disabilityNameCipher, &hasDisabilityIDCipher, &gradeCipher,
 		&addressCipher, &phoneCipher, &emailCipher, &publicAssistanceCipher,
 		&admissionDateStr, &dischargeDateStr, &createdAtStr, &updatedAtStr,
+		&recipient.Version,
+		&addressCiphers.postalCode, &addressCiphers.prefecture, &addressCiphers.city, &addressCiphers.street,
+		&disabilityCiphers.handbooks, &disabilityCiphers.supportCategory, &disabilityCiphers.intractableDisease,
 	)
 
 	if err != nil {
@@ -623,11 +773,19 @@ func (r *RecipientRepository) scanRecipient(row scanner) (*domain.Recipient, err
 		return nil, &domain.RepositoryError{Op: "decrypt grade", Err: err}
 	}
 
+	if err := r.decryptDisability(&recipient, &disabilityCiphers); err != nil {
+		return nil, err
+	}
+
 	recipient.Address, err = r.cipher.Decrypt(addressCipher)
 	if err != nil {
 		return nil, &domain.RepositoryError{Op: "decrypt address", Err: err}
 	}
 
+	if err := r.decryptAddress(&recipient, &addressCiphers); err != nil {
+		return nil, err
+	}
+
 	recipient.Phone, err = r.cipher.Decrypt(phoneCipher)
 	if err != nil {
 		return nil, &domain.RepositoryError{Op: "decrypt phone", Err: err}
@@ -16,6 +16,7 @@ func (r *RecipientRepository) scanRecipientSecure(row scanner) (*domain.Recipien
 	var disabilityNameCipher, hasDisabilityIDCipher, gradeCipher []byte
 	var addressCipher, phoneCipher, emailCipher, publicAssistanceCipher []byte
 	var admissionDateStr, dischargeDateStr, createdAtStr, updatedAtStr *string
+	var addressCiphers recipientAddressCiphers
 
 	err := row.Scan(
 		&recipient.ID,
@@ -34,6 +35,11 @@ func (r *RecipientRepository) scanRecipientSecure(row scanner) (*domain.Recipien
 		&dischargeDateStr,
 		&createdAtStr,
 		&updatedAtStr,
+		&recipient.Version,
+		&addressCiphers.postalCode,
+		&addressCiphers.prefecture,
+		&addressCiphers.city,
+		&addressCiphers.street,
 	)
 	if err != nil {
 		return nil, err
@@ -101,6 +107,22 @@ func (r *RecipientRepository) scanRecipientSecure(row scanner) (*domain.Recipien
 		return nil, err2
 	}
 
+	for _, field := range []struct {
+		value  []byte
+		name   string
+		target *string
+	}{
+		{addressCiphers.postalCode, "postal_code", &recipient.PostalCode},
+		{addressCiphers.prefecture, "prefecture", &recipient.Prefecture},
+		{addressCiphers.city, "city", &recipient.City},
+		{addressCiphers.street, "street", &recipient.Street},
+	} {
+		*field.target, err2 = decryptSecureField(field.value, field.name)
+		if err2 != nil {
+			return nil, err2
+		}
+	}
+
 	recipient.Phone, err2 = decryptSecureField(phoneCipher, "phone")
 	if err2 != nil {
 		return nil, err2
@@ -158,7 +180,8 @@ func (r *RecipientRepository) GetByIDSecure(ctx context.Context, id domain.ID) (
             id, name_cipher, kana_cipher, sex_cipher, birth_date_cipher,
             disability_name_cipher, has_disability_id_cipher, grade_cipher,
             address_cipher, phone_cipher, email_cipher, public_assistance_cipher,
-            admission_date, discharge_date, created_at, updated_at
+            admission_date, discharge_date, created_at, updated_at, version,
+            postal_code_cipher, prefecture_cipher, city_cipher, street_cipher
         FROM recipients
         WHERE id = ?`
 
@@ -197,6 +220,10 @@ func (s *SecureRecipientData) Clear() {
 		crypto.ClearString(&s.recipient.DisabilityName)
 		crypto.ClearString(&s.recipient.Grade)
 		crypto.ClearString(&s.recipient.Address)
+		crypto.ClearString(&s.recipient.PostalCode)
+		crypto.ClearString(&s.recipient.Prefecture)
+		crypto.ClearString(&s.recipient.City)
+		crypto.ClearString(&s.recipient.Street)
 		crypto.ClearString(&s.recipient.Phone)
 		crypto.ClearString(&s.recipient.Email)
 	}
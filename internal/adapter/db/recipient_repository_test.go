@@ -2,11 +2,14 @@ package db
 
 import (
 	"context"
+	"fmt"
 	"path/filepath"
 	"testing"
 	"time"
 
 	"shien-system/internal/domain"
+
+	"github.com/stretchr/testify/require"
 )
 
 func setupTestDatabase(t *testing.T) *Database {
@@ -61,7 +64,7 @@ func TestRecipientRepository_Create(t *testing.T) {
 		UpdatedAt:        now,
 	}
 
-	err := recipientRepo.Create(ctx, recipient)
+	err = recipientRepo.Create(ctx, recipient)
 	if err != nil {
 		t.Errorf("Create() error = %v", err)
 	}
@@ -137,7 +140,7 @@ func TestRecipientRepository_CreateDuplicate(t *testing.T) {
 	}
 
 	// First creation should succeed
-	err := recipientRepo.Create(ctx, recipient)
+	err = recipientRepo.Create(ctx, recipient)
 	if err != nil {
 		t.Errorf("First Create() error = %v", err)
 	}
@@ -189,7 +192,7 @@ func TestRecipientRepository_Update(t *testing.T) {
 		UpdatedAt:        now,
 	}
 
-	err := recipientRepo.Create(ctx, recipient)
+	err = recipientRepo.Create(ctx, recipient)
 	if err != nil {
 		t.Fatalf("Create() error = %v", err)
 	}
@@ -259,7 +262,7 @@ func TestRecipientRepository_Delete(t *testing.T) {
 		UpdatedAt:        now,
 	}
 
-	err := recipientRepo.Create(ctx, recipient)
+	err = recipientRepo.Create(ctx, recipient)
 	if err != nil {
 		t.Fatalf("Create() error = %v", err)
 	}
@@ -425,6 +428,7 @@ func TestRecipientRepository_GetActive(t *testing.T) {
 	ctx := context.Background()
 	now := time.Now().UTC().Truncate(time.Second)
 	discharged := now.Add(-24 * time.Hour)
+	admitted := now.Add(-48 * time.Hour)
 
 	// Create active recipient (no discharge date)
 	activeRecipient := &domain.Recipient{
@@ -448,13 +452,13 @@ func TestRecipientRepository_GetActive(t *testing.T) {
 		BirthDate:        time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC),
 		HasDisabilityID:  false,
 		PublicAssistance: false,
-		AdmissionDate:    &now,
+		AdmissionDate:    &admitted,
 		DischargeDate:    &discharged, // Already discharged
 		CreatedAt:        now,
 		UpdatedAt:        now,
 	}
 
-	err := recipientRepo.Create(ctx, activeRecipient)
+	err = recipientRepo.Create(ctx, activeRecipient)
 	if err != nil {
 		t.Fatalf("Create active recipient error = %v", err)
 	}
@@ -464,8 +468,24 @@ func TestRecipientRepository_GetActive(t *testing.T) {
 		t.Fatalf("Create discharged recipient error = %v", err)
 	}
 
+	// Enrollment is decided by enrollment periods
+	periodRepo := NewEnrollmentPeriodRepository(db)
+	for _, recipient := range []*domain.Recipient{activeRecipient, dischargedRecipient} {
+		err = periodRepo.Create(ctx, &domain.EnrollmentPeriod{
+			ID:            "period-" + recipient.ID,
+			RecipientID:   recipient.ID,
+			AdmissionDate: *recipient.AdmissionDate,
+			DischargeDate: recipient.DischargeDate,
+			CreatedAt:     now,
+			UpdatedAt:     now,
+		})
+		if err != nil {
+			t.Fatalf("Create enrollment period error = %v", err)
+		}
+	}
+
 	// Get active recipients only
-	activeRecipients, err := recipientRepo.GetActive(ctx, 10, 0)
+	activeRecipients, err := recipientRepo.GetActive(ctx, now, 10, 0)
 	if err != nil {
 		t.Errorf("GetActive() error = %v", err)
 	}
@@ -486,7 +506,7 @@ func TestRecipientRepository_GetActive(t *testing.T) {
 	}
 
 	// Test active count
-	activeCount, err := recipientRepo.CountActive(ctx)
+	activeCount, err := recipientRepo.CountActive(ctx, now)
 	if err != nil {
 		t.Errorf("CountActive() error = %v", err)
 	}
@@ -494,6 +514,161 @@ func TestRecipientRepository_GetActive(t *testing.T) {
 	if activeCount < 1 {
 		t.Errorf("CountActive() = %d, want at least 1", activeCount)
 	}
+
+	// The discharged recipient was still enrolled before the discharge date
+	enrolledBefore, err := recipientRepo.GetActive(ctx, discharged.Add(-12*time.Hour), 10, 0)
+	if err != nil {
+		t.Errorf("GetActive() error = %v", err)
+	}
+
+	found = false
+	for _, recipient := range enrolledBefore {
+		if recipient.ID == dischargedRecipient.ID {
+			found = true
+		}
+	}
+
+	if !found {
+		t.Error("GetActive() did not return recipient enrolled on the given date")
+	}
+}
+
+func TestRecipientRepository_GetActive_LocalCalendarDate(t *testing.T) {
+	db := setupTestDatabase(t)
+	defer db.Close()
+
+	recipientRepo, err := NewRecipientRepository(db)
+	require.NoError(t, err)
+	periodRepo := NewEnrollmentPeriodRepository(db)
+
+	ctx := context.Background()
+	jst := time.FixedZone("JST", 9*60*60)
+	now := time.Now().UTC().Truncate(time.Second)
+	// A date picked in JST is midnight, which is still the previous day in UTC
+	admission := time.Date(2024, 4, 1, 10, 0, 0, 0, jst)
+	discharge := time.Date(2024, 4, 10, 10, 0, 0, 0, jst)
+
+	recipient := &domain.Recipient{
+		ID:            "jst-001",
+		Name:          "早朝太郎",
+		Sex:           domain.SexMale,
+		BirthDate:     time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC),
+		AdmissionDate: &admission,
+		DischargeDate: &discharge,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+	require.NoError(t, recipientRepo.Create(ctx, recipient))
+	require.NoError(t, periodRepo.Create(ctx, &domain.EnrollmentPeriod{
+		ID:            "period-jst-001",
+		RecipientID:   recipient.ID,
+		AdmissionDate: admission,
+		DischargeDate: &discharge,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}))
+
+	tests := []struct {
+		name string
+		asOf time.Time
+		want int
+	}{
+		{"day before admission", time.Date(2024, 3, 31, 0, 0, 0, 0, jst), 0},
+		{"admission day", time.Date(2024, 4, 1, 0, 0, 0, 0, jst), 1},
+		{"discharge day", time.Date(2024, 4, 10, 0, 0, 0, 0, jst), 1},
+		{"day after discharge", time.Date(2024, 4, 11, 0, 0, 0, 0, jst), 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recipients, err := recipientRepo.GetActive(ctx, tt.asOf, 10, 0)
+			require.NoError(t, err)
+			if len(recipients) != tt.want {
+				t.Errorf("GetActive() returned %d recipients, want %d", len(recipients), tt.want)
+			}
+
+			count, err := recipientRepo.CountActive(ctx, tt.asOf)
+			require.NoError(t, err)
+			if count != tt.want {
+				t.Errorf("CountActive() = %d, want %d", count, tt.want)
+			}
+		})
+	}
+}
+
+func TestRecipientRepository_Search_Normalized(t *testing.T) {
+	db := setupTestDatabase(t)
+	defer db.Close()
+
+	recipientRepo, err := NewRecipientRepository(db)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Second)
+	// A hiragana name and a half-width kana reading, as rows written before
+	// input normalization may still contain them
+	for _, recipient := range []*domain.Recipient{
+		{ID: "search-001", Name: "やまだ Taro", Kana: "ﾔﾏﾀﾞ ﾀﾛｳ"},
+		{ID: "search-002", Name: "佐藤 花子", Kana: "サトウ ハナコ"},
+	} {
+		recipient.Sex = domain.SexFemale
+		recipient.BirthDate = time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC)
+		recipient.CreatedAt = now
+		recipient.UpdatedAt = now
+		require.NoError(t, recipientRepo.Create(ctx, recipient))
+	}
+
+	tests := []struct {
+		query string
+		want  []domain.ID
+	}{
+		{"やまだ", []domain.ID{"search-001"}},
+		{"ヤマダ", []domain.ID{"search-001"}},
+		{"ﾔﾏﾀﾞ", []domain.ID{"search-001"}},
+		{"たろう", []domain.ID{"search-001"}},
+		{"ＴＡＲＯ", []domain.ID{"search-001"}},
+		{"さとう", []domain.ID{"search-002"}},
+		{"鈴木", nil},
+	}
+	for _, tt := range tests {
+		recipients, err := recipientRepo.Search(ctx, tt.query, 10, 0)
+		require.NoError(t, err)
+		var got []domain.ID
+		for _, recipient := range recipients {
+			got = append(got, recipient.ID)
+		}
+		require.Equal(t, tt.want, got, "Search(%q)", tt.query)
+	}
+}
+
+func TestRecipientRepository_Search_PagesThroughAllRecipients(t *testing.T) {
+	db := setupTestDatabase(t)
+	defer db.Close()
+
+	recipientRepo, err := NewRecipientRepository(db)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Second)
+	// List returns the newest first, so the oldest recipient is on the last page
+	for i := 0; i <= recipientSearchPageSize; i++ {
+		recipient := &domain.Recipient{
+			ID:        domain.ID(fmt.Sprintf("page-%04d", i)),
+			Name:      fmt.Sprintf("利用者 %d", i),
+			Sex:       domain.SexMale,
+			BirthDate: time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC),
+			CreatedAt: now.Add(time.Duration(i) * time.Second),
+			UpdatedAt: now,
+		}
+		if i == 0 {
+			recipient.Name = "最古 太郎"
+		}
+		require.NoError(t, recipientRepo.Create(ctx, recipient))
+	}
+
+	recipients, err := recipientRepo.Search(ctx, "最古", 10, 0)
+	require.NoError(t, err)
+	require.Len(t, recipients, 1)
+	require.Equal(t, domain.ID("page-0000"), recipients[0].ID)
 }
 
 // Note: Search functionality will be implemented with encrypted fields
@@ -8,6 +8,8 @@ import (
 	"time"
 
 	"shien-system/internal/domain"
+
+	"github.com/stretchr/testify/require"
 )
 
 func TestRepositoryIntegration_EncryptionRoundTrip(t *testing.T) {
@@ -41,7 +43,7 @@ func TestRepositoryIntegration_EncryptionRoundTrip(t *testing.T) {
 	}
 
 	// Create the recipient
-	err := recipientRepo.Create(ctx, recipient)
+	err = recipientRepo.Create(ctx, recipient)
 	if err != nil {
 		t.Fatalf("Create() error = %v", err)
 	}
@@ -96,7 +98,7 @@ func TestRepositoryIntegration_DataIsActuallyEncrypted(t *testing.T) {
 		UpdatedAt:        now,
 	}
 
-	err := recipientRepo.Create(ctx, recipient)
+	err = recipientRepo.Create(ctx, recipient)
 	if err != nil {
 		t.Fatalf("Create() error = %v", err)
 	}
@@ -149,7 +151,7 @@ func TestRepositoryIntegration_TransactionRollback(t *testing.T) {
 	}
 
 	// Test transaction rollback
-	err := db.WithTransaction(ctx, func(ctx context.Context) error {
+	err = db.WithTransaction(ctx, func(ctx context.Context) error {
 		// Create recipient within transaction
 		err := recipientRepo.Create(ctx, recipient)
 		if err != nil {
@@ -199,7 +201,7 @@ func TestRepositoryIntegration_TransactionCommit(t *testing.T) {
 	}
 
 	// Test successful transaction
-	err := db.WithTransaction(ctx, func(ctx context.Context) error {
+	err = db.WithTransaction(ctx, func(ctx context.Context) error {
 		return recipientRepo.Create(ctx, recipient)
 	})
 
@@ -354,7 +356,7 @@ func TestRepositoryIntegration_LargeDataHandling(t *testing.T) {
 
 	// Test creation with large data
 	start := time.Now()
-	err := recipientRepo.Create(ctx, recipient)
+	err = recipientRepo.Create(ctx, recipient)
 	createDuration := time.Since(start)
 
 	if err != nil {
@@ -412,7 +414,7 @@ func TestRepositoryIntegration_EncryptionConsistency(t *testing.T) {
 	}
 
 	// Create recipient
-	err := recipientRepo.Create(ctx, recipient)
+	err = recipientRepo.Create(ctx, recipient)
 	if err != nil {
 		t.Fatalf("Create() error = %v", err)
 	}
@@ -472,7 +474,7 @@ func BenchmarkRepositoryIntegration_EncryptionPerformance(b *testing.B) {
 	}
 
 	recipientRepo, err := NewRecipientRepository(db)
-	require.NoError(t, err)
+	require.NoError(b, err)
 	now := time.Now().UTC().Truncate(time.Second)
 
 	b.ResetTimer()
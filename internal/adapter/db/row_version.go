@@ -0,0 +1,24 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"shien-system/internal/domain"
+)
+
+// versionMismatchError explains why a versioned UPDATE matched no rows: the
+// row is gone, or someone else saved it after the caller loaded it
+func versionMismatchError(ctx context.Context, exec executor, table string, id domain.ID) error {
+	var version int
+	query := fmt.Sprintf(`SELECT version FROM %s WHERE id = ?`, table)
+	err := exec.QueryRowContext(ctx, query, id).Scan(&version)
+	if err == sql.ErrNoRows {
+		return domain.ErrNotFound
+	}
+	if err != nil {
+		return &domain.RepositoryError{Op: "check version", Err: err}
+	}
+	return domain.ErrVersionConflict
+}
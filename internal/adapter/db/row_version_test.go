@@ -0,0 +1,69 @@
+package db
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"shien-system/internal/domain"
+)
+
+func TestStaffRepository_UpdateChecksVersion(t *testing.T) {
+	database, err := NewDatabase(Config{Path: filepath.Join(t.TempDir(), "test.db")})
+	if err != nil {
+		t.Fatalf("failed to create database: %v", err)
+	}
+	defer database.Close()
+
+	ctx := context.Background()
+	if err := database.RunMigrations(ctx); err != nil {
+		t.Fatalf("RunMigrations() error = %v", err)
+	}
+
+	repo := NewStaffRepository(database)
+	now := time.Now().UTC().Truncate(time.Second)
+	staff := &domain.Staff{ID: "staff-001", Name: "職員", Role: domain.RoleStaff, PasswordHash: "hash", CreatedAt: now, UpdatedAt: now}
+	if err := repo.Create(ctx, staff); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	// Two windows load the same row
+	first, err := repo.GetByID(ctx, "staff-001")
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	second, err := repo.GetByID(ctx, "staff-001")
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if first.Version != 1 {
+		t.Fatalf("Version = %d, want 1", first.Version)
+	}
+
+	first.Name = "一人目の変更"
+	if err := repo.Update(ctx, first); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if first.Version != 2 {
+		t.Errorf("Version after update = %d, want 2", first.Version)
+	}
+
+	second.Name = "二人目の変更"
+	if err := repo.Update(ctx, second); err != domain.ErrVersionConflict {
+		t.Errorf("stale Update() error = %v, want ErrVersionConflict", err)
+	}
+
+	stored, err := repo.GetByID(ctx, "staff-001")
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if stored.Name != "一人目の変更" || stored.Version != 2 {
+		t.Errorf("stored = %q v%d, want the first update at v2", stored.Name, stored.Version)
+	}
+
+	missing := &domain.Staff{ID: "missing", Name: "x", Role: domain.RoleStaff, Version: 1}
+	if err := repo.Update(ctx, missing); err != domain.ErrNotFound {
+		t.Errorf("Update(missing) error = %v, want ErrNotFound", err)
+	}
+}
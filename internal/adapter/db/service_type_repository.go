@@ -0,0 +1,101 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+
+	"shien-system/internal/domain"
+)
+
+// ServiceTypeRepository implements domain.ServiceTypeRepository
+type ServiceTypeRepository struct {
+	db *Database
+}
+
+// NewServiceTypeRepository creates a new service type master repository
+func NewServiceTypeRepository(db *Database) *ServiceTypeRepository {
+	return &ServiceTypeRepository{
+		db: db,
+	}
+}
+
+// List returns every service type ordered by code
+func (r *ServiceTypeRepository) List(ctx context.Context) ([]*domain.ServiceType, error) {
+	executor := r.getExecutor(ctx)
+	rows, err := executor.QueryContext(ctx, `SELECT code, name FROM service_types ORDER BY code`)
+	if err != nil {
+		return nil, &domain.RepositoryError{Op: "list service types", Err: err}
+	}
+	defer rows.Close()
+
+	var serviceTypes []*domain.ServiceType
+	for rows.Next() {
+		var serviceType domain.ServiceType
+		if err := rows.Scan(&serviceType.Code, &serviceType.Name); err != nil {
+			return nil, &domain.RepositoryError{Op: "scan service type", Err: err}
+		}
+		serviceTypes = append(serviceTypes, &serviceType)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, &domain.RepositoryError{Op: "rows iteration", Err: err}
+	}
+
+	return serviceTypes, nil
+}
+
+// FindByCode returns the service type with a two-digit サービス種類コード
+func (r *ServiceTypeRepository) FindByCode(ctx context.Context, code string) (*domain.ServiceType, error) {
+	executor := r.getExecutor(ctx)
+	var serviceType domain.ServiceType
+	err := executor.QueryRowContext(ctx, `SELECT code, name FROM service_types WHERE code = ?`, code).
+		Scan(&serviceType.Code, &serviceType.Name)
+	if err == sql.ErrNoRows {
+		return nil, domain.ErrNotFound
+	}
+	if err != nil {
+		return nil, &domain.RepositoryError{Op: "find service type", Err: err}
+	}
+	return &serviceType, nil
+}
+
+// ReplaceAll deletes the master and inserts serviceTypes in one transaction
+func (r *ServiceTypeRepository) ReplaceAll(ctx context.Context, serviceTypes []*domain.ServiceType) error {
+	return r.db.WithTransaction(ctx, func(ctx context.Context) error {
+		executor := r.getExecutor(ctx)
+		if _, err := executor.ExecContext(ctx, `DELETE FROM service_types`); err != nil {
+			return &domain.RepositoryError{Op: "clear service types", Err: err}
+		}
+
+		stmt, err := executor.PrepareContext(ctx, `INSERT INTO service_types (code, name) VALUES (?, ?)`)
+		if err != nil {
+			return &domain.RepositoryError{Op: "prepare insert service type", Err: err}
+		}
+		defer stmt.Close()
+
+		for _, serviceType := range serviceTypes {
+			if _, err := stmt.ExecContext(ctx, serviceType.Code, serviceType.Name); err != nil {
+				return &domain.RepositoryError{Op: "insert service type " + serviceType.Code, Err: err}
+			}
+		}
+		return nil
+	})
+}
+
+// Count returns the number of service types
+func (r *ServiceTypeRepository) Count(ctx context.Context) (int, error) {
+	executor := r.getExecutor(ctx)
+	var count int
+	if err := executor.QueryRowContext(ctx, `SELECT COUNT(*) FROM service_types`).Scan(&count); err != nil {
+		return 0, &domain.RepositoryError{Op: "count service types", Err: err}
+	}
+	return count, nil
+}
+
+// getExecutor returns either a transaction or the database connection
+func (r *ServiceTypeRepository) getExecutor(ctx context.Context) executor {
+	if tx := ctx.Value("tx"); tx != nil {
+		return tx.(*sql.Tx)
+	}
+	return r.db.DB()
+}
@@ -0,0 +1,49 @@
+package db
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"shien-system/internal/domain"
+)
+
+func TestServiceTypeRepository_ReplaceAllAndFind(t *testing.T) {
+	database, err := NewDatabase(Config{Path: filepath.Join(t.TempDir(), "test.db")})
+	if err != nil {
+		t.Fatalf("failed to create database: %v", err)
+	}
+	defer database.Close()
+
+	ctx := context.Background()
+	if err := database.RunMigrations(ctx); err != nil {
+		t.Fatalf("RunMigrations() error = %v", err)
+	}
+
+	repo := NewServiceTypeRepository(database)
+	if err := repo.ReplaceAll(ctx, []*domain.ServiceType{
+		{Code: "46", Name: "就労継続支援B型"},
+		{Code: "22", Name: "生活介護"},
+	}); err != nil {
+		t.Fatalf("ReplaceAll() error = %v", err)
+	}
+
+	list, err := repo.List(ctx)
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(list) != 2 || list[0].Code != "22" || list[1].Code != "46" {
+		t.Errorf("List() = %+v, want both service types ordered by code", list)
+	}
+
+	found, err := repo.FindByCode(ctx, "46")
+	if err != nil || found.Name != "就労継続支援B型" {
+		t.Errorf("FindByCode() = %+v, %v", found, err)
+	}
+	if _, err := repo.FindByCode(ctx, "99"); err != domain.ErrNotFound {
+		t.Errorf("FindByCode() unknown code error = %v, want ErrNotFound", err)
+	}
+	if count, err := repo.Count(ctx); err != nil || count != 2 {
+		t.Errorf("Count() = %d, %v, want 2", count, err)
+	}
+}
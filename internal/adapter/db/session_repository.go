@@ -76,14 +76,15 @@ func (r *SessionRepository) CreateSession(ctx context.Context, session *usecase.
 	return nil
 }
 
-// GetSession retrieves a session by ID
+// GetSession retrieves a session by ID, including invalidated sessions so the
+// caller can tell why a session is no longer usable
 func (r *SessionRepository) GetSession(ctx context.Context, sessionID string) (*usecase.Session, error) {
 	query := `
 		SELECT id, user_id, user_role_cipher, client_ip_cipher, user_agent_cipher,
 		       csrf_token, created_at, expires_at, last_accessed_at, is_active,
 		       invalidation_reason, invalidated_at
 		FROM sessions 
-		WHERE id = ? AND is_active = 1`
+		WHERE id = ?`
 
 	row := r.db.QueryRowContext(ctx, query, sessionID)
 
@@ -226,9 +227,26 @@ func (r *SessionRepository) GetSessionsByUserID(ctx context.Context, userID doma
 		WHERE user_id = ? AND is_active = 1 
 		ORDER BY last_accessed_at DESC`
 
-	rows, err := r.db.QueryContext(ctx, query, userID)
+	return r.querySessions(ctx, "query sessions by user ID", query, userID)
+}
+
+// GetActiveSessions retrieves every active, unexpired session
+func (r *SessionRepository) GetActiveSessions(ctx context.Context) ([]*usecase.Session, error) {
+	query := `
+		SELECT id, user_id, user_role_cipher, client_ip_cipher, user_agent_cipher,
+		       csrf_token, created_at, expires_at, last_accessed_at, is_active
+		FROM sessions 
+		WHERE is_active = 1 AND expires_at > ?
+		ORDER BY last_accessed_at DESC`
+
+	return r.querySessions(ctx, "query active sessions", query, time.Now().Format(time.RFC3339))
+}
+
+// querySessions runs a session list query and decrypts each row
+func (r *SessionRepository) querySessions(ctx context.Context, op, query string, args ...interface{}) ([]*usecase.Session, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to query sessions by user ID: %w", err)
+		return nil, fmt.Errorf("failed to %s: %w", op, err)
 	}
 	defer rows.Close()
 
@@ -6,6 +6,8 @@ import (
 	"time"
 
 	"shien-system/internal/domain"
+
+	"github.com/stretchr/testify/require"
 )
 
 func setupStaffAssignmentTestData(t *testing.T, db *Database) (context.Context, *domain.Staff, *domain.Recipient) {
@@ -247,7 +249,7 @@ func TestStaffAssignmentRepository_GetByStaffID(t *testing.T) {
 		CreatedAt:        time.Now().UTC(),
 		UpdatedAt:        time.Now().UTC(),
 	}
-	err := recipientRepo.Create(ctx, recipient2)
+	err = recipientRepo.Create(ctx, recipient2)
 	if err != nil {
 		t.Fatalf("Create recipient2 error = %v", err)
 	}
@@ -24,8 +24,8 @@ func NewStaffRepository(db *Database) *StaffRepository {
 // Create creates a new staff member
 func (r *StaffRepository) Create(ctx context.Context, staff *domain.Staff) error {
 	query := `
-		INSERT INTO staff (id, name, role, password_hash, created_at, updated_at) 
-		VALUES (?, ?, ?, ?, ?, ?)`
+		INSERT INTO staff (id, name, role, password_hash, date_style, created_at, updated_at) 
+		VALUES (?, ?, ?, ?, ?, ?, ?)`
 
 	executor := r.getExecutor(ctx)
 	_, err := executor.ExecContext(ctx, query,
@@ -33,6 +33,7 @@ func (r *StaffRepository) Create(ctx context.Context, staff *domain.Staff) error
 		staff.Name,
 		string(staff.Role),
 		staff.PasswordHash,
+		dateStyleOrDefault(staff.DateStyle),
 		staff.CreatedAt.Format(time.RFC3339),
 		staff.UpdatedAt.Format(time.RFC3339),
 	)
@@ -40,6 +41,7 @@ func (r *StaffRepository) Create(ctx context.Context, staff *domain.Staff) error
 	if err != nil {
 		return &domain.RepositoryError{Op: "create staff", Err: err}
 	}
+	staff.Version = 1
 
 	return nil
 }
@@ -47,7 +49,7 @@ func (r *StaffRepository) Create(ctx context.Context, staff *domain.Staff) error
 // GetByID retrieves a staff member by ID
 func (r *StaffRepository) GetByID(ctx context.Context, id domain.ID) (*domain.Staff, error) {
 	query := `
-		SELECT id, name, role, password_hash, created_at, updated_at
+		SELECT id, name, role, password_hash, date_style, created_at, updated_at, version
 		FROM staff 
 		WHERE id = ?`
 
@@ -61,16 +63,18 @@ func (r *StaffRepository) GetByID(ctx context.Context, id domain.ID) (*domain.St
 func (r *StaffRepository) Update(ctx context.Context, staff *domain.Staff) error {
 	query := `
 		UPDATE staff 
-		SET name = ?, role = ?, password_hash = ?, updated_at = ?
-		WHERE id = ?`
+		SET name = ?, role = ?, password_hash = ?, date_style = ?, updated_at = ?, version = version + 1
+		WHERE id = ? AND version = ?`
 
 	executor := r.getExecutor(ctx)
 	result, err := executor.ExecContext(ctx, query,
 		staff.Name,
 		string(staff.Role),
 		staff.PasswordHash,
+		dateStyleOrDefault(staff.DateStyle),
 		staff.UpdatedAt.Format(time.RFC3339),
 		staff.ID,
+		staff.Version,
 	)
 
 	if err != nil {
@@ -83,8 +87,9 @@ func (r *StaffRepository) Update(ctx context.Context, staff *domain.Staff) error
 	}
 
 	if rowsAffected == 0 {
-		return domain.ErrNotFound
+		return versionMismatchError(ctx, executor, "staff", staff.ID)
 	}
+	staff.Version++
 
 	return nil
 }
@@ -114,7 +119,7 @@ func (r *StaffRepository) Delete(ctx context.Context, id domain.ID) error {
 // List retrieves staff members with pagination
 func (r *StaffRepository) List(ctx context.Context, limit, offset int) ([]*domain.Staff, error) {
 	query := `
-		SELECT id, name, role, password_hash, created_at, updated_at
+		SELECT id, name, role, password_hash, date_style, created_at, updated_at, version
 		FROM staff 
 		ORDER BY created_at DESC
 		LIMIT ? OFFSET ?`
@@ -145,7 +150,7 @@ func (r *StaffRepository) List(ctx context.Context, limit, offset int) ([]*domai
 // GetByRole retrieves staff members by role
 func (r *StaffRepository) GetByRole(ctx context.Context, role domain.StaffRole) ([]*domain.Staff, error) {
 	query := `
-		SELECT id, name, role, password_hash, created_at, updated_at
+		SELECT id, name, role, password_hash, date_style, created_at, updated_at, version
 		FROM staff 
 		WHERE role = ?
 		ORDER BY created_at DESC`
@@ -176,7 +181,7 @@ func (r *StaffRepository) GetByRole(ctx context.Context, role domain.StaffRole)
 // GetByExactName retrieves a single staff member by exact name match
 func (r *StaffRepository) GetByExactName(ctx context.Context, name string) (*domain.Staff, error) {
 	query := `
-		SELECT id, name, role, password_hash, created_at, updated_at
+		SELECT id, name, role, password_hash, date_style, created_at, updated_at, version
 		FROM staff 
 		WHERE name = ?`
 
@@ -189,7 +194,7 @@ func (r *StaffRepository) GetByExactName(ctx context.Context, name string) (*dom
 // GetByName retrieves staff members by name (partial match)
 func (r *StaffRepository) GetByName(ctx context.Context, name string) ([]*domain.Staff, error) {
 	query := `
-		SELECT id, name, role, password_hash, created_at, updated_at
+		SELECT id, name, role, password_hash, date_style, created_at, updated_at, version
 		FROM staff 
 		WHERE name LIKE ?
 		ORDER BY name`
@@ -239,6 +244,14 @@ func (r *StaffRepository) getExecutor(ctx context.Context) executor {
 	return r.db.DB()
 }
 
+// dateStyleOrDefault stores an unset date style as the column default
+func dateStyleOrDefault(style string) string {
+	if style == "" {
+		return "gregorian"
+	}
+	return style
+}
+
 // scanStaff scans a staff member from a database row
 func (r *StaffRepository) scanStaff(row scanner) (*domain.Staff, error) {
 	var staff domain.Staff
@@ -249,8 +262,10 @@ func (r *StaffRepository) scanStaff(row scanner) (*domain.Staff, error) {
 		&staff.Name,
 		&roleStr,
 		&staff.PasswordHash,
+		&staff.DateStyle,
 		&createdAtStr,
 		&updatedAtStr,
+		&staff.Version,
 	)
 
 	if err != nil {
@@ -132,6 +132,7 @@ func TestStaffRepository_Update(t *testing.T) {
 	updatedTime := now.Add(time.Hour)
 	staff.Name = "更新後太郎"
 	staff.Role = domain.RoleAdmin
+	staff.DateStyle = "wareki"
 	staff.UpdatedAt = updatedTime
 
 	err = staffRepo.Update(ctx, staff)
@@ -151,6 +152,9 @@ func TestStaffRepository_Update(t *testing.T) {
 	if retrieved.Role != domain.RoleAdmin {
 		t.Errorf("Role = %v, want %v", retrieved.Role, domain.RoleAdmin)
 	}
+	if retrieved.DateStyle != "wareki" {
+		t.Errorf("DateStyle = %v, want %v", retrieved.DateStyle, "wareki")
+	}
 	if !retrieved.UpdatedAt.Equal(updatedTime) {
 		t.Errorf("UpdatedAt = %v, want %v", retrieved.UpdatedAt, updatedTime)
 	}
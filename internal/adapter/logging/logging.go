@@ -0,0 +1,106 @@
+// Package logging builds the application logger on log/slog: JSON lines in a
+// rotating log file, optional console output and PII redaction.
+package logging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+
+	"shien-system/internal/config"
+)
+
+// ParseLevel converts a configured level name (debug, info, warn, error)
+func ParseLevel(name string) (slog.Level, error) {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(strings.TrimSpace(name))); err != nil {
+		return slog.LevelInfo, fmt.Errorf("invalid log level: %s", name)
+	}
+	return level, nil
+}
+
+// New builds the application logger from the logging configuration. The
+// returned closer closes the log file and must be called on shutdown.
+func New(cfg config.LoggingConfig) (*slog.Logger, io.Closer, error) {
+	level, err := ParseLevel(cfg.Level)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	options := &slog.HandlerOptions{Level: level}
+	var handlers []slog.Handler
+	var closer io.Closer = nopCloser{}
+
+	if cfg.FilePath != "" {
+		file, err := NewRotatingFile(cfg.FilePath, int64(cfg.MaxSizeMB)*1024*1024, cfg.MaxBackups, cfg.RotateDaily)
+		if err != nil {
+			return nil, nil, err
+		}
+		handlers = append(handlers, slog.NewJSONHandler(file, options))
+		closer = file
+	}
+
+	if cfg.Console || len(handlers) == 0 {
+		handlers = append(handlers, slog.NewTextHandler(os.Stdout, options))
+	}
+
+	handler := slog.Handler(&fanoutHandler{handlers: handlers})
+	if len(handlers) == 1 {
+		handler = handlers[0]
+	}
+	if cfg.RedactPII {
+		handler = NewRedactHandler(handler)
+	}
+
+	return slog.New(handler), closer, nil
+}
+
+// fanoutHandler sends each record to several handlers, e.g. file and console
+type fanoutHandler struct {
+	handlers []slog.Handler
+}
+
+func (h *fanoutHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	for _, handler := range h.handlers {
+		if handler.Enabled(ctx, level) {
+			return true
+		}
+	}
+	return false
+}
+
+func (h *fanoutHandler) Handle(ctx context.Context, r slog.Record) error {
+	var errs []error
+	for _, handler := range h.handlers {
+		if handler.Enabled(ctx, r.Level) {
+			if err := handler.Handle(ctx, r.Clone()); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (h *fanoutHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	handlers := make([]slog.Handler, len(h.handlers))
+	for i, handler := range h.handlers {
+		handlers[i] = handler.WithAttrs(attrs)
+	}
+	return &fanoutHandler{handlers: handlers}
+}
+
+func (h *fanoutHandler) WithGroup(name string) slog.Handler {
+	handlers := make([]slog.Handler, len(h.handlers))
+	for i, handler := range h.handlers {
+		handlers[i] = handler.WithGroup(name)
+	}
+	return &fanoutHandler{handlers: handlers}
+}
+
+type nopCloser struct{}
+
+func (nopCloser) Close() error { return nil }
@@ -0,0 +1,126 @@
+package logging
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"shien-system/internal/config"
+	"shien-system/internal/usecase"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLevel(t *testing.T) {
+	level, err := ParseLevel("warn")
+	require.NoError(t, err)
+	assert.Equal(t, "WARN", level.String())
+
+	_, err = ParseLevel("verbose")
+	assert.Error(t, err)
+}
+
+func TestRotatingFile_RotatesBySizeAndKeepsBackups(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	file, err := NewRotatingFile(path, 32, 2, false)
+	require.NoError(t, err)
+	defer file.Close()
+
+	clock := time.Date(2026, 10, 18, 9, 0, 0, 0, time.Local)
+	file.now = func() time.Time {
+		clock = clock.Add(time.Second)
+		return clock
+	}
+
+	for i := 0; i < 5; i++ {
+		_, err := file.Write([]byte(strings.Repeat("x", 20) + "\n"))
+		require.NoError(t, err)
+	}
+
+	// Every write after the first overflows 32 bytes; only two backups survive
+	backups := RotatedFiles(path)
+	assert.Len(t, backups, 2)
+	assert.True(t, backups[0] > backups[1], "backups should be newest first")
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, int64(21), info.Size())
+}
+
+func TestRotatingFile_RotatesDaily(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	file, err := NewRotatingFile(path, 0, 5, true)
+	require.NoError(t, err)
+	defer file.Close()
+
+	day := time.Date(2026, 10, 18, 23, 59, 0, 0, time.Local)
+	file.now = func() time.Time { return day }
+	file.openedDay = day.Format("2006-01-02")
+
+	_, err = file.Write([]byte("before midnight\n"))
+	require.NoError(t, err)
+
+	day = day.Add(2 * time.Minute)
+	_, err = file.Write([]byte("after midnight\n"))
+	require.NoError(t, err)
+
+	assert.Len(t, RotatedFiles(path), 1)
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "after midnight\n", string(content))
+}
+
+func TestNew_WritesRedactedEntriesReadableByReader(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logs", "shien-system.log")
+	logger, closer, err := New(config.LoggingConfig{
+		Level:      "info",
+		FilePath:   path,
+		MaxSizeMB:  1,
+		MaxBackups: 3,
+		RedactPII:  true,
+	})
+	require.NoError(t, err)
+
+	logger.Debug("not written at info level")
+	logger.Info("recipient created", "recipient_id", "r-001", "name", "山田太郎")
+	logger.Warn("failed to write audit log", "action", "UPDATE", "error", "connection lost")
+	logger.Error("backup failed", "phone", "090-1234-5678")
+	require.NoError(t, closer.Close())
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(content), "山田太郎")
+	assert.NotContains(t, string(content), "090-1234-5678")
+
+	reader := NewReader(path)
+	ctx := context.Background()
+
+	entries, err := reader.ReadEntries(ctx, usecase.LogQuery{})
+	require.NoError(t, err)
+	require.Len(t, entries, 3)
+	assert.Equal(t, "backup failed", entries[0].Message)
+	assert.Equal(t, "ERROR", entries[0].Level)
+	assert.Equal(t, "r-001", entries[2].Attrs["recipient_id"])
+	assert.Equal(t, redacted, entries[2].Attrs["name"])
+
+	warnings, err := reader.ReadEntries(ctx, usecase.LogQuery{MinLevel: "warn"})
+	require.NoError(t, err)
+	assert.Len(t, warnings, 2)
+
+	matches, err := reader.ReadEntries(ctx, usecase.LogQuery{Text: "AUDIT"})
+	require.NoError(t, err)
+	require.Len(t, matches, 1)
+	assert.Equal(t, "UPDATE", matches[0].Attrs["action"])
+
+	limited, err := reader.ReadEntries(ctx, usecase.LogQuery{Limit: 1})
+	require.NoError(t, err)
+	assert.Len(t, limited, 1)
+
+	missing, err := NewReader(filepath.Join(t.TempDir(), "none.log")).ReadEntries(ctx, usecase.LogQuery{})
+	require.NoError(t, err)
+	assert.Empty(t, missing)
+}
@@ -0,0 +1,181 @@
+package logging
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"shien-system/internal/usecase"
+)
+
+// DefaultReadLimit is the number of entries returned when the query sets no limit
+const DefaultReadLimit = 500
+
+// maxLineSize bounds a single log line; longer lines are skipped
+const maxLineSize = 1024 * 1024
+
+// Reader reads the JSON log file and its rotated files for the log viewer
+type Reader struct {
+	path string
+}
+
+// NewReader creates a reader for the log file at path
+func NewReader(path string) *Reader {
+	return &Reader{path: path}
+}
+
+// ReadEntries returns the entries matching query, newest first. Rotated files
+// are read only until the limit is reached.
+func (r *Reader) ReadEntries(ctx context.Context, query usecase.LogQuery) ([]*usecase.LogEntry, error) {
+	if r.path == "" {
+		return []*usecase.LogEntry{}, nil
+	}
+
+	limit := query.Limit
+	if limit <= 0 {
+		limit = DefaultReadLimit
+	}
+
+	minLevel := slog.LevelDebug
+	if query.MinLevel != "" {
+		level, err := ParseLevel(query.MinLevel)
+		if err != nil {
+			return nil, err
+		}
+		minLevel = level
+	}
+
+	text := strings.ToLower(strings.TrimSpace(query.Text))
+	entries := make([]*usecase.LogEntry, 0)
+
+	for _, path := range append([]string{r.path}, RotatedFiles(r.path)...) {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		matches, err := readFile(path, minLevel, text, query.Since)
+		if err != nil {
+			return nil, err
+		}
+
+		// Files are visited newest first and lines are in time order
+		for i := len(matches) - 1; i >= 0 && len(entries) < limit; i-- {
+			entries = append(entries, matches[i])
+		}
+		if len(entries) >= limit {
+			break
+		}
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Time.After(entries[j].Time)
+	})
+
+	return entries, nil
+}
+
+func readFile(path string, minLevel slog.Level, text string, since time.Time) ([]*usecase.LogEntry, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to open log file: %w", err)
+	}
+	defer file.Close()
+
+	var matches []*usecase.LogEntry
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), maxLineSize)
+
+	for scanner.Scan() {
+		entry, level, ok := parseLine(scanner.Bytes())
+		if !ok || level < minLevel {
+			continue
+		}
+		if !since.IsZero() && entry.Time.Before(since) {
+			continue
+		}
+		if text != "" && !entryContains(entry, text) {
+			continue
+		}
+		matches = append(matches, entry)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read log file: %w", err)
+	}
+
+	return matches, nil
+}
+
+// parseLine decodes one line written by slog's JSON handler
+func parseLine(line []byte) (*usecase.LogEntry, slog.Level, bool) {
+	var fields map[string]interface{}
+	if err := json.Unmarshal(line, &fields); err != nil {
+		return nil, 0, false
+	}
+
+	entry := &usecase.LogEntry{Attrs: make(map[string]string)}
+
+	if value, ok := fields[slog.TimeKey].(string); ok {
+		entry.Time, _ = time.Parse(time.RFC3339Nano, value)
+	}
+	levelName, _ := fields[slog.LevelKey].(string)
+	level, err := ParseLevel(levelName)
+	if err != nil {
+		return nil, 0, false
+	}
+	entry.Level = level.String()
+	entry.Message, _ = fields[slog.MessageKey].(string)
+
+	for key, value := range fields {
+		switch key {
+		case slog.TimeKey, slog.LevelKey, slog.MessageKey:
+			continue
+		}
+		flattenAttr(entry.Attrs, key, value)
+	}
+
+	return entry, level, true
+}
+
+// flattenAttr stores grouped attributes as group.key
+func flattenAttr(attrs map[string]string, key string, value interface{}) {
+	if group, ok := value.(map[string]interface{}); ok {
+		for member, memberValue := range group {
+			flattenAttr(attrs, key+"."+member, memberValue)
+		}
+		return
+	}
+
+	if s, ok := value.(string); ok {
+		attrs[key] = s
+		return
+	}
+
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		attrs[key] = fmt.Sprint(value)
+		return
+	}
+	attrs[key] = string(encoded)
+}
+
+func entryContains(entry *usecase.LogEntry, text string) bool {
+	if strings.Contains(strings.ToLower(entry.Message), text) {
+		return true
+	}
+	for key, value := range entry.Attrs {
+		if strings.Contains(strings.ToLower(key), text) || strings.Contains(strings.ToLower(value), text) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,146 @@
+package logging
+
+import (
+	"context"
+	"log/slog"
+	"regexp"
+	"strings"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// redacted replaces personal information in log output
+const redacted = "[REDACTED]"
+
+// sensitiveKeys are attribute keys whose values are always masked. Names cannot
+// be recognised in free text, so they must be logged under one of these keys.
+var sensitiveKeys = map[string]bool{
+	"name":           true,
+	"full_name":      true,
+	"kana":           true,
+	"name_kana":      true,
+	"recipient_name": true,
+	"staff_name":     true,
+	"contact_name":   true,
+	"username":       true,
+	"user_name":      true,
+	"address":        true,
+	"phone":          true,
+	"phone_number":   true,
+	"tel":            true,
+	"mobile":         true,
+	"email":          true,
+	"birth_date":     true,
+}
+
+var (
+	// Japanese phone numbers, with or without hyphens: 03-1234-5678, 090-1234-5678, 0312345678.
+	// Matched against NFKC text, so full-width digits are already ASCII; a
+	// match next to another digit is part of a longer number and is skipped
+	phonePattern = regexp.MustCompile(`0[0-9]{1,4}[-‐‑‒–—―−ー][0-9]{1,4}[-‐‑‒–—―−ー][0-9]{3,4}|0[0-9]{9,10}`)
+
+	// Addresses starting with a prefecture, or a municipality followed by a block number
+	addressPattern = regexp.MustCompile(`(北海道|東京都|京都府|大阪府|[^\s　、。,，"]{2,3}県)[^\s　、。,，"]+` +
+		`|[^\s　、。,，"]{1,6}[市区町村][^\s　、。,，"]*?[0-9０-９一二三四五六七八九十]+(丁目|番地|番|号)[^\s　、。,，"]*`)
+)
+
+// RedactHandler masks names, addresses and phone numbers before records reach
+// the wrapped handler
+type RedactHandler struct {
+	next slog.Handler
+}
+
+// NewRedactHandler wraps next with PII redaction
+func NewRedactHandler(next slog.Handler) *RedactHandler {
+	return &RedactHandler{next: next}
+}
+
+// Enabled reports whether the wrapped handler handles records at level
+func (h *RedactHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+// Handle redacts the message and attributes of r and passes it on
+func (h *RedactHandler) Handle(ctx context.Context, r slog.Record) error {
+	clean := slog.NewRecord(r.Time, r.Level, RedactText(r.Message), r.PC)
+	r.Attrs(func(attr slog.Attr) bool {
+		clean.AddAttrs(redactAttr(attr))
+		return true
+	})
+	return h.next.Handle(ctx, clean)
+}
+
+// WithAttrs returns a handler whose preset attributes are already redacted
+func (h *RedactHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	clean := make([]slog.Attr, len(attrs))
+	for i, attr := range attrs {
+		clean[i] = redactAttr(attr)
+	}
+	return &RedactHandler{next: h.next.WithAttrs(clean)}
+}
+
+// WithGroup returns a redacting handler for the group
+func (h *RedactHandler) WithGroup(name string) slog.Handler {
+	return &RedactHandler{next: h.next.WithGroup(name)}
+}
+
+// RedactText masks phone numbers and addresses found in free text. The text
+// is NFKC-normalized first, so ０９０－１２３４－５６７８ is caught as well.
+func RedactText(text string) string {
+	text = redactPhones(norm.NFKC.String(text))
+	return addressPattern.ReplaceAllString(text, redacted)
+}
+
+// redactPhones masks phone numbers that are not part of a longer digit run.
+// The boundary is checked outside the pattern so that it does not consume
+// the separator between two adjacent numbers.
+func redactPhones(text string) string {
+	var b strings.Builder
+	last := 0
+	for _, loc := range phonePattern.FindAllStringIndex(text, -1) {
+		if (loc[0] > 0 && isDigit(text[loc[0]-1])) || (loc[1] < len(text) && isDigit(text[loc[1]])) {
+			continue
+		}
+		b.WriteString(text[last:loc[0]])
+		b.WriteString(redacted)
+		last = loc[1]
+	}
+	b.WriteString(text[last:])
+	return b.String()
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func redactAttr(attr slog.Attr) slog.Attr {
+	value := attr.Value.Resolve()
+
+	if value.Kind() == slog.KindGroup {
+		group := value.Group()
+		clean := make([]any, len(group))
+		for i, member := range group {
+			clean[i] = redactAttr(member)
+		}
+		return slog.Group(attr.Key, clean...)
+	}
+
+	if sensitiveKeys[strings.ToLower(attr.Key)] {
+		return slog.String(attr.Key, redacted)
+	}
+
+	switch value.Kind() {
+	case slog.KindString:
+		return slog.String(attr.Key, RedactText(value.String()))
+	case slog.KindAny:
+		// Errors and other values are logged through their text form
+		if err, ok := value.Any().(error); ok {
+			return slog.String(attr.Key, RedactText(err.Error()))
+		}
+		if s, ok := value.Any().(interface{ String() string }); ok {
+			return slog.String(attr.Key, RedactText(s.String()))
+		}
+	}
+
+	return slog.Attr{Key: attr.Key, Value: value}
+}
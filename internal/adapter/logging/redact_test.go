@@ -0,0 +1,55 @@
+package logging
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedactText(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"mobile phone", "連絡先 090-1234-5678 に連絡", "連絡先 [REDACTED] に連絡"},
+		{"landline without hyphens", "tel=0312345678.", "tel=[REDACTED]."},
+		{"full-width digits", "連絡先 ０９０－１２３４－５６７８ に連絡", "連絡先 [REDACTED] に連絡"},
+		{"adjacent numbers", "090-1234-5678 03-1234-5678", "[REDACTED] [REDACTED]"},
+		{"longer digit runs are kept", "order 10312345678 and 0312345678901", "order 10312345678 and 0312345678901"},
+		{"prefecture address", "住所: 東京都千代田区千代田1-1 を更新", "住所: [REDACTED] を更新"},
+		{"municipal address", "横浜市中区山下町3丁目 へ転居", "[REDACTED] へ転居"},
+		{"identifiers are kept", "recipient 123e4567-e89b-12d3-a456-426614174000 updated", "recipient 123e4567-e89b-12d3-a456-426614174000 updated"},
+		{"timestamps are kept", "backup-20261018-020000.db created", "backup-20261018-020000.db created"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, RedactText(tt.input))
+		})
+	}
+}
+
+func TestRedactHandler(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(NewRedactHandler(slog.NewJSONHandler(&buf, nil)))
+
+	logger.With("staff_name", "山田太郎").Info("recipient updated: 090-1111-2222",
+		"name", "佐藤花子",
+		"recipient_id", "r-001",
+		slog.Group("contact", "phone", "03-1234-5678", "relationship", "母"),
+		"error", errors.New("geocoding failed for 大阪府大阪市北区梅田1-1"),
+	)
+
+	output := buf.String()
+	for _, secret := range []string{"山田太郎", "佐藤花子", "090-1111-2222", "03-1234-5678", "大阪府大阪市北区梅田1-1"} {
+		assert.NotContains(t, output, secret)
+	}
+	assert.Contains(t, output, `"recipient_id":"r-001"`)
+	assert.Contains(t, output, `"relationship":"母"`)
+	assert.Equal(t, 5, strings.Count(output, redacted))
+}
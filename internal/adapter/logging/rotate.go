@@ -0,0 +1,172 @@
+package logging
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RotatingFile is an io.Writer that appends to a log file and moves it aside
+// when it grows past maxSize or, with daily rotation, when the date changes.
+// Rotated files are named <base>-YYYYMMDD-HHMMSS<ext> next to the log file.
+type RotatingFile struct {
+	mu         sync.Mutex
+	path       string
+	maxSize    int64
+	maxBackups int
+	daily      bool
+
+	file      *os.File
+	size      int64
+	openedDay string
+
+	now func() time.Time
+}
+
+// NewRotatingFile opens (or creates) the log file at path. maxBackups is the
+// number of rotated files kept; 0 keeps none.
+func NewRotatingFile(path string, maxSize int64, maxBackups int, daily bool) (*RotatingFile, error) {
+	r := &RotatingFile{
+		path:       path,
+		maxSize:    maxSize,
+		maxBackups: maxBackups,
+		daily:      daily,
+		now:        time.Now,
+	}
+
+	if err := r.open(); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// Write appends p to the log file, rotating first when needed
+func (r *RotatingFile) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.file == nil {
+		return 0, os.ErrClosed
+	}
+
+	if r.shouldRotate(len(p)) {
+		if err := r.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := r.file.Write(p)
+	r.size += int64(n)
+	return n, err
+}
+
+// Close closes the log file
+func (r *RotatingFile) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.file == nil {
+		return nil
+	}
+
+	err := r.file.Close()
+	r.file = nil
+	return err
+}
+
+// Path returns the path of the current log file
+func (r *RotatingFile) Path() string {
+	return r.path
+}
+
+func (r *RotatingFile) shouldRotate(incoming int) bool {
+	if r.size == 0 {
+		return false
+	}
+	if r.maxSize > 0 && r.size+int64(incoming) > r.maxSize {
+		return true
+	}
+	return r.daily && r.now().Format("2006-01-02") != r.openedDay
+}
+
+func (r *RotatingFile) open() error {
+	if err := os.MkdirAll(filepath.Dir(r.path), 0700); err != nil {
+		return fmt.Errorf("failed to create log directory: %w", err)
+	}
+
+	file, err := os.OpenFile(r.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open log file: %w", err)
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("failed to stat log file: %w", err)
+	}
+
+	r.file = file
+	r.size = info.Size()
+	r.openedDay = r.now().Format("2006-01-02")
+	if r.size > 0 {
+		// An existing file belongs to the day it was last written
+		r.openedDay = info.ModTime().Format("2006-01-02")
+	}
+
+	return nil
+}
+
+func (r *RotatingFile) rotate() error {
+	if err := r.file.Close(); err != nil {
+		return fmt.Errorf("failed to close log file: %w", err)
+	}
+	r.file = nil
+
+	ext := filepath.Ext(r.path)
+	base := strings.TrimSuffix(r.path, ext)
+	rotated := fmt.Sprintf("%s-%s%s", base, r.now().Format("20060102-150405"), ext)
+	for i := 1; fileExists(rotated); i++ {
+		rotated = fmt.Sprintf("%s-%s.%d%s", base, r.now().Format("20060102-150405"), i, ext)
+	}
+
+	if err := os.Rename(r.path, rotated); err != nil {
+		return fmt.Errorf("failed to rotate log file: %w", err)
+	}
+
+	r.prune()
+
+	return r.open()
+}
+
+// prune removes the oldest rotated files beyond maxBackups
+func (r *RotatingFile) prune() {
+	backups := RotatedFiles(r.path)
+	for i := r.maxBackups; i < len(backups); i++ {
+		os.Remove(backups[i])
+	}
+}
+
+// RotatedFiles returns the rotated files of the log at path, newest first
+func RotatedFiles(path string) []string {
+	ext := filepath.Ext(path)
+	base := strings.TrimSuffix(path, ext)
+
+	matches, err := filepath.Glob(base + "-*" + ext)
+	if err != nil {
+		return nil
+	}
+
+	// The timestamp in the name sorts chronologically
+	sort.Sort(sort.Reverse(sort.StringSlice(matches)))
+	return matches
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
@@ -0,0 +1,150 @@
+// Package masterdata reads the municipality and service type masters used
+// to code benefit certificates from CSV files.
+package masterdata
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding/japanese"
+
+	"shien-system/internal/domain"
+	"shien-system/internal/validation"
+)
+
+// CSVParser reads master CSV files in Shift_JIS or UTF-8. A heading row is
+// skipped, so files exported from Excel can be imported as they are.
+//
+// Municipalities: 市町村番号, 都道府県, 市町村名. This is also the column order
+// of the local government code list (全国地方公共団体コード); its rows for the
+// prefectures themselves have no 市町村名 and are skipped.
+//
+// Service types: サービス種類コード, サービス名称.
+type CSVParser struct{}
+
+// NewCSVParser creates a new master CSV parser
+func NewCSVParser() *CSVParser {
+	return &CSVParser{}
+}
+
+// ParseMunicipalities returns the municipalities in the file. Codes must be
+// six digits with a valid check digit and appear only once.
+func (p *CSVParser) ParseMunicipalities(r io.Reader) ([]*domain.Municipality, error) {
+	var municipalities []*domain.Municipality
+	seen := make(map[string]int)
+
+	err := readRecords(r, 3, func(line int, record []string) error {
+		code := validation.NormalizeText(record[0])
+		prefecture := validation.NormalizeText(record[1])
+		name := validation.NormalizeText(record[2])
+		if name == "" {
+			return nil
+		}
+		if validation.MunicipalityCodeDigits(code) == "" {
+			return fmt.Errorf("line %d: invalid municipality code %q", line, code)
+		}
+		if first, ok := seen[code]; ok {
+			return fmt.Errorf("line %d: municipality code %s already appears on line %d", line, code, first)
+		}
+		seen[code] = line
+
+		municipalities = append(municipalities, &domain.Municipality{Code: code, Prefecture: prefecture, Name: name})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(municipalities) == 0 {
+		return nil, fmt.Errorf("municipality file has no entries")
+	}
+	return municipalities, nil
+}
+
+// ParseServiceTypes returns the service types in the file. Codes must be two
+// digits and appear only once.
+func (p *CSVParser) ParseServiceTypes(r io.Reader) ([]*domain.ServiceType, error) {
+	var serviceTypes []*domain.ServiceType
+	seen := make(map[string]int)
+
+	err := readRecords(r, 2, func(line int, record []string) error {
+		code := validation.NormalizeText(record[0])
+		name := validation.NormalizeText(record[1])
+		if validation.ServiceCodeDigits(code) == "" {
+			return fmt.Errorf("line %d: invalid service type code %q", line, code)
+		}
+		if name == "" {
+			return fmt.Errorf("line %d: service type %s has no name", line, code)
+		}
+		if first, ok := seen[code]; ok {
+			return fmt.Errorf("line %d: service type code %s already appears on line %d", line, code, first)
+		}
+		seen[code] = line
+
+		serviceTypes = append(serviceTypes, &domain.ServiceType{Code: code, Name: name})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(serviceTypes) == 0 {
+		return nil, fmt.Errorf("service type file has no entries")
+	}
+	return serviceTypes, nil
+}
+
+// readRecords decodes the file and calls fn for every row with at least
+// minFields columns, skipping a heading row and blank rows
+func readRecords(r io.Reader, minFields int, fn func(line int, record []string) error) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return fmt.Errorf("failed to read master file: %w", err)
+	}
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+	if !utf8.Valid(data) {
+		if data, err = japanese.ShiftJIS.NewDecoder().Bytes(data); err != nil {
+			return fmt.Errorf("failed to decode Shift_JIS: %w", err)
+		}
+	}
+
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = -1
+
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("invalid master file: %w", err)
+		}
+		if isBlankRecord(record) {
+			continue
+		}
+		if len(record) < minFields {
+			return fmt.Errorf("line %d: %d columns, want at least %d", line, len(record), minFields)
+		}
+		// The heading row is the only one whose code column is not numeric
+		if line == 1 && strings.Trim(validation.NormalizeText(record[0]), "0123456789") != "" {
+			continue
+		}
+		if err := fn(line, record); err != nil {
+			return err
+		}
+	}
+}
+
+func isBlankRecord(record []string) bool {
+	for _, field := range record {
+		if strings.TrimSpace(field) != "" {
+			return false
+		}
+	}
+	return true
+}
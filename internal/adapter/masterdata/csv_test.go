@@ -0,0 +1,84 @@
+package masterdata
+
+import (
+	"strings"
+	"testing"
+
+	"golang.org/x/text/encoding/japanese"
+)
+
+// municipalitySample follows the local government code list, including the
+// row for the prefecture itself and full-width digits
+const municipalitySample = `団体コード,都道府県名（漢字）,市区町村名（漢字）,都道府県名（カナ）,市区町村名（カナ）
+010006,北海道,,ﾎｯｶｲﾄﾞｳ,
+011002,北海道,札幌市,ﾎｯｶｲﾄﾞｳ,ｻｯﾎﾟﾛｼ
+１３１０１６,東京都,千代田区,ﾄｳｷｮｳﾄ,ﾁﾖﾀﾞｸ
+
+141003,神奈川県,横浜市,ｶﾅｶﾞﾜｹﾝ,ﾖｺﾊﾏｼ
+`
+
+func TestCSVParser_ParseMunicipalitiesShiftJIS(t *testing.T) {
+	encoded, err := japanese.ShiftJIS.NewEncoder().String(municipalitySample)
+	if err != nil {
+		t.Fatalf("failed to encode sample: %v", err)
+	}
+
+	municipalities, err := NewCSVParser().ParseMunicipalities(strings.NewReader(encoded))
+	if err != nil {
+		t.Fatalf("ParseMunicipalities() error = %v", err)
+	}
+
+	want := []struct{ code, prefecture, name string }{
+		{"011002", "北海道", "札幌市"},
+		{"131016", "東京都", "千代田区"},
+		{"141003", "神奈川県", "横浜市"},
+	}
+	if len(municipalities) != len(want) {
+		t.Fatalf("ParseMunicipalities() returned %d entries, want %d: %+v", len(municipalities), len(want), municipalities)
+	}
+	for i, w := range want {
+		got := municipalities[i]
+		if got.Code != w.code || got.Prefecture != w.prefecture || got.Name != w.name {
+			t.Errorf("entry %d = %+v, want %+v", i, got, w)
+		}
+	}
+}
+
+func TestCSVParser_ParseMunicipalitiesRejectsBadRows(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"check digit", "131017,東京都,千代田区\n"},
+		{"duplicate", "131016,東京都,千代田区\n131016,東京都,千代田区\n"},
+		{"too few columns", "131016,千代田区\n"},
+		{"no entries", "団体コード,都道府県名,市区町村名\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewCSVParser().ParseMunicipalities(strings.NewReader(tt.input)); err == nil {
+				t.Error("ParseMunicipalities() succeeded, want error")
+			}
+		})
+	}
+}
+
+func TestCSVParser_ParseServiceTypes(t *testing.T) {
+	input := "\ufeffサービス種類コード,サービス名称\n22,生活介護\n46,就労継続支援Ｂ型\n"
+
+	serviceTypes, err := NewCSVParser().ParseServiceTypes(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ParseServiceTypes() error = %v", err)
+	}
+	if len(serviceTypes) != 2 || serviceTypes[0].Code != "22" || serviceTypes[1].Name != "就労継続支援B型" {
+		t.Errorf("ParseServiceTypes() = %+v", serviceTypes)
+	}
+
+	if _, err := NewCSVParser().ParseServiceTypes(strings.NewReader("460,就労継続支援B型\n")); err == nil {
+		t.Error("ParseServiceTypes() accepted a three-digit code")
+	}
+	if _, err := NewCSVParser().ParseServiceTypes(strings.NewReader("46,\n")); err == nil {
+		t.Error("ParseServiceTypes() accepted a service type without a name")
+	}
+}
@@ -0,0 +1,80 @@
+package pdf
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/go-pdf/fpdf"
+)
+
+// watermarkLabel is printed across every page of an exported report
+const watermarkLabel = "持出禁止"
+
+// ExportOptions are the confidentiality controls for a report that leaves the
+// application as a file. The zero value applies none of them.
+type ExportOptions struct {
+	// ExportedBy and ExportedAt are printed in the watermark on every page.
+	// An empty ExportedBy leaves the pages unmarked.
+	ExportedBy string
+	ExportedAt time.Time
+
+	// Password is required to open the PDF; empty leaves it unencrypted
+	Password string
+
+	// AllowPrint and AllowCopy grant printing and copying text from a
+	// password protected PDF. Editing is never granted.
+	AllowPrint bool
+	AllowCopy  bool
+}
+
+// Protected reports whether the PDF will be password protected
+func (o ExportOptions) Protected() bool {
+	return o.Password != ""
+}
+
+// watermarkDetail returns the line under the label: staff name and time
+func (o ExportOptions) watermarkDetail() string {
+	return fmt.Sprintf("%s  %s", o.ExportedBy, o.ExportedAt.Local().Format("2006/01/02 15:04"))
+}
+
+// protect encrypts the document. The owner password is left empty so fpdf
+// picks a random one and the permissions cannot be lifted by the reader.
+func (o ExportOptions) protect(pdf *fpdf.Fpdf) {
+	if !o.Protected() {
+		return
+	}
+	var permissions byte
+	if o.AllowPrint {
+		permissions |= fpdf.CnProtectPrint
+	}
+	if o.AllowCopy {
+		permissions |= fpdf.CnProtectCopy
+	}
+	pdf.SetProtection(permissions, o.Password, "")
+}
+
+// drawWatermark prints the label, staff name and export time diagonally
+// across the current page. It is drawn semi-transparent over the content so
+// table fills cannot hide it.
+func drawWatermark(pdf *fpdf.Fpdf, family string, opts ExportOptions) {
+	if opts.ExportedBy == "" {
+		return
+	}
+	width, height := pdf.GetPageSize()
+	cx, cy := width/2, height/2
+	angle := math.Atan2(height, width) * 180 / math.Pi
+	detail := opts.watermarkDetail()
+
+	pdf.SetAlpha(0.2, "Normal")
+	pdf.SetTextColor(128, 128, 128)
+	pdf.TransformBegin()
+	pdf.TransformRotate(angle, cx, cy)
+	pdf.SetFont(family, "B", 60)
+	pdf.Text(cx-pdf.GetStringWidth(watermarkLabel)/2, cy, watermarkLabel)
+	pdf.SetFont(family, "", 16)
+	pdf.Text(cx-pdf.GetStringWidth(detail)/2, cy+12, detail)
+	pdf.TransformEnd()
+	pdf.SetAlpha(1, "Normal")
+	pdf.SetTextColor(0, 0, 0)
+}
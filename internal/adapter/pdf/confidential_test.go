@@ -0,0 +1,64 @@
+package pdf
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-pdf/fpdf"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"shien-system/internal/domain"
+)
+
+func TestRender_WatermarkOnEveryPage(t *testing.T) {
+	service := NewPDFService("./fonts", nil)
+	tmpl, err := service.templateFor(ReportStaffList)
+	require.NoError(t, err)
+
+	staff := make([]domain.Staff, 120)
+	for i := range staff {
+		staff[i] = domain.Staff{Name: "staff", Role: domain.RoleStaff}
+	}
+	opts := ExportOptions{
+		ExportedBy: "Yamada",
+		ExportedAt: time.Date(2026, 10, 18, 9, 30, 0, 0, time.Local),
+	}
+	pdfBytes, err := service.render(tmpl, map[string]interface{}{"Staff": staff}, opts, func(pdf *fpdf.Fpdf) {
+		pdf.SetCompression(false)
+	})
+	require.NoError(t, err)
+
+	pages := strings.Split(string(pdfBytes), "/Type /Page\n")[1:]
+	require.Greater(t, len(pages), 1)
+	for i, page := range pages {
+		assert.Contains(t, page, "Yamada  2026/10/18 09:30", "page %d", i+1)
+	}
+
+	// Without an exporting staff member the pages are left unmarked
+	unmarked, err := service.render(tmpl, map[string]interface{}{"Staff": staff}, ExportOptions{}, func(pdf *fpdf.Fpdf) {
+		pdf.SetCompression(false)
+	})
+	require.NoError(t, err)
+	assert.NotContains(t, string(unmarked), "2026/10/18")
+}
+
+func TestPDFService_ExportOptionsPassword(t *testing.T) {
+	service := NewPDFService("./fonts", nil)
+	ctx := context.Background()
+	logs := []domain.AuditLog{{ID: "log-001", Action: "CREATE", At: time.Now()}}
+
+	plain, err := service.GenerateAuditReport(ctx, logs, time.Now(), time.Now(), ExportOptions{})
+	require.NoError(t, err)
+	assert.NotContains(t, string(plain), "/Encrypt")
+
+	protected, err := service.GenerateAuditReport(ctx, logs, time.Now(), time.Now(), ExportOptions{
+		ExportedBy: "Yamada",
+		ExportedAt: time.Now(),
+		Password:   "secret-password",
+		AllowPrint: true,
+	})
+	require.NoError(t, err)
+	assert.Contains(t, string(protected), "/Encrypt")
+}
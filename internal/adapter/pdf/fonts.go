@@ -3,6 +3,11 @@ package pdf
 import (
 	"embed"
 	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+
+	"github.com/go-pdf/fpdf"
 )
 
 // Embedded Japanese fonts for PDF generation
@@ -38,3 +43,99 @@ func (fm *FontManager) HasFont(fontName string) bool {
 	_, err := fs.Stat(fm.fontFS, "fonts/"+fontName)
 	return err == nil
 }
+
+// japaneseFontFamily is the family name Japanese fonts are registered under
+const japaneseFontFamily = "NotoSansCJK"
+
+// fallbackFontFamily is the core PDF font used when no Japanese font is
+// installed. It cannot render Japanese.
+const fallbackFontFamily = "Arial"
+
+// japaneseFontFiles lists the supported TrueType fonts in order of
+// preference. A missing bold file falls back to the regular one.
+var japaneseFontFiles = []struct{ regular, bold string }{
+	{"NotoSansCJK-Regular.ttf", "NotoSansCJK-Bold.ttf"},
+	{"NotoSansJP-Regular.ttf", "NotoSansJP-Bold.ttf"},
+	{"ipaexg.ttf", ""},
+}
+
+// japaneseFont holds the font data found by findJapaneseFont
+type japaneseFont struct {
+	regular []byte
+	bold    []byte
+}
+
+// findJapaneseFont looks for a Japanese font in the embedded fonts and then
+// in the font directory. It returns nil when none is installed.
+func (p *PDFService) findJapaneseFont() *japaneseFont {
+	fontManager := NewFontManager()
+	for _, files := range japaneseFontFiles {
+		if fontManager.HasFont(files.regular) {
+			regular, err := fontManager.GetFontBytes(files.regular)
+			if err != nil {
+				continue
+			}
+			font := &japaneseFont{regular: regular, bold: regular}
+			if bold, err := fontManager.GetFontBytes(files.bold); err == nil {
+				font.bold = bold
+			}
+			return font
+		}
+	}
+
+	for _, files := range japaneseFontFiles {
+		regular, err := os.ReadFile(filepath.Join(p.fontPath, files.regular))
+		if err != nil {
+			continue
+		}
+		font := &japaneseFont{regular: regular, bold: regular}
+		if files.bold != "" {
+			if bold, err := os.ReadFile(filepath.Join(p.fontPath, files.bold)); err == nil {
+				font.bold = bold
+			}
+		}
+		return font
+	}
+	return nil
+}
+
+// JapaneseFontAvailable reports whether a Japanese font was found. Without
+// one, reports are printed with a core font that cannot show Japanese text.
+func (p *PDFService) JapaneseFontAvailable() bool {
+	return p.loadJapaneseFont() != nil
+}
+
+// loadJapaneseFont finds the Japanese font once and warns when it is missing
+func (p *PDFService) loadJapaneseFont() *japaneseFont {
+	p.fontOnce.Do(func() {
+		p.font = p.findJapaneseFont()
+		if p.font == nil {
+			slog.Warn("No Japanese font found; PDF reports cannot show Japanese text",
+				"font_dir", p.fontPath, "expected", japaneseFontFiles[0].regular)
+		}
+	})
+	return p.font
+}
+
+// fontFamily returns the family every report uses for its text
+func (p *PDFService) fontFamily() string {
+	if p.loadJapaneseFont() == nil {
+		return fallbackFontFamily
+	}
+	return japaneseFontFamily
+}
+
+// newDocument creates a PDF document with the Japanese font registered for
+// the regular and bold styles and selected at 12pt
+func (p *PDFService) newDocument(orientation, size string) *fpdf.Fpdf {
+	pdf := fpdf.New(orientation, "mm", size, "")
+	// Must be set before a UTF-8 font is added
+	pdf.AliasNbPages("")
+
+	if font := p.loadJapaneseFont(); font != nil {
+		pdf.AddUTF8FontFromBytes(japaneseFontFamily, "", font.regular)
+		pdf.AddUTF8FontFromBytes(japaneseFontFamily, "B", font.bold)
+	}
+	pdf.SetFont(p.fontFamily(), "", 12)
+	return pdf
+}
@@ -0,0 +1,612 @@
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+	"unicode/utf8"
+
+	"github.com/go-pdf/fpdf"
+	"shien-system/internal/domain"
+)
+
+// templateFuncs are available in every report template
+var templateFuncs = template.FuncMap{
+	"date":     formatTemplateDate,
+	"sex":      sexLabel,
+	"role":     staffRoleLabel,
+	"phones":   formatContactPhones,
+	"truncate": truncateRunes,
+	"join":     func(sep string, items []string) string { return strings.Join(items, sep) },
+	"default": func(fallback string, value interface{}) string {
+		if s := fmt.Sprint(value); value != nil && s != "" {
+			return s
+		}
+		return fallback
+	},
+}
+
+// formatTemplateDate formats a time.Time or *time.Time; zero and nil print nothing
+func formatTemplateDate(layout string, value interface{}) string {
+	switch t := value.(type) {
+	case time.Time:
+		if t.IsZero() {
+			return ""
+		}
+		return t.Format(layout)
+	case *time.Time:
+		if t == nil || t.IsZero() {
+			return ""
+		}
+		return t.Format(layout)
+	default:
+		return ""
+	}
+}
+
+// formatStyledDate formats a time.Time or *time.Time with format; zero and nil print nothing
+func formatStyledDate(format func(time.Time) string, value interface{}) string {
+	switch t := value.(type) {
+	case time.Time:
+		if t.IsZero() {
+			return ""
+		}
+		return format(t)
+	case *time.Time:
+		if t == nil || t.IsZero() {
+			return ""
+		}
+		return format(*t)
+	default:
+		return ""
+	}
+}
+
+// truncateRunes shortens s to at most n characters
+func truncateRunes(n int, s string) string {
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	return string([]rune(s)[:n-1]) + "…"
+}
+
+// parseColor parses #RRGGBB; an empty string is black
+func parseColor(color string) ([3]int, error) {
+	var rgb [3]int
+	if color == "" {
+		return rgb, nil
+	}
+	if len(color) != 7 || color[0] != '#' {
+		return rgb, fmt.Errorf("color %q must be #RRGGBB", color)
+	}
+	for i := range rgb {
+		v, err := strconv.ParseUint(color[1+2*i:3+2*i], 16, 8)
+		if err != nil {
+			return rgb, fmt.Errorf("color %q must be #RRGGBB", color)
+		}
+		rgb[i] = int(v)
+	}
+	return rgb, nil
+}
+
+// renderer lays out one report template on an fpdf document
+type renderer struct {
+	pdf    *fpdf.Fpdf
+	tmpl   *ReportTemplate
+	family string
+	data   interface{}
+	title  string
+	err    error
+	margin float64
+	export ExportOptions
+}
+
+// render produces the PDF for tmpl and data. The document is created by
+// newDocument so Japanese fonts are registered and protected as opts asks;
+// configure can adjust it before the body is laid out.
+func (p *PDFService) render(tmpl *ReportTemplate, data interface{}, opts ExportOptions, configure func(*fpdf.Fpdf)) ([]byte, error) {
+	orientation, size, err := tmpl.Page.paper()
+	if err != nil {
+		return nil, err
+	}
+	pdf := p.newDocument(orientation, size)
+	opts.protect(pdf)
+	if configure != nil {
+		configure(pdf)
+	}
+
+	r := &renderer{pdf: pdf, tmpl: tmpl, family: p.fontFamily(), data: data, margin: tmpl.Page.Margin, export: opts}
+	if r.margin <= 0 {
+		r.margin = 15
+	}
+	r.title = r.execute(tmpl.Title, data)
+	pdf.SetTitle(r.title, true)
+	pdf.SetMargins(r.margin, r.margin, r.margin)
+	pdf.SetAutoPageBreak(true, r.margin)
+	pdf.SetHeaderFuncMode(r.header, true)
+	pdf.SetFooterFunc(r.footer)
+
+	pdf.AddPage()
+	if r.title != "" {
+		r.setFont("B", 16)
+		r.writeLines(r.title, 0, 8, "L")
+		pdf.Ln(4)
+	}
+	for _, block := range tmpl.Blocks {
+		if r.err != nil {
+			break
+		}
+		r.block(block)
+	}
+	if r.err != nil {
+		return nil, fmt.Errorf("report template %s: %w", tmpl.Name, r.err)
+	}
+
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return nil, fmt.Errorf("failed to generate PDF: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// execute evaluates a compiled text template, remembering the first error
+func (r *renderer) execute(text string, data interface{}) string {
+	if text == "" || r.err != nil {
+		return ""
+	}
+	var buf strings.Builder
+	if err := r.tmpl.compiled[text].Execute(&buf, data); err != nil {
+		r.err = err
+		return ""
+	}
+	return strings.TrimSpace(buf.String())
+}
+
+func (r *renderer) baseFontSize() float64 {
+	if r.tmpl.Page.FontSize > 0 {
+		return r.tmpl.Page.FontSize
+	}
+	return 10
+}
+
+func (r *renderer) setFont(style string, size float64) {
+	r.pdf.SetFont(r.family, style, size)
+}
+
+// lineHeight returns the line height in mm for a font size in points
+func lineHeight(size float64) float64 {
+	return size * 0.5
+}
+
+// contentWidth returns the printable width of the page
+func (r *renderer) contentWidth() float64 {
+	width, _ := r.pdf.GetPageSize()
+	left, _, right, _ := r.pdf.GetMargins()
+	return width - left - right
+}
+
+// spaceLeft returns the height left above the bottom margin
+func (r *renderer) spaceLeft() float64 {
+	_, height := r.pdf.GetPageSize()
+	_, _, _, bottom := r.pdf.GetMargins()
+	return height - bottom - r.pdf.GetY()
+}
+
+// wrap splits text into lines fitting width with the current font
+func (r *renderer) wrap(text string, width float64) []string {
+	return wrapText(text, width-2*r.pdf.GetCellMargin(), r.pdf.GetStringWidth)
+}
+
+// writeLines writes wrapped text across width (0 means up to the right margin)
+func (r *renderer) writeLines(text string, width, height float64, align string) {
+	if width == 0 {
+		width = r.contentWidth() - (r.pdf.GetX() - r.margin)
+	}
+	x := r.pdf.GetX()
+	for _, line := range r.wrap(text, width) {
+		r.pdf.SetX(x)
+		r.pdf.CellFormat(width, height, line, "", 1, align, false, 0, "")
+	}
+}
+
+// band prints a header or footer line at y
+func (r *renderer) band(band PageBand, y float64) {
+	if band.Left == "" && band.Center == "" && band.Right == "" {
+		return
+	}
+	data := map[string]interface{}{
+		"Page":  r.pdf.PageNo(),
+		"Pages": "{nb}",
+		"Title": r.title,
+		"Data":  r.data,
+	}
+	size := band.FontSize
+	if size == 0 {
+		size = 8
+	}
+	r.setFont("", size)
+	width := r.contentWidth()
+	for _, part := range []struct{ text, align string }{
+		{band.Left, "L"}, {band.Center, "C"}, {band.Right, "R"},
+	} {
+		if part.text == "" {
+			continue
+		}
+		r.pdf.SetXY(r.margin, y)
+		r.pdf.CellFormat(width, 5, r.execute(part.text, data), "", 0, alignment(part.align), false, 0, "")
+	}
+}
+
+func (r *renderer) header() {
+	r.band(r.tmpl.Header, r.margin/2-2.5)
+	r.pdf.SetXY(r.margin, r.margin)
+}
+
+func (r *renderer) footer() {
+	_, height := r.pdf.GetPageSize()
+	r.band(r.tmpl.Footer, height-r.margin/2-2.5)
+	// The footer runs after the page content, so the watermark is on top
+	drawWatermark(r.pdf, r.family, r.export)
+}
+
+// alignment converts left/center/right to fpdf alignment
+func alignment(align string) string {
+	switch strings.ToLower(align) {
+	case "center", "c":
+		return "C"
+	case "right", "r":
+		return "R"
+	default:
+		return "L"
+	}
+}
+
+// block renders one body block
+func (r *renderer) block(block Block) {
+	if block.If != "" && r.execute(conditionTemplate(block.If), r.data) == "" {
+		return
+	}
+
+	size := block.FontSize
+	style := ""
+	if block.Bold {
+		style = "B"
+	}
+
+	switch block.Type {
+	case "heading":
+		if size == 0 {
+			size = 13
+		}
+		text := r.execute(block.Text, r.data)
+		// Keep a heading with at least one following line
+		if r.spaceLeft() < lineHeight(size)+lineHeight(r.baseFontSize())*3 {
+			r.pdf.AddPage()
+		}
+		r.pdf.Ln(2)
+		r.setFont("B", size)
+		r.writeLines(text, 0, lineHeight(size)+1, alignment(block.Align))
+		r.pdf.Ln(2)
+	case "text":
+		text := r.execute(block.Text, r.data)
+		if text == "" {
+			return
+		}
+		if size == 0 {
+			size = r.baseFontSize()
+		}
+		r.setFont(style, size)
+		r.writeLines(text, 0, lineHeight(size), alignment(block.Align))
+		r.pdf.Ln(2)
+	case "keyvalue":
+		r.keyValue(block, size, style)
+	case "table":
+		r.table(block, size)
+	case "list":
+		r.list(block, size, style)
+	case "banner":
+		r.banner(block, size)
+	case "spacer":
+		r.pdf.Ln(block.Height)
+	case "page_break":
+		r.pdf.AddPage()
+	}
+}
+
+func (r *renderer) keyValue(block Block, size float64, style string) {
+	if size == 0 {
+		size = r.baseFontSize()
+	}
+	labelWidth := block.LabelWidth
+	if labelWidth == 0 {
+		labelWidth = 40
+	}
+	height := lineHeight(size) + 1
+
+	for _, item := range block.Items {
+		value := r.execute(item.Value, r.data)
+		if item.OmitEmpty && value == "" {
+			continue
+		}
+		r.setFont(style, size)
+		lines := r.wrap(value, r.contentWidth()-labelWidth)
+		if r.spaceLeft() < height*float64(len(lines)) && r.spaceLeft() < height*3 {
+			r.pdf.AddPage()
+		}
+		r.pdf.SetX(r.margin)
+		r.setFont("B", size)
+		r.pdf.CellFormat(labelWidth, height, item.Label, "", 0, "L", false, 0, "")
+		r.setFont(style, size)
+		for i, line := range lines {
+			if i > 0 {
+				r.pdf.SetX(r.margin + labelWidth)
+			}
+			r.pdf.CellFormat(0, height, line, "", 1, "L", false, 0, "")
+		}
+	}
+	r.pdf.Ln(3)
+}
+
+// rows resolves the source of a table or list block into its elements
+func (r *renderer) rows(block Block) []interface{} {
+	source, err := resolvePath(r.data, block.Source)
+	if err != nil {
+		r.err = err
+		return nil
+	}
+	value := reflect.ValueOf(source)
+	if !value.IsValid() || (value.Kind() != reflect.Slice && value.Kind() != reflect.Array) {
+		return nil
+	}
+	rows := make([]interface{}, value.Len())
+	for i := range rows {
+		rows[i] = value.Index(i).Interface()
+	}
+	return rows
+}
+
+// table prints a bordered table whose header row is repeated after every
+// page break. Cells wrap and the row grows to the tallest cell.
+func (r *renderer) table(block Block, size float64) {
+	if size == 0 {
+		size = r.baseFontSize() - 1
+	}
+	height := lineHeight(size) + 1
+	rows := r.rows(block)
+	if len(rows) == 0 {
+		r.emptyText(block, size)
+		return
+	}
+
+	widths := columnWidths(block.Columns, r.contentWidth())
+	headers := make([]string, len(block.Columns))
+	for i, column := range block.Columns {
+		headers[i] = column.Header
+	}
+	aligns := make([]string, len(block.Columns))
+	for i, column := range block.Columns {
+		aligns[i] = alignment(column.Align)
+	}
+
+	r.setFont("B", size)
+	headerLines := r.cellLines(headers, widths)
+	printHeader := func() {
+		r.setFont("B", size)
+		r.pdf.SetFillColor(230, 230, 230)
+		r.tableRow(headerLines, widths, nil, height, true)
+		r.pdf.SetFillColor(255, 255, 255)
+		r.setFont("", size)
+	}
+
+	headerHeight := rowHeight(headerLines, height)
+	r.setFont("", size)
+	for i, row := range rows {
+		values := make([]string, len(block.Columns))
+		for j, column := range block.Columns {
+			values[j] = r.execute(column.Value, row)
+		}
+		if r.err != nil {
+			return
+		}
+		lines := r.cellLines(values, widths)
+		needed := rowHeight(lines, height)
+		if i == 0 {
+			needed += headerHeight
+		}
+		if block.Fit {
+			// Leave room for the overflow line while rows remain
+			reserve := 0.0
+			if i < len(rows)-1 {
+				reserve = height
+			}
+			if r.spaceLeft() < needed+reserve {
+				r.overflow(block, size, len(rows)-i)
+				return
+			}
+		}
+		if r.spaceLeft() < needed {
+			r.pdf.AddPage()
+			printHeader()
+		} else if i == 0 {
+			printHeader()
+		}
+		r.tableRow(lines, widths, aligns, height, false)
+	}
+	r.pdf.Ln(4)
+}
+
+// overflow prints the overflow text of a fitted table in place of the rest rows
+func (r *renderer) overflow(block Block, size float64, rest int) {
+	text := r.execute(block.Overflow, map[string]interface{}{"Rest": rest, "Data": r.data})
+	if text != "" {
+		r.setFont("", size)
+		r.pdf.SetX(r.margin)
+		r.writeLines(text, 0, lineHeight(size)+1, "L")
+	}
+	r.pdf.Ln(4)
+}
+
+// columnWidths gives columns without a width an equal share of the rest
+func columnWidths(columns []Column, total float64) []float64 {
+	widths := make([]float64, len(columns))
+	fixed, flexible := 0.0, 0
+	for i, column := range columns {
+		widths[i] = column.Width
+		if column.Width > 0 {
+			fixed += column.Width
+		} else {
+			flexible++
+		}
+	}
+	if flexible > 0 {
+		share := (total - fixed) / float64(flexible)
+		if share < 10 {
+			share = 10
+		}
+		for i := range widths {
+			if widths[i] == 0 {
+				widths[i] = share
+			}
+		}
+	}
+	return widths
+}
+
+func (r *renderer) cellLines(values []string, widths []float64) [][]string {
+	lines := make([][]string, len(values))
+	for i, value := range values {
+		lines[i] = r.wrap(value, widths[i])
+	}
+	return lines
+}
+
+func rowHeight(lines [][]string, height float64) float64 {
+	max := 1
+	for _, cell := range lines {
+		if len(cell) > max {
+			max = len(cell)
+		}
+	}
+	return float64(max) * height
+}
+
+// tableRow draws one row of bordered cells at the current position
+func (r *renderer) tableRow(lines [][]string, widths []float64, aligns []string, height float64, fill bool) {
+	x, y := r.margin, r.pdf.GetY()
+	total := rowHeight(lines, height)
+	style := "D"
+	if fill {
+		style = "FD"
+	}
+	for i, cell := range lines {
+		r.pdf.Rect(x, y, widths[i], total, style)
+		align := "L"
+		if aligns != nil {
+			align = aligns[i]
+		}
+		for j, line := range cell {
+			r.pdf.SetXY(x, y+float64(j)*height)
+			r.pdf.CellFormat(widths[i], height, line, "", 0, align, false, 0, "")
+		}
+		x += widths[i]
+	}
+	r.pdf.SetXY(r.margin, y+total)
+}
+
+func (r *renderer) list(block Block, size float64, style string) {
+	if size == 0 {
+		size = r.baseFontSize()
+	}
+	rows := r.rows(block)
+	if len(rows) == 0 {
+		r.emptyText(block, size)
+		return
+	}
+	r.setFont(style, size)
+	for _, row := range rows {
+		text := r.execute(block.Text, row)
+		if r.err != nil {
+			return
+		}
+		r.pdf.SetX(r.margin + 4)
+		r.writeLines("・"+text, r.contentWidth()-4, lineHeight(size), "L")
+	}
+	r.pdf.Ln(3)
+}
+
+func (r *renderer) emptyText(block Block, size float64) {
+	text := r.execute(block.Empty, r.data)
+	if text == "" {
+		return
+	}
+	r.setFont("", size)
+	r.pdf.SetX(r.margin)
+	r.writeLines(text, 0, lineHeight(size), "L")
+	r.pdf.Ln(3)
+}
+
+// banner prints text in a filled box, used for warnings such as critical allergies
+func (r *renderer) banner(block Block, size float64) {
+	text := r.execute(block.Text, r.data)
+	if text == "" {
+		return
+	}
+	if size == 0 {
+		size = 12
+	}
+	fill, _ := parseColor(block.Fill)
+	color, _ := parseColor(block.Color)
+	height := lineHeight(size) + 2
+
+	r.setFont("B", size)
+	lines := r.wrap(text, r.contentWidth())
+	total := height * float64(len(lines))
+	if r.spaceLeft() < total {
+		r.pdf.AddPage()
+	}
+	x, y := r.margin, r.pdf.GetY()
+	r.pdf.SetFillColor(fill[0], fill[1], fill[2])
+	r.pdf.Rect(x, y, r.contentWidth(), total, "FD")
+	r.pdf.SetTextColor(color[0], color[1], color[2])
+	for i, line := range lines {
+		r.pdf.SetXY(x, y+float64(i)*height)
+		r.pdf.CellFormat(r.contentWidth(), height, line, "", 0, "L", false, 0, "")
+	}
+	r.pdf.SetTextColor(0, 0, 0)
+	r.pdf.SetFillColor(255, 255, 255)
+	r.pdf.SetXY(r.margin, y+total)
+	r.pdf.Ln(5)
+}
+
+// certificateRow is a certificate with the values looked up for the list report
+type certificateRow struct {
+	domain.BenefitCertificate
+	RecipientName string
+	Status        string
+}
+
+// countRow is a labelled count of the incident statistics report
+type countRow struct {
+	Label string
+	Count int
+}
+
+// signOffRow is a sign-off row of the incident report: who and when
+type signOffRow struct {
+	Label string
+	Name  string
+	At    *time.Time
+}
+
+// localTime returns t in local time, so templates print the office's clock
+func localTime(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	local := t.Local()
+	return &local
+}
@@ -1,11 +1,11 @@
 package pdf
 
 import (
-	"bytes"
 	"context"
 	"fmt"
-	"os"
-	"path/filepath"
+	"log/slog"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/go-pdf/fpdf"
@@ -16,346 +16,249 @@ import (
 type PDFService struct {
 	fontPath string
 	cipher   *crypto.FieldCipher
+
+	fontOnce sync.Once
+	font     *japaneseFont
+
+	mu        sync.RWMutex
+	templates map[string]*ReportTemplate
+	selected  map[string]string // report kind -> template name
 }
 
+// NewPDFService creates the PDF service with the built-in report templates.
+// Call LoadTemplates to add the office's own templates.
 func NewPDFService(fontPath string, cipher *crypto.FieldCipher) *PDFService {
-	return &PDFService{
+	service := &PDFService{
 		fontPath: fontPath,
 		cipher:   cipher,
 	}
+	if err := service.LoadTemplates("", nil, nil); err != nil {
+		slog.Error("Failed to load report templates", "error", err)
+	}
+	return service
 }
 
-// GenerateRecipientReport generates a comprehensive recipient report
-func (p *PDFService) GenerateRecipientReport(ctx context.Context, recipient *domain.Recipient, certificates []domain.BenefitCertificate, assignments []domain.StaffAssignment) ([]byte, error) {
-	pdf := fpdf.New("P", "mm", "A4", "")
-
-	// Use Arial as default font (Japanese fonts would be added in production)
-	pdf.SetFont("Arial", "", 12)
-
-	pdf.AddPage()
-
-	// Title
-	pdf.SetFont("Arial", "B", 16)
-	pdf.Cell(0, 10, "利用者情報報告書")
-	pdf.Ln(15)
-
-	// Basic information section
-	p.addBasicInfo(pdf, recipient)
-
-	// Certificates section
-	if len(certificates) > 0 {
-		p.addCertificatesSection(pdf, certificates)
+// renderReport renders the template selected for kind with data. GeneratedAt
+// is the current time unless data has it; configure is passed on to render.
+func (p *PDFService) renderReport(kind string, data map[string]interface{}, opts ExportOptions, configure func(*fpdf.Fpdf)) ([]byte, error) {
+	tmpl, err := p.templateFor(kind)
+	if err != nil {
+		return nil, err
 	}
-
-	// Staff assignments section
-	if len(assignments) > 0 {
-		p.addAssignmentsSection(pdf, assignments)
+	if _, ok := data["GeneratedAt"]; !ok {
+		data["GeneratedAt"] = time.Now()
 	}
+	return p.render(tmpl, data, opts, configure)
+}
 
-	// Footer
-	p.addFooter(pdf)
-
-	// Generate PDF bytes
-	var buf bytes.Buffer
-	if err := pdf.Output(&buf); err != nil {
-		return nil, fmt.Errorf("failed to generate PDF: %w", err)
+// GenerateRecipientReport generates a comprehensive recipient report with the
+// confidentiality controls in opts.
+// Template data: Recipient, Certificates, Assignments, Contacts, Medical,
+// CriticalAllergies and GeneratedAt.
+func (p *PDFService) GenerateRecipientReport(ctx context.Context, recipient *domain.Recipient, certificates []domain.BenefitCertificate, assignments []domain.StaffAssignment, contacts []domain.EmergencyContact, medical *domain.MedicalRecord, opts ExportOptions) ([]byte, error) {
+	// Critical allergies are shown before anything else
+	criticalAllergies := ""
+	if medical != nil {
+		var items []string
+		for _, allergy := range medical.CriticalAllergies() {
+			item := allergy.Allergen
+			if allergy.Reaction != "" {
+				item += fmt.Sprintf(" (%s)", allergy.Reaction)
+			}
+			items = append(items, item)
+		}
+		criticalAllergies = strings.Join(items, "、")
 	}
 
-	return buf.Bytes(), nil
+	return p.renderReport(ReportRecipient, map[string]interface{}{
+		"Recipient":         recipient,
+		"Certificates":      certificates,
+		"Assignments":       assignments,
+		"Contacts":          contacts,
+		"Medical":           medical,
+		"CriticalAllergies": criticalAllergies,
+	}, opts, nil)
 }
 
-// GenerateAuditReport generates an audit log report
-func (p *PDFService) GenerateAuditReport(ctx context.Context, logs []domain.AuditLog, startDate, endDate time.Time) ([]byte, error) {
-	pdf := fpdf.New("P", "mm", "A4", "")
-
-	// Use Arial as default font (Japanese fonts would be added in production)
-	pdf.SetFont("Arial", "", 12)
-
-	pdf.AddPage()
-
-	// Title
-	pdf.SetFont("Arial", "B", 16)
-	title := fmt.Sprintf("監査ログ報告書 (%s - %s)",
-		startDate.Format("2006/01/02"), endDate.Format("2006/01/02"))
-	pdf.Cell(0, 10, title)
-	pdf.Ln(15)
-
-	// Audit logs table
-	p.addAuditLogsTable(pdf, logs)
-
-	// Footer
-	p.addFooter(pdf)
-
-	var buf bytes.Buffer
-	if err := pdf.Output(&buf); err != nil {
-		return nil, fmt.Errorf("failed to generate PDF: %w", err)
-	}
-
-	return buf.Bytes(), nil
+// GenerateAuditReport generates an audit log report with the confidentiality
+// controls in opts.
+// Template data: Logs, StartDate, EndDate and GeneratedAt.
+func (p *PDFService) GenerateAuditReport(ctx context.Context, logs []domain.AuditLog, startDate, endDate time.Time, opts ExportOptions) ([]byte, error) {
+	return p.renderReport(ReportAuditLog, map[string]interface{}{
+		"Logs":      logs,
+		"StartDate": startDate,
+		"EndDate":   endDate,
+	}, opts, nil)
 }
 
-// GenerateStaffReport generates a PDF report for staff list
+// GenerateStaffReport generates a PDF report for staff list.
+// Template data: Staff and GeneratedAt.
 func (p *PDFService) GenerateStaffReport(ctx context.Context, staff []domain.Staff) ([]byte, error) {
-	pdf := fpdf.New("P", "mm", "A4", "")
-
-	// Use Arial as default font (Japanese fonts would be added in production)
-	pdf.SetFont("Arial", "", 12)
-
-	pdf.AddPage()
-
-	// Title
-	pdf.SetFont("Arial", "B", 16)
-	title := fmt.Sprintf("職員一覧 (%s)", time.Now().Format("2006年01月02日"))
-	pdf.Cell(0, 10, title)
-	pdf.Ln(15)
-
-	// Staff table
-	p.addStaffTable(pdf, staff)
-
-	// Footer
-	p.addFooter(pdf)
+	return p.renderReport(ReportStaffList, map[string]interface{}{
+		"Staff": staff,
+	}, ExportOptions{}, nil)
+}
 
-	var buf bytes.Buffer
-	if err := pdf.Output(&buf); err != nil {
-		return nil, fmt.Errorf("failed to generate PDF: %w", err)
+// GenerateCertificateReport generates a PDF report for certificate list with
+// the confidentiality controls in opts.
+// Template data: Certificates (with RecipientName and Status) and GeneratedAt.
+func (p *PDFService) GenerateCertificateReport(ctx context.Context, certificates []domain.BenefitCertificate, recipientMap map[domain.ID]*domain.Recipient, opts ExportOptions) ([]byte, error) {
+	rows := make([]certificateRow, len(certificates))
+	for i, cert := range certificates {
+		recipientName := "不明"
+		if recipient, exists := recipientMap[cert.RecipientID]; exists && recipient != nil {
+			recipientName = recipient.Name
+		}
+		rows[i] = certificateRow{
+			BenefitCertificate: cert,
+			RecipientName:      recipientName,
+			Status:             p.calculateCertificateStatus(cert),
+		}
 	}
 
-	return buf.Bytes(), nil
+	return p.renderReport(ReportCertificateList, map[string]interface{}{
+		"Certificates": rows,
+	}, opts, nil)
 }
 
-// GenerateCertificateReport generates a PDF report for certificate list
-func (p *PDFService) GenerateCertificateReport(ctx context.Context, certificates []domain.BenefitCertificate, recipientMap map[domain.ID]*domain.Recipient) ([]byte, error) {
-	pdf := fpdf.New("P", "mm", "A4", "")
-
-	// Use Arial as default font (Japanese fonts would be added in production)
-	pdf.SetFont("Arial", "", 12)
-
-	pdf.AddPage()
-
-	// Title
-	pdf.SetFont("Arial", "B", 16)
-	title := fmt.Sprintf("受給者証一覧 (%s)", time.Now().Format("2006年01月02日"))
-	pdf.Cell(0, 10, title)
-	pdf.Ln(15)
-
-	// Certificate table
-	p.addCertificateTable(pdf, certificates, recipientMap)
-
-	// Footer
-	p.addFooter(pdf)
-
-	var buf bytes.Buffer
-	if err := pdf.Output(&buf); err != nil {
-		return nil, fmt.Errorf("failed to generate PDF: %w", err)
-	}
-
-	return buf.Bytes(), nil
+// GenerateEnrollmentReport generates a PDF roster of recipients enrolled on the given date.
+// Template data: Date, Recipients and GeneratedAt.
+func (p *PDFService) GenerateEnrollmentReport(ctx context.Context, date time.Time, recipients []domain.Recipient) ([]byte, error) {
+	return p.renderReport(ReportEnrollmentRoster, map[string]interface{}{
+		"Date":       date,
+		"Recipients": recipients,
+	}, ExportOptions{}, nil)
 }
 
-// addJapaneseFont adds Japanese font support to the PDF
-func (p *PDFService) addJapaneseFont(pdf *fpdf.Fpdf) error {
-	// Try to use embedded fonts first
-	fontManager := NewFontManager()
-	
-	// Check for Noto Sans CJK JP font (recommended for Japanese)
-	if fontManager.HasFont("NotoSansCJK-Regular.ttf") {
-		fontBytes, err := fontManager.GetFontBytes("NotoSansCJK-Regular.ttf")
-		if err == nil {
-			// Write font to temporary file for fpdf
-			tmpFile, err := os.CreateTemp("", "font-*.ttf")
-			if err == nil {
-				defer os.Remove(tmpFile.Name())
-				defer tmpFile.Close()
-				
-				if _, err := tmpFile.Write(fontBytes); err == nil {
-					pdf.AddUTF8Font("NotoSansCJK", "", tmpFile.Name())
-					pdf.SetFont("NotoSansCJK", "", 12)
-					return nil
-				}
-			}
+// GenerateDisclosureReport generates the human-readable part of a disclosure package (開示請求).
+// The machine-readable JSON is embedded as a document attachment, and a non-empty password
+// encrypts both the document and the attachment.
+// Template data: Recipient, EnrollmentPeriods, Certificates, Assignments, Consents, Contacts,
+// Medical, Incidents, Merges, AuditLogs and GeneratedAt (when the package was assembled).
+func (p *PDFService) GenerateDisclosureReport(ctx context.Context, pkg *domain.DisclosurePackage, jsonData []byte, password string) ([]byte, error) {
+	// Printing is allowed; the owner password is random
+	opts := ExportOptions{Password: password, AllowPrint: true}
+
+	return p.renderReport(ReportDisclosure, map[string]interface{}{
+		"Recipient":         &pkg.Recipient,
+		"EnrollmentPeriods": pkg.EnrollmentPeriods,
+		"Certificates":      pkg.Certificates,
+		"Assignments":       pkg.Assignments,
+		"Consents":          pkg.Consents,
+		"Contacts":          pkg.EmergencyContacts,
+		"Medical":           pkg.MedicalRecord,
+		"Incidents":         pkg.Incidents,
+		"Merges":            pkg.Merges,
+		"AuditLogs":         pkg.AuditLogs,
+		"GeneratedAt":       pkg.GeneratedAt,
+	}, opts, func(pdf *fpdf.Fpdf) {
+		// Machine-readable copy
+		if len(jsonData) > 0 {
+			pdf.SetAttachments([]fpdf.Attachment{{
+				Content:     jsonData,
+				Filename:    fmt.Sprintf("disclosure_%s.json", pkg.Recipient.ID),
+				Description: "開示データ (JSON)",
+			}})
 		}
-	}
-	
-	// Check for external font files
-	fontFile := filepath.Join(p.fontPath, "NotoSansCJK-Regular.ttf")
-	if _, err := os.Stat(fontFile); err == nil {
-		pdf.AddUTF8Font("NotoSansCJK", "", fontFile)
-		boldFontFile := filepath.Join(p.fontPath, "NotoSansCJK-Bold.ttf")
-		if _, err := os.Stat(boldFontFile); err == nil {
-			pdf.AddUTF8Font("NotoSansCJK", "B", boldFontFile)
-		}
-		pdf.SetFont("NotoSansCJK", "", 12)
-		return nil
-	}
-	
-	// Fallback to Arial for basic ASCII text
-	pdf.SetFont("Arial", "", 12)
-	return nil
+	})
 }
 
-// addBasicInfo adds basic recipient information to PDF
-func (p *PDFService) addBasicInfo(pdf *fpdf.Fpdf, recipient *domain.Recipient) {
-	pdf.SetFont("Arial", "B", 14)
-	pdf.Cell(0, 8, "基本情報")
-	pdf.Ln(12)
-
-	pdf.SetFont("Arial", "", 10)
-
-	// Name
-	pdf.Cell(40, 6, "氏名:")
-	pdf.Cell(0, 6, recipient.Name)
-	pdf.Ln(8)
+// GenerateEmergencyContactSheet generates a one-page emergency contact sheet to carry on excursions.
+// The built-in template fits the contacts table to the page and counts the rest.
+// Template data: Recipient, Contacts (in calling order) and GeneratedAt.
+func (p *PDFService) GenerateEmergencyContactSheet(ctx context.Context, recipient *domain.Recipient, contacts []domain.EmergencyContact) ([]byte, error) {
+	return p.renderReport(ReportEmergencyContacts, map[string]interface{}{
+		"Recipient": recipient,
+		"Contacts":  contacts,
+	}, ExportOptions{}, nil)
+}
 
-	// Kana
-	if recipient.Kana != "" {
-		pdf.Cell(40, 6, "フリガナ:")
-		pdf.Cell(0, 6, recipient.Kana)
-		pdf.Ln(8)
+// GenerateIncidentReport generates an accident/near-miss report laid out like the
+// municipal standard form (事故報告書), with sign-off rows for the workflow.
+// Template data: Report, Recipients (unknown ones named 不明), Staff (names of the
+// staff involved), SignOffs (Label, Name and At) and GeneratedAt.
+func (p *PDFService) GenerateIncidentReport(ctx context.Context, report *domain.IncidentReport, recipientMap map[domain.ID]*domain.Recipient, staffMap map[domain.ID]*domain.Staff) ([]byte, error) {
+	recipients := make([]domain.Recipient, len(report.RecipientIDs))
+	for i, recipientID := range report.RecipientIDs {
+		if recipient, ok := recipientMap[recipientID]; ok {
+			recipients[i] = *recipient
+		} else {
+			recipients[i] = domain.Recipient{ID: recipientID, Name: "不明"}
+		}
 	}
 
-	// Birth date
-	pdf.Cell(40, 6, "生年月日:")
-	pdf.Cell(0, 6, recipient.BirthDate.Format("2006年01月02日"))
-	pdf.Ln(8)
-
-	// Sex
-	pdf.Cell(40, 6, "性別:")
-	sexStr := p.formatSex(recipient.Sex)
-	pdf.Cell(0, 6, sexStr)
-	pdf.Ln(8)
-
-	// Disability information
-	if recipient.DisabilityName != "" {
-		pdf.Cell(40, 6, "障害名:")
-		pdf.Cell(0, 6, recipient.DisabilityName)
-		pdf.Ln(8)
+	signOffs := []signOffRow{
+		{Label: "報告者", Name: p.formatIncidentStaff([]domain.ID{report.ReportedBy}, staffMap), At: localTime(report.SubmittedAt)},
+		{Label: "確認者", At: localTime(report.ReviewedAt)},
+		{Label: "承認者", At: localTime(report.ApprovedAt)},
 	}
-
-	// Address
-	if recipient.Address != "" {
-		pdf.Cell(40, 6, "住所:")
-		pdf.Cell(0, 6, recipient.Address)
-		pdf.Ln(8)
+	if report.ReviewedBy != nil {
+		signOffs[1].Name = p.formatIncidentStaff([]domain.ID{*report.ReviewedBy}, staffMap)
+	}
+	if report.ApprovedBy != nil {
+		signOffs[2].Name = p.formatIncidentStaff([]domain.ID{*report.ApprovedBy}, staffMap)
 	}
 
-	pdf.Ln(8)
+	return p.renderReport(ReportIncident, map[string]interface{}{
+		"Report":     report,
+		"Recipients": recipients,
+		"Staff":      p.formatIncidentStaff(report.StaffIDs, staffMap),
+		"SignOffs":   signOffs,
+	}, ExportOptions{}, nil)
 }
 
-// addCertificatesSection adds benefit certificates section
-func (p *PDFService) addCertificatesSection(pdf *fpdf.Fpdf, certificates []domain.BenefitCertificate) {
-	pdf.SetFont("Arial", "B", 14)
-	pdf.Cell(0, 8, "受給者証情報")
-	pdf.Ln(12)
-
-	pdf.SetFont("Arial", "", 9)
-
-	// Table header
-	pdf.Cell(40, 6, "サービス種別")
-	pdf.Cell(30, 6, "開始日")
-	pdf.Cell(30, 6, "終了日")
-	pdf.Cell(30, 6, "発行者")
-	pdf.Cell(30, 6, "月間日数")
-	pdf.Ln(8)
-
-	// Table content
-	for _, cert := range certificates {
-		pdf.Cell(40, 6, cert.ServiceType)
-		pdf.Cell(30, 6, cert.StartDate.Format("2006/01/02"))
-		pdf.Cell(30, 6, cert.EndDate.Format("2006/01/02"))
-		pdf.Cell(30, 6, cert.Issuer)
-		pdf.Cell(30, 6, fmt.Sprintf("%d日", cert.MaxBenefitDaysPerMonth))
-		pdf.Ln(6)
+// GenerateIncidentStatisticsReport generates the monthly incident statistics by category and severity.
+// Template data: Statistics, ByCategory, BySeverity (Label and Count) and GeneratedAt.
+func (p *PDFService) GenerateIncidentStatisticsReport(ctx context.Context, stats *domain.IncidentStatistics) ([]byte, error) {
+	byCategory := make([]countRow, len(domain.IncidentCategories))
+	for i, category := range domain.IncidentCategories {
+		byCategory[i] = countRow{Label: category.Label(), Count: stats.ByCategory[category]}
+	}
+	bySeverity := make([]countRow, len(domain.IncidentSeverities))
+	for i, severity := range domain.IncidentSeverities {
+		bySeverity[i] = countRow{Label: severity.Label(), Count: stats.BySeverity[severity]}
 	}
 
-	pdf.Ln(8)
+	return p.renderReport(ReportIncidentStatistics, map[string]interface{}{
+		"Statistics": stats,
+		"ByCategory": byCategory,
+		"BySeverity": bySeverity,
+	}, ExportOptions{}, nil)
 }
 
-// addAssignmentsSection adds staff assignments section
-func (p *PDFService) addAssignmentsSection(pdf *fpdf.Fpdf, assignments []domain.StaffAssignment) {
-	pdf.SetFont("Arial", "B", 14)
-	pdf.Cell(0, 8, "担当者割り当て")
-	pdf.Ln(12)
-
-	pdf.SetFont("Arial", "", 9)
-
-	// Table header
-	pdf.Cell(50, 6, "担当者ID")
-	pdf.Cell(40, 6, "役割")
-	pdf.Cell(40, 6, "開始日")
-	pdf.Cell(40, 6, "終了日")
-	pdf.Ln(8)
-
-	// Table content
-	for _, assignment := range assignments {
-		pdf.Cell(50, 6, assignment.StaffID)
-		pdf.Cell(40, 6, assignment.Role)
-		pdf.Cell(40, 6, assignment.AssignedAt.Format("2006/01/02"))
-
-		endDate := ""
-		if assignment.UnassignedAt != nil {
-			endDate = assignment.UnassignedAt.Format("2006/01/02")
-		}
-		pdf.Cell(40, 6, endDate)
-		pdf.Ln(6)
+// formatContactPhones joins the registered phone numbers of a contact with their labels
+func formatContactPhones(contact domain.EmergencyContact) string {
+	var phones []string
+	if contact.Phone != "" {
+		phones = append(phones, "自宅 "+contact.Phone)
 	}
-
-	pdf.Ln(8)
+	if contact.MobilePhone != "" {
+		phones = append(phones, "携帯 "+contact.MobilePhone)
+	}
+	if contact.WorkPhone != "" {
+		phones = append(phones, "勤務先 "+contact.WorkPhone)
+	}
+	return strings.Join(phones, " / ")
 }
 
-// addAuditLogsTable adds audit logs table to PDF
-func (p *PDFService) addAuditLogsTable(pdf *fpdf.Fpdf, logs []domain.AuditLog) {
-	pdf.SetFont("Arial", "", 8)
-
-	// Table header
-	pdf.Cell(30, 6, "日時")
-	pdf.Cell(30, 6, "実行者")
-	pdf.Cell(30, 6, "アクション")
-	pdf.Cell(30, 6, "対象")
-	pdf.Cell(70, 6, "詳細")
-	pdf.Ln(8)
-
-	// Table content
-	for _, log := range logs {
-		pdf.Cell(30, 6, log.At.Format("01/02 15:04"))
-		pdf.Cell(30, 6, log.ActorID)
-		pdf.Cell(30, 6, log.Action)
-		pdf.Cell(30, 6, log.Target)
-
-		// Truncate details if too long
-		details := log.Details
-		if len(details) > 30 {
-			details = details[:27] + "..."
+// formatIncidentStaff joins staff names, falling back to the ID for unknown staff
+func (p *PDFService) formatIncidentStaff(staffIDs []domain.ID, staffMap map[domain.ID]*domain.Staff) string {
+	names := make([]string, 0, len(staffIDs))
+	for _, staffID := range staffIDs {
+		if staff, ok := staffMap[staffID]; ok {
+			names = append(names, staff.Name)
+		} else {
+			names = append(names, staffID)
 		}
-		pdf.Cell(70, 6, details)
-		pdf.Ln(6)
 	}
-}
-
-// addStaffTable adds a staff table to the PDF
-func (p *PDFService) addStaffTable(pdf *fpdf.Fpdf, staff []domain.Staff) {
-	pdf.SetFont("Arial", "", 9)
-
-	// Header
-	pdf.Cell(60, 6, "職員名")
-	pdf.Cell(40, 6, "ロール")
-	pdf.Cell(50, 6, "作成日")
-	pdf.Cell(40, 6, "更新日")
-	pdf.Ln(8)
-
-	// Data rows
-	for _, s := range staff {
-		pdf.Cell(60, 6, s.Name)
-		pdf.Cell(40, 6, p.formatStaffRole(s.Role))
-		pdf.Cell(50, 6, s.CreatedAt.Format("2006/01/02 15:04"))
-		pdf.Cell(40, 6, s.UpdatedAt.Format("2006/01/02 15:04"))
-		pdf.Ln(6)
+	if len(names) == 0 {
+		return "なし"
 	}
-
-	pdf.Ln(8)
+	return strings.Join(names, "、")
 }
 
-// formatStaffRole formats staff role for Japanese display
-func (p *PDFService) formatStaffRole(role domain.StaffRole) string {
+// staffRoleLabel formats staff role for Japanese display
+func staffRoleLabel(role domain.StaffRole) string {
 	switch role {
 	case domain.RoleAdmin:
 		return "管理者"
@@ -368,44 +271,6 @@ func (p *PDFService) formatStaffRole(role domain.StaffRole) string {
 	}
 }
 
-// addCertificateTable adds a certificate table to the PDF
-func (p *PDFService) addCertificateTable(pdf *fpdf.Fpdf, certificates []domain.BenefitCertificate, recipientMap map[domain.ID]*domain.Recipient) {
-	pdf.SetFont("Arial", "", 8)
-
-	// Header
-	pdf.Cell(50, 6, "利用者名")
-	pdf.Cell(30, 6, "サービス種別")
-	pdf.Cell(25, 6, "開始日")
-	pdf.Cell(25, 6, "終了日")
-	pdf.Cell(30, 6, "発行者")
-	pdf.Cell(20, 6, "月間日数")
-	pdf.Cell(20, 6, "状態")
-	pdf.Ln(8)
-
-	// Data rows
-	for _, cert := range certificates {
-		// Get recipient name
-		recipientName := "不明"
-		if recipient, exists := recipientMap[cert.RecipientID]; exists && recipient != nil {
-			recipientName = recipient.Name
-		}
-
-		// Calculate status
-		status := p.calculateCertificateStatus(cert)
-
-		pdf.Cell(50, 6, recipientName)
-		pdf.Cell(30, 6, cert.ServiceType)
-		pdf.Cell(25, 6, cert.StartDate.Format("2006/01/02"))
-		pdf.Cell(25, 6, cert.EndDate.Format("2006/01/02"))
-		pdf.Cell(30, 6, cert.Issuer)
-		pdf.Cell(20, 6, fmt.Sprintf("%d日", cert.MaxBenefitDaysPerMonth))
-		pdf.Cell(20, 6, status)
-		pdf.Ln(6)
-	}
-
-	pdf.Ln(8)
-}
-
 // calculateCertificateStatus calculates the status of a certificate
 func (p *PDFService) calculateCertificateStatus(cert domain.BenefitCertificate) string {
 	now := time.Now()
@@ -423,15 +288,13 @@ func (p *PDFService) calculateCertificateStatus(cert domain.BenefitCertificate)
 	return "有効"
 }
 
-// addFooter adds footer to PDF
-func (p *PDFService) addFooter(pdf *fpdf.Fpdf) {
-	pdf.SetY(-15)
-	pdf.SetFont("Arial", "", 8)
-	pdf.Cell(0, 10, fmt.Sprintf("生成日時: %s", time.Now().Format("2006年01月02日 15:04:05")))
-}
-
 // formatSex formats sex enum to Japanese string
 func (p *PDFService) formatSex(sex domain.Sex) string {
+	return sexLabel(sex)
+}
+
+// sexLabel formats sex enum to Japanese string
+func sexLabel(sex domain.Sex) string {
 	switch sex {
 	case domain.SexMale:
 		return "男性"
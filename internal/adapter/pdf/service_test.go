@@ -1,7 +1,9 @@
 package pdf
 
 import (
+	"bytes"
 	"context"
+	"fmt"
 	"testing"
 	"time"
 
@@ -65,8 +67,36 @@ func TestPDFService_GenerateRecipientReport(t *testing.T) {
 		},
 	}
 
+	// Create test emergency contacts
+	contacts := []domain.EmergencyContact{
+		{
+			ID:           "contact-001",
+			RecipientID:  "recipient-001",
+			Priority:     1,
+			Name:         "テスト花子",
+			Relationship: "母",
+			IsGuardian:   true,
+			MobilePhone:  "090-1234-5678",
+			Notes:        "体調不良時は最初に連絡",
+		},
+	}
+
+	// Create test medical record with a critical allergy
+	medical := &domain.MedicalRecord{
+		RecipientID: "recipient-001",
+		Medications: []domain.Medication{
+			{Name: "デパケンR", Dose: "200mg", Timing: "朝夕食後"},
+		},
+		Allergies: []domain.Allergy{
+			{Allergen: "そば", Reaction: "アナフィラキシー", Critical: true},
+		},
+		HasEpilepsy:     true,
+		SeizureProtocol: "5分以上続く場合は救急要請",
+		HospitalName:    "テスト中央病院",
+	}
+
 	ctx := context.Background()
-	pdfBytes, err := service.GenerateRecipientReport(ctx, recipient, certificates, assignments)
+	pdfBytes, err := service.GenerateRecipientReport(ctx, recipient, certificates, assignments, contacts, medical, ExportOptions{})
 
 	assert.NoError(t, err)
 	assert.NotEmpty(t, pdfBytes)
@@ -74,6 +104,11 @@ func TestPDFService_GenerateRecipientReport(t *testing.T) {
 
 	// Check PDF header
 	assert.Equal(t, "%PDF", string(pdfBytes[:4]), "Should start with PDF header")
+
+	// The medical section and allergy banner add content to the report
+	withoutMedical, err := service.GenerateRecipientReport(ctx, recipient, certificates, assignments, contacts, nil, ExportOptions{})
+	require.NoError(t, err)
+	assert.Greater(t, len(pdfBytes), len(withoutMedical))
 }
 
 func TestPDFService_GenerateAuditReport(t *testing.T) {
@@ -108,7 +143,7 @@ func TestPDFService_GenerateAuditReport(t *testing.T) {
 	endDate := time.Date(2024, 1, 31, 23, 59, 59, 0, time.UTC)
 
 	ctx := context.Background()
-	pdfBytes, err := service.GenerateAuditReport(ctx, logs, startDate, endDate)
+	pdfBytes, err := service.GenerateAuditReport(ctx, logs, startDate, endDate, ExportOptions{})
 
 	assert.NoError(t, err)
 	assert.NotEmpty(t, pdfBytes)
@@ -118,6 +153,177 @@ func TestPDFService_GenerateAuditReport(t *testing.T) {
 	assert.Equal(t, "%PDF", string(pdfBytes[:4]), "Should start with PDF header")
 }
 
+func TestPDFService_GenerateEnrollmentReport(t *testing.T) {
+	cipher, err := crypto.NewFieldCipherWithKey(make([]byte, 32))
+	require.NoError(t, err)
+
+	service := NewPDFService("./fonts", cipher)
+
+	admission := time.Date(2023, 4, 1, 0, 0, 0, 0, time.UTC)
+	discharge := time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC)
+	recipients := []domain.Recipient{
+		{
+			ID:            "recipient-001",
+			Name:          "テスト太郎",
+			Kana:          "テストタロウ",
+			Sex:           domain.SexMale,
+			AdmissionDate: &admission,
+		},
+		{
+			ID:            "recipient-002",
+			Name:          "テスト花子",
+			Kana:          "テストハナコ",
+			Sex:           domain.SexFemale,
+			AdmissionDate: &admission,
+			DischargeDate: &discharge,
+		},
+	}
+
+	ctx := context.Background()
+	pdfBytes, err := service.GenerateEnrollmentReport(ctx, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), recipients)
+
+	assert.NoError(t, err)
+	assert.NotEmpty(t, pdfBytes)
+	assert.Equal(t, "%PDF", string(pdfBytes[:4]), "Should start with PDF header")
+}
+
+func TestPDFService_GenerateDisclosureReport(t *testing.T) {
+	cipher, err := crypto.NewFieldCipherWithKey(make([]byte, 32))
+	require.NoError(t, err)
+
+	service := NewPDFService("./fonts", cipher)
+
+	admission := time.Date(2023, 4, 1, 0, 0, 0, 0, time.UTC)
+	pkg := &domain.DisclosurePackage{
+		Recipient: domain.Recipient{
+			ID:        "recipient-001",
+			Name:      "テスト太郎",
+			Sex:       domain.SexMale,
+			BirthDate: time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		EnrollmentPeriods: []domain.EnrollmentPeriod{
+			{ID: "period-001", RecipientID: "recipient-001", AdmissionDate: admission},
+		},
+		Consents: []domain.Consent{
+			{ID: "consent-001", RecipientID: "recipient-001", ConsentType: "個人情報利用", Content: "同意内容", Method: "書面", ObtainedAt: admission},
+		},
+		Incidents: []domain.IncidentReport{
+			{ID: "incident-001", RecipientIDs: []domain.ID{"recipient-001"}, OccurredAt: admission, Location: "食堂",
+				Category: domain.IncidentCategoryChoking, Severity: domain.IncidentSeverityNearMiss,
+				Description: "昼食時にむせ込みがあった", Status: domain.IncidentStatusApproved},
+		},
+		Merges: []domain.RecipientMerge{
+			{ID: "merge-001", SurvivorID: "recipient-001", Merged: domain.Recipient{ID: "recipient-009", Name: "テスト 太郎"},
+				Reason: "重複登録", MergedAt: admission},
+		},
+		AuditLogs: []domain.AuditLog{
+			{ID: "log-001", ActorID: "staff-001", Action: "CREATE", Target: "recipient:recipient-001", At: admission},
+		},
+		GeneratedAt: time.Now(),
+		GeneratedBy: "admin-001",
+	}
+	jsonData := []byte(`{"recipient":{"id":"recipient-001"}}`)
+
+	ctx := context.Background()
+
+	t.Run("without password", func(t *testing.T) {
+		pdfBytes, err := service.GenerateDisclosureReport(ctx, pkg, jsonData, "")
+		require.NoError(t, err)
+		assert.Equal(t, "%PDF", string(pdfBytes[:4]))
+		assert.Contains(t, string(pdfBytes), "/EmbeddedFiles", "JSON should be attached")
+		assert.NotContains(t, string(pdfBytes), "/Encrypt")
+	})
+
+	t.Run("with password", func(t *testing.T) {
+		pdfBytes, err := service.GenerateDisclosureReport(ctx, pkg, jsonData, "secret-pass")
+		require.NoError(t, err)
+		assert.Equal(t, "%PDF", string(pdfBytes[:4]))
+		assert.Contains(t, string(pdfBytes), "/Encrypt", "PDF should be encrypted")
+	})
+}
+
+func TestPDFService_GenerateEmergencyContactSheet(t *testing.T) {
+	cipher, err := crypto.NewFieldCipherWithKey(make([]byte, 32))
+	require.NoError(t, err)
+
+	service := NewPDFService("./fonts", cipher)
+
+	recipient := &domain.Recipient{
+		ID:        "recipient-001",
+		Name:      "テスト太郎",
+		BirthDate: time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	// More contacts than fit on a page must still produce a single page
+	var contacts []domain.EmergencyContact
+	for i := 1; i <= 20; i++ {
+		contacts = append(contacts, domain.EmergencyContact{
+			ID:           domain.ID(fmt.Sprintf("contact-%02d", i)),
+			Priority:     i,
+			Name:         "テスト連絡先",
+			Relationship: "家族",
+			Phone:        "03-1234-5678",
+			Notes:        "平日日中は勤務先へ連絡",
+		})
+	}
+
+	pdfBytes, err := service.GenerateEmergencyContactSheet(context.Background(), recipient, contacts)
+	require.NoError(t, err)
+	assert.Equal(t, "%PDF", string(pdfBytes[:4]))
+	assert.Equal(t, 1, bytes.Count(pdfBytes, []byte("/Type /Page\n")), "sheet must fit on one page")
+}
+
+func TestPDFService_GenerateIncidentReport(t *testing.T) {
+	cipher, err := crypto.NewFieldCipherWithKey(make([]byte, 32))
+	require.NoError(t, err)
+
+	service := NewPDFService("./fonts", cipher)
+
+	reviewer := domain.ID("staff-002")
+	reviewedAt := time.Date(2025, 4, 11, 9, 0, 0, 0, time.UTC)
+	report := &domain.IncidentReport{
+		ID:           "incident-001",
+		OccurredAt:   time.Date(2025, 4, 10, 14, 30, 0, 0, time.UTC),
+		Location:     "作業室",
+		RecipientIDs: []domain.ID{"recipient-001", "recipient-unknown"},
+		StaffIDs:     []domain.ID{"staff-001"},
+		Category:     domain.IncidentCategoryFall,
+		Severity:     domain.IncidentSeverityModerate,
+		Description:  "椅子から立ち上がる際に転倒した",
+		Response:     "看護師が確認し受診した",
+		Prevention:   "立ち上がり時の見守りを徹底する",
+		Status:       domain.IncidentStatusReviewed,
+		ReportedBy:   "staff-001",
+		ReviewedBy:   &reviewer,
+		ReviewedAt:   &reviewedAt,
+	}
+	recipientMap := map[domain.ID]*domain.Recipient{
+		"recipient-001": {ID: "recipient-001", Name: "テスト太郎", Sex: domain.SexMale, BirthDate: time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	staffMap := map[domain.ID]*domain.Staff{
+		"staff-001": {ID: "staff-001", Name: "報告職員"},
+		"staff-002": {ID: "staff-002", Name: "確認職員"},
+	}
+
+	pdfBytes, err := service.GenerateIncidentReport(context.Background(), report, recipientMap, staffMap)
+	require.NoError(t, err)
+	assert.Equal(t, "%PDF", string(pdfBytes[:4]))
+
+	stats := &domain.IncidentStatistics{
+		Year:       2025,
+		Month:      time.April,
+		Total:      3,
+		NearMisses: 1,
+		Accidents:  2,
+		ByCategory: map[domain.IncidentCategory]int{domain.IncidentCategoryFall: 2, domain.IncidentCategoryMedication: 1},
+		BySeverity: map[domain.IncidentSeverity]int{domain.IncidentSeverityNearMiss: 1, domain.IncidentSeverityModerate: 2},
+	}
+
+	pdfBytes, err = service.GenerateIncidentStatisticsReport(context.Background(), stats)
+	require.NoError(t, err)
+	assert.Equal(t, "%PDF", string(pdfBytes[:4]))
+}
+
 func TestPDFService_FormatSex(t *testing.T) {
 	cipher, err := crypto.NewFieldCipherWithKey(make([]byte, 32))
 	require.NoError(t, err)
@@ -176,7 +382,7 @@ func BenchmarkPDFService_GenerateRecipientReport(b *testing.B) {
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		_, err := service.GenerateRecipientReport(ctx, recipient, nil, nil)
+		_, err := service.GenerateRecipientReport(ctx, recipient, nil, nil, nil, nil, ExportOptions{})
 		if err != nil {
 			b.Fatal(err)
 		}
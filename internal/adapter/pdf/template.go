@@ -0,0 +1,488 @@
+package pdf
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"text/template"
+
+	"gopkg.in/yaml.v3"
+	"shien-system/internal/wareki"
+)
+
+// builtinTemplates holds the report templates shipped with the application.
+// Templates in the configured template directory override them by name.
+//
+//go:embed templates/*.yaml
+var builtinTemplates embed.FS
+
+// Report kinds. Each kind has a built-in template of the same name and
+// passes its own data to the template, see the Generate* methods.
+const (
+	ReportRecipient          = "recipient"
+	ReportAuditLog           = "audit_log"
+	ReportStaffList          = "staff_list"
+	ReportCertificateList    = "certificate_list"
+	ReportEnrollmentRoster   = "enrollment_roster"
+	ReportIncidentStatistics = "incident_statistics"
+	ReportDisclosure         = "disclosure"
+	ReportEmergencyContacts  = "emergency_contacts"
+	ReportIncident           = "incident_report"
+)
+
+var reportKinds = []string{
+	ReportRecipient,
+	ReportAuditLog,
+	ReportStaffList,
+	ReportCertificateList,
+	ReportEnrollmentRoster,
+	ReportIncidentStatistics,
+	ReportDisclosure,
+	ReportEmergencyContacts,
+	ReportIncident,
+}
+
+// ReportTemplate is a declarative report layout loaded from YAML or JSON.
+// Text values are Go text/template strings evaluated against the report data.
+type ReportTemplate struct {
+	Name   string     `yaml:"name"`
+	Kind   string     `yaml:"kind"`
+	Title  string     `yaml:"title"`
+	Page   PageLayout `yaml:"page"`
+	Header PageBand   `yaml:"header"`
+	Footer PageBand   `yaml:"footer"`
+	Blocks []Block    `yaml:"blocks"`
+	// DateStyle is how jdate and jdate_short print dates: gregorian or
+	// wareki. Empty uses the configured style of the report kind.
+	DateStyle string `yaml:"date_style"`
+
+	source    string
+	compiled  map[string]*template.Template
+	dateStyle wareki.Style // Effective style, fixed before the template is published
+}
+
+// PageLayout sets the paper, margins and base font size in mm and points
+type PageLayout struct {
+	Size        string  `yaml:"size"`        // A3, A4, A5, B5, Letter (default A4)
+	Orientation string  `yaml:"orientation"` // portrait or landscape (default portrait)
+	Margin      float64 `yaml:"margin"`      // all sides, default 15
+	FontSize    float64 `yaml:"font_size"`   // default 10
+}
+
+// PageBand is a page header or footer. The templates additionally see
+// .Page, .Pages, .Title and the report data as .Data.
+type PageBand struct {
+	Left     string  `yaml:"left"`
+	Center   string  `yaml:"center"`
+	Right    string  `yaml:"right"`
+	FontSize float64 `yaml:"font_size"`
+}
+
+// Block is one element of the report body
+type Block struct {
+	Type string `yaml:"type"` // heading, text, keyvalue, table, list, banner, spacer, page_break
+	// If is a template pipeline such as ".Certificates"; the block is skipped
+	// when it evaluates to an empty value
+	If       string  `yaml:"if"`
+	Text     string  `yaml:"text"`
+	FontSize float64 `yaml:"font_size"`
+	Bold     bool    `yaml:"bold"`
+	Align    string  `yaml:"align"`  // left, center, right
+	Height   float64 `yaml:"height"` // spacer height in mm
+
+	// keyvalue
+	LabelWidth float64    `yaml:"label_width"`
+	Items      []KeyValue `yaml:"items"`
+
+	// table and list: Source is a field path such as ".Recipient.Contacts";
+	// column values and list text are evaluated against each element
+	Source  string   `yaml:"source"`
+	Columns []Column `yaml:"columns"`
+	Empty   string   `yaml:"empty"` // printed instead of an empty table or list
+
+	// Fit stops a table at the end of the current page instead of breaking
+	// it; Overflow is printed instead of the rows left out, counted by .Rest
+	Fit      bool   `yaml:"fit"`
+	Overflow string `yaml:"overflow"`
+
+	// banner colors as #RRGGBB
+	Fill  string `yaml:"fill"`
+	Color string `yaml:"color"`
+}
+
+// KeyValue is a labelled row of a keyvalue block
+type KeyValue struct {
+	Label     string `yaml:"label"`
+	Value     string `yaml:"value"`
+	OmitEmpty bool   `yaml:"omit_empty"`
+}
+
+// Column is a table column. A zero width shares the remaining page width.
+type Column struct {
+	Header string  `yaml:"header"`
+	Width  float64 `yaml:"width"`
+	Value  string  `yaml:"value"`
+	Align  string  `yaml:"align"`
+}
+
+var blockTypes = map[string]bool{
+	"heading": true, "text": true, "keyvalue": true, "table": true,
+	"list": true, "banner": true, "spacer": true, "page_break": true,
+}
+
+// ParseTemplate parses and validates a report template. JSON is accepted as
+// it is a subset of YAML.
+func ParseTemplate(data []byte, source string) (*ReportTemplate, error) {
+	var tmpl ReportTemplate
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&tmpl); err != nil {
+		return nil, fmt.Errorf("%s: %w", source, err)
+	}
+	tmpl.source = source
+	if err := tmpl.compile(); err != nil {
+		return nil, fmt.Errorf("%s: %w", source, err)
+	}
+	return &tmpl, nil
+}
+
+// compile validates the template and parses every text template it contains
+func (t *ReportTemplate) compile() error {
+	if t.Name == "" {
+		return errors.New("name is required")
+	}
+	if !isReportKind(t.Kind) {
+		return fmt.Errorf("unknown kind %q (one of %s)", t.Kind, strings.Join(reportKinds, ", "))
+	}
+	if _, _, err := t.Page.paper(); err != nil {
+		return err
+	}
+	style, err := wareki.ParseStyle(t.DateStyle)
+	if err != nil {
+		return err
+	}
+	t.dateStyle = style
+
+	// jdate reads the effective style when the report is rendered
+	funcs := template.FuncMap{
+		"jdate":       func(value interface{}) string { return formatStyledDate(t.dateStyle.FormatLong, value) },
+		"jdate_short": func(value interface{}) string { return formatStyledDate(t.dateStyle.FormatShort, value) },
+	}
+
+	t.compiled = map[string]*template.Template{}
+	add := func(where, text string) error {
+		if text == "" {
+			return nil
+		}
+		if _, ok := t.compiled[text]; ok {
+			return nil
+		}
+		parsed, err := template.New(where).Funcs(templateFuncs).Funcs(funcs).Option("missingkey=zero").Parse(text)
+		if err != nil {
+			return fmt.Errorf("%s: %w", where, err)
+		}
+		t.compiled[text] = parsed
+		return nil
+	}
+
+	texts := map[string]string{
+		"title": t.Title, "header.left": t.Header.Left, "header.center": t.Header.Center, "header.right": t.Header.Right,
+		"footer.left": t.Footer.Left, "footer.center": t.Footer.Center, "footer.right": t.Footer.Right,
+	}
+	for where, text := range texts {
+		if err := add(where, text); err != nil {
+			return err
+		}
+	}
+
+	for i, block := range t.Blocks {
+		where := fmt.Sprintf("blocks[%d]", i)
+		if !blockTypes[block.Type] {
+			return fmt.Errorf("%s: unknown type %q", where, block.Type)
+		}
+		if block.If != "" {
+			if err := add(where+".if", conditionTemplate(block.If)); err != nil {
+				return err
+			}
+		}
+		if err := add(where+".text", block.Text); err != nil {
+			return err
+		}
+		if err := add(where+".empty", block.Empty); err != nil {
+			return err
+		}
+		if err := add(where+".overflow", block.Overflow); err != nil {
+			return err
+		}
+		for j, item := range block.Items {
+			if err := add(fmt.Sprintf("%s.items[%d]", where, j), item.Value); err != nil {
+				return err
+			}
+		}
+		for j, column := range block.Columns {
+			if err := add(fmt.Sprintf("%s.columns[%d]", where, j), column.Value); err != nil {
+				return err
+			}
+		}
+
+		switch block.Type {
+		case "table":
+			if len(block.Columns) == 0 {
+				return fmt.Errorf("%s: a table needs columns", where)
+			}
+			fallthrough
+		case "list":
+			if !strings.HasPrefix(block.Source, ".") {
+				return fmt.Errorf("%s: source must be a field path such as .Items", where)
+			}
+		case "banner":
+			for _, color := range []string{block.Fill, block.Color} {
+				if _, err := parseColor(color); err != nil {
+					return fmt.Errorf("%s: %w", where, err)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// conditionTemplate turns an if pipeline into a template printing 1 when it holds
+func conditionTemplate(pipeline string) string {
+	return "{{if " + pipeline + "}}1{{end}}"
+}
+
+func isReportKind(kind string) bool {
+	for _, k := range reportKinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// paper returns the fpdf orientation and size of the layout
+func (l PageLayout) paper() (string, string, error) {
+	orientation := "P"
+	switch strings.ToLower(l.Orientation) {
+	case "", "portrait":
+	case "landscape":
+		orientation = "L"
+	default:
+		return "", "", fmt.Errorf("unknown page orientation %q", l.Orientation)
+	}
+
+	size := l.Size
+	if size == "" {
+		size = "A4"
+	}
+	switch strings.ToUpper(size) {
+	case "A3", "A4", "A5", "B5":
+		size = strings.ToUpper(size)
+	case "LETTER":
+		size = "Letter"
+	default:
+		return "", "", fmt.Errorf("unknown page size %q", l.Size)
+	}
+	return orientation, size, nil
+}
+
+// loadBuiltinTemplates parses the templates embedded in the binary
+func loadBuiltinTemplates() (map[string]*ReportTemplate, error) {
+	templates := map[string]*ReportTemplate{}
+	entries, err := fs.ReadDir(builtinTemplates, "templates")
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		data, err := fs.ReadFile(builtinTemplates, "templates/"+entry.Name())
+		if err != nil {
+			return nil, err
+		}
+		tmpl, err := ParseTemplate(data, entry.Name())
+		if err != nil {
+			return nil, err
+		}
+		templates[tmpl.Name] = tmpl
+	}
+	for _, kind := range reportKinds {
+		if tmpl, ok := templates[kind]; !ok || tmpl.Kind != kind {
+			return nil, fmt.Errorf("built-in template %q is missing", kind)
+		}
+	}
+	return templates, nil
+}
+
+// loadTemplateDir parses every *.yaml, *.yml and *.json file in dir. A
+// missing directory is not an error. Files that fail to parse are reported
+// together while the valid ones are still returned.
+func loadTemplateDir(dir string) (map[string]*ReportTemplate, error) {
+	templates := map[string]*ReportTemplate{}
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return templates, nil
+	}
+	if err != nil {
+		return templates, fmt.Errorf("failed to read template directory: %w", err)
+	}
+
+	var errs []error
+	for _, entry := range entries {
+		switch strings.ToLower(filepath.Ext(entry.Name())) {
+		case ".yaml", ".yml", ".json":
+		default:
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		data, err := os.ReadFile(path)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		tmpl, err := ParseTemplate(data, path)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if other, ok := templates[tmpl.Name]; ok {
+			errs = append(errs, fmt.Errorf("%s: template %q is also defined in %s", path, tmpl.Name, other.source))
+			continue
+		}
+		templates[tmpl.Name] = tmpl
+	}
+	return templates, errors.Join(errs...)
+}
+
+// TemplateNames returns the names of the loaded templates for a report kind
+func (p *PDFService) TemplateNames(kind string) []string {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	var names []string
+	for name, tmpl := range p.templates {
+		if tmpl.Kind == kind {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// LoadTemplates adds the templates in dir to the built-in ones, replacing
+// built-ins of the same name, and selects which template each report kind
+// uses (kind -> template name). dateStyles sets the date style of each
+// report kind (kind -> gregorian or wareki) for templates that do not set
+// date_style themselves. Invalid files, selections and styles are reported
+// in the returned error; everything valid is still applied.
+func (p *PDFService) LoadTemplates(dir string, selections, dateStyles map[string]string) error {
+	templates, err := loadBuiltinTemplates()
+	if err != nil {
+		return fmt.Errorf("failed to load built-in report templates: %w", err)
+	}
+
+	var errs []error
+	if dir != "" {
+		custom, err := loadTemplateDir(dir)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		for name, tmpl := range custom {
+			if builtin, ok := templates[name]; ok && builtin.Kind != tmpl.Kind {
+				errs = append(errs, fmt.Errorf("%s: template %q must have kind %q", tmpl.source, name, builtin.Kind))
+				continue
+			}
+			templates[name] = tmpl
+		}
+	}
+
+	selected := map[string]string{}
+	for kind, name := range selections {
+		tmpl, ok := templates[name]
+		switch {
+		case !isReportKind(kind):
+			errs = append(errs, fmt.Errorf("unknown report kind %q", kind))
+		case !ok:
+			errs = append(errs, fmt.Errorf("report template %q for %s not found", name, kind))
+		case tmpl.Kind != kind:
+			errs = append(errs, fmt.Errorf("report template %q is for %s, not %s", name, tmpl.Kind, kind))
+		default:
+			selected[kind] = name
+		}
+	}
+
+	for kind, value := range dateStyles {
+		style, err := wareki.ParseStyle(value)
+		switch {
+		case !isReportKind(kind):
+			errs = append(errs, fmt.Errorf("unknown report kind %q", kind))
+		case err != nil:
+			errs = append(errs, fmt.Errorf("date style for %s: %w", kind, err))
+		default:
+			for _, tmpl := range templates {
+				if tmpl.Kind == kind && tmpl.DateStyle == "" {
+					tmpl.dateStyle = style
+				}
+			}
+		}
+	}
+
+	p.mu.Lock()
+	p.templates = templates
+	p.selected = selected
+	p.mu.Unlock()
+	return errors.Join(errs...)
+}
+
+// templateFor returns the template selected for a report kind
+func (p *PDFService) templateFor(kind string) (*ReportTemplate, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if p.templates == nil {
+		return nil, fmt.Errorf("report templates are not loaded")
+	}
+	name := kind
+	if selected, ok := p.selected[kind]; ok {
+		name = selected
+	}
+	tmpl, ok := p.templates[name]
+	if !ok {
+		return nil, fmt.Errorf("report template %q not found", name)
+	}
+	return tmpl, nil
+}
+
+// resolvePath follows a field path such as ".Medical.Medications" through
+// structs, pointers and string-keyed maps. A nil pointer on the way yields nil.
+func resolvePath(data interface{}, path string) (interface{}, error) {
+	value := reflect.ValueOf(data)
+	for _, field := range strings.Split(strings.TrimPrefix(path, "."), ".") {
+		for value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface {
+			if value.IsNil() {
+				return nil, nil
+			}
+			value = value.Elem()
+		}
+		switch value.Kind() {
+		case reflect.Map:
+			value = value.MapIndex(reflect.ValueOf(field))
+			if !value.IsValid() {
+				return nil, nil
+			}
+		case reflect.Struct:
+			value = value.FieldByName(field)
+			if !value.IsValid() {
+				return nil, fmt.Errorf("%s: no field %s", path, field)
+			}
+		default:
+			return nil, fmt.Errorf("%s: cannot read %s of %s", path, field, value.Kind())
+		}
+	}
+	return value.Interface(), nil
+}
@@ -0,0 +1,185 @@
+package pdf
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-pdf/fpdf"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"shien-system/internal/domain"
+)
+
+func TestLoadBuiltinTemplates(t *testing.T) {
+	templates, err := loadBuiltinTemplates()
+	require.NoError(t, err)
+
+	for _, kind := range reportKinds {
+		assert.Contains(t, templates, kind)
+	}
+}
+
+func TestParseTemplate_Invalid(t *testing.T) {
+	testCases := []struct {
+		name     string
+		template string
+		message  string
+	}{
+		{"unknown kind", "name: x\nkind: invoice\n", "unknown kind"},
+		{"unknown field", "name: x\nkind: staff_list\ncolour: red\n", "colour"},
+		{"unknown block", "name: x\nkind: staff_list\nblocks:\n  - type: chart\n", "unknown type"},
+		{"bad template", "name: x\nkind: staff_list\nblocks:\n  - type: text\n    text: '{{.Name'\n", "blocks[0].text"},
+		{"table without columns", "name: x\nkind: staff_list\nblocks:\n  - type: table\n    source: .Staff\n", "needs columns"},
+		{"bad paper", "name: x\nkind: staff_list\npage:\n  size: B4\n", "page size"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ParseTemplate([]byte(tc.template), "test.yaml")
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.message)
+		})
+	}
+}
+
+func TestPDFService_LoadTemplates(t *testing.T) {
+	dir := t.TempDir()
+	custom := `{"name": "staff_simple", "kind": "staff_list", "title": "職員名簿",
+		"blocks": [{"type": "list", "source": ".Staff", "text": "{{.Name}}"}]}`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "staff_simple.json"), []byte(custom), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "broken.yaml"), []byte("name: broken\nkind: nope\n"), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("ignored"), 0600))
+
+	service := NewPDFService("./fonts", nil)
+	err := service.LoadTemplates(dir, map[string]string{
+		ReportStaffList: "staff_simple",
+		ReportAuditLog:  "staff_simple",
+	}, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "broken.yaml")
+	assert.Contains(t, err.Error(), `"staff_simple" is for staff_list, not audit_log`)
+
+	// The valid template is loaded and selected despite the errors
+	assert.Equal(t, []string{"staff_list", "staff_simple"}, service.TemplateNames(ReportStaffList))
+	tmpl, err := service.templateFor(ReportStaffList)
+	require.NoError(t, err)
+	assert.Equal(t, "staff_simple", tmpl.Name)
+	tmpl, err = service.templateFor(ReportAuditLog)
+	require.NoError(t, err)
+	assert.Equal(t, "audit_log", tmpl.Name)
+
+	pdfBytes, err := service.GenerateStaffReport(context.Background(), []domain.Staff{{Name: "山田", Role: domain.RoleStaff}})
+	require.NoError(t, err)
+	assert.Equal(t, "%PDF", string(pdfBytes[:4]))
+}
+
+func TestPDFService_LoadTemplates_OverrideMustKeepKind(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "recipient.yaml"), []byte("name: recipient\nkind: staff_list\n"), 0600))
+
+	service := NewPDFService("./fonts", nil)
+	err := service.LoadTemplates(dir, nil, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `must have kind "recipient"`)
+
+	tmpl, err := service.templateFor(ReportRecipient)
+	require.NoError(t, err)
+	assert.Equal(t, ReportRecipient, tmpl.Kind)
+}
+
+func TestPDFService_LoadTemplates_DateStyles(t *testing.T) {
+	dir := t.TempDir()
+	title := "title: '{{jdate .Date}} {{jdate_short .Date}}'\n"
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "roster_plain.yaml"),
+		[]byte("name: roster_plain\nkind: enrollment_roster\n"+title), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "roster_gregorian.yaml"),
+		[]byte("name: roster_gregorian\nkind: enrollment_roster\ndate_style: gregorian\n"+title), 0600))
+
+	service := NewPDFService("./fonts", nil)
+	err := service.LoadTemplates(dir, nil, map[string]string{
+		ReportEnrollmentRoster: "wareki",
+		ReportAuditLog:         "heisei",
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "date style for audit_log")
+
+	data := map[string]interface{}{"Date": time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)}
+	titleOf := func(name string) string {
+		tmpl := service.templates[name]
+		var buf bytes.Buffer
+		require.NoError(t, tmpl.compiled[tmpl.Title].Execute(&buf, data))
+		return buf.String()
+	}
+	// The configured style applies unless the template sets its own
+	assert.Equal(t, "令和7年4月1日 R7.4.1", titleOf("roster_plain"))
+	assert.Equal(t, "2025年04月01日 2025/04/01", titleOf("roster_gregorian"))
+
+	_, err = ParseTemplate([]byte("name: x\nkind: staff_list\ndate_style: showa\n"), "x.yaml")
+	assert.Error(t, err)
+}
+
+func TestRender_TableHeaderRepeatsOnEveryPage(t *testing.T) {
+	service := NewPDFService("./fonts", nil)
+	tmpl, err := ParseTemplate([]byte(`
+name: test
+kind: staff_list
+footer:
+  right: "{{.Page}} / {{.Pages}}"
+blocks:
+  - type: table
+    source: .Staff
+    columns:
+      - {header: Name, value: "{{.Name}}"}
+      - {header: Role, width: 40, value: "{{.Role}}"}
+`), "test.yaml")
+	require.NoError(t, err)
+
+	staff := make([]domain.Staff, 120)
+	for i := range staff {
+		staff[i] = domain.Staff{Name: "staff", Role: domain.RoleStaff}
+	}
+	pdfBytes, err := service.render(tmpl, map[string]interface{}{"Staff": staff}, ExportOptions{}, func(pdf *fpdf.Fpdf) {
+		pdf.SetCompression(false)
+	})
+	require.NoError(t, err)
+
+	pages := strings.Split(string(pdfBytes), "/Type /Page\n")[1:]
+	require.Greater(t, len(pages), 1)
+	for i, page := range pages {
+		// The header row is the only filled row: one filled rectangle per column
+		assert.Equal(t, 2, strings.Count(page, "re B"), "page %d", i+1)
+		assert.Contains(t, page, "/ "+strconv.Itoa(len(pages))+")", "page %d", i+1)
+	}
+}
+
+func TestWrapText(t *testing.T) {
+	// Every character is one unit wide
+	measure := func(s string) float64 { return float64(len([]rune(s))) }
+
+	testCases := []struct {
+		name  string
+		text  string
+		width float64
+		want  []string
+	}{
+		{"fits", "利用者情報", 10, []string{"利用者情報"}},
+		{"breaks anywhere", "あいうえおかきくけこ", 4, []string{"あいうえ", "おかきく", "けこ"}},
+		{"no closing punctuation at line start", "あいうえ。かき", 4, []string{"あいう", "え。かき"}},
+		{"no opening bracket at line end", "あいう「えお」", 4, []string{"あいう", "「えお」"}},
+		{"keeps ASCII words", "abc defgh", 6, []string{"abc", "defgh"}},
+		{"splits long words", "abcdefgh", 3, []string{"abc", "def", "gh"}},
+		{"keeps newlines", "あい\n\nう", 4, []string{"あい", "", "う"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, wrapText(tc.text, tc.width, measure))
+		})
+	}
+}
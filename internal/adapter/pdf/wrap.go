@@ -0,0 +1,113 @@
+package pdf
+
+import (
+	"strings"
+	"unicode"
+)
+
+// noLineStart lists characters that must not start a line (行頭禁則)
+const noLineStart = "、。，．・：；？！ー～…‥）」』】〕〉》］｝ぁぃぅぇぉっゃゅょゎァィゥェォッャュョヮヵヶ々ゝゞヽヾ,.:;?!)]}%"
+
+// noLineEnd lists characters that must not end a line (行末禁則)
+const noLineEnd = "（「『【〔〈《［｛([{"
+
+// wrapText splits text into lines no wider than width as reported by
+// measure. Japanese text may break between any two characters while runs of
+// ASCII letters and digits are kept together when they fit on a line.
+// Closing punctuation is kept off the start of a line and opening brackets
+// off its end by carrying the previous character over (追い出し).
+func wrapText(text string, width float64, measure func(string) float64) []string {
+	var lines []string
+	for _, paragraph := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		lines = append(lines, wrapParagraph(paragraph, width, measure)...)
+	}
+	return lines
+}
+
+func wrapParagraph(paragraph string, width float64, measure func(string) float64) []string {
+	tokens := splitTokens(paragraph, width, measure)
+	if len(tokens) == 0 {
+		return []string{""}
+	}
+
+	var lines []string
+	var line []string
+	for _, token := range tokens {
+		if len(line) == 0 && token == " " {
+			continue
+		}
+		if len(line) == 0 || measure(strings.Join(line, "")+token) <= width {
+			line = append(line, token)
+			continue
+		}
+
+		// Carry tokens over so the new line does not start with closing
+		// punctuation and the finished one does not end with an opening bracket
+		carry := 0
+		if startsWithAny(token, noLineStart) && len(line) > 1 {
+			carry = 1
+			for carry < len(line)-1 && startsWithAny(line[len(line)-carry], noLineStart) {
+				carry++
+			}
+		}
+		for carry < len(line)-1 && endsWithAny(line[len(line)-1-carry], noLineEnd) {
+			carry++
+		}
+
+		next := append([]string{}, line[len(line)-carry:]...)
+		lines = append(lines, strings.TrimRight(strings.Join(line[:len(line)-carry], ""), " "))
+		line = append(next, token)
+		if token == " " && len(next) == 0 {
+			line = nil
+		}
+	}
+	lines = append(lines, strings.TrimRight(strings.Join(line, ""), " "))
+	return lines
+}
+
+// splitTokens splits a paragraph into break units: single characters, single
+// spaces and ASCII words. Words wider than width are split into characters.
+func splitTokens(paragraph string, width float64, measure func(string) float64) []string {
+	var tokens []string
+	var word []rune
+	flush := func() {
+		if len(word) == 0 {
+			return
+		}
+		if measure(string(word)) <= width {
+			tokens = append(tokens, string(word))
+		} else {
+			for _, r := range word {
+				tokens = append(tokens, string(r))
+			}
+		}
+		word = word[:0]
+	}
+
+	for _, r := range paragraph {
+		switch {
+		case r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r) || strings.ContainsRune("-_@/'\"&+#", r)):
+			word = append(word, r)
+		case unicode.IsSpace(r):
+			flush()
+			tokens = append(tokens, " ")
+		default:
+			flush()
+			tokens = append(tokens, string(r))
+		}
+	}
+	flush()
+	return tokens
+}
+
+func startsWithAny(token, chars string) bool {
+	for _, r := range token {
+		return strings.ContainsRune(chars, r)
+	}
+	return false
+}
+
+func endsWithAny(token, chars string) bool {
+	runes := []rune(token)
+	return len(runes) > 0 && strings.ContainsRune(chars, runes[len(runes)-1])
+}
@@ -0,0 +1,145 @@
+// Package postal reads Japan Post's postal code dictionary (KEN_ALL.CSV) into
+// domain.PostalAddress entries for the offline postal code lookup.
+package postal
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding/japanese"
+
+	"shien-system/internal/domain"
+	"shien-system/internal/validation"
+)
+
+// kenAllFields is the number of columns in KEN_ALL.CSV
+const kenAllFields = 15
+
+// KEN_ALL.CSV columns used by the parser
+const (
+	colCode           = 2
+	colPrefectureKana = 3
+	colCityKana       = 4
+	colTownKana       = 5
+	colPrefecture     = 6
+	colCity           = 7
+	colTown           = 8
+)
+
+// KenAllParser reads KEN_ALL.CSV as downloaded from Japan Post. Both the
+// Shift_JIS original and the UTF-8 edition are accepted.
+type KenAllParser struct{}
+
+// NewKenAllParser creates a new KEN_ALL.CSV parser
+func NewKenAllParser() *KenAllParser {
+	return &KenAllParser{}
+}
+
+// Parse returns one entry per postal code and town. Half-width kana and
+// full-width digits are normalized like user input, notes in parentheses are
+// dropped and towns that Japan Post splits over several lines are joined.
+func (p *KenAllParser) Parse(r io.Reader) ([]*domain.PostalAddress, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read postal code file: %w", err)
+	}
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+	if !utf8.Valid(data) {
+		if data, err = japanese.ShiftJIS.NewDecoder().Bytes(data); err != nil {
+			return nil, fmt.Errorf("failed to decode Shift_JIS: %w", err)
+		}
+	}
+
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = kenAllFields
+
+	var addresses []*domain.PostalAddress
+	seen := make(map[string]bool)
+	var pending *domain.PostalAddress
+
+	add := func(address *domain.PostalAddress) {
+		cleanTown(address)
+		key := address.Code + "\x00" + address.Town
+		if !seen[key] {
+			seen[key] = true
+			addresses = append(addresses, address)
+		}
+	}
+
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid postal code file: %w", err)
+		}
+
+		address := &domain.PostalAddress{
+			Code:           strings.TrimSpace(record[colCode]),
+			Prefecture:     validation.NormalizeText(record[colPrefecture]),
+			City:           validation.NormalizeText(record[colCity]),
+			Town:           validation.NormalizeText(record[colTown]),
+			PrefectureKana: validation.NormalizeKana(record[colPrefectureKana]),
+			CityKana:       validation.NormalizeKana(record[colCityKana]),
+			TownKana:       validation.NormalizeKana(record[colTownKana]),
+		}
+		if len(address.Code) != 7 || strings.Trim(address.Code, "0123456789") != "" {
+			return nil, fmt.Errorf("line %d: invalid postal code %q", line, address.Code)
+		}
+
+		// A town name with an unclosed parenthesis continues on the next lines
+		if pending != nil {
+			if pending.Code == address.Code {
+				pending.Town += address.Town
+				pending.TownKana += address.TownKana
+				if strings.Contains(pending.Town, ")") {
+					add(pending)
+					pending = nil
+				}
+				continue
+			}
+			add(pending)
+			pending = nil
+		}
+		if strings.Contains(address.Town, "(") && !strings.Contains(address.Town, ")") {
+			pending = address
+			continue
+		}
+		add(address)
+	}
+	if pending != nil {
+		add(pending)
+	}
+
+	if len(addresses) == 0 {
+		return nil, fmt.Errorf("postal code file has no entries")
+	}
+	return addresses, nil
+}
+
+// cleanTown removes what KEN_ALL.CSV puts in the town column that is not
+// part of an address: placeholders for codes covering a whole city and
+// notes in parentheses such as 大通西(1～19丁目)
+func cleanTown(address *domain.PostalAddress) {
+	town := address.Town
+	if town == "以下に掲載がない場合" ||
+		strings.HasSuffix(town, "の次に番地がくる場合") ||
+		(strings.HasSuffix(town, "一円") && town != "一円") {
+		address.Town = ""
+		address.TownKana = ""
+		return
+	}
+
+	if i := strings.Index(town, "("); i >= 0 {
+		address.Town = town[:i]
+	}
+	if i := strings.Index(address.TownKana, "("); i >= 0 {
+		address.TownKana = address.TownKana[:i]
+	}
+}
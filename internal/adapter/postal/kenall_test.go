@@ -0,0 +1,75 @@
+package postal
+
+import (
+	"strings"
+	"testing"
+
+	"golang.org/x/text/encoding/japanese"
+)
+
+// kenAllSample follows the KEN_ALL.CSV layout, including a town split over
+// two lines and the placeholder for codes covering a whole city
+const kenAllSample = `01101,"060  ","0600000","ﾎｯｶｲﾄﾞｳ","ｻｯﾎﾟﾛｼﾁｭｳｵｳｸ","ｲｶﾆｹｲｻｲｶﾞﾅｲﾊﾞｱｲ","北海道","札幌市中央区","以下に掲載がない場合",0,0,0,0,0,0
+01101,"060  ","0600042","ﾎｯｶｲﾄﾞｳ","ｻｯﾎﾟﾛｼﾁｭｳｵｳｸ","ｵｵﾄﾞｵﾘﾆｼ(1-19ﾁｮｳﾒ)","北海道","札幌市中央区","大通西（１～１９丁目）",1,0,1,0,0,0
+01101,"064  ","0640820","ﾎｯｶｲﾄﾞｳ","ｻｯﾎﾟﾛｼﾁｭｳｵｳｸ","ｵｵﾄﾞｵﾘﾆｼ(20-28ﾁｮｳﾒ)","北海道","札幌市中央区","大通西（２０～２８丁目）",1,0,1,0,0,0
+13101,"100  ","1000001","ﾄｳｷｮｳﾄ","ﾁﾖﾀﾞｸ","ﾁﾖﾀﾞ","東京都","千代田区","千代田",0,0,0,0,0,0
+01224,"066  ","0660005","ﾎｯｶｲﾄﾞｳ","ﾁﾄｾｼ","ﾗﾝｺｼ(ﾗﾝｺｼ)","北海道","千歳市","蘭越（蘭越、",1,0,0,0,0,0
+01224,"066  ","0660005","ﾎｯｶｲﾄﾞｳ","ﾁﾄｾｼ","ﾗﾝｺｼ(ﾗﾝｺｼ)","北海道","千歳市","新川）",1,0,0,0,0,0
+13308,"19802","1980200","ﾄｳｷｮｳﾄ","ﾆｼﾀﾏｸﾞﾝｵｸﾀﾏﾏﾁ","ｵｸﾀﾏﾏﾁｲﾁｴﾝ","東京都","西多摩郡奥多摩町","奥多摩町一円",0,0,0,0,0,0
+`
+
+func TestKenAllParser_ParseShiftJIS(t *testing.T) {
+	encoded, err := japanese.ShiftJIS.NewEncoder().String(kenAllSample)
+	if err != nil {
+		t.Fatalf("failed to encode sample: %v", err)
+	}
+
+	addresses, err := NewKenAllParser().Parse(strings.NewReader(encoded))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	want := []struct{ code, prefecture, city, town, townKana string }{
+		{"0600000", "北海道", "札幌市中央区", "", ""},
+		{"0600042", "北海道", "札幌市中央区", "大通西", "オオドオリニシ"},
+		{"0640820", "北海道", "札幌市中央区", "大通西", "オオドオリニシ"},
+		{"1000001", "東京都", "千代田区", "千代田", "チヨダ"},
+		{"0660005", "北海道", "千歳市", "蘭越", "ランコシ"},
+		{"1980200", "東京都", "西多摩郡奥多摩町", "", ""},
+	}
+	if len(addresses) != len(want) {
+		t.Fatalf("Parse() returned %d entries, want %d: %+v", len(addresses), len(want), addresses)
+	}
+	for i, w := range want {
+		got := addresses[i]
+		if got.Code != w.code || got.Prefecture != w.prefecture || got.City != w.city || got.Town != w.town || got.TownKana != w.townKana {
+			t.Errorf("entry %d = %+v, want %+v", i, got, w)
+		}
+	}
+	if addresses[3].PrefectureKana != "トウキョウト" {
+		t.Errorf("PrefectureKana = %q, want full-width katakana", addresses[3].PrefectureKana)
+	}
+}
+
+func TestKenAllParser_ParseUTF8(t *testing.T) {
+	addresses, err := NewKenAllParser().Parse(strings.NewReader("\ufeff" + kenAllSample))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if len(addresses) != 6 {
+		t.Errorf("Parse() returned %d entries, want 6", len(addresses))
+	}
+}
+
+func TestKenAllParser_RejectsMalformedFiles(t *testing.T) {
+	tests := map[string]string{
+		"empty":           "",
+		"wrong columns":   "a,b,c\n",
+		"bad postal code": `13101,"100  ","100-001","ﾄｳｷｮｳﾄ","ﾁﾖﾀﾞｸ","ﾁﾖﾀﾞ","東京都","千代田区","千代田",0,0,0,0,0,0` + "\n",
+	}
+	for name, input := range tests {
+		if _, err := NewKenAllParser().Parse(strings.NewReader(input)); err == nil {
+			t.Errorf("%s: Parse() error = nil, want an error", name)
+		}
+	}
+}
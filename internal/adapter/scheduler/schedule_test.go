@@ -0,0 +1,71 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSchedule_Next(t *testing.T) {
+	// 2025-04-01 is a Tuesday
+	base := time.Date(2025, 4, 1, 10, 7, 30, 0, time.Local)
+
+	tests := []struct {
+		name string
+		spec string
+		want time.Time
+	}{
+		{"every minute", "* * * * *", time.Date(2025, 4, 1, 10, 8, 0, 0, time.Local)},
+		{"step", "*/15 * * * *", time.Date(2025, 4, 1, 10, 15, 0, 0, time.Local)},
+		{"daily later today", "0 18 * * *", time.Date(2025, 4, 1, 18, 0, 0, 0, time.Local)},
+		{"daily tomorrow", "0 3 * * *", time.Date(2025, 4, 2, 3, 0, 0, 0, time.Local)},
+		{"list and range", "30 8-9,20 * * *", time.Date(2025, 4, 1, 20, 30, 0, 0, time.Local)},
+		{"weekday", "0 9 * * 1-5", time.Date(2025, 4, 2, 9, 0, 0, 0, time.Local)},
+		{"sunday as 7", "0 0 * * 7", time.Date(2025, 4, 6, 0, 0, 0, 0, time.Local)},
+		{"day of month or weekday", "0 0 15 * 5", time.Date(2025, 4, 4, 0, 0, 0, 0, time.Local)},
+		{"day of month step and weekday", "0 0 */2 * 1", time.Date(2025, 4, 7, 0, 0, 0, 0, time.Local)},
+		{"day of month and weekday step", "0 0 15 * */2", time.Date(2025, 4, 15, 0, 0, 0, 0, time.Local)},
+		{"month", "0 0 1 6 *", time.Date(2025, 6, 1, 0, 0, 0, 0, time.Local)},
+		{"monthly", "@monthly", time.Date(2025, 5, 1, 0, 0, 0, 0, time.Local)},
+		{"hourly", "@hourly", time.Date(2025, 4, 1, 11, 0, 0, 0, time.Local)},
+		{"every", "@every 90m", base.Add(90 * time.Minute)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule, err := ParseSchedule(tt.spec)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, schedule.Next(base))
+		})
+	}
+}
+
+func TestParseSchedule_Invalid(t *testing.T) {
+	specs := []string{
+		"",
+		"* * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"*/0 * * * *",
+		"5-1 * * * *",
+		"a * * * *",
+		"@every",
+		"@every -5m",
+		"@yearly",
+	}
+
+	for _, spec := range specs {
+		_, err := ParseSchedule(spec)
+		assert.Error(t, err, "spec %q", spec)
+	}
+}
+
+func TestParseSchedule_NeverFires(t *testing.T) {
+	schedule, err := ParseSchedule("0 0 31 2 *")
+	require.NoError(t, err)
+	assert.True(t, schedule.Next(time.Now()).IsZero())
+}
@@ -0,0 +1,364 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"shien-system/internal/domain"
+)
+
+var (
+	// ErrJobNotFound is returned when a job name is not registered
+	ErrJobNotFound = errors.New("job not found")
+	// ErrJobRunning is returned when a job is triggered while it is still running
+	ErrJobRunning = errors.New("job is already running")
+	// ErrNotStarted is returned when a job is triggered before Start or after Stop
+	ErrNotStarted = errors.New("scheduler is not running")
+	// ErrAlreadyStarted is returned when registering jobs on a running scheduler
+	ErrAlreadyStarted = errors.New("scheduler is already running")
+)
+
+// stateSaveTimeout bounds persisting job state, which also happens during shutdown
+const stateSaveTimeout = 5 * time.Second
+
+// Logger is the logging interface used by the scheduler
+type Logger interface {
+	Info(msg string, fields ...interface{})
+	Error(msg string, fields ...interface{})
+	Warn(msg string, fields ...interface{})
+}
+
+// Job describes a periodic task
+type Job struct {
+	Name        string
+	Description string
+	// Schedule is a cron expression or "@every <duration>", see ParseSchedule
+	Schedule string
+	Run      func(ctx context.Context) error
+	// MaxRetries is the number of additional attempts after a failure
+	MaxRetries int
+	// Backoff is the delay before the first retry; it doubles on each retry
+	Backoff time.Duration
+	// Timeout bounds a single attempt; zero means no timeout
+	Timeout time.Duration
+}
+
+// JobStatus is a snapshot of a registered job
+type JobStatus struct {
+	Name        string
+	Description string
+	Schedule    string
+	Running     bool
+	State       domain.JobState
+}
+
+type entry struct {
+	job      Job
+	schedule Schedule
+	running  bool
+	state    domain.JobState
+}
+
+// Scheduler runs registered jobs in-process on their schedules.
+//
+// Each job runs at most once at a time; triggers that arrive while a job
+// is still running are skipped. Run results and the next activation time
+// are persisted so missed runs are caught up after a restart.
+type Scheduler struct {
+	repo   domain.JobStateRepository
+	logger Logger
+	now    func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]*entry
+	ctx     context.Context
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+}
+
+// New creates a new scheduler
+func New(repo domain.JobStateRepository, logger Logger) *Scheduler {
+	return &Scheduler{
+		repo:    repo,
+		logger:  logger,
+		now:     time.Now,
+		entries: make(map[string]*entry),
+	}
+}
+
+// Register adds a job to the scheduler
+func (s *Scheduler) Register(job Job) error {
+	if job.Name == "" {
+		return fmt.Errorf("job name is required")
+	}
+	if job.Run == nil {
+		return fmt.Errorf("job %s has no run function", job.Name)
+	}
+	if job.MaxRetries < 0 {
+		return fmt.Errorf("job %s: max retries cannot be negative", job.Name)
+	}
+
+	schedule, err := ParseSchedule(job.Schedule)
+	if err != nil {
+		return fmt.Errorf("job %s: %w", job.Name, err)
+	}
+	if schedule.Next(s.now()).IsZero() {
+		return fmt.Errorf("job %s: schedule %q never fires", job.Name, job.Schedule)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.ctx != nil {
+		return ErrAlreadyStarted
+	}
+	if _, exists := s.entries[job.Name]; exists {
+		return fmt.Errorf("job %s is already registered", job.Name)
+	}
+
+	s.entries[job.Name] = &entry{
+		job:      job,
+		schedule: schedule,
+		state:    domain.JobState{Name: job.Name},
+	}
+
+	return nil
+}
+
+// Start loads the persisted job states and starts scheduling
+func (s *Scheduler) Start(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.ctx != nil {
+		return ErrAlreadyStarted
+	}
+
+	now := s.now()
+	s.ctx, s.cancel = context.WithCancel(ctx)
+
+	for _, e := range s.entries {
+		if persisted, err := s.repo.GetByName(ctx, e.job.Name); err == nil {
+			e.state = *persisted
+		} else if err != domain.ErrNotFound {
+			s.logger.Warn("Failed to load job state", "job", e.job.Name, "error", err)
+		}
+
+		// A run that was due while the application was closed is caught up once
+		next := e.schedule.Next(now)
+		if e.state.NextRunAt != nil && e.state.NextRunAt.Before(now) {
+			next = now
+		}
+		e.state.NextRunAt = &next
+		s.saveState(e.state)
+
+		s.wg.Add(1)
+		go s.loop(e)
+	}
+
+	s.logger.Info("Job scheduler started", "jobs", len(s.entries))
+	return nil
+}
+
+// Stop stops scheduling and waits for running jobs to finish or ctx to expire
+func (s *Scheduler) Stop(ctx context.Context) error {
+	s.mu.Lock()
+	if s.ctx == nil {
+		s.mu.Unlock()
+		return nil
+	}
+	s.cancel()
+	s.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		s.logger.Info("Job scheduler stopped")
+		return nil
+	case <-ctx.Done():
+		s.logger.Warn("Job scheduler stop timed out", "error", ctx.Err())
+		return ctx.Err()
+	}
+}
+
+// RunNow triggers a job immediately in the background
+func (s *Scheduler) RunNow(name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, ok := s.entries[name]
+	if !ok {
+		return ErrJobNotFound
+	}
+	if s.ctx == nil || s.ctx.Err() != nil {
+		return ErrNotStarted
+	}
+	if e.running {
+		return ErrJobRunning
+	}
+
+	e.running = true
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		s.execute(s.ctx, e)
+	}()
+
+	return nil
+}
+
+// Status returns a snapshot of all registered jobs ordered by name
+func (s *Scheduler) Status() []JobStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	statuses := make([]JobStatus, 0, len(s.entries))
+	for _, e := range s.entries {
+		statuses = append(statuses, JobStatus{
+			Name:        e.job.Name,
+			Description: e.job.Description,
+			Schedule:    e.job.Schedule,
+			Running:     e.running,
+			State:       e.state,
+		})
+	}
+
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Name < statuses[j].Name
+	})
+
+	return statuses
+}
+
+// loop waits for each activation time of a job and runs it
+func (s *Scheduler) loop(e *entry) {
+	defer s.wg.Done()
+
+	for {
+		s.mu.Lock()
+		next := *e.state.NextRunAt
+		s.mu.Unlock()
+
+		timer := time.NewTimer(next.Sub(s.now()))
+		select {
+		case <-s.ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		s.mu.Lock()
+		if e.running {
+			// Single-flight: a manual run is still in progress, skip this activation
+			following := e.schedule.Next(s.now())
+			e.state.NextRunAt = &following
+			s.mu.Unlock()
+			s.logger.Warn("Skipping job because it is still running", "job", e.job.Name)
+			continue
+		}
+		e.running = true
+		s.mu.Unlock()
+
+		s.execute(s.ctx, e)
+	}
+}
+
+// execute runs a job with retries and records the result. The caller must
+// have marked the entry as running.
+func (s *Scheduler) execute(ctx context.Context, e *entry) {
+	started := s.now()
+	err := s.runWithRetry(ctx, e.job)
+	finished := s.now()
+
+	s.mu.Lock()
+	e.running = false
+	e.state.LastRunAt = &started
+	e.state.LastDuration = finished.Sub(started)
+	if err != nil {
+		e.state.LastError = err.Error()
+		e.state.ConsecutiveFailures++
+	} else {
+		e.state.LastError = ""
+		e.state.ConsecutiveFailures = 0
+		e.state.LastSuccessAt = &finished
+	}
+	next := e.schedule.Next(finished)
+	e.state.NextRunAt = &next
+	state := e.state
+	s.mu.Unlock()
+
+	if err != nil {
+		s.logger.Error("Job failed", "job", e.job.Name, "failures", state.ConsecutiveFailures, "error", err)
+	} else {
+		s.logger.Info("Job completed", "job", e.job.Name, "duration", state.LastDuration)
+	}
+
+	s.saveState(state)
+}
+
+// runWithRetry runs a job, retrying failures with exponential backoff
+func (s *Scheduler) runWithRetry(ctx context.Context, job Job) error {
+	backoff := job.Backoff
+
+	var err error
+	for attempt := 0; attempt <= job.MaxRetries; attempt++ {
+		if attempt > 0 {
+			s.logger.Warn("Retrying job", "job", job.Name, "attempt", attempt, "backoff", backoff, "error", err)
+
+			timer := time.NewTimer(backoff)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return fmt.Errorf("%w (retry cancelled: %v)", err, ctx.Err())
+			case <-timer.C:
+			}
+			backoff *= 2
+		}
+
+		err = runAttempt(ctx, job)
+		if err == nil || ctx.Err() != nil {
+			return err
+		}
+	}
+
+	return err
+}
+
+// runAttempt runs a single attempt, converting panics into errors
+func runAttempt(ctx context.Context, job Job) (err error) {
+	if job.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, job.Timeout)
+		defer cancel()
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("job panicked: %v", r)
+		}
+	}()
+
+	return job.Run(ctx)
+}
+
+// saveState persists a job state, independently of the scheduler context so
+// results of runs interrupted by shutdown are still recorded
+func (s *Scheduler) saveState(state domain.JobState) {
+	state.UpdatedAt = s.now()
+
+	ctx, cancel := context.WithTimeout(context.Background(), stateSaveTimeout)
+	defer cancel()
+
+	if err := s.repo.Save(ctx, &state); err != nil {
+		s.logger.Error("Failed to save job state", "job", state.Name, "error", err)
+	}
+}
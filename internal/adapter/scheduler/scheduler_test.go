@@ -0,0 +1,224 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"shien-system/internal/domain"
+)
+
+// mockJobStateRepository is an in-memory domain.JobStateRepository
+type mockJobStateRepository struct {
+	mu     sync.Mutex
+	states map[string]domain.JobState
+}
+
+func newMockJobStateRepository() *mockJobStateRepository {
+	return &mockJobStateRepository{states: make(map[string]domain.JobState)}
+}
+
+func (m *mockJobStateRepository) GetByName(ctx context.Context, name string) (*domain.JobState, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	state, ok := m.states[name]
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	return &state, nil
+}
+
+func (m *mockJobStateRepository) Save(ctx context.Context, state *domain.JobState) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.states[state.Name] = *state
+	return nil
+}
+
+func (m *mockJobStateRepository) List(ctx context.Context) ([]*domain.JobState, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var states []*domain.JobState
+	for _, state := range m.states {
+		state := state
+		states = append(states, &state)
+	}
+	return states, nil
+}
+
+type nopLogger struct{}
+
+func (nopLogger) Info(msg string, fields ...interface{})  {}
+func (nopLogger) Error(msg string, fields ...interface{}) {}
+func (nopLogger) Warn(msg string, fields ...interface{})  {}
+
+func stopScheduler(t *testing.T, s *Scheduler) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	require.NoError(t, s.Stop(ctx))
+}
+
+func TestScheduler_RunsJobsOnSchedule(t *testing.T) {
+	repo := newMockJobStateRepository()
+	s := New(repo, nopLogger{})
+
+	var runs int32
+	require.NoError(t, s.Register(Job{
+		Name:     "tick",
+		Schedule: "@every 10ms",
+		Run: func(ctx context.Context) error {
+			atomic.AddInt32(&runs, 1)
+			return nil
+		},
+	}))
+
+	require.NoError(t, s.Start(context.Background()))
+	assert.Eventually(t, func() bool { return atomic.LoadInt32(&runs) >= 3 }, time.Second, 5*time.Millisecond)
+	stopScheduler(t, s)
+
+	state, err := repo.GetByName(context.Background(), "tick")
+	require.NoError(t, err)
+	assert.NotNil(t, state.LastRunAt)
+	assert.NotNil(t, state.LastSuccessAt)
+	assert.NotNil(t, state.NextRunAt)
+	assert.Empty(t, state.LastError)
+}
+
+func TestScheduler_RetriesWithBackoff(t *testing.T) {
+	repo := newMockJobStateRepository()
+	s := New(repo, nopLogger{})
+
+	var attempts int32
+	require.NoError(t, s.Register(Job{
+		Name:       "flaky",
+		Schedule:   "@daily",
+		MaxRetries: 2,
+		Backoff:    time.Millisecond,
+		Run: func(ctx context.Context) error {
+			if atomic.AddInt32(&attempts, 1) < 3 {
+				return errors.New("temporary failure")
+			}
+			return nil
+		},
+	}))
+	require.NoError(t, s.Register(Job{
+		Name:       "broken",
+		Schedule:   "@daily",
+		MaxRetries: 1,
+		Backoff:    time.Millisecond,
+		Run: func(ctx context.Context) error {
+			panic("boom")
+		},
+	}))
+
+	require.NoError(t, s.Start(context.Background()))
+	require.NoError(t, s.RunNow("flaky"))
+	require.NoError(t, s.RunNow("broken"))
+	assert.Eventually(t, func() bool {
+		for _, status := range s.Status() {
+			if status.Running || status.State.LastRunAt == nil {
+				return false
+			}
+		}
+		return true
+	}, time.Second, 5*time.Millisecond)
+	stopScheduler(t, s)
+
+	assert.Equal(t, int32(3), atomic.LoadInt32(&attempts))
+
+	statuses := s.Status()
+	require.Len(t, statuses, 2)
+	assert.Equal(t, "broken", statuses[0].Name)
+	assert.Equal(t, 1, statuses[0].State.ConsecutiveFailures)
+	assert.Contains(t, statuses[0].State.LastError, "boom")
+	assert.Nil(t, statuses[0].State.LastSuccessAt)
+	assert.Equal(t, 0, statuses[1].State.ConsecutiveFailures)
+	assert.NotNil(t, statuses[1].State.LastSuccessAt)
+}
+
+func TestScheduler_SingleFlightAndGracefulStop(t *testing.T) {
+	s := New(newMockJobStateRepository(), nopLogger{})
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	var finished int32
+	require.NoError(t, s.Register(Job{
+		Name:     "slow",
+		Schedule: "@daily",
+		Run: func(ctx context.Context) error {
+			close(started)
+			<-release
+			atomic.StoreInt32(&finished, 1)
+			return nil
+		},
+	}))
+
+	assert.ErrorIs(t, s.RunNow("slow"), ErrNotStarted)
+	require.NoError(t, s.Start(context.Background()))
+	assert.ErrorIs(t, s.RunNow("missing"), ErrJobNotFound)
+	assert.ErrorIs(t, s.Register(Job{Name: "late", Schedule: "@daily", Run: func(ctx context.Context) error { return nil }}), ErrAlreadyStarted)
+
+	require.NoError(t, s.RunNow("slow"))
+	<-started
+	assert.ErrorIs(t, s.RunNow("slow"), ErrJobRunning)
+
+	// Stop waits for the running job
+	time.AfterFunc(20*time.Millisecond, func() { close(release) })
+	stopScheduler(t, s)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&finished))
+	assert.ErrorIs(t, s.RunNow("slow"), ErrNotStarted)
+}
+
+func TestScheduler_CatchesUpMissedRun(t *testing.T) {
+	repo := newMockJobStateRepository()
+	missed := time.Now().Add(-time.Hour)
+	require.NoError(t, repo.Save(context.Background(), &domain.JobState{
+		Name:                "nightly",
+		NextRunAt:           &missed,
+		ConsecutiveFailures: 2,
+		LastError:           "previous failure",
+	}))
+
+	s := New(repo, nopLogger{})
+	var runs int32
+	require.NoError(t, s.Register(Job{
+		Name:     "nightly",
+		Schedule: "0 3 * * *",
+		Run: func(ctx context.Context) error {
+			atomic.AddInt32(&runs, 1)
+			return nil
+		},
+	}))
+
+	require.NoError(t, s.Start(context.Background()))
+	assert.Eventually(t, func() bool {
+		state, err := repo.GetByName(context.Background(), "nightly")
+		return err == nil && state.LastSuccessAt != nil
+	}, time.Second, 5*time.Millisecond)
+	stopScheduler(t, s)
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&runs))
+	state, err := repo.GetByName(context.Background(), "nightly")
+	require.NoError(t, err)
+	assert.Equal(t, 0, state.ConsecutiveFailures)
+	assert.True(t, state.NextRunAt.After(time.Now()))
+}
+
+func TestScheduler_RegisterValidation(t *testing.T) {
+	s := New(newMockJobStateRepository(), nopLogger{})
+	run := func(ctx context.Context) error { return nil }
+
+	assert.Error(t, s.Register(Job{Schedule: "@daily", Run: run}))
+	assert.Error(t, s.Register(Job{Name: "a", Schedule: "@daily"}))
+	assert.Error(t, s.Register(Job{Name: "a", Schedule: "bogus", Run: run}))
+	assert.Error(t, s.Register(Job{Name: "a", Schedule: "0 0 30 2 *", Run: run}))
+	assert.Error(t, s.Register(Job{Name: "a", Schedule: "@daily", Run: run, MaxRetries: -1}))
+	require.NoError(t, s.Register(Job{Name: "a", Schedule: "@daily", Run: run}))
+	assert.Error(t, s.Register(Job{Name: "a", Schedule: "@hourly", Run: run}))
+}
@@ -2,6 +2,7 @@ package session
 
 import (
 	"context"
+	"sort"
 	"sync"
 	"time"
 
@@ -60,6 +61,11 @@ func (m *MemorySessionManager) ValidateSession(ctx context.Context, sessionID st
 		return nil, usecase.ErrInvalidSession
 	}
 
+	// Revoked sessions are kept until they expire so the caller learns why
+	if !session.IsActive {
+		return nil, usecase.ErrSessionRevoked
+	}
+
 	// Check if session is expired
 	if time.Now().After(session.ExpiresAt) {
 		// Remove expired session
@@ -75,18 +81,7 @@ func (m *MemorySessionManager) ValidateSession(ctx context.Context, sessionID st
 	session.LastAccessedAt = time.Now()
 
 	// Return a copy to avoid external modifications
-	return &usecase.Session{
-		ID:             session.ID,
-		UserID:         session.UserID,
-		UserRole:       session.UserRole,
-		CreatedAt:      session.CreatedAt,
-		ExpiresAt:      session.ExpiresAt,
-		LastAccessedAt: session.LastAccessedAt,
-		ClientIP:       session.ClientIP,
-		UserAgent:      session.UserAgent,
-		CSRFToken:      session.CSRFToken,
-		IsActive:       session.IsActive,
-	}, nil
+	return copySession(session), nil
 }
 
 // DeleteSession removes a session
@@ -108,6 +103,10 @@ func (m *MemorySessionManager) RefreshSession(ctx context.Context, sessionID str
 		return nil, usecase.ErrInvalidSession
 	}
 
+	if !session.IsActive {
+		return nil, usecase.ErrSessionRevoked
+	}
+
 	// Check if session is expired
 	if time.Now().After(session.ExpiresAt) {
 		delete(m.sessions, sessionID)
@@ -148,6 +147,85 @@ func (m *MemorySessionManager) CleanupExpiredSessions(ctx context.Context) error
 	return nil
 }
 
+// ListSessions returns the active sessions, newest activity first
+func (m *MemorySessionManager) ListSessions(ctx context.Context, userID domain.ID) ([]*usecase.Session, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	now := time.Now()
+	var sessions []*usecase.Session
+	for _, session := range m.sessions {
+		if !session.IsActive || now.After(session.ExpiresAt) {
+			continue
+		}
+		if userID != "" && session.UserID != userID {
+			continue
+		}
+		sessions = append(sessions, copySession(session))
+	}
+
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].LastAccessedAt.After(sessions[j].LastAccessedAt)
+	})
+
+	return sessions, nil
+}
+
+// RevokeSession marks a session as revoked
+func (m *MemorySessionManager) RevokeSession(ctx context.Context, sessionID, reason string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	session, exists := m.sessions[sessionID]
+	if !exists || !session.IsActive {
+		return usecase.ErrSessionNotFound
+	}
+
+	m.revoke(session, reason)
+	return nil
+}
+
+// RevokeUserSessions revokes every active session of a user except keepSessionID
+func (m *MemorySessionManager) RevokeUserSessions(ctx context.Context, userID domain.ID, keepSessionID, reason string) (int, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	revoked := 0
+	for _, session := range m.sessions {
+		if session.UserID != userID || session.ID == keepSessionID || !session.IsActive {
+			continue
+		}
+		m.revoke(session, reason)
+		revoked++
+	}
+
+	return revoked, nil
+}
+
+// revoke deactivates a session; the caller holds the write lock
+func (m *MemorySessionManager) revoke(session *usecase.Session, reason string) {
+	now := time.Now()
+	session.IsActive = false
+	session.InvalidationReason = reason
+	session.InvalidatedAt = &now
+}
+
+// copySession returns a copy of a stored session without the invalidation details
+func copySession(session *usecase.Session) *usecase.Session {
+	return &usecase.Session{
+		ID:             session.ID,
+		UserID:         session.UserID,
+		UserRole:       session.UserRole,
+		CreatedAt:      session.CreatedAt,
+		ExpiresAt:      session.ExpiresAt,
+		LastAccessedAt: session.LastAccessedAt,
+		ClientIP:       session.ClientIP,
+		UserAgent:      session.UserAgent,
+		CSRFToken:      session.CSRFToken,
+		IsActive:       session.IsActive,
+	}
+}
+
 // getClientIPFromContext extracts client IP from context
 func getClientIPFromContext(ctx context.Context) string {
 	if clientIP := ctx.Value(usecase.ContextKeyClientIP); clientIP != nil {
@@ -240,3 +240,44 @@ func TestSessionManager_ConcurrentAccess(t *testing.T) {
 
 	assert.Equal(t, numGoroutines, len(sessions))
 }
+
+func TestSessionManager_ListAndRevokeSessions(t *testing.T) {
+	manager := NewMemorySessionManager(8 * time.Hour)
+	registry := manager.(usecase.SessionRegistry)
+	ctx := context.Background()
+
+	first, err := manager.CreateSession(ctx, "user-123", domain.RoleStaff)
+	require.NoError(t, err)
+	second, err := manager.CreateSession(ctx, "user-123", domain.RoleStaff)
+	require.NoError(t, err)
+	other, err := manager.CreateSession(ctx, "user-456", domain.RoleAdmin)
+	require.NoError(t, err)
+
+	all, err := registry.ListSessions(ctx, "")
+	require.NoError(t, err)
+	assert.Len(t, all, 3)
+
+	own, err := registry.ListSessions(ctx, "user-123")
+	require.NoError(t, err)
+	assert.Len(t, own, 2)
+
+	// A revoked session fails validation with a dedicated error
+	require.NoError(t, registry.RevokeSession(ctx, other.ID, "端末紛失"))
+	_, err = manager.ValidateSession(ctx, other.ID)
+	assert.Equal(t, usecase.ErrSessionRevoked, err)
+	assert.Equal(t, usecase.ErrSessionNotFound, registry.RevokeSession(ctx, other.ID, "再実行"))
+
+	// Logging out everywhere keeps the caller's own session
+	revoked, err := registry.RevokeUserSessions(ctx, "user-123", first.ID, "パスワード変更")
+	require.NoError(t, err)
+	assert.Equal(t, 1, revoked)
+
+	_, err = manager.ValidateSession(ctx, first.ID)
+	assert.NoError(t, err)
+	_, err = manager.ValidateSession(ctx, second.ID)
+	assert.Equal(t, usecase.ErrSessionRevoked, err)
+
+	remaining, err := registry.ListSessions(ctx, "")
+	require.NoError(t, err)
+	assert.Len(t, remaining, 1)
+}
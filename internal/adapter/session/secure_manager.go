@@ -14,6 +14,9 @@ import (
 	"shien-system/internal/usecase"
 )
 
+// revokedReasonPrefix marks invalidation reasons of sessions ended by a user or administrator
+const revokedReasonPrefix = "revoked: "
+
 // SecureSessionManager implements SessionManager interface with database persistence
 // and enhanced security features including session fixation protection, CSRF tokens,
 // and configurable security policies
@@ -154,6 +157,16 @@ func (m *SecureSessionManager) ValidateSession(ctx context.Context, sessionID st
 		return nil, err
 	}
 
+	// 無効化済みセッションのチェック（管理画面などから終了されたもの）
+	// メモリ上のセッションが無効になるのは終了された場合のみ
+	if !session.IsActive {
+		inMemory := !m.config.PersistenceEnabled || m.config.StorageType != "database"
+		if inMemory || strings.HasPrefix(session.InvalidationReason, revokedReasonPrefix) {
+			return nil, usecase.ErrSessionRevoked
+		}
+		return nil, usecase.ErrInvalidSession
+	}
+
 	// 有効期限チェック
 	if time.Now().After(session.ExpiresAt) {
 		// 期限切れセッションを無効化
@@ -292,6 +305,88 @@ func (m *SecureSessionManager) InvalidateSession(ctx context.Context, sessionID
 	}
 }
 
+// ListSessions returns the active sessions, only those of userID when it is not empty
+func (m *SecureSessionManager) ListSessions(ctx context.Context, userID domain.ID) ([]*usecase.Session, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	if !m.config.PersistenceEnabled || m.config.StorageType != "database" {
+		return m.memoryManager.ListSessions(ctx, userID)
+	}
+
+	if userID == "" {
+		return m.repository.GetActiveSessions(ctx)
+	}
+
+	sessions, err := m.repository.GetSessionsByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 期限切れでまだクリーンアップされていないセッションは除外
+	now := time.Now()
+	active := sessions[:0]
+	for _, session := range sessions {
+		if now.Before(session.ExpiresAt) {
+			active = append(active, session)
+		}
+	}
+
+	return active, nil
+}
+
+// RevokeSession ends a session; the next ValidateSession returns ErrSessionRevoked
+func (m *SecureSessionManager) RevokeSession(ctx context.Context, sessionID, reason string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	return m.revokeSession(ctx, sessionID, reason)
+}
+
+// RevokeUserSessions ends every session of a user except keepSessionID
+func (m *SecureSessionManager) RevokeUserSessions(ctx context.Context, userID domain.ID, keepSessionID, reason string) (int, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if !m.config.PersistenceEnabled || m.config.StorageType != "database" {
+		return m.memoryManager.RevokeUserSessions(ctx, userID, keepSessionID, reason)
+	}
+
+	sessions, err := m.repository.GetSessionsByUserID(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	revoked := 0
+	for _, session := range sessions {
+		if session.ID == keepSessionID {
+			continue
+		}
+		if err := m.revokeSession(ctx, session.ID, reason); err != nil {
+			return revoked, err
+		}
+		revoked++
+	}
+
+	return revoked, nil
+}
+
+// revokeSession invalidates a session with a reason marking it as revoked
+func (m *SecureSessionManager) revokeSession(ctx context.Context, sessionID, reason string) error {
+	if !m.config.PersistenceEnabled || m.config.StorageType != "database" {
+		return m.memoryManager.RevokeSession(ctx, sessionID, reason)
+	}
+
+	if err := m.repository.InvalidateSession(ctx, sessionID, revokedReasonPrefix+reason); err != nil {
+		if err == usecase.ErrInvalidSession {
+			return usecase.ErrSessionNotFound
+		}
+		return err
+	}
+
+	return nil
+}
+
 // ValidateCSRFToken validates CSRF token for a session
 func (m *SecureSessionManager) ValidateCSRFToken(ctx context.Context, sessionID, csrfToken string) error {
 	session, err := m.ValidateSession(ctx, sessionID)
@@ -11,8 +11,6 @@ import (
 	"shien-system/internal/config"
 	"shien-system/internal/domain"
 	"shien-system/internal/usecase"
-
-	_ "github.com/mattn/go-sqlite3"
 )
 
 func TestSecureSessionManager_CreateSession(t *testing.T) {
@@ -0,0 +1,188 @@
+// Package spreadsheet reads and writes the CSV and Excel (XLSX) files that
+// offices exchange with the application.
+package spreadsheet
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/xuri/excelize/v2"
+	"golang.org/x/text/encoding/japanese"
+)
+
+// ErrUnsupportedFormat is returned for files that are neither CSV nor XLSX
+var ErrUnsupportedFormat = errors.New("unsupported file format")
+
+// Reader reads the first sheet of a CSV or XLSX file into rows of cells
+type Reader struct{}
+
+// NewReader creates a new spreadsheet reader
+func NewReader() *Reader {
+	return &Reader{}
+}
+
+// Read returns every row of the file, header included. The format follows
+// the file name extension: .csv (UTF-8 or Shift_JIS) or .xlsx. Cells are
+// trimmed and trailing blank rows are dropped.
+func (r *Reader) Read(source io.Reader, fileName string) ([][]string, error) {
+	var rows [][]string
+	var err error
+
+	switch strings.ToLower(filepath.Ext(fileName)) {
+	case ".csv", ".txt":
+		rows, err = readCSV(source)
+	case ".xlsx", ".xlsm":
+		rows, err = readXLSX(source)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedFormat, filepath.Ext(fileName))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		for i := range row {
+			row[i] = strings.TrimSpace(row[i])
+		}
+	}
+	for len(rows) > 0 && isBlankRow(rows[len(rows)-1]) {
+		rows = rows[:len(rows)-1]
+	}
+	return rows, nil
+}
+
+// readCSV decodes Shift_JIS unless the file is valid UTF-8, as Excel on
+// Japanese Windows saves CSV in Shift_JIS
+func readCSV(source io.Reader) ([][]string, error) {
+	data, err := io.ReadAll(source)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+	if !utf8.Valid(data) {
+		if data, err = japanese.ShiftJIS.NewDecoder().Bytes(data); err != nil {
+			return nil, fmt.Errorf("failed to decode Shift_JIS: %w", err)
+		}
+	}
+
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("invalid CSV file: %w", err)
+	}
+	return rows, nil
+}
+
+// readXLSX reads the first sheet. Date cells are returned as 2006-01-02
+// instead of their display format, which depends on the Excel locale.
+func readXLSX(source io.Reader) ([][]string, error) {
+	file, err := excelize.OpenReader(source)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Excel file: %w", err)
+	}
+	defer file.Close()
+
+	sheets := file.GetSheetList()
+	if len(sheets) == 0 {
+		return nil, nil
+	}
+	sheet := sheets[0]
+
+	rows, err := file.GetRows(sheet)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read sheet %s: %w", sheet, err)
+	}
+	raw, err := file.GetRows(sheet, excelize.Options{RawCellValue: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read sheet %s: %w", sheet, err)
+	}
+
+	dateStyles := make(map[int]bool)
+	for y, row := range rows {
+		for x := range row {
+			if y >= len(raw) || x >= len(raw[y]) {
+				continue
+			}
+			serial, err := strconv.ParseFloat(raw[y][x], 64)
+			if err != nil {
+				continue
+			}
+			cell, err := excelize.CoordinatesToCellName(x+1, y+1)
+			if err != nil {
+				continue
+			}
+			styleID, err := file.GetCellStyle(sheet, cell)
+			if err != nil {
+				continue
+			}
+			isDate, ok := dateStyles[styleID]
+			if !ok {
+				isDate = isDateStyle(file, styleID)
+				dateStyles[styleID] = isDate
+			}
+			if !isDate {
+				continue
+			}
+			if date, err := excelize.ExcelDateToTime(serial, false); err == nil {
+				row[x] = date.Format("2006-01-02")
+			}
+		}
+	}
+	return rows, nil
+}
+
+// isDateStyle reports whether a cell style shows numbers as dates
+func isDateStyle(file *excelize.File, styleID int) bool {
+	style, err := file.GetStyle(styleID)
+	if err != nil || style == nil {
+		return false
+	}
+
+	if style.CustomNumFmt != nil {
+		// Drop literal text such as "年" and sections such as [Red] or [$-411]
+		var format strings.Builder
+		inQuote, inBracket := false, false
+		for _, c := range strings.ToLower(*style.CustomNumFmt) {
+			switch {
+			case c == '"' && !inBracket:
+				inQuote = !inQuote
+			case c == '[' && !inQuote:
+				inBracket = true
+			case c == ']' && inBracket:
+				inBracket = false
+			case !inQuote && !inBracket:
+				format.WriteRune(c)
+			}
+		}
+		// y/d for dates, g/e for Japanese eras; digit placeholders mean a number
+		return !strings.ContainsAny(format.String(), "0#?") && strings.ContainsAny(format.String(), "ydge")
+	}
+
+	// Built-in date formats, including the Japanese era formats
+	switch {
+	case style.NumFmt >= 14 && style.NumFmt <= 17,
+		style.NumFmt >= 27 && style.NumFmt <= 36,
+		style.NumFmt >= 50 && style.NumFmt <= 58:
+		return true
+	}
+	return false
+}
+
+func isBlankRow(row []string) bool {
+	for _, cell := range row {
+		if strings.TrimSpace(cell) != "" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,98 @@
+package spreadsheet
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+	"golang.org/x/text/encoding/japanese"
+)
+
+func TestReader_ReadCSV(t *testing.T) {
+	const sample = "氏名,フリガナ,生年月日\n山田 太郎 ,ﾔﾏﾀﾞﾀﾛｳ,1980/04/01\n\"佐藤, 花子\",サトウハナコ\n,,\n"
+	want := [][]string{
+		{"氏名", "フリガナ", "生年月日"},
+		{"山田 太郎", "ﾔﾏﾀﾞﾀﾛｳ", "1980/04/01"},
+		{"佐藤, 花子", "サトウハナコ"},
+	}
+
+	sjis, err := japanese.ShiftJIS.NewEncoder().String(sample)
+	if err != nil {
+		t.Fatalf("failed to encode sample: %v", err)
+	}
+
+	for name, input := range map[string]string{
+		"UTF-8":     sample,
+		"UTF-8 BOM": "\ufeff" + sample,
+		"Shift_JIS": sjis,
+	} {
+		rows, err := NewReader().Read(strings.NewReader(input), "利用者.CSV")
+		if err != nil {
+			t.Fatalf("%s: Read() error = %v", name, err)
+		}
+		if !reflect.DeepEqual(rows, want) {
+			t.Errorf("%s: Read() = %q, want %q", name, rows, want)
+		}
+	}
+}
+
+func TestReader_ReadXLSX(t *testing.T) {
+	file := excelize.NewFile()
+	defer file.Close()
+
+	sheet := file.GetSheetName(0)
+	if err := file.SetSheetRow(sheet, "A1", &[]interface{}{"氏名", "生年月日", "最大給付日数"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := file.SetSheetRow(sheet, "A2", &[]interface{}{"山田太郎", time.Date(1980, 4, 1, 0, 0, 0, 0, time.UTC), 22}); err != nil {
+		t.Fatal(err)
+	}
+	// A date shown in the Japanese era format
+	eraStyle := `[$-411]ggge"年"m"月"d"日"`
+	style, err := file.NewStyle(&excelize.Style{CustomNumFmt: &eraStyle})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := file.SetCellValue(sheet, "B3", 45748); err != nil { // 2025-04-01
+		t.Fatal(err)
+	}
+	if err := file.SetCellStyle(sheet, "B3", "B3", style); err != nil {
+		t.Fatal(err)
+	}
+	if err := file.SetCellValue(sheet, "A3", "佐藤花子"); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := file.Write(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	rows, err := NewReader().Read(&buf, "import.xlsx")
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	want := [][]string{
+		{"氏名", "生年月日", "最大給付日数"},
+		{"山田太郎", "1980-04-01", "22"},
+		{"佐藤花子", "2025-04-01"},
+	}
+	if !reflect.DeepEqual(rows, want) {
+		t.Errorf("Read() = %q, want %q", rows, want)
+	}
+}
+
+func TestReader_RejectsUnsupportedFormats(t *testing.T) {
+	_, err := NewReader().Read(strings.NewReader("data"), "import.xls")
+	if !errors.Is(err, ErrUnsupportedFormat) {
+		t.Errorf("Read() error = %v, want ErrUnsupportedFormat", err)
+	}
+
+	if _, err := NewReader().Read(strings.NewReader("not a zip"), "import.xlsx"); err == nil {
+		t.Error("Read() of a broken XLSX error = nil, want an error")
+	}
+}
@@ -0,0 +1,174 @@
+package spreadsheet
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// Writer writes a table with a header row to a CSV or XLSX file. Cells may
+// be strings, integers or time.Time; nil and the zero time are left blank.
+type Writer struct{}
+
+// NewWriter creates a new spreadsheet writer
+func NewWriter() *Writer {
+	return &Writer{}
+}
+
+// WriteCSV writes UTF-8 CSV with a BOM so that Excel detects the encoding.
+// Text that Excel would evaluate as a formula is prefixed with a quote.
+func (w *Writer) WriteCSV(dst io.Writer, header []string, rows [][]interface{}) error {
+	var buf bytes.Buffer
+	buf.WriteString("\ufeff")
+
+	writer := csv.NewWriter(&buf)
+	writer.UseCRLF = true
+	if err := writer.Write(header); err != nil {
+		return fmt.Errorf("failed to write CSV: %w", err)
+	}
+	record := make([]string, len(header))
+	for _, row := range rows {
+		for i := range record {
+			record[i] = ""
+			if i < len(row) {
+				record[i] = csvCell(row[i])
+			}
+		}
+		if err := writer.Write(record); err != nil {
+			return fmt.Errorf("failed to write CSV: %w", err)
+		}
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return fmt.Errorf("failed to write CSV: %w", err)
+	}
+
+	_, err := dst.Write(buf.Bytes())
+	return err
+}
+
+// WriteXLSX writes a workbook with one sheet. The header is bold and stays
+// visible while scrolling; dates are stored as Excel dates.
+func (w *Writer) WriteXLSX(dst io.Writer, sheetName string, header []string, rows [][]interface{}) error {
+	file := excelize.NewFile()
+	defer file.Close()
+
+	sheet := file.GetSheetName(0)
+	if sheetName != "" {
+		if err := file.SetSheetName(sheet, sheetName); err != nil {
+			return fmt.Errorf("invalid sheet name %s: %w", sheetName, err)
+		}
+		sheet = sheetName
+	}
+
+	headerStyle, err := file.NewStyle(&excelize.Style{Font: &excelize.Font{Bold: true}})
+	if err != nil {
+		return err
+	}
+	dateFormat, dateTimeFormat := "yyyy/mm/dd", "yyyy/mm/dd hh:mm:ss"
+	dateStyle, err := file.NewStyle(&excelize.Style{CustomNumFmt: &dateFormat})
+	if err != nil {
+		return err
+	}
+	dateTimeStyle, err := file.NewStyle(&excelize.Style{CustomNumFmt: &dateTimeFormat})
+	if err != nil {
+		return err
+	}
+
+	cells := make([]interface{}, len(header))
+	for i, heading := range header {
+		cells[i] = heading
+	}
+	if err := file.SetSheetRow(sheet, "A1", &cells); err != nil {
+		return fmt.Errorf("failed to write header: %w", err)
+	}
+	if len(header) > 0 {
+		last, _ := excelize.CoordinatesToCellName(len(header), 1)
+		if err := file.SetCellStyle(sheet, "A1", last, headerStyle); err != nil {
+			return err
+		}
+	}
+
+	for y, row := range rows {
+		for x, value := range row {
+			if x >= len(header) {
+				break
+			}
+			cell, err := excelize.CoordinatesToCellName(x+1, y+2)
+			if err != nil {
+				return err
+			}
+			switch v := value.(type) {
+			case nil:
+				continue
+			case time.Time:
+				if v.IsZero() {
+					continue
+				}
+				style := dateTimeStyle
+				if isMidnight(v) {
+					style = dateStyle
+				}
+				// Excel dates have no time zone: store the wall clock time
+				wall := time.Date(v.Year(), v.Month(), v.Day(), v.Hour(), v.Minute(), v.Second(), 0, time.UTC)
+				if err := file.SetCellValue(sheet, cell, wall); err != nil {
+					return err
+				}
+				if err := file.SetCellStyle(sheet, cell, cell, style); err != nil {
+					return err
+				}
+			default:
+				if err := file.SetCellValue(sheet, cell, v); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	if err := file.SetPanes(sheet, &excelize.Panes{
+		Freeze:      true,
+		YSplit:      1,
+		TopLeftCell: "A2",
+		ActivePane:  "bottomLeft",
+	}); err != nil {
+		return err
+	}
+
+	if err := file.Write(dst); err != nil {
+		return fmt.Errorf("failed to write Excel file: %w", err)
+	}
+	return nil
+}
+
+// csvCell formats a cell for CSV
+func csvCell(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		// Keep =, +, -, @ at the start from being run as a formula in Excel
+		if v != "" && strings.ContainsRune("=+-@\t\r", rune(v[0])) {
+			return "'" + v
+		}
+		return v
+	case time.Time:
+		if v.IsZero() {
+			return ""
+		}
+		if isMidnight(v) {
+			return v.Format("2006/01/02")
+		}
+		return v.Format("2006/01/02 15:04:05")
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func isMidnight(t time.Time) bool {
+	return t.Hour() == 0 && t.Minute() == 0 && t.Second() == 0
+}
@@ -0,0 +1,79 @@
+package spreadsheet
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func TestWriter_WriteCSV(t *testing.T) {
+	header := []string{"氏名", "生年月日", "支給日数", "備考"}
+	rows := [][]interface{}{
+		{"山田 太郎", time.Date(1980, 4, 1, 0, 0, 0, 0, time.UTC), 22, "=HYPERLINK(\"x\")"},
+		{"佐藤, 花子", time.Time{}, nil},
+	}
+
+	var buf bytes.Buffer
+	if err := NewWriter().WriteCSV(&buf, header, rows); err != nil {
+		t.Fatalf("WriteCSV() error = %v", err)
+	}
+
+	want := "\ufeff氏名,生年月日,支給日数,備考\r\n" +
+		"山田 太郎,1980/04/01,22,\"'=HYPERLINK(\"\"x\"\")\"\r\n" +
+		"\"佐藤, 花子\",,,\r\n"
+	if buf.String() != want {
+		t.Errorf("WriteCSV() = %q, want %q", buf.String(), want)
+	}
+
+	// The reader gets the same table back
+	read, err := NewReader().Read(strings.NewReader(buf.String()), "export.csv")
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	if read[1][1] != "1980/04/01" || read[2][0] != "佐藤, 花子" {
+		t.Errorf("Read() = %q", read)
+	}
+}
+
+func TestWriter_WriteXLSX(t *testing.T) {
+	jst := time.FixedZone("JST", 9*60*60)
+	header := []string{"日時", "生年月日", "件数", "氏名"}
+	rows := [][]interface{}{
+		{time.Date(2025, 4, 1, 9, 30, 0, 0, jst), time.Date(1980, 4, 1, 0, 0, 0, 0, time.UTC), 3, "=1+1"},
+	}
+
+	var buf bytes.Buffer
+	if err := NewWriter().WriteXLSX(&buf, "監査ログ", header, rows); err != nil {
+		t.Fatalf("WriteXLSX() error = %v", err)
+	}
+
+	file, err := excelize.OpenReader(&buf)
+	if err != nil {
+		t.Fatalf("OpenReader() error = %v", err)
+	}
+	defer file.Close()
+
+	if got := file.GetSheetList(); !reflect.DeepEqual(got, []string{"監査ログ"}) {
+		t.Errorf("sheets = %v, want [監査ログ]", got)
+	}
+	got, err := file.GetRows("監査ログ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := [][]string{
+		{"日時", "生年月日", "件数", "氏名"},
+		{"2025/04/01 09:30:00", "1980/04/01", "3", "=1+1"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("rows = %q, want %q", got, want)
+	}
+
+	// Text is stored as text, not as a formula
+	if formula, _ := file.GetCellFormula("監査ログ", "D2"); formula != "" {
+		t.Errorf("D2 formula = %q, want none", formula)
+	}
+}
@@ -6,18 +6,24 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"strings"
 	"time"
 
 	"gopkg.in/yaml.v3"
+
+	"shien-system/internal/domain"
 )
 
 // Config holds all application configuration
 type Config struct {
-	Database DatabaseConfig `yaml:"database"`
-	Security SecurityConfig `yaml:"security"`
-	UI       UIConfig       `yaml:"ui"`
-	Logging  LoggingConfig  `yaml:"logging"`
-	Backup   BackupConfig   `yaml:"backup"`
+	Database      DatabaseConfig     `yaml:"database"`
+	Security      SecurityConfig     `yaml:"security"`
+	UI            UIConfig           `yaml:"ui"`
+	Logging       LoggingConfig      `yaml:"logging"`
+	Backup        BackupConfig       `yaml:"backup"`
+	Jobs          JobsConfig         `yaml:"jobs"`
+	Notifications NotificationConfig `yaml:"notifications"`
+	Reports       ReportConfig       `yaml:"reports"`
 }
 
 // DatabaseConfig holds database-related configuration
@@ -25,6 +31,10 @@ type DatabaseConfig struct {
 	Path       string `yaml:"path"`
 	BackupDir  string `yaml:"backup_dir"`
 	MaxBackups int    `yaml:"max_backups"`
+	// Encrypted opens the database file with SQLCipher using a key derived
+	// from the OS keyring. Requires a build with -tags sqlcipher and a
+	// database converted with "migrate encrypt".
+	Encrypted bool `yaml:"encrypted"`
 }
 
 // SecurityConfig holds security-related configuration
@@ -52,6 +62,22 @@ type RateLimitConfig struct {
 	EnableProgressiveLockout bool     `yaml:"enable_progressive_lockout"`
 }
 
+// ToDomain converts the rate limit section into the policy stored in the database
+func (c RateLimitConfig) ToDomain() *domain.RateLimitConfig {
+	return &domain.RateLimitConfig{
+		Enabled:                  c.Enabled,
+		MaxAttemptsPerIP:         c.MaxAttemptsPerIP,
+		MaxAttemptsPerUser:       c.MaxAttemptsPerUser,
+		WindowSizeMinutes:        c.WindowSizeMinutes,
+		LockoutDurationMinutes:   c.LockoutDurationMinutes,
+		BackoffMultiplier:        c.BackoffMultiplier,
+		MaxLockoutHours:          c.MaxLockoutHours,
+		WhitelistIPs:             append([]string{}, c.WhitelistIPs...),
+		EnableProgressiveLockout: c.EnableProgressiveLockout,
+		Source:                   domain.RateLimitSourceConfig,
+	}
+}
+
 // SessionConfig holds session management configuration
 type SessionConfig struct {
 	StorageType                string `yaml:"storage_type"`                  // "memory", "database", "file"
@@ -74,8 +100,36 @@ type UIConfig struct {
 
 // LoggingConfig holds logging configuration
 type LoggingConfig struct {
-	Level    string `yaml:"level"`
-	FilePath string `yaml:"file_path"`
+	Level       string `yaml:"level"`        // debug, info, warn, error
+	FilePath    string `yaml:"file_path"`    // ログファイルのパス
+	MaxSizeMB   int    `yaml:"max_size_mb"`  // このサイズを超えたらローテーション
+	MaxBackups  int    `yaml:"max_backups"`  // 残す古いログファイルの数
+	RotateDaily bool   `yaml:"rotate_daily"` // 日付が変わったらローテーション
+	RedactPII   bool   `yaml:"redact_pii"`   // 氏名・住所・電話番号を伏せ字にする
+	Console     bool   `yaml:"console"`      // 標準出力にも出力
+}
+
+// JobsConfig holds background job scheduler configuration
+type JobsConfig struct {
+	Enabled            bool              `yaml:"enabled"`              // スケジューラ有効/無効
+	Schedules          map[string]string `yaml:"schedules"`            // ジョブ名ごとのスケジュール上書き（cron形式）
+	MaxRetries         int               `yaml:"max_retries"`          // 失敗時リトライ回数
+	RetryBackoffSec    int               `yaml:"retry_backoff_sec"`    // 初回リトライまでの待機時間（秒、以降倍増）
+	ShutdownTimeoutSec int               `yaml:"shutdown_timeout_sec"` // 終了時に実行中ジョブを待つ最大時間（秒）
+}
+
+// NotificationConfig holds in-app notification configuration
+type NotificationConfig struct {
+	CertificateExpiryDays  []int `yaml:"certificate_expiry_days"`  // 受給者証期限の通知しきい値（日）
+	RefreshIntervalMinutes int   `yaml:"refresh_interval_minutes"` // 通知チェック間隔（分）
+}
+
+// ReportConfig holds PDF report configuration
+type ReportConfig struct {
+	FontDir     string            `yaml:"font_dir"`     // 日本語フォント（NotoSansCJK-Regular.ttf など）の配置先
+	TemplateDir string            `yaml:"template_dir"` // 独自の帳票テンプレート（*.yaml, *.json）の配置先
+	Templates   map[string]string `yaml:"templates"`    // 帳票の種類ごとに使うテンプレート名
+	DateStyles  map[string]string `yaml:"date_styles"`  // 帳票の種類ごとの日付表記（gregorian: 西暦、wareki: 和暦）
 }
 
 // BackupConfig holds backup-related configuration
@@ -262,8 +316,13 @@ func GetDefaultConfig() *Config {
 			FontSize: 12,
 		},
 		Logging: LoggingConfig{
-			Level:    "info",
-			FilePath: filepath.Join(appDataDir, "logs", "shien-system.log"),
+			Level:       "info",
+			FilePath:    filepath.Join(appDataDir, "logs", "shien-system.log"),
+			MaxSizeMB:   10,
+			MaxBackups:  7,
+			RotateDaily: true,
+			RedactPII:   true,
+			Console:     true,
 		},
 		Backup: BackupConfig{
 			// 基本設定
@@ -307,6 +366,23 @@ func GetDefaultConfig() *Config {
 			RetryCount:       3,  // 3回リトライ
 			RetryIntervalSec: 60, // 60秒間隔
 		},
+		Jobs: JobsConfig{
+			Enabled:            true,
+			Schedules:          map[string]string{},
+			MaxRetries:         3,
+			RetryBackoffSec:    30,
+			ShutdownTimeoutSec: 10,
+		},
+		Notifications: NotificationConfig{
+			CertificateExpiryDays:  []int{30, 60, 90},
+			RefreshIntervalMinutes: 60,
+		},
+		Reports: ReportConfig{
+			FontDir:     "assets/fonts",
+			TemplateDir: filepath.Join(appDataDir, "report_templates"),
+			Templates:   map[string]string{},
+			DateStyles:  map[string]string{},
+		},
 	}
 }
 
@@ -429,6 +505,11 @@ func ValidateConfig(config *Config) error {
 		return fmt.Errorf("CSRF token length must be at least 16 bytes")
 	}
 
+	// Validate rate limit ranges; the same rules apply to edits on the admin screen
+	if errs := config.Security.RateLimit.ToDomain().Validate(); len(errs) > 0 {
+		return fmt.Errorf("invalid rate limit configuration: %s", strings.Join(errs, ", "))
+	}
+
 	// Validate UI settings
 	if config.UI.FontSize < 8 || config.UI.FontSize > 24 {
 		return fmt.Errorf("font size must be between 8 and 24")
@@ -482,6 +563,49 @@ func ValidateConfig(config *Config) error {
 		}
 	}
 
+	// Validate job scheduler configuration
+	if config.Jobs.MaxRetries < 0 {
+		return fmt.Errorf("job max retries cannot be negative")
+	}
+
+	if config.Jobs.RetryBackoffSec < 1 {
+		return fmt.Errorf("job retry backoff must be at least 1 second")
+	}
+
+	if config.Jobs.ShutdownTimeoutSec < 1 {
+		return fmt.Errorf("job shutdown timeout must be at least 1 second")
+	}
+
+	// Validate logging configuration
+	switch strings.ToLower(config.Logging.Level) {
+	case "debug", "info", "warn", "error":
+	default:
+		return fmt.Errorf("invalid log level: %s (must be one of: debug, info, warn, error)", config.Logging.Level)
+	}
+
+	if config.Logging.MaxSizeMB < 1 || config.Logging.MaxSizeMB > 1024 {
+		return fmt.Errorf("log max size must be between 1 and 1024 MB")
+	}
+
+	if config.Logging.MaxBackups < 0 {
+		return fmt.Errorf("log max backups cannot be negative")
+	}
+
+	// Validate notification configuration
+	if len(config.Notifications.CertificateExpiryDays) == 0 {
+		return fmt.Errorf("at least one certificate expiry threshold is required")
+	}
+
+	for _, days := range config.Notifications.CertificateExpiryDays {
+		if days < 1 || days > 365 {
+			return fmt.Errorf("certificate expiry threshold must be between 1 and 365 days: %d", days)
+		}
+	}
+
+	if config.Notifications.RefreshIntervalMinutes < 1 {
+		return fmt.Errorf("notification refresh interval must be at least 1 minute")
+	}
+
 	return nil
 }
 
@@ -546,6 +670,10 @@ func applyDefaults(config *Config) {
 		config.Logging.FilePath = defaults.Logging.FilePath
 	}
 
+	if config.Logging.MaxSizeMB == 0 {
+		config.Logging.MaxSizeMB = defaults.Logging.MaxSizeMB
+	}
+
 	// バックアップ設定のデフォルト値適用
 	if config.Backup.BackupDir == "" {
 		config.Backup.BackupDir = defaults.Backup.BackupDir
@@ -582,6 +710,45 @@ func applyDefaults(config *Config) {
 	if config.Backup.RetryIntervalSec == 0 {
 		config.Backup.RetryIntervalSec = defaults.Backup.RetryIntervalSec
 	}
+
+	// ジョブスケジューラ設定のデフォルト値適用
+	if config.Jobs.Schedules == nil {
+		config.Jobs.Schedules = defaults.Jobs.Schedules
+	}
+
+	if config.Jobs.RetryBackoffSec == 0 {
+		config.Jobs.RetryBackoffSec = defaults.Jobs.RetryBackoffSec
+	}
+
+	if config.Jobs.ShutdownTimeoutSec == 0 {
+		config.Jobs.ShutdownTimeoutSec = defaults.Jobs.ShutdownTimeoutSec
+	}
+
+	// 通知設定のデフォルト値適用
+	if len(config.Notifications.CertificateExpiryDays) == 0 {
+		config.Notifications.CertificateExpiryDays = defaults.Notifications.CertificateExpiryDays
+	}
+
+	if config.Notifications.RefreshIntervalMinutes == 0 {
+		config.Notifications.RefreshIntervalMinutes = defaults.Notifications.RefreshIntervalMinutes
+	}
+
+	// 帳票設定のデフォルト値適用
+	if config.Reports.FontDir == "" {
+		config.Reports.FontDir = defaults.Reports.FontDir
+	}
+
+	if config.Reports.TemplateDir == "" {
+		config.Reports.TemplateDir = defaults.Reports.TemplateDir
+	}
+
+	if config.Reports.Templates == nil {
+		config.Reports.Templates = defaults.Reports.Templates
+	}
+
+	if config.Reports.DateStyles == nil {
+		config.Reports.DateStyles = defaults.Reports.DateStyles
+	}
 }
 
 // applyEnvironmentOverrides applies environment variable overrides
@@ -590,6 +757,10 @@ func applyEnvironmentOverrides(config *Config) {
 		config.Database.Path = dbPath
 	}
 
+	if encrypted := os.Getenv("SHIEN_DB_ENCRYPTED"); encrypted != "" {
+		config.Database.Encrypted = encrypted == "true"
+	}
+
 	if backupDir := os.Getenv("SHIEN_BACKUP_DIR"); backupDir != "" {
 		config.Database.BackupDir = backupDir
 		config.Backup.BackupDir = backupDir // 新しいバックアップ設定にも適用
@@ -693,24 +864,12 @@ func getAppDataDir() string {
 	}
 }
 
-// GetMigrationDir returns the path to migration files
+// GetMigrationDir returns a directory that overrides the migrations embedded
+// in the binary, or an empty string to use the embedded ones. Only an
+// explicit SHIEN_MIGRATION_DIR overrides them, so a stray "migrations" folder
+// in the working directory cannot replace the schema of a release build.
 func GetMigrationDir() string {
-	// In development, use relative path
-	if _, err := os.Stat("migrations"); err == nil {
-		return "migrations"
-	}
-
-	// In production, look for migrations relative to executable
-	execPath, err := os.Executable()
-	if err == nil {
-		migrationPath := filepath.Join(filepath.Dir(execPath), "migrations")
-		if _, err := os.Stat(migrationPath); err == nil {
-			return migrationPath
-		}
-	}
-
-	// Fallback to current directory
-	return "migrations"
+	return os.Getenv("SHIEN_MIGRATION_DIR")
 }
 
 // CreateDefaultConfigFile creates a default configuration file with comments
@@ -108,6 +108,60 @@ func TestValidateConfig(t *testing.T) {
 			},
 			expectError: true,
 		},
+		{
+			name: "invalid job retry backoff",
+			config: func() *Config {
+				config := GetDefaultConfig()
+				config.Jobs.RetryBackoffSec = 0
+				return config
+			}(),
+			expectError: true,
+		},
+		{
+			name: "invalid rate limit window",
+			config: func() *Config {
+				config := GetDefaultConfig()
+				config.Security.RateLimit.WindowSizeMinutes = 0
+				return config
+			}(),
+			expectError: true,
+		},
+		{
+			name: "invalid rate limit whitelist entry",
+			config: func() *Config {
+				config := GetDefaultConfig()
+				config.Security.RateLimit.WhitelistIPs = []string{"localhost"}
+				return config
+			}(),
+			expectError: true,
+		},
+		{
+			name: "invalid certificate expiry threshold",
+			config: func() *Config {
+				config := GetDefaultConfig()
+				config.Notifications.CertificateExpiryDays = []int{30, 0}
+				return config
+			}(),
+			expectError: true,
+		},
+		{
+			name: "invalid log level",
+			config: func() *Config {
+				config := GetDefaultConfig()
+				config.Logging.Level = "verbose"
+				return config
+			}(),
+			expectError: true,
+		},
+		{
+			name: "log max size out of range",
+			config: func() *Config {
+				config := GetDefaultConfig()
+				config.Logging.MaxSizeMB = 0
+				return config
+			}(),
+			expectError: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -173,25 +227,22 @@ func TestCreateDefaultConfigFile(t *testing.T) {
 }
 
 func TestGetMigrationDir(t *testing.T) {
-	// Test when migrations directory exists in current directory
+	// A migrations folder in the working directory does not override the
+	// embedded migrations
 	tempDir := t.TempDir()
 	originalWd, _ := os.Getwd()
 	defer os.Chdir(originalWd)
 
 	os.Chdir(tempDir)
-
-	// Create migrations directory
-	migrationDir := filepath.Join(tempDir, "migrations")
-	err := os.MkdirAll(migrationDir, 0755)
+	err := os.MkdirAll(filepath.Join(tempDir, "migrations"), 0755)
 	require.NoError(t, err)
 
-	result := GetMigrationDir()
-	assert.Equal(t, "migrations", result)
+	t.Setenv("SHIEN_MIGRATION_DIR", "")
+	assert.Equal(t, "", GetMigrationDir()) // Embedded migrations are used
 
-	// Test when migrations directory doesn't exist
-	os.RemoveAll(migrationDir)
-	result = GetMigrationDir()
-	assert.Equal(t, "migrations", result) // Should still return fallback
+	// Only the environment variable selects a directory
+	t.Setenv("SHIEN_MIGRATION_DIR", "migrations")
+	assert.Equal(t, "migrations", GetMigrationDir())
 }
 
 func TestGetAppDataDir(t *testing.T) {
@@ -238,4 +289,7 @@ func TestApplyDefaults(t *testing.T) {
 	assert.Equal(t, 12, config.UI.FontSize)
 	assert.Equal(t, "info", config.Logging.Level)
 	assert.NotEmpty(t, config.Logging.FilePath)
+	assert.Equal(t, 30, config.Jobs.RetryBackoffSec)
+	assert.Equal(t, 10, config.Jobs.ShutdownTimeoutSec)
+	assert.Equal(t, []int{30, 60, 90}, config.Notifications.CertificateExpiryDays)
 }
@@ -1,6 +1,11 @@
 package domain
 
-import "time"
+import (
+	"fmt"
+	"net"
+	"strings"
+	"time"
+)
 
 type ID = string
 
@@ -8,9 +13,11 @@ type Staff struct {
 	ID           ID        `json:"id"`
 	Name         string    `json:"name"`
 	Role         StaffRole `json:"role"`
-	PasswordHash string    `json:"-"` // Never include in JSON output for security
+	PasswordHash string    `json:"-"`          // Never include in JSON output for security
+	DateStyle    string    `json:"date_style"` // How dates are shown to this user: gregorian or wareki (see package wareki)
 	CreatedAt    time.Time `json:"created_at"`
 	UpdatedAt    time.Time `json:"updated_at"`
+	Version      int       `json:"version"` // Incremented on every update, see ErrVersionConflict
 }
 
 type StaffRole string
@@ -22,22 +29,219 @@ const (
 )
 
 type Recipient struct {
-	ID               ID         `json:"id"`
-	Name             string     `json:"name"`
-	Kana             string     `json:"kana"`
-	Sex              Sex        `json:"sex"`
-	BirthDate        time.Time  `json:"birth_date"`
-	DisabilityName   string     `json:"disability_name"`
-	HasDisabilityID  bool       `json:"has_disability_id"`
-	Grade            string     `json:"grade"`
-	Address          string     `json:"address"`
-	Phone            string     `json:"phone"`
-	Email            string     `json:"email"`
-	PublicAssistance bool       `json:"public_assistance"`
-	AdmissionDate    *time.Time `json:"admission_date,omitempty"`
-	DischargeDate    *time.Time `json:"discharge_date,omitempty"`
-	CreatedAt        time.Time  `json:"created_at"`
-	UpdatedAt        time.Time  `json:"updated_at"`
+	ID                 ID                   `json:"id"`
+	Name               string               `json:"name"`
+	Kana               string               `json:"kana"`
+	Sex                Sex                  `json:"sex"`
+	BirthDate          time.Time            `json:"birth_date"`
+	DisabilityName     string               `json:"disability_name"`
+	HasDisabilityID    bool                 `json:"has_disability_id"`
+	Grade              string               `json:"grade"`
+	Address            string               `json:"address"`     // Full address; Prefecture + City + Street when those are set
+	PostalCode         string               `json:"postal_code"` // 123-4567
+	Prefecture         string               `json:"prefecture"`
+	City               string               `json:"city"`
+	Street             string               `json:"street"` // Town, block number and building
+	Phone              string               `json:"phone"`
+	Email              string               `json:"email"`
+	PublicAssistance   bool                 `json:"public_assistance"`
+	Handbooks          []DisabilityHandbook `json:"handbooks,omitempty"`
+	SupportCategory    *SupportCategory     `json:"support_category,omitempty"`
+	IntractableDisease *IntractableDisease  `json:"intractable_disease,omitempty"` // 指定難病
+	AdmissionDate      *time.Time           `json:"admission_date,omitempty"`      // Latest enrollment period, see EnrollmentPeriod
+	DischargeDate      *time.Time           `json:"discharge_date,omitempty"`      // Latest enrollment period, see EnrollmentPeriod
+	CreatedAt          time.Time            `json:"created_at"`
+	UpdatedAt          time.Time            `json:"updated_at"`
+	Version            int                  `json:"version"` // Incremented on every update, see ErrVersionConflict
+}
+
+// ComposeAddress joins the address components into the full address.
+// Legacy records have only Address, which is returned unchanged.
+func (r *Recipient) ComposeAddress() string {
+	if r.Prefecture == "" && r.City == "" && r.Street == "" {
+		return r.Address
+	}
+	return r.Prefecture + r.City + r.Street
+}
+
+// Handbook returns the recipient's handbook of the given type, or nil
+func (r *Recipient) Handbook(handbookType HandbookType) *DisabilityHandbook {
+	for i := range r.Handbooks {
+		if r.Handbooks[i].Type == handbookType {
+			return &r.Handbooks[i]
+		}
+	}
+	return nil
+}
+
+// SupportLevelOn returns the 障害支援区分 in effect on the given date, or
+// 0 when the recipient has no valid certification on that date
+func (r *Recipient) SupportLevelOn(date time.Time) int {
+	if r.SupportCategory == nil || !r.SupportCategory.ValidOn(date) {
+		return 0
+	}
+	return r.SupportCategory.Level
+}
+
+// HandbookType identifies one of the three disability handbooks
+type HandbookType string
+
+const (
+	HandbookTypePhysical     HandbookType = "physical"     // 身体障害者手帳
+	HandbookTypeIntellectual HandbookType = "intellectual" // 療育手帳
+	HandbookTypeMental       HandbookType = "mental"       // 精神障害者保健福祉手帳
+)
+
+// HandbookTypes lists the handbook types in display order
+var HandbookTypes = []HandbookType{
+	HandbookTypePhysical,
+	HandbookTypeIntellectual,
+	HandbookTypeMental,
+}
+
+// Label returns the Japanese name of the handbook
+func (t HandbookType) Label() string {
+	switch t {
+	case HandbookTypePhysical:
+		return "身体障害者手帳"
+	case HandbookTypeIntellectual:
+		return "療育手帳"
+	case HandbookTypeMental:
+		return "精神障害者保健福祉手帳"
+	default:
+		return string(t)
+	}
+}
+
+// DisabilityHandbook is one disability handbook held by a recipient. The
+// grade is kept as written on the handbook (e.g. "2級", "A1", "B").
+type DisabilityHandbook struct {
+	Type        HandbookType `json:"type"`
+	Number      string       `json:"number"`
+	Grade       string       `json:"grade"`
+	RenewalDate *time.Time   `json:"renewal_date,omitempty"` // Next renewal or re-examination; nil when the handbook does not expire
+}
+
+// Support category levels. Level 0 records a certification of 非該当.
+const (
+	SupportCategoryNone = 0
+	SupportCategoryMin  = 1
+	SupportCategoryMax  = 6
+)
+
+// SupportCategory is the recipient's 障害支援区分 certification
+type SupportCategory struct {
+	Level      int       `json:"level"` // 1〜6, or SupportCategoryNone for 非該当
+	ValidFrom  time.Time `json:"valid_from"`
+	ValidUntil time.Time `json:"valid_until"`
+}
+
+// Label returns the category as shown on the certification, e.g. "区分3"
+func (c *SupportCategory) Label() string {
+	return SupportCategoryLabel(c.Level)
+}
+
+// ValidOn reports whether the certification is valid on the given date.
+// Both the first and last day count as valid days.
+func (c *SupportCategory) ValidOn(date time.Time) bool {
+	day := truncateToDate(date)
+	return !day.Before(truncateToDate(c.ValidFrom)) && !day.After(truncateToDate(c.ValidUntil))
+}
+
+// SupportCategoryLabel returns the Japanese name of a support category level
+func SupportCategoryLabel(level int) string {
+	if level == SupportCategoryNone {
+		return "非該当"
+	}
+	return fmt.Sprintf("区分%d", level)
+}
+
+// IntractableDisease records a 指定難病 designation
+type IntractableDisease struct {
+	Name       string     `json:"name"`
+	ValidUntil *time.Time `json:"valid_until,omitempty"` // Expiry of the 医療受給者証, when known
+}
+
+// PostalAddress is one entry of the offline postal code dictionary, imported
+// from Japan Post's KEN_ALL.CSV. One code may have several entries.
+type PostalAddress struct {
+	Code           string `json:"code"` // Seven digits without hyphen
+	Prefecture     string `json:"prefecture"`
+	City           string `json:"city"`
+	Town           string `json:"town"` // Empty when the code covers the whole city
+	PrefectureKana string `json:"prefecture_kana"`
+	CityKana       string `json:"city_kana"`
+	TownKana       string `json:"town_kana"`
+}
+
+// Municipality is one entry of the municipality master, imported from CSV
+type Municipality struct {
+	Code       string `json:"code"` // 市町村番号: six digits, the last one a check digit
+	Prefecture string `json:"prefecture"`
+	Name       string `json:"name"`
+}
+
+// ServiceType is one entry of the service type master, imported from CSV
+type ServiceType struct {
+	Code string `json:"code"` // サービス種類コード: two digits
+	Name string `json:"name"`
+}
+
+// RecipientMerge records that a duplicate registration was merged into the
+// surviving recipient. The removed duplicate is kept as it was before the merge.
+type RecipientMerge struct {
+	ID            ID                   `json:"id"`
+	SurvivorID    ID                   `json:"survivor_id"`
+	Merged        Recipient            `json:"merged"`                   // The removed duplicate
+	MergedMedical *MedicalRecord       `json:"merged_medical,omitempty"` // Its medical record, if it had one
+	Moved         RecipientMergeCounts `json:"moved"`
+	Reason        string               `json:"reason"`
+	MergedBy      ID                   `json:"merged_by"`
+	MergedAt      time.Time            `json:"merged_at"`
+}
+
+// RecipientMergeCounts counts the records moved to the surviving recipient
+type RecipientMergeCounts struct {
+	Certificates      int  `json:"certificates"`
+	Assignments       int  `json:"assignments"`
+	Consents          int  `json:"consents"`
+	EnrollmentPeriods int  `json:"enrollment_periods"`
+	EmergencyContacts int  `json:"emergency_contacts"`
+	Incidents         int  `json:"incidents"`
+	MedicalRecord     bool `json:"medical_record"` // False when the survivor already had one
+}
+
+// EnrollmentPeriod represents one admission-to-discharge span of a recipient
+type EnrollmentPeriod struct {
+	ID            ID         `json:"id"`
+	RecipientID   ID         `json:"recipient_id"`
+	AdmissionDate time.Time  `json:"admission_date"`
+	DischargeDate *time.Time `json:"discharge_date,omitempty"` // nil while still enrolled
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
+
+// IsOpen reports whether the period has not been closed by a discharge
+func (p *EnrollmentPeriod) IsOpen() bool {
+	return p.DischargeDate == nil
+}
+
+// CoversDate reports whether the recipient was enrolled on the given date.
+// Both the admission and discharge days count as enrolled days.
+func (p *EnrollmentPeriod) CoversDate(date time.Time) bool {
+	day := truncateToDate(date)
+	if day.Before(truncateToDate(p.AdmissionDate)) {
+		return false
+	}
+	return p.DischargeDate == nil || !day.After(truncateToDate(*p.DischargeDate))
+}
+
+// truncateToDate drops the time-of-day part, keeping the calendar date in
+// t's own location. The repositories store dates as RFC 3339 in that offset
+// and compare the date part, so a JST date stays on its day before 09:00.
+func truncateToDate(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
 }
 
 type Sex string
@@ -52,14 +256,18 @@ const (
 type BenefitCertificate struct {
 	ID                     ID        `json:"id"`
 	RecipientID            ID        `json:"recipient_id"`
+	CertificateNumber      string    `json:"certificate_number"` // 受給者証番号, ten digits
 	StartDate              time.Time `json:"start_date"`
 	EndDate                time.Time `json:"end_date"`
+	MunicipalityCode       string    `json:"municipality_code"` // 市町村番号 of the issuer, see Municipality
 	Issuer                 string    `json:"issuer"`
+	ServiceCode            string    `json:"service_code"` // サービス種類コード, see ServiceType
 	ServiceType            string    `json:"service_type"`
 	MaxBenefitDaysPerMonth int       `json:"max_benefit_days_per_month"`
 	BenefitDetails         string    `json:"benefit_details"`
 	CreatedAt              time.Time `json:"created_at"`
 	UpdatedAt              time.Time `json:"updated_at"`
+	Version                int       `json:"version"` // Incremented on every update, see ErrVersionConflict
 }
 
 type StaffAssignment struct {
@@ -82,6 +290,332 @@ type Consent struct {
 	RevokedAt   *time.Time `json:"revoked_at,omitempty"`
 }
 
+// EmergencyContact represents a guardian, family member or other person to call for a recipient
+type EmergencyContact struct {
+	ID           ID        `json:"id"`
+	RecipientID  ID        `json:"recipient_id"`
+	Priority     int       `json:"priority"` // 1 is called first
+	Name         string    `json:"name"`
+	Relationship string    `json:"relationship"` // 続柄 (母, 兄, 成年後見人 etc.)
+	IsGuardian   bool      `json:"is_guardian"`
+	Phone        string    `json:"phone"`
+	MobilePhone  string    `json:"mobile_phone"`
+	WorkPhone    string    `json:"work_phone"`
+	Email        string    `json:"email"`
+	Notes        string    `json:"notes"` // Whom to call for what, reachable hours etc.
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// MedicalRecord holds the health information staff need to support a recipient safely
+type MedicalRecord struct {
+	RecipientID         ID           `json:"recipient_id"`
+	Medications         []Medication `json:"medications"`
+	Allergies           []Allergy    `json:"allergies"`
+	HasEpilepsy         bool         `json:"has_epilepsy"`
+	SeizureProtocol     string       `json:"seizure_protocol"` // What to do during a seizure, when to call an ambulance
+	HospitalName        string       `json:"hospital_name"`    // かかりつけ医療機関
+	HospitalPhone       string       `json:"hospital_phone"`
+	DoctorName          string       `json:"doctor_name"`    // 主治医
+	InsuranceType       string       `json:"insurance_type"` // 国民健康保険, 社会保険, 後期高齢者医療 etc.
+	InsurerNumber       string       `json:"insurer_number"` // 保険者番号
+	InsuredSymbol       string       `json:"insured_symbol"` // 被保険者証の記号
+	InsuredNumber       string       `json:"insured_number"` // 被保険者証の番号
+	InsuranceValidUntil *time.Time   `json:"insurance_valid_until,omitempty"`
+	Notes               string       `json:"notes"`
+	UpdatedBy           ID           `json:"updated_by"`
+	CreatedAt           time.Time    `json:"created_at"`
+	UpdatedAt           time.Time    `json:"updated_at"`
+}
+
+// Medication is a medicine the recipient currently takes
+type Medication struct {
+	Name   string `json:"name"`
+	Dose   string `json:"dose"`   // e.g. 1錠, 5mg
+	Timing string `json:"timing"` // e.g. 朝食後, 就寝前, 発作時
+	Notes  string `json:"notes"`
+}
+
+// Allergy is a known allergy; Critical marks those that can be life-threatening
+type Allergy struct {
+	Allergen string `json:"allergen"`
+	Reaction string `json:"reaction"`
+	Critical bool   `json:"critical"`
+}
+
+// CriticalAllergies returns the allergies flagged as critical
+func (m *MedicalRecord) CriticalAllergies() []Allergy {
+	var critical []Allergy
+	for _, allergy := range m.Allergies {
+		if allergy.Critical {
+			critical = append(critical, allergy)
+		}
+	}
+	return critical
+}
+
+// IncidentReport records an accident or near miss (事故・ヒヤリハット) and its follow-up
+type IncidentReport struct {
+	ID            ID               `json:"id"`
+	OccurredAt    time.Time        `json:"occurred_at"`
+	Location      string           `json:"location"`
+	RecipientIDs  []ID             `json:"recipient_ids"` // Recipients involved
+	StaffIDs      []ID             `json:"staff_ids"`     // Staff involved or present
+	Category      IncidentCategory `json:"category"`
+	Severity      IncidentSeverity `json:"severity"`
+	Description   string           `json:"description"` // What happened
+	Response      string           `json:"response"`    // Immediate response (対応)
+	Prevention    string           `json:"prevention"`  // Measures to prevent recurrence (再発防止策)
+	Status        IncidentStatus   `json:"status"`
+	ReportedBy    ID               `json:"reported_by"`
+	SubmittedAt   *time.Time       `json:"submitted_at,omitempty"`
+	ReviewedBy    *ID              `json:"reviewed_by,omitempty"`
+	ReviewedAt    *time.Time       `json:"reviewed_at,omitempty"`
+	ReviewComment string           `json:"review_comment"`
+	ApprovedBy    *ID              `json:"approved_by,omitempty"`
+	ApprovedAt    *time.Time       `json:"approved_at,omitempty"`
+	CreatedAt     time.Time        `json:"created_at"`
+	UpdatedAt     time.Time        `json:"updated_at"`
+}
+
+// IncidentCategory classifies what kind of incident occurred
+type IncidentCategory string
+
+const (
+	IncidentCategoryFall       IncidentCategory = "fall"       // 転倒・転落
+	IncidentCategoryMedication IncidentCategory = "medication" // 誤薬・与薬漏れ
+	IncidentCategoryChoking    IncidentCategory = "choking"    // 誤嚥・窒息
+	IncidentCategoryMissing    IncidentCategory = "missing"    // 離設・行方不明
+	IncidentCategoryInjury     IncidentCategory = "injury"     // 外傷・異食
+	IncidentCategoryConflict   IncidentCategory = "conflict"   // 利用者間トラブル・暴力
+	IncidentCategoryTransport  IncidentCategory = "transport"  // 送迎中の事故
+	IncidentCategoryInfection  IncidentCategory = "infection"  // 感染症・食中毒
+	IncidentCategoryPrivacy    IncidentCategory = "privacy"    // 個人情報の漏えい
+	IncidentCategoryOther      IncidentCategory = "other"      // その他
+)
+
+// IncidentCategories lists every category in display order
+var IncidentCategories = []IncidentCategory{
+	IncidentCategoryFall,
+	IncidentCategoryMedication,
+	IncidentCategoryChoking,
+	IncidentCategoryMissing,
+	IncidentCategoryInjury,
+	IncidentCategoryConflict,
+	IncidentCategoryTransport,
+	IncidentCategoryInfection,
+	IncidentCategoryPrivacy,
+	IncidentCategoryOther,
+}
+
+// Label returns the Japanese name of the category
+func (c IncidentCategory) Label() string {
+	switch c {
+	case IncidentCategoryFall:
+		return "転倒・転落"
+	case IncidentCategoryMedication:
+		return "誤薬・与薬漏れ"
+	case IncidentCategoryChoking:
+		return "誤嚥・窒息"
+	case IncidentCategoryMissing:
+		return "離設・行方不明"
+	case IncidentCategoryInjury:
+		return "外傷・異食"
+	case IncidentCategoryConflict:
+		return "利用者間トラブル"
+	case IncidentCategoryTransport:
+		return "送迎中の事故"
+	case IncidentCategoryInfection:
+		return "感染症・食中毒"
+	case IncidentCategoryPrivacy:
+		return "個人情報の漏えい"
+	case IncidentCategoryOther:
+		return "その他"
+	default:
+		return string(c)
+	}
+}
+
+// IncidentSeverity grades the outcome of an incident; near misses did not reach the recipient
+type IncidentSeverity string
+
+const (
+	IncidentSeverityNearMiss IncidentSeverity = "near_miss" // ヒヤリハット
+	IncidentSeverityMinor    IncidentSeverity = "minor"     // 軽微（処置不要・施設内処置）
+	IncidentSeverityModerate IncidentSeverity = "moderate"  // 受診を要した
+	IncidentSeveritySevere   IncidentSeverity = "severe"    // 入院・死亡等の重大事故
+)
+
+// IncidentSeverities lists every severity from least to most serious
+var IncidentSeverities = []IncidentSeverity{
+	IncidentSeverityNearMiss,
+	IncidentSeverityMinor,
+	IncidentSeverityModerate,
+	IncidentSeveritySevere,
+}
+
+// Label returns the Japanese name of the severity
+func (s IncidentSeverity) Label() string {
+	switch s {
+	case IncidentSeverityNearMiss:
+		return "ヒヤリハット"
+	case IncidentSeverityMinor:
+		return "事故（軽微）"
+	case IncidentSeverityModerate:
+		return "事故（要受診）"
+	case IncidentSeveritySevere:
+		return "事故（重大）"
+	default:
+		return string(s)
+	}
+}
+
+// IsAccident reports whether the incident actually reached the recipient
+func (s IncidentSeverity) IsAccident() bool {
+	return s != IncidentSeverityNearMiss
+}
+
+// IncidentStatus tracks an incident report through the submit → review → approve workflow
+type IncidentStatus string
+
+const (
+	IncidentStatusDraft     IncidentStatus = "draft"     // 作成中 (also after being sent back)
+	IncidentStatusSubmitted IncidentStatus = "submitted" // 提出済み
+	IncidentStatusReviewed  IncidentStatus = "reviewed"  // 確認済み
+	IncidentStatusApproved  IncidentStatus = "approved"  // 承認済み
+)
+
+// Label returns the Japanese name of the status
+func (s IncidentStatus) Label() string {
+	switch s {
+	case IncidentStatusDraft:
+		return "作成中"
+	case IncidentStatusSubmitted:
+		return "提出済み"
+	case IncidentStatusReviewed:
+		return "確認済み"
+	case IncidentStatusApproved:
+		return "承認済み"
+	default:
+		return string(s)
+	}
+}
+
+// IncidentFilter defines filters for querying incident reports
+type IncidentFilter struct {
+	OccurredFrom *time.Time
+	OccurredTo   *time.Time // Exclusive
+	Status       *IncidentStatus
+	Category     *IncidentCategory
+	RecipientID  *ID
+}
+
+// IncidentStatistics summarizes the incidents of one month
+type IncidentStatistics struct {
+	Year       int                      `json:"year"`
+	Month      time.Month               `json:"month"`
+	Total      int                      `json:"total"`
+	NearMisses int                      `json:"near_misses"`
+	Accidents  int                      `json:"accidents"`
+	ByCategory map[IncidentCategory]int `json:"by_category"`
+	BySeverity map[IncidentSeverity]int `json:"by_severity"`
+}
+
+// JobState is the persisted run state of a background maintenance job
+type JobState struct {
+	Name                string        `json:"name"`
+	LastRunAt           *time.Time    `json:"last_run_at,omitempty"`
+	LastSuccessAt       *time.Time    `json:"last_success_at,omitempty"`
+	LastDuration        time.Duration `json:"last_duration"`
+	LastError           string        `json:"last_error"` // Empty when the last run succeeded
+	ConsecutiveFailures int           `json:"consecutive_failures"`
+	NextRunAt           *time.Time    `json:"next_run_at,omitempty"`
+	UpdatedAt           time.Time     `json:"updated_at"`
+}
+
+// Notification is an in-app alert raised by the periodic checks. The same
+// condition keeps a single open notification (identified by DedupKey) until
+// it no longer holds and the notification is resolved.
+type Notification struct {
+	ID         ID                   `json:"id"`
+	Kind       NotificationKind     `json:"kind"`
+	Severity   NotificationSeverity `json:"severity"`
+	Audience   NotificationAudience `json:"audience"`
+	Title      string               `json:"title"`
+	Message    string               `json:"message"` // May contain recipient names; encrypted at rest
+	TargetType string               `json:"target_type"`
+	TargetID   ID                   `json:"target_id"`
+	DedupKey   string               `json:"dedup_key"`
+	CreatedAt  time.Time            `json:"created_at"`
+	ResolvedAt *time.Time           `json:"resolved_at,omitempty"`
+
+	// Per-user state, populated when listing notifications for a staff member
+	ReadAt         *time.Time `json:"read_at,omitempty"`
+	AcknowledgedAt *time.Time `json:"acknowledged_at,omitempty"`
+}
+
+// IsRead reports whether the staff member the notification was listed for has read it
+func (n *Notification) IsRead() bool {
+	return n.ReadAt != nil
+}
+
+// NotificationKind identifies the check that raised a notification
+type NotificationKind string
+
+const (
+	NotificationKindCertificateExpiring NotificationKind = "certificate_expiring" // 受給者証の期限が近い
+	NotificationKindDischargedAssigned  NotificationKind = "discharged_assigned"  // 退所済みだが担当者が残っている
+	NotificationKindAccountLocked       NotificationKind = "account_locked"       // アカウントロック中
+	NotificationKindDisabilityRenewal   NotificationKind = "disability_renewal"   // 手帳・障害支援区分・難病の更新が近い
+)
+
+// Label returns the Japanese name of the notification kind
+func (k NotificationKind) Label() string {
+	switch k {
+	case NotificationKindCertificateExpiring:
+		return "受給者証期限"
+	case NotificationKindDischargedAssigned:
+		return "退所者の担当"
+	case NotificationKindAccountLocked:
+		return "アカウントロック"
+	case NotificationKindDisabilityRenewal:
+		return "手帳・区分の更新"
+	default:
+		return string(k)
+	}
+}
+
+// NotificationSeverity indicates how urgently a notification needs attention
+type NotificationSeverity string
+
+const (
+	NotificationSeverityInfo     NotificationSeverity = "info"
+	NotificationSeverityWarning  NotificationSeverity = "warning"
+	NotificationSeverityCritical NotificationSeverity = "critical"
+)
+
+// Label returns the Japanese name of the severity
+func (s NotificationSeverity) Label() string {
+	switch s {
+	case NotificationSeverityInfo:
+		return "お知らせ"
+	case NotificationSeverityWarning:
+		return "注意"
+	case NotificationSeverityCritical:
+		return "重要"
+	default:
+		return string(s)
+	}
+}
+
+// NotificationAudience restricts which staff members see a notification
+type NotificationAudience string
+
+const (
+	NotificationAudienceAll   NotificationAudience = "all"   // Every staff member
+	NotificationAudienceAdmin NotificationAudience = "admin" // Administrators only
+)
+
 type AuditLog struct {
 	ID      ID        `json:"id"`
 	ActorID ID        `json:"actor_id"`
@@ -92,6 +626,23 @@ type AuditLog struct {
 	Details string    `json:"details"`
 }
 
+// DisclosurePackage gathers every record held about a recipient for a
+// personal information disclosure request (開示請求)
+type DisclosurePackage struct {
+	Recipient         Recipient            `json:"recipient"`
+	EnrollmentPeriods []EnrollmentPeriod   `json:"enrollment_periods"`
+	Certificates      []BenefitCertificate `json:"certificates"`
+	Assignments       []StaffAssignment    `json:"assignments"`
+	Consents          []Consent            `json:"consents"`
+	EmergencyContacts []EmergencyContact   `json:"emergency_contacts"`
+	MedicalRecord     *MedicalRecord       `json:"medical_record,omitempty"`
+	Incidents         []IncidentReport     `json:"incidents"`  // Incident reports involving the recipient
+	Merges            []RecipientMerge     `json:"merges"`     // Duplicate records merged into the recipient
+	AuditLogs         []AuditLog           `json:"audit_logs"` // Events concerning the recipient and their records
+	GeneratedAt       time.Time            `json:"generated_at"`
+	GeneratedBy       ID                   `json:"generated_by"`
+}
+
 // AuditLogFilter defines filters for querying audit logs
 type AuditLogFilter struct {
 	ActorID   *ID
@@ -139,6 +690,7 @@ const (
 // RateLimitConfig represents rate limiting configuration
 type RateLimitConfig struct {
 	ID                       ID        `json:"id"`
+	Enabled                  bool      `json:"enabled"`
 	MaxAttemptsPerIP         int       `json:"max_attempts_per_ip"`
 	MaxAttemptsPerUser       int       `json:"max_attempts_per_user"`
 	WindowSizeMinutes        int       `json:"window_size_minutes"`
@@ -147,10 +699,73 @@ type RateLimitConfig struct {
 	MaxLockoutHours          int       `json:"max_lockout_hours"`
 	WhitelistIPs             []string  `json:"whitelist_ips"`
 	EnableProgressiveLockout bool      `json:"enable_progressive_lockout"`
+	Source                   string    `json:"source"`      // Where the effective values came from
+	ConfigHash               string    `json:"config_hash"` // Hash of the config file section last seen
+	UpdatedBy                ID        `json:"updated_by,omitempty"`
 	CreatedAt                time.Time `json:"created_at"`
 	UpdatedAt                time.Time `json:"updated_at"`
 }
 
+// Rate limit configuration sources
+const (
+	RateLimitSourceDefault = "default" // Built-in defaults
+	RateLimitSourceConfig  = "config"  // Synchronized from config.yaml
+	RateLimitSourceAdmin   = "admin"   // Edited on the admin screen
+)
+
+// Allowed ranges for rate limit settings
+const (
+	RateLimitMaxAttemptsPerIP     = 1000
+	RateLimitMaxAttemptsPerUser   = 100
+	RateLimitMaxWindowMinutes     = 24 * 60
+	RateLimitMaxLockoutMinutes    = 24 * 60
+	RateLimitMinBackoffMultiplier = 1.0
+	RateLimitMaxBackoffMultiplier = 10.0
+	RateLimitMaxLockoutHoursLimit = 30 * 24
+)
+
+// Validate checks that every setting is within its allowed range and
+// returns one message per violation
+func (c *RateLimitConfig) Validate() []string {
+	var errs []string
+
+	if c.MaxAttemptsPerIP < 1 || c.MaxAttemptsPerIP > RateLimitMaxAttemptsPerIP {
+		errs = append(errs, fmt.Sprintf("IPごとの試行上限は1〜%d回で指定してください", RateLimitMaxAttemptsPerIP))
+	}
+	if c.MaxAttemptsPerUser < 1 || c.MaxAttemptsPerUser > RateLimitMaxAttemptsPerUser {
+		errs = append(errs, fmt.Sprintf("ユーザーごとの試行上限は1〜%d回で指定してください", RateLimitMaxAttemptsPerUser))
+	}
+	if c.WindowSizeMinutes < 1 || c.WindowSizeMinutes > RateLimitMaxWindowMinutes {
+		errs = append(errs, fmt.Sprintf("集計期間は1〜%d分で指定してください", RateLimitMaxWindowMinutes))
+	}
+	if c.LockoutDurationMinutes < 1 || c.LockoutDurationMinutes > RateLimitMaxLockoutMinutes {
+		errs = append(errs, fmt.Sprintf("ロック時間は1〜%d分で指定してください", RateLimitMaxLockoutMinutes))
+	}
+	if c.BackoffMultiplier < RateLimitMinBackoffMultiplier || c.BackoffMultiplier > RateLimitMaxBackoffMultiplier {
+		errs = append(errs, fmt.Sprintf("延長倍率は%.1f〜%.1fで指定してください", RateLimitMinBackoffMultiplier, RateLimitMaxBackoffMultiplier))
+	}
+	if c.MaxLockoutHours < 1 || c.MaxLockoutHours > RateLimitMaxLockoutHoursLimit {
+		errs = append(errs, fmt.Sprintf("最大ロック時間は1〜%d時間で指定してください", RateLimitMaxLockoutHoursLimit))
+	} else if c.MaxLockoutHours*60 < c.LockoutDurationMinutes {
+		errs = append(errs, "最大ロック時間はロック時間以上にしてください")
+	}
+	for _, entry := range c.WhitelistIPs {
+		if !isIPOrCIDR(entry) {
+			errs = append(errs, fmt.Sprintf("許可リストの形式が正しくありません: %s", entry))
+		}
+	}
+
+	return errs
+}
+
+func isIPOrCIDR(entry string) bool {
+	if strings.Contains(entry, "/") {
+		_, _, err := net.ParseCIDR(entry)
+		return err == nil
+	}
+	return net.ParseIP(entry) != nil
+}
+
 // AttackPattern represents detected attack patterns
 type AttackPattern struct {
 	ID              ID        `json:"id"`
@@ -164,3 +779,24 @@ type AttackPattern struct {
 	Status          string    `json:"status"` // active, blocked, resolved
 	Details         string    `json:"details"`
 }
+
+// Attack pattern types recorded by the detector
+const (
+	AttackPatternTypeDistributed        = "distributed"         // Many IPs targeting the same username
+	AttackPatternTypeCredentialStuffing = "credential_stuffing" // One IP trying many usernames
+)
+
+// Attack pattern severities, from least to most severe
+const (
+	AttackSeverityLow      = "low"
+	AttackSeverityMedium   = "medium"
+	AttackSeverityHigh     = "high"
+	AttackSeverityCritical = "critical"
+)
+
+// Attack pattern statuses
+const (
+	AttackPatternStatusActive   = "active"   // Detected and not yet handled
+	AttackPatternStatusBlocked  = "blocked"  // Sources locked out by an administrator
+	AttackPatternStatusResolved = "resolved" // Closed by an administrator
+)
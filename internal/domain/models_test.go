@@ -395,3 +395,163 @@ func BenchmarkRecipient_JSONMarshal(b *testing.B) {
 		}
 	}
 }
+
+func TestEnrollmentPeriod_CoversDate(t *testing.T) {
+	discharge := time.Date(2021, 3, 31, 0, 0, 0, 0, time.UTC)
+	closed := EnrollmentPeriod{
+		ID:            "period-001",
+		RecipientID:   "recipient-001",
+		AdmissionDate: time.Date(2020, 4, 1, 0, 0, 0, 0, time.UTC),
+		DischargeDate: &discharge,
+	}
+	open := EnrollmentPeriod{
+		ID:            "period-002",
+		RecipientID:   "recipient-001",
+		AdmissionDate: time.Date(2022, 4, 1, 0, 0, 0, 0, time.UTC),
+	}
+	jst := time.FixedZone("JST", 9*60*60)
+	jstDischarge := time.Date(2025, 3, 31, 0, 0, 0, 0, jst)
+	jstPeriod := EnrollmentPeriod{
+		ID:            "period-003",
+		RecipientID:   "recipient-002",
+		AdmissionDate: time.Date(2024, 4, 1, 0, 0, 0, 0, jst),
+		DischargeDate: &jstDischarge,
+	}
+
+	if closed.IsOpen() {
+		t.Error("period with discharge date should not be open")
+	}
+	if !open.IsOpen() {
+		t.Error("period without discharge date should be open")
+	}
+
+	testCases := []struct {
+		name   string
+		period EnrollmentPeriod
+		date   time.Time
+		want   bool
+	}{
+		{"day before admission", closed, time.Date(2020, 3, 31, 23, 59, 0, 0, time.UTC), false},
+		{"admission day", closed, time.Date(2020, 4, 1, 12, 0, 0, 0, time.UTC), true},
+		{"discharge day", closed, time.Date(2021, 3, 31, 18, 0, 0, 0, time.UTC), true},
+		{"day after discharge", closed, time.Date(2021, 4, 1, 0, 0, 0, 0, time.UTC), false},
+		{"open period far future", open, time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC), true},
+		// Before 09:00 JST the UTC date is still the previous day
+		{"JST admission day early morning", jstPeriod, time.Date(2024, 4, 1, 8, 0, 0, 0, jst), true},
+		{"JST day after discharge early morning", jstPeriod, time.Date(2025, 4, 1, 8, 0, 0, 0, jst), false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.period.CoversDate(tc.date); got != tc.want {
+				t.Errorf("CoversDate(%v) = %v, want %v", tc.date, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestRecipient_SupportLevelOn(t *testing.T) {
+	recipient := Recipient{
+		SupportCategory: &SupportCategory{
+			Level:      4,
+			ValidFrom:  time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC),
+			ValidUntil: time.Date(2027, 3, 31, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	testCases := []struct {
+		name string
+		date time.Time
+		want int
+	}{
+		{"day before certification", time.Date(2024, 3, 31, 12, 0, 0, 0, time.UTC), 0},
+		{"first day", time.Date(2024, 4, 1, 9, 0, 0, 0, time.UTC), 4},
+		{"last day", time.Date(2027, 3, 31, 18, 0, 0, 0, time.UTC), 4},
+		{"expired", time.Date(2027, 4, 1, 0, 0, 0, 0, time.UTC), 0},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := recipient.SupportLevelOn(tc.date); got != tc.want {
+				t.Errorf("SupportLevelOn(%v) = %d, want %d", tc.date, got, tc.want)
+			}
+		})
+	}
+
+	if got := (&Recipient{}).SupportLevelOn(time.Now()); got != 0 {
+		t.Errorf("SupportLevelOn() without certification = %d, want 0", got)
+	}
+	if got := recipient.SupportCategory.Label(); got != "区分4" {
+		t.Errorf("Label() = %q, want 区分4", got)
+	}
+	if got := SupportCategoryLabel(SupportCategoryNone); got != "非該当" {
+		t.Errorf("SupportCategoryLabel(0) = %q, want 非該当", got)
+	}
+}
+
+func TestMedicalRecord_CriticalAllergies(t *testing.T) {
+	record := MedicalRecord{
+		RecipientID: "recipient-001",
+		Allergies: []Allergy{
+			{Allergen: "花粉", Reaction: "鼻炎"},
+			{Allergen: "そば", Reaction: "アナフィラキシー", Critical: true},
+			{Allergen: "ペニシリン", Reaction: "呼吸困難", Critical: true},
+		},
+	}
+
+	critical := record.CriticalAllergies()
+	if len(critical) != 2 {
+		t.Fatalf("CriticalAllergies() returned %d allergies, want 2", len(critical))
+	}
+	if critical[0].Allergen != "そば" || critical[1].Allergen != "ペニシリン" {
+		t.Errorf("CriticalAllergies() = %+v", critical)
+	}
+
+	empty := MedicalRecord{Allergies: []Allergy{{Allergen: "花粉"}}}
+	if len(empty.CriticalAllergies()) != 0 {
+		t.Error("record without critical allergies should return none")
+	}
+}
+
+func TestRateLimitConfig_Validate(t *testing.T) {
+	valid := func() *RateLimitConfig {
+		return &RateLimitConfig{
+			Enabled:                true,
+			MaxAttemptsPerIP:       5,
+			MaxAttemptsPerUser:     3,
+			WindowSizeMinutes:      15,
+			LockoutDurationMinutes: 30,
+			BackoffMultiplier:      2.0,
+			MaxLockoutHours:        24,
+			WhitelistIPs:           []string{"127.0.0.1", "::1", "10.0.0.0/8"},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		modify   func(c *RateLimitConfig)
+		wantErrs int
+	}{
+		{"valid", func(c *RateLimitConfig) {}, 0},
+		{"zero attempts per IP", func(c *RateLimitConfig) { c.MaxAttemptsPerIP = 0 }, 1},
+		{"too many attempts per user", func(c *RateLimitConfig) { c.MaxAttemptsPerUser = 101 }, 1},
+		{"window too long", func(c *RateLimitConfig) { c.WindowSizeMinutes = 1441 }, 1},
+		{"backoff below one", func(c *RateLimitConfig) { c.BackoffMultiplier = 0.5 }, 1},
+		{"max lockout shorter than lockout", func(c *RateLimitConfig) {
+			c.LockoutDurationMinutes = 120
+			c.MaxLockoutHours = 1
+		}, 1},
+		{"invalid whitelist entries", func(c *RateLimitConfig) {
+			c.WhitelistIPs = []string{"localhost", "10.0.0.0/33"}
+		}, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := valid()
+			tt.modify(config)
+			if errs := config.Validate(); len(errs) != tt.wantErrs {
+				t.Errorf("Validate() = %v, want %d errors", errs, tt.wantErrs)
+			}
+		})
+	}
+}
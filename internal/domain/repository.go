@@ -31,11 +31,53 @@ type RecipientRepository interface {
 	Update(ctx context.Context, recipient *Recipient) error
 	Delete(ctx context.Context, id ID) error
 	List(ctx context.Context, limit, offset int) ([]*Recipient, error)
-	Search(ctx context.Context, query string, limit, offset int) ([]*Recipient, error)
+	Search(ctx context.Context, query string, limit, offset int) ([]*Recipient, error) // Matches name or kana ignoring width and hiragana/katakana
 	GetByStaffID(ctx context.Context, staffID ID) ([]*Recipient, error)
-	GetActive(ctx context.Context, limit, offset int) ([]*Recipient, error) // Not discharged
+	GetActive(ctx context.Context, asOf time.Time, limit, offset int) ([]*Recipient, error) // Enrolled on asOf
 	Count(ctx context.Context) (int, error)
-	CountActive(ctx context.Context) (int, error)
+	CountActive(ctx context.Context, asOf time.Time) (int, error)
+}
+
+// RecipientMergeRepository merges duplicate recipients and keeps the merge history
+type RecipientMergeRepository interface {
+	// Merge moves every record of merge.Merged to the survivor, stores the
+	// history entry and deletes the merged recipient, filling in merge.Moved
+	Merge(ctx context.Context, merge *RecipientMerge) error
+	GetBySurvivorID(ctx context.Context, survivorID ID) ([]*RecipientMerge, error) // Newest first
+}
+
+// PostalCodeRepository defines the interface for the offline postal code dictionary
+type PostalCodeRepository interface {
+	FindByCode(ctx context.Context, code string) ([]*PostalAddress, error) // code is seven digits; none when unknown
+	ReplaceAll(ctx context.Context, addresses []*PostalAddress) error      // Replaces the whole dictionary atomically
+	Count(ctx context.Context) (int, error)
+}
+
+// MunicipalityRepository defines the interface for the municipality master
+type MunicipalityRepository interface {
+	List(ctx context.Context) ([]*Municipality, error)                    // Ordered by code
+	FindByCode(ctx context.Context, code string) (*Municipality, error)   // ErrNotFound when unknown
+	ReplaceAll(ctx context.Context, municipalities []*Municipality) error // Replaces the whole master atomically
+	Count(ctx context.Context) (int, error)
+}
+
+// ServiceTypeRepository defines the interface for the service type master
+type ServiceTypeRepository interface {
+	List(ctx context.Context) ([]*ServiceType, error)                  // Ordered by code
+	FindByCode(ctx context.Context, code string) (*ServiceType, error) // ErrNotFound when unknown
+	ReplaceAll(ctx context.Context, serviceTypes []*ServiceType) error // Replaces the whole master atomically
+	Count(ctx context.Context) (int, error)
+}
+
+// EnrollmentPeriodRepository defines the interface for enrollment period data access
+type EnrollmentPeriodRepository interface {
+	Create(ctx context.Context, period *EnrollmentPeriod) error
+	GetByID(ctx context.Context, id ID) (*EnrollmentPeriod, error)
+	Update(ctx context.Context, period *EnrollmentPeriod) error
+	Delete(ctx context.Context, id ID) error
+	GetByRecipientID(ctx context.Context, recipientID ID) ([]*EnrollmentPeriod, error)
+	GetOpenByRecipientID(ctx context.Context, recipientID ID) (*EnrollmentPeriod, error)
+	GetLatestByRecipientID(ctx context.Context, recipientID ID) (*EnrollmentPeriod, error)
 }
 
 // BenefitCertificateRepository defines the interface for benefit certificate data access
@@ -80,6 +122,53 @@ type ConsentRepository interface {
 	Count(ctx context.Context) (int, error)
 }
 
+// EmergencyContactRepository defines the interface for emergency contact data access
+type EmergencyContactRepository interface {
+	Create(ctx context.Context, contact *EmergencyContact) error
+	GetByID(ctx context.Context, id ID) (*EmergencyContact, error)
+	Update(ctx context.Context, contact *EmergencyContact) error
+	Delete(ctx context.Context, id ID) error
+	GetByRecipientID(ctx context.Context, recipientID ID) ([]*EmergencyContact, error) // Ordered by priority
+}
+
+// MedicalRecordRepository defines the interface for medical record data access
+type MedicalRecordRepository interface {
+	GetByRecipientID(ctx context.Context, recipientID ID) (*MedicalRecord, error)
+	Save(ctx context.Context, record *MedicalRecord) error // Creates or replaces the recipient's record
+	Delete(ctx context.Context, recipientID ID) error
+}
+
+// IncidentReportRepository defines the interface for incident report data access
+type IncidentReportRepository interface {
+	Create(ctx context.Context, report *IncidentReport) error
+	GetByID(ctx context.Context, id ID) (*IncidentReport, error)
+	Update(ctx context.Context, report *IncidentReport) error
+	List(ctx context.Context, filter IncidentFilter, limit, offset int) ([]*IncidentReport, error) // Newest first
+}
+
+// JobStateRepository defines the interface for background job state persistence
+type JobStateRepository interface {
+	GetByName(ctx context.Context, name string) (*JobState, error)
+	Save(ctx context.Context, state *JobState) error // Creates or replaces the state
+	List(ctx context.Context) ([]*JobState, error)
+}
+
+// NotificationRepository defines the interface for notification data access.
+// Listing methods return only notifications visible to the given audiences
+// and fill in the per-user read and acknowledgement state.
+type NotificationRepository interface {
+	// CreateIfAbsent stores the notification unless an open one with the same DedupKey exists
+	CreateIfAbsent(ctx context.Context, notification *Notification) (bool, error)
+	GetByID(ctx context.Context, id ID) (*Notification, error)
+	// ResolveStale resolves open notifications of a kind whose DedupKey is not in activeKeys
+	ResolveStale(ctx context.Context, kind NotificationKind, activeKeys []string, resolvedAt time.Time) (int, error)
+	ListForUser(ctx context.Context, userID ID, audiences []NotificationAudience, unreadOnly bool, limit, offset int) ([]*Notification, error)
+	CountUnread(ctx context.Context, userID ID, audiences []NotificationAudience) (int, error)
+	MarkRead(ctx context.Context, id ID, userID ID, readAt time.Time) error
+	MarkAllRead(ctx context.Context, userID ID, audiences []NotificationAudience, readAt time.Time) error
+	Acknowledge(ctx context.Context, id ID, userID ID, acknowledgedAt time.Time) error
+}
+
 // AuditLogRepository defines the interface for audit log data access
 type AuditLogRepository interface {
 	Create(ctx context.Context, log *AuditLog) error
@@ -102,6 +191,7 @@ type LoginAttemptRepository interface {
 	GetByIPAddress(ctx context.Context, ipAddress string, since time.Time) ([]*LoginAttempt, error)
 	GetByUsername(ctx context.Context, username string, since time.Time) ([]*LoginAttempt, error)
 	GetFailedAttempts(ctx context.Context, ipAddress, username string, since time.Time) ([]*LoginAttempt, error)
+	GetRecentFailures(ctx context.Context, since time.Time, limit int) ([]*LoginAttempt, error)
 	DeleteOldAttempts(ctx context.Context, before time.Time) error
 	CountRecentFailures(ctx context.Context, ipAddress, username string, since time.Time) (int, error)
 }
@@ -134,6 +224,8 @@ type AttackPatternRepository interface {
 	GetByID(ctx context.Context, id ID) (*AttackPattern, error)
 	GetActiveByIP(ctx context.Context, sourceIP string) ([]*AttackPattern, error)
 	GetByTimeRange(ctx context.Context, start, end time.Time) ([]*AttackPattern, error)
+	GetUnresolved(ctx context.Context) ([]*AttackPattern, error)
+	Update(ctx context.Context, pattern *AttackPattern) error
 	UpdateStatus(ctx context.Context, id ID, status string) error
 	List(ctx context.Context, limit, offset int) ([]*AttackPattern, error)
 	Count(ctx context.Context) (int, error)
@@ -201,4 +293,7 @@ var (
 	ErrAlreadyExists = &RepositoryError{Op: "already exists"}
 	ErrInvalidInput  = &RepositoryError{Op: "invalid input"}
 	ErrConstraint    = &RepositoryError{Op: "constraint violation"}
+	// ErrVersionConflict is returned by Update when the stored row has a
+	// different version than the entity, i.e. someone else saved it first
+	ErrVersionConflict = &RepositoryError{Op: "version conflict"}
 )
@@ -29,12 +29,14 @@ func TestAuthenticationIntegration_CompleteFlow(t *testing.T) {
 	attemptRepo := db.NewLoginAttemptRepository(database)
 	lockoutRepo := db.NewAccountLockoutRepository(database)
 	configRepo := db.NewRateLimitConfigRepository(database)
+	patternRepo := db.NewAttackPatternRepository(database)
 	
 	// Initialize rate limit service
 	rateLimitSvc := usecase.NewRateLimitService(
 		attemptRepo,
 		lockoutRepo,
 		configRepo,
+		patternRepo,
 		auditRepo,
 	)
 
@@ -156,12 +158,14 @@ func TestAuthenticationIntegration_InvalidCredentials(t *testing.T) {
 	attemptRepo := db.NewLoginAttemptRepository(database)
 	lockoutRepo := db.NewAccountLockoutRepository(database)
 	configRepo := db.NewRateLimitConfigRepository(database)
+	patternRepo := db.NewAttackPatternRepository(database)
 	
 	// Initialize rate limit service
 	rateLimitSvc := usecase.NewRateLimitService(
 		attemptRepo,
 		lockoutRepo,
 		configRepo,
+		patternRepo,
 		auditRepo,
 	)
 
@@ -196,12 +200,14 @@ func TestAuthenticationIntegration_SessionExpiry(t *testing.T) {
 	attemptRepo := db.NewLoginAttemptRepository(database)
 	lockoutRepo := db.NewAccountLockoutRepository(database)
 	configRepo := db.NewRateLimitConfigRepository(database)
+	patternRepo := db.NewAttackPatternRepository(database)
 	
 	// Initialize rate limit service
 	rateLimitSvc := usecase.NewRateLimitService(
 		attemptRepo,
 		lockoutRepo,
 		configRepo,
+		patternRepo,
 		auditRepo,
 	)
 
@@ -260,12 +266,14 @@ func TestAuthenticationIntegration_MultipleUsers(t *testing.T) {
 	attemptRepo := db.NewLoginAttemptRepository(database)
 	lockoutRepo := db.NewAccountLockoutRepository(database)
 	configRepo := db.NewRateLimitConfigRepository(database)
+	patternRepo := db.NewAttackPatternRepository(database)
 	
 	// Initialize rate limit service
 	rateLimitSvc := usecase.NewRateLimitService(
 		attemptRepo,
 		lockoutRepo,
 		configRepo,
+		patternRepo,
 		auditRepo,
 	)
 
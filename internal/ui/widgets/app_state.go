@@ -2,14 +2,17 @@ package widgets
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"shien-system/internal/adapter/pdf"
+	"shien-system/internal/adapter/scheduler"
 	"shien-system/internal/config"
 	"shien-system/internal/domain"
 	"shien-system/internal/usecase"
 
 	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
 )
 
 // StateObserver defines an interface for observing app state changes
@@ -35,12 +38,30 @@ type AppState struct {
 	config *config.Config
 
 	// Use cases
-	authUseCase        usecase.AuthUseCase
-	recipientUseCase   usecase.RecipientUseCase
-	certificateUseCase usecase.CertificateUseCase
-	staffUseCase       usecase.StaffUseCase
-	setupUseCase       usecase.SetupUseCase
-	backupUseCase      *usecase.BackupUseCase
+	authUseCase            usecase.AuthUseCase
+	recipientUseCase       usecase.RecipientUseCase
+	certificateUseCase     usecase.CertificateUseCase
+	staffUseCase           usecase.StaffUseCase
+	setupUseCase           usecase.SetupUseCase
+	backupUseCase          *usecase.BackupUseCase
+	disclosureUseCase      usecase.DisclosureUseCase
+	exportUseCase          usecase.ExportUseCase
+	contactUseCase         usecase.EmergencyContactUseCase
+	medicalUseCase         usecase.MedicalRecordUseCase
+	incidentUseCase        usecase.IncidentUseCase
+	notificationUseCase    usecase.NotificationUseCase
+	securityUseCase        usecase.SecurityUseCase
+	rateLimitPolicyUseCase usecase.RateLimitPolicyUseCase
+	sessionUseCase         usecase.SessionUseCase
+	logUseCase             usecase.LogUseCase
+	integrityUseCase       usecase.IntegrityUseCase
+	postalCodeUseCase      usecase.PostalCodeUseCase
+	importUseCase          usecase.ImportUseCase
+	recipientMergeUseCase  usecase.RecipientMergeUseCase
+	masterDataUseCase      usecase.MasterDataUseCase
+
+	// Background job scheduler
+	jobScheduler *scheduler.Scheduler
 
 	// Services
 	pdfService *pdf.PDFService
@@ -60,6 +81,15 @@ type AppState struct {
 	certificateForm     *CertificateForm
 	certificateList     *CertificateList
 	auditLogList        *AuditLogList
+	incidentList        *IncidentList
+	jobStatusPanel      *JobStatusPanel
+	notificationCenter  *NotificationCenter
+	securityView        *SecurityView
+	sessionView         *SessionView
+	logViewer           *LogViewer
+	integrityView       *IntegrityView
+	importView          *ImportView
+	duplicateView       *DuplicateView
 	staffList           *StaffList
 	staffForm           *StaffForm
 	settingsView        *SettingsView
@@ -151,6 +181,15 @@ func (as *AppState) Logout() {
 	as.certificateForm = nil
 	as.certificateList = nil
 	as.auditLogList = nil
+	as.incidentList = nil
+	as.jobStatusPanel = nil
+	as.notificationCenter = nil
+	as.securityView = nil
+	as.sessionView = nil
+	as.logViewer = nil
+	as.integrityView = nil
+	as.importView = nil
+	as.duplicateView = nil
 	as.staffList = nil
 	as.staffForm = nil
 	as.settingsView = nil
@@ -189,6 +228,10 @@ func (as *AppState) SetCurrentView(view string) {
 		// Non-authenticated users can only access login view
 		return
 	}
+	// A session ended elsewhere takes effect at the next navigation
+	if as.isAuthenticated && !as.checkSession() {
+		return
+	}
 	oldView := as.currentView
 	as.currentView = view
 
@@ -198,6 +241,31 @@ func (as *AppState) SetCurrentView(view string) {
 	}
 }
 
+// checkSession logs out when the current session has been revoked or has expired
+func (as *AppState) checkSession() bool {
+	if as.authUseCase == nil || as.sessionID == "" {
+		return true
+	}
+
+	_, err := as.authUseCase.ValidateSession(context.Background(), as.sessionID)
+	if err == nil {
+		return true
+	}
+
+	var useCaseErr *usecase.UseCaseError
+	if !errors.As(err, &useCaseErr) ||
+		(err != usecase.ErrSessionRevoked && err != usecase.ErrInvalidSession) {
+		// Keep working when the check itself failed
+		return true
+	}
+
+	as.Logout()
+	if as.window != nil {
+		dialog.ShowInformation("ログアウトしました", useCaseErr.Message, as.window)
+	}
+	return false
+}
+
 // GetSetupForm returns the setup form (lazy loading)
 func (as *AppState) GetSetupForm() *SetupForm {
 	if as.setupForm == nil {
@@ -237,6 +305,9 @@ func (as *AppState) GetRecipientList() *RecipientList {
 			as.staffUseCase,
 			as.pdfService,
 		)
+		as.recipientList.SetEmergencyContactUseCase(as.contactUseCase)
+		as.recipientList.SetMedicalRecordUseCase(as.medicalUseCase, as.currentUser)
+		as.recipientList.SetExportUseCase(as.exportUseCase, as.currentUser)
 
 		// Set up event handlers
 		as.recipientList.SetOnNewRecipient(func() {
@@ -262,6 +333,7 @@ func (as *AppState) GetStaffList() *StaffList {
 
 	if as.staffList == nil && as.staffUseCase != nil {
 		as.staffList = NewStaffList(as.staffUseCase, as.pdfService)
+		as.staffList.SetExportUseCase(as.exportUseCase, as.currentUser)
 
 		// Set up event handlers
 		as.staffList.SetOnNewStaff(func() {
@@ -406,6 +478,60 @@ func (as *AppState) CreateContent() fyne.CanvasObject {
 			return auditLogList.CreateObject()
 		}
 		fallthrough
+	case "incidents":
+		incidentList := as.GetIncidentList()
+		if incidentList != nil {
+			return incidentList.CreateObject()
+		}
+		fallthrough
+	case "notifications":
+		notificationCenter := as.GetNotificationCenter()
+		if notificationCenter != nil {
+			return notificationCenter.CreateObject()
+		}
+		fallthrough
+	case "jobs":
+		jobStatusPanel := as.GetJobStatusPanel()
+		if jobStatusPanel != nil {
+			return jobStatusPanel.CreateObject()
+		}
+		fallthrough
+	case "security":
+		securityView := as.GetSecurityView()
+		if securityView != nil {
+			return securityView.CreateObject()
+		}
+		fallthrough
+	case "logs":
+		logViewer := as.GetLogViewer()
+		if logViewer != nil {
+			return logViewer.CreateObject()
+		}
+		fallthrough
+	case "integrity":
+		integrityView := as.GetIntegrityView()
+		if integrityView != nil {
+			return integrityView.CreateObject()
+		}
+		fallthrough
+	case "import":
+		importView := as.GetImportView()
+		if importView != nil {
+			return importView.CreateObject()
+		}
+		fallthrough
+	case "duplicates":
+		duplicateView := as.GetDuplicateView()
+		if duplicateView != nil {
+			return duplicateView.CreateObject()
+		}
+		fallthrough
+	case "sessions":
+		sessionView := as.GetSessionView()
+		if sessionView != nil {
+			return sessionView.CreateObject()
+		}
+		fallthrough
 	case "staff":
 		staffList := as.GetStaffList()
 		if staffList != nil {
@@ -486,6 +612,16 @@ func (as *AppState) GetRecipientForm() *RecipientForm {
 
 	if as.recipientForm == nil && as.recipientUseCase != nil {
 		as.recipientForm = NewRecipientForm(as.recipientUseCase)
+		as.recipientForm.SetDisclosureUseCase(as.disclosureUseCase)
+		if as.postalCodeUseCase != nil {
+			as.recipientForm.SetPostalCodeUseCase(as.postalCodeUseCase)
+		}
+		if as.contactUseCase != nil {
+			as.recipientForm.SetEmergencyContactSection(NewEmergencyContactSection(as.contactUseCase, as.pdfService))
+		}
+		if as.medicalUseCase != nil {
+			as.recipientForm.SetMedicalRecordSection(NewMedicalRecordSection(as.medicalUseCase))
+		}
 
 		// Set up event handlers
 		as.recipientForm.SetOnSaved(func(recipient *domain.Recipient) {
@@ -583,6 +719,7 @@ func (as *AppState) GetCertificateList() *CertificateList {
 
 	if as.certificateList == nil && as.certificateUseCase != nil && as.recipientUseCase != nil {
 		as.certificateList = NewCertificateList(as.certificateUseCase, as.recipientUseCase, as.pdfService)
+		as.certificateList.SetExportUseCase(as.exportUseCase, as.currentUser)
 
 		// Set up event handlers
 		as.certificateList.SetOnNewCertificate(func() {
@@ -608,6 +745,9 @@ func (as *AppState) GetCertificateForm() *CertificateForm {
 
 	if as.certificateForm == nil && as.certificateUseCase != nil && as.recipientUseCase != nil {
 		as.certificateForm = NewCertificateForm(as.certificateUseCase, as.recipientUseCase, as.currentUser)
+		if as.masterDataUseCase != nil {
+			as.certificateForm.SetMasterDataUseCase(as.masterDataUseCase)
+		}
 
 		// Set up event handlers
 		as.certificateForm.SetOnSaved(func(certificate *domain.BenefitCertificate) {
@@ -705,6 +845,15 @@ func (as *AppState) GetSettingsView() *SettingsView {
 
 	if as.settingsView == nil && as.config != nil {
 		as.settingsView = NewSettingsView(as.config)
+		if as.staffUseCase != nil && as.currentUser != nil {
+			as.settingsView.SetStaffUseCase(as.staffUseCase, as.currentUser)
+		}
+		if as.postalCodeUseCase != nil {
+			as.settingsView.SetPostalCodeUseCase(as.postalCodeUseCase)
+		}
+		if as.masterDataUseCase != nil {
+			as.settingsView.SetMasterDataUseCase(as.masterDataUseCase)
+		}
 
 		// Set up event handlers
 		as.settingsView.SetOnSaved(func() {
@@ -727,6 +876,86 @@ func (as *AppState) GetAccessibilityManager() *AccessibilityManager {
 	return as.accessibilityManager
 }
 
+// SetDisclosureUseCase sets the use case for personal information disclosure packages
+func (as *AppState) SetDisclosureUseCase(disclosureUseCase usecase.DisclosureUseCase) {
+	as.disclosureUseCase = disclosureUseCase
+}
+
+// SetExportUseCase sets the use case that records PDF exports of personal data
+func (as *AppState) SetExportUseCase(exportUseCase usecase.ExportUseCase) {
+	as.exportUseCase = exportUseCase
+}
+
+// SetEmergencyContactUseCase sets the use case for recipient emergency contacts
+func (as *AppState) SetEmergencyContactUseCase(contactUseCase usecase.EmergencyContactUseCase) {
+	as.contactUseCase = contactUseCase
+}
+
+// SetMedicalRecordUseCase sets the use case for recipient medical information
+func (as *AppState) SetMedicalRecordUseCase(medicalUseCase usecase.MedicalRecordUseCase) {
+	as.medicalUseCase = medicalUseCase
+}
+
+// SetIncidentUseCase sets the use case for accident and near-miss reports
+func (as *AppState) SetIncidentUseCase(incidentUseCase usecase.IncidentUseCase) {
+	as.incidentUseCase = incidentUseCase
+}
+
+// SetNotificationUseCase sets the use case behind the notification center
+func (as *AppState) SetNotificationUseCase(notificationUseCase usecase.NotificationUseCase) {
+	as.notificationUseCase = notificationUseCase
+}
+
+// SetSecurityUseCase sets the use case behind the security dashboard
+func (as *AppState) SetSecurityUseCase(securityUseCase usecase.SecurityUseCase) {
+	as.securityUseCase = securityUseCase
+}
+
+// SetRateLimitPolicyUseCase sets the use case behind the rate limit settings tab
+func (as *AppState) SetRateLimitPolicyUseCase(rateLimitPolicyUseCase usecase.RateLimitPolicyUseCase) {
+	as.rateLimitPolicyUseCase = rateLimitPolicyUseCase
+}
+
+// SetSessionUseCase sets the use case behind the session management view
+func (as *AppState) SetSessionUseCase(sessionUseCase usecase.SessionUseCase) {
+	as.sessionUseCase = sessionUseCase
+}
+
+// SetLogUseCase sets the use case behind the log viewer
+func (as *AppState) SetLogUseCase(logUseCase usecase.LogUseCase) {
+	as.logUseCase = logUseCase
+}
+
+// SetIntegrityUseCase sets the use case behind the database check view
+func (as *AppState) SetIntegrityUseCase(integrityUseCase usecase.IntegrityUseCase) {
+	as.integrityUseCase = integrityUseCase
+}
+
+// SetImportUseCase sets the use case behind the bulk import view
+func (as *AppState) SetImportUseCase(importUseCase usecase.ImportUseCase) {
+	as.importUseCase = importUseCase
+}
+
+// SetRecipientMergeUseCase sets the use case behind the duplicate recipient view
+func (as *AppState) SetRecipientMergeUseCase(recipientMergeUseCase usecase.RecipientMergeUseCase) {
+	as.recipientMergeUseCase = recipientMergeUseCase
+}
+
+// SetPostalCodeUseCase sets the use case behind address autocompletion and the dictionary import
+func (as *AppState) SetPostalCodeUseCase(postalCodeUseCase usecase.PostalCodeUseCase) {
+	as.postalCodeUseCase = postalCodeUseCase
+}
+
+// SetMasterDataUseCase sets the use case behind the certificate code pickers and the master imports
+func (as *AppState) SetMasterDataUseCase(masterDataUseCase usecase.MasterDataUseCase) {
+	as.masterDataUseCase = masterDataUseCase
+}
+
+// SetJobScheduler sets the background job scheduler shown in the jobs panel
+func (as *AppState) SetJobScheduler(jobScheduler *scheduler.Scheduler) {
+	as.jobScheduler = jobScheduler
+}
+
 // GetBackupUseCase returns the backup use case
 func (as *AppState) GetBackupUseCase() *usecase.BackupUseCase {
 	return as.backupUseCase
@@ -740,6 +969,7 @@ func (as *AppState) GetAuditLogList() *AuditLogList {
 
 	if as.auditLogList == nil && as.auditRepo != nil && as.staffRepo != nil {
 		as.auditLogList = NewAuditLogList(as.auditRepo, as.staffRepo, as.pdfService)
+		as.auditLogList.SetExportUseCase(as.exportUseCase, as.currentUser)
 
 		// Load initial data
 		go as.auditLogList.LoadData()
@@ -747,3 +977,166 @@ func (as *AppState) GetAuditLogList() *AuditLogList {
 
 	return as.auditLogList
 }
+
+// GetIncidentList returns the incident report list (lazy loading, auth required)
+func (as *AppState) GetIncidentList() *IncidentList {
+	if !as.isAuthenticated {
+		return nil
+	}
+
+	if as.incidentList == nil && as.incidentUseCase != nil {
+		as.incidentList = NewIncidentList(as.incidentUseCase, as.recipientUseCase, as.staffUseCase, as.pdfService, as.currentUser)
+
+		// Load initial data
+		go as.incidentList.LoadData()
+	}
+
+	return as.incidentList
+}
+
+// GetJobStatusPanel returns the background job panel (lazy loading, admin only)
+func (as *AppState) GetJobStatusPanel() *JobStatusPanel {
+	if !as.isAuthenticated || as.currentUser == nil || as.currentUser.Role != domain.RoleAdmin {
+		return nil
+	}
+
+	if as.jobStatusPanel == nil && as.jobScheduler != nil {
+		as.jobStatusPanel = NewJobStatusPanel(as.jobScheduler, as.currentUser)
+		as.jobStatusPanel.LoadData()
+	}
+
+	return as.jobStatusPanel
+}
+
+// GetSecurityView returns the security dashboard (lazy loading, admin only)
+func (as *AppState) GetSecurityView() *SecurityView {
+	if !as.isAuthenticated || as.currentUser == nil || as.currentUser.Role != domain.RoleAdmin {
+		return nil
+	}
+
+	if as.securityView == nil && as.securityUseCase != nil {
+		as.securityView = NewSecurityView(as.securityUseCase, as.rateLimitPolicyUseCase, as.currentUser)
+		as.securityView.LoadData()
+	}
+
+	return as.securityView
+}
+
+// GetLogViewer returns the application log viewer (lazy loading, admin only)
+func (as *AppState) GetLogViewer() *LogViewer {
+	if !as.isAuthenticated || as.currentUser == nil || as.currentUser.Role != domain.RoleAdmin {
+		return nil
+	}
+
+	if as.logViewer == nil && as.logUseCase != nil {
+		as.logViewer = NewLogViewer(as.logUseCase, as.currentUser)
+		as.logViewer.LoadData()
+	}
+
+	return as.logViewer
+}
+
+// GetIntegrityView returns the database check view (lazy loading, admin only).
+// The check itself runs only when the administrator starts it.
+func (as *AppState) GetIntegrityView() *IntegrityView {
+	if !as.isAuthenticated || as.currentUser == nil || as.currentUser.Role != domain.RoleAdmin {
+		return nil
+	}
+
+	if as.integrityView == nil && as.integrityUseCase != nil {
+		as.integrityView = NewIntegrityView(as.integrityUseCase, as.currentUser)
+	}
+
+	return as.integrityView
+}
+
+// GetImportView returns the bulk import view (lazy loading, admin only)
+func (as *AppState) GetImportView() *ImportView {
+	if !as.isAuthenticated || as.currentUser == nil || as.currentUser.Role != domain.RoleAdmin {
+		return nil
+	}
+
+	if as.importView == nil && as.importUseCase != nil {
+		as.importView = NewImportView(as.importUseCase, as.currentUser)
+
+		// Show the imported rows in the lists that are already open
+		as.importView.SetOnImported(func(kind usecase.ImportKind) {
+			if as.recipientList != nil {
+				as.recipientList.LoadData()
+			}
+			if as.certificateList != nil {
+				as.certificateList.LoadData()
+			}
+		})
+	}
+
+	return as.importView
+}
+
+// GetDuplicateView returns the duplicate recipient view (lazy loading, admin only).
+// The search decrypts every recipient, so it only runs when the administrator starts it.
+func (as *AppState) GetDuplicateView() *DuplicateView {
+	if !as.isAuthenticated || as.currentUser == nil || as.currentUser.Role != domain.RoleAdmin {
+		return nil
+	}
+
+	if as.duplicateView == nil && as.recipientMergeUseCase != nil {
+		as.duplicateView = NewDuplicateView(as.recipientMergeUseCase, as.currentUser)
+
+		// Merged recipients disappear from the lists that are already open
+		as.duplicateView.SetOnMerged(func() {
+			if as.recipientList != nil {
+				as.recipientList.LoadData()
+			}
+			if as.certificateList != nil {
+				as.certificateList.LoadData()
+			}
+		})
+	}
+
+	return as.duplicateView
+}
+
+// GetSessionView returns the session management view (lazy loading, auth required)
+func (as *AppState) GetSessionView() *SessionView {
+	if !as.isAuthenticated || as.currentUser == nil {
+		return nil
+	}
+
+	if as.sessionView == nil && as.sessionUseCase != nil {
+		as.sessionView = NewSessionView(as.sessionUseCase, as.currentUser, as.sessionID, as.Logout)
+		as.sessionView.LoadData()
+	}
+
+	return as.sessionView
+}
+
+// GetNotificationCenter returns the notification center (lazy loading, auth required)
+func (as *AppState) GetNotificationCenter() *NotificationCenter {
+	if !as.isAuthenticated || as.currentUser == nil {
+		return nil
+	}
+
+	if as.notificationCenter == nil && as.notificationUseCase != nil {
+		// Rebuilding the view refreshes the unread badge in the header
+		as.notificationCenter = NewNotificationCenter(as.notificationUseCase, as.currentUser, as.notifyObservers)
+		as.notificationCenter.LoadData()
+	}
+
+	return as.notificationCenter
+}
+
+// UnreadNotificationCount returns the number of unread notifications of the current user.
+// It returns -1 when the notification center is unavailable.
+func (as *AppState) UnreadNotificationCount() int {
+	if !as.isAuthenticated || as.currentUser == nil || as.notificationUseCase == nil {
+		return -1
+	}
+
+	count, err := as.notificationUseCase.CountUnread(context.Background(), as.currentUser.ID)
+	if err != nil {
+		return -1
+	}
+
+	return count
+}
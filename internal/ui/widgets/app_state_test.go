@@ -1,10 +1,12 @@
 package widgets
 
 import (
+	"context"
 	"testing"
 
 	"shien-system/internal/config"
 	"shien-system/internal/domain"
+	"shien-system/internal/usecase"
 
 	"fyne.io/fyne/v2/app"
 )
@@ -246,6 +248,44 @@ func TestAppState_SetCurrentView_AuthenticationRequired(t *testing.T) {
 	}
 }
 
+func TestAppState_SetCurrentView_RevokedSessionLogsOut(t *testing.T) {
+	revoked := false
+	mockAuth := &MockAuthUseCase{
+		validateFunc: func(ctx context.Context, sessionID string) (*usecase.SessionInfo, error) {
+			if revoked {
+				return nil, usecase.ErrSessionRevoked
+			}
+			return &usecase.SessionInfo{SessionID: sessionID}, nil
+		},
+	}
+	mockRecipient := &MockRecipientUseCase{}
+	mockCertificate := &MockCertificateUseCase{}
+	mockSetup := &MockSetupUseCase{needsSetup: false}
+	mockAuditRepo := &MockAuditLogRepository{}
+	mockStaffRepo := &MockStaffRepository{}
+	mockStaff := &MockStaffUseCase{}
+	mockConfig := &config.Config{}
+	appState := NewAppState(mockAuth, mockRecipient, mockCertificate, mockStaff, mockSetup, nil, mockAuditRepo, mockStaffRepo, nil, mockConfig)
+
+	appState.Login("test-session-123", &domain.Staff{ID: "staff-001", Name: "職員", Role: domain.RoleStaff})
+
+	appState.SetCurrentView("certificates")
+	if appState.GetCurrentView() != "certificates" {
+		t.Fatalf("Expected view 'certificates', got '%s'", appState.GetCurrentView())
+	}
+
+	// Revoked from another window: the next navigation logs out
+	revoked = true
+	appState.SetCurrentView("staff")
+
+	if appState.IsAuthenticated() {
+		t.Error("Should be logged out after the session was revoked")
+	}
+	if appState.GetCurrentView() != "login" {
+		t.Errorf("Expected login view after revocation, got '%s'", appState.GetCurrentView())
+	}
+}
+
 func TestAppState_GetLoginForm(t *testing.T) {
 	myApp := app.New()
 	defer myApp.Quit()
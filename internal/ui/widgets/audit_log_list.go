@@ -3,10 +3,14 @@ package widgets
 import (
 	"context"
 	"fmt"
+	"io"
+	"strings"
 	"time"
 
 	"shien-system/internal/adapter/pdf"
 	"shien-system/internal/domain"
+	"shien-system/internal/usecase"
+	"shien-system/internal/wareki"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/container"
@@ -21,10 +25,15 @@ type AuditLogList struct {
 	staffRepo domain.StaffRepository
 	pdfService *pdf.PDFService
 
+	exportUseCase usecase.ExportUseCase
+	currentUser   *domain.Staff
+
 	// UI components
 	table         *widget.Table
 	refreshButton *widget.Button
 	exportButton  *widget.Button
+	sheetButton   *widget.Button
+	traceButton   *widget.Button
 	actionFilter  *widget.Select
 	dateFromEntry *widget.Entry
 	dateToEntry   *widget.Entry
@@ -82,9 +91,17 @@ func (al *AuditLogList) createWidgets() {
 		al.exportToPDF()
 	})
 
+	al.sheetButton = widget.NewButton("Excel・CSV出力", func() {
+		al.exportToSpreadsheet()
+	})
+
+	al.traceButton = widget.NewButton("出力ファイル照合", func() {
+		al.traceExport()
+	})
+
 	// Filters
 	al.actionFilter = widget.NewSelect(
-		[]string{"全て", "LOGIN_SUCCESS", "LOGIN_FAILED", "LOGOUT", "CREATE_RECIPIENT", "UPDATE_RECIPIENT", "DELETE_RECIPIENT"},
+		[]string{"全て", "LOGIN_SUCCESS", "LOGIN_FAILED", "LOGOUT", "CREATE_RECIPIENT", "UPDATE_RECIPIENT", "DELETE_RECIPIENT", "MERGE_RECIPIENT", "EXPORT_PDF", "EXPORT_SPREADSHEET"},
 		func(selected string) {
 			al.onActionFilterChanged(selected)
 		},
@@ -98,10 +115,10 @@ func (al *AuditLogList) createWidgets() {
 
 	// Date filters
 	al.dateFromEntry = widget.NewEntry()
-	al.dateFromEntry.SetPlaceHolder("開始日 (YYYY/MM/DD)")
+	al.dateFromEntry.SetPlaceHolder("開始日 (" + datePlaceHolder + ")")
 
 	al.dateToEntry = widget.NewEntry()
-	al.dateToEntry.SetPlaceHolder("終了日 (YYYY/MM/DD)")
+	al.dateToEntry.SetPlaceHolder("終了日 (" + datePlaceHolder + ")")
 }
 
 // setupTable configures the table widget
@@ -133,7 +150,7 @@ func (al *AuditLogList) setupEventHandlers() {
 
 // validateAndSetDate validates and sets date filter
 func (al *AuditLogList) validateAndSetDate(dateStr string, isFrom bool) {
-	parsed, err := time.Parse("2006/01/02", dateStr)
+	parsed, err := wareki.Parse(dateStr)
 	if err != nil {
 		// Show validation error
 		fmt.Printf("Invalid date format: %s\n", dateStr)
@@ -164,11 +181,7 @@ func (al *AuditLogList) updateTableCell(id widget.TableCellID, label *widget.Lab
 	case 0: // 日時
 		label.SetText(log.At.Format("2006/01/02 15:04:05"))
 	case 1: // 操作者
-		if staff, ok := al.staffMap[log.ActorID]; ok {
-			label.SetText(staff.Name)
-		} else {
-			label.SetText(string(log.ActorID))
-		}
+		label.SetText(al.actorName(log))
 	case 2: // アクション
 		label.SetText(al.formatAction(log.Action))
 	case 3: // 対象
@@ -182,6 +195,14 @@ func (al *AuditLogList) updateTableCell(id widget.TableCellID, label *widget.Lab
 	}
 }
 
+// actorName returns the name of the staff member who performed the action
+func (al *AuditLogList) actorName(log *domain.AuditLog) string {
+	if staff, ok := al.staffMap[log.ActorID]; ok {
+		return staff.Name
+	}
+	return string(log.ActorID)
+}
+
 // formatAction formats action codes to Japanese
 func (al *AuditLogList) formatAction(action string) string {
 	switch action {
@@ -197,12 +218,18 @@ func (al *AuditLogList) formatAction(action string) string {
 		return "利用者更新"
 	case "DELETE_RECIPIENT":
 		return "利用者削除"
+	case "MERGE_RECIPIENT":
+		return "利用者統合"
 	case "CREATE_CERTIFICATE":
 		return "受給者証作成"
 	case "UPDATE_CERTIFICATE":
 		return "受給者証更新"
 	case "DELETE_CERTIFICATE":
 		return "受給者証削除"
+	case "EXPORT_PDF":
+		return "PDF出力"
+	case "EXPORT_SPREADSHEET":
+		return "Excel・CSV出力"
 	default:
 		return action
 	}
@@ -310,6 +337,12 @@ func (al *AuditLogList) applyFilters() {
 
 // CreateObject creates the main UI object for this widget
 func (al *AuditLogList) CreateObject() fyne.CanvasObject {
+	// Tracing leaked files is limited to administrators
+	actions := container.NewHBox(al.refreshButton, al.exportButton, al.sheetButton)
+	if al.canTraceExports() {
+		actions.Add(al.traceButton)
+	}
+
 	// Filter controls
 	filterControls := container.NewVBox(
 		container.NewHBox(
@@ -317,8 +350,7 @@ func (al *AuditLogList) CreateObject() fyne.CanvasObject {
 			al.actionFilter,
 			widget.NewLabel("操作者:"),
 			al.actorFilter,
-			al.refreshButton,
-			al.exportButton,
+			actions,
 		),
 		container.NewHBox(
 			widget.NewLabel("期間:"),
@@ -362,6 +394,13 @@ func (al *AuditLogList) Length() int {
 	return len(al.filteredData)
 }
 
+// SetExportUseCase enables PDF export and, for administrators, tracing a
+// leaked file back to its export
+func (al *AuditLogList) SetExportUseCase(exportUseCase usecase.ExportUseCase, currentUser *domain.Staff) {
+	al.exportUseCase = exportUseCase
+	al.currentUser = currentUser
+}
+
 // exportToPDF exports the filtered audit logs to a PDF report
 func (al *AuditLogList) exportToPDF() {
 	if al.pdfService == nil {
@@ -374,67 +413,140 @@ func (al *AuditLogList) exportToPDF() {
 		return
 	}
 
-	// Show file save dialog
-	saveDialog := dialog.NewFileSave(func(writer fyne.URIWriteCloser, err error) {
-		if err != nil {
-			dialog.ShowError(fmt.Errorf("ファイルの保存に失敗しました: %w", err), fyne.CurrentApp().Driver().AllWindows()[0])
-			return
-		}
-		if writer == nil {
-			return // User cancelled
+	// Convert pointer slice to value slice for PDF service
+	auditLogValues := make([]domain.AuditLog, len(al.filteredData))
+	for i, log := range al.filteredData {
+		if log != nil {
+			auditLogValues[i] = *log
 		}
-		defer writer.Close()
+	}
 
-		// Convert pointer slice to value slice for PDF service
-		auditLogValues := make([]domain.AuditLog, len(al.filteredData))
-		for i, log := range al.filteredData {
-			if log != nil {
-				auditLogValues[i] = *log
+	// Determine date range for the report
+	var startDate, endDate time.Time
+	if len(auditLogValues) > 0 {
+		startDate = auditLogValues[len(auditLogValues)-1].At // Oldest (assuming reverse chronological order)
+		endDate = auditLogValues[0].At                        // Newest
+	}
+
+	// If custom date filters are applied, use those instead
+	if !al.currentDateFrom.IsZero() {
+		startDate = al.currentDateFrom
+	}
+	if !al.currentDateTo.IsZero() {
+		endDate = al.currentDateTo
+	}
+
+	// If still no dates, use a default range
+	if startDate.IsZero() || endDate.IsZero() {
+		endDate = time.Now()
+		startDate = endDate.AddDate(0, -1, 0) // Last month
+	}
+
+	exportConfidentialPDF(fyne.CurrentApp().Driver().AllWindows()[0], al.exportUseCase, al.currentUser, confidentialExport{
+		report:   "監査ログ報告書",
+		target:   "report:" + pdf.ReportAuditLog,
+		fileName: fmt.Sprintf("監査ログ_%s.pdf", time.Now().Format("20060102_150405")),
+		count:    len(auditLogValues),
+		generate: func(opts pdf.ExportOptions) ([]byte, error) {
+			pdfBytes, err := al.pdfService.GenerateAuditReport(context.Background(), auditLogValues, startDate, endDate, opts)
+			if err != nil {
+				return nil, fmt.Errorf("PDF生成に失敗しました: %w", err)
 			}
-		}
+			return pdfBytes, nil
+		},
+	})
+}
 
-		// Determine date range for the report
-		var startDate, endDate time.Time
-		if len(auditLogValues) > 0 {
-			startDate = auditLogValues[len(auditLogValues)-1].At // Oldest (assuming reverse chronological order)
-			endDate = auditLogValues[0].At                        // Newest
-		}
+// exportToSpreadsheet exports the filtered audit logs to CSV or Excel
+func (al *AuditLogList) exportToSpreadsheet() {
+	logs := al.filteredData
+	exportSpreadsheet(fyne.CurrentApp().Driver().AllWindows()[0], al.exportUseCase, al.currentUser, spreadsheetExport{
+		report: "監査ログ",
+		target: "report:" + pdf.ReportAuditLog,
+		table:  usecase.ExportTableAuditLogs,
+		count:  len(logs),
+		cell: func(row int, key string) interface{} {
+			return al.exportCell(logs[row], key)
+		},
+	})
+}
 
-		// If custom date filters are applied, use those instead
-		if !al.currentDateFrom.IsZero() {
-			startDate = al.currentDateFrom
-		}
-		if !al.currentDateTo.IsZero() {
-			endDate = al.currentDateTo
-		}
+// exportCell returns the value of a spreadsheet column for an audit log entry
+func (al *AuditLogList) exportCell(log *domain.AuditLog, key string) interface{} {
+	switch key {
+	case "at":
+		return log.At.Local()
+	case "actor":
+		return al.actorName(log)
+	case "action":
+		return al.formatAction(log.Action)
+	case "target":
+		return log.Target
+	case "ip":
+		return log.IP
+	case "details":
+		return log.Details
+	default:
+		return nil
+	}
+}
 
-		// If still no dates, use a default range
-		if startDate.IsZero() || endDate.IsZero() {
-			endDate = time.Now()
-			startDate = endDate.AddDate(0, -1, 0) // Last month
-		}
+// canTraceExports reports whether the leaked file lookup should be offered
+func (al *AuditLogList) canTraceExports() bool {
+	return al.exportUseCase != nil && al.currentUser != nil && al.currentUser.Role == domain.RoleAdmin
+}
 
-		// Generate PDF
-		ctx := context.Background()
-		pdfBytes, err := al.pdfService.GenerateAuditReport(ctx, auditLogValues, startDate, endDate)
+// traceExport looks up who exported a PDF, Excel or CSV file found outside the office
+func (al *AuditLogList) traceExport() {
+	if !al.canTraceExports() {
+		return
+	}
+
+	parent := fyne.CurrentApp().Driver().AllWindows()[0]
+
+	openDialog := dialog.NewFileOpen(func(reader fyne.URIReadCloser, err error) {
 		if err != nil {
-			dialog.ShowError(fmt.Errorf("PDF生成に失敗しました: %w", err), fyne.CurrentApp().Driver().AllWindows()[0])
+			dialog.ShowError(fmt.Errorf("ファイルを開けませんでした: %w", err), parent)
 			return
 		}
+		if reader == nil {
+			return // User cancelled
+		}
+		defer reader.Close()
 
-		// Write PDF to file
-		_, err = writer.Write(pdfBytes)
+		data, err := io.ReadAll(reader)
 		if err != nil {
-			dialog.ShowError(fmt.Errorf("ファイルの書き込みに失敗しました: %w", err), fyne.CurrentApp().Driver().AllWindows()[0])
+			dialog.ShowError(fmt.Errorf("ファイルの読み込みに失敗しました: %w", err), parent)
 			return
 		}
 
-		dialog.ShowInformation("成功", fmt.Sprintf("%d件の監査ログデータをPDFにエクスポートしました。", len(al.filteredData)), fyne.CurrentApp().Driver().AllWindows()[0])
-	}, fyne.CurrentApp().Driver().AllWindows()[0])
+		logs, err := al.exportUseCase.TraceExport(context.Background(), usecase.TraceExportRequest{
+			Data:    data,
+			ActorID: al.currentUser.ID,
+		})
+		if err != nil {
+			dialog.ShowError(fmt.Errorf("出力記録の照合に失敗しました: %w", err), parent)
+			return
+		}
+		if len(logs) == 0 {
+			dialog.ShowInformation("出力ファイル照合", "このファイルの出力記録は見つかりませんでした。\n内容が変更されているか、このシステムから出力されたファイルではありません。", parent)
+			return
+		}
 
-	// Set default filename with timestamp
-	defaultName := fmt.Sprintf("監査ログ_%s.pdf", time.Now().Format("20060102_150405"))
-	saveDialog.SetFileName(defaultName)
-	saveDialog.SetFilter(storage.NewExtensionFileFilter([]string{".pdf"}))
-	saveDialog.Show()
+		lines := make([]string, len(logs))
+		for i, log := range logs {
+			actor := string(log.ActorID)
+			if staff, ok := al.staffMap[log.ActorID]; ok {
+				actor = staff.Name
+			}
+			lines[i] = fmt.Sprintf("%s  %s\n%s", log.At.Local().Format("2006/01/02 15:04:05"), actor, log.Details)
+		}
+		result := widget.NewLabel(strings.Join(lines, "\n\n"))
+		result.Wrapping = fyne.TextWrapWord
+		content := container.NewVScroll(result)
+		content.SetMinSize(fyne.NewSize(560, 240))
+		dialog.ShowCustom("出力ファイル照合", "閉じる", content, parent)
+	}, parent)
+	openDialog.SetFilter(storage.NewExtensionFileFilter([]string{".pdf", ".xlsx", ".csv"}))
+	openDialog.Show()
 }
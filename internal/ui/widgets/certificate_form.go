@@ -2,6 +2,7 @@ package widgets
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
@@ -15,19 +16,23 @@ import (
 
 	"shien-system/internal/domain"
 	"shien-system/internal/usecase"
+	"shien-system/internal/validation"
+	"shien-system/internal/wareki"
 )
 
 // CertificateForm represents a form for creating/editing benefit certificates
 type CertificateForm struct {
-	useCase     usecase.CertificateUseCase
-	recipientUC usecase.RecipientUseCase
+	useCase      usecase.CertificateUseCase
+	recipientUC  usecase.RecipientUseCase
+	masterDataUC usecase.MasterDataUseCase // Optional, fills the municipality and service type pickers
 
 	// UI components - Certificate Information
 	recipientSelect         *widget.Select
+	certificateNumberEntry  *widget.Entry
 	startDateEntry          *widget.Entry
 	endDateEntry            *widget.Entry
-	issuerEntry             *widget.Entry
-	serviceTypeEntry        *widget.Entry
+	municipalityPicker      *codePicker
+	serviceTypePicker       *codePicker
 	maxBenefitDaysEntry     *widget.Entry
 	benefitDetailsEntry     *widget.Entry
 
@@ -39,6 +44,7 @@ type CertificateForm struct {
 	isEditing     bool
 	certificateID *domain.ID
 	currentUser   *domain.Staff
+	original      *domain.BenefitCertificate // Record as loaded into the form, see handleEditConflict
 	recipients    []*domain.Recipient
 
 	// Event handlers
@@ -66,19 +72,20 @@ func (cf *CertificateForm) createWidgets() {
 	cf.recipientSelect = widget.NewSelect([]string{}, nil)
 	cf.recipientSelect.PlaceHolder = "利用者を選択してください"
 
+	cf.certificateNumberEntry = widget.NewEntry()
+	cf.certificateNumberEntry.SetPlaceHolder("受給者証番号（10桁）")
+	cf.certificateNumberEntry.Validator = cf.validateCertificateNumber
+
 	cf.startDateEntry = widget.NewEntry()
-	cf.startDateEntry.SetPlaceHolder("YYYY-MM-DD")
+	cf.startDateEntry.SetPlaceHolder(datePlaceHolder)
 	cf.startDateEntry.Validator = cf.validateDateFormat
 
 	cf.endDateEntry = widget.NewEntry()
-	cf.endDateEntry.SetPlaceHolder("YYYY-MM-DD")
+	cf.endDateEntry.SetPlaceHolder(datePlaceHolder)
 	cf.endDateEntry.Validator = cf.validateDateFormat
 
-	cf.issuerEntry = widget.NewEntry()
-	cf.issuerEntry.SetPlaceHolder("発行機関名を入力")
-
-	cf.serviceTypeEntry = widget.NewEntry()
-	cf.serviceTypeEntry.SetPlaceHolder("サービス種別を入力")
+	cf.municipalityPicker = newMunicipalityPicker()
+	cf.serviceTypePicker = newServiceTypePicker()
 
 	cf.maxBenefitDaysEntry = widget.NewEntry()
 	cf.maxBenefitDaysEntry.SetPlaceHolder("月あたりの給付日数上限")
@@ -103,41 +110,57 @@ func (cf *CertificateForm) validateDateFormat(text string) error {
 	if text == "" {
 		return nil
 	}
-	_, err := time.Parse("2006-01-02", text)
+	_, err := wareki.Parse(text)
 	if err != nil {
-		return fmt.Errorf("日付は YYYY-MM-DD 形式で入力してください")
+		return fmt.Errorf("日付は %s の形式で入力してください", datePlaceHolder)
 	}
 	return nil
 }
 
+// validateCertificateNumber validates the ten-digit certificate number
+func (cf *CertificateForm) validateCertificateNumber(text string) error {
+	if text == "" {
+		return nil
+	}
+	if validation.CertificateNumberDigits(text) == "" {
+		return fmt.Errorf("受給者証番号は10桁の数字で入力してください")
+	}
+	return nil
+}
+
+// SetMasterDataUseCase fills the municipality and service type pickers from
+// the masters. Without it, codes are typed in with their names.
+func (cf *CertificateForm) SetMasterDataUseCase(masterDataUC usecase.MasterDataUseCase) {
+	cf.masterDataUC = masterDataUC
+}
+
 // SetForEdit configures the form for editing an existing certificate
 func (cf *CertificateForm) SetForEdit(certificate *domain.BenefitCertificate) {
 	cf.isEditing = true
 	cf.certificateID = &certificate.ID
+	cf.original = certificate
 	cf.saveButton.SetText("更新")
 
-	// Load recipient data first
+	// Load recipient and master data first
 	cf.loadRecipientData()
+	cf.loadMasterData()
 
 	// Set form values
 	cf.setRecipientInSelect(certificate.RecipientID)
-	cf.startDateEntry.SetText(certificate.StartDate.Format("2006-01-02"))
-	cf.endDateEntry.SetText(certificate.EndDate.Format("2006-01-02"))
-	cf.issuerEntry.SetText(certificate.Issuer)
-	cf.serviceTypeEntry.SetText(certificate.ServiceType)
-	cf.maxBenefitDaysEntry.SetText(strconv.Itoa(certificate.MaxBenefitDaysPerMonth))
-	cf.benefitDetailsEntry.SetText(certificate.BenefitDetails)
+	setFieldValues(cf.conflictFields(), cf.fieldValues(certificate))
 }
 
 // SetForCreate configures the form for creating a new certificate
 func (cf *CertificateForm) SetForCreate(recipientID *domain.ID) {
 	cf.isEditing = false
 	cf.certificateID = nil
+	cf.original = nil
 	cf.saveButton.SetText("登録")
 	cf.clearForm()
 
-	// Load recipient data
+	// Load recipient and master data
 	cf.loadRecipientData()
+	cf.loadMasterData()
 
 	// Pre-select recipient if provided
 	if recipientID != nil {
@@ -148,10 +171,11 @@ func (cf *CertificateForm) SetForCreate(recipientID *domain.ID) {
 // clearForm resets all form fields
 func (cf *CertificateForm) clearForm() {
 	cf.recipientSelect.SetSelected("")
+	cf.certificateNumberEntry.SetText("")
 	cf.startDateEntry.SetText("")
 	cf.endDateEntry.SetText("")
-	cf.issuerEntry.SetText("")
-	cf.serviceTypeEntry.SetText("")
+	cf.municipalityPicker.setValue("", "")
+	cf.serviceTypePicker.setValue("", "")
 	cf.maxBenefitDaysEntry.SetText("")
 	cf.benefitDetailsEntry.SetText("")
 }
@@ -173,6 +197,28 @@ func (cf *CertificateForm) loadRecipientData() {
 	cf.recipientSelect.Options = options
 }
 
+// loadMasterData fills the municipality and service type pickers
+func (cf *CertificateForm) loadMasterData() {
+	if cf.masterDataUC == nil {
+		return
+	}
+
+	ctx := context.Background()
+	municipalities, err := cf.masterDataUC.ListMunicipalities(ctx)
+	if err != nil {
+		cf.showError("市町村マスタの読み込みに失敗しました", err)
+		return
+	}
+	cf.municipalityPicker.setMunicipalities(municipalities)
+
+	serviceTypes, err := cf.masterDataUC.ListServiceTypes(ctx)
+	if err != nil {
+		cf.showError("サービス種類マスタの読み込みに失敗しました", err)
+		return
+	}
+	cf.serviceTypePicker.setServiceTypes(serviceTypes)
+}
+
 // setRecipientInSelect sets the selected recipient by ID
 func (cf *CertificateForm) setRecipientInSelect(recipientID domain.ID) {
 	for i, recipient := range cf.recipients {
@@ -237,6 +283,10 @@ func (cf *CertificateForm) handleUpdate() {
 
 	ctx := context.Background()
 	certificate, err := cf.useCase.UpdateCertificate(ctx, *req)
+	if errors.Is(err, usecase.ErrEditConflict) {
+		cf.handleEditConflict(err)
+		return
+	}
 	if err != nil {
 		cf.showError("受給者証の更新に失敗しました", err)
 		return
@@ -276,13 +326,18 @@ func (cf *CertificateForm) buildCreateRequest() (*usecase.CreateCertificateReque
 	if err := cf.validateForm(); err != nil {
 		return nil, err
 	}
+	municipalityCode, issuer := cf.municipalityPicker.value()
+	serviceCode, serviceType := cf.serviceTypePicker.value()
 
 	return &usecase.CreateCertificateRequest{
 		RecipientID:            *recipientID,
+		CertificateNumber:      validation.CertificateNumberDigits(cf.certificateNumberEntry.Text),
 		StartDate:              startDate,
 		EndDate:                endDate,
-		Issuer:                 strings.TrimSpace(cf.issuerEntry.Text),
-		ServiceType:            strings.TrimSpace(cf.serviceTypeEntry.Text),
+		MunicipalityCode:       municipalityCode,
+		Issuer:                 issuer,
+		ServiceCode:            serviceCode,
+		ServiceType:            serviceType,
 		MaxBenefitDaysPerMonth: maxBenefitDays,
 		BenefitDetails:         strings.TrimSpace(cf.benefitDetailsEntry.Text),
 		ActorID:                cf.currentUser.ID,
@@ -291,7 +346,7 @@ func (cf *CertificateForm) buildCreateRequest() (*usecase.CreateCertificateReque
 
 // buildUpdateRequest builds a certificate update request from form data
 func (cf *CertificateForm) buildUpdateRequest() (*usecase.UpdateCertificateRequest, error) {
-	if cf.certificateID == nil {
+	if cf.certificateID == nil || cf.original == nil {
 		return nil, fmt.Errorf("更新対象の受給者証が選択されていません")
 	}
 
@@ -317,30 +372,90 @@ func (cf *CertificateForm) buildUpdateRequest() (*usecase.UpdateCertificateReque
 	if err := cf.validateForm(); err != nil {
 		return nil, err
 	}
+	municipalityCode, issuer := cf.municipalityPicker.value()
+	serviceCode, serviceType := cf.serviceTypePicker.value()
 
 	return &usecase.UpdateCertificateRequest{
 		ID:                     *cf.certificateID,
+		CertificateNumber:      validation.CertificateNumberDigits(cf.certificateNumberEntry.Text),
 		StartDate:              startDate,
 		EndDate:                endDate,
-		Issuer:                 strings.TrimSpace(cf.issuerEntry.Text),
-		ServiceType:            strings.TrimSpace(cf.serviceTypeEntry.Text),
+		MunicipalityCode:       municipalityCode,
+		Issuer:                 issuer,
+		ServiceCode:            serviceCode,
+		ServiceType:            serviceType,
 		MaxBenefitDaysPerMonth: maxBenefitDays,
 		BenefitDetails:         strings.TrimSpace(cf.benefitDetailsEntry.Text),
+		Version:                cf.original.Version,
 		ActorID:                cf.currentUser.ID,
 	}, nil
 }
 
+// conflictFields binds the form fields compared by the edit conflict dialog
+func (cf *CertificateForm) conflictFields() []conflictField {
+	entry := func(label string, e *widget.Entry) conflictField {
+		return conflictField{label: label, get: func() string { return strings.TrimSpace(e.Text) }, set: e.SetText}
+	}
+	return []conflictField{
+		entry("受給者証番号", cf.certificateNumberEntry),
+		entry("開始日", cf.startDateEntry),
+		entry("終了日", cf.endDateEntry),
+		entry("市町村", &cf.municipalityPicker.entry.Entry),
+		entry("サービス種類", &cf.serviceTypePicker.entry.Entry),
+		entry("月あたりの給付日数上限", cf.maxBenefitDaysEntry),
+		entry("給付内容", cf.benefitDetailsEntry),
+	}
+}
+
+// fieldValues formats certificate in the order of conflictFields
+func (cf *CertificateForm) fieldValues(certificate *domain.BenefitCertificate) []string {
+	style := dateStyleOf(cf.currentUser)
+	return []string{
+		certificate.CertificateNumber,
+		style.Format(certificate.StartDate),
+		style.Format(certificate.EndDate),
+		cf.municipalityPicker.label(certificate.MunicipalityCode, certificate.Issuer),
+		cf.serviceTypePicker.label(certificate.ServiceCode, certificate.ServiceType),
+		strconv.Itoa(certificate.MaxBenefitDaysPerMonth),
+		certificate.BenefitDetails,
+	}
+}
+
+// handleEditConflict offers to reload or merge when another staff member
+// saved the certificate while the form was open
+func (cf *CertificateForm) handleEditConflict(err error) {
+	current, ok := storedRecord(err).(*domain.BenefitCertificate)
+	if !ok || current == nil || cf.original == nil {
+		cf.showError("受給者証の更新に失敗しました", err)
+		return
+	}
+
+	fields := cf.conflictFields()
+	conflict := newEditConflict(fields, cf.fieldValues(cf.original), cf.fieldValues(current))
+	parent := fyne.CurrentApp().Driver().AllWindows()[0]
+	showEditConflictDialog(parent, conflict, func() {
+		cf.original = current
+		setFieldValues(fields, conflict.theirs)
+	}, func() {
+		cf.original = current
+		showMergeResult(parent, conflict.merge())
+	})
+}
+
 // validateForm validates the entire form
 func (cf *CertificateForm) validateForm() error {
-	if strings.TrimSpace(cf.issuerEntry.Text) == "" {
-		return fmt.Errorf("発行機関名を入力してください")
+	if strings.TrimSpace(cf.certificateNumberEntry.Text) == "" {
+		return fmt.Errorf("受給者証番号を入力してください")
+	}
+	if err := cf.validateCertificateNumber(cf.certificateNumberEntry.Text); err != nil {
+		return err
 	}
 
-	if strings.TrimSpace(cf.serviceTypeEntry.Text) == "" {
-		return fmt.Errorf("サービス種別を入力してください")
+	if err := cf.municipalityPicker.check(); err != nil {
+		return err
 	}
 
-	return nil
+	return cf.serviceTypePicker.check()
 }
 
 // parseDateField parses a date field with error context
@@ -350,9 +465,9 @@ func (cf *CertificateForm) parseDateField(text, fieldName string) (time.Time, er
 		return time.Time{}, fmt.Errorf("%sを入力してください", fieldName)
 	}
 
-	date, err := time.Parse("2006-01-02", text)
+	date, err := wareki.Parse(text)
 	if err != nil {
-		return time.Time{}, fmt.Errorf("%sは YYYY-MM-DD 形式で入力してください", fieldName)
+		return time.Time{}, fmt.Errorf("%sは %s の形式で入力してください: %w", fieldName, datePlaceHolder, err)
 	}
 
 	return date, nil
@@ -394,14 +509,19 @@ func (cf *CertificateForm) setFormEnabled(enabled bool) {
 		cf.endDateEntry.Enable()
 	}
 
-	cf.issuerEntry.Disable()
+	cf.certificateNumberEntry.Disable()
+	if enabled {
+		cf.certificateNumberEntry.Enable()
+	}
+
+	cf.municipalityPicker.entry.Disable()
 	if enabled {
-		cf.issuerEntry.Enable()
+		cf.municipalityPicker.entry.Enable()
 	}
 
-	cf.serviceTypeEntry.Disable()
+	cf.serviceTypePicker.entry.Disable()
 	if enabled {
-		cf.serviceTypeEntry.Enable()
+		cf.serviceTypePicker.entry.Enable()
 	}
 
 	cf.maxBenefitDaysEntry.Disable()
@@ -438,7 +558,7 @@ func (cf *CertificateForm) showError(title string, err error) {
 	if err != nil {
 		message = fmt.Sprintf("%s\n\nエラー詳細: %v", title, err)
 	}
-	dialog.ShowError(fmt.Errorf(message), nil)
+	dialog.ShowError(errors.New(message), nil)
 }
 
 // CreateDialog creates a dialog containing the form
@@ -459,6 +579,8 @@ func (cf *CertificateForm) CreateObject() fyne.CanvasObject {
 	recipientSection := container.NewVBox(
 		widget.NewLabel("利用者"),
 		cf.recipientSelect,
+		widget.NewLabel("受給者証番号"),
+		cf.certificateNumberEntry,
 	)
 
 	datesSection := container.NewVBox(
@@ -478,12 +600,12 @@ func (cf *CertificateForm) CreateObject() fyne.CanvasObject {
 	serviceSection := container.NewVBox(
 		widget.NewLabel("サービス情報"),
 		container.NewVBox(
-			widget.NewLabel("発行機関"),
-			cf.issuerEntry,
+			widget.NewLabel("支給決定市町村（市町村番号）"),
+			cf.municipalityPicker.entry,
 		),
 		container.NewVBox(
-			widget.NewLabel("サービス種別"),
-			cf.serviceTypeEntry,
+			widget.NewLabel("サービス種類"),
+			cf.serviceTypePicker.entry,
 		),
 		container.NewVBox(
 			widget.NewLabel("月あたりの給付日数上限"),
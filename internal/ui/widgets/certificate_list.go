@@ -12,7 +12,6 @@ import (
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/container"
 	"fyne.io/fyne/v2/dialog"
-	"fyne.io/fyne/v2/storage"
 	"fyne.io/fyne/v2/widget"
 )
 
@@ -20,6 +19,8 @@ import (
 type CertificateList struct {
 	certificateUseCase usecase.CertificateUseCase
 	recipientUseCase   usecase.RecipientUseCase
+	exportUseCase      usecase.ExportUseCase
+	currentUser        *domain.Staff
 	pdfService         *pdf.PDFService
 
 	// UI components
@@ -27,6 +28,7 @@ type CertificateList struct {
 	newButton       *widget.Button
 	refreshButton   *widget.Button
 	exportButton    *widget.Button
+	sheetButton     *widget.Button
 	recipientFilter *widget.Select
 	statusFilter    *widget.Select
 
@@ -92,6 +94,10 @@ func (cl *CertificateList) createWidgets() {
 		cl.exportToPDF()
 	})
 
+	cl.sheetButton = widget.NewButton("Excel・CSV出力", func() {
+		cl.exportToSpreadsheet()
+	})
+
 	// Filters
 	cl.recipientFilter = widget.NewSelect([]string{"全利用者"}, func(selected string) {
 		cl.onRecipientFilterChanged(selected)
@@ -143,17 +149,13 @@ func (cl *CertificateList) updateTableCell(id widget.TableCellID, label *widget.
 
 	switch id.Col {
 	case 0: // 利用者名
-		if recipient, ok := cl.recipientMap[certificate.RecipientID]; ok {
-			label.SetText(recipient.Name)
-		} else {
-			label.SetText("未設定")
-		}
+		label.SetText(cl.recipientName(certificate))
 	case 1: // サービス種別
 		label.SetText(certificate.ServiceType)
 	case 2: // 開始日
-		label.SetText(certificate.StartDate.Format("2006/01/02"))
+		label.SetText(dateStyleOf(cl.currentUser).FormatShort(certificate.StartDate))
 	case 3: // 終了日
-		label.SetText(certificate.EndDate.Format("2006/01/02"))
+		label.SetText(dateStyleOf(cl.currentUser).FormatShort(certificate.EndDate))
 	case 4: // 支給日数
 		label.SetText(fmt.Sprintf("%d日", certificate.MaxBenefitDaysPerMonth))
 	case 5: // 発行者
@@ -175,6 +177,14 @@ func (cl *CertificateList) updateTableCell(id widget.TableCellID, label *widget.
 	}
 }
 
+// recipientName returns the name of the certificate's recipient
+func (cl *CertificateList) recipientName(certificate *domain.BenefitCertificate) string {
+	if recipient, ok := cl.recipientMap[certificate.RecipientID]; ok {
+		return recipient.Name
+	}
+	return "未設定"
+}
+
 // calculateStatus calculates the certificate status based on dates
 func (cl *CertificateList) calculateStatus(cert *domain.BenefitCertificate) string {
 	now := time.Now()
@@ -301,6 +311,7 @@ func (cl *CertificateList) CreateObject() fyne.CanvasObject {
 			cl.newButton,
 			cl.refreshButton,
 			cl.exportButton,
+			cl.sheetButton,
 		),
 		nil,
 	)
@@ -349,6 +360,12 @@ func (cl *CertificateList) Length() int {
 	return len(cl.filteredData)
 }
 
+// SetExportUseCase enables PDF export; every export is recorded for currentUser
+func (cl *CertificateList) SetExportUseCase(exportUseCase usecase.ExportUseCase, currentUser *domain.Staff) {
+	cl.exportUseCase = exportUseCase
+	cl.currentUser = currentUser
+}
+
 // exportToPDF exports the filtered certificate list to a PDF report
 func (cl *CertificateList) exportToPDF() {
 	if cl.pdfService == nil {
@@ -361,46 +378,69 @@ func (cl *CertificateList) exportToPDF() {
 		return
 	}
 
-	// Show file save dialog
-	saveDialog := dialog.NewFileSave(func(writer fyne.URIWriteCloser, err error) {
-		if err != nil {
-			dialog.ShowError(fmt.Errorf("ファイルの保存に失敗しました: %w", err), fyne.CurrentApp().Driver().AllWindows()[0])
-			return
+	// Convert pointer slice to value slice for PDF service
+	certificateValues := make([]domain.BenefitCertificate, len(cl.filteredData))
+	for i, cert := range cl.filteredData {
+		if cert != nil {
+			certificateValues[i] = *cert
 		}
-		if writer == nil {
-			return // User cancelled
-		}
-		defer writer.Close()
+	}
 
-		// Convert pointer slice to value slice for PDF service
-		certificateValues := make([]domain.BenefitCertificate, len(cl.filteredData))
-		for i, cert := range cl.filteredData {
-			if cert != nil {
-				certificateValues[i] = *cert
+	exportConfidentialPDF(fyne.CurrentApp().Driver().AllWindows()[0], cl.exportUseCase, cl.currentUser, confidentialExport{
+		report:   "受給者証一覧",
+		target:   "report:" + pdf.ReportCertificateList,
+		fileName: fmt.Sprintf("受給者証一覧_%s.pdf", time.Now().Format("20060102_150405")),
+		count:    len(certificateValues),
+		generate: func(opts pdf.ExportOptions) ([]byte, error) {
+			pdfBytes, err := cl.pdfService.GenerateCertificateReport(context.Background(), certificateValues, cl.recipientMap, opts)
+			if err != nil {
+				return nil, fmt.Errorf("PDF生成に失敗しました: %w", err)
 			}
-		}
-
-		// Generate PDF for certificate list
-		ctx := context.Background()
-		pdfBytes, err := cl.pdfService.GenerateCertificateReport(ctx, certificateValues, cl.recipientMap)
-		if err != nil {
-			dialog.ShowError(fmt.Errorf("PDF生成に失敗しました: %w", err), fyne.CurrentApp().Driver().AllWindows()[0])
-			return
-		}
-
-		// Write PDF to file
-		_, err = writer.Write(pdfBytes)
-		if err != nil {
-			dialog.ShowError(fmt.Errorf("ファイルの書き込みに失敗しました: %w", err), fyne.CurrentApp().Driver().AllWindows()[0])
-			return
-		}
+			return pdfBytes, nil
+		},
+	})
+}
 
-		dialog.ShowInformation("成功", fmt.Sprintf("%d件の受給者証データをPDFにエクスポートしました。", len(cl.filteredData)), fyne.CurrentApp().Driver().AllWindows()[0])
-	}, fyne.CurrentApp().Driver().AllWindows()[0])
+// exportToSpreadsheet exports the filtered certificates to CSV or Excel
+func (cl *CertificateList) exportToSpreadsheet() {
+	certificates := cl.filteredData
+	exportSpreadsheet(fyne.CurrentApp().Driver().AllWindows()[0], cl.exportUseCase, cl.currentUser, spreadsheetExport{
+		report: "受給者証一覧",
+		target: "report:" + pdf.ReportCertificateList,
+		table:  usecase.ExportTableCertificates,
+		count:  len(certificates),
+		cell: func(row int, key string) interface{} {
+			return cl.exportCell(certificates[row], key)
+		},
+	})
+}
 
-	// Set default filename with timestamp
-	defaultName := fmt.Sprintf("受給者証一覧_%s.pdf", time.Now().Format("20060102_150405"))
-	saveDialog.SetFileName(defaultName)
-	saveDialog.SetFilter(storage.NewExtensionFileFilter([]string{".pdf"}))
-	saveDialog.Show()
+// exportCell returns the value of a spreadsheet column for a certificate
+func (cl *CertificateList) exportCell(certificate *domain.BenefitCertificate, key string) interface{} {
+	switch key {
+	case "recipient_name":
+		return cl.recipientName(certificate)
+	case "certificate_number":
+		return certificate.CertificateNumber
+	case "service_code":
+		return certificate.ServiceCode
+	case "service_type":
+		return certificate.ServiceType
+	case "start_date":
+		return certificate.StartDate
+	case "end_date":
+		return certificate.EndDate
+	case "max_benefit_days":
+		return certificate.MaxBenefitDaysPerMonth
+	case "municipality_code":
+		return certificate.MunicipalityCode
+	case "issuer":
+		return certificate.Issuer
+	case "benefit_details":
+		return certificate.BenefitDetails
+	case "status":
+		return cl.calculateStatus(certificate)
+	default:
+		return nil
+	}
 }
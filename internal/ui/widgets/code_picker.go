@@ -0,0 +1,189 @@
+package widgets
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"shien-system/internal/domain"
+	"shien-system/internal/validation"
+
+	"fyne.io/fyne/v2/widget"
+)
+
+// maxPickerOptions caps the drop-down of a code picker; typing part of a code
+// or name narrows it down
+const maxPickerOptions = 100
+
+// codeOption is one master entry offered by a code picker
+type codeOption struct {
+	code  string
+	name  string // Stored on the certificate, e.g. the issuer
+	label string // Shown in the drop-down, starting with the code
+}
+
+// codePicker picks an entry of the municipality or service type master. The
+// input shows "<code> <name>"; a well-formed code with a name can also be
+// typed while the master has not been imported.
+type codePicker struct {
+	entry   *widget.SelectEntry
+	options []codeOption
+	byCode  map[string]codeOption
+
+	digits func(string) string // Digits of a well-formed code, or ""
+	field  string              // Name of the field in messages
+	format string              // Message for a malformed code
+}
+
+// newCodePicker creates an empty picker
+func newCodePicker(placeHolder, field, format string, digits func(string) string) *codePicker {
+	p := &codePicker{
+		entry:  widget.NewSelectEntry(nil),
+		byCode: make(map[string]codeOption),
+		digits: digits,
+		field:  field,
+		format: format,
+	}
+	p.entry.SetPlaceHolder(placeHolder)
+	p.entry.Validator = p.validate
+	p.entry.OnChanged = p.filter
+	return p
+}
+
+// newMunicipalityPicker creates the picker for the issuing municipality
+func newMunicipalityPicker() *codePicker {
+	return newCodePicker("市町村番号または市町村名で検索", "市町村",
+		"市町村番号は検査数字を含む6桁の数字で入力してください", validation.MunicipalityCodeDigits)
+}
+
+// newServiceTypePicker creates the picker for the service type
+func newServiceTypePicker() *codePicker {
+	return newCodePicker("サービス種類コードまたは名称で検索", "サービス種類",
+		"サービス種類コードは2桁の数字で入力してください", validation.ServiceCodeDigits)
+}
+
+// setMunicipalities offers the municipality master
+func (p *codePicker) setMunicipalities(municipalities []*domain.Municipality) {
+	options := make([]codeOption, len(municipalities))
+	for i, municipality := range municipalities {
+		options[i] = codeOption{
+			code:  municipality.Code,
+			name:  municipality.Name,
+			label: fmt.Sprintf("%s %s（%s）", municipality.Code, municipality.Name, municipality.Prefecture),
+		}
+	}
+	p.setOptions(options)
+}
+
+// setServiceTypes offers the service type master
+func (p *codePicker) setServiceTypes(serviceTypes []*domain.ServiceType) {
+	options := make([]codeOption, len(serviceTypes))
+	for i, serviceType := range serviceTypes {
+		options[i] = codeOption{
+			code:  serviceType.Code,
+			name:  serviceType.Name,
+			label: serviceType.Code + " " + serviceType.Name,
+		}
+	}
+	p.setOptions(options)
+}
+
+func (p *codePicker) setOptions(options []codeOption) {
+	p.options = options
+	p.byCode = make(map[string]codeOption, len(options))
+	for _, option := range options {
+		p.byCode[option.code] = option
+	}
+	p.filter(p.entry.Text)
+}
+
+// filter narrows the drop-down to the entries containing text
+func (p *codePicker) filter(text string) {
+	p.entry.SetOptions(p.matches(text))
+}
+
+// matches returns the labels of the entries containing text
+func (p *codePicker) matches(text string) []string {
+	key := validation.NormalizeSearch(strings.TrimSpace(text))
+	if option, ok := p.byCode[p.code(text)]; ok && option.label == text {
+		key = "" // A chosen entry lists every entry again
+	}
+
+	var labels []string
+	for _, option := range p.options {
+		if len(labels) == maxPickerOptions {
+			break
+		}
+		if key == "" || strings.Contains(validation.NormalizeSearch(option.label), key) {
+			labels = append(labels, option.label)
+		}
+	}
+	return labels
+}
+
+// code returns the well-formed code at the start of text, or ""
+func (p *codePicker) code(text string) string {
+	fields := strings.Fields(validation.NormalizeText(text))
+	if len(fields) == 0 {
+		return ""
+	}
+	return p.digits(fields[0])
+}
+
+// value returns the chosen code and name. The name comes from the master when
+// it knows the code, otherwise from the text after the code.
+func (p *codePicker) value() (code, name string) {
+	fields := strings.Fields(validation.NormalizeText(p.entry.Text))
+	if len(fields) == 0 {
+		return "", ""
+	}
+	code = p.digits(fields[0])
+	if option, ok := p.byCode[code]; ok {
+		return code, option.name
+	}
+	return code, strings.Join(fields[1:], " ")
+}
+
+// setValue shows code and name like the drop-down does. Certificates saved
+// before the masters only have a name.
+func (p *codePicker) setValue(code, name string) {
+	p.entry.SetText(p.label(code, name))
+}
+
+// label formats code and name as the drop-down does
+func (p *codePicker) label(code, name string) string {
+	if option, ok := p.byCode[code]; ok {
+		return option.label
+	}
+	return strings.TrimSpace(code + " " + name)
+}
+
+// validate accepts an empty input, a master entry and, while the master is
+// empty, any well-formed code
+func (p *codePicker) validate(text string) error {
+	if strings.TrimSpace(text) == "" {
+		return nil
+	}
+	code := p.code(text)
+	if code == "" {
+		return errors.New(p.format)
+	}
+	if _, ok := p.byCode[code]; !ok && len(p.options) > 0 {
+		return fmt.Errorf("%sの一覧にないコードです: %s", p.field, code)
+	}
+	return nil
+}
+
+// check validates the input for saving, where a choice is required
+func (p *codePicker) check() error {
+	if strings.TrimSpace(p.entry.Text) == "" {
+		return fmt.Errorf("%sを選択してください", p.field)
+	}
+	if err := p.validate(p.entry.Text); err != nil {
+		return err
+	}
+	if _, name := p.value(); name == "" {
+		return fmt.Errorf("%sの名称を入力してください（例：コードの後に空白を入れて名称）", p.field)
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package widgets
+
+import (
+	"testing"
+
+	"shien-system/internal/domain"
+
+	"fyne.io/fyne/v2/test"
+)
+
+func TestCodePicker_MunicipalityMaster(t *testing.T) {
+	app := test.NewApp()
+	defer app.Quit()
+
+	picker := newMunicipalityPicker()
+
+	// Before the master is imported, a well-formed code is typed with its name
+	picker.entry.SetText("１４１００３ 横浜市")
+	if err := picker.check(); err != nil {
+		t.Fatalf("check() without master error = %v", err)
+	}
+	if code, name := picker.value(); code != "141003" || name != "横浜市" {
+		t.Errorf("value() = %q, %q, want 141003 横浜市", code, name)
+	}
+	if err := picker.validate("141004 横浜市"); err == nil {
+		t.Error("validate() accepted a wrong check digit")
+	}
+	picker.entry.SetText("141003")
+	if err := picker.check(); err == nil {
+		t.Error("check() accepted a code without a name while the master is empty")
+	}
+
+	picker.setMunicipalities([]*domain.Municipality{
+		{Code: "011002", Prefecture: "北海道", Name: "札幌市"},
+		{Code: "131016", Prefecture: "東京都", Name: "千代田区"},
+	})
+
+	// Typing part of a name narrows the drop-down
+	if labels := picker.matches("千代田"); len(labels) != 1 || labels[0] != "131016 千代田区（東京都）" {
+		t.Errorf("matches() = %v, want the 千代田区 entry only", labels)
+	}
+
+	// A code alone is enough once the master knows it
+	picker.entry.SetText("131016")
+	if err := picker.check(); err != nil {
+		t.Errorf("check() with master error = %v", err)
+	}
+	if code, name := picker.value(); code != "131016" || name != "千代田区" {
+		t.Errorf("value() = %q, %q, want the name from the master", code, name)
+	}
+
+	if err := picker.validate("141003 横浜市"); err == nil {
+		t.Error("validate() accepted a code missing from the master")
+	}
+
+	// Certificates saved before the masters only have a name
+	picker.setValue("", "千代田区役所")
+	if picker.entry.Text != "千代田区役所" {
+		t.Errorf("setValue() text = %q", picker.entry.Text)
+	}
+	if err := picker.check(); err == nil {
+		t.Error("check() accepted a name without a code")
+	}
+}
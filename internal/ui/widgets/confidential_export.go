@@ -0,0 +1,122 @@
+package widgets
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"shien-system/internal/adapter/pdf"
+	"shien-system/internal/domain"
+	"shien-system/internal/usecase"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/widget"
+)
+
+// minExportPasswordLength matches the disclosure package password rule
+const minExportPasswordLength = 8
+
+// confidentialExport describes a PDF of personal data about to leave the application
+type confidentialExport struct {
+	report   string // Report name for the audit log, e.g. 受給者証一覧
+	target   string // Audit target, e.g. report:certificate_list
+	fileName string // Suggested file name
+	count    int    // Number of records in the report
+	generate func(opts pdf.ExportOptions) ([]byte, error)
+}
+
+// exportConfidentialPDF asks for optional password protection, renders the
+// report with a watermark naming the current user, records the export with
+// the SHA-256 of the file and only then writes the file
+func exportConfidentialPDF(parent fyne.Window, exportUseCase usecase.ExportUseCase, currentUser *domain.Staff, export confidentialExport) {
+	if exportUseCase == nil || currentUser == nil {
+		dialog.ShowError(fmt.Errorf("出力記録が利用できないため、PDFを出力できません"), parent)
+		return
+	}
+
+	passwordEntry := widget.NewPasswordEntry()
+	passwordEntry.SetPlaceHolder("8文字以上（空欄の場合は保護なし）")
+	allowPrintCheck := widget.NewCheck("印刷を許可", nil)
+	allowPrintCheck.SetChecked(true)
+	allowCopyCheck := widget.NewCheck("文字のコピーを許可", nil)
+	notice := widget.NewLabel("各ページに「持出禁止」と出力者名・出力日時が入ります。")
+	notice.Wrapping = fyne.TextWrapWord
+
+	items := []*widget.FormItem{
+		widget.NewFormItem("PDFパスワード", passwordEntry),
+		widget.NewFormItem("パスワード保護時", allowPrintCheck),
+		widget.NewFormItem("", allowCopyCheck),
+		widget.NewFormItem("", notice),
+	}
+
+	dialog.ShowForm(export.report+"のPDF出力", "出力", "キャンセル", items, func(confirmed bool) {
+		if !confirmed {
+			return
+		}
+
+		password := passwordEntry.Text
+		if password != "" && len(password) < minExportPasswordLength {
+			dialog.ShowError(fmt.Errorf("パスワードは%d文字以上で指定してください", minExportPasswordLength), parent)
+			return
+		}
+
+		opts := pdf.ExportOptions{
+			ExportedBy: currentUser.Name,
+			ExportedAt: time.Now(),
+			Password:   password,
+			AllowPrint: allowPrintCheck.Checked,
+			AllowCopy:  allowCopyCheck.Checked,
+		}
+		data, err := export.generate(opts)
+		if err != nil {
+			dialog.ShowError(err, parent)
+			return
+		}
+
+		saveConfidentialPDF(parent, exportUseCase, currentUser, export, data, opts.Protected())
+	}, parent)
+}
+
+// saveConfidentialPDF lets the user pick a destination, records the export and writes the file.
+// When the export cannot be recorded the empty file is removed again.
+func saveConfidentialPDF(parent fyne.Window, exportUseCase usecase.ExportUseCase, currentUser *domain.Staff, export confidentialExport, data []byte, protected bool) {
+	saveDialog := dialog.NewFileSave(func(writer fyne.URIWriteCloser, err error) {
+		if err != nil {
+			dialog.ShowError(fmt.Errorf("ファイルの保存に失敗しました: %w", err), parent)
+			return
+		}
+		if writer == nil {
+			return // User cancelled
+		}
+
+		record, err := exportUseCase.RecordExport(context.Background(), usecase.RecordExportRequest{
+			Report:    export.report,
+			Target:    export.target,
+			FileName:  writer.URI().Name(),
+			Data:      data,
+			Count:     export.count,
+			Protected: protected,
+			ActorID:   currentUser.ID,
+		})
+		if err != nil {
+			writer.Close()
+			_ = storage.Delete(writer.URI())
+			dialog.ShowError(fmt.Errorf("PDFを出力できませんでした: %w", err), parent)
+			return
+		}
+		defer writer.Close()
+
+		if _, err := writer.Write(data); err != nil {
+			dialog.ShowError(fmt.Errorf("ファイルの書き込みに失敗しました: %w", err), parent)
+			return
+		}
+
+		dialog.ShowInformation("成功", fmt.Sprintf("%sをPDFに出力しました（%d件）。\n出力記録 SHA-256: %s", export.report, export.count, record.SHA256), parent)
+	}, parent)
+
+	saveDialog.SetFileName(export.fileName)
+	saveDialog.SetFilter(storage.NewExtensionFileFilter([]string{".pdf"}))
+	saveDialog.Show()
+}
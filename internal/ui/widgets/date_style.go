@@ -0,0 +1,21 @@
+package widgets
+
+import (
+	"shien-system/internal/domain"
+	"shien-system/internal/wareki"
+)
+
+// datePlaceHolder hints that both calendars are accepted in date entries
+const datePlaceHolder = "2025/04/01 または R7.4.1"
+
+// dateStyleOf returns how dates are shown to user; 西暦 when unknown
+func dateStyleOf(user *domain.Staff) wareki.Style {
+	if user == nil {
+		return wareki.StyleGregorian
+	}
+	style, err := wareki.ParseStyle(user.DateStyle)
+	if err != nil {
+		return wareki.StyleGregorian
+	}
+	return style
+}
@@ -0,0 +1,248 @@
+package widgets
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"shien-system/internal/domain"
+	"shien-system/internal/wareki"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+)
+
+// supportLevelUncertified is the support category option for recipients
+// without a certification
+const supportLevelUncertified = "未認定"
+
+// handbookRow holds the inputs of one handbook type
+type handbookRow struct {
+	handbookType domain.HandbookType
+	number       *widget.Entry
+	grade        *widget.Entry
+	renewal      *widget.Entry
+}
+
+// disabilityFields edits the handbooks, support category and intractable
+// disease of a recipient on the recipient form
+type disabilityFields struct {
+	handbooks     []*handbookRow // One per domain.HandbookTypes entry
+	supportLevel  *widget.Select
+	supportFrom   *widget.Entry
+	supportUntil  *widget.Entry
+	diseaseName   *widget.Entry
+	diseaseExpiry *widget.Entry
+}
+
+// newDisabilityFields creates the inputs
+func newDisabilityFields() *disabilityFields {
+	df := &disabilityFields{}
+
+	for _, handbookType := range domain.HandbookTypes {
+		row := &handbookRow{
+			handbookType: handbookType,
+			number:       widget.NewEntry(),
+			grade:        widget.NewEntry(),
+			renewal:      widget.NewEntry(),
+		}
+		row.number.SetPlaceHolder("番号")
+		row.grade.SetPlaceHolder("等級・程度")
+		row.renewal.SetPlaceHolder("更新日 (" + datePlaceHolder + ")")
+		df.handbooks = append(df.handbooks, row)
+	}
+
+	levels := []string{supportLevelUncertified}
+	for level := domain.SupportCategoryMin; level <= domain.SupportCategoryMax; level++ {
+		levels = append(levels, domain.SupportCategoryLabel(level))
+	}
+	levels = append(levels, domain.SupportCategoryLabel(domain.SupportCategoryNone))
+	df.supportLevel = widget.NewSelect(levels, nil)
+	df.supportLevel.Selected = supportLevelUncertified
+
+	df.supportFrom = widget.NewEntry()
+	df.supportFrom.SetPlaceHolder("有効期間の開始 (" + datePlaceHolder + ")")
+	df.supportUntil = widget.NewEntry()
+	df.supportUntil.SetPlaceHolder("有効期間の終了 (" + datePlaceHolder + ")")
+
+	df.diseaseName = widget.NewEntry()
+	df.diseaseName.SetPlaceHolder("指定難病の疾病名")
+	df.diseaseExpiry = widget.NewEntry()
+	df.diseaseExpiry.SetPlaceHolder("医療受給者証の有効期限 (" + datePlaceHolder + ")")
+
+	return df
+}
+
+// conflictFields binds the inputs for the edit conflict dialog, in the order of values
+func (df *disabilityFields) conflictFields() []conflictField {
+	entry := func(label string, e *widget.Entry) conflictField {
+		return conflictField{label: label, get: func() string { return strings.TrimSpace(e.Text) }, set: e.SetText}
+	}
+	var fields []conflictField
+	for _, row := range df.handbooks {
+		label := row.handbookType.Label()
+		fields = append(fields,
+			entry(label+" 番号", row.number),
+			entry(label+" 等級", row.grade),
+			entry(label+" 更新日", row.renewal),
+		)
+	}
+	return append(fields,
+		conflictField{label: "障害支援区分", get: func() string { return df.supportLevel.Selected }, set: df.supportLevel.SetSelected},
+		entry("障害支援区分 有効期間開始", df.supportFrom),
+		entry("障害支援区分 有効期間終了", df.supportUntil),
+		entry("指定難病", df.diseaseName),
+		entry("医療受給者証の有効期限", df.diseaseExpiry),
+	)
+}
+
+// values formats the recipient's data in the order of conflictFields
+func (df *disabilityFields) values(recipient *domain.Recipient, style wareki.Style) []string {
+	optionalDate := func(date *time.Time) string {
+		if date == nil {
+			return ""
+		}
+		return style.Format(*date)
+	}
+
+	var values []string
+	for _, row := range df.handbooks {
+		handbook := recipient.Handbook(row.handbookType)
+		if handbook == nil {
+			values = append(values, "", "", "")
+			continue
+		}
+		values = append(values, handbook.Number, handbook.Grade, optionalDate(handbook.RenewalDate))
+	}
+
+	if category := recipient.SupportCategory; category != nil {
+		values = append(values, category.Label(), style.Format(category.ValidFrom), style.Format(category.ValidUntil))
+	} else {
+		values = append(values, supportLevelUncertified, "", "")
+	}
+
+	if disease := recipient.IntractableDisease; disease != nil {
+		values = append(values, disease.Name, optionalDate(disease.ValidUntil))
+	} else {
+		values = append(values, "", "")
+	}
+
+	return values
+}
+
+// clear resets every input
+func (df *disabilityFields) clear() {
+	for _, row := range df.handbooks {
+		row.number.SetText("")
+		row.grade.SetText("")
+		row.renewal.SetText("")
+	}
+	df.supportLevel.SetSelected(supportLevelUncertified)
+	df.supportFrom.SetText("")
+	df.supportUntil.SetText("")
+	df.diseaseName.SetText("")
+	df.diseaseExpiry.SetText("")
+}
+
+// widgets returns every input, for enabling and disabling the form
+func (df *disabilityFields) widgets() []fyne.Disableable {
+	var widgets []fyne.Disableable
+	for _, row := range df.handbooks {
+		widgets = append(widgets, row.number, row.grade, row.renewal)
+	}
+	return append(widgets, df.supportLevel, df.supportFrom, df.supportUntil, df.diseaseName, df.diseaseExpiry)
+}
+
+// build reads the inputs. Blank handbook rows and a blank disease are left
+// out; the usecase checks the rest.
+func (df *disabilityFields) build() ([]domain.DisabilityHandbook, *domain.SupportCategory, *domain.IntractableDisease, error) {
+	var handbooks []domain.DisabilityHandbook
+	for _, row := range df.handbooks {
+		number, grade := strings.TrimSpace(row.number.Text), strings.TrimSpace(row.grade.Text)
+		renewal, err := parseOptionalDate(row.renewal.Text, row.handbookType.Label()+"の更新日")
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		if number == "" && grade == "" && renewal == nil {
+			continue
+		}
+		handbooks = append(handbooks, domain.DisabilityHandbook{
+			Type:        row.handbookType,
+			Number:      number,
+			Grade:       grade,
+			RenewalDate: renewal,
+		})
+	}
+
+	var category *domain.SupportCategory
+	if selected := df.supportLevel.Selected; selected != "" && selected != supportLevelUncertified {
+		category = &domain.SupportCategory{Level: domain.SupportCategoryNone}
+		for level := domain.SupportCategoryMin; level <= domain.SupportCategoryMax; level++ {
+			if domain.SupportCategoryLabel(level) == selected {
+				category.Level = level
+			}
+		}
+		from, err := parseOptionalDate(df.supportFrom.Text, "障害支援区分の有効期間の開始")
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		until, err := parseOptionalDate(df.supportUntil.Text, "障害支援区分の有効期間の終了")
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		if from != nil {
+			category.ValidFrom = *from
+		}
+		if until != nil {
+			category.ValidUntil = *until
+		}
+	}
+
+	var disease *domain.IntractableDisease
+	expiry, err := parseOptionalDate(df.diseaseExpiry.Text, "医療受給者証の有効期限")
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if name := strings.TrimSpace(df.diseaseName.Text); name != "" || expiry != nil {
+		disease = &domain.IntractableDisease{Name: name, ValidUntil: expiry}
+	}
+
+	return handbooks, category, disease, nil
+}
+
+// CreateObject lays out the inputs for the disability section of the form
+func (df *disabilityFields) CreateObject() fyne.CanvasObject {
+	handbookGrid := container.NewGridWithColumns(4,
+		widget.NewLabel("手帳"), widget.NewLabel("番号"), widget.NewLabel("等級"), widget.NewLabel("更新日"),
+	)
+	for _, row := range df.handbooks {
+		handbookGrid.Add(widget.NewLabel(row.handbookType.Label()))
+		handbookGrid.Add(row.number)
+		handbookGrid.Add(row.grade)
+		handbookGrid.Add(row.renewal)
+	}
+
+	return container.NewVBox(
+		handbookGrid,
+		container.NewGridWithColumns(2,
+			widget.NewLabel("障害支援区分:"), df.supportLevel,
+			widget.NewLabel("有効期間:"), container.NewGridWithColumns(2, df.supportFrom, df.supportUntil),
+			widget.NewLabel("指定難病:"), df.diseaseName,
+			widget.NewLabel("医療受給者証の有効期限:"), df.diseaseExpiry,
+		),
+	)
+}
+
+// parseOptionalDate parses a date input, returning nil when it is blank
+func parseOptionalDate(text, fieldName string) (*time.Time, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil, nil
+	}
+	parsed, err := wareki.Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("%sの形式が正しくありません (%s の形式で入力してください): %w", fieldName, datePlaceHolder, err)
+	}
+	return &parsed, nil
+}
@@ -0,0 +1,312 @@
+package widgets
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"shien-system/internal/domain"
+	"shien-system/internal/usecase"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+// Score thresholds offered by the duplicate search
+var duplicateScoreOptions = []struct {
+	label string
+	score int
+}{
+	{"高い候補のみ（80点以上）", 80},
+	{"標準（60点以上）", 60},
+	{"広く探す（40点以上）", 40},
+}
+
+// DuplicateView lets administrators find recipients registered more than
+// once and merge them. The search decrypts every recipient, so it only runs
+// on request.
+type DuplicateView struct {
+	useCase     usecase.RecipientMergeUseCase
+	currentUser *domain.Staff
+
+	// UI components
+	scoreSelect    *widget.Select
+	searchButton   *widget.Button
+	mergeButton    *widget.Button
+	historyButton  *widget.Button
+	summaryLabel   *widget.Label
+	candidateTable *widget.Table
+
+	// Data
+	candidates []*usecase.DuplicateCandidate
+	selected   int
+
+	// Callbacks
+	onMerged func()
+}
+
+// NewDuplicateView creates a new DuplicateView widget
+func NewDuplicateView(useCase usecase.RecipientMergeUseCase, currentUser *domain.Staff) *DuplicateView {
+	dv := &DuplicateView{
+		useCase:     useCase,
+		currentUser: currentUser,
+		selected:    -1,
+	}
+
+	dv.createWidgets()
+
+	return dv
+}
+
+// SetOnMerged sets the callback run after a merge, e.g. to reload the lists
+func (dv *DuplicateView) SetOnMerged(callback func()) {
+	dv.onMerged = callback
+}
+
+// createWidgets initializes all UI components
+func (dv *DuplicateView) createWidgets() {
+	labels := make([]string, len(duplicateScoreOptions))
+	for i, option := range duplicateScoreOptions {
+		labels[i] = option.label
+	}
+	dv.scoreSelect = widget.NewSelect(labels, nil)
+	dv.scoreSelect.SetSelected(duplicateScoreOptions[1].label)
+
+	dv.searchButton = widget.NewButton("重複候補を検索", func() {
+		dv.Search()
+	})
+	dv.mergeButton = widget.NewButton("統合...", func() {
+		dv.showMergeDialog()
+	})
+	dv.historyButton = widget.NewButton("統合履歴", func() {
+		dv.showHistory()
+	})
+
+	dv.summaryLabel = widget.NewLabel("まだ検索していません。")
+
+	dv.candidateTable = newSecurityTable(
+		[]float32{60, 260, 260, 320},
+		func() int { return len(dv.candidates) },
+		func(row, col int) string { return dv.candidateCell(dv.candidates[row], col) },
+	)
+	dv.candidateTable.OnSelected = func(id widget.TableCellID) {
+		dv.selected = id.Row
+		dv.updateButtons()
+	}
+
+	dv.updateButtons()
+}
+
+// candidateCell formats one cell of the candidate table
+func (dv *DuplicateView) candidateCell(candidate *usecase.DuplicateCandidate, col int) string {
+	switch col {
+	case 0:
+		return fmt.Sprintf("%d点", candidate.Score)
+	case 1:
+		return dv.describeRecipient(candidate.First)
+	case 2:
+		return dv.describeRecipient(candidate.Second)
+	default:
+		return strings.Join(candidate.Reasons, "・")
+	}
+}
+
+// describeRecipient identifies a recipient in the table and dialogs
+func (dv *DuplicateView) describeRecipient(recipient *domain.Recipient) string {
+	return fmt.Sprintf("%s（%s）%s", recipient.Name, valueOrDash(recipient.Kana),
+		dateStyleOf(dv.currentUser).FormatShort(recipient.BirthDate))
+}
+
+// Search looks for duplicate candidates with the chosen threshold
+func (dv *DuplicateView) Search() {
+	if !dv.isAdmin() {
+		return
+	}
+
+	minScore := 0
+	for _, option := range duplicateScoreOptions {
+		if option.label == dv.scoreSelect.Selected {
+			minScore = option.score
+		}
+	}
+
+	candidates, err := dv.useCase.FindDuplicates(context.Background(), usecase.FindDuplicatesRequest{
+		MinScore: minScore,
+		ActorID:  dv.currentUser.ID,
+	})
+	if err != nil {
+		dv.showError(fmt.Errorf("重複候補の検索に失敗しました: %w", err))
+		return
+	}
+
+	dv.candidates = candidates
+	dv.selected = -1
+	dv.candidateTable.UnselectAll()
+	if len(candidates) == 0 {
+		dv.summaryLabel.SetText("重複の候補は見つかりませんでした。")
+	} else {
+		dv.summaryLabel.SetText(fmt.Sprintf("%d組の候補が見つかりました。左側は先に登録された利用者です。", len(candidates)))
+	}
+	dv.candidateTable.Refresh()
+	dv.updateButtons()
+}
+
+func (dv *DuplicateView) updateButtons() {
+	if dv.selectedCandidate() == nil {
+		dv.mergeButton.Disable()
+		dv.historyButton.Disable()
+		return
+	}
+	dv.mergeButton.Enable()
+	dv.historyButton.Enable()
+}
+
+func (dv *DuplicateView) selectedCandidate() *usecase.DuplicateCandidate {
+	if dv.selected < 0 || dv.selected >= len(dv.candidates) {
+		return nil
+	}
+	return dv.candidates[dv.selected]
+}
+
+// showMergeDialog lets the administrator choose the record to keep and merge the pair
+func (dv *DuplicateView) showMergeDialog() {
+	candidate := dv.selectedCandidate()
+	if candidate == nil {
+		return
+	}
+
+	first, second := dv.describeRecipient(candidate.First), dv.describeRecipient(candidate.Second)
+	survivorRadio := widget.NewRadioGroup([]string{first, second}, nil)
+	survivorRadio.Required = true
+	survivorRadio.SetSelected(first)
+
+	reasonEntry := widget.NewMultiLineEntry()
+	reasonEntry.SetPlaceHolder("理由（必須。例: 異体字で二重登録されていたため）")
+
+	notice := widget.NewLabel("もう一方の利用者の受給者証・担当・同意・在籍期間・緊急連絡先・事故報告を残す利用者に移し、" +
+		"統合前の内容を統合履歴に保存してから削除します。この操作は元に戻せません。")
+	notice.Wrapping = fyne.TextWrapWord
+
+	dialog.ShowForm("利用者の統合", "統合", "キャンセル",
+		[]*widget.FormItem{
+			widget.NewFormItem("残す利用者", survivorRadio),
+			widget.NewFormItem("理由", reasonEntry),
+			widget.NewFormItem("", notice),
+		},
+		func(confirmed bool) {
+			if !confirmed {
+				return
+			}
+
+			survivor, merged := candidate.First, candidate.Second
+			if survivorRadio.Selected == second {
+				survivor, merged = merged, survivor
+			}
+			dv.merge(survivor, merged, reasonEntry.Text)
+		}, dv.parentWindow())
+}
+
+// merge merges the pair and searches again
+func (dv *DuplicateView) merge(survivor, merged *domain.Recipient, reason string) {
+	merge, err := dv.useCase.MergeRecipients(context.Background(), usecase.MergeRecipientsRequest{
+		SurvivorID: survivor.ID,
+		MergedID:   merged.ID,
+		Reason:     reason,
+		ActorID:    dv.currentUser.ID,
+	})
+	if err != nil {
+		dv.showError(fmt.Errorf("統合できませんでした: %w", err))
+		return
+	}
+
+	moved := merge.Moved
+	dialog.ShowInformation("統合", fmt.Sprintf("「%s」を「%s」に統合しました。\n受給者証 %d件・担当 %d件・同意 %d件・在籍期間 %d件・緊急連絡先 %d件・事故報告 %d件を移しました。",
+		merged.Name, survivor.Name, moved.Certificates, moved.Assignments, moved.Consents,
+		moved.EnrollmentPeriods, moved.EmergencyContacts, moved.Incidents), dv.parentWindow())
+
+	if dv.onMerged != nil {
+		dv.onMerged()
+	}
+	dv.Search()
+}
+
+// showHistory shows the recipients merged into either record of the selected pair
+func (dv *DuplicateView) showHistory() {
+	candidate := dv.selectedCandidate()
+	if candidate == nil {
+		return
+	}
+
+	var lines []string
+	for _, recipient := range []*domain.Recipient{candidate.First, candidate.Second} {
+		merges, err := dv.useCase.GetMergeHistory(context.Background(), recipient.ID, dv.currentUser.ID)
+		if err != nil {
+			dv.showError(fmt.Errorf("統合履歴を取得できませんでした: %w", err))
+			return
+		}
+		for _, merge := range merges {
+			lines = append(lines, fmt.Sprintf("%s 「%s」に「%s」（ID: %s）を統合　理由: %s",
+				formatSecurityTime(merge.MergedAt), recipient.Name, merge.Merged.Name, merge.Merged.ID, merge.Reason))
+		}
+	}
+	if len(lines) == 0 {
+		lines = append(lines, "この2名への統合履歴はありません。")
+	}
+
+	label := widget.NewLabel(strings.Join(lines, "\n"))
+	label.Wrapping = fyne.TextWrapWord
+	scroll := container.NewVScroll(label)
+	scroll.SetMinSize(fyne.NewSize(560, 200))
+	dialog.ShowCustom("統合履歴", "閉じる", scroll, dv.parentWindow())
+}
+
+func (dv *DuplicateView) isAdmin() bool {
+	return dv.currentUser != nil && dv.currentUser.Role == domain.RoleAdmin
+}
+
+// parentWindow returns the main window for dialogs
+func (dv *DuplicateView) parentWindow() fyne.Window {
+	return fyne.CurrentApp().Driver().AllWindows()[0]
+}
+
+// showError shows an error dialog on the main window
+func (dv *DuplicateView) showError(err error) {
+	if app := fyne.CurrentApp(); app != nil && len(app.Driver().AllWindows()) > 0 {
+		dialog.ShowError(err, app.Driver().AllWindows()[0])
+	}
+}
+
+// CreateObject creates the UI object for the duplicate view
+func (dv *DuplicateView) CreateObject() fyne.CanvasObject {
+	if !dv.isAdmin() {
+		return container.NewCenter(widget.NewLabel("この画面は管理者のみ利用できます。"))
+	}
+
+	header := container.NewBorder(
+		nil, nil,
+		widget.NewLabel("重複利用者の統合"),
+		container.NewHBox(dv.scoreSelect, dv.searchButton),
+		dv.summaryLabel,
+	)
+
+	actions := container.NewHBox(dv.mergeButton, dv.historyButton)
+
+	headers := []string{"スコア", "利用者（先に登録）", "利用者（後から登録）", "一致した項目"}
+	headerWidgets := make([]fyne.CanvasObject, len(headers))
+	for i, text := range headers {
+		label := widget.NewLabel(text)
+		label.TextStyle.Bold = true
+		headerWidgets[i] = label
+	}
+
+	top := container.NewVBox(header, actions, container.NewHBox(headerWidgets...))
+
+	return container.NewBorder(top, nil, nil, nil, dv.candidateTable)
+}
+
+// Length returns the number of candidates shown (for testing)
+func (dv *DuplicateView) Length() int {
+	return len(dv.candidates)
+}
@@ -0,0 +1,80 @@
+package widgets
+
+import (
+	"testing"
+	"time"
+
+	"shien-system/internal/domain"
+	"shien-system/internal/usecase"
+
+	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/widget"
+)
+
+func TestDuplicateView_SearchAndMerge(t *testing.T) {
+	app := test.NewApp()
+	defer app.Quit()
+	app.NewWindow("test").Show()
+
+	birth := time.Date(1980, 4, 1, 0, 0, 0, 0, time.UTC)
+	mock := &MockRecipientMergeUseCase{
+		candidates: []*usecase.DuplicateCandidate{{
+			First:   &domain.Recipient{ID: "recipient-001", Name: "髙橋 太郎", Kana: "タカハシ タロウ", BirthDate: birth},
+			Second:  &domain.Recipient{ID: "recipient-002", Name: "高橋太郎", Kana: "タカハシ タロウ", BirthDate: birth},
+			Score:   80,
+			Reasons: []string{"フリガナが一致", "生年月日が一致"},
+		}},
+	}
+	admin := &domain.Staff{ID: "admin-001", Name: "管理者", Role: domain.RoleAdmin}
+	view := NewDuplicateView(mock, admin)
+
+	merged := 0
+	view.SetOnMerged(func() { merged++ })
+
+	if !view.mergeButton.Disabled() {
+		t.Error("merge button enabled before a pair is selected")
+	}
+
+	view.scoreSelect.SetSelected(duplicateScoreOptions[0].label)
+	view.Search()
+	if view.Length() != 1 {
+		t.Fatalf("Length() = %d, want 1", view.Length())
+	}
+	if got := mock.searches[0].MinScore; got != 80 {
+		t.Errorf("MinScore = %d, want 80", got)
+	}
+	if got := view.candidateCell(view.candidates[0], 3); got != "フリガナが一致・生年月日が一致" {
+		t.Errorf("reasons cell = %q", got)
+	}
+
+	view.candidateTable.Select(widget.TableCellID{Row: 0, Col: 0})
+	if view.mergeButton.Disabled() {
+		t.Fatal("merge button disabled after selecting a pair")
+	}
+
+	candidate := view.selectedCandidate()
+	view.merge(candidate.Second, candidate.First, "異体字で二重登録")
+	if len(mock.merges) != 1 || mock.merges[0].SurvivorID != "recipient-002" || mock.merges[0].MergedID != "recipient-001" {
+		t.Errorf("merge requests = %+v", mock.merges)
+	}
+	if merged != 1 {
+		t.Errorf("onMerged called %d times, want 1", merged)
+	}
+	if view.Length() != 0 || !view.mergeButton.Disabled() {
+		t.Error("candidates were not searched again after the merge")
+	}
+}
+
+func TestDuplicateView_StaffCannotSearch(t *testing.T) {
+	app := test.NewApp()
+	defer app.Quit()
+
+	mock := &MockRecipientMergeUseCase{}
+	staff := &domain.Staff{ID: "staff-001", Name: "職員", Role: domain.RoleStaff}
+	view := NewDuplicateView(mock, staff)
+
+	view.Search()
+	if len(mock.searches) != 0 {
+		t.Error("staff member searched for duplicates")
+	}
+}
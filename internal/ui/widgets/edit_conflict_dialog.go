@@ -0,0 +1,177 @@
+package widgets
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"shien-system/internal/usecase"
+)
+
+// conflictField binds one form field to the edit conflict dialog. Values are
+// compared as the text shown in the form.
+type conflictField struct {
+	label string
+	get   func() string
+	set   func(string)
+}
+
+// editConflict holds the values of an edited record as they were when the
+// form was opened (base), as typed by the user (mine) and as stored by the
+// other staff member (theirs)
+type editConflict struct {
+	fields []conflictField
+	base   []string
+	mine   []string
+	theirs []string
+}
+
+func newEditConflict(fields []conflictField, base, theirs []string) *editConflict {
+	mine := make([]string, len(fields))
+	for i, field := range fields {
+		mine[i] = field.get()
+	}
+	return &editConflict{fields: fields, base: base, mine: mine, theirs: theirs}
+}
+
+// changedByMe reports whether the user edited field i
+func (c *editConflict) changedByMe(i int) bool {
+	return c.mine[i] != c.base[i]
+}
+
+// changedByThem reports whether the stored value of field i changed while the
+// form was open
+func (c *editConflict) changedByThem(i int) bool {
+	return c.theirs[i] != c.base[i]
+}
+
+// conflicting reports whether both sides changed field i to different values
+func (c *editConflict) conflicting(i int) bool {
+	return c.changedByMe(i) && c.changedByThem(i) && c.mine[i] != c.theirs[i]
+}
+
+// merge keeps the user's own edits and takes the stored value for every other
+// field. Fields both sides changed keep the user's value and are returned so
+// the user can review them before saving again.
+func (c *editConflict) merge() []string {
+	var overwritten []string
+	for i, field := range c.fields {
+		if !c.changedByMe(i) {
+			field.set(c.theirs[i])
+			continue
+		}
+		if c.conflicting(i) {
+			overwritten = append(overwritten, field.label)
+		}
+	}
+	return overwritten
+}
+
+// fieldValues reads the current value of every field
+func fieldValues(fields []conflictField) []string {
+	values := make([]string, len(fields))
+	for i, field := range fields {
+		values[i] = field.get()
+	}
+	return values
+}
+
+// setFieldValues writes values to the fields in order
+func setFieldValues(fields []conflictField, values []string) {
+	for i, field := range fields {
+		field.set(values[i])
+	}
+}
+
+// checkText formats a check box value for comparison and display
+func checkText(checked bool) string {
+	if checked {
+		return "はい"
+	}
+	return "いいえ"
+}
+
+// storedRecord returns the record the other staff member saved, or nil when
+// err is not an edit conflict or the record could not be reloaded
+func storedRecord(err error) interface{} {
+	var conflict *usecase.EditConflictError
+	if errors.As(err, &conflict) {
+		return conflict.Current
+	}
+	return nil
+}
+
+// showEditConflictDialog lists the fields that differ and lets the user
+// reload the stored record or merge their edits into it. Fields changed by
+// the other staff member are highlighted; fields both sides changed are
+// marked as conflicts.
+func showEditConflictDialog(parent fyne.Window, c *editConflict, onReload, onMerge func()) {
+	rows := container.NewGridWithColumns(4,
+		widget.NewLabelWithStyle("項目", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+		widget.NewLabelWithStyle("編集開始時", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+		widget.NewLabelWithStyle("あなたの入力", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+		widget.NewLabelWithStyle("保存済みの値", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+	)
+	for i, field := range c.fields {
+		if !c.changedByMe(i) && !c.changedByThem(i) {
+			continue
+		}
+
+		label := widget.NewLabel(field.label)
+		theirs := widget.NewLabel(c.theirs[i])
+		switch {
+		case c.conflicting(i):
+			label.SetText(field.label + "（競合）")
+			label.Importance = widget.DangerImportance
+			theirs.Importance = widget.DangerImportance
+		case c.changedByThem(i):
+			label.Importance = widget.WarningImportance
+			theirs.Importance = widget.WarningImportance
+		}
+		rows.Add(label)
+		rows.Add(widget.NewLabel(c.base[i]))
+		rows.Add(widget.NewLabel(c.mine[i]))
+		rows.Add(theirs)
+	}
+
+	message := widget.NewLabel("編集中に他の職員がこのデータを更新しました。\n" +
+		"「再読み込み」は入力内容を破棄して保存済みの値を表示します。\n" +
+		"「マージ」はあなたが変更した項目を残し、それ以外を保存済みの値にします。")
+	message.Wrapping = fyne.TextWrapWord
+
+	var dlg dialog.Dialog
+	reloadButton := widget.NewButton("再読み込み", func() {
+		dlg.Hide()
+		onReload()
+	})
+	mergeButton := widget.NewButton("マージ", func() {
+		dlg.Hide()
+		onMerge()
+	})
+	mergeButton.Importance = widget.HighImportance
+	cancelButton := widget.NewButton("キャンセル", func() {
+		dlg.Hide()
+	})
+
+	content := container.NewBorder(message,
+		container.NewHBox(reloadButton, mergeButton, cancelButton), nil, nil,
+		container.NewVScroll(rows))
+	dlg = dialog.NewCustomWithoutButtons("編集の競合", content, parent)
+	dlg.Resize(fyne.NewSize(640, 420))
+	dlg.Show()
+}
+
+// showMergeResult tells the user that the merged form has not been saved yet
+func showMergeResult(parent fyne.Window, overwritten []string) {
+	message := "保存済みの値とマージしました。内容を確認して、もう一度保存してください。"
+	if len(overwritten) > 0 {
+		message = fmt.Sprintf("%s\n\n次の項目はあなたの入力を残しています（他の職員の変更を上書きします）:\n• %s",
+			message, strings.Join(overwritten, "\n• "))
+	}
+	dialog.ShowInformation("マージ", message, parent)
+}
@@ -0,0 +1,38 @@
+package widgets
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestEditConflict_Merge(t *testing.T) {
+	// Name: changed by me only, Phone: changed by them only,
+	// Address: changed by both, Email: unchanged
+	values := []string{"山田 太郎", "090-0000-0000", "東京都", "a@example.com"}
+	labels := []string{"氏名", "電話番号", "住所", "メール"}
+	fields := make([]conflictField, len(values))
+	for i := range fields {
+		i := i
+		fields[i] = conflictField{
+			label: labels[i],
+			get:   func() string { return values[i] },
+			set:   func(value string) { values[i] = value },
+		}
+	}
+	base := []string{"山田 太朗", "090-0000-0000", "大阪府", "a@example.com"}
+	theirs := []string{"山田 太朗", "080-1111-1111", "京都府", "a@example.com"}
+
+	conflict := newEditConflict(fields, base, theirs)
+	if conflict.changedByThem(0) || !conflict.changedByThem(1) || !conflict.conflicting(2) || conflict.conflicting(3) {
+		t.Fatalf("unexpected change detection: mine=%v", conflict.mine)
+	}
+
+	overwritten := conflict.merge()
+	want := []string{"山田 太郎", "080-1111-1111", "東京都", "a@example.com"}
+	if !reflect.DeepEqual(values, want) {
+		t.Errorf("merged values = %v, want %v", values, want)
+	}
+	if !reflect.DeepEqual(overwritten, []string{"住所"}) {
+		t.Errorf("overwritten = %v, want [住所]", overwritten)
+	}
+}
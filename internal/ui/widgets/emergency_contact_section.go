@@ -0,0 +1,340 @@
+package widgets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"shien-system/internal/adapter/pdf"
+	"shien-system/internal/domain"
+	"shien-system/internal/usecase"
+	"shien-system/internal/validation"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/widget"
+)
+
+// EmergencyContactSection lists and edits the emergency contacts of a recipient
+type EmergencyContactSection struct {
+	useCase    usecase.EmergencyContactUseCase
+	pdfService *pdf.PDFService
+
+	// UI components
+	list        *fyne.Container
+	addButton   *widget.Button
+	printButton *widget.Button
+
+	// State
+	recipient   *domain.Recipient
+	currentUser *domain.Staff
+	contacts    []*domain.EmergencyContact
+}
+
+// NewEmergencyContactSection creates a new emergency contact section
+func NewEmergencyContactSection(useCase usecase.EmergencyContactUseCase, pdfService *pdf.PDFService) *EmergencyContactSection {
+	section := &EmergencyContactSection{
+		useCase:    useCase,
+		pdfService: pdfService,
+	}
+	section.createWidgets()
+	return section
+}
+
+// createWidgets initializes all UI components
+func (ecs *EmergencyContactSection) createWidgets() {
+	ecs.list = container.NewVBox()
+
+	ecs.addButton = widget.NewButton("連絡先を追加", func() {
+		ecs.showContactDialog(nil)
+	})
+
+	ecs.printButton = widget.NewButton("緊急連絡先シート印刷", func() {
+		ecs.exportSheet()
+	})
+}
+
+// SetRecipient loads the contacts of the recipient being edited
+func (ecs *EmergencyContactSection) SetRecipient(recipient *domain.Recipient, currentUser *domain.Staff) {
+	ecs.recipient = recipient
+	ecs.currentUser = currentUser
+
+	if currentUser != nil && currentUser.Role == domain.RoleReadOnly {
+		ecs.addButton.Disable()
+	} else {
+		ecs.addButton.Enable()
+	}
+
+	ecs.LoadData()
+}
+
+// LoadData reloads the contact list
+func (ecs *EmergencyContactSection) LoadData() {
+	ecs.list.RemoveAll()
+	if ecs.recipient == nil {
+		return
+	}
+
+	contacts, err := ecs.useCase.GetEmergencyContacts(context.Background(), ecs.recipient.ID)
+	if err != nil {
+		ecs.list.Add(widget.NewLabel("緊急連絡先を取得できませんでした"))
+		return
+	}
+	ecs.contacts = contacts
+
+	if len(contacts) == 0 {
+		ecs.list.Add(widget.NewLabel("緊急連絡先は登録されていません"))
+		return
+	}
+
+	canEdit := ecs.currentUser != nil && ecs.currentUser.Role != domain.RoleReadOnly
+	for _, contact := range contacts {
+		contact := contact
+		label := widget.NewLabel(formatEmergencyContact(contact))
+		label.Wrapping = fyne.TextWrapWord
+
+		editButton := widget.NewButton("編集", func() {
+			ecs.showContactDialog(contact)
+		})
+		deleteButton := widget.NewButton("削除", func() {
+			ecs.confirmDelete(contact)
+		})
+		if !canEdit {
+			editButton.Disable()
+			deleteButton.Disable()
+		}
+
+		ecs.list.Add(container.NewBorder(nil, nil, nil, container.NewHBox(editButton, deleteButton), label))
+	}
+}
+
+// formatEmergencyContact renders a contact as a short multi-line summary
+func formatEmergencyContact(contact *domain.EmergencyContact) string {
+	header := fmt.Sprintf("%d. %s（%s）", contact.Priority, contact.Name, contact.Relationship)
+	if contact.IsGuardian {
+		header += " [保護者・後見人]"
+	}
+
+	var phones []string
+	if contact.Phone != "" {
+		phones = append(phones, "自宅 "+contact.Phone)
+	}
+	if contact.MobilePhone != "" {
+		phones = append(phones, "携帯 "+contact.MobilePhone)
+	}
+	if contact.WorkPhone != "" {
+		phones = append(phones, "勤務先 "+contact.WorkPhone)
+	}
+
+	lines := []string{header, strings.Join(phones, " / ")}
+	if contact.Notes != "" {
+		lines = append(lines, "備考: "+contact.Notes)
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// showContactDialog shows the add/edit dialog; a nil contact means a new one
+func (ecs *EmergencyContactSection) showContactDialog(contact *domain.EmergencyContact) {
+	if ecs.recipient == nil || ecs.currentUser == nil {
+		return
+	}
+
+	parent := fyne.CurrentApp().Driver().AllWindows()[0]
+
+	priorityEntry := widget.NewEntry()
+	priorityEntry.SetPlaceHolder("空欄の場合は最後")
+	nameEntry := widget.NewEntry()
+	relationshipEntry := widget.NewEntry()
+	relationshipEntry.SetPlaceHolder("例: 母、兄、成年後見人")
+	guardianCheck := widget.NewCheck("保護者・成年後見人", nil)
+	phoneEntry := widget.NewEntry()
+	mobilePhoneEntry := widget.NewEntry()
+	workPhoneEntry := widget.NewEntry()
+	emailEntry := widget.NewEntry()
+	notesEntry := widget.NewMultiLineEntry()
+	notesEntry.SetPlaceHolder("例: 体調不良時は最初に連絡、平日日中は勤務先へ")
+
+	title := "緊急連絡先の追加"
+	if contact != nil {
+		title = "緊急連絡先の編集"
+		priorityEntry.SetText(strconv.Itoa(contact.Priority))
+		nameEntry.SetText(contact.Name)
+		relationshipEntry.SetText(contact.Relationship)
+		guardianCheck.SetChecked(contact.IsGuardian)
+		phoneEntry.SetText(contact.Phone)
+		mobilePhoneEntry.SetText(contact.MobilePhone)
+		workPhoneEntry.SetText(contact.WorkPhone)
+		emailEntry.SetText(contact.Email)
+		notesEntry.SetText(contact.Notes)
+	}
+
+	items := []*widget.FormItem{
+		widget.NewFormItem("連絡順", priorityEntry),
+		widget.NewFormItem("氏名*", nameEntry),
+		widget.NewFormItem("続柄*", relationshipEntry),
+		widget.NewFormItem("", guardianCheck),
+		widget.NewFormItem("自宅電話", phoneEntry),
+		widget.NewFormItem("携帯電話", mobilePhoneEntry),
+		widget.NewFormItem("勤務先電話", workPhoneEntry),
+		widget.NewFormItem("メール", emailEntry),
+		widget.NewFormItem("備考", notesEntry),
+	}
+
+	dlg := dialog.NewForm(title, "保存", "キャンセル", items, func(confirmed bool) {
+		if !confirmed {
+			return
+		}
+
+		formValidator := validation.NewFormValidator()
+		formData := map[string]string{
+			"priority":     strings.TrimSpace(priorityEntry.Text),
+			"name":         formValidator.SanitizeInput(nameEntry.Text),
+			"relationship": formValidator.SanitizeInput(relationshipEntry.Text),
+			"phone":        strings.TrimSpace(phoneEntry.Text),
+			"mobile_phone": strings.TrimSpace(mobilePhoneEntry.Text),
+			"work_phone":   strings.TrimSpace(workPhoneEntry.Text),
+			"email":        strings.TrimSpace(emailEntry.Text),
+			"notes":        formValidator.SanitizeInput(notesEntry.Text),
+		}
+		if validationErrors := formValidator.ValidateEmergencyContactForm(formData); len(validationErrors) > 0 {
+			dialog.ShowError(errors.New(validationErrors.Error()), parent)
+			return
+		}
+
+		priority := 0
+		if formData["priority"] != "" {
+			priority, _ = strconv.Atoi(formData["priority"])
+		}
+
+		ctx := context.Background()
+		var err error
+		if contact == nil {
+			_, err = ecs.useCase.AddEmergencyContact(ctx, usecase.CreateEmergencyContactRequest{
+				RecipientID:  ecs.recipient.ID,
+				Priority:     priority,
+				Name:         formData["name"],
+				Relationship: formData["relationship"],
+				IsGuardian:   guardianCheck.Checked,
+				Phone:        formData["phone"],
+				MobilePhone:  formData["mobile_phone"],
+				WorkPhone:    formData["work_phone"],
+				Email:        formData["email"],
+				Notes:        formData["notes"],
+				ActorID:      ecs.currentUser.ID,
+			})
+		} else {
+			if priority == 0 {
+				priority = contact.Priority
+			}
+			_, err = ecs.useCase.UpdateEmergencyContact(ctx, usecase.UpdateEmergencyContactRequest{
+				ID:           contact.ID,
+				Priority:     priority,
+				Name:         formData["name"],
+				Relationship: formData["relationship"],
+				IsGuardian:   guardianCheck.Checked,
+				Phone:        formData["phone"],
+				MobilePhone:  formData["mobile_phone"],
+				WorkPhone:    formData["work_phone"],
+				Email:        formData["email"],
+				Notes:        formData["notes"],
+				ActorID:      ecs.currentUser.ID,
+			})
+		}
+
+		if err != nil {
+			dialog.ShowError(fmt.Errorf("緊急連絡先の保存に失敗しました: %w", err), parent)
+			return
+		}
+
+		ecs.LoadData()
+	}, parent)
+
+	dlg.Resize(fyne.NewSize(480, 560))
+	dlg.Show()
+}
+
+// confirmDelete asks for confirmation before removing a contact
+func (ecs *EmergencyContactSection) confirmDelete(contact *domain.EmergencyContact) {
+	parent := fyne.CurrentApp().Driver().AllWindows()[0]
+
+	dialog.ShowConfirm("削除確認", fmt.Sprintf("緊急連絡先「%s（%s）」を削除しますか？", contact.Name, contact.Relationship), func(confirmed bool) {
+		if !confirmed {
+			return
+		}
+
+		err := ecs.useCase.DeleteEmergencyContact(context.Background(), usecase.DeleteEmergencyContactRequest{
+			ID:      contact.ID,
+			ActorID: ecs.currentUser.ID,
+		})
+		if err != nil {
+			dialog.ShowError(fmt.Errorf("緊急連絡先の削除に失敗しました: %w", err), parent)
+			return
+		}
+
+		ecs.LoadData()
+	}, parent)
+}
+
+// exportSheet saves the one-page emergency contact sheet as PDF
+func (ecs *EmergencyContactSection) exportSheet() {
+	parent := fyne.CurrentApp().Driver().AllWindows()[0]
+
+	if ecs.pdfService == nil {
+		dialog.ShowError(fmt.Errorf("PDFサービスが利用できません"), parent)
+		return
+	}
+	if ecs.recipient == nil {
+		return
+	}
+
+	contactValues := make([]domain.EmergencyContact, 0, len(ecs.contacts))
+	for _, contact := range ecs.contacts {
+		if contact != nil {
+			contactValues = append(contactValues, *contact)
+		}
+	}
+
+	saveDialog := dialog.NewFileSave(func(writer fyne.URIWriteCloser, err error) {
+		if err != nil {
+			dialog.ShowError(fmt.Errorf("ファイルの保存に失敗しました: %w", err), parent)
+			return
+		}
+		if writer == nil {
+			return // User cancelled
+		}
+		defer writer.Close()
+
+		pdfBytes, err := ecs.pdfService.GenerateEmergencyContactSheet(context.Background(), ecs.recipient, contactValues)
+		if err != nil {
+			dialog.ShowError(fmt.Errorf("PDF生成に失敗しました: %w", err), parent)
+			return
+		}
+
+		if _, err := writer.Write(pdfBytes); err != nil {
+			dialog.ShowError(fmt.Errorf("ファイルの書き込みに失敗しました: %w", err), parent)
+			return
+		}
+
+		dialog.ShowInformation("成功", "緊急連絡先シートを保存しました。", parent)
+	}, parent)
+
+	saveDialog.SetFileName(fmt.Sprintf("緊急連絡先_%s_%s.pdf", ecs.recipient.Name, time.Now().Format("20060102")))
+	saveDialog.SetFilter(storage.NewExtensionFileFilter([]string{".pdf"}))
+	saveDialog.Show()
+}
+
+// CreateObject creates the main UI object for this section
+func (ecs *EmergencyContactSection) CreateObject() fyne.CanvasObject {
+	return container.NewVBox(
+		widget.NewLabel("緊急連絡先"),
+		widget.NewSeparator(),
+		ecs.list,
+		container.NewHBox(ecs.addButton, ecs.printButton),
+	)
+}
@@ -0,0 +1,431 @@
+package widgets
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"shien-system/internal/domain"
+	"shien-system/internal/usecase"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/widget"
+)
+
+// importColumnNone is the mapping option for fields not taken from the file
+const importColumnNone = "（取り込まない）"
+
+// importKindOptions are the import kinds offered, in display order
+var importKindOptions = []usecase.ImportKind{usecase.ImportKindRecipients, usecase.ImportKindCertificates}
+
+// ImportView is the bulk import wizard for administrators: choose a file,
+// map its columns, check every row with a dry run, then import.
+type ImportView struct {
+	useCase     usecase.ImportUseCase
+	currentUser *domain.Staff
+
+	// UI components
+	kindSelect       *widget.Select
+	fileButton       *widget.Button
+	fileLabel        *widget.Label
+	mappingContainer *fyne.Container
+	mappingSelects   map[string]*widget.Select
+	skipInvalidCheck *widget.Check
+	dryRunButton     *widget.Button
+	importButton     *widget.Button
+	reportButton     *widget.Button
+	summaryLabel     *widget.Label
+	errorsTable      *widget.Table
+
+	// Data
+	kind   usecase.ImportKind
+	file   *usecase.ImportFile
+	result *usecase.ImportResult // Result of the last dry run or import
+
+	// Callbacks
+	onImported func(kind usecase.ImportKind)
+}
+
+// NewImportView creates a new ImportView widget
+func NewImportView(useCase usecase.ImportUseCase, currentUser *domain.Staff) *ImportView {
+	iv := &ImportView{
+		useCase:     useCase,
+		currentUser: currentUser,
+		kind:        usecase.ImportKindRecipients,
+	}
+
+	iv.createWidgets()
+
+	return iv
+}
+
+// createWidgets initializes all UI components
+func (iv *ImportView) createWidgets() {
+	labels := make([]string, len(importKindOptions))
+	for i, kind := range importKindOptions {
+		labels[i] = kind.Label()
+	}
+	iv.kindSelect = widget.NewSelect(labels, func(selected string) {
+		for _, kind := range importKindOptions {
+			if kind.Label() == selected && kind != iv.kind {
+				iv.kind = kind
+				iv.setFile(nil)
+			}
+		}
+	})
+	iv.kindSelect.SetSelected(iv.kind.Label())
+
+	iv.fileButton = widget.NewButton("ファイルを選択...", func() {
+		iv.chooseFile()
+	})
+	iv.fileLabel = widget.NewLabel("CSV（UTF-8・Shift_JIS）またはExcel（.xlsx）の1行目に見出しを入れてください。")
+	iv.fileLabel.Wrapping = fyne.TextWrapWord
+
+	iv.mappingContainer = container.NewVBox()
+	iv.mappingSelects = make(map[string]*widget.Select)
+
+	iv.skipInvalidCheck = widget.NewCheck("エラーのある行を除いて取り込む", func(bool) {
+		iv.updateButtons()
+	})
+
+	iv.dryRunButton = widget.NewButton("確認（登録しない）", func() {
+		iv.RunDryRun()
+	})
+	iv.importButton = widget.NewButton("取り込む", func() {
+		iv.confirmImport()
+	})
+	iv.importButton.Importance = widget.HighImportance
+	iv.reportButton = widget.NewButton("エラーレポートを保存", func() {
+		iv.saveErrorReport()
+	})
+
+	iv.summaryLabel = widget.NewLabel("")
+	iv.summaryLabel.Wrapping = fyne.TextWrapWord
+
+	iv.errorsTable = newSecurityTable(
+		[]float32{60, 160, 520},
+		func() int {
+			if iv.result == nil {
+				return 0
+			}
+			return len(iv.result.Errors)
+		},
+		func(row, col int) string {
+			rowErr := iv.result.Errors[row]
+			switch col {
+			case 0:
+				return strconv.Itoa(rowErr.Line)
+			case 1:
+				return valueOrDash(rowErr.Field)
+			default:
+				return rowErr.Message
+			}
+		},
+	)
+
+	iv.updateButtons()
+}
+
+// chooseFile asks for the file to import
+func (iv *ImportView) chooseFile() {
+	parent := iv.parentWindow()
+
+	openDialog := dialog.NewFileOpen(func(reader fyne.URIReadCloser, err error) {
+		if err != nil {
+			dialog.ShowError(fmt.Errorf("ファイルを開けませんでした: %w", err), parent)
+			return
+		}
+		if reader == nil {
+			return // User cancelled
+		}
+		defer reader.Close()
+
+		if err := iv.LoadFile(reader, reader.URI().Name()); err != nil {
+			dialog.ShowError(err, parent)
+		}
+	}, parent)
+	openDialog.SetFilter(storage.NewExtensionFileFilter([]string{".csv", ".CSV", ".xlsx", ".XLSX"}))
+	openDialog.Show()
+}
+
+// LoadFile reads a file and shows its columns for mapping
+func (iv *ImportView) LoadFile(source io.Reader, fileName string) error {
+	if !iv.isAdmin() {
+		return nil
+	}
+
+	file, err := iv.useCase.ReadFile(context.Background(), usecase.ReadImportFileRequest{
+		Kind:     iv.kind,
+		Source:   source,
+		FileName: fileName,
+		ActorID:  iv.currentUser.ID,
+	})
+	if err != nil {
+		return fmt.Errorf("ファイルを読み込めませんでした: %w", err)
+	}
+
+	iv.setFile(file)
+	return nil
+}
+
+// setFile replaces the loaded file and rebuilds the column mapping; nil clears it
+func (iv *ImportView) setFile(file *usecase.ImportFile) {
+	iv.file = file
+	iv.clearResult()
+	iv.mappingSelects = make(map[string]*widget.Select)
+
+	if file == nil {
+		iv.fileLabel.SetText("ファイルが選択されていません。")
+		iv.mappingContainer.Objects = nil
+		iv.mappingContainer.Refresh()
+		return
+	}
+
+	iv.fileLabel.SetText(fmt.Sprintf("%s（%d行）: 各項目に対応する列を選んでください。", file.FileName, len(file.Rows)))
+
+	options := []string{importColumnNone}
+	for col, heading := range file.Header {
+		options = append(options, importColumnOption(col, heading))
+	}
+
+	form := widget.NewForm()
+	for _, field := range file.Fields {
+		mappingSelect := widget.NewSelect(options, func(string) {
+			iv.clearResult()
+		})
+		if col, ok := file.Mapping[field.Key]; ok {
+			mappingSelect.SetSelected(options[col+1])
+		} else {
+			mappingSelect.SetSelected(importColumnNone)
+		}
+		iv.mappingSelects[field.Key] = mappingSelect
+
+		label := field.Label
+		if field.Required {
+			label += " *"
+		}
+		form.Append(label, mappingSelect)
+	}
+
+	iv.mappingContainer.Objects = []fyne.CanvasObject{form}
+	iv.mappingContainer.Refresh()
+}
+
+// clearResult forgets the last dry run, which no longer matches the mapping
+func (iv *ImportView) clearResult() {
+	iv.result = nil
+	iv.summaryLabel.SetText("")
+	iv.errorsTable.Refresh()
+	iv.updateButtons()
+}
+
+// mapping returns the column chosen for each field
+func (iv *ImportView) mapping() map[string]int {
+	mapping := make(map[string]int)
+	for key, mappingSelect := range iv.mappingSelects {
+		if index := mappingSelect.SelectedIndex(); index > 0 {
+			mapping[key] = index - 1
+		}
+	}
+	return mapping
+}
+
+// request builds the import request for the loaded file
+func (iv *ImportView) request() usecase.ImportRequest {
+	return usecase.ImportRequest{
+		File:            iv.file,
+		Mapping:         iv.mapping(),
+		SkipInvalidRows: iv.skipInvalidCheck.Checked,
+		ActorID:         iv.currentUser.ID,
+	}
+}
+
+// RunDryRun checks every row without saving
+func (iv *ImportView) RunDryRun() {
+	if iv.file == nil || !iv.isAdmin() {
+		return
+	}
+
+	result, err := iv.useCase.DryRun(context.Background(), iv.request())
+	if err != nil {
+		iv.showError(fmt.Errorf("確認できませんでした: %w", err))
+		return
+	}
+
+	iv.setResult(result)
+}
+
+// confirmImport asks before saving the rows of the last dry run
+func (iv *ImportView) confirmImport() {
+	if iv.result == nil {
+		return
+	}
+
+	message := fmt.Sprintf("%d件の%sを登録します。", iv.result.ValidRows(), iv.file.Kind.Label())
+	if iv.result.InvalidRows > 0 {
+		message += fmt.Sprintf("\nエラーのある%d行は取り込みません。", iv.result.InvalidRows)
+	}
+	dialog.ShowConfirm("一括取込", message+"\nよろしいですか？", func(confirmed bool) {
+		if confirmed {
+			iv.RunImport()
+		}
+	}, iv.parentWindow())
+}
+
+// RunImport saves the valid rows in one transaction
+func (iv *ImportView) RunImport() {
+	if iv.file == nil || !iv.isAdmin() {
+		return
+	}
+
+	result, err := iv.useCase.Import(context.Background(), iv.request())
+	if err != nil {
+		iv.showError(fmt.Errorf("取り込みに失敗しました: %w", err))
+		return
+	}
+
+	iv.setResult(result)
+	if result.Committed && iv.onImported != nil {
+		iv.onImported(result.Kind)
+	}
+}
+
+// SetOnImported sets the callback for when rows have been imported
+func (iv *ImportView) SetOnImported(callback func(kind usecase.ImportKind)) {
+	iv.onImported = callback
+}
+
+// setResult shows the outcome of a dry run or import
+func (iv *ImportView) setResult(result *usecase.ImportResult) {
+	iv.result = result
+
+	switch {
+	case result.Committed:
+		iv.summaryLabel.SetText(fmt.Sprintf("%d件の%sを登録しました。取り込まなかった行: %d行（うち重複 %d行）",
+			result.Imported, result.Kind.Label(), result.InvalidRows, result.Duplicates))
+	case result.ValidRows() == 0:
+		iv.summaryLabel.SetText(fmt.Sprintf("全%d行にエラーがあり、登録できる行がありません。", result.TotalRows))
+	case result.InvalidRows > 0:
+		iv.summaryLabel.SetText(fmt.Sprintf("全%d行のうち%d行にエラーがあります（うち重複 %d行）。修正するか、エラーのある行を除いて取り込んでください。",
+			result.TotalRows, result.InvalidRows, result.Duplicates))
+	default:
+		iv.summaryLabel.SetText(fmt.Sprintf("全%d行を登録できます。", result.TotalRows))
+	}
+
+	iv.errorsTable.Refresh()
+	iv.updateButtons()
+}
+
+func (iv *ImportView) updateButtons() {
+	if iv.file == nil {
+		iv.dryRunButton.Disable()
+	} else {
+		iv.dryRunButton.Enable()
+	}
+
+	// Only a file checked with the current mapping can be imported
+	if iv.result == nil || iv.result.Committed || iv.result.ValidRows() == 0 ||
+		(iv.result.InvalidRows > 0 && !iv.skipInvalidCheck.Checked) {
+		iv.importButton.Disable()
+	} else {
+		iv.importButton.Enable()
+	}
+
+	if iv.result == nil || len(iv.result.Errors) == 0 {
+		iv.reportButton.Disable()
+	} else {
+		iv.reportButton.Enable()
+	}
+}
+
+// saveErrorReport writes the row errors of the last result to a CSV file
+func (iv *ImportView) saveErrorReport() {
+	if iv.result == nil {
+		return
+	}
+	result := iv.result
+	parent := iv.parentWindow()
+
+	saveDialog := dialog.NewFileSave(func(writer fyne.URIWriteCloser, err error) {
+		if err != nil {
+			dialog.ShowError(fmt.Errorf("ファイルの保存に失敗しました: %w", err), parent)
+			return
+		}
+		if writer == nil {
+			return // User cancelled
+		}
+		defer writer.Close()
+
+		if err := result.WriteErrorReport(writer); err != nil {
+			dialog.ShowError(fmt.Errorf("ファイルの書き込みに失敗しました: %w", err), parent)
+			return
+		}
+
+		dialog.ShowInformation("成功", "エラーレポートを保存しました。", parent)
+	}, parent)
+
+	saveDialog.SetFileName(fmt.Sprintf("取込エラー_%s.csv", time.Now().Format("20060102_150405")))
+	saveDialog.SetFilter(storage.NewExtensionFileFilter([]string{".csv"}))
+	saveDialog.Show()
+}
+
+func (iv *ImportView) isAdmin() bool {
+	return iv.currentUser != nil && iv.currentUser.Role == domain.RoleAdmin
+}
+
+// parentWindow returns the main window for dialogs
+func (iv *ImportView) parentWindow() fyne.Window {
+	return fyne.CurrentApp().Driver().AllWindows()[0]
+}
+
+// showError shows an error dialog on the main window
+func (iv *ImportView) showError(err error) {
+	if app := fyne.CurrentApp(); app != nil && len(app.Driver().AllWindows()) > 0 {
+		dialog.ShowError(err, app.Driver().AllWindows()[0])
+	}
+}
+
+// CreateObject creates the UI object for the import view
+func (iv *ImportView) CreateObject() fyne.CanvasObject {
+	if !iv.isAdmin() {
+		return container.NewCenter(widget.NewLabel("この画面は管理者のみ利用できます。"))
+	}
+
+	header := container.NewBorder(
+		nil, nil,
+		widget.NewLabel("一括取込"),
+		container.NewHBox(iv.kindSelect, iv.fileButton),
+		iv.fileLabel,
+	)
+
+	actions := container.NewHBox(iv.dryRunButton, iv.skipInvalidCheck, iv.importButton, iv.reportButton)
+
+	headers := []string{"行", "項目", "エラー内容"}
+	headerWidgets := make([]fyne.CanvasObject, len(headers))
+	for i, text := range headers {
+		label := widget.NewLabel(text)
+		label.TextStyle.Bold = true
+		headerWidgets[i] = label
+	}
+
+	top := container.NewVBox(header, iv.mappingContainer, actions, iv.summaryLabel, container.NewHBox(headerWidgets...))
+
+	return container.NewBorder(top, nil, nil, nil, iv.errorsTable)
+}
+
+// importColumnOption labels a column the way spreadsheets name them, e.g. "B: 氏名"
+func importColumnOption(col int, heading string) string {
+	name := ""
+	for n := col + 1; n > 0; n = (n - 1) / 26 {
+		name = string(rune('A'+(n-1)%26)) + name
+	}
+	if heading == "" {
+		return name
+	}
+	return name + ": " + heading
+}
@@ -0,0 +1,100 @@
+package widgets
+
+import (
+	"strings"
+	"testing"
+
+	"shien-system/internal/domain"
+	"shien-system/internal/usecase"
+
+	"fyne.io/fyne/v2/test"
+)
+
+func TestImportView_DryRunBeforeImport(t *testing.T) {
+	app := test.NewApp()
+	defer app.Quit()
+
+	mock := &MockImportUseCase{
+		file: &usecase.ImportFile{
+			Kind:     usecase.ImportKindRecipients,
+			FileName: "利用者.csv",
+			Header:   []string{"氏名", "性別", "生年月日"},
+			Rows:     [][]string{{"佐藤花子", "女", "1985/08/20"}, {"鈴木一郎", "不詳", "1990/01/01"}},
+			Fields: []usecase.ImportField{
+				{Key: "name", Label: "氏名", Required: true},
+				{Key: "sex", Label: "性別", Required: true},
+				{Key: "birth_date", Label: "生年月日", Required: true},
+			},
+			Mapping: map[string]int{"name": 0, "birth_date": 2},
+		},
+		result: &usecase.ImportResult{
+			Kind:        usecase.ImportKindRecipients,
+			TotalRows:   2,
+			InvalidRows: 1,
+			Errors:      []usecase.ImportRowError{{Line: 3, Field: "性別", Message: "性別が正しくありません"}},
+		},
+	}
+	admin := &domain.Staff{ID: "admin-001", Name: "管理者", Role: domain.RoleAdmin}
+	view := NewImportView(mock, admin)
+
+	imported := 0
+	view.SetOnImported(func(kind usecase.ImportKind) {
+		imported++
+	})
+
+	if err := view.LoadFile(strings.NewReader(""), "利用者.csv"); err != nil {
+		t.Fatalf("LoadFile() error = %v", err)
+	}
+	if view.mappingSelects["sex"].Selected != importColumnNone {
+		t.Errorf("sex column = %q, want %q", view.mappingSelects["sex"].Selected, importColumnNone)
+	}
+	if !view.importButton.Disabled() {
+		t.Error("import button enabled before the dry run")
+	}
+
+	view.mappingSelects["sex"].SetSelected(importColumnOption(1, "性別"))
+	view.RunDryRun()
+	if got := mock.requests[0].Mapping; got["sex"] != 1 || got["name"] != 0 || got["birth_date"] != 2 {
+		t.Errorf("dry run mapping = %v, want name 0, sex 1, birth_date 2", got)
+	}
+
+	// Rows with errors are only imported when the user skips them
+	if !view.importButton.Disabled() || view.reportButton.Disabled() {
+		t.Error("import must wait for skipping invalid rows and the error report must be available")
+	}
+	view.skipInvalidCheck.SetChecked(true)
+	if view.importButton.Disabled() {
+		t.Fatal("import button disabled after choosing to skip invalid rows")
+	}
+
+	// Changing the mapping requires a new dry run
+	view.mappingSelects["sex"].SetSelected(importColumnNone)
+	if !view.importButton.Disabled() {
+		t.Error("import button enabled after the mapping changed")
+	}
+	view.mappingSelects["sex"].SetSelected(importColumnOption(1, "性別"))
+	view.RunDryRun()
+
+	view.RunImport()
+	if mock.imports != 1 || !mock.requests[len(mock.requests)-1].SkipInvalidRows || imported != 1 {
+		t.Errorf("imports = %d, callbacks = %d, want one import skipping invalid rows", mock.imports, imported)
+	}
+	if !view.importButton.Disabled() {
+		t.Error("import button enabled after the rows were imported")
+	}
+}
+
+func TestImportView_StaffCannotImport(t *testing.T) {
+	app := test.NewApp()
+	defer app.Quit()
+
+	mock := &MockImportUseCase{file: &usecase.ImportFile{Kind: usecase.ImportKindRecipients}}
+	view := NewImportView(mock, &domain.Staff{ID: "staff-001", Name: "職員", Role: domain.RoleStaff})
+
+	if err := view.LoadFile(strings.NewReader(""), "利用者.csv"); err != nil {
+		t.Fatalf("LoadFile() error = %v", err)
+	}
+	if view.file != nil || !view.dryRunButton.Disabled() {
+		t.Error("staff member loaded an import file")
+	}
+}
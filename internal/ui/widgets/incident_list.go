@@ -0,0 +1,763 @@
+package widgets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"shien-system/internal/adapter/pdf"
+	"shien-system/internal/domain"
+	"shien-system/internal/usecase"
+	"shien-system/internal/validation"
+	"shien-system/internal/wareki"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/widget"
+)
+
+// IncidentList lists accident and near-miss reports and drives their approval workflow
+type IncidentList struct {
+	useCase          usecase.IncidentUseCase
+	recipientUseCase usecase.RecipientUseCase
+	staffUseCase     usecase.StaffUseCase
+	pdfService       *pdf.PDFService
+	currentUser      *domain.Staff
+
+	// UI components
+	table         *widget.Table
+	newButton     *widget.Button
+	refreshButton *widget.Button
+	statsButton   *widget.Button
+	monthFilter   *widget.Select
+	statusFilter  *widget.Select
+
+	// Data
+	reports      []*domain.IncidentReport
+	recipientMap map[domain.ID]*domain.Recipient // For recipient name lookup
+	staffMap     map[domain.ID]*domain.Staff     // For staff name lookup
+	months       []time.Time
+	currentMonth time.Time
+	currentState *domain.IncidentStatus
+}
+
+// NewIncidentList creates a new IncidentList widget
+func NewIncidentList(useCase usecase.IncidentUseCase, recipientUseCase usecase.RecipientUseCase, staffUseCase usecase.StaffUseCase, pdfService *pdf.PDFService, currentUser *domain.Staff) *IncidentList {
+	il := &IncidentList{
+		useCase:          useCase,
+		recipientUseCase: recipientUseCase,
+		staffUseCase:     staffUseCase,
+		pdfService:       pdfService,
+		currentUser:      currentUser,
+		reports:          make([]*domain.IncidentReport, 0),
+		recipientMap:     make(map[domain.ID]*domain.Recipient),
+		staffMap:         make(map[domain.ID]*domain.Staff),
+	}
+
+	il.createWidgets()
+	il.setupTable()
+
+	return il
+}
+
+// createWidgets initializes all UI components
+func (il *IncidentList) createWidgets() {
+	// Table
+	il.table = widget.NewTable(
+		func() (int, int) {
+			return len(il.reports), 6 // 6 columns
+		},
+		func() fyne.CanvasObject {
+			return widget.NewLabel("")
+		},
+		func(id widget.TableCellID, obj fyne.CanvasObject) {
+			il.updateTableCell(id, obj.(*widget.Label))
+		},
+	)
+
+	// Buttons
+	il.newButton = widget.NewButton("新規報告", func() {
+		il.showIncidentDialog(nil)
+	})
+	if il.currentUser == nil || il.currentUser.Role == domain.RoleReadOnly {
+		il.newButton.Disable()
+	}
+
+	il.refreshButton = widget.NewButton("更新", func() {
+		il.LoadData()
+	})
+
+	il.statsButton = widget.NewButton("月次集計", func() {
+		il.showStatistics()
+	})
+
+	// Month filter: the current month and the previous eleven
+	now := time.Now()
+	firstOfMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.Local)
+	monthOptions := make([]string, 0, 12)
+	for i := 0; i < 12; i++ {
+		month := firstOfMonth.AddDate(0, -i, 0)
+		il.months = append(il.months, month)
+		monthOptions = append(monthOptions, month.Format("2006年01月"))
+	}
+	il.currentMonth = firstOfMonth
+
+	il.monthFilter = widget.NewSelect(monthOptions, func(selected string) {
+		for i, option := range monthOptions {
+			if option == selected {
+				il.currentMonth = il.months[i]
+			}
+		}
+		il.LoadData()
+	})
+	il.monthFilter.Selected = monthOptions[0]
+
+	statusOptions := []string{"全て"}
+	statuses := []domain.IncidentStatus{domain.IncidentStatusDraft, domain.IncidentStatusSubmitted, domain.IncidentStatusReviewed, domain.IncidentStatusApproved}
+	for _, status := range statuses {
+		statusOptions = append(statusOptions, status.Label())
+	}
+	il.statusFilter = widget.NewSelect(statusOptions, func(selected string) {
+		il.currentState = nil
+		for _, status := range statuses {
+			if status.Label() == selected {
+				status := status
+				il.currentState = &status
+			}
+		}
+		il.LoadData()
+	})
+	il.statusFilter.Selected = "全て"
+}
+
+// setupTable configures the table widget
+func (il *IncidentList) setupTable() {
+	// Set column widths
+	il.table.SetColumnWidth(0, 130) // 発生日時
+	il.table.SetColumnWidth(1, 130) // 種別
+	il.table.SetColumnWidth(2, 110) // 程度
+	il.table.SetColumnWidth(3, 120) // 発生場所
+	il.table.SetColumnWidth(4, 160) // 対象者
+	il.table.SetColumnWidth(5, 80)  // 状態
+
+	il.table.OnSelected = func(id widget.TableCellID) {
+		if id.Row < len(il.reports) {
+			il.showDetailDialog(il.reports[id.Row])
+		}
+		il.table.UnselectAll()
+	}
+}
+
+// updateTableCell updates a specific table cell with report data
+func (il *IncidentList) updateTableCell(id widget.TableCellID, label *widget.Label) {
+	if id.Row >= len(il.reports) {
+		label.SetText("")
+		return
+	}
+
+	report := il.reports[id.Row]
+
+	switch id.Col {
+	case 0: // 発生日時
+		occurredAt := report.OccurredAt.Local()
+		label.SetText(dateStyleOf(il.currentUser).FormatShort(occurredAt) + occurredAt.Format(" 15:04"))
+	case 1: // 種別
+		label.SetText(report.Category.Label())
+	case 2: // 程度
+		label.SetText(report.Severity.Label())
+	case 3: // 発生場所
+		label.SetText(report.Location)
+	case 4: // 対象者
+		label.SetText(il.recipientNames(report.RecipientIDs))
+	case 5: // 状態
+		label.SetText(report.Status.Label())
+	default:
+		label.SetText("")
+	}
+}
+
+// LoadData loads the reports of the selected month
+func (il *IncidentList) LoadData() error {
+	if il.currentUser == nil {
+		return nil
+	}
+
+	ctx := context.Background()
+	from := il.currentMonth
+	to := from.AddDate(0, 1, 0)
+
+	reports, err := il.useCase.ListIncidents(ctx, usecase.ListIncidentsRequest{
+		Filter: domain.IncidentFilter{
+			OccurredFrom: &from,
+			OccurredTo:   &to,
+			Status:       il.currentState,
+		},
+		Limit:   500,
+		ActorID: il.currentUser.ID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to load incident reports: %w", err)
+	}
+
+	il.reports = reports
+	il.loadNames(ctx)
+	il.table.Refresh()
+
+	return nil
+}
+
+// loadNames loads recipient and staff names for display purposes
+func (il *IncidentList) loadNames(ctx context.Context) {
+	for _, report := range il.reports {
+		for _, recipientID := range report.RecipientIDs {
+			if _, ok := il.recipientMap[recipientID]; ok {
+				continue
+			}
+			recipient, err := il.recipientUseCase.GetRecipient(ctx, recipientID)
+			if err != nil {
+				continue
+			}
+			il.recipientMap[recipientID] = recipient
+		}
+
+		staffIDs := append([]domain.ID{report.ReportedBy}, report.StaffIDs...)
+		if report.ReviewedBy != nil {
+			staffIDs = append(staffIDs, *report.ReviewedBy)
+		}
+		if report.ApprovedBy != nil {
+			staffIDs = append(staffIDs, *report.ApprovedBy)
+		}
+		for _, staffID := range staffIDs {
+			if _, ok := il.staffMap[staffID]; ok {
+				continue
+			}
+			staff, err := il.staffUseCase.GetStaff(ctx, staffID)
+			if err != nil {
+				continue
+			}
+			il.staffMap[staffID] = staff
+		}
+	}
+}
+
+// recipientNames joins the names of the given recipients
+func (il *IncidentList) recipientNames(recipientIDs []domain.ID) string {
+	names := make([]string, 0, len(recipientIDs))
+	for _, recipientID := range recipientIDs {
+		if recipient, ok := il.recipientMap[recipientID]; ok {
+			names = append(names, recipient.Name)
+		} else {
+			names = append(names, "不明")
+		}
+	}
+	return strings.Join(names, "、")
+}
+
+// staffName returns the name of a staff member
+func (il *IncidentList) staffName(staffID domain.ID) string {
+	if staff, ok := il.staffMap[staffID]; ok {
+		return staff.Name
+	}
+	return staffID
+}
+
+// showDetailDialog shows a report with the workflow actions available to the current user
+func (il *IncidentList) showDetailDialog(report *domain.IncidentReport) {
+	parent := fyne.CurrentApp().Driver().AllWindows()[0]
+
+	lines := []string{
+		fmt.Sprintf("発生日時: %s%s", dateStyleOf(il.currentUser).FormatLong(report.OccurredAt.Local()), report.OccurredAt.Local().Format(" 15:04")),
+		fmt.Sprintf("発生場所: %s", report.Location),
+		fmt.Sprintf("種別: %s / 程度: %s", report.Category.Label(), report.Severity.Label()),
+		fmt.Sprintf("対象者: %s", il.recipientNames(report.RecipientIDs)),
+		fmt.Sprintf("報告者: %s", il.staffName(report.ReportedBy)),
+		fmt.Sprintf("状態: %s", report.Status.Label()),
+		"",
+		"【発生時の状況】", report.Description,
+		"【発生時の対応】", report.Response,
+		"【再発防止策】", report.Prevention,
+	}
+	if report.ReviewComment != "" {
+		lines = append(lines, "【確認者所見・差戻し理由】", report.ReviewComment)
+	}
+
+	detail := widget.NewLabel(strings.Join(lines, "\n"))
+	detail.Wrapping = fyne.TextWrapWord
+
+	var dlg dialog.Dialog
+	actions := container.NewHBox()
+	addAction := func(label string, action func()) {
+		actions.Add(widget.NewButton(label, func() {
+			dlg.Hide()
+			action()
+		}))
+	}
+
+	user := il.currentUser
+	canWrite := user != nil && user.Role != domain.RoleReadOnly
+	isAdmin := user != nil && user.Role == domain.RoleAdmin
+	isReporter := user != nil && report.ReportedBy == user.ID
+
+	switch report.Status {
+	case domain.IncidentStatusDraft:
+		if canWrite && (isAdmin || isReporter) {
+			addAction("編集", func() { il.showIncidentDialog(report) })
+			addAction("提出", func() { il.runWorkflow("提出", report, il.useCase.SubmitIncident) })
+		}
+	case domain.IncidentStatusSubmitted:
+		if canWrite && !isReporter {
+			addAction("確認", func() { il.promptComment("確認", report, il.useCase.ReviewIncident, false) })
+			addAction("差戻し", func() { il.promptComment("差戻し", report, il.useCase.ReturnIncident, true) })
+		}
+	case domain.IncidentStatusReviewed:
+		if isAdmin {
+			isReviewer := report.ReviewedBy != nil && *report.ReviewedBy == user.ID
+			if !isReporter && !isReviewer {
+				addAction("承認", func() { il.promptComment("承認", report, il.useCase.ApproveIncident, false) })
+			}
+			addAction("差戻し", func() { il.promptComment("差戻し", report, il.useCase.ReturnIncident, true) })
+		}
+	}
+	addAction("PDF出力", func() { il.exportReport(report) })
+
+	content := container.NewBorder(nil, actions, nil, nil, container.NewVScroll(detail))
+	dlg = dialog.NewCustom("事故・ヒヤリハット報告", "閉じる", content, parent)
+	dlg.Resize(fyne.NewSize(560, 520))
+	dlg.Show()
+}
+
+// incidentWorkflowAction is a usecase method that moves a report through the workflow
+type incidentWorkflowAction func(ctx context.Context, req usecase.IncidentWorkflowRequest) (*domain.IncidentReport, error)
+
+// promptComment asks for a comment before running a workflow step
+func (il *IncidentList) promptComment(label string, report *domain.IncidentReport, action incidentWorkflowAction, required bool) {
+	parent := fyne.CurrentApp().Driver().AllWindows()[0]
+
+	commentEntry := widget.NewMultiLineEntry()
+	title := "コメント（任意）"
+	if required {
+		title = "差し戻し理由*"
+	}
+
+	dialog.ShowForm(label, label, "キャンセル", []*widget.FormItem{
+		widget.NewFormItem(title, commentEntry),
+	}, func(confirmed bool) {
+		if !confirmed {
+			return
+		}
+		il.runWorkflowWithComment(label, report, action, commentEntry.Text)
+	}, parent)
+}
+
+// runWorkflow runs a workflow step after a confirmation
+func (il *IncidentList) runWorkflow(label string, report *domain.IncidentReport, action incidentWorkflowAction) {
+	parent := fyne.CurrentApp().Driver().AllWindows()[0]
+
+	dialog.ShowConfirm(label+"確認", fmt.Sprintf("この報告を%sしますか？", label), func(confirmed bool) {
+		if confirmed {
+			il.runWorkflowWithComment(label, report, action, "")
+		}
+	}, parent)
+}
+
+// runWorkflowWithComment calls the usecase and reloads the list
+func (il *IncidentList) runWorkflowWithComment(label string, report *domain.IncidentReport, action incidentWorkflowAction, comment string) {
+	parent := fyne.CurrentApp().Driver().AllWindows()[0]
+	formValidator := validation.NewFormValidator()
+
+	_, err := action(context.Background(), usecase.IncidentWorkflowRequest{
+		ID:      report.ID,
+		Comment: formValidator.SanitizeInput(comment),
+		ActorID: il.currentUser.ID,
+	})
+	if err != nil {
+		dialog.ShowError(fmt.Errorf("%sに失敗しました: %w", label, err), parent)
+		return
+	}
+
+	dialog.ShowInformation("成功", fmt.Sprintf("報告を%sしました。", label), parent)
+	il.LoadData()
+}
+
+// showIncidentDialog shows the create/edit dialog; a nil report means a new one
+func (il *IncidentList) showIncidentDialog(report *domain.IncidentReport) {
+	if il.currentUser == nil {
+		return
+	}
+
+	parent := fyne.CurrentApp().Driver().AllWindows()[0]
+	ctx := context.Background()
+
+	// Choices for the people involved
+	recipientOptions, recipientIDs := il.recipientChoices(ctx)
+	staffOptions, staffIDs := il.staffChoices(ctx)
+
+	now := time.Now()
+	dateEntry := widget.NewEntry()
+	dateEntry.SetPlaceHolder(datePlaceHolder)
+	dateEntry.SetText(dateStyleOf(il.currentUser).Format(now))
+	timeEntry := widget.NewEntry()
+	timeEntry.SetPlaceHolder("HH:MM")
+	timeEntry.SetText(now.Format("15:04"))
+	locationEntry := widget.NewEntry()
+	locationEntry.SetPlaceHolder("例: 作業室、送迎車内")
+
+	categoryLabels := make([]string, 0, len(domain.IncidentCategories))
+	for _, category := range domain.IncidentCategories {
+		categoryLabels = append(categoryLabels, category.Label())
+	}
+	categorySelect := widget.NewSelect(categoryLabels, nil)
+
+	severityLabels := make([]string, 0, len(domain.IncidentSeverities))
+	for _, severity := range domain.IncidentSeverities {
+		severityLabels = append(severityLabels, severity.Label())
+	}
+	severitySelect := widget.NewSelect(severityLabels, nil)
+
+	recipientCheck := widget.NewCheckGroup(recipientOptions, nil)
+	staffCheck := widget.NewCheckGroup(staffOptions, nil)
+	descriptionEntry := widget.NewMultiLineEntry()
+	descriptionEntry.SetPlaceHolder("いつ・どこで・誰が・どのように")
+	responseEntry := widget.NewMultiLineEntry()
+	preventionEntry := widget.NewMultiLineEntry()
+
+	title := "事故・ヒヤリハット報告の作成"
+	if report != nil {
+		title = "事故・ヒヤリハット報告の編集"
+		occurredAt := report.OccurredAt.Local()
+		dateEntry.SetText(dateStyleOf(il.currentUser).Format(occurredAt))
+		timeEntry.SetText(occurredAt.Format("15:04"))
+		locationEntry.SetText(report.Location)
+		categorySelect.SetSelected(report.Category.Label())
+		severitySelect.SetSelected(report.Severity.Label())
+		recipientCheck.SetSelected(labelsForIDs(report.RecipientIDs, recipientOptions, recipientIDs))
+		staffCheck.SetSelected(labelsForIDs(report.StaffIDs, staffOptions, staffIDs))
+		descriptionEntry.SetText(report.Description)
+		responseEntry.SetText(report.Response)
+		preventionEntry.SetText(report.Prevention)
+	}
+
+	items := []*widget.FormItem{
+		widget.NewFormItem("発生日*", dateEntry),
+		widget.NewFormItem("発生時刻*", timeEntry),
+		widget.NewFormItem("発生場所", locationEntry),
+		widget.NewFormItem("事故の種別*", categorySelect),
+		widget.NewFormItem("程度*", severitySelect),
+		widget.NewFormItem("対象者", container.NewVScroll(recipientCheck)),
+		widget.NewFormItem("関係職員", container.NewVScroll(staffCheck)),
+		widget.NewFormItem("発生時の状況*", descriptionEntry),
+		widget.NewFormItem("発生時の対応", responseEntry),
+		widget.NewFormItem("再発防止策", preventionEntry),
+	}
+
+	dlg := dialog.NewForm(title, "保存", "キャンセル", items, func(confirmed bool) {
+		if !confirmed {
+			return
+		}
+
+		formValidator := validation.NewFormValidator()
+		formData := map[string]string{
+			"occurred_date": strings.TrimSpace(dateEntry.Text),
+			"occurred_time": strings.TrimSpace(timeEntry.Text),
+			"location":      formValidator.SanitizeInput(locationEntry.Text),
+			"category":      categorySelect.Selected,
+			"severity":      severitySelect.Selected,
+			"description":   formValidator.SanitizeInput(descriptionEntry.Text),
+			"response":      formValidator.SanitizeInput(responseEntry.Text),
+			"prevention":    formValidator.SanitizeInput(preventionEntry.Text),
+		}
+		if validationErrors := formValidator.ValidateIncidentForm(formData); len(validationErrors) > 0 {
+			dialog.ShowError(errors.New(validationErrors.Error()), parent)
+			return
+		}
+
+		day, _ := wareki.Parse(formData["occurred_date"])
+		clock, _ := time.Parse("15:04", formData["occurred_time"])
+		occurredAt := time.Date(day.Year(), day.Month(), day.Day(), clock.Hour(), clock.Minute(), 0, 0, time.Local)
+
+		var category domain.IncidentCategory
+		for _, c := range domain.IncidentCategories {
+			if c.Label() == formData["category"] {
+				category = c
+			}
+		}
+		var severity domain.IncidentSeverity
+		for _, s := range domain.IncidentSeverities {
+			if s.Label() == formData["severity"] {
+				severity = s
+			}
+		}
+
+		selectedRecipients := idsForLabels(recipientCheck.Selected, recipientOptions, recipientIDs)
+		selectedStaff := idsForLabels(staffCheck.Selected, staffOptions, staffIDs)
+
+		var err error
+		if report == nil {
+			_, err = il.useCase.CreateIncident(context.Background(), usecase.CreateIncidentRequest{
+				OccurredAt:   occurredAt,
+				Location:     formData["location"],
+				RecipientIDs: selectedRecipients,
+				StaffIDs:     selectedStaff,
+				Category:     category,
+				Severity:     severity,
+				Description:  formData["description"],
+				Response:     formData["response"],
+				Prevention:   formData["prevention"],
+				ActorID:      il.currentUser.ID,
+			})
+		} else {
+			_, err = il.useCase.UpdateIncident(context.Background(), usecase.UpdateIncidentRequest{
+				ID:           report.ID,
+				OccurredAt:   occurredAt,
+				Location:     formData["location"],
+				RecipientIDs: selectedRecipients,
+				StaffIDs:     selectedStaff,
+				Category:     category,
+				Severity:     severity,
+				Description:  formData["description"],
+				Response:     formData["response"],
+				Prevention:   formData["prevention"],
+				ActorID:      il.currentUser.ID,
+			})
+		}
+
+		if err != nil {
+			dialog.ShowError(fmt.Errorf("報告の保存に失敗しました: %w", err), parent)
+			return
+		}
+
+		il.LoadData()
+	}, parent)
+
+	dlg.Resize(fyne.NewSize(620, 720))
+	dlg.Show()
+}
+
+// recipientChoices returns the selectable recipients as labels with matching IDs
+func (il *IncidentList) recipientChoices(ctx context.Context) ([]string, []domain.ID) {
+	recipients, err := il.recipientUseCase.GetActiveRecipients(ctx)
+	if err != nil {
+		return nil, nil
+	}
+
+	sort.Slice(recipients, func(i, j int) bool { return recipients[i].Kana < recipients[j].Kana })
+
+	labels := make([]string, 0, len(recipients))
+	ids := make([]domain.ID, 0, len(recipients))
+	for _, recipient := range recipients {
+		il.recipientMap[recipient.ID] = recipient
+		labels = append(labels, uniqueChoiceLabel(labels, recipient.Name))
+		ids = append(ids, recipient.ID)
+	}
+	return labels, ids
+}
+
+// staffChoices returns the selectable staff as labels with matching IDs
+func (il *IncidentList) staffChoices(ctx context.Context) ([]string, []domain.ID) {
+	result, err := il.staffUseCase.ListStaff(ctx, usecase.ListStaffRequest{Limit: 500})
+	if err != nil {
+		return nil, nil
+	}
+
+	labels := make([]string, 0, len(result.Staff))
+	ids := make([]domain.ID, 0, len(result.Staff))
+	for _, staff := range result.Staff {
+		il.staffMap[staff.ID] = staff
+		labels = append(labels, uniqueChoiceLabel(labels, staff.Name))
+		ids = append(ids, staff.ID)
+	}
+	return labels, ids
+}
+
+// uniqueChoiceLabel numbers a label when the same name is already listed
+func uniqueChoiceLabel(existing []string, label string) string {
+	candidate := label
+	for n := 2; ; n++ {
+		duplicate := false
+		for _, e := range existing {
+			if e == candidate {
+				duplicate = true
+				break
+			}
+		}
+		if !duplicate {
+			return candidate
+		}
+		candidate = fmt.Sprintf("%s (%d)", label, n)
+	}
+}
+
+// labelsForIDs maps selected IDs back to their choice labels
+func labelsForIDs(selected []domain.ID, labels []string, ids []domain.ID) []string {
+	var result []string
+	for _, id := range selected {
+		for i := range ids {
+			if ids[i] == id {
+				result = append(result, labels[i])
+			}
+		}
+	}
+	return result
+}
+
+// idsForLabels maps selected choice labels to their IDs
+func idsForLabels(selected []string, labels []string, ids []domain.ID) []domain.ID {
+	var result []domain.ID
+	for _, label := range selected {
+		for i := range labels {
+			if labels[i] == label {
+				result = append(result, ids[i])
+			}
+		}
+	}
+	return result
+}
+
+// showStatistics shows the monthly counts by category and severity with a PDF export
+func (il *IncidentList) showStatistics() {
+	if il.currentUser == nil {
+		return
+	}
+
+	parent := fyne.CurrentApp().Driver().AllWindows()[0]
+
+	stats, err := il.useCase.GetMonthlyStatistics(context.Background(), il.currentMonth.Year(), il.currentMonth.Month(), il.currentUser.ID)
+	if err != nil {
+		dialog.ShowError(fmt.Errorf("集計に失敗しました: %w", err), parent)
+		return
+	}
+
+	lines := []string{
+		fmt.Sprintf("合計: %d件（ヒヤリハット %d件 / 事故 %d件）", stats.Total, stats.NearMisses, stats.Accidents),
+		"",
+		"【種別】",
+	}
+	for _, category := range domain.IncidentCategories {
+		lines = append(lines, fmt.Sprintf("%s: %d件", category.Label(), stats.ByCategory[category]))
+	}
+	lines = append(lines, "", "【程度】")
+	for _, severity := range domain.IncidentSeverities {
+		lines = append(lines, fmt.Sprintf("%s: %d件", severity.Label(), stats.BySeverity[severity]))
+	}
+
+	exportButton := widget.NewButton("PDF出力", func() {
+		il.exportStatistics(stats)
+	})
+
+	content := container.NewBorder(nil, exportButton, nil, nil, container.NewVScroll(widget.NewLabel(strings.Join(lines, "\n"))))
+	dlg := dialog.NewCustom(fmt.Sprintf("%d年%d月の集計", stats.Year, int(stats.Month)), "閉じる", content, parent)
+	dlg.Resize(fyne.NewSize(420, 520))
+	dlg.Show()
+}
+
+// exportReport saves a single report in the municipal form as PDF
+func (il *IncidentList) exportReport(report *domain.IncidentReport) {
+	defaultName := fmt.Sprintf("事故報告書_%s.pdf", report.OccurredAt.Local().Format("20060102_1504"))
+	il.savePDF(defaultName, "報告書を保存しました。", func(ctx context.Context) ([]byte, error) {
+		return il.pdfService.GenerateIncidentReport(ctx, report, il.recipientMap, il.staffMap)
+	})
+}
+
+// exportStatistics saves the monthly statistics as PDF
+func (il *IncidentList) exportStatistics(stats *domain.IncidentStatistics) {
+	defaultName := fmt.Sprintf("事故ヒヤリハット集計_%04d%02d.pdf", stats.Year, int(stats.Month))
+	il.savePDF(defaultName, "集計を保存しました。", func(ctx context.Context) ([]byte, error) {
+		return il.pdfService.GenerateIncidentStatisticsReport(ctx, stats)
+	})
+}
+
+// savePDF asks for a file name and writes the generated PDF
+func (il *IncidentList) savePDF(defaultName, successMessage string, generate func(ctx context.Context) ([]byte, error)) {
+	parent := fyne.CurrentApp().Driver().AllWindows()[0]
+
+	if il.pdfService == nil {
+		dialog.ShowError(fmt.Errorf("PDFサービスが利用できません"), parent)
+		return
+	}
+
+	saveDialog := dialog.NewFileSave(func(writer fyne.URIWriteCloser, err error) {
+		if err != nil {
+			dialog.ShowError(fmt.Errorf("ファイルの保存に失敗しました: %w", err), parent)
+			return
+		}
+		if writer == nil {
+			return // User cancelled
+		}
+		defer writer.Close()
+
+		pdfBytes, err := generate(context.Background())
+		if err != nil {
+			dialog.ShowError(fmt.Errorf("PDF生成に失敗しました: %w", err), parent)
+			return
+		}
+
+		if _, err := writer.Write(pdfBytes); err != nil {
+			dialog.ShowError(fmt.Errorf("ファイルの書き込みに失敗しました: %w", err), parent)
+			return
+		}
+
+		dialog.ShowInformation("成功", successMessage, parent)
+	}, parent)
+
+	saveDialog.SetFileName(defaultName)
+	saveDialog.SetFilter(storage.NewExtensionFileFilter([]string{".pdf"}))
+	saveDialog.Show()
+}
+
+// CreateObject creates the main UI object for this widget
+func (il *IncidentList) CreateObject() fyne.CanvasObject {
+	// Header with filters and controls
+	header := container.NewBorder(
+		nil, nil,
+		container.NewHBox(
+			widget.NewLabel("対象月:"),
+			il.monthFilter,
+			widget.NewLabel("状態:"),
+			il.statusFilter,
+		),
+		container.NewHBox(
+			il.newButton,
+			il.refreshButton,
+			il.statsButton,
+		),
+		nil,
+	)
+
+	// Table with headers
+	tableContainer := container.NewBorder(
+		il.createTableHeader(),
+		nil, nil, nil,
+		il.table,
+	)
+
+	// Complete layout
+	return container.NewBorder(
+		header,
+		nil, nil, nil,
+		tableContainer,
+	)
+}
+
+// createTableHeader creates the table header
+func (il *IncidentList) createTableHeader() fyne.CanvasObject {
+	headers := []string{"発生日時", "種別", "程度", "発生場所", "対象者", "状態"}
+	headerWidgets := make([]fyne.CanvasObject, len(headers))
+
+	for i, header := range headers {
+		label := widget.NewLabel(header)
+		label.TextStyle.Bold = true
+		headerWidgets[i] = label
+	}
+
+	return container.NewHBox(headerWidgets...)
+}
+
+// Length returns the number of visible items in the table (for testing)
+func (il *IncidentList) Length() int {
+	return len(il.reports)
+}
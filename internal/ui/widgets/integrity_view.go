@@ -0,0 +1,295 @@
+package widgets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"shien-system/internal/domain"
+	"shien-system/internal/usecase"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+// integrityFinding is one row of the findings table
+type integrityFinding struct {
+	kind    string
+	subject string
+	detail  string
+}
+
+// IntegrityView lets administrators check the database and run guided repairs.
+// A check decrypts every encrypted value, so it only runs on request.
+type IntegrityView struct {
+	useCase     usecase.IntegrityUseCase
+	currentUser *domain.Staff
+
+	// UI components
+	checkButton      *widget.Button
+	quarantineButton *widget.Button
+	restoreButton    *widget.Button
+	summaryLabel     *widget.Label
+	guidanceLabel    *widget.Label
+	findingsTable    *widget.Table
+
+	// Data
+	report   *usecase.IntegrityReport
+	findings []integrityFinding
+}
+
+// NewIntegrityView creates a new IntegrityView widget
+func NewIntegrityView(useCase usecase.IntegrityUseCase, currentUser *domain.Staff) *IntegrityView {
+	iv := &IntegrityView{
+		useCase:     useCase,
+		currentUser: currentUser,
+	}
+
+	iv.createWidgets()
+
+	return iv
+}
+
+// createWidgets initializes all UI components
+func (iv *IntegrityView) createWidgets() {
+	iv.checkButton = widget.NewButton("チェックを実行", func() {
+		iv.RunCheck()
+	})
+	iv.quarantineButton = widget.NewButton("復号できない行を隔離", func() {
+		iv.showQuarantineDialog()
+	})
+	iv.restoreButton = widget.NewButton("検証済みバックアップから復元", func() {
+		iv.showRestoreDialog()
+	})
+
+	iv.summaryLabel = widget.NewLabel("まだチェックしていません。")
+	iv.guidanceLabel = widget.NewLabel("")
+	iv.guidanceLabel.Wrapping = fyne.TextWrapWord
+
+	iv.findingsTable = newSecurityTable(
+		[]float32{120, 260, 560},
+		func() int { return len(iv.findings) },
+		func(row, col int) string {
+			finding := iv.findings[row]
+			switch col {
+			case 0:
+				return finding.kind
+			case 1:
+				return finding.subject
+			default:
+				return finding.detail
+			}
+		},
+	)
+
+	iv.updateButtons()
+}
+
+// RunCheck runs the integrity check and shows the findings
+func (iv *IntegrityView) RunCheck() {
+	if !iv.isAdmin() {
+		return
+	}
+
+	report, err := iv.useCase.RunCheck(context.Background(), iv.currentUser.ID)
+	if err != nil {
+		iv.showError(fmt.Errorf("整合性チェックに失敗しました: %w", err))
+		return
+	}
+
+	iv.setReport(report)
+}
+
+// setReport replaces the shown report
+func (iv *IntegrityView) setReport(report *usecase.IntegrityReport) {
+	iv.report = report
+	iv.findings = integrityFindings(report)
+
+	if report.Healthy() {
+		iv.summaryLabel.SetText(fmt.Sprintf("%s 問題は見つかりませんでした（暗号化された値 %d件を確認）",
+			formatSecurityTime(report.CheckedAt), report.CheckedValues))
+	} else {
+		iv.summaryLabel.SetText(fmt.Sprintf("%s %d件の問題が見つかりました",
+			formatSecurityTime(report.CheckedAt), len(iv.findings)))
+	}
+	iv.guidanceLabel.SetText(integrityGuidance(report))
+
+	iv.findingsTable.Refresh()
+	iv.updateButtons()
+}
+
+func (iv *IntegrityView) updateButtons() {
+	if iv.report == nil || iv.report.Healthy() {
+		iv.quarantineButton.Disable()
+		iv.restoreButton.Disable()
+		return
+	}
+
+	if len(iv.report.UndecryptableRows) > 0 {
+		iv.quarantineButton.Enable()
+	} else {
+		iv.quarantineButton.Disable()
+	}
+	iv.restoreButton.Enable()
+}
+
+// showQuarantineDialog moves undecryptable rows out of the way
+func (iv *IntegrityView) showQuarantineDialog() {
+	if iv.report == nil {
+		return
+	}
+
+	reasonEntry := widget.NewMultiLineEntry()
+	reasonEntry.SetPlaceHolder("理由（必須。例: 旧端末の鍵が失われたため）")
+
+	dialog.ShowForm("復号できない行を隔離", "隔離", "キャンセル",
+		[]*widget.FormItem{
+			widget.NewFormItem("対象", widget.NewLabel(fmt.Sprintf("%d行と、それに属する受給者証・連絡先などの行",
+				len(iv.report.UndecryptableRows)))),
+			widget.NewFormItem("理由", reasonEntry),
+			widget.NewFormItem("", widget.NewLabel("隔離した行は画面に表示されなくなります。暗号文は隔離テーブルに保存されます。")),
+		},
+		func(confirmed bool) {
+			if !confirmed {
+				return
+			}
+
+			moved, err := iv.useCase.QuarantineUndecryptable(context.Background(), usecase.QuarantineRowsRequest{
+				Reason:  reasonEntry.Text,
+				ActorID: iv.currentUser.ID,
+			})
+			if err != nil && !errors.Is(err, usecase.ErrNothingToQuarantine) {
+				iv.showError(fmt.Errorf("隔離できませんでした: %w", err))
+				return
+			}
+
+			dialog.ShowInformation("隔離", fmt.Sprintf("%d行を隔離しました。", moved), iv.parentWindow())
+			iv.RunCheck()
+		}, iv.parentWindow())
+}
+
+// showRestoreDialog restores the newest backup that passes validation
+func (iv *IntegrityView) showRestoreDialog() {
+	reasonEntry := widget.NewMultiLineEntry()
+	reasonEntry.SetPlaceHolder("理由（必須）")
+
+	dialog.ShowForm("検証済みバックアップから復元", "復元", "キャンセル",
+		[]*widget.FormItem{
+			widget.NewFormItem("理由", reasonEntry),
+			widget.NewFormItem("", widget.NewLabel("検証に成功した最新のバックアップで現在のデータを置き換えます。\nバックアップ以降の変更は失われます。")),
+		},
+		func(confirmed bool) {
+			if !confirmed {
+				return
+			}
+
+			resp, err := iv.useCase.RestoreLastVerifiedBackup(context.Background(), usecase.RestoreVerifiedBackupRequest{
+				Reason:  reasonEntry.Text,
+				ActorID: iv.currentUser.ID,
+			})
+			if err != nil {
+				iv.showError(fmt.Errorf("復元できませんでした: %w", err))
+				return
+			}
+
+			dialog.ShowInformation("復元", fmt.Sprintf("バックアップ %s から復元しました。アプリケーションを再起動してください。",
+				resp.BackupID), iv.parentWindow())
+		}, iv.parentWindow())
+}
+
+func (iv *IntegrityView) isAdmin() bool {
+	return iv.currentUser != nil && iv.currentUser.Role == domain.RoleAdmin
+}
+
+// parentWindow returns the main window for dialogs
+func (iv *IntegrityView) parentWindow() fyne.Window {
+	return fyne.CurrentApp().Driver().AllWindows()[0]
+}
+
+// showError shows an error dialog on the main window
+func (iv *IntegrityView) showError(err error) {
+	if app := fyne.CurrentApp(); app != nil && len(app.Driver().AllWindows()) > 0 {
+		dialog.ShowError(err, app.Driver().AllWindows()[0])
+	}
+}
+
+// CreateObject creates the UI object for the integrity view
+func (iv *IntegrityView) CreateObject() fyne.CanvasObject {
+	if !iv.isAdmin() {
+		return container.NewCenter(widget.NewLabel("この画面は管理者のみ利用できます。"))
+	}
+
+	header := container.NewBorder(
+		nil, nil,
+		widget.NewLabel("データベース点検"),
+		iv.checkButton,
+		iv.summaryLabel,
+	)
+
+	repairs := container.NewHBox(iv.quarantineButton, iv.restoreButton)
+
+	headers := []string{"種類", "対象", "詳細"}
+	headerWidgets := make([]fyne.CanvasObject, len(headers))
+	for i, text := range headers {
+		label := widget.NewLabel(text)
+		label.TextStyle.Bold = true
+		headerWidgets[i] = label
+	}
+
+	top := container.NewVBox(header, iv.guidanceLabel, repairs, container.NewHBox(headerWidgets...))
+
+	return container.NewBorder(top, nil, nil, nil, iv.findingsTable)
+}
+
+// integrityFindings flattens a report into table rows
+func integrityFindings(report *usecase.IntegrityReport) []integrityFinding {
+	var findings []integrityFinding
+	for _, message := range report.IntegrityErrors {
+		findings = append(findings, integrityFinding{"ファイル破損", "-", message})
+	}
+	for _, violation := range report.ForeignKeyViolations {
+		findings = append(findings, integrityFinding{
+			"参照整合性",
+			fmt.Sprintf("%s (rowid %d)", violation.Table, violation.RowID),
+			fmt.Sprintf("%s に存在しない行を参照しています", violation.Parent),
+		})
+	}
+	for _, row := range report.UndecryptableRows {
+		findings = append(findings, integrityFinding{
+			"復号不可",
+			fmt.Sprintf("%s %s", row.Table, valueOrDash(row.Key)),
+			strings.Join(row.Columns, ", "),
+		})
+	}
+	for _, orphan := range report.Orphans {
+		findings = append(findings, integrityFinding{
+			"参照切れ",
+			orphan.Table,
+			fmt.Sprintf("%s: %d件", orphan.Check, orphan.Count),
+		})
+	}
+	return findings
+}
+
+// integrityGuidance suggests the repair for a report
+func integrityGuidance(report *usecase.IntegrityReport) string {
+	switch {
+	case report.Healthy():
+		return ""
+	case report.NeedsRestore():
+		return "データベースファイルが破損しています。これ以上の更新を避け、検証済みのバックアップから復元してください。"
+	case len(report.UndecryptableRows) > 0:
+		return "現在の暗号鍵で復号できない行があります。鍵を移行していない端末のデータの可能性があります。" +
+			"旧い鍵が見つからない場合は行を隔離するか、バックアップから復元してください。"
+	default:
+		return "参照先が存在しない行があります。直前の削除操作を確認し、必要ならバックアップから復元してください。"
+	}
+}
+
+// Length returns the number of findings shown (for testing)
+func (iv *IntegrityView) Length() int {
+	return len(iv.findings)
+}
@@ -0,0 +1,232 @@
+package widgets
+
+import (
+	"fmt"
+	"time"
+
+	"shien-system/internal/adapter/scheduler"
+	"shien-system/internal/domain"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+// JobStatusPanel shows the state of the background maintenance jobs to administrators
+type JobStatusPanel struct {
+	scheduler   *scheduler.Scheduler
+	currentUser *domain.Staff
+
+	// UI components
+	table         *widget.Table
+	runButton     *widget.Button
+	refreshButton *widget.Button
+
+	// Data
+	statuses    []scheduler.JobStatus
+	selectedRow int
+}
+
+// NewJobStatusPanel creates a new JobStatusPanel widget
+func NewJobStatusPanel(jobScheduler *scheduler.Scheduler, currentUser *domain.Staff) *JobStatusPanel {
+	jp := &JobStatusPanel{
+		scheduler:   jobScheduler,
+		currentUser: currentUser,
+		selectedRow: -1,
+	}
+
+	jp.createWidgets()
+	jp.setupTable()
+
+	return jp
+}
+
+// createWidgets initializes all UI components
+func (jp *JobStatusPanel) createWidgets() {
+	// Table
+	jp.table = widget.NewTable(
+		func() (int, int) {
+			return len(jp.statuses), 7 // 7 columns
+		},
+		func() fyne.CanvasObject {
+			return widget.NewLabel("")
+		},
+		func(id widget.TableCellID, obj fyne.CanvasObject) {
+			jp.updateTableCell(id, obj.(*widget.Label))
+		},
+	)
+
+	// Buttons
+	jp.runButton = widget.NewButton("今すぐ実行", func() {
+		jp.runSelected()
+	})
+	jp.runButton.Disable()
+
+	jp.refreshButton = widget.NewButton("更新", func() {
+		jp.LoadData()
+	})
+}
+
+// setupTable configures the table widget
+func (jp *JobStatusPanel) setupTable() {
+	// Set column widths
+	jp.table.SetColumnWidth(0, 260) // ジョブ
+	jp.table.SetColumnWidth(1, 110) // スケジュール
+	jp.table.SetColumnWidth(2, 130) // 前回実行
+	jp.table.SetColumnWidth(3, 80)  // 所要時間
+	jp.table.SetColumnWidth(4, 200) // 結果
+	jp.table.SetColumnWidth(5, 130) // 次回実行
+	jp.table.SetColumnWidth(6, 80)  // 連続失敗
+
+	jp.table.OnSelected = func(id widget.TableCellID) {
+		jp.selectedRow = id.Row
+		if id.Row < len(jp.statuses) && !jp.statuses[id.Row].Running {
+			jp.runButton.Enable()
+		} else {
+			jp.runButton.Disable()
+		}
+	}
+}
+
+// updateTableCell updates a specific table cell with job data
+func (jp *JobStatusPanel) updateTableCell(id widget.TableCellID, label *widget.Label) {
+	if id.Row >= len(jp.statuses) {
+		label.SetText("")
+		return
+	}
+
+	status := jp.statuses[id.Row]
+	state := status.State
+
+	switch id.Col {
+	case 0: // ジョブ
+		label.SetText(status.Description)
+	case 1: // スケジュール
+		label.SetText(status.Schedule)
+	case 2: // 前回実行
+		label.SetText(formatJobTime(state.LastRunAt))
+	case 3: // 所要時間
+		if state.LastRunAt == nil {
+			label.SetText("-")
+		} else {
+			label.SetText(state.LastDuration.Round(time.Millisecond).String())
+		}
+	case 4: // 結果
+		label.SetText(jobResultText(status))
+	case 5: // 次回実行
+		label.SetText(formatJobTime(state.NextRunAt))
+	case 6: // 連続失敗
+		label.SetText(fmt.Sprintf("%d", state.ConsecutiveFailures))
+	default:
+		label.SetText("")
+	}
+}
+
+// jobResultText summarizes the outcome of the last run
+func jobResultText(status scheduler.JobStatus) string {
+	switch {
+	case status.Running:
+		return "実行中"
+	case status.State.LastRunAt == nil:
+		return "未実行"
+	case status.State.LastError != "":
+		return "失敗: " + status.State.LastError
+	default:
+		return "成功"
+	}
+}
+
+// formatJobTime formats an optional job timestamp for display
+func formatJobTime(t *time.Time) string {
+	if t == nil {
+		return "-"
+	}
+	return t.Local().Format("2006/01/02 15:04")
+}
+
+// LoadData reloads the job statuses from the scheduler
+func (jp *JobStatusPanel) LoadData() {
+	if jp.currentUser == nil || jp.currentUser.Role != domain.RoleAdmin {
+		return
+	}
+
+	jp.statuses = jp.scheduler.Status()
+	jp.selectedRow = -1
+	jp.runButton.Disable()
+	jp.table.UnselectAll()
+	jp.table.Refresh()
+}
+
+// runSelected triggers the selected job immediately
+func (jp *JobStatusPanel) runSelected() {
+	if jp.selectedRow < 0 || jp.selectedRow >= len(jp.statuses) {
+		return
+	}
+
+	parent := fyne.CurrentApp().Driver().AllWindows()[0]
+	status := jp.statuses[jp.selectedRow]
+
+	if err := jp.scheduler.RunNow(status.Name); err != nil {
+		if err == scheduler.ErrJobRunning {
+			dialog.ShowInformation("実行中", fmt.Sprintf("「%s」は実行中です。", status.Description), parent)
+			return
+		}
+		dialog.ShowError(fmt.Errorf("ジョブの実行に失敗しました: %w", err), parent)
+		return
+	}
+
+	dialog.ShowInformation("実行開始", fmt.Sprintf("「%s」を開始しました。完了後に「更新」で結果を確認してください。", status.Description), parent)
+	jp.LoadData()
+}
+
+// CreateObject creates the UI object for the job status panel
+func (jp *JobStatusPanel) CreateObject() fyne.CanvasObject {
+	if jp.currentUser == nil || jp.currentUser.Role != domain.RoleAdmin {
+		return container.NewCenter(widget.NewLabel("この画面は管理者のみ利用できます。"))
+	}
+
+	// Header with controls
+	header := container.NewBorder(
+		nil, nil,
+		widget.NewLabel("バックグラウンドジョブ"),
+		container.NewHBox(
+			jp.runButton,
+			jp.refreshButton,
+		),
+		nil,
+	)
+
+	// Table with headers
+	tableContainer := container.NewBorder(
+		jp.createTableHeader(),
+		nil, nil, nil,
+		jp.table,
+	)
+
+	// Complete layout
+	return container.NewBorder(
+		header,
+		nil, nil, nil,
+		tableContainer,
+	)
+}
+
+// createTableHeader creates the table header
+func (jp *JobStatusPanel) createTableHeader() fyne.CanvasObject {
+	headers := []string{"ジョブ", "スケジュール", "前回実行", "所要時間", "結果", "次回実行", "連続失敗"}
+	headerWidgets := make([]fyne.CanvasObject, len(headers))
+
+	for i, header := range headers {
+		label := widget.NewLabel(header)
+		label.TextStyle.Bold = true
+		headerWidgets[i] = label
+	}
+
+	return container.NewHBox(headerWidgets...)
+}
+
+// Length returns the number of visible items in the table (for testing)
+func (jp *JobStatusPanel) Length() int {
+	return len(jp.statuses)
+}
@@ -0,0 +1,264 @@
+package widgets
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"shien-system/internal/domain"
+	"shien-system/internal/usecase"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+// logLevelOptions are the minimum levels offered in the log viewer
+var logLevelOptions = []struct {
+	label string
+	level string
+}{
+	{"すべて", "debug"},
+	{"情報以上", "info"},
+	{"警告以上", "warn"},
+	{"エラーのみ", "error"},
+}
+
+// logPeriodOptions are the periods offered in the log viewer
+var logPeriodOptions = []struct {
+	label  string
+	period time.Duration
+}{
+	{"1時間", time.Hour},
+	{"24時間", 24 * time.Hour},
+	{"7日", 7 * 24 * time.Hour},
+	{"すべて", 0},
+}
+
+// LogViewer shows the application log to administrators. Personal information
+// is already masked when the log is written.
+type LogViewer struct {
+	useCase     usecase.LogUseCase
+	currentUser *domain.Staff
+
+	// UI components
+	levelSelect   *widget.Select
+	periodSelect  *widget.Select
+	searchEntry   *widget.Entry
+	summaryLabel  *widget.Label
+	logTable      *widget.Table
+	refreshButton *widget.Button
+
+	// Data
+	entries []*usecase.LogEntry
+}
+
+// NewLogViewer creates a new LogViewer widget
+func NewLogViewer(useCase usecase.LogUseCase, currentUser *domain.Staff) *LogViewer {
+	lv := &LogViewer{
+		useCase:     useCase,
+		currentUser: currentUser,
+	}
+
+	lv.createWidgets()
+
+	return lv
+}
+
+// createWidgets initializes all UI components
+func (lv *LogViewer) createWidgets() {
+	levelLabels := make([]string, len(logLevelOptions))
+	for i, option := range logLevelOptions {
+		levelLabels[i] = option.label
+	}
+	lv.levelSelect = widget.NewSelect(levelLabels, func(string) {
+		lv.LoadData()
+	})
+
+	periodLabels := make([]string, len(logPeriodOptions))
+	for i, option := range logPeriodOptions {
+		periodLabels[i] = option.label
+	}
+	lv.periodSelect = widget.NewSelect(periodLabels, func(string) {
+		lv.LoadData()
+	})
+
+	lv.searchEntry = widget.NewEntry()
+	lv.searchEntry.SetPlaceHolder("メッセージ・項目を検索")
+	lv.searchEntry.OnSubmitted = func(string) {
+		lv.LoadData()
+	}
+
+	lv.summaryLabel = widget.NewLabel("")
+
+	lv.logTable = newSecurityTable(
+		[]float32{150, 70, 320, 520},
+		func() int { return len(lv.entries) },
+		func(row, col int) string { return logEntryCell(lv.entries[row], col) },
+	)
+	lv.logTable.OnSelected = func(id widget.TableCellID) {
+		if id.Row < len(lv.entries) {
+			lv.showEntryDialog(lv.entries[id.Row])
+		}
+		lv.logTable.UnselectAll()
+	}
+
+	lv.refreshButton = widget.NewButton("更新", func() {
+		lv.LoadData()
+	})
+
+	// Set the defaults last so the change callbacks do not load twice
+	lv.levelSelect.Selected = logLevelOptions[1].label
+	lv.periodSelect.Selected = logPeriodOptions[1].label
+}
+
+// LoadData reloads the log with the current filters
+func (lv *LogViewer) LoadData() {
+	if lv.currentUser == nil || lv.currentUser.Role != domain.RoleAdmin {
+		return
+	}
+
+	query := usecase.LogQuery{Text: lv.searchEntry.Text}
+	for _, option := range logLevelOptions {
+		if option.label == lv.levelSelect.Selected {
+			query.MinLevel = option.level
+		}
+	}
+	for _, option := range logPeriodOptions {
+		if option.label == lv.periodSelect.Selected && option.period > 0 {
+			query.Since = time.Now().Add(-option.period)
+		}
+	}
+
+	entries, err := lv.useCase.ListEntries(context.Background(), usecase.ListLogEntriesRequest{
+		Query:   query,
+		ActorID: lv.currentUser.ID,
+	})
+	if err != nil {
+		lv.showError(fmt.Errorf("ログの読み込みに失敗しました: %w", err))
+		return
+	}
+
+	lv.entries = entries
+	lv.summaryLabel.SetText(fmt.Sprintf("%d件", len(entries)))
+	lv.logTable.Refresh()
+}
+
+// showEntryDialog shows every attribute of an entry
+func (lv *LogViewer) showEntryDialog(entry *usecase.LogEntry) {
+	lines := []string{
+		fmt.Sprintf("日時: %s", entry.Time.Local().Format("2006/01/02 15:04:05")),
+		fmt.Sprintf("レベル: %s", logLevelLabel(entry.Level)),
+		fmt.Sprintf("メッセージ: %s", entry.Message),
+	}
+	for _, key := range sortedAttrKeys(entry.Attrs) {
+		lines = append(lines, fmt.Sprintf("%s: %s", key, entry.Attrs[key]))
+	}
+
+	content := widget.NewLabel(strings.Join(lines, "\n"))
+	content.Wrapping = fyne.TextWrapWord
+	scroll := container.NewVScroll(content)
+	scroll.SetMinSize(fyne.NewSize(600, 300))
+
+	dialog.ShowCustom("ログの詳細", "閉じる", scroll, lv.parentWindow())
+}
+
+// parentWindow returns the main window for dialogs
+func (lv *LogViewer) parentWindow() fyne.Window {
+	return fyne.CurrentApp().Driver().AllWindows()[0]
+}
+
+// showError shows an error dialog on the main window
+func (lv *LogViewer) showError(err error) {
+	if app := fyne.CurrentApp(); app != nil && len(app.Driver().AllWindows()) > 0 {
+		dialog.ShowError(err, app.Driver().AllWindows()[0])
+	}
+}
+
+// CreateObject creates the UI object for the log viewer
+func (lv *LogViewer) CreateObject() fyne.CanvasObject {
+	if lv.currentUser == nil || lv.currentUser.Role != domain.RoleAdmin {
+		return container.NewCenter(widget.NewLabel("この画面は管理者のみ利用できます。"))
+	}
+
+	header := container.NewBorder(
+		nil, nil,
+		widget.NewLabel("システムログ"),
+		lv.refreshButton,
+		lv.summaryLabel,
+	)
+
+	filters := container.NewBorder(
+		nil, nil,
+		container.NewHBox(
+			widget.NewLabel("レベル"), lv.levelSelect,
+			widget.NewLabel("期間"), lv.periodSelect,
+		),
+		nil,
+		lv.searchEntry,
+	)
+
+	headers := []string{"日時", "レベル", "メッセージ", "詳細"}
+	headerWidgets := make([]fyne.CanvasObject, len(headers))
+	for i, text := range headers {
+		label := widget.NewLabel(text)
+		label.TextStyle.Bold = true
+		headerWidgets[i] = label
+	}
+
+	top := container.NewVBox(header, filters, container.NewHBox(headerWidgets...))
+
+	return container.NewBorder(top, nil, nil, nil, lv.logTable)
+}
+
+// Cell formatting
+
+func logEntryCell(entry *usecase.LogEntry, col int) string {
+	switch col {
+	case 0:
+		return entry.Time.Local().Format("2006/01/02 15:04:05")
+	case 1:
+		return logLevelLabel(entry.Level)
+	case 2:
+		return entry.Message
+	case 3:
+		parts := make([]string, 0, len(entry.Attrs))
+		for _, key := range sortedAttrKeys(entry.Attrs) {
+			parts = append(parts, key+"="+entry.Attrs[key])
+		}
+		return strings.Join(parts, " ")
+	}
+	return ""
+}
+
+func logLevelLabel(level string) string {
+	switch level {
+	case "DEBUG":
+		return "デバッグ"
+	case "INFO":
+		return "情報"
+	case "WARN":
+		return "警告"
+	case "ERROR":
+		return "エラー"
+	default:
+		return level
+	}
+}
+
+func sortedAttrKeys(attrs map[string]string) []string {
+	keys := make([]string, 0, len(attrs))
+	for key := range attrs {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Length returns the number of log entries shown (for testing)
+func (lv *LogViewer) Length() int {
+	return len(lv.entries)
+}
@@ -0,0 +1,437 @@
+package widgets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"image/color"
+	"strings"
+	"time"
+
+	"shien-system/internal/domain"
+	"shien-system/internal/usecase"
+	"shien-system/internal/wareki"
+	"shien-system/internal/validation"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// MedicalRecordSection shows and edits the medical information of a recipient
+type MedicalRecordSection struct {
+	useCase usecase.MedicalRecordUseCase
+
+	// UI components
+	allergyBanner        *fyne.Container
+	allergyBannerLabel   *widget.Label
+	medicationList       *fyne.Container
+	allergyList          *fyne.Container
+	addMedicationButton  *widget.Button
+	addAllergyButton     *widget.Button
+	epilepsyCheck        *widget.Check
+	seizureProtocolEntry *widget.Entry
+	hospitalNameEntry    *widget.Entry
+	hospitalPhoneEntry   *widget.Entry
+	doctorNameEntry      *widget.Entry
+	insuranceTypeSelect  *widget.SelectEntry
+	insurerNumberEntry   *widget.Entry
+	insuredSymbolEntry   *widget.Entry
+	insuredNumberEntry   *widget.Entry
+	insuranceValidEntry  *widget.Entry
+	notesEntry           *widget.Entry
+	saveButton           *widget.Button
+	statusLabel          *widget.Label
+
+	// State
+	recipient   *domain.Recipient
+	currentUser *domain.Staff
+	medications []domain.Medication
+	allergies   []domain.Allergy
+}
+
+// NewMedicalRecordSection creates a new medical record section
+func NewMedicalRecordSection(useCase usecase.MedicalRecordUseCase) *MedicalRecordSection {
+	section := &MedicalRecordSection{
+		useCase: useCase,
+	}
+	section.createWidgets()
+	return section
+}
+
+// createWidgets initializes all UI components
+func (mrs *MedicalRecordSection) createWidgets() {
+	mrs.allergyBannerLabel = widget.NewLabel("")
+	mrs.allergyBannerLabel.TextStyle = fyne.TextStyle{Bold: true}
+	mrs.allergyBannerLabel.Importance = widget.DangerImportance
+	mrs.allergyBannerLabel.Wrapping = fyne.TextWrapWord
+	mrs.allergyBanner = container.NewStack(
+		canvas.NewRectangle(color.NRGBA{R: 255, G: 220, B: 220, A: 255}),
+		container.NewBorder(nil, nil, widget.NewIcon(theme.WarningIcon()), nil, mrs.allergyBannerLabel),
+	)
+	mrs.allergyBanner.Hide()
+
+	mrs.medicationList = container.NewVBox()
+	mrs.allergyList = container.NewVBox()
+
+	mrs.addMedicationButton = widget.NewButton("服薬を追加", func() {
+		mrs.showMedicationDialog()
+	})
+	mrs.addAllergyButton = widget.NewButton("アレルギーを追加", func() {
+		mrs.showAllergyDialog()
+	})
+
+	mrs.epilepsyCheck = widget.NewCheck("てんかんあり", nil)
+	mrs.seizureProtocolEntry = widget.NewMultiLineEntry()
+	mrs.seizureProtocolEntry.SetPlaceHolder("例: 横向きに寝かせ時間を計測、5分以上続く場合は救急要請")
+
+	mrs.hospitalNameEntry = widget.NewEntry()
+	mrs.hospitalPhoneEntry = widget.NewEntry()
+	mrs.hospitalPhoneEntry.SetPlaceHolder("03-1234-5678")
+	mrs.doctorNameEntry = widget.NewEntry()
+
+	mrs.insuranceTypeSelect = widget.NewSelectEntry([]string{"国民健康保険", "社会保険", "後期高齢者医療", "生活保護（医療扶助）"})
+	mrs.insurerNumberEntry = widget.NewEntry()
+	mrs.insurerNumberEntry.SetPlaceHolder("6桁または8桁")
+	mrs.insuredSymbolEntry = widget.NewEntry()
+	mrs.insuredNumberEntry = widget.NewEntry()
+	mrs.insuranceValidEntry = widget.NewEntry()
+	mrs.insuranceValidEntry.SetPlaceHolder(datePlaceHolder)
+
+	mrs.notesEntry = widget.NewMultiLineEntry()
+
+	mrs.saveButton = widget.NewButton("医療情報を保存", func() {
+		mrs.handleSave()
+	})
+	mrs.saveButton.Importance = widget.HighImportance
+
+	mrs.statusLabel = widget.NewLabel("")
+}
+
+// SetRecipient loads the medical record of the recipient being edited
+func (mrs *MedicalRecordSection) SetRecipient(recipient *domain.Recipient, currentUser *domain.Staff) {
+	mrs.recipient = recipient
+	mrs.currentUser = currentUser
+	mrs.LoadData()
+}
+
+// LoadData reloads the medical record; a recipient without one starts with an empty form
+func (mrs *MedicalRecordSection) LoadData() {
+	mrs.populate(&domain.MedicalRecord{})
+	mrs.statusLabel.SetText("")
+
+	if mrs.recipient == nil || mrs.currentUser == nil {
+		return
+	}
+
+	record, err := mrs.useCase.GetMedicalRecord(context.Background(), mrs.recipient.ID, mrs.currentUser.ID)
+	if err != nil {
+		if errors.Is(err, usecase.ErrMedicalRecordNotFound) {
+			mrs.statusLabel.SetText("医療情報は未登録です")
+			return
+		}
+		mrs.statusLabel.SetText("医療情報を取得できませんでした")
+		return
+	}
+
+	mrs.populate(record)
+	mrs.statusLabel.SetText(fmt.Sprintf("最終更新: %s", record.UpdatedAt.Local().Format("2006/01/02 15:04")))
+}
+
+// populate fills the form with a record
+func (mrs *MedicalRecordSection) populate(record *domain.MedicalRecord) {
+	mrs.medications = append([]domain.Medication(nil), record.Medications...)
+	mrs.allergies = append([]domain.Allergy(nil), record.Allergies...)
+
+	mrs.epilepsyCheck.SetChecked(record.HasEpilepsy)
+	mrs.seizureProtocolEntry.SetText(record.SeizureProtocol)
+	mrs.hospitalNameEntry.SetText(record.HospitalName)
+	mrs.hospitalPhoneEntry.SetText(record.HospitalPhone)
+	mrs.doctorNameEntry.SetText(record.DoctorName)
+	mrs.insuranceTypeSelect.SetText(record.InsuranceType)
+	mrs.insurerNumberEntry.SetText(record.InsurerNumber)
+	mrs.insuredSymbolEntry.SetText(record.InsuredSymbol)
+	mrs.insuredNumberEntry.SetText(record.InsuredNumber)
+	if record.InsuranceValidUntil != nil {
+		mrs.insuranceValidEntry.SetText(dateStyleOf(mrs.currentUser).Format(*record.InsuranceValidUntil))
+	} else {
+		mrs.insuranceValidEntry.SetText("")
+	}
+	mrs.notesEntry.SetText(record.Notes)
+
+	mrs.refreshLists()
+}
+
+// refreshLists redraws the medication and allergy lists and the allergy banner
+func (mrs *MedicalRecordSection) refreshLists() {
+	mrs.medicationList.RemoveAll()
+	if len(mrs.medications) == 0 {
+		mrs.medicationList.Add(widget.NewLabel("服薬は登録されていません"))
+	}
+	for i, medication := range mrs.medications {
+		index := i
+		text := fmt.Sprintf("%s %s %s", medication.Name, medication.Dose, medication.Timing)
+		if medication.Notes != "" {
+			text += fmt.Sprintf("（%s）", medication.Notes)
+		}
+		removeButton := widget.NewButton("削除", func() {
+			mrs.medications = append(mrs.medications[:index], mrs.medications[index+1:]...)
+			mrs.refreshLists()
+		})
+		mrs.medicationList.Add(container.NewBorder(nil, nil, nil, removeButton, widget.NewLabel(text)))
+	}
+
+	mrs.allergyList.RemoveAll()
+	if len(mrs.allergies) == 0 {
+		mrs.allergyList.Add(widget.NewLabel("アレルギーは登録されていません"))
+	}
+	for i, allergy := range mrs.allergies {
+		index := i
+		label := widget.NewLabel(formatAllergy(allergy))
+		if allergy.Critical {
+			label.Importance = widget.DangerImportance
+			label.TextStyle = fyne.TextStyle{Bold: true}
+		}
+		removeButton := widget.NewButton("削除", func() {
+			mrs.allergies = append(mrs.allergies[:index], mrs.allergies[index+1:]...)
+			mrs.refreshLists()
+		})
+		mrs.allergyList.Add(container.NewBorder(nil, nil, nil, removeButton, label))
+	}
+
+	mrs.updateAllergyBanner()
+}
+
+// updateAllergyBanner shows the banner while a critical allergy is flagged
+func (mrs *MedicalRecordSection) updateAllergyBanner() {
+	var critical []string
+	for _, allergy := range mrs.allergies {
+		if allergy.Critical {
+			critical = append(critical, formatAllergy(allergy))
+		}
+	}
+
+	if len(critical) == 0 {
+		mrs.allergyBanner.Hide()
+		return
+	}
+
+	mrs.allergyBannerLabel.SetText("重篤なアレルギーがあります: " + strings.Join(critical, "、"))
+	mrs.allergyBanner.Show()
+}
+
+// formatAllergy renders an allergy as "allergen（reaction）"
+func formatAllergy(allergy domain.Allergy) string {
+	if allergy.Reaction == "" {
+		return allergy.Allergen
+	}
+	return fmt.Sprintf("%s（%s）", allergy.Allergen, allergy.Reaction)
+}
+
+// showMedicationDialog asks for a medication to add
+func (mrs *MedicalRecordSection) showMedicationDialog() {
+	parent := fyne.CurrentApp().Driver().AllWindows()[0]
+
+	nameEntry := widget.NewEntry()
+	doseEntry := widget.NewEntry()
+	doseEntry.SetPlaceHolder("例: 1錠、200mg")
+	timingEntry := widget.NewEntry()
+	timingEntry.SetPlaceHolder("例: 朝夕食後、就寝前、発作時")
+	notesEntry := widget.NewEntry()
+
+	items := []*widget.FormItem{
+		widget.NewFormItem("薬剤名*", nameEntry),
+		widget.NewFormItem("用量", doseEntry),
+		widget.NewFormItem("服用時間", timingEntry),
+		widget.NewFormItem("備考", notesEntry),
+	}
+
+	dialog.ShowForm("服薬の追加", "追加", "キャンセル", items, func(confirmed bool) {
+		if !confirmed {
+			return
+		}
+
+		formValidator := validation.NewFormValidator()
+		name := formValidator.SanitizeInput(nameEntry.Text)
+		if name == "" {
+			dialog.ShowError(fmt.Errorf("薬剤名は必須です"), parent)
+			return
+		}
+
+		mrs.medications = append(mrs.medications, domain.Medication{
+			Name:   name,
+			Dose:   formValidator.SanitizeInput(doseEntry.Text),
+			Timing: formValidator.SanitizeInput(timingEntry.Text),
+			Notes:  formValidator.SanitizeInput(notesEntry.Text),
+		})
+		mrs.refreshLists()
+	}, parent)
+}
+
+// showAllergyDialog asks for an allergy to add
+func (mrs *MedicalRecordSection) showAllergyDialog() {
+	parent := fyne.CurrentApp().Driver().AllWindows()[0]
+
+	allergenEntry := widget.NewEntry()
+	allergenEntry.SetPlaceHolder("例: そば、ペニシリン")
+	reactionEntry := widget.NewEntry()
+	reactionEntry.SetPlaceHolder("例: 発疹、アナフィラキシー")
+	criticalCheck := widget.NewCheck("重篤（命に関わる）", nil)
+
+	items := []*widget.FormItem{
+		widget.NewFormItem("アレルゲン*", allergenEntry),
+		widget.NewFormItem("症状", reactionEntry),
+		widget.NewFormItem("", criticalCheck),
+	}
+
+	dialog.ShowForm("アレルギーの追加", "追加", "キャンセル", items, func(confirmed bool) {
+		if !confirmed {
+			return
+		}
+
+		formValidator := validation.NewFormValidator()
+		allergen := formValidator.SanitizeInput(allergenEntry.Text)
+		if allergen == "" {
+			dialog.ShowError(fmt.Errorf("アレルゲンは必須です"), parent)
+			return
+		}
+
+		mrs.allergies = append(mrs.allergies, domain.Allergy{
+			Allergen: allergen,
+			Reaction: formValidator.SanitizeInput(reactionEntry.Text),
+			Critical: criticalCheck.Checked,
+		})
+		mrs.refreshLists()
+	}, parent)
+}
+
+// handleSave validates the form and saves the whole record
+func (mrs *MedicalRecordSection) handleSave() {
+	if mrs.recipient == nil || mrs.currentUser == nil {
+		return
+	}
+
+	parent := fyne.CurrentApp().Driver().AllWindows()[0]
+
+	formValidator := validation.NewFormValidator()
+	formData := map[string]string{
+		"hospital_phone":        strings.TrimSpace(mrs.hospitalPhoneEntry.Text),
+		"insurer_number":        strings.TrimSpace(mrs.insurerNumberEntry.Text),
+		"insurance_valid_until": strings.TrimSpace(mrs.insuranceValidEntry.Text),
+		"seizure_protocol":      formValidator.SanitizeInput(mrs.seizureProtocolEntry.Text),
+		"notes":                 formValidator.SanitizeInput(mrs.notesEntry.Text),
+	}
+	if mrs.epilepsyCheck.Checked {
+		formData["has_epilepsy"] = "true"
+	}
+	if validationErrors := formValidator.ValidateMedicalRecordForm(formData); len(validationErrors) > 0 {
+		dialog.ShowError(errors.New(validationErrors.Error()), parent)
+		return
+	}
+
+	var validUntil *time.Time
+	if formData["insurance_valid_until"] != "" {
+		parsed, _ := wareki.Parse(formData["insurance_valid_until"])
+		validUntil = &parsed
+	}
+
+	_, err := mrs.useCase.SaveMedicalRecord(context.Background(), usecase.SaveMedicalRecordRequest{
+		RecipientID:         mrs.recipient.ID,
+		Medications:         mrs.medications,
+		Allergies:           mrs.allergies,
+		HasEpilepsy:         mrs.epilepsyCheck.Checked,
+		SeizureProtocol:     formData["seizure_protocol"],
+		HospitalName:        formValidator.SanitizeInput(mrs.hospitalNameEntry.Text),
+		HospitalPhone:       formData["hospital_phone"],
+		DoctorName:          formValidator.SanitizeInput(mrs.doctorNameEntry.Text),
+		InsuranceType:       formValidator.SanitizeInput(mrs.insuranceTypeSelect.Text),
+		InsurerNumber:       formData["insurer_number"],
+		InsuredSymbol:       formValidator.SanitizeInput(mrs.insuredSymbolEntry.Text),
+		InsuredNumber:       formValidator.SanitizeInput(mrs.insuredNumberEntry.Text),
+		InsuranceValidUntil: validUntil,
+		Notes:               formData["notes"],
+		ActorID:             mrs.currentUser.ID,
+	})
+	if err != nil {
+		dialog.ShowError(fmt.Errorf("医療情報の保存に失敗しました: %w", err), parent)
+		return
+	}
+
+	mrs.LoadData()
+	dialog.ShowInformation("成功", "医療情報を保存しました。", parent)
+}
+
+// AlertBanner returns the critical allergy banner, which is hidden unless a
+// critical allergy is flagged. It is placed above the recipient form tabs so it
+// stays visible whichever tab is open.
+func (mrs *MedicalRecordSection) AlertBanner() fyne.CanvasObject {
+	return mrs.allergyBanner
+}
+
+// CreateObject creates the main UI object for this section
+func (mrs *MedicalRecordSection) CreateObject() fyne.CanvasObject {
+	medicationSection := container.NewVBox(
+		widget.NewLabel("服薬"),
+		widget.NewSeparator(),
+		mrs.medicationList,
+		container.NewHBox(mrs.addMedicationButton),
+	)
+
+	allergySection := container.NewVBox(
+		widget.NewLabel("アレルギー"),
+		widget.NewSeparator(),
+		mrs.allergyList,
+		container.NewHBox(mrs.addAllergyButton),
+	)
+
+	seizureSection := container.NewVBox(
+		widget.NewLabel("てんかん・発作"),
+		widget.NewSeparator(),
+		mrs.epilepsyCheck,
+		widget.NewLabel("発作時の対応:"),
+		mrs.seizureProtocolEntry,
+	)
+
+	hospitalSection := container.NewVBox(
+		widget.NewLabel("かかりつけ医療機関"),
+		widget.NewSeparator(),
+		container.NewGridWithColumns(2,
+			widget.NewLabel("医療機関名:"), mrs.hospitalNameEntry,
+			widget.NewLabel("電話番号:"), mrs.hospitalPhoneEntry,
+			widget.NewLabel("主治医:"), mrs.doctorNameEntry,
+		),
+	)
+
+	insuranceSection := container.NewVBox(
+		widget.NewLabel("健康保険証"),
+		widget.NewSeparator(),
+		container.NewGridWithColumns(2,
+			widget.NewLabel("保険種別:"), mrs.insuranceTypeSelect,
+			widget.NewLabel("保険者番号:"), mrs.insurerNumberEntry,
+			widget.NewLabel("記号:"), mrs.insuredSymbolEntry,
+			widget.NewLabel("番号:"), mrs.insuredNumberEntry,
+			widget.NewLabel("有効期限:"), mrs.insuranceValidEntry,
+		),
+	)
+
+	content := container.NewVBox(
+		allergySection,
+		widget.NewSeparator(),
+		medicationSection,
+		widget.NewSeparator(),
+		seizureSection,
+		widget.NewSeparator(),
+		hospitalSection,
+		widget.NewSeparator(),
+		insuranceSection,
+		widget.NewSeparator(),
+		widget.NewLabel("備考:"),
+		mrs.notesEntry,
+		container.NewHBox(mrs.saveButton, mrs.statusLabel),
+	)
+
+	return container.NewScroll(content)
+}
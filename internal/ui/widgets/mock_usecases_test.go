@@ -66,6 +66,28 @@ func (m *MockRecipientUseCase) UnassignStaff(ctx context.Context, req usecase.Un
 	return m.err
 }
 
+func (m *MockRecipientUseCase) GetRecipientsEnrolledOn(ctx context.Context, date time.Time) ([]*domain.Recipient, error) {
+	return m.recipients, m.err
+}
+
+func (m *MockRecipientUseCase) GetEnrollmentPeriods(ctx context.Context, recipientID domain.ID) ([]*domain.EnrollmentPeriod, error) {
+	return nil, m.err
+}
+
+func (m *MockRecipientUseCase) AdmitRecipient(ctx context.Context, req usecase.AdmitRecipientRequest) (*domain.EnrollmentPeriod, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &domain.EnrollmentPeriod{}, nil
+}
+
+func (m *MockRecipientUseCase) DischargeRecipient(ctx context.Context, req usecase.DischargeRecipientRequest) (*domain.EnrollmentPeriod, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &domain.EnrollmentPeriod{}, nil
+}
+
 // MockCertificateUseCase implements usecase.CertificateUseCase for testing
 type MockCertificateUseCase struct {
 	certificates []*domain.BenefitCertificate
@@ -118,6 +140,84 @@ func (m *MockCertificateUseCase) ValidateCertificate(ctx context.Context, certif
 	}, nil
 }
 
+// MockPostalCodeUseCase implements usecase.PostalCodeUseCase for testing
+type MockPostalCodeUseCase struct {
+	addresses []*domain.PostalAddress
+	err       error
+}
+
+func (m *MockPostalCodeUseCase) LookupAddress(ctx context.Context, code string) ([]*domain.PostalAddress, error) {
+	return m.addresses, m.err
+}
+
+func (m *MockPostalCodeUseCase) IsKnownPostalCode(ctx context.Context, code string) (bool, error) {
+	return len(m.addresses) > 0, m.err
+}
+
+func (m *MockPostalCodeUseCase) ImportDictionary(ctx context.Context, req usecase.ImportPostalCodesRequest) (int, error) {
+	return len(m.addresses), m.err
+}
+
+func (m *MockPostalCodeUseCase) DictionarySize(ctx context.Context) (int, error) {
+	return len(m.addresses), m.err
+}
+
+// MockImportUseCase implements usecase.ImportUseCase for testing
+type MockImportUseCase struct {
+	file     *usecase.ImportFile
+	result   *usecase.ImportResult
+	requests []usecase.ImportRequest
+	imports  int
+	err      error
+}
+
+func (m *MockImportUseCase) ReadFile(ctx context.Context, req usecase.ReadImportFileRequest) (*usecase.ImportFile, error) {
+	return m.file, m.err
+}
+
+func (m *MockImportUseCase) DryRun(ctx context.Context, req usecase.ImportRequest) (*usecase.ImportResult, error) {
+	m.requests = append(m.requests, req)
+	return m.result, m.err
+}
+
+func (m *MockImportUseCase) Import(ctx context.Context, req usecase.ImportRequest) (*usecase.ImportResult, error) {
+	m.requests = append(m.requests, req)
+	m.imports++
+	if m.err != nil {
+		return nil, m.err
+	}
+	result := *m.result
+	result.Imported = result.ValidRows()
+	result.Committed = true
+	return &result, nil
+}
+
+// MockRecipientMergeUseCase implements usecase.RecipientMergeUseCase for testing
+type MockRecipientMergeUseCase struct {
+	candidates []*usecase.DuplicateCandidate
+	searches   []usecase.FindDuplicatesRequest
+	merges     []usecase.MergeRecipientsRequest
+	err        error
+}
+
+func (m *MockRecipientMergeUseCase) FindDuplicates(ctx context.Context, req usecase.FindDuplicatesRequest) ([]*usecase.DuplicateCandidate, error) {
+	m.searches = append(m.searches, req)
+	return m.candidates, m.err
+}
+
+func (m *MockRecipientMergeUseCase) MergeRecipients(ctx context.Context, req usecase.MergeRecipientsRequest) (*domain.RecipientMerge, error) {
+	m.merges = append(m.merges, req)
+	if m.err != nil {
+		return nil, m.err
+	}
+	m.candidates = nil
+	return &domain.RecipientMerge{SurvivorID: req.SurvivorID, Merged: domain.Recipient{ID: req.MergedID}, Moved: domain.RecipientMergeCounts{Certificates: 1}}, nil
+}
+
+func (m *MockRecipientMergeUseCase) GetMergeHistory(ctx context.Context, recipientID domain.ID, actorID domain.ID) ([]*domain.RecipientMerge, error) {
+	return nil, m.err
+}
+
 // MockSetupUseCase implements usecase.SetupUseCase for testing
 type MockSetupUseCase struct {
 	needsSetup bool
@@ -240,6 +340,10 @@ func (m *MockStaffUseCase) GetAssignments(ctx context.Context, staffID domain.ID
 	return nil, m.err
 }
 
+func (m *MockStaffUseCase) UpdateDateStyle(ctx context.Context, req usecase.UpdateDateStyleRequest) (*domain.Staff, error) {
+	return nil, m.err
+}
+
 func (m *MockStaffRepository) Create(ctx context.Context, staff *domain.Staff) error {
 	return m.err
 }
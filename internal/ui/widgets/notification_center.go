@@ -0,0 +1,328 @@
+package widgets
+
+import (
+	"context"
+	"fmt"
+
+	"shien-system/internal/domain"
+	"shien-system/internal/usecase"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+// notificationPageSize is the number of notifications loaded into the center
+const notificationPageSize = 200
+
+// NotificationCenter lists the notifications of the current user and lets them be read or acknowledged
+type NotificationCenter struct {
+	useCase     usecase.NotificationUseCase
+	currentUser *domain.Staff
+	onChanged   func()
+
+	// UI components
+	table             *widget.Table
+	detailLabel       *widget.Label
+	unreadOnlyCheck   *widget.Check
+	readButton        *widget.Button
+	readAllButton     *widget.Button
+	acknowledgeButton *widget.Button
+	refreshButton     *widget.Button
+
+	// Data
+	notifications []*domain.Notification
+	selectedRow   int
+}
+
+// NewNotificationCenter creates a new NotificationCenter widget.
+// onChanged is called after the read state changes so that the unread badge can be refreshed.
+func NewNotificationCenter(useCase usecase.NotificationUseCase, currentUser *domain.Staff, onChanged func()) *NotificationCenter {
+	nc := &NotificationCenter{
+		useCase:       useCase,
+		currentUser:   currentUser,
+		onChanged:     onChanged,
+		notifications: make([]*domain.Notification, 0),
+		selectedRow:   -1,
+	}
+
+	nc.createWidgets()
+	nc.setupTable()
+
+	return nc
+}
+
+// createWidgets initializes all UI components
+func (nc *NotificationCenter) createWidgets() {
+	// Table
+	nc.table = widget.NewTable(
+		func() (int, int) {
+			return len(nc.notifications), 5 // 5 columns
+		},
+		func() fyne.CanvasObject {
+			return widget.NewLabel("")
+		},
+		func(id widget.TableCellID, obj fyne.CanvasObject) {
+			nc.updateTableCell(id, obj.(*widget.Label))
+		},
+	)
+
+	nc.detailLabel = widget.NewLabel("通知を選択すると詳細が表示されます。")
+	nc.detailLabel.Wrapping = fyne.TextWrapWord
+
+	nc.unreadOnlyCheck = widget.NewCheck("未読のみ", func(bool) {
+		nc.LoadData()
+	})
+
+	// Buttons
+	nc.readButton = widget.NewButton("既読にする", func() {
+		nc.markSelectedRead()
+	})
+	nc.readButton.Disable()
+
+	nc.readAllButton = widget.NewButton("すべて既読", func() {
+		nc.markAllRead()
+	})
+
+	nc.acknowledgeButton = widget.NewButton("対応済みにする", func() {
+		nc.showAcknowledgeDialog()
+	})
+	nc.acknowledgeButton.Disable()
+
+	nc.refreshButton = widget.NewButton("更新", func() {
+		nc.LoadData()
+	})
+}
+
+// setupTable configures the table widget
+func (nc *NotificationCenter) setupTable() {
+	// Set column widths
+	nc.table.SetColumnWidth(0, 70)  // 状態
+	nc.table.SetColumnWidth(1, 70)  // 重要度
+	nc.table.SetColumnWidth(2, 120) // 種類
+	nc.table.SetColumnWidth(3, 360) // 件名
+	nc.table.SetColumnWidth(4, 130) // 通知日時
+
+	nc.table.OnSelected = func(id widget.TableCellID) {
+		nc.selectedRow = id.Row
+		if id.Row >= len(nc.notifications) {
+			nc.updateButtons()
+			return
+		}
+
+		notification := nc.notifications[id.Row]
+		nc.detailLabel.SetText(fmt.Sprintf("%s\n%s", notification.Title, notification.Message))
+		nc.updateButtons()
+	}
+}
+
+// updateButtons enables the actions that apply to the selected notification
+func (nc *NotificationCenter) updateButtons() {
+	if nc.selectedRow < 0 || nc.selectedRow >= len(nc.notifications) {
+		nc.readButton.Disable()
+		nc.acknowledgeButton.Disable()
+		return
+	}
+
+	notification := nc.notifications[nc.selectedRow]
+	if notification.IsRead() {
+		nc.readButton.Disable()
+	} else {
+		nc.readButton.Enable()
+	}
+	if notification.AcknowledgedAt != nil {
+		nc.acknowledgeButton.Disable()
+	} else {
+		nc.acknowledgeButton.Enable()
+	}
+}
+
+// updateTableCell updates a specific table cell with notification data
+func (nc *NotificationCenter) updateTableCell(id widget.TableCellID, label *widget.Label) {
+	if id.Row >= len(nc.notifications) {
+		label.SetText("")
+		return
+	}
+
+	notification := nc.notifications[id.Row]
+	label.TextStyle.Bold = !notification.IsRead()
+
+	switch id.Col {
+	case 0: // 状態
+		label.SetText(notificationStateText(notification))
+	case 1: // 重要度
+		label.SetText(notification.Severity.Label())
+	case 2: // 種類
+		label.SetText(notification.Kind.Label())
+	case 3: // 件名
+		label.SetText(notification.Title)
+	case 4: // 通知日時
+		label.SetText(notification.CreatedAt.Local().Format("2006/01/02 15:04"))
+	default:
+		label.SetText("")
+	}
+}
+
+// notificationStateText describes the per-user state of a notification
+func notificationStateText(notification *domain.Notification) string {
+	switch {
+	case notification.AcknowledgedAt != nil:
+		return "対応済"
+	case notification.IsRead():
+		return "既読"
+	default:
+		return "未読"
+	}
+}
+
+// LoadData reloads the notifications of the current user
+func (nc *NotificationCenter) LoadData() {
+	if nc.currentUser == nil {
+		return
+	}
+
+	notifications, err := nc.useCase.ListNotifications(context.Background(), usecase.ListNotificationsRequest{
+		UnreadOnly: nc.unreadOnlyCheck.Checked,
+		Limit:      notificationPageSize,
+		ActorID:    nc.currentUser.ID,
+	})
+	if err != nil {
+		nc.showError(fmt.Errorf("通知の読み込みに失敗しました: %w", err))
+		return
+	}
+
+	nc.notifications = notifications
+	nc.selectedRow = -1
+	nc.detailLabel.SetText("通知を選択すると詳細が表示されます。")
+	nc.table.UnselectAll()
+	nc.updateButtons()
+	nc.table.Refresh()
+}
+
+// markSelectedRead marks the selected notification as read
+func (nc *NotificationCenter) markSelectedRead() {
+	if nc.selectedRow < 0 || nc.selectedRow >= len(nc.notifications) {
+		return
+	}
+
+	notification := nc.notifications[nc.selectedRow]
+	if err := nc.useCase.MarkRead(context.Background(), notification.ID, nc.currentUser.ID); err != nil {
+		nc.showError(fmt.Errorf("既読にできませんでした: %w", err))
+		return
+	}
+
+	nc.afterChange()
+}
+
+// markAllRead marks every visible notification as read
+func (nc *NotificationCenter) markAllRead() {
+	if err := nc.useCase.MarkAllRead(context.Background(), nc.currentUser.ID); err != nil {
+		nc.showError(fmt.Errorf("既読にできませんでした: %w", err))
+		return
+	}
+
+	nc.afterChange()
+}
+
+// showAcknowledgeDialog asks for an optional comment and acknowledges the selected notification
+func (nc *NotificationCenter) showAcknowledgeDialog() {
+	if nc.selectedRow < 0 || nc.selectedRow >= len(nc.notifications) {
+		return
+	}
+
+	notification := nc.notifications[nc.selectedRow]
+	parent := fyne.CurrentApp().Driver().AllWindows()[0]
+
+	commentEntry := widget.NewMultiLineEntry()
+	commentEntry.SetPlaceHolder("対応内容（任意）")
+
+	dialog.ShowForm("対応済みにする", "記録", "キャンセル",
+		[]*widget.FormItem{
+			widget.NewFormItem("通知", widget.NewLabel(notification.Title)),
+			widget.NewFormItem("対応内容", commentEntry),
+		},
+		func(confirmed bool) {
+			if !confirmed {
+				return
+			}
+
+			err := nc.useCase.AcknowledgeNotification(context.Background(), usecase.AcknowledgeNotificationRequest{
+				ID:      notification.ID,
+				Comment: commentEntry.Text,
+				ActorID: nc.currentUser.ID,
+			})
+			if err != nil {
+				nc.showError(fmt.Errorf("対応済みにできませんでした: %w", err))
+				return
+			}
+
+			nc.afterChange()
+		}, parent)
+}
+
+// afterChange reloads the list and lets the owner refresh the unread badge
+func (nc *NotificationCenter) afterChange() {
+	nc.LoadData()
+	if nc.onChanged != nil {
+		nc.onChanged()
+	}
+}
+
+// showError shows an error dialog on the main window
+func (nc *NotificationCenter) showError(err error) {
+	if app := fyne.CurrentApp(); app != nil && len(app.Driver().AllWindows()) > 0 {
+		dialog.ShowError(err, app.Driver().AllWindows()[0])
+	}
+}
+
+// CreateObject creates the UI object for the notification center
+func (nc *NotificationCenter) CreateObject() fyne.CanvasObject {
+	// Header with controls
+	header := container.NewBorder(
+		nil, nil,
+		widget.NewLabel("通知"),
+		container.NewHBox(
+			nc.unreadOnlyCheck,
+			nc.readButton,
+			nc.readAllButton,
+			nc.acknowledgeButton,
+			nc.refreshButton,
+		),
+		nil,
+	)
+
+	// Table with headers
+	tableContainer := container.NewBorder(
+		nc.createTableHeader(),
+		nil, nil, nil,
+		nc.table,
+	)
+
+	// Complete layout
+	return container.NewBorder(
+		header,
+		widget.NewCard("詳細", "", nc.detailLabel),
+		nil, nil,
+		tableContainer,
+	)
+}
+
+// createTableHeader creates the table header
+func (nc *NotificationCenter) createTableHeader() fyne.CanvasObject {
+	headers := []string{"状態", "重要度", "種類", "件名", "通知日時"}
+	headerWidgets := make([]fyne.CanvasObject, len(headers))
+
+	for i, header := range headers {
+		label := widget.NewLabel(header)
+		label.TextStyle.Bold = true
+		headerWidgets[i] = label
+	}
+
+	return container.NewHBox(headerWidgets...)
+}
+
+// Length returns the number of visible items in the table (for testing)
+func (nc *NotificationCenter) Length() int {
+	return len(nc.notifications)
+}
@@ -0,0 +1,279 @@
+package widgets
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"shien-system/internal/domain"
+	"shien-system/internal/usecase"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+// RateLimitPolicyPanel edits the effective rate limit policy and shows where it
+// differs from config.yaml
+type RateLimitPolicyPanel struct {
+	useCase     usecase.RateLimitPolicyUseCase
+	currentUser *domain.Staff
+
+	// UI components
+	sourceLabel      *widget.Label
+	conflictLabel    *widget.Label
+	enabledCheck     *widget.Check
+	perIPEntry       *widget.Entry
+	perUserEntry     *widget.Entry
+	windowEntry      *widget.Entry
+	lockoutEntry     *widget.Entry
+	backoffEntry     *widget.Entry
+	maxHoursEntry    *widget.Entry
+	whitelistEntry   *widget.Entry
+	progressiveCheck *widget.Check
+	saveButton       *widget.Button
+	applyFileButton  *widget.Button
+
+	// Data
+	policy *usecase.RateLimitPolicy
+}
+
+// NewRateLimitPolicyPanel creates a new RateLimitPolicyPanel widget
+func NewRateLimitPolicyPanel(useCase usecase.RateLimitPolicyUseCase, currentUser *domain.Staff) *RateLimitPolicyPanel {
+	rp := &RateLimitPolicyPanel{
+		useCase:     useCase,
+		currentUser: currentUser,
+	}
+
+	rp.createWidgets()
+
+	return rp
+}
+
+// createWidgets initializes all UI components
+func (rp *RateLimitPolicyPanel) createWidgets() {
+	rp.sourceLabel = widget.NewLabel("")
+	rp.conflictLabel = widget.NewLabel("")
+	rp.conflictLabel.Wrapping = fyne.TextWrapWord
+
+	rp.enabledCheck = widget.NewCheck("レート制限を有効にする", nil)
+	rp.perIPEntry = widget.NewEntry()
+	rp.perUserEntry = widget.NewEntry()
+	rp.windowEntry = widget.NewEntry()
+	rp.lockoutEntry = widget.NewEntry()
+	rp.backoffEntry = widget.NewEntry()
+	rp.maxHoursEntry = widget.NewEntry()
+	rp.whitelistEntry = widget.NewMultiLineEntry()
+	rp.whitelistEntry.SetPlaceHolder("1行に1件（例: 127.0.0.1, 192.168.0.0/24）")
+	rp.progressiveCheck = widget.NewCheck("ロックを繰り返すたびにロック時間を延長する", nil)
+
+	rp.saveButton = widget.NewButton("保存", func() {
+		rp.showSaveDialog()
+	})
+
+	rp.applyFileButton = widget.NewButton("config.yaml の値を適用", func() {
+		rp.showApplyFileDialog()
+	})
+	rp.applyFileButton.Disable()
+}
+
+// LoadData reloads the effective policy into the form
+func (rp *RateLimitPolicyPanel) LoadData() {
+	if rp.currentUser == nil || rp.currentUser.Role != domain.RoleAdmin {
+		return
+	}
+
+	policy, err := rp.useCase.GetPolicy(context.Background(), rp.currentUser.ID)
+	if err != nil {
+		rp.showError(fmt.Errorf("レート制限設定の読み込みに失敗しました: %w", err))
+		return
+	}
+
+	rp.policy = policy
+	config := policy.Effective
+
+	rp.enabledCheck.SetChecked(config.Enabled)
+	rp.perIPEntry.SetText(strconv.Itoa(config.MaxAttemptsPerIP))
+	rp.perUserEntry.SetText(strconv.Itoa(config.MaxAttemptsPerUser))
+	rp.windowEntry.SetText(strconv.Itoa(config.WindowSizeMinutes))
+	rp.lockoutEntry.SetText(strconv.Itoa(config.LockoutDurationMinutes))
+	rp.backoffEntry.SetText(strconv.FormatFloat(config.BackoffMultiplier, 'g', -1, 64))
+	rp.maxHoursEntry.SetText(strconv.Itoa(config.MaxLockoutHours))
+	rp.whitelistEntry.SetText(strings.Join(config.WhitelistIPs, "\n"))
+	rp.progressiveCheck.SetChecked(config.EnableProgressiveLockout)
+
+	rp.sourceLabel.SetText(fmt.Sprintf("現在の設定: %s（%s 更新）",
+		rateLimitSourceLabel(config.Source), formatSecurityTime(config.UpdatedAt)))
+
+	if policy.File == nil {
+		rp.conflictLabel.SetText("config.yaml の設定は読み込まれていません。")
+		rp.applyFileButton.Disable()
+		return
+	}
+
+	rp.applyFileButton.Enable()
+	if len(policy.Conflicts) == 0 {
+		rp.conflictLabel.SetText("config.yaml の設定と一致しています。")
+		return
+	}
+
+	lines := []string{"config.yaml と異なる項目:"}
+	for _, conflict := range policy.Conflicts {
+		lines = append(lines, fmt.Sprintf("・%s: 現在 %s ／ config.yaml %s",
+			conflict.Label, valueOrDash(conflict.Current), valueOrDash(conflict.Proposed)))
+	}
+	rp.conflictLabel.SetText(strings.Join(lines, "\n"))
+}
+
+// formPolicy reads the policy values from the form
+func (rp *RateLimitPolicyPanel) formPolicy() (domain.RateLimitConfig, error) {
+	policy := domain.RateLimitConfig{
+		Enabled:                  rp.enabledCheck.Checked,
+		EnableProgressiveLockout: rp.progressiveCheck.Checked,
+	}
+
+	ints := []struct {
+		label string
+		entry *widget.Entry
+		value *int
+	}{
+		{"IPごとの試行上限", rp.perIPEntry, &policy.MaxAttemptsPerIP},
+		{"ユーザーごとの試行上限", rp.perUserEntry, &policy.MaxAttemptsPerUser},
+		{"集計期間", rp.windowEntry, &policy.WindowSizeMinutes},
+		{"ロック時間", rp.lockoutEntry, &policy.LockoutDurationMinutes},
+		{"最大ロック時間", rp.maxHoursEntry, &policy.MaxLockoutHours},
+	}
+	for _, field := range ints {
+		value, err := strconv.Atoi(strings.TrimSpace(field.entry.Text))
+		if err != nil {
+			return policy, fmt.Errorf("%sは整数で入力してください", field.label)
+		}
+		*field.value = value
+	}
+
+	backoff, err := strconv.ParseFloat(strings.TrimSpace(rp.backoffEntry.Text), 64)
+	if err != nil {
+		return policy, fmt.Errorf("延長倍率は数値で入力してください")
+	}
+	policy.BackoffMultiplier = backoff
+
+	policy.WhitelistIPs = []string{}
+	for _, line := range strings.Split(rp.whitelistEntry.Text, "\n") {
+		if entry := strings.TrimSpace(line); entry != "" {
+			policy.WhitelistIPs = append(policy.WhitelistIPs, entry)
+		}
+	}
+
+	return policy, nil
+}
+
+// showSaveDialog asks for a reason and stores the policy entered in the form
+func (rp *RateLimitPolicyPanel) showSaveDialog() {
+	policy, err := rp.formPolicy()
+	if err != nil {
+		rp.showError(err)
+		return
+	}
+
+	reasonEntry := widget.NewMultiLineEntry()
+	reasonEntry.SetPlaceHolder("変更の理由（必須）")
+
+	dialog.ShowForm("レート制限設定の変更", "保存", "キャンセル",
+		[]*widget.FormItem{
+			widget.NewFormItem("", widget.NewLabel("変更後は config.yaml より管理画面の設定が優先されます。")),
+			widget.NewFormItem("理由", reasonEntry),
+		},
+		func(confirmed bool) {
+			if !confirmed {
+				return
+			}
+
+			_, err := rp.useCase.UpdatePolicy(context.Background(), usecase.UpdateRateLimitPolicyRequest{
+				Policy:  policy,
+				Reason:  reasonEntry.Text,
+				ActorID: rp.currentUser.ID,
+			})
+			if err != nil {
+				rp.showError(fmt.Errorf("レート制限設定を保存できませんでした: %w", err))
+				return
+			}
+
+			rp.LoadData()
+		}, rp.parentWindow())
+}
+
+// showApplyFileDialog hands the policy back to config.yaml
+func (rp *RateLimitPolicyPanel) showApplyFileDialog() {
+	reasonEntry := widget.NewMultiLineEntry()
+	reasonEntry.SetPlaceHolder("理由（必須）")
+
+	dialog.ShowForm("config.yaml の値を適用", "適用", "キャンセル",
+		[]*widget.FormItem{
+			widget.NewFormItem("", widget.NewLabel("管理画面での変更を破棄し、config.yaml の値に戻します。")),
+			widget.NewFormItem("理由", reasonEntry),
+		},
+		func(confirmed bool) {
+			if !confirmed {
+				return
+			}
+
+			_, err := rp.useCase.ApplyConfigFile(context.Background(), usecase.ApplyRateLimitConfigRequest{
+				Reason:  reasonEntry.Text,
+				ActorID: rp.currentUser.ID,
+			})
+			if err != nil {
+				rp.showError(fmt.Errorf("config.yaml の値を適用できませんでした: %w", err))
+				return
+			}
+
+			rp.LoadData()
+		}, rp.parentWindow())
+}
+
+// parentWindow returns the main window for dialogs
+func (rp *RateLimitPolicyPanel) parentWindow() fyne.Window {
+	return fyne.CurrentApp().Driver().AllWindows()[0]
+}
+
+// showError shows an error dialog on the main window
+func (rp *RateLimitPolicyPanel) showError(err error) {
+	if app := fyne.CurrentApp(); app != nil && len(app.Driver().AllWindows()) > 0 {
+		dialog.ShowError(err, app.Driver().AllWindows()[0])
+	}
+}
+
+// CreateObject creates the UI object for the policy editor
+func (rp *RateLimitPolicyPanel) CreateObject() fyne.CanvasObject {
+	form := widget.NewForm(
+		widget.NewFormItem("", rp.enabledCheck),
+		widget.NewFormItem("IPごとの試行上限（回）", rp.perIPEntry),
+		widget.NewFormItem("ユーザーごとの試行上限（回）", rp.perUserEntry),
+		widget.NewFormItem("集計期間（分）", rp.windowEntry),
+		widget.NewFormItem("ロック時間（分）", rp.lockoutEntry),
+		widget.NewFormItem("延長倍率", rp.backoffEntry),
+		widget.NewFormItem("最大ロック時間（時間）", rp.maxHoursEntry),
+		widget.NewFormItem("許可リスト", rp.whitelistEntry),
+		widget.NewFormItem("", rp.progressiveCheck),
+	)
+
+	return container.NewVScroll(container.NewVBox(
+		rp.sourceLabel,
+		form,
+		container.NewHBox(rp.saveButton, rp.applyFileButton),
+		widget.NewSeparator(),
+		rp.conflictLabel,
+	))
+}
+
+func rateLimitSourceLabel(source string) string {
+	switch source {
+	case domain.RateLimitSourceConfig:
+		return "config.yaml"
+	case domain.RateLimitSourceAdmin:
+		return "管理画面で変更"
+	default:
+		return "初期値"
+	}
+}
@@ -2,23 +2,33 @@ package widgets
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"path/filepath"
 	"strings"
 	"time"
 
 	"shien-system/internal/domain"
 	"shien-system/internal/usecase"
 	"shien-system/internal/validation"
+	"shien-system/internal/wareki"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/container"
 	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
 	"fyne.io/fyne/v2/widget"
 )
 
 // RecipientForm represents the recipient creation/editing form
 type RecipientForm struct {
-	useCase usecase.RecipientUseCase
+	useCase           usecase.RecipientUseCase
+	disclosureUseCase usecase.DisclosureUseCase
+	postalCodeUseCase usecase.PostalCodeUseCase
+
+	// Emergency contacts and medical information are managed per recipient once it exists
+	emergencyContacts *EmergencyContactSection
+	medicalRecord     *MedicalRecordSection
 
 	// UI components - Basic Information
 	nameEntry      *widget.Entry
@@ -30,25 +40,38 @@ type RecipientForm struct {
 	disabilityNameEntry  *widget.Entry
 	hasDisabilityIDCheck *widget.Check
 	gradeEntry           *widget.Entry
+	disability           *disabilityFields // Handbooks, support category and intractable disease
 
 	// UI components - Contact Information
-	addressEntry *widget.Entry
-	phoneEntry   *widget.Entry
-	emailEntry   *widget.Entry
+	postalCodeEntry  *widget.Entry
+	postalCandidates *widget.Select // Towns sharing the postal code
+	postalHint       *widget.Label
+	prefectureEntry  *widget.Entry
+	cityEntry        *widget.Entry
+	streetEntry      *widget.Entry
+	phoneEntry       *widget.Entry
+	emailEntry       *widget.Entry
 
 	// UI components - Service Information
 	publicAssistanceCheck *widget.Check
 	admissionDateEntry    *widget.Entry
 	dischargeDateEntry    *widget.Entry
+	enrollmentHistory     *widget.Label
 
 	// Form controls
-	saveButton   *widget.Button
-	cancelButton *widget.Button
+	saveButton       *widget.Button
+	cancelButton     *widget.Button
+	disclosureButton *widget.Button
 
 	// State
 	isEditing   bool
 	recipientID *domain.ID
 	currentUser *domain.Staff
+	original    *domain.Recipient // Record as loaded into the form, see handleEditConflict
+
+	// Postal code autocompletion, see onPostalCodeChanged
+	loadingFields  bool   // Fields are being filled from a record, not typed
+	autofilledTown string // Town last filled in from the dictionary
 
 	// Event handlers
 	onSaved     func(*domain.Recipient)
@@ -83,7 +106,7 @@ func (rf *RecipientForm) createWidgets() {
 	rf.sexSelect.Selected = "未設定"
 
 	rf.birthDateEntry = widget.NewEntry()
-	rf.birthDateEntry.SetPlaceHolder("生年月日 (YYYY/MM/DD)")
+	rf.birthDateEntry.SetPlaceHolder("生年月日 (" + datePlaceHolder + ")")
 
 	// Disability Information
 	rf.disabilityNameEntry = widget.NewEntry()
@@ -96,10 +119,29 @@ func (rf *RecipientForm) createWidgets() {
 	rf.gradeEntry = widget.NewEntry()
 	rf.gradeEntry.SetPlaceHolder("等級")
 
+	rf.disability = newDisabilityFields()
+
 	// Contact Information
-	rf.addressEntry = widget.NewEntry()
-	rf.addressEntry.SetPlaceHolder("住所")
-	rf.addressEntry.MultiLine = true
+	rf.postalCodeEntry = widget.NewEntry()
+	rf.postalCodeEntry.SetPlaceHolder("郵便番号 (100-0001)")
+
+	rf.postalCandidates = widget.NewSelect(nil, func(town string) {
+		rf.fillTown(town)
+	})
+	rf.postalCandidates.PlaceHolder = "町域を選択"
+	rf.postalCandidates.Hide()
+
+	rf.postalHint = widget.NewLabel("")
+
+	rf.prefectureEntry = widget.NewEntry()
+	rf.prefectureEntry.SetPlaceHolder("都道府県")
+
+	rf.cityEntry = widget.NewEntry()
+	rf.cityEntry.SetPlaceHolder("市区町村")
+
+	rf.streetEntry = widget.NewEntry()
+	rf.streetEntry.SetPlaceHolder("町域・番地・建物名")
+	rf.streetEntry.MultiLine = true
 
 	rf.phoneEntry = widget.NewEntry()
 	rf.phoneEntry.SetPlaceHolder("電話番号")
@@ -113,10 +155,13 @@ func (rf *RecipientForm) createWidgets() {
 	})
 
 	rf.admissionDateEntry = widget.NewEntry()
-	rf.admissionDateEntry.SetPlaceHolder("入所日 (YYYY/MM/DD)")
+	rf.admissionDateEntry.SetPlaceHolder("入所日 (" + datePlaceHolder + ")")
 
 	rf.dischargeDateEntry = widget.NewEntry()
-	rf.dischargeDateEntry.SetPlaceHolder("退所日 (YYYY/MM/DD) - 空白の場合は在籍中")
+	rf.dischargeDateEntry.SetPlaceHolder("退所日 (" + datePlaceHolder + ") - 空白の場合は在籍中")
+
+	rf.enrollmentHistory = widget.NewLabel("")
+	rf.enrollmentHistory.Wrapping = fyne.TextWrapWord
 
 	// Form controls
 	rf.saveButton = widget.NewButton("保存", func() {
@@ -127,6 +172,10 @@ func (rf *RecipientForm) createWidgets() {
 	rf.cancelButton = widget.NewButton("キャンセル", func() {
 		rf.handleCancel()
 	})
+
+	rf.disclosureButton = widget.NewButton("開示資料作成", func() {
+		rf.handleDisclosure()
+	})
 }
 
 // setupEventHandlers configures event handlers
@@ -136,6 +185,9 @@ func (rf *RecipientForm) setupEventHandlers() {
 		rf.validateDateFormat(text, "生年月日")
 	}
 
+	// Address autocompletion from the postal code dictionary
+	rf.postalCodeEntry.OnChanged = rf.onPostalCodeChanged
+
 	// Date validation on admission date
 	rf.admissionDateEntry.OnChanged = func(text string) {
 		if text != "" {
@@ -157,7 +209,7 @@ func (rf *RecipientForm) validateDateFormat(dateStr, fieldName string) {
 		return
 	}
 
-	_, err := time.Parse("2006/01/02", dateStr)
+	_, err := wareki.Parse(dateStr)
 	if err != nil {
 		// Show validation error in status or tooltip
 		// For now, we'll just log it
@@ -171,27 +223,18 @@ func (rf *RecipientForm) SetForEdit(recipient *domain.Recipient, currentUser *do
 	rf.recipientID = &recipient.ID
 	rf.currentUser = currentUser
 
-	// Populate form fields
-	rf.nameEntry.SetText(recipient.Name)
-	rf.kanaEntry.SetText(recipient.Kana)
-	rf.sexSelect.Selected = rf.formatSexForSelect(recipient.Sex)
-	rf.birthDateEntry.SetText(recipient.BirthDate.Format("2006/01/02"))
-
-	rf.disabilityNameEntry.SetText(recipient.DisabilityName)
-	rf.hasDisabilityIDCheck.SetChecked(recipient.HasDisabilityID)
-	rf.gradeEntry.SetText(recipient.Grade)
+	rf.original = recipient
 
-	rf.addressEntry.SetText(recipient.Address)
-	rf.phoneEntry.SetText(recipient.Phone)
-	rf.emailEntry.SetText(recipient.Email)
+	// Populate form fields
+	rf.loadFieldValues(rf.fieldValues(recipient))
 
-	rf.publicAssistanceCheck.SetChecked(recipient.PublicAssistance)
+	rf.loadEnrollmentHistory(recipient.ID)
 
-	if recipient.AdmissionDate != nil {
-		rf.admissionDateEntry.SetText(recipient.AdmissionDate.Format("2006/01/02"))
+	if rf.emergencyContacts != nil {
+		rf.emergencyContacts.SetRecipient(recipient, currentUser)
 	}
-	if recipient.DischargeDate != nil {
-		rf.dischargeDateEntry.SetText(recipient.DischargeDate.Format("2006/01/02"))
+	if rf.canViewMedicalRecord() {
+		rf.medicalRecord.SetRecipient(recipient, currentUser)
 	}
 
 	// Update button text
@@ -203,6 +246,7 @@ func (rf *RecipientForm) SetForCreate(currentUser *domain.Staff) {
 	rf.isEditing = false
 	rf.recipientID = nil
 	rf.currentUser = currentUser
+	rf.original = nil
 	rf.clearForm()
 
 	// Update button text
@@ -219,14 +263,49 @@ func (rf *RecipientForm) clearForm() {
 	rf.disabilityNameEntry.SetText("")
 	rf.hasDisabilityIDCheck.SetChecked(false)
 	rf.gradeEntry.SetText("")
+	rf.disability.clear()
 
-	rf.addressEntry.SetText("")
+	rf.loadFieldValues(nil)
+	rf.postalCodeEntry.SetText("")
+	rf.prefectureEntry.SetText("")
+	rf.cityEntry.SetText("")
+	rf.streetEntry.SetText("")
 	rf.phoneEntry.SetText("")
 	rf.emailEntry.SetText("")
 
 	rf.publicAssistanceCheck.SetChecked(false)
 	rf.admissionDateEntry.SetText("")
 	rf.dischargeDateEntry.SetText("")
+	rf.enrollmentHistory.SetText("")
+}
+
+// loadEnrollmentHistory shows every enrollment period of the recipient, oldest first
+func (rf *RecipientForm) loadEnrollmentHistory(recipientID domain.ID) {
+	periods, err := rf.useCase.GetEnrollmentPeriods(context.Background(), recipientID)
+	if err != nil {
+		rf.enrollmentHistory.SetText("在籍履歴を取得できませんでした")
+		return
+	}
+
+	rf.enrollmentHistory.SetText(formatEnrollmentHistory(periods, dateStyleOf(rf.currentUser)))
+}
+
+// formatEnrollmentHistory renders enrollment periods as one line per period
+func formatEnrollmentHistory(periods []*domain.EnrollmentPeriod, style wareki.Style) string {
+	if len(periods) == 0 {
+		return "在籍履歴はありません"
+	}
+
+	lines := make([]string, 0, len(periods))
+	for i, period := range periods {
+		discharge := "在籍中"
+		if period.DischargeDate != nil {
+			discharge = style.Format(*period.DischargeDate)
+		}
+		lines = append(lines, fmt.Sprintf("%d. %s 〜 %s", i+1, style.Format(period.AdmissionDate), discharge))
+	}
+
+	return strings.Join(lines, "\n")
 }
 
 // handleSave processes form submission
@@ -281,6 +360,11 @@ func (rf *RecipientForm) handleUpdate(ctx context.Context) {
 	}
 
 	recipient, err := rf.useCase.UpdateRecipient(ctx, *req)
+	if errors.Is(err, usecase.ErrEditConflict) {
+		rf.setFormEnabled(true)
+		rf.handleEditConflict(err)
+		return
+	}
 	if err != nil {
 		rf.setFormEnabled(true)
 		rf.showError("更新エラー", err)
@@ -300,19 +384,30 @@ func (rf *RecipientForm) buildCreateRequest() (*usecase.CreateRecipientRequest,
 		return nil, err
 	}
 
+	handbooks, supportCategory, intractableDisease, err := rf.disability.build()
+	if err != nil {
+		return nil, err
+	}
+
 	req := &usecase.CreateRecipientRequest{
-		Name:             strings.TrimSpace(rf.nameEntry.Text),
-		Kana:             strings.TrimSpace(rf.kanaEntry.Text),
-		Sex:              rf.parseSexFromSelect(rf.sexSelect.Selected),
-		BirthDate:        birthDate,
-		DisabilityName:   strings.TrimSpace(rf.disabilityNameEntry.Text),
-		HasDisabilityID:  rf.hasDisabilityIDCheck.Checked,
-		Grade:            strings.TrimSpace(rf.gradeEntry.Text),
-		Address:          strings.TrimSpace(rf.addressEntry.Text),
-		Phone:            strings.TrimSpace(rf.phoneEntry.Text),
-		Email:            strings.TrimSpace(rf.emailEntry.Text),
-		PublicAssistance: rf.publicAssistanceCheck.Checked,
-		ActorID:          rf.currentUser.ID,
+		Name:               strings.TrimSpace(rf.nameEntry.Text),
+		Kana:               strings.TrimSpace(rf.kanaEntry.Text),
+		Sex:                rf.parseSexFromSelect(rf.sexSelect.Selected),
+		BirthDate:          birthDate,
+		DisabilityName:     strings.TrimSpace(rf.disabilityNameEntry.Text),
+		HasDisabilityID:    rf.hasDisabilityIDCheck.Checked,
+		Grade:              strings.TrimSpace(rf.gradeEntry.Text),
+		PostalCode:         strings.TrimSpace(rf.postalCodeEntry.Text),
+		Prefecture:         strings.TrimSpace(rf.prefectureEntry.Text),
+		City:               strings.TrimSpace(rf.cityEntry.Text),
+		Street:             strings.TrimSpace(rf.streetEntry.Text),
+		Phone:              strings.TrimSpace(rf.phoneEntry.Text),
+		Email:              strings.TrimSpace(rf.emailEntry.Text),
+		PublicAssistance:   rf.publicAssistanceCheck.Checked,
+		Handbooks:          handbooks,
+		SupportCategory:    supportCategory,
+		IntractableDisease: intractableDisease,
+		ActorID:            rf.currentUser.ID,
 	}
 
 	// Optional dates
@@ -340,20 +435,32 @@ func (rf *RecipientForm) buildUpdateRequest() (*usecase.UpdateRecipientRequest,
 		return nil, err
 	}
 
+	handbooks, supportCategory, intractableDisease, err := rf.disability.build()
+	if err != nil {
+		return nil, err
+	}
+
 	req := &usecase.UpdateRecipientRequest{
-		ID:               *rf.recipientID,
-		Name:             strings.TrimSpace(rf.nameEntry.Text),
-		Kana:             strings.TrimSpace(rf.kanaEntry.Text),
-		Sex:              rf.parseSexFromSelect(rf.sexSelect.Selected),
-		BirthDate:        birthDate,
-		DisabilityName:   strings.TrimSpace(rf.disabilityNameEntry.Text),
-		HasDisabilityID:  rf.hasDisabilityIDCheck.Checked,
-		Grade:            strings.TrimSpace(rf.gradeEntry.Text),
-		Address:          strings.TrimSpace(rf.addressEntry.Text),
-		Phone:            strings.TrimSpace(rf.phoneEntry.Text),
-		Email:            strings.TrimSpace(rf.emailEntry.Text),
-		PublicAssistance: rf.publicAssistanceCheck.Checked,
-		ActorID:          rf.currentUser.ID,
+		ID:                 *rf.recipientID,
+		Name:               strings.TrimSpace(rf.nameEntry.Text),
+		Kana:               strings.TrimSpace(rf.kanaEntry.Text),
+		Sex:                rf.parseSexFromSelect(rf.sexSelect.Selected),
+		BirthDate:          birthDate,
+		DisabilityName:     strings.TrimSpace(rf.disabilityNameEntry.Text),
+		HasDisabilityID:    rf.hasDisabilityIDCheck.Checked,
+		Grade:              strings.TrimSpace(rf.gradeEntry.Text),
+		PostalCode:         strings.TrimSpace(rf.postalCodeEntry.Text),
+		Prefecture:         strings.TrimSpace(rf.prefectureEntry.Text),
+		City:               strings.TrimSpace(rf.cityEntry.Text),
+		Street:             strings.TrimSpace(rf.streetEntry.Text),
+		Phone:              strings.TrimSpace(rf.phoneEntry.Text),
+		Email:              strings.TrimSpace(rf.emailEntry.Text),
+		PublicAssistance:   rf.publicAssistanceCheck.Checked,
+		Handbooks:          handbooks,
+		SupportCategory:    supportCategory,
+		IntractableDisease: intractableDisease,
+		Version:            rf.original.Version,
+		ActorID:            rf.currentUser.ID,
 	}
 
 	// Optional dates
@@ -376,28 +483,126 @@ func (rf *RecipientForm) buildUpdateRequest() (*usecase.UpdateRecipientRequest,
 	return req, nil
 }
 
+// conflictFields binds the form fields compared by the edit conflict dialog
+func (rf *RecipientForm) conflictFields() []conflictField {
+	entry := func(label string, e *widget.Entry) conflictField {
+		return conflictField{label: label, get: func() string { return strings.TrimSpace(e.Text) }, set: e.SetText}
+	}
+	check := func(label string, c *widget.Check) conflictField {
+		return conflictField{
+			label: label,
+			get:   func() string { return checkText(c.Checked) },
+			set:   func(value string) { c.SetChecked(value == checkText(true)) },
+		}
+	}
+	fields := []conflictField{
+		entry("氏名", rf.nameEntry),
+		entry("フリガナ", rf.kanaEntry),
+		{label: "性別", get: func() string { return rf.sexSelect.Selected }, set: rf.sexSelect.SetSelected},
+		entry("生年月日", rf.birthDateEntry),
+		entry("障害名", rf.disabilityNameEntry),
+		check("身体障害者手帳等", rf.hasDisabilityIDCheck),
+		entry("等級", rf.gradeEntry),
+		entry("郵便番号", rf.postalCodeEntry),
+		entry("都道府県", rf.prefectureEntry),
+		entry("市区町村", rf.cityEntry),
+		entry("町域・番地", rf.streetEntry),
+		entry("電話番号", rf.phoneEntry),
+		entry("メール", rf.emailEntry),
+		check("生活保護", rf.publicAssistanceCheck),
+		entry("入所日", rf.admissionDateEntry),
+		entry("退所日", rf.dischargeDateEntry),
+	}
+	return append(fields, rf.disability.conflictFields()...)
+}
+
+// fieldValues formats recipient in the order of conflictFields
+func (rf *RecipientForm) fieldValues(recipient *domain.Recipient) []string {
+	style := dateStyleOf(rf.currentUser)
+	optionalDate := func(date *time.Time) string {
+		if date == nil {
+			return ""
+		}
+		return style.Format(*date)
+	}
+	// Recipients saved before postal codes have only the full address
+	street := recipient.Street
+	if recipient.Prefecture == "" && recipient.City == "" && street == "" {
+		street = recipient.Address
+	}
+	values := []string{
+		recipient.Name,
+		recipient.Kana,
+		rf.formatSexForSelect(recipient.Sex),
+		style.Format(recipient.BirthDate),
+		recipient.DisabilityName,
+		checkText(recipient.HasDisabilityID),
+		recipient.Grade,
+		recipient.PostalCode,
+		recipient.Prefecture,
+		recipient.City,
+		street,
+		recipient.Phone,
+		recipient.Email,
+		checkText(recipient.PublicAssistance),
+		optionalDate(recipient.AdmissionDate),
+		optionalDate(recipient.DischargeDate),
+	}
+	return append(values, rf.disability.values(recipient, style)...)
+}
+
+// handleEditConflict offers to reload or merge when another staff member
+// saved the recipient while the form was open
+func (rf *RecipientForm) handleEditConflict(err error) {
+	current, ok := storedRecord(err).(*domain.Recipient)
+	if !ok || current == nil || rf.original == nil {
+		rf.showError("更新エラー", err)
+		return
+	}
+
+	fields := rf.conflictFields()
+	conflict := newEditConflict(fields, rf.fieldValues(rf.original), rf.fieldValues(current))
+	parent := fyne.CurrentApp().Driver().AllWindows()[0]
+	showEditConflictDialog(parent, conflict, func() {
+		rf.original = current
+		rf.loadFieldValues(conflict.theirs)
+	}, func() {
+		rf.original = current
+		showMergeResult(parent, conflict.merge())
+	})
+}
+
 // validateForm validates all form fields
 func (rf *RecipientForm) validateForm() error {
 	// Create form validator
 	formValidator := validation.NewFormValidator()
+	if rf.postalCodeUseCase != nil {
+		formValidator.SetPostalCodeDictionary(func(code string) bool {
+			known, err := rf.postalCodeUseCase.IsKnownPostalCode(context.Background(), code)
+			return err != nil || known // The save itself reports lookup failures
+		})
+	}
 	
 	// Collect and sanitize form data
 	formData := map[string]string{
 		"name":            formValidator.SanitizeInput(rf.nameEntry.Text),
 		"kana":            formValidator.SanitizeInput(rf.kanaEntry.Text),
-		"birth_date":      strings.ReplaceAll(rf.birthDateEntry.Text, "/", "-"), // Convert format for validator
+		"birth_date":      rf.birthDateEntry.Text,
 		"disability_name": formValidator.SanitizeInput(rf.disabilityNameEntry.Text),
 		"grade":           formValidator.SanitizeInput(rf.gradeEntry.Text),
-		"address":         formValidator.SanitizeInput(rf.addressEntry.Text),
+		"postal_code":     rf.postalCodeEntry.Text,
+		"prefecture":      formValidator.SanitizeInput(rf.prefectureEntry.Text),
+		"city":            formValidator.SanitizeInput(rf.cityEntry.Text),
+		"address":         formValidator.SanitizeInput(rf.streetEntry.Text),
 		"phone":           formValidator.SanitizeInput(rf.phoneEntry.Text),
 		"email":           formValidator.SanitizeInput(rf.emailEntry.Text),
-		"admission_date":  strings.ReplaceAll(rf.admissionDateEntry.Text, "/", "-"),
-		"discharge_date":  strings.ReplaceAll(rf.dischargeDateEntry.Text, "/", "-"),
+		"admission_date":  rf.admissionDateEntry.Text,
+		"discharge_date":  rf.dischargeDateEntry.Text,
 	}
 	
 	// Validate using the comprehensive form validator
 	if validationErrors := formValidator.ValidateRecipientForm(formData); len(validationErrors) > 0 {
-		return fmt.Errorf(validationErrors.Error())
+		return errors.New(validationErrors.Error())
 	}
 	
 	return nil
@@ -405,9 +610,9 @@ func (rf *RecipientForm) validateForm() error {
 
 // parseDateField parses a date field with error handling
 func (rf *RecipientForm) parseDateField(dateStr, fieldName string) (time.Time, error) {
-	parsed, err := time.Parse("2006/01/02", dateStr)
+	parsed, err := wareki.Parse(dateStr)
 	if err != nil {
-		return time.Time{}, fmt.Errorf("%sの形式が正しくありません (YYYY/MM/DD形式で入力してください)", fieldName)
+		return time.Time{}, fmt.Errorf("%sの形式が正しくありません (%s の形式で入力してください): %w", fieldName, datePlaceHolder, err)
 	}
 	return parsed, nil
 }
@@ -452,7 +657,14 @@ func (rf *RecipientForm) setFormEnabled(enabled bool) {
 		rf.disabilityNameEntry.Enable()
 		rf.hasDisabilityIDCheck.Enable()
 		rf.gradeEntry.Enable()
-		rf.addressEntry.Enable()
+		for _, input := range rf.disability.widgets() {
+			input.Enable()
+		}
+		rf.postalCodeEntry.Enable()
+		rf.postalCandidates.Enable()
+		rf.prefectureEntry.Enable()
+		rf.cityEntry.Enable()
+		rf.streetEntry.Enable()
 		rf.phoneEntry.Enable()
 		rf.emailEntry.Enable()
 		rf.publicAssistanceCheck.Enable()
@@ -468,7 +680,14 @@ func (rf *RecipientForm) setFormEnabled(enabled bool) {
 		rf.disabilityNameEntry.Disable()
 		rf.hasDisabilityIDCheck.Disable()
 		rf.gradeEntry.Disable()
-		rf.addressEntry.Disable()
+		for _, input := range rf.disability.widgets() {
+			input.Disable()
+		}
+		rf.postalCodeEntry.Disable()
+		rf.postalCandidates.Disable()
+		rf.prefectureEntry.Disable()
+		rf.cityEntry.Disable()
+		rf.streetEntry.Disable()
 		rf.phoneEntry.Disable()
 		rf.emailEntry.Disable()
 		rf.publicAssistanceCheck.Disable()
@@ -529,6 +748,7 @@ func (rf *RecipientForm) CreateObject() fyne.CanvasObject {
 			widget.NewLabel("等級:"), rf.gradeEntry,
 		),
 		rf.hasDisabilityIDCheck,
+		rf.disability.CreateObject(),
 	)
 
 	// Contact Information Section
@@ -536,7 +756,10 @@ func (rf *RecipientForm) CreateObject() fyne.CanvasObject {
 		widget.NewLabel("連絡先情報"),
 		widget.NewSeparator(),
 		container.NewGridWithColumns(2,
-			widget.NewLabel("住所:"), rf.addressEntry,
+			widget.NewLabel("郵便番号:"), container.NewVBox(rf.postalCodeEntry, rf.postalCandidates, rf.postalHint),
+			widget.NewLabel("都道府県:"), rf.prefectureEntry,
+			widget.NewLabel("市区町村:"), rf.cityEntry,
+			widget.NewLabel("町域・番地:"), rf.streetEntry,
 			widget.NewLabel("電話番号:"), rf.phoneEntry,
 			widget.NewLabel("メール:"), rf.emailEntry,
 		),
@@ -553,12 +776,24 @@ func (rf *RecipientForm) CreateObject() fyne.CanvasObject {
 		),
 	)
 
+	// Enrollment history is only meaningful for existing recipients
+	if rf.isEditing {
+		serviceInfo.Add(widget.NewLabel("在籍履歴:"))
+		serviceInfo.Add(rf.enrollmentHistory)
+	}
+
 	// Form controls
 	controls := container.NewHBox(
 		rf.saveButton,
 		rf.cancelButton,
 	)
 
+	// Disclosure packages are only available to administrators
+	if rf.canDisclose() {
+		controls.Add(widget.NewSeparator())
+		controls.Add(rf.disclosureButton)
+	}
+
 	// Main layout with scroll container for better UX
 	formContent := container.NewVBox(
 		basicInfo,
@@ -569,9 +804,25 @@ func (rf *RecipientForm) CreateObject() fyne.CanvasObject {
 		widget.NewSeparator(),
 		serviceInfo,
 		widget.NewSeparator(),
-		controls,
 	)
 
+	// Emergency contacts reference the recipient, so they are edited after creation
+	if rf.isEditing && rf.emergencyContacts != nil {
+		formContent.Add(rf.emergencyContacts.CreateObject())
+		formContent.Add(widget.NewSeparator())
+	}
+
+	formContent.Add(controls)
+
+	// Medical information lives in its own tab, below the critical allergy banner
+	if rf.canViewMedicalRecord() {
+		tabs := container.NewAppTabs(
+			container.NewTabItem("基本情報", container.NewScroll(formContent)),
+			container.NewTabItem("医療情報", rf.medicalRecord.CreateObject()),
+		)
+		return container.NewBorder(rf.medicalRecord.AlertBanner(), nil, nil, nil, tabs)
+	}
+
 	return container.NewScroll(formContent)
 }
 
@@ -584,3 +835,181 @@ func (rf *RecipientForm) SetOnSaved(callback func(*domain.Recipient)) {
 func (rf *RecipientForm) SetOnCancelled(callback func()) {
 	rf.onCancelled = callback
 }
+
+// SetDisclosureUseCase enables the disclosure package export for administrators
+func (rf *RecipientForm) SetDisclosureUseCase(disclosureUseCase usecase.DisclosureUseCase) {
+	rf.disclosureUseCase = disclosureUseCase
+}
+
+// SetPostalCodeUseCase enables address autocompletion and rejects postal
+// codes missing from the dictionary
+func (rf *RecipientForm) SetPostalCodeUseCase(postalCodeUseCase usecase.PostalCodeUseCase) {
+	rf.postalCodeUseCase = postalCodeUseCase
+}
+
+// loadFieldValues fills the form from a record without autocompleting the
+// address, so the stored address is shown as saved. nil only resets the
+// autocompletion state.
+func (rf *RecipientForm) loadFieldValues(values []string) {
+	rf.loadingFields = true
+	defer func() { rf.loadingFields = false }()
+
+	rf.autofilledTown = ""
+	rf.postalCandidates.Hide()
+	rf.postalHint.SetText("")
+	if values != nil {
+		setFieldValues(rf.conflictFields(), values)
+	}
+}
+
+// onPostalCodeChanged fills in the prefecture, city and town once a complete
+// postal code is typed. Codes shared by several towns offer a choice.
+func (rf *RecipientForm) onPostalCodeChanged(text string) {
+	if rf.loadingFields || rf.postalCodeUseCase == nil {
+		return
+	}
+
+	rf.postalCandidates.Hide()
+	rf.postalHint.SetText("")
+	if validation.PostalCodeDigits(text) == "" {
+		return
+	}
+
+	addresses, err := rf.postalCodeUseCase.LookupAddress(context.Background(), text)
+	if err != nil {
+		rf.postalHint.SetText("郵便番号を検索できませんでした")
+		return
+	}
+	if len(addresses) == 0 {
+		rf.postalHint.SetText("郵便番号辞書にない郵便番号です")
+		return
+	}
+
+	rf.prefectureEntry.SetText(addresses[0].Prefecture)
+	rf.cityEntry.SetText(addresses[0].City)
+	if len(addresses) == 1 {
+		rf.fillTown(addresses[0].Town)
+		return
+	}
+
+	towns := make([]string, len(addresses))
+	for i, address := range addresses {
+		towns[i] = address.Town
+	}
+	rf.postalCandidates.Options = towns
+	rf.postalCandidates.ClearSelected()
+	rf.postalCandidates.Show()
+	rf.postalHint.SetText(fmt.Sprintf("この郵便番号には%d件の町域があります", len(towns)))
+}
+
+// fillTown puts the town in front of the street. A street typed by hand is
+// kept; after a previously filled town only the town part is replaced, so
+// the block number survives a corrected postal code.
+func (rf *RecipientForm) fillTown(town string) {
+	street := strings.TrimSpace(rf.streetEntry.Text)
+	switch {
+	case street == "":
+		rf.streetEntry.SetText(town)
+	case rf.autofilledTown != "" && strings.HasPrefix(street, rf.autofilledTown):
+		rf.streetEntry.SetText(town + strings.TrimPrefix(street, rf.autofilledTown))
+	default:
+		return
+	}
+	rf.autofilledTown = town
+}
+
+// SetEmergencyContactSection enables emergency contact management in edit mode
+func (rf *RecipientForm) SetEmergencyContactSection(section *EmergencyContactSection) {
+	rf.emergencyContacts = section
+}
+
+// SetMedicalRecordSection enables the medical information tab in edit mode
+func (rf *RecipientForm) SetMedicalRecordSection(section *MedicalRecordSection) {
+	rf.medicalRecord = section
+}
+
+// canViewMedicalRecord reports whether the medical information tab should be offered;
+// read-only staff may not see health information
+func (rf *RecipientForm) canViewMedicalRecord() bool {
+	return rf.isEditing && rf.medicalRecord != nil &&
+		rf.currentUser != nil && rf.currentUser.Role != domain.RoleReadOnly
+}
+
+// canDisclose reports whether the disclosure export should be offered
+func (rf *RecipientForm) canDisclose() bool {
+	return rf.isEditing && rf.disclosureUseCase != nil &&
+		rf.currentUser != nil && rf.currentUser.Role == domain.RoleAdmin
+}
+
+// handleDisclosure asks for the reason and an optional password, then exports the disclosure package
+func (rf *RecipientForm) handleDisclosure() {
+	if !rf.canDisclose() || rf.recipientID == nil {
+		return
+	}
+
+	parent := fyne.CurrentApp().Driver().AllWindows()[0]
+
+	reasonEntry := widget.NewEntry()
+	reasonEntry.SetPlaceHolder("例: 本人からの開示請求")
+	passwordEntry := widget.NewPasswordEntry()
+	passwordEntry.SetPlaceHolder("8文字以上（空欄の場合は保護なし）")
+
+	items := []*widget.FormItem{
+		widget.NewFormItem("開示理由", reasonEntry),
+		widget.NewFormItem("PDFパスワード", passwordEntry),
+	}
+
+	dialog.ShowForm("開示資料作成", "作成", "キャンセル", items, func(confirmed bool) {
+		if !confirmed {
+			return
+		}
+
+		result, err := rf.disclosureUseCase.CreateDisclosurePackage(context.Background(), usecase.CreateDisclosureRequest{
+			RecipientID: *rf.recipientID,
+			Reason:      strings.TrimSpace(reasonEntry.Text),
+			Password:    passwordEntry.Text,
+			ActorID:     rf.currentUser.ID,
+		})
+		if err != nil {
+			dialog.ShowError(fmt.Errorf("開示資料の作成に失敗しました: %w", err), parent)
+			return
+		}
+
+		baseName := fmt.Sprintf("開示資料_%s_%s", result.Package.Recipient.Name, result.Package.GeneratedAt.Local().Format("20060102_150405"))
+		rf.saveDisclosureFile(parent, baseName+".pdf", result.PDF, func() {
+			// Password-protected packages carry the JSON only inside the PDF
+			if result.JSON == nil {
+				dialog.ShowInformation("成功", "開示資料（パスワード保護付きPDF）を保存しました。", parent)
+				return
+			}
+			rf.saveDisclosureFile(parent, baseName+".json", result.JSON, func() {
+				dialog.ShowInformation("成功", "開示資料（PDF・JSON）を保存しました。", parent)
+			})
+		})
+	}, parent)
+}
+
+// saveDisclosureFile lets the user pick a destination and writes data to it
+func (rf *RecipientForm) saveDisclosureFile(parent fyne.Window, fileName string, data []byte, onSaved func()) {
+	saveDialog := dialog.NewFileSave(func(writer fyne.URIWriteCloser, err error) {
+		if err != nil {
+			dialog.ShowError(fmt.Errorf("ファイルの保存に失敗しました: %w", err), parent)
+			return
+		}
+		if writer == nil {
+			return // User cancelled
+		}
+		defer writer.Close()
+
+		if _, err := writer.Write(data); err != nil {
+			dialog.ShowError(fmt.Errorf("ファイルの書き込みに失敗しました: %w", err), parent)
+			return
+		}
+
+		onSaved()
+	}, parent)
+
+	saveDialog.SetFileName(fileName)
+	saveDialog.SetFilter(storage.NewExtensionFileFilter([]string{filepath.Ext(fileName)}))
+	saveDialog.Show()
+}
@@ -0,0 +1,141 @@
+package widgets
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"shien-system/internal/domain"
+
+	"fyne.io/fyne/v2/test"
+)
+
+func TestRecipientForm_PostalCodeAutocomplete(t *testing.T) {
+	app := test.NewApp()
+	defer app.Quit()
+
+	form := NewRecipientForm(&MockRecipientUseCase{})
+	postal := &MockPostalCodeUseCase{addresses: []*domain.PostalAddress{
+		{Code: "1500002", Prefecture: "東京都", City: "渋谷区", Town: "渋谷"},
+	}}
+	form.SetPostalCodeUseCase(postal)
+
+	// Nothing is looked up until the code is complete
+	form.postalCodeEntry.SetText("150-000")
+	if form.prefectureEntry.Text != "" {
+		t.Errorf("prefecture = %q after a partial code, want empty", form.prefectureEntry.Text)
+	}
+
+	form.postalCodeEntry.SetText("150-0002")
+	if form.prefectureEntry.Text != "東京都" || form.cityEntry.Text != "渋谷区" || form.streetEntry.Text != "渋谷" {
+		t.Errorf("address = %q %q %q, want 東京都 渋谷区 渋谷",
+			form.prefectureEntry.Text, form.cityEntry.Text, form.streetEntry.Text)
+	}
+
+	// A corrected code replaces the filled-in town but keeps the block number
+	form.streetEntry.SetText("渋谷1-1-1")
+	postal.addresses = []*domain.PostalAddress{
+		{Code: "1500001", Prefecture: "東京都", City: "渋谷区", Town: "神宮前"},
+	}
+	form.postalCodeEntry.SetText("150-0001")
+	if form.streetEntry.Text != "神宮前1-1-1" {
+		t.Errorf("street = %q, want 神宮前1-1-1", form.streetEntry.Text)
+	}
+
+	// Several towns share a code: the user picks one
+	postal.addresses = []*domain.PostalAddress{
+		{Code: "0600042", Prefecture: "北海道", City: "札幌市中央区", Town: "大通西"},
+		{Code: "0600042", Prefecture: "北海道", City: "札幌市中央区", Town: "大通東"},
+	}
+	form.streetEntry.SetText("")
+	form.postalCodeEntry.SetText("060-0042")
+	if !form.postalCandidates.Visible() || len(form.postalCandidates.Options) != 2 {
+		t.Fatalf("town candidates = %v, want both 大通 towns", form.postalCandidates.Options)
+	}
+	form.postalCandidates.SetSelected("大通東")
+	if form.streetEntry.Text != "大通東" {
+		t.Errorf("street = %q, want 大通東", form.streetEntry.Text)
+	}
+}
+
+func TestRecipientForm_SetForEditKeepsStoredAddress(t *testing.T) {
+	app := test.NewApp()
+	defer app.Quit()
+
+	form := NewRecipientForm(&MockRecipientUseCase{})
+	form.SetPostalCodeUseCase(&MockPostalCodeUseCase{addresses: []*domain.PostalAddress{
+		{Code: "1500002", Prefecture: "東京都", City: "渋谷区", Town: "渋谷"},
+	}})
+	staff := &domain.Staff{ID: "staff-001", Name: "職員", Role: domain.RoleStaff}
+
+	form.SetForEdit(&domain.Recipient{ID: "recipient-001", Name: "田中太郎", PostalCode: "150-0002",
+		Prefecture: "東京都", City: "渋谷区", Street: "道玄坂1-2-3"}, staff)
+	if form.streetEntry.Text != "道玄坂1-2-3" {
+		t.Errorf("street = %q, want the stored street", form.streetEntry.Text)
+	}
+
+	// Recipients saved before postal codes show their full address as the street
+	form.SetForEdit(&domain.Recipient{ID: "recipient-002", Name: "佐藤花子", Address: "東京都新宿区西新宿2-8-1"}, staff)
+	if form.streetEntry.Text != "東京都新宿区西新宿2-8-1" || form.prefectureEntry.Text != "" {
+		t.Errorf("address = %q %q, want the legacy address in the street", form.prefectureEntry.Text, form.streetEntry.Text)
+	}
+}
+
+func TestRecipientForm_DisabilityClassificationRoundTrip(t *testing.T) {
+	app := test.NewApp()
+	defer app.Quit()
+
+	form := NewRecipientForm(&MockRecipientUseCase{})
+	staff := &domain.Staff{ID: "staff-001", Name: "職員", Role: domain.RoleStaff}
+
+	renewal := time.Date(2028, 6, 30, 0, 0, 0, 0, time.UTC)
+	recipient := &domain.Recipient{
+		ID:        "recipient-001",
+		Name:      "田中太郎",
+		BirthDate: time.Date(1990, 5, 15, 0, 0, 0, 0, time.UTC),
+		Version:   3,
+		Handbooks: []domain.DisabilityHandbook{
+			{Type: domain.HandbookTypeIntellectual, Number: "第123号", Grade: "A2", RenewalDate: &renewal},
+		},
+		SupportCategory: &domain.SupportCategory{
+			Level:      5,
+			ValidFrom:  time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC),
+			ValidUntil: time.Date(2028, 3, 31, 0, 0, 0, 0, time.UTC),
+		},
+	}
+	form.SetForEdit(recipient, staff)
+
+	if form.disability.supportLevel.Selected != "区分5" {
+		t.Errorf("support level = %q, want 区分5", form.disability.supportLevel.Selected)
+	}
+
+	req, err := form.buildUpdateRequest()
+	if err != nil {
+		t.Fatalf("buildUpdateRequest() error = %v", err)
+	}
+	if !reflect.DeepEqual(req.Handbooks, recipient.Handbooks) {
+		t.Errorf("Handbooks = %+v, want %+v", req.Handbooks, recipient.Handbooks)
+	}
+	if !reflect.DeepEqual(req.SupportCategory, recipient.SupportCategory) {
+		t.Errorf("SupportCategory = %+v, want %+v", req.SupportCategory, recipient.SupportCategory)
+	}
+	if req.IntractableDisease != nil {
+		t.Errorf("IntractableDisease = %+v, want nil for blank inputs", req.IntractableDisease)
+	}
+
+	// Choosing 未認定 drops the certification
+	form.disability.supportLevel.SetSelected(supportLevelUncertified)
+	form.disability.diseaseName.SetText("パーキンソン病")
+	req, err = form.buildUpdateRequest()
+	if err != nil {
+		t.Fatalf("buildUpdateRequest() error = %v", err)
+	}
+	if req.SupportCategory != nil || req.IntractableDisease == nil || req.IntractableDisease.Name != "パーキンソン病" {
+		t.Errorf("SupportCategory = %+v, IntractableDisease = %+v", req.SupportCategory, req.IntractableDisease)
+	}
+
+	form.disability.handbooks[0].renewal.SetText("来年")
+	if _, err := form.buildUpdateRequest(); err == nil {
+		t.Error("buildUpdateRequest() accepted an invalid renewal date")
+	}
+}
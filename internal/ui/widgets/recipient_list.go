@@ -2,12 +2,15 @@ package widgets
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"shien-system/internal/adapter/pdf"
 	"shien-system/internal/domain"
 	"shien-system/internal/usecase"
+	"shien-system/internal/wareki"
 	"shien-system/internal/validation"
 
 	"fyne.io/fyne/v2"
@@ -22,15 +25,23 @@ type RecipientList struct {
 	useCase            usecase.RecipientUseCase
 	certificateUseCase usecase.CertificateUseCase
 	staffUseCase       usecase.StaffUseCase
+	contactUseCase     usecase.EmergencyContactUseCase
+	medicalUseCase     usecase.MedicalRecordUseCase
+	exportUseCase      usecase.ExportUseCase
+	currentUser        *domain.Staff
 	pdfService         *pdf.PDFService
 
 	// UI components
-	searchEntry    *widget.Entry
-	table          *widget.Table
-	newButton      *widget.Button
-	refreshButton  *widget.Button
-	exportButton   *widget.Button
-	staffFilter    *widget.Select
+	searchEntry     *widget.Entry
+	table           *widget.Table
+	newButton       *widget.Button
+	refreshButton   *widget.Button
+	exportButton    *widget.Button
+	sheetButton     *widget.Button
+	staffFilter     *widget.Select
+	supportFilter   *widget.Select
+	enrolledOnEntry *widget.Entry
+	rosterButton    *widget.Button
 
 	// Data
 	recipients     []*domain.Recipient
@@ -95,11 +106,31 @@ func (rl *RecipientList) createWidgets() {
 		rl.exportSelectedToPDF()
 	})
 
+	rl.sheetButton = widget.NewButton("Excel・CSV出力", func() {
+		rl.exportToSpreadsheet()
+	})
+
+	// Enrollment roster ("who was enrolled on date X")
+	rl.enrolledOnEntry = widget.NewEntry()
+	rl.enrolledOnEntry.SetPlaceHolder("基準日 (" + datePlaceHolder + ")")
+	rl.enrolledOnEntry.SetText(time.Now().Format("2006/01/02"))
+
+	rl.rosterButton = widget.NewButton("在籍者名簿", func() {
+		rl.exportEnrollmentRoster()
+	})
+
 	// Staff filter
 	rl.staffFilter = widget.NewSelect([]string{"全て", "担当者1", "担当者2"}, func(selected string) {
 		rl.onStaffFilterChanged(selected)
 	})
 	rl.staffFilter.Selected = "全て"
+
+	// Support category filter, by the certification valid today
+	rl.supportFilter = widget.NewSelect(supportFilterOptions(), func(string) {
+		rl.applyFilters()
+		rl.table.Refresh()
+	})
+	rl.supportFilter.Selected = supportFilterAll
 }
 
 // setupTable configures the table widget
@@ -147,7 +178,7 @@ func (rl *RecipientList) updateTableCell(id widget.TableCellID, label *widget.La
 	case 2: // 性別
 		label.SetText(rl.formatSex(recipient.Sex))
 	case 3: // 生年月日
-		label.SetText(recipient.BirthDate.Format("2006/01/02"))
+		label.SetText(dateStyleOf(rl.currentUser).FormatShort(recipient.BirthDate))
 	case 4: // 障害名
 		label.SetText(recipient.DisabilityName)
 	case 5: // 等級
@@ -155,16 +186,34 @@ func (rl *RecipientList) updateTableCell(id widget.TableCellID, label *widget.La
 	case 6: // 担当者
 		label.SetText("未実装") // TODO: Implement staff assignment display
 	case 7: // 状態
-		status := "利用中"
-		if recipient.DischargeDate != nil {
-			status = "退所"
-		}
-		label.SetText(status)
+		label.SetText(rl.formatStatus(recipient))
 	default:
 		label.SetText("")
 	}
 }
 
+// formatStatus returns whether the recipient is still enrolled
+func (rl *RecipientList) formatStatus(recipient *domain.Recipient) string {
+	if recipient.DischargeDate != nil && recipient.DischargeDate.Before(time.Now()) {
+		return "退所"
+	}
+	return "利用中"
+}
+
+// formatHandbooks lists the handbooks as "療育手帳 第123号 B1（更新 R10.6.30）"
+func (rl *RecipientList) formatHandbooks(handbooks []domain.DisabilityHandbook) string {
+	style := dateStyleOf(rl.currentUser)
+	parts := make([]string, 0, len(handbooks))
+	for _, handbook := range handbooks {
+		text := strings.TrimSpace(fmt.Sprintf("%s %s %s", handbook.Type.Label(), handbook.Number, handbook.Grade))
+		if handbook.RenewalDate != nil {
+			text += fmt.Sprintf("（更新 %s）", style.FormatShort(*handbook.RenewalDate))
+		}
+		parts = append(parts, text)
+	}
+	return strings.Join(parts, "、")
+}
+
 // formatSex converts Sex enum to Japanese string
 func (rl *RecipientList) formatSex(sex domain.Sex) string {
 	switch sex {
@@ -253,24 +302,60 @@ func (rl *RecipientList) getStaffIDFilter() *domain.ID {
 func (rl *RecipientList) applyFilters() {
 	rl.filteredData = make([]*domain.Recipient, 0)
 
-	searchLower := rl.currentSearch
+	search := validation.NormalizeSearch(rl.currentSearch)
+	today := time.Now()
 
 	for _, recipient := range rl.recipients {
 		// Apply search filter
-		if searchLower != "" {
-			if !rl.matchesSearch(recipient, searchLower) {
+		if search != "" {
+			if !rl.matchesSearch(recipient, search) {
 				continue
 			}
 		}
 
+		if !matchesSupportFilter(recipient, rl.supportFilter.Selected, today) {
+			continue
+		}
+
 		rl.filteredData = append(rl.filteredData, recipient)
 	}
 }
 
-// matchesSearch checks if a recipient matches the search criteria
+// matchesSearch checks if a recipient's name or kana contains search, which
+// is normalized with validation.NormalizeSearch so that やまだ, ﾔﾏﾀﾞ and ヤマダ match
 func (rl *RecipientList) matchesSearch(recipient *domain.Recipient, search string) bool {
-	// Simple search implementation - can be enhanced
-	return recipient.Name == search || recipient.Kana == search
+	return strings.Contains(validation.NormalizeSearch(recipient.Name), search) ||
+		strings.Contains(validation.NormalizeSearch(recipient.Kana), search)
+}
+
+// Support category filter options besides the levels
+const (
+	supportFilterAll         = "全区分"
+	supportFilterUncertified = "未認定・期限切れ"
+)
+
+// supportFilterOptions lists the support category filter options
+func supportFilterOptions() []string {
+	options := []string{supportFilterAll}
+	for level := domain.SupportCategoryMin; level <= domain.SupportCategoryMax; level++ {
+		options = append(options, domain.SupportCategoryLabel(level))
+	}
+	return append(options, domain.SupportCategoryLabel(domain.SupportCategoryNone), supportFilterUncertified)
+}
+
+// matchesSupportFilter checks the recipient's support category valid on date
+// against a supportFilterOptions entry
+func matchesSupportFilter(recipient *domain.Recipient, option string, date time.Time) bool {
+	category := recipient.SupportCategory
+	valid := category != nil && category.ValidOn(date)
+	switch option {
+	case "", supportFilterAll:
+		return true
+	case supportFilterUncertified:
+		return !valid
+	default:
+		return valid && category.Label() == option
+	}
 }
 
 // CreateObject creates the main UI object for this widget
@@ -281,11 +366,16 @@ func (rl *RecipientList) CreateObject() fyne.CanvasObject {
 		container.NewHBox(
 			widget.NewLabel("担当者:"),
 			rl.staffFilter,
+			widget.NewLabel("支援区分:"),
+			rl.supportFilter,
 		),
 		container.NewHBox(
 			rl.newButton,
 			rl.refreshButton,
 			rl.exportButton,
+			rl.sheetButton,
+			rl.enrolledOnEntry,
+			rl.rosterButton,
 		),
 		container.NewBorder(
 			nil, nil,
@@ -334,12 +424,30 @@ func (rl *RecipientList) SetOnEditRecipient(callback func(recipientID domain.ID)
 	rl.onEditRecipient = callback
 }
 
+// SetEmergencyContactUseCase includes emergency contacts in exported reports
+func (rl *RecipientList) SetEmergencyContactUseCase(contactUseCase usecase.EmergencyContactUseCase) {
+	rl.contactUseCase = contactUseCase
+}
+
+// SetMedicalRecordUseCase includes medical information in reports exported by the given user
+func (rl *RecipientList) SetMedicalRecordUseCase(medicalUseCase usecase.MedicalRecordUseCase, currentUser *domain.Staff) {
+	rl.medicalUseCase = medicalUseCase
+	rl.currentUser = currentUser
+}
+
 // Length returns the number of visible items in the table (for testing)
 func (rl *RecipientList) Length() int {
 	return len(rl.filteredData)
 }
 
-// exportSelectedToPDF exports the filtered recipients to a PDF report
+// SetExportUseCase enables PDF export; every export is recorded for currentUser
+func (rl *RecipientList) SetExportUseCase(exportUseCase usecase.ExportUseCase, currentUser *domain.Staff) {
+	rl.exportUseCase = exportUseCase
+	rl.currentUser = currentUser
+}
+
+// exportSelectedToPDF exports the report of the first filtered recipient.
+// Reports are not combined, so narrow the list down to the recipient first.
 func (rl *RecipientList) exportSelectedToPDF() {
 	if rl.pdfService == nil {
 		dialog.ShowError(fmt.Errorf("PDFサービスが利用できません"), fyne.CurrentApp().Driver().AllWindows()[0])
@@ -351,7 +459,174 @@ func (rl *RecipientList) exportSelectedToPDF() {
 		return
 	}
 
-	// Show file save dialog
+	recipient := rl.filteredData[0]
+	exportConfidentialPDF(fyne.CurrentApp().Driver().AllWindows()[0], rl.exportUseCase, rl.currentUser, confidentialExport{
+		report:   fmt.Sprintf("利用者「%s」の利用者情報報告書", recipient.Name),
+		target:   fmt.Sprintf("recipient:%s", recipient.ID),
+		fileName: fmt.Sprintf("利用者情報_%s_%s.pdf", recipient.Name, time.Now().Format("20060102_150405")),
+		count:    1,
+		generate: func(opts pdf.ExportOptions) ([]byte, error) {
+			return rl.generateRecipientReport(context.Background(), recipient, opts)
+		},
+	})
+}
+
+// exportToSpreadsheet exports the filtered recipients to CSV or Excel
+func (rl *RecipientList) exportToSpreadsheet() {
+	recipients := rl.filteredData
+	exportSpreadsheet(fyne.CurrentApp().Driver().AllWindows()[0], rl.exportUseCase, rl.currentUser, spreadsheetExport{
+		report: "利用者一覧",
+		target: "report:recipient_list",
+		table:  usecase.ExportTableRecipients,
+		count:  len(recipients),
+		cell: func(row int, key string) interface{} {
+			return rl.exportCell(recipients[row], key)
+		},
+	})
+}
+
+// exportCell returns the value of a spreadsheet column for a recipient
+func (rl *RecipientList) exportCell(recipient *domain.Recipient, key string) interface{} {
+	switch key {
+	case "name":
+		return recipient.Name
+	case "kana":
+		return recipient.Kana
+	case "sex":
+		return rl.formatSex(recipient.Sex)
+	case "birth_date":
+		return recipient.BirthDate
+	case "disability_name":
+		return recipient.DisabilityName
+	case "has_disability_id":
+		return formatPresence(recipient.HasDisabilityID)
+	case "grade":
+		return recipient.Grade
+	case "handbooks":
+		return rl.formatHandbooks(recipient.Handbooks)
+	case "support_category":
+		if recipient.SupportCategory == nil {
+			return ""
+		}
+		return recipient.SupportCategory.Label()
+	case "support_category_until":
+		if recipient.SupportCategory == nil {
+			return nil
+		}
+		return recipient.SupportCategory.ValidUntil
+	case "intractable_disease":
+		if recipient.IntractableDisease == nil {
+			return ""
+		}
+		return recipient.IntractableDisease.Name
+	case "postal_code":
+		return recipient.PostalCode
+	case "address":
+		return recipient.ComposeAddress()
+	case "phone":
+		return recipient.Phone
+	case "email":
+		return recipient.Email
+	case "public_assistance":
+		return formatPresence(recipient.PublicAssistance)
+	case "admission_date":
+		return optionalTime(recipient.AdmissionDate)
+	case "discharge_date":
+		return optionalTime(recipient.DischargeDate)
+	case "status":
+		return rl.formatStatus(recipient)
+	default:
+		return nil
+	}
+}
+
+// generateRecipientReport gathers the records of one recipient and renders the report
+func (rl *RecipientList) generateRecipientReport(ctx context.Context, recipient *domain.Recipient, opts pdf.ExportOptions) ([]byte, error) {
+	// Get certificates for this recipient
+	certificates, err := rl.getCertificatesForRecipient(ctx, recipient.ID)
+	if err != nil {
+		return nil, fmt.Errorf("受給者証データの取得に失敗しました: %w", err)
+	}
+
+	// Get assignments for this recipient
+	assignments, err := rl.getAssignmentsForRecipient(ctx, recipient.ID)
+	if err != nil {
+		return nil, fmt.Errorf("担当者データの取得に失敗しました: %w", err)
+	}
+
+	// Convert pointer slices to value slices for PDF service
+	certificateValues := make([]domain.BenefitCertificate, len(certificates))
+	for i, cert := range certificates {
+		if cert != nil {
+			certificateValues[i] = *cert
+		}
+	}
+
+	assignmentValues := make([]domain.StaffAssignment, len(assignments))
+	for i, assign := range assignments {
+		if assign != nil {
+			assignmentValues[i] = *assign
+		}
+	}
+
+	// Get emergency contacts for this recipient
+	var contactValues []domain.EmergencyContact
+	if rl.contactUseCase != nil {
+		contacts, err := rl.contactUseCase.GetEmergencyContacts(ctx, recipient.ID)
+		if err != nil {
+			return nil, fmt.Errorf("緊急連絡先の取得に失敗しました: %w", err)
+		}
+		for _, contact := range contacts {
+			if contact != nil {
+				contactValues = append(contactValues, *contact)
+			}
+		}
+	}
+
+	// Get medical information; read-only staff export the report without it
+	var medical *domain.MedicalRecord
+	if rl.medicalUseCase != nil && rl.currentUser != nil && rl.currentUser.Role != domain.RoleReadOnly {
+		medical, err = rl.medicalUseCase.GetMedicalRecord(ctx, recipient.ID, rl.currentUser.ID)
+		if err != nil && !errors.Is(err, usecase.ErrMedicalRecordNotFound) {
+			return nil, fmt.Errorf("医療情報の取得に失敗しました: %w", err)
+		}
+	}
+
+	pdfBytes, err := rl.pdfService.GenerateRecipientReport(ctx, recipient, certificateValues, assignmentValues, contactValues, medical, opts)
+	if err != nil {
+		return nil, fmt.Errorf("PDF生成に失敗しました: %w", err)
+	}
+	return pdfBytes, nil
+}
+
+// exportEnrollmentRoster exports the recipients enrolled on the entered date to a PDF roster
+func (rl *RecipientList) exportEnrollmentRoster() {
+	if rl.pdfService == nil {
+		dialog.ShowError(fmt.Errorf("PDFサービスが利用できません"), fyne.CurrentApp().Driver().AllWindows()[0])
+		return
+	}
+
+	date, err := wareki.Parse(rl.enrolledOnEntry.Text)
+	if err != nil {
+		dialog.ShowError(fmt.Errorf("基準日の形式が正しくありません (%s の形式で入力してください)", datePlaceHolder), fyne.CurrentApp().Driver().AllWindows()[0])
+		return
+	}
+
+	ctx := context.Background()
+	recipients, err := rl.useCase.GetRecipientsEnrolledOn(ctx, date)
+	if err != nil {
+		dialog.ShowError(fmt.Errorf("在籍者の取得に失敗しました: %w", err), fyne.CurrentApp().Driver().AllWindows()[0])
+		return
+	}
+
+	// Convert pointer slice to value slice for PDF service
+	recipientValues := make([]domain.Recipient, 0, len(recipients))
+	for _, recipient := range recipients {
+		if recipient != nil {
+			recipientValues = append(recipientValues, *recipient)
+		}
+	}
+
 	saveDialog := dialog.NewFileSave(func(writer fyne.URIWriteCloser, err error) {
 		if err != nil {
 			dialog.ShowError(fmt.Errorf("ファイルの保存に失敗しました: %w", err), fyne.CurrentApp().Driver().AllWindows()[0])
@@ -362,66 +637,21 @@ func (rl *RecipientList) exportSelectedToPDF() {
 		}
 		defer writer.Close()
 
-		// Generate PDF for each recipient
-		for i, recipient := range rl.filteredData {
-			ctx := context.Background()
-			
-			// Get certificates for this recipient
-			certificates, err := rl.getCertificatesForRecipient(ctx, recipient.ID)
-			if err != nil {
-				dialog.ShowError(fmt.Errorf("受給者証データの取得に失敗しました: %w", err), fyne.CurrentApp().Driver().AllWindows()[0])
-				return
-			}
-
-			// Get assignments for this recipient
-			assignments, err := rl.getAssignmentsForRecipient(ctx, recipient.ID)
-			if err != nil {
-				dialog.ShowError(fmt.Errorf("担当者データの取得に失敗しました: %w", err), fyne.CurrentApp().Driver().AllWindows()[0])
-				return
-			}
-
-			// Convert pointer slices to value slices for PDF service
-			certificateValues := make([]domain.BenefitCertificate, len(certificates))
-			for i, cert := range certificates {
-				if cert != nil {
-					certificateValues[i] = *cert
-				}
-			}
-
-			assignmentValues := make([]domain.StaffAssignment, len(assignments))
-			for i, assign := range assignments {
-				if assign != nil {
-					assignmentValues[i] = *assign
-				}
-			}
-
-			// Generate PDF
-			pdfBytes, err := rl.pdfService.GenerateRecipientReport(ctx, recipient, certificateValues, assignmentValues)
-			if err != nil {
-				dialog.ShowError(fmt.Errorf("PDF生成に失敗しました: %w", err), fyne.CurrentApp().Driver().AllWindows()[0])
-				return
-			}
-
-			// For the first recipient, write directly. For subsequent ones, append.
-			if i == 0 {
-				_, err = writer.Write(pdfBytes)
-			} else {
-				// Note: This is a simplified implementation. 
-				// For multiple recipients, you might want to combine PDFs or create separate files.
-				break
-			}
+		pdfBytes, err := rl.pdfService.GenerateEnrollmentReport(ctx, date, recipientValues)
+		if err != nil {
+			dialog.ShowError(fmt.Errorf("PDF生成に失敗しました: %w", err), fyne.CurrentApp().Driver().AllWindows()[0])
+			return
+		}
 
-			if err != nil {
-				dialog.ShowError(fmt.Errorf("ファイルの書き込みに失敗しました: %w", err), fyne.CurrentApp().Driver().AllWindows()[0])
-				return
-			}
+		if _, err := writer.Write(pdfBytes); err != nil {
+			dialog.ShowError(fmt.Errorf("ファイルの書き込みに失敗しました: %w", err), fyne.CurrentApp().Driver().AllWindows()[0])
+			return
 		}
 
-		dialog.ShowInformation("成功", fmt.Sprintf("%d人の利用者データをPDFにエクスポートしました。", len(rl.filteredData)), fyne.CurrentApp().Driver().AllWindows()[0])
+		dialog.ShowInformation("成功", fmt.Sprintf("%s時点の在籍者%d人を名簿に出力しました。", dateStyleOf(rl.currentUser).Format(date), len(recipientValues)), fyne.CurrentApp().Driver().AllWindows()[0])
 	}, fyne.CurrentApp().Driver().AllWindows()[0])
 
-	// Set default filename with timestamp
-	defaultName := fmt.Sprintf("利用者一覧_%s.pdf", time.Now().Format("20060102_150405"))
+	defaultName := fmt.Sprintf("在籍者名簿_%s.pdf", date.Format("20060102"))
 	saveDialog.SetFileName(defaultName)
 	saveDialog.SetFilter(storage.NewExtensionFileFilter([]string{".pdf"}))
 	saveDialog.Show()
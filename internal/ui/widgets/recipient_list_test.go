@@ -137,6 +137,56 @@ func TestRecipientList_SearchFunctionality(t *testing.T) {
 	// Test passed
 }
 
+func TestRecipientList_SearchIgnoresKanaWidth(t *testing.T) {
+	app := test.NewApp()
+	defer app.Quit()
+
+	mockUseCase := &MockRecipientUseCase{recipients: createTestRecipients()}
+	recipientList := NewRecipientList(mockUseCase, nil, nil, nil)
+	recipientList.LoadData()
+
+	for _, search := range []string{"たなか", "ﾀﾅｶ", "タナカ"} {
+		recipientList.onSearchChanged(search)
+		if recipientList.Length() != 1 || recipientList.filteredData[0].ID != "recipient-001" {
+			t.Errorf("search %q matched %d recipients, want only recipient-001", search, recipientList.Length())
+		}
+	}
+}
+
+func TestRecipientList_SupportCategoryFilter(t *testing.T) {
+	app := test.NewApp()
+	defer app.Quit()
+
+	recipients := createTestRecipients()
+	now := time.Now()
+	recipients[0].SupportCategory = &domain.SupportCategory{Level: 4, ValidFrom: now.AddDate(-1, 0, 0), ValidUntil: now.AddDate(2, 0, 0)}
+	// Expired certifications no longer count
+	recipients[1].SupportCategory = &domain.SupportCategory{Level: 4, ValidFrom: now.AddDate(-3, 0, 0), ValidUntil: now.AddDate(0, 0, -1)}
+
+	recipientList := NewRecipientList(&MockRecipientUseCase{recipients: recipients}, nil, nil, nil)
+	recipientList.LoadData()
+
+	tests := []struct {
+		option string
+		want   []domain.ID
+	}{
+		{"区分4", []domain.ID{"recipient-001"}},
+		{"区分3", nil},
+		{"未認定・期限切れ", []domain.ID{"recipient-002"}},
+		{"全区分", []domain.ID{"recipient-001", "recipient-002"}},
+	}
+	for _, tt := range tests {
+		recipientList.supportFilter.SetSelected(tt.option)
+		var got []domain.ID
+		for _, recipient := range recipientList.filteredData {
+			got = append(got, recipient.ID)
+		}
+		if len(got) != len(tt.want) || (len(got) > 0 && got[0] != tt.want[0]) {
+			t.Errorf("filter %s = %v, want %v", tt.option, got, tt.want)
+		}
+	}
+}
+
 func TestRecipientList_TableColumns(t *testing.T) {
 	app := test.NewApp()
 	defer app.Quit()
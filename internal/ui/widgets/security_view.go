@@ -0,0 +1,675 @@
+package widgets
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"shien-system/internal/domain"
+	"shien-system/internal/usecase"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+// lockoutDurationOptions are the durations offered for manual lockouts and pattern blocks
+var lockoutDurationOptions = []struct {
+	label   string
+	minutes int
+}{
+	{"30分", 30},
+	{"1時間", 60},
+	{"24時間", 24 * 60},
+	{"7日", 7 * 24 * 60},
+	{"30日", 30 * 24 * 60},
+}
+
+// SecurityView is the administrator security dashboard: lockouts, failed logins,
+// detected attack patterns and the security event history
+type SecurityView struct {
+	useCase     usecase.SecurityUseCase
+	currentUser *domain.Staff
+	policyPanel *RateLimitPolicyPanel // nil when the policy use case is not configured
+
+	// UI components
+	summaryLabel  *widget.Label
+	lockoutTable  *widget.Table
+	patternTable  *widget.Table
+	failureTable  *widget.Table
+	historyTable  *widget.Table
+	eventTable    *widget.Table
+	unlockButton  *widget.Button
+	lockButton    *widget.Button
+	blockButton   *widget.Button
+	resolveButton *widget.Button
+	refreshButton *widget.Button
+
+	// Data
+	dashboard       *usecase.SecurityDashboard
+	selectedLockout int
+	selectedPattern int
+}
+
+// NewSecurityView creates a new SecurityView widget. policyUseCase may be nil,
+// in which case the rate limit settings tab is not shown.
+func NewSecurityView(useCase usecase.SecurityUseCase, policyUseCase usecase.RateLimitPolicyUseCase, currentUser *domain.Staff) *SecurityView {
+	sv := &SecurityView{
+		useCase:         useCase,
+		currentUser:     currentUser,
+		dashboard:       &usecase.SecurityDashboard{},
+		selectedLockout: -1,
+		selectedPattern: -1,
+	}
+
+	if policyUseCase != nil {
+		sv.policyPanel = NewRateLimitPolicyPanel(policyUseCase, currentUser)
+	}
+
+	sv.createWidgets()
+
+	return sv
+}
+
+// createWidgets initializes all UI components
+func (sv *SecurityView) createWidgets() {
+	sv.summaryLabel = widget.NewLabel("")
+
+	sv.lockoutTable = newSecurityTable(
+		[]float32{140, 130, 80, 130, 130, 260},
+		func() int { return len(sv.dashboard.ActiveLockouts) },
+		func(row, col int) string { return lockoutCell(sv.dashboard.ActiveLockouts[row], col) },
+	)
+	sv.lockoutTable.OnSelected = func(id widget.TableCellID) {
+		sv.selectedLockout = id.Row
+		sv.unlockButton.Enable()
+	}
+
+	sv.patternTable = newSecurityTable(
+		[]float32{170, 60, 90, 200, 180, 60, 130},
+		func() int { return len(sv.dashboard.AttackPatterns) },
+		func(row, col int) string { return patternCell(sv.dashboard.AttackPatterns[row], col) },
+	)
+	sv.patternTable.OnSelected = func(id widget.TableCellID) {
+		sv.selectedPattern = id.Row
+		sv.updatePatternButtons()
+	}
+
+	sv.failureTable = newSecurityTable(
+		[]float32{130, 140, 130, 360},
+		func() int { return len(sv.dashboard.RecentFailures) },
+		func(row, col int) string { return failureCell(sv.dashboard.RecentFailures[row], col) },
+	)
+
+	sv.historyTable = newSecurityTable(
+		[]float32{130, 140, 130, 80, 90, 130, 260},
+		func() int { return len(sv.dashboard.LockoutHistory) },
+		func(row, col int) string { return historyCell(sv.dashboard.LockoutHistory[row], col) },
+	)
+
+	sv.eventTable = newSecurityTable(
+		[]float32{130, 150, 130, 480},
+		func() int { return len(sv.dashboard.Events) },
+		func(row, col int) string { return eventCell(sv.dashboard.Events[row], col) },
+	)
+
+	// Buttons
+	sv.unlockButton = widget.NewButton("ロック解除", func() {
+		sv.showUnlockDialog()
+	})
+	sv.unlockButton.Disable()
+
+	sv.lockButton = widget.NewButton("手動ロック", func() {
+		sv.showManualLockoutDialog()
+	})
+
+	sv.blockButton = widget.NewButton("ブロック", func() {
+		sv.showPatternStatusDialog(domain.AttackPatternStatusBlocked)
+	})
+	sv.blockButton.Disable()
+
+	sv.resolveButton = widget.NewButton("解決済みにする", func() {
+		sv.showPatternStatusDialog(domain.AttackPatternStatusResolved)
+	})
+	sv.resolveButton.Disable()
+
+	sv.refreshButton = widget.NewButton("更新", func() {
+		sv.LoadData()
+	})
+}
+
+// newSecurityTable creates a read-only label table with the given column widths
+func newSecurityTable(widths []float32, rows func() int, cell func(row, col int) string) *widget.Table {
+	table := widget.NewTable(
+		func() (int, int) {
+			return rows(), len(widths)
+		},
+		func() fyne.CanvasObject {
+			return widget.NewLabel("")
+		},
+		func(id widget.TableCellID, obj fyne.CanvasObject) {
+			label := obj.(*widget.Label)
+			if id.Row >= rows() {
+				label.SetText("")
+				return
+			}
+			label.SetText(cell(id.Row, id.Col))
+		},
+	)
+
+	for i, width := range widths {
+		table.SetColumnWidth(i, width)
+	}
+
+	return table
+}
+
+// LoadData reloads the dashboard
+func (sv *SecurityView) LoadData() {
+	if sv.currentUser == nil || sv.currentUser.Role != domain.RoleAdmin {
+		return
+	}
+
+	dashboard, err := sv.useCase.GetDashboard(context.Background(), sv.currentUser.ID)
+	if err != nil {
+		sv.showError(fmt.Errorf("セキュリティ情報の読み込みに失敗しました: %w", err))
+		return
+	}
+
+	sv.dashboard = dashboard
+	sv.selectedLockout = -1
+	sv.selectedPattern = -1
+	sv.unlockButton.Disable()
+	sv.updatePatternButtons()
+
+	activePatterns := 0
+	for _, pattern := range dashboard.AttackPatterns {
+		if pattern.Status == domain.AttackPatternStatusActive {
+			activePatterns++
+		}
+	}
+	sv.summaryLabel.SetText(fmt.Sprintf("ロック中: %d件　24時間以内のログイン失敗: %d件　未対応の攻撃パターン: %d件　（%s 時点）",
+		len(dashboard.ActiveLockouts), len(dashboard.RecentFailures), activePatterns,
+		dashboard.GeneratedAt.Format("2006/01/02 15:04")))
+
+	for _, table := range []*widget.Table{sv.lockoutTable, sv.patternTable, sv.failureTable, sv.historyTable, sv.eventTable} {
+		table.UnselectAll()
+		table.Refresh()
+	}
+
+	if sv.policyPanel != nil {
+		sv.policyPanel.LoadData()
+	}
+}
+
+// updatePatternButtons enables the transitions allowed from the selected pattern's status
+func (sv *SecurityView) updatePatternButtons() {
+	sv.blockButton.Disable()
+	sv.resolveButton.Disable()
+
+	if sv.selectedPattern < 0 || sv.selectedPattern >= len(sv.dashboard.AttackPatterns) {
+		return
+	}
+
+	switch sv.dashboard.AttackPatterns[sv.selectedPattern].Status {
+	case domain.AttackPatternStatusActive:
+		sv.blockButton.Enable()
+		sv.resolveButton.Enable()
+	case domain.AttackPatternStatusBlocked:
+		sv.resolveButton.Enable()
+	}
+}
+
+// showUnlockDialog asks for a reason and lifts the selected lockout
+func (sv *SecurityView) showUnlockDialog() {
+	if sv.selectedLockout < 0 || sv.selectedLockout >= len(sv.dashboard.ActiveLockouts) {
+		return
+	}
+
+	lockout := sv.dashboard.ActiveLockouts[sv.selectedLockout]
+	reasonEntry := widget.NewMultiLineEntry()
+	reasonEntry.SetPlaceHolder("解除の理由（必須）")
+
+	dialog.ShowForm("ロック解除", "解除", "キャンセル",
+		[]*widget.FormItem{
+			widget.NewFormItem("対象", widget.NewLabel(lockoutSubject(lockout))),
+			widget.NewFormItem("理由", reasonEntry),
+		},
+		func(confirmed bool) {
+			if !confirmed {
+				return
+			}
+
+			err := sv.useCase.UnlockLockout(context.Background(), usecase.UnlockLockoutRequest{
+				LockoutID: lockout.ID,
+				Reason:    reasonEntry.Text,
+				ActorID:   sv.currentUser.ID,
+			})
+			if err != nil {
+				sv.showError(fmt.Errorf("ロックを解除できませんでした: %w", err))
+				return
+			}
+
+			sv.LoadData()
+		}, sv.parentWindow())
+}
+
+// showManualLockoutDialog locks a username or IP address chosen by the administrator
+func (sv *SecurityView) showManualLockoutDialog() {
+	usernameEntry := widget.NewEntry()
+	usernameEntry.SetPlaceHolder("ユーザー名")
+	ipEntry := widget.NewEntry()
+	ipEntry.SetPlaceHolder("例: 192.0.2.10")
+	durationSelect := newLockoutDurationSelect()
+	reasonEntry := widget.NewMultiLineEntry()
+	reasonEntry.SetPlaceHolder("ロックの理由（必須）")
+
+	dialog.ShowForm("手動ロック", "ロック", "キャンセル",
+		[]*widget.FormItem{
+			widget.NewFormItem("ユーザー名", usernameEntry),
+			widget.NewFormItem("IPアドレス", ipEntry),
+			widget.NewFormItem("ロック時間", durationSelect),
+			widget.NewFormItem("理由", reasonEntry),
+		},
+		func(confirmed bool) {
+			if !confirmed {
+				return
+			}
+
+			_, err := sv.useCase.CreateManualLockout(context.Background(), usecase.ManualLockoutRequest{
+				Username:        usernameEntry.Text,
+				IPAddress:       ipEntry.Text,
+				Reason:          reasonEntry.Text,
+				DurationMinutes: selectedLockoutMinutes(durationSelect),
+				ActorID:         sv.currentUser.ID,
+			})
+			if err != nil {
+				sv.showError(fmt.Errorf("ロックできませんでした: %w", err))
+				return
+			}
+
+			sv.LoadData()
+		}, sv.parentWindow())
+}
+
+// showPatternStatusDialog blocks or resolves the selected attack pattern
+func (sv *SecurityView) showPatternStatusDialog(status string) {
+	if sv.selectedPattern < 0 || sv.selectedPattern >= len(sv.dashboard.AttackPatterns) {
+		return
+	}
+
+	pattern := sv.dashboard.AttackPatterns[sv.selectedPattern]
+	reasonEntry := widget.NewMultiLineEntry()
+	reasonEntry.SetPlaceHolder("理由（必須）")
+
+	items := []*widget.FormItem{
+		widget.NewFormItem("攻撃パターン", widget.NewLabel(attackPatternTypeLabel(pattern.PatternType))),
+		widget.NewFormItem("送信元", widget.NewLabel(strings.ReplaceAll(pattern.SourceIP, ",", ", "))),
+	}
+
+	title, confirm := "攻撃パターンを解決済みにする", "解決済みにする"
+	var durationSelect *widget.Select
+	if status == domain.AttackPatternStatusBlocked {
+		title, confirm = "攻撃パターンをブロック", "ブロック"
+		durationSelect = newLockoutDurationSelect()
+		durationSelect.SetSelected("24時間")
+		items = append(items, widget.NewFormItem("送信元のロック時間", durationSelect))
+	}
+	items = append(items, widget.NewFormItem("理由", reasonEntry))
+
+	dialog.ShowForm(title, confirm, "キャンセル", items,
+		func(confirmed bool) {
+			if !confirmed {
+				return
+			}
+
+			req := usecase.UpdateAttackPatternStatusRequest{
+				PatternID: pattern.ID,
+				Status:    status,
+				Reason:    reasonEntry.Text,
+				ActorID:   sv.currentUser.ID,
+			}
+			if durationSelect != nil {
+				req.DurationMinutes = selectedLockoutMinutes(durationSelect)
+			}
+
+			if err := sv.useCase.UpdateAttackPatternStatus(context.Background(), req); err != nil {
+				sv.showError(fmt.Errorf("攻撃パターンを更新できませんでした: %w", err))
+				return
+			}
+
+			sv.LoadData()
+		}, sv.parentWindow())
+}
+
+// newLockoutDurationSelect creates the lockout duration picker
+func newLockoutDurationSelect() *widget.Select {
+	options := make([]string, len(lockoutDurationOptions))
+	for i, option := range lockoutDurationOptions {
+		options[i] = option.label
+	}
+
+	durationSelect := widget.NewSelect(options, nil)
+	durationSelect.SetSelected("1時間")
+	return durationSelect
+}
+
+// selectedLockoutMinutes returns the duration chosen in a lockout duration picker
+func selectedLockoutMinutes(durationSelect *widget.Select) int {
+	for _, option := range lockoutDurationOptions {
+		if option.label == durationSelect.Selected {
+			return option.minutes
+		}
+	}
+	return 0
+}
+
+// parentWindow returns the main window for dialogs
+func (sv *SecurityView) parentWindow() fyne.Window {
+	return fyne.CurrentApp().Driver().AllWindows()[0]
+}
+
+// showError shows an error dialog on the main window
+func (sv *SecurityView) showError(err error) {
+	if app := fyne.CurrentApp(); app != nil && len(app.Driver().AllWindows()) > 0 {
+		dialog.ShowError(err, app.Driver().AllWindows()[0])
+	}
+}
+
+// CreateObject creates the UI object for the security dashboard
+func (sv *SecurityView) CreateObject() fyne.CanvasObject {
+	if sv.currentUser == nil || sv.currentUser.Role != domain.RoleAdmin {
+		return container.NewCenter(widget.NewLabel("この画面は管理者のみ利用できます。"))
+	}
+
+	header := container.NewBorder(
+		nil, nil,
+		widget.NewLabel("セキュリティ"),
+		sv.refreshButton,
+		sv.summaryLabel,
+	)
+
+	tabs := container.NewAppTabs(
+		container.NewTabItem("ロック中", sv.tableWithHeader(
+			[]string{"ユーザー名", "IPアドレス", "種類", "ロック日時", "解除予定", "理由"},
+			sv.lockoutTable,
+			container.NewHBox(sv.unlockButton, sv.lockButton),
+		)),
+		container.NewTabItem("攻撃パターン", sv.tableWithHeader(
+			[]string{"種類", "重要度", "状態", "送信元", "対象ユーザー", "失敗数", "最終検出"},
+			sv.patternTable,
+			container.NewHBox(sv.blockButton, sv.resolveButton),
+		)),
+		container.NewTabItem("ログイン失敗（24時間）", sv.tableWithHeader(
+			[]string{"日時", "IPアドレス", "ユーザー名", "ユーザーエージェント"},
+			sv.failureTable,
+			nil,
+		)),
+		container.NewTabItem("ロック履歴", sv.tableWithHeader(
+			[]string{"ロック日時", "IPアドレス", "ユーザー名", "種類", "状態", "解除日時", "理由"},
+			sv.historyTable,
+			nil,
+		)),
+		container.NewTabItem("イベント", sv.tableWithHeader(
+			[]string{"日時", "イベント", "IPアドレス", "詳細"},
+			sv.eventTable,
+			nil,
+		)),
+	)
+	if sv.policyPanel != nil {
+		tabs.Append(container.NewTabItem("レート制限設定", sv.policyPanel.CreateObject()))
+	}
+
+	return container.NewBorder(
+		header,
+		nil, nil, nil,
+		tabs,
+	)
+}
+
+// tableWithHeader lays out a table under a bold header row, with optional actions on top
+func (sv *SecurityView) tableWithHeader(headers []string, table *widget.Table, actions fyne.CanvasObject) fyne.CanvasObject {
+	headerWidgets := make([]fyne.CanvasObject, len(headers))
+	for i, header := range headers {
+		label := widget.NewLabel(header)
+		label.TextStyle.Bold = true
+		headerWidgets[i] = label
+	}
+
+	top := fyne.CanvasObject(container.NewHBox(headerWidgets...))
+	if actions != nil {
+		top = container.NewVBox(actions, top)
+	}
+
+	return container.NewBorder(top, nil, nil, nil, table)
+}
+
+// Cell formatting
+
+func lockoutCell(lockout *domain.AccountLockout, col int) string {
+	switch col {
+	case 0:
+		return valueOrDash(lockout.Username)
+	case 1:
+		return valueOrDash(lockout.IPAddress)
+	case 2:
+		return lockoutTypeLabel(lockout.LockoutType)
+	case 3:
+		return formatSecurityTime(lockout.LockedAt)
+	case 4:
+		if lockout.Duration <= 0 {
+			return "無期限"
+		}
+		return formatSecurityTime(lockout.LockedAt.Add(time.Duration(lockout.Duration) * time.Second))
+	case 5:
+		return lockout.Reason
+	default:
+		return ""
+	}
+}
+
+func historyCell(lockout *domain.AccountLockout, col int) string {
+	switch col {
+	case 0:
+		return formatSecurityTime(lockout.LockedAt)
+	case 1:
+		return valueOrDash(lockout.IPAddress)
+	case 2:
+		return valueOrDash(lockout.Username)
+	case 3:
+		return lockoutTypeLabel(lockout.LockoutType)
+	case 4:
+		return lockoutStatusLabel(lockout, time.Now())
+	case 5:
+		if lockout.UnlockedAt == nil {
+			return "-"
+		}
+		return formatSecurityTime(*lockout.UnlockedAt)
+	case 6:
+		return lockout.Reason
+	default:
+		return ""
+	}
+}
+
+func patternCell(pattern *domain.AttackPattern, col int) string {
+	switch col {
+	case 0:
+		return attackPatternTypeLabel(pattern.PatternType)
+	case 1:
+		return attackSeverityLabel(pattern.Severity)
+	case 2:
+		return attackPatternStatusLabel(pattern.Status)
+	case 3:
+		return strings.ReplaceAll(pattern.SourceIP, ",", ", ")
+	case 4:
+		return strings.Join(pattern.TargetUsernames, ", ")
+	case 5:
+		return fmt.Sprintf("%d", pattern.AttemptsCount)
+	case 6:
+		return formatSecurityTime(pattern.LastDetectedAt)
+	default:
+		return ""
+	}
+}
+
+func failureCell(attempt *domain.LoginAttempt, col int) string {
+	switch col {
+	case 0:
+		return formatSecurityTime(attempt.AttemptedAt)
+	case 1:
+		return attempt.IPAddress
+	case 2:
+		return attempt.Username
+	case 3:
+		return valueOrDash(attempt.UserAgent)
+	default:
+		return ""
+	}
+}
+
+func eventCell(event *domain.AuditLog, col int) string {
+	switch col {
+	case 0:
+		return formatSecurityTime(event.At)
+	case 1:
+		return securityEventLabel(event.Action)
+	case 2:
+		return valueOrDash(event.IP)
+	case 3:
+		return event.Details
+	default:
+		return ""
+	}
+}
+
+// lockoutStatusLabel describes where a lockout is in its lifecycle
+func lockoutStatusLabel(lockout *domain.AccountLockout, now time.Time) string {
+	switch {
+	case lockout.UnlockedAt != nil:
+		return "解除済み"
+	case lockout.Duration > 0 && now.After(lockout.LockedAt.Add(time.Duration(lockout.Duration)*time.Second)):
+		return "期限切れ"
+	default:
+		return "ロック中"
+	}
+}
+
+func lockoutSubject(lockout *domain.AccountLockout) string {
+	if lockout.Username != "" && lockout.IPAddress != "" {
+		return fmt.Sprintf("%s（%s）", lockout.Username, lockout.IPAddress)
+	}
+	if lockout.Username != "" {
+		return lockout.Username
+	}
+	return lockout.IPAddress
+}
+
+func lockoutTypeLabel(lockoutType domain.LockoutType) string {
+	switch lockoutType {
+	case domain.LockoutTypeAccount:
+		return "アカウント"
+	case domain.LockoutTypeIP:
+		return "IP"
+	case domain.LockoutTypeMixed:
+		return "複合"
+	case domain.LockoutTypeManual:
+		return "手動"
+	default:
+		return string(lockoutType)
+	}
+}
+
+func attackPatternTypeLabel(patternType string) string {
+	switch patternType {
+	case domain.AttackPatternTypeDistributed:
+		return "分散型総当たり"
+	case domain.AttackPatternTypeCredentialStuffing:
+		return "リスト型攻撃"
+	default:
+		return patternType
+	}
+}
+
+func attackSeverityLabel(severity string) string {
+	switch severity {
+	case domain.AttackSeverityLow:
+		return "低"
+	case domain.AttackSeverityMedium:
+		return "中"
+	case domain.AttackSeverityHigh:
+		return "高"
+	case domain.AttackSeverityCritical:
+		return "重大"
+	default:
+		return severity
+	}
+}
+
+func attackPatternStatusLabel(status string) string {
+	switch status {
+	case domain.AttackPatternStatusActive:
+		return "未対応"
+	case domain.AttackPatternStatusBlocked:
+		return "ブロック中"
+	case domain.AttackPatternStatusResolved:
+		return "解決済み"
+	default:
+		return status
+	}
+}
+
+func securityEventLabel(action string) string {
+	switch action {
+	case "IP_LOCKOUT_TRIGGERED":
+		return "IPロック"
+	case "ACCOUNT_LOCKOUT_TRIGGERED":
+		return "アカウントロック"
+	case "MANUAL_LOCKOUT_CREATED":
+		return "手動ロック"
+	case "MANUAL_UNLOCK_PERFORMED":
+		return "手動解除"
+	case "LOCKOUT_UNLOCK_FAILED":
+		return "ロック解除失敗"
+	case "ATTACK_PATTERN_DETECTED":
+		return "攻撃パターン検出"
+	case "ATTACK_PATTERN_BLOCKED":
+		return "攻撃パターンをブロック"
+	case "ATTACK_PATTERN_RESOLVED":
+		return "攻撃パターンを解決"
+	case "RATE_LIMIT_POLICY_UPDATED":
+		return "レート制限設定を変更"
+	case "RATE_LIMIT_POLICY_FILE_APPLIED":
+		return "config.yaml の設定を適用"
+	case "SESSION_REVOKED":
+		return "セッションを終了"
+	case "SESSIONS_REVOKED_ALL":
+		return "全端末からログアウト"
+	case "INTEGRITY_CHECK":
+		return "データベース点検"
+	case "INTEGRITY_QUARANTINE":
+		return "復号できない行を隔離"
+	case "INTEGRITY_RESTORE":
+		return "バックアップから復元"
+	default:
+		return action
+	}
+}
+
+func formatSecurityTime(t time.Time) string {
+	return t.Local().Format("2006/01/02 15:04")
+}
+
+func valueOrDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
+
+// Length returns the number of active lockouts shown (for testing)
+func (sv *SecurityView) Length() int {
+	return len(sv.dashboard.ActiveLockouts)
+}
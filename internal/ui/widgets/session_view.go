@@ -0,0 +1,305 @@
+package widgets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"shien-system/internal/domain"
+	"shien-system/internal/usecase"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+// SessionView lists login sessions and lets them be ended remotely. Staff see
+// their own sessions; administrators see every user's.
+type SessionView struct {
+	useCase          usecase.SessionUseCase
+	currentUser      *domain.Staff
+	currentSessionID string
+	onCurrentRevoked func() // Called when the window's own session was ended
+
+	// UI components
+	summaryLabel  *widget.Label
+	sessionTable  *widget.Table
+	revokeButton  *widget.Button
+	revokeAllBtn  *widget.Button
+	refreshButton *widget.Button
+
+	// Data
+	sessions []*usecase.SessionSummary
+	selected int
+}
+
+// NewSessionView creates a new SessionView widget
+func NewSessionView(useCase usecase.SessionUseCase, currentUser *domain.Staff, currentSessionID string, onCurrentRevoked func()) *SessionView {
+	sv := &SessionView{
+		useCase:          useCase,
+		currentUser:      currentUser,
+		currentSessionID: currentSessionID,
+		onCurrentRevoked: onCurrentRevoked,
+		selected:         -1,
+	}
+
+	sv.createWidgets()
+
+	return sv
+}
+
+// createWidgets initializes all UI components
+func (sv *SessionView) createWidgets() {
+	sv.summaryLabel = widget.NewLabel("")
+
+	sv.sessionTable = newSecurityTable(
+		[]float32{140, 130, 130, 130, 130, 220, 80},
+		func() int { return len(sv.sessions) },
+		func(row, col int) string { return sessionCell(sv.sessions[row], col) },
+	)
+	sv.sessionTable.OnSelected = func(id widget.TableCellID) {
+		sv.selected = id.Row
+		sv.updateButtons()
+	}
+
+	sv.revokeButton = widget.NewButton("セッションを終了", func() {
+		sv.showRevokeDialog()
+	})
+	sv.revokeButton.Disable()
+
+	sv.revokeAllBtn = widget.NewButton(sv.revokeAllLabel(), func() {
+		sv.showRevokeAllDialog()
+	})
+	if sv.isAdmin() {
+		sv.revokeAllBtn.Disable()
+	}
+
+	sv.refreshButton = widget.NewButton("更新", func() {
+		sv.LoadData()
+	})
+}
+
+// LoadData reloads the session list
+func (sv *SessionView) LoadData() {
+	if sv.currentUser == nil {
+		return
+	}
+
+	sessions, err := sv.useCase.ListSessions(context.Background(), usecase.ListSessionsRequest{
+		CurrentSessionID: sv.currentSessionID,
+		ActorID:          sv.currentUser.ID,
+	})
+	if err != nil {
+		sv.showError(fmt.Errorf("セッション一覧の読み込みに失敗しました: %w", err))
+		return
+	}
+
+	sv.sessions = sessions
+	sv.selected = -1
+	sv.updateButtons()
+
+	sv.summaryLabel.SetText(fmt.Sprintf("ログイン中のセッション: %d件", len(sessions)))
+
+	sv.sessionTable.UnselectAll()
+	sv.sessionTable.Refresh()
+}
+
+// updateButtons enables the actions allowed for the selected session
+func (sv *SessionView) updateButtons() {
+	if sv.selected < 0 || sv.selected >= len(sv.sessions) {
+		sv.revokeButton.Disable()
+		if sv.isAdmin() {
+			sv.revokeAllBtn.Disable()
+		}
+		return
+	}
+
+	sv.revokeButton.Enable()
+	sv.revokeAllBtn.Enable()
+}
+
+// showRevokeDialog ends the selected session
+func (sv *SessionView) showRevokeDialog() {
+	if sv.selected < 0 || sv.selected >= len(sv.sessions) {
+		return
+	}
+
+	session := sv.sessions[sv.selected]
+	reasonEntry := widget.NewMultiLineEntry()
+	if session.UserID == sv.currentUser.ID {
+		reasonEntry.SetPlaceHolder("理由（任意）")
+	} else {
+		reasonEntry.SetPlaceHolder("終了の理由（必須）")
+	}
+
+	items := []*widget.FormItem{
+		widget.NewFormItem("対象", widget.NewLabel(sessionSubject(session))),
+		widget.NewFormItem("理由", reasonEntry),
+	}
+	if session.Current {
+		items = append(items, widget.NewFormItem("", widget.NewLabel("この端末のセッションです。終了するとログアウトします。")))
+	}
+
+	dialog.ShowForm("セッションを終了", "終了", "キャンセル", items,
+		func(confirmed bool) {
+			if !confirmed {
+				return
+			}
+
+			err := sv.useCase.RevokeSession(context.Background(), usecase.RevokeSessionRequest{
+				SessionID: session.ID,
+				Reason:    reasonEntry.Text,
+				ActorID:   sv.currentUser.ID,
+			})
+			if err != nil && !errors.Is(err, usecase.ErrSessionNotFound) {
+				sv.showError(fmt.Errorf("セッションを終了できませんでした: %w", err))
+				return
+			}
+
+			if session.Current && sv.onCurrentRevoked != nil {
+				sv.onCurrentRevoked()
+				return
+			}
+
+			sv.LoadData()
+		}, sv.parentWindow())
+}
+
+// showRevokeAllDialog logs the selected user (administrators) or the current
+// user's other devices out everywhere
+func (sv *SessionView) showRevokeAllDialog() {
+	// The window's own session stays open unless an administrator logs out
+	// another user
+	req := usecase.RevokeAllSessionsRequest{
+		UserID:        sv.currentUser.ID,
+		KeepSessionID: sv.currentSessionID,
+		ActorID:       sv.currentUser.ID,
+	}
+	subject := "この端末以外のすべてのセッション"
+
+	if sv.isAdmin() {
+		if sv.selected < 0 || sv.selected >= len(sv.sessions) {
+			return
+		}
+		if session := sv.sessions[sv.selected]; session.UserID != sv.currentUser.ID {
+			req.UserID = session.UserID
+			req.KeepSessionID = ""
+			subject = fmt.Sprintf("%s のすべてのセッション", sessionSubject(session))
+		}
+	}
+
+	reasonEntry := widget.NewMultiLineEntry()
+	if req.UserID == sv.currentUser.ID {
+		reasonEntry.SetPlaceHolder("理由（任意）")
+	} else {
+		reasonEntry.SetPlaceHolder("理由（必須。例: パスワード変更）")
+	}
+
+	dialog.ShowForm(sv.revokeAllLabel(), "ログアウト", "キャンセル",
+		[]*widget.FormItem{
+			widget.NewFormItem("対象", widget.NewLabel(subject)),
+			widget.NewFormItem("理由", reasonEntry),
+		},
+		func(confirmed bool) {
+			if !confirmed {
+				return
+			}
+
+			req.Reason = reasonEntry.Text
+			count, err := sv.useCase.RevokeAllSessions(context.Background(), req)
+			if err != nil {
+				sv.showError(fmt.Errorf("ログアウトできませんでした: %w", err))
+				return
+			}
+
+			dialog.ShowInformation("ログアウト", fmt.Sprintf("%d件のセッションを終了しました。", count), sv.parentWindow())
+			sv.LoadData()
+		}, sv.parentWindow())
+}
+
+func (sv *SessionView) revokeAllLabel() string {
+	if sv.isAdmin() {
+		return "全端末からログアウト"
+	}
+	return "他の端末をすべてログアウト"
+}
+
+func (sv *SessionView) isAdmin() bool {
+	return sv.currentUser != nil && sv.currentUser.Role == domain.RoleAdmin
+}
+
+// parentWindow returns the main window for dialogs
+func (sv *SessionView) parentWindow() fyne.Window {
+	return fyne.CurrentApp().Driver().AllWindows()[0]
+}
+
+// showError shows an error dialog on the main window
+func (sv *SessionView) showError(err error) {
+	if app := fyne.CurrentApp(); app != nil && len(app.Driver().AllWindows()) > 0 {
+		dialog.ShowError(err, app.Driver().AllWindows()[0])
+	}
+}
+
+// CreateObject creates the UI object for the session list
+func (sv *SessionView) CreateObject() fyne.CanvasObject {
+	header := container.NewBorder(
+		nil, nil,
+		widget.NewLabel("ログイン中の端末"),
+		sv.refreshButton,
+		sv.summaryLabel,
+	)
+
+	headers := []string{"ユーザー", "作成日時", "最終アクセス", "有効期限", "接続元", "クライアント", "状態"}
+	headerWidgets := make([]fyne.CanvasObject, len(headers))
+	for i, text := range headers {
+		label := widget.NewLabel(text)
+		label.TextStyle.Bold = true
+		headerWidgets[i] = label
+	}
+
+	top := container.NewVBox(
+		header,
+		container.NewHBox(sv.revokeButton, sv.revokeAllBtn),
+		container.NewHBox(headerWidgets...),
+	)
+
+	return container.NewBorder(top, nil, nil, nil, sv.sessionTable)
+}
+
+// Cell formatting
+
+func sessionCell(session *usecase.SessionSummary, col int) string {
+	switch col {
+	case 0:
+		return sessionSubject(session)
+	case 1:
+		return formatSecurityTime(session.CreatedAt)
+	case 2:
+		return formatSecurityTime(session.LastAccessedAt)
+	case 3:
+		return formatSecurityTime(session.ExpiresAt)
+	case 4:
+		return valueOrDash(session.ClientIP)
+	case 5:
+		return valueOrDash(session.UserAgent)
+	case 6:
+		if session.Current {
+			return "この端末"
+		}
+		return ""
+	}
+	return ""
+}
+
+func sessionSubject(session *usecase.SessionSummary) string {
+	if session.UserName != "" {
+		return session.UserName
+	}
+	return string(session.UserID)
+}
+
+// Length returns the number of sessions shown (for testing)
+func (sv *SessionView) Length() int {
+	return len(sv.sessions)
+}
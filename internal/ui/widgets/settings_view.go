@@ -1,15 +1,21 @@
 package widgets
 
 import (
+	"context"
+	"errors"
 	"fmt"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/container"
 	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
 	"fyne.io/fyne/v2/widget"
 	"fyne.io/fyne/v2/layout"
 
 	"shien-system/internal/config"
+	"shien-system/internal/domain"
+	"shien-system/internal/usecase"
+	"shien-system/internal/wareki"
 )
 
 // SettingsView represents the application settings interface
@@ -32,6 +38,25 @@ type SettingsView struct {
 	backupPathEntry       *widget.Entry
 	backupPathBrowseButton *widget.Button
 
+	// Display settings of the logged-in user, stored with the staff record
+	displayGroup    *widget.Card
+	dateStyleSelect *widget.Select
+	staffUseCase    usecase.StaffUseCase
+	currentUser     *domain.Staff
+
+	// Postal code dictionary, imported by administrators
+	postalGroup        *widget.Card
+	postalCountLabel   *widget.Label
+	postalImportButton *widget.Button
+	postalCodeUseCase  usecase.PostalCodeUseCase
+
+	// Municipality and service type masters, imported by administrators
+	masterGroup              *widget.Card
+	masterCountLabel         *widget.Label
+	municipalityImportButton *widget.Button
+	serviceTypeImportButton  *widget.Button
+	masterDataUseCase        usecase.MasterDataUseCase
+
 	// Control buttons
 	saveButton   *widget.Button
 	resetButton  *widget.Button
@@ -108,6 +133,50 @@ func (sv *SettingsView) createWidgets() {
 		),
 	)
 
+	// Display settings
+	dateStyleLabels := make([]string, 0, len(wareki.Styles))
+	for _, style := range wareki.Styles {
+		dateStyleLabels = append(dateStyleLabels, style.Label())
+	}
+	sv.dateStyleSelect = widget.NewSelect(dateStyleLabels, nil)
+	sv.dateStyleSelect.Disable()
+
+	sv.displayGroup = widget.NewCard("表示設定", "ログイン中の職員ごとに保存されます",
+		container.NewVBox(
+			widget.NewLabel("日付の表示形式（入力は西暦・和暦のどちらでも可能です）"),
+			sv.dateStyleSelect,
+		),
+	)
+
+	// Postal code dictionary
+	sv.postalCountLabel = widget.NewLabel("")
+	sv.postalImportButton = widget.NewButton("KEN_ALL.CSV を取り込む...", func() {
+		sv.importPostalCodes()
+	})
+
+	sv.postalGroup = widget.NewCard("郵便番号辞書", "日本郵便の KEN_ALL.CSV（Shift_JIS・UTF-8）を取り込むと、郵便番号から住所を補完します",
+		container.NewVBox(
+			sv.postalCountLabel,
+			sv.postalImportButton,
+		),
+	)
+
+	// Municipality and service type masters
+	sv.masterCountLabel = widget.NewLabel("")
+	sv.municipalityImportButton = widget.NewButton("市町村マスタを取り込む...", func() {
+		sv.importMasterData("市町村マスタ", "市町村", sv.masterDataUseCase.ImportMunicipalities)
+	})
+	sv.serviceTypeImportButton = widget.NewButton("サービス種類マスタを取り込む...", func() {
+		sv.importMasterData("サービス種類マスタ", "サービス種類", sv.masterDataUseCase.ImportServiceTypes)
+	})
+
+	sv.masterGroup = widget.NewCard("受給者証マスタ", "市町村（市町村番号・都道府県・市町村名）とサービス種類（コード・名称）のCSVを取り込むと、受給者証の入力で選択できます",
+		container.NewVBox(
+			sv.masterCountLabel,
+			container.NewHBox(sv.municipalityImportButton, sv.serviceTypeImportButton),
+		),
+	)
+
 	// Control buttons
 	sv.saveButton = widget.NewButton("設定を保存", nil)
 	sv.resetButton = widget.NewButton("デフォルトに戻す", nil)
@@ -159,6 +228,10 @@ func (sv *SettingsView) setupEventHandlers() {
 		sv.showBackupPathDialog()
 	}
 
+	sv.dateStyleSelect.OnChanged = func(string) {
+		sv.hasChanges = true
+	}
+
 	// Control button handlers
 	sv.saveButton.OnTapped = sv.handleSave
 	sv.resetButton.OnTapped = sv.handleReset
@@ -201,6 +274,8 @@ func (sv *SettingsView) loadCurrentSettings() {
 
 	sv.backupPathEntry.SetText(sv.config.Backup.BackupDir)
 
+	sv.dateStyleSelect.SetSelected(dateStyleOf(sv.currentUser).Label())
+
 	// Enable/disable backup settings based on backup enabled state
 	if !sv.config.Backup.Enabled {
 		sv.backupIntervalSelect.Disable()
@@ -251,6 +326,12 @@ func (sv *SettingsView) handleSave() {
 		return
 	}
 
+	// The date style belongs to the staff record, not to the config file
+	if err := sv.saveDateStyle(); err != nil {
+		sv.showError("保存エラー", err)
+		return
+	}
+
 	// Apply settings to config
 	sv.applySettings()
 
@@ -268,6 +349,31 @@ func (sv *SettingsView) handleSave() {
 	}
 }
 
+// saveDateStyle stores the selected date style for the current user
+func (sv *SettingsView) saveDateStyle() error {
+	if sv.staffUseCase == nil || sv.currentUser == nil {
+		return nil
+	}
+
+	style := wareki.StyleFromLabel(sv.dateStyleSelect.Selected)
+	if style == dateStyleOf(sv.currentUser) {
+		return nil
+	}
+
+	updated, err := sv.staffUseCase.UpdateDateStyle(context.Background(), usecase.UpdateDateStyleRequest{
+		DateStyle: string(style),
+		ActorID:   sv.currentUser.ID,
+	})
+	if err != nil {
+		return fmt.Errorf("日付の表示形式の保存に失敗しました: %v", err)
+	}
+
+	// Screens share the current user, so they pick up the new style on their next refresh
+	sv.currentUser.DateStyle = updated.DateStyle
+	sv.currentUser.Version = updated.Version
+	return nil
+}
+
 // handleReset resets all settings to defaults
 func (sv *SettingsView) handleReset() {
 	dialog.ShowConfirm("設定をリセット", 
@@ -362,6 +468,7 @@ func (sv *SettingsView) resetToDefaults() {
 	sv.backupEnabledCheck.SetChecked(true)
 	sv.backupIntervalSelect.SetSelected("毎日")
 	sv.backupPathEntry.SetText("./backups")
+	sv.dateStyleSelect.SetSelected(wareki.StyleGregorian.Label())
 
 	sv.hasChanges = true
 }
@@ -372,7 +479,7 @@ func (sv *SettingsView) showError(title string, err error) {
 	if err != nil {
 		message = fmt.Sprintf("%s\n\nエラー詳細: %v", title, err)
 	}
-	dialog.ShowError(fmt.Errorf(message), nil)
+	dialog.ShowError(errors.New(message), nil)
 }
 
 // showInfo displays an information message
@@ -385,8 +492,15 @@ func (sv *SettingsView) CreateObject() fyne.CanvasObject {
 	// Create main content with scrolling
 	content := container.NewVBox(
 		sv.themeGroup,
+		sv.displayGroup,
 		sv.applicationGroup,
 	)
+	if sv.canImportPostalCodes() {
+		content.Add(sv.postalGroup)
+	}
+	if sv.canImportMasterData() {
+		content.Add(sv.masterGroup)
+	}
 
 	scrollContent := container.NewScroll(content)
 	scrollContent.SetMinSize(content.MinSize())
@@ -409,6 +523,158 @@ func (sv *SettingsView) CreateObject() fyne.CanvasObject {
 	)
 }
 
+// SetStaffUseCase enables the per-user display settings of currentUser
+func (sv *SettingsView) SetStaffUseCase(staffUseCase usecase.StaffUseCase, currentUser *domain.Staff) {
+	sv.staffUseCase = staffUseCase
+	sv.currentUser = currentUser
+	sv.dateStyleSelect.SetSelected(dateStyleOf(currentUser).Label())
+	sv.dateStyleSelect.Enable()
+	sv.hasChanges = false
+}
+
+// SetPostalCodeUseCase enables the postal code dictionary import for
+// administrators; call after SetStaffUseCase
+func (sv *SettingsView) SetPostalCodeUseCase(postalCodeUseCase usecase.PostalCodeUseCase) {
+	sv.postalCodeUseCase = postalCodeUseCase
+	sv.refreshPostalCount()
+}
+
+// canImportPostalCodes reports whether the dictionary card should be offered
+func (sv *SettingsView) canImportPostalCodes() bool {
+	return sv.postalCodeUseCase != nil && sv.currentUser != nil && sv.currentUser.Role == domain.RoleAdmin
+}
+
+// refreshPostalCount shows the number of dictionary entries
+func (sv *SettingsView) refreshPostalCount() {
+	if sv.postalCodeUseCase == nil {
+		return
+	}
+	count, err := sv.postalCodeUseCase.DictionarySize(context.Background())
+	switch {
+	case err != nil:
+		sv.postalCountLabel.SetText("登録件数を取得できませんでした")
+	case count == 0:
+		sv.postalCountLabel.SetText("未登録（郵便番号は形式のみ確認します）")
+	default:
+		sv.postalCountLabel.SetText(fmt.Sprintf("登録件数: %d件", count))
+	}
+}
+
+// importPostalCodes replaces the dictionary with a KEN_ALL.CSV chosen by the user
+func (sv *SettingsView) importPostalCodes() {
+	if !sv.canImportPostalCodes() {
+		return
+	}
+
+	parent := fyne.CurrentApp().Driver().AllWindows()[0]
+
+	openDialog := dialog.NewFileOpen(func(reader fyne.URIReadCloser, err error) {
+		if err != nil {
+			dialog.ShowError(fmt.Errorf("ファイルを開けませんでした: %w", err), parent)
+			return
+		}
+		if reader == nil {
+			return // User cancelled
+		}
+		defer reader.Close()
+
+		sv.postalImportButton.Disable()
+		defer sv.postalImportButton.Enable()
+
+		count, err := sv.postalCodeUseCase.ImportDictionary(context.Background(), usecase.ImportPostalCodesRequest{
+			Source:  reader,
+			ActorID: sv.currentUser.ID,
+		})
+		if err != nil {
+			dialog.ShowError(fmt.Errorf("郵便番号辞書の取り込みに失敗しました: %w", err), parent)
+			return
+		}
+
+		sv.refreshPostalCount()
+		dialog.ShowInformation("郵便番号辞書", fmt.Sprintf("%d件の郵便番号を取り込みました。", count), parent)
+	}, parent)
+	openDialog.SetFilter(storage.NewExtensionFileFilter([]string{".csv", ".CSV"}))
+	openDialog.Show()
+}
+
+// SetMasterDataUseCase enables the municipality and service type master
+// imports for administrators; call after SetStaffUseCase
+func (sv *SettingsView) SetMasterDataUseCase(masterDataUseCase usecase.MasterDataUseCase) {
+	sv.masterDataUseCase = masterDataUseCase
+	sv.refreshMasterCount()
+}
+
+// canImportMasterData reports whether the master card should be offered
+func (sv *SettingsView) canImportMasterData() bool {
+	return sv.masterDataUseCase != nil && sv.currentUser != nil && sv.currentUser.Role == domain.RoleAdmin
+}
+
+// refreshMasterCount shows the number of entries in each master
+func (sv *SettingsView) refreshMasterCount() {
+	if sv.masterDataUseCase == nil {
+		return
+	}
+	ctx := context.Background()
+	municipalities, err := sv.masterDataUseCase.ListMunicipalities(ctx)
+	if err != nil {
+		sv.masterCountLabel.SetText("登録件数を取得できませんでした")
+		return
+	}
+	serviceTypes, err := sv.masterDataUseCase.ListServiceTypes(ctx)
+	if err != nil {
+		sv.masterCountLabel.SetText("登録件数を取得できませんでした")
+		return
+	}
+	sv.masterCountLabel.SetText(fmt.Sprintf("市町村: %s　サービス種類: %s",
+		masterCountText(len(municipalities)), masterCountText(len(serviceTypes))))
+}
+
+func masterCountText(count int) string {
+	if count == 0 {
+		return "未登録（コードは形式のみ確認します）"
+	}
+	return fmt.Sprintf("%d件", count)
+}
+
+// importMasterData replaces a master with a CSV chosen by the user
+func (sv *SettingsView) importMasterData(name, unit string, importFn func(context.Context, usecase.ImportMasterDataRequest) (int, error)) {
+	if !sv.canImportMasterData() {
+		return
+	}
+
+	parent := fyne.CurrentApp().Driver().AllWindows()[0]
+
+	openDialog := dialog.NewFileOpen(func(reader fyne.URIReadCloser, err error) {
+		if err != nil {
+			dialog.ShowError(fmt.Errorf("ファイルを開けませんでした: %w", err), parent)
+			return
+		}
+		if reader == nil {
+			return // User cancelled
+		}
+		defer reader.Close()
+
+		sv.municipalityImportButton.Disable()
+		sv.serviceTypeImportButton.Disable()
+		defer sv.municipalityImportButton.Enable()
+		defer sv.serviceTypeImportButton.Enable()
+
+		count, err := importFn(context.Background(), usecase.ImportMasterDataRequest{
+			Source:  reader,
+			ActorID: sv.currentUser.ID,
+		})
+		if err != nil {
+			dialog.ShowError(fmt.Errorf("%sの取り込みに失敗しました: %w", name, err), parent)
+			return
+		}
+
+		sv.refreshMasterCount()
+		dialog.ShowInformation(name, fmt.Sprintf("%d件の%sを取り込みました。", count, unit), parent)
+	}, parent)
+	openDialog.SetFilter(storage.NewExtensionFileFilter([]string{".csv", ".CSV"}))
+	openDialog.Show()
+}
+
 // SetOnSaved sets the callback for when settings are saved
 func (sv *SettingsView) SetOnSaved(callback func()) {
 	sv.onSaved = callback
@@ -0,0 +1,208 @@
+package widgets
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"time"
+
+	"shien-system/internal/adapter/spreadsheet"
+	"shien-system/internal/domain"
+	"shien-system/internal/usecase"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/widget"
+)
+
+// Format options of the spreadsheet export dialog
+const (
+	spreadsheetFormatXLSX = "Excel（.xlsx）"
+	spreadsheetFormatCSV  = "CSV（.csv）"
+)
+
+// spreadsheetExport describes a list about to be exported to CSV or Excel
+type spreadsheetExport struct {
+	report string              // Report name for the audit log and sheet, e.g. 利用者一覧
+	target string              // Audit target, e.g. report:recipient_list
+	table  usecase.ExportTable // Column catalog of the list
+	count  int                 // Number of rows, as filtered on screen
+	cell   func(row int, key string) interface{}
+}
+
+// exportSpreadsheet lets the user choose the format and the columns allowed
+// for their role, then records the export and writes the file
+func exportSpreadsheet(parent fyne.Window, exportUseCase usecase.ExportUseCase, currentUser *domain.Staff, export spreadsheetExport) {
+	if exportUseCase == nil || currentUser == nil {
+		dialog.ShowError(fmt.Errorf("出力記録が利用できないため、ファイルを出力できません"), parent)
+		return
+	}
+	if export.count == 0 {
+		dialog.ShowInformation("情報", fmt.Sprintf("エクスポートする%sのデータがありません。", export.report), parent)
+		return
+	}
+
+	columns, err := exportUseCase.SpreadsheetColumns(context.Background(), export.table, currentUser.ID)
+	if err != nil {
+		dialog.ShowError(fmt.Errorf("出力できる列を取得できませんでした: %w", err), parent)
+		return
+	}
+
+	formatRadio := widget.NewRadioGroup([]string{spreadsheetFormatXLSX, spreadsheetFormatCSV}, nil)
+	formatRadio.Horizontal = true
+	formatRadio.Required = true
+	formatRadio.SetSelected(spreadsheetFormatXLSX)
+
+	labels := make([]string, len(columns))
+	for i, column := range columns {
+		labels[i] = column.Label
+	}
+	columnCheck := widget.NewCheckGroup(labels, nil)
+	columnCheck.SetSelected(labels)
+
+	notice := widget.NewLabel(fmt.Sprintf("画面の絞り込み条件のまま%d件を出力します。出力は監査ログに記録されます。", export.count))
+	notice.Wrapping = fyne.TextWrapWord
+	if currentUser.Role == domain.RoleReadOnly {
+		notice.SetText(notice.Text + "\n閲覧専用ユーザーは個人情報の列を出力できません。")
+	}
+
+	items := []*widget.FormItem{
+		widget.NewFormItem("形式", formatRadio),
+		widget.NewFormItem("列", columnCheck),
+		widget.NewFormItem("", notice),
+	}
+
+	dialog.ShowForm(export.report+"のExcel・CSV出力", "出力", "キャンセル", items, func(confirmed bool) {
+		if !confirmed {
+			return
+		}
+
+		selected := selectedExportColumns(columns, columnCheck.Selected)
+		if len(selected) == 0 {
+			dialog.ShowError(fmt.Errorf("出力する列を選択してください"), parent)
+			return
+		}
+		format := usecase.ExportFormatXLSX
+		if formatRadio.Selected == spreadsheetFormatCSV {
+			format = usecase.ExportFormatCSV
+		}
+
+		data, err := renderSpreadsheet(export, format, selected)
+		if err != nil {
+			dialog.ShowError(fmt.Errorf("ファイルの作成に失敗しました: %w", err), parent)
+			return
+		}
+
+		saveSpreadsheet(parent, exportUseCase, currentUser, export, format, selected, data)
+	}, parent)
+}
+
+// selectedExportColumns keeps the catalog order of the checked columns
+func selectedExportColumns(columns []usecase.ExportColumn, labels []string) []usecase.ExportColumn {
+	checked := make(map[string]bool, len(labels))
+	for _, label := range labels {
+		checked[label] = true
+	}
+
+	var selected []usecase.ExportColumn
+	for _, column := range columns {
+		if checked[column.Label] {
+			selected = append(selected, column)
+		}
+	}
+	return selected
+}
+
+// renderSpreadsheet writes the rows of the export with the selected columns
+func renderSpreadsheet(export spreadsheetExport, format usecase.ExportFormat, columns []usecase.ExportColumn) ([]byte, error) {
+	header := make([]string, len(columns))
+	for i, column := range columns {
+		header[i] = column.Label
+	}
+	rows := make([][]interface{}, export.count)
+	for i := range rows {
+		rows[i] = make([]interface{}, len(columns))
+		for j, column := range columns {
+			rows[i][j] = export.cell(i, column.Key)
+		}
+	}
+
+	var buf bytes.Buffer
+	writer := spreadsheet.NewWriter()
+	var err error
+	if format == usecase.ExportFormatCSV {
+		err = writer.WriteCSV(&buf, header, rows)
+	} else {
+		err = writer.WriteXLSX(&buf, export.report, header, rows)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// saveSpreadsheet lets the user pick a destination, records the export and writes the file.
+// When the export cannot be recorded the empty file is removed again.
+func saveSpreadsheet(parent fyne.Window, exportUseCase usecase.ExportUseCase, currentUser *domain.Staff, export spreadsheetExport, format usecase.ExportFormat, columns []usecase.ExportColumn, data []byte) {
+	keys := make([]string, len(columns))
+	for i, column := range columns {
+		keys[i] = column.Key
+	}
+
+	saveDialog := dialog.NewFileSave(func(writer fyne.URIWriteCloser, err error) {
+		if err != nil {
+			dialog.ShowError(fmt.Errorf("ファイルの保存に失敗しました: %w", err), parent)
+			return
+		}
+		if writer == nil {
+			return // User cancelled
+		}
+
+		_, err = exportUseCase.RecordExport(context.Background(), usecase.RecordExportRequest{
+			Report:   export.report,
+			Target:   export.target,
+			Format:   format,
+			Table:    export.table,
+			Columns:  keys,
+			FileName: writer.URI().Name(),
+			Data:     data,
+			Count:    export.count,
+			ActorID:  currentUser.ID,
+		})
+		if err != nil {
+			writer.Close()
+			_ = storage.Delete(writer.URI())
+			dialog.ShowError(fmt.Errorf("ファイルを出力できませんでした: %w", err), parent)
+			return
+		}
+		defer writer.Close()
+
+		if _, err := writer.Write(data); err != nil {
+			dialog.ShowError(fmt.Errorf("ファイルの書き込みに失敗しました: %w", err), parent)
+			return
+		}
+
+		dialog.ShowInformation("成功", fmt.Sprintf("%sを出力しました（%d件・%d列）。", export.report, export.count, len(columns)), parent)
+	}, parent)
+
+	saveDialog.SetFileName(fmt.Sprintf("%s_%s.%s", export.report, time.Now().Format("20060102_150405"), format))
+	saveDialog.SetFilter(storage.NewExtensionFileFilter([]string{"." + string(format)}))
+	saveDialog.Show()
+}
+
+// formatPresence shows a yes/no value the way the forms do
+func formatPresence(value bool) string {
+	if value {
+		return "有"
+	}
+	return "無"
+}
+
+// optionalTime returns nil for a missing date so that the cell stays blank
+func optionalTime(t *time.Time) interface{} {
+	if t == nil {
+		return nil
+	}
+	return *t
+}
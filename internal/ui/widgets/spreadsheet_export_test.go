@@ -0,0 +1,82 @@
+package widgets
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+	"time"
+
+	"shien-system/internal/adapter/spreadsheet"
+	"shien-system/internal/domain"
+	"shien-system/internal/usecase"
+
+	"fyne.io/fyne/v2/test"
+)
+
+func TestRenderSpreadsheet_RecipientColumns(t *testing.T) {
+	app := test.NewApp()
+	defer app.Quit()
+
+	admission := time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)
+	list := NewRecipientList(&MockRecipientUseCase{}, nil, nil, nil)
+	recipients := []*domain.Recipient{
+		{Name: "山田太郎", Sex: domain.SexMale, BirthDate: time.Date(1980, 4, 1, 0, 0, 0, 0, time.UTC),
+			Prefecture: "東京都", City: "渋谷区", Street: "渋谷1-1-1", PublicAssistance: true, AdmissionDate: &admission},
+		{Name: "佐藤花子", Sex: domain.SexFemale, Address: "東京都新宿区西新宿2-8-1"},
+	}
+	export := spreadsheetExport{
+		report: "利用者一覧",
+		table:  usecase.ExportTableRecipients,
+		count:  len(recipients),
+		cell: func(row int, key string) interface{} {
+			return list.exportCell(recipients[row], key)
+		},
+	}
+	columns := []usecase.ExportColumn{
+		{Key: "name", Label: "氏名"},
+		{Key: "sex", Label: "性別"},
+		{Key: "address", Label: "住所"},
+		{Key: "public_assistance", Label: "生活保護"},
+		{Key: "admission_date", Label: "利用開始日"},
+	}
+	tests := []struct {
+		format   usecase.ExportFormat
+		fileName string
+		want     [][]string
+	}{
+		{usecase.ExportFormatCSV, "利用者一覧.csv", [][]string{
+			{"氏名", "性別", "住所", "生活保護", "利用開始日"},
+			{"山田太郎", "男性", "東京都渋谷区渋谷1-1-1", "有", "2025/04/01"},
+			{"佐藤花子", "女性", "東京都新宿区西新宿2-8-1", "無", ""},
+		}},
+		// Excel leaves blank cells out and the reader returns dates as 2006-01-02
+		{usecase.ExportFormatXLSX, "利用者一覧.xlsx", [][]string{
+			{"氏名", "性別", "住所", "生活保護", "利用開始日"},
+			{"山田太郎", "男性", "東京都渋谷区渋谷1-1-1", "有", "2025-04-01"},
+			{"佐藤花子", "女性", "東京都新宿区西新宿2-8-1", "無"},
+		}},
+	}
+
+	for _, tt := range tests {
+		data, err := renderSpreadsheet(export, tt.format, columns)
+		if err != nil {
+			t.Fatalf("%s: renderSpreadsheet() error = %v", tt.format, err)
+		}
+		rows, err := spreadsheet.NewReader().Read(bytes.NewReader(data), tt.fileName)
+		if err != nil {
+			t.Fatalf("%s: Read() error = %v", tt.format, err)
+		}
+		if !reflect.DeepEqual(rows, tt.want) {
+			t.Errorf("%s: rows = %q, want %q", tt.format, rows, tt.want)
+		}
+	}
+}
+
+func TestSelectedExportColumns_KeepsCatalogOrder(t *testing.T) {
+	columns := []usecase.ExportColumn{{Key: "name", Label: "氏名"}, {Key: "kana", Label: "フリガナ"}, {Key: "phone", Label: "電話番号"}}
+
+	selected := selectedExportColumns(columns, []string{"電話番号", "氏名"})
+	if len(selected) != 2 || selected[0].Key != "name" || selected[1].Key != "phone" {
+		t.Errorf("selectedExportColumns() = %+v, want name then phone", selected)
+	}
+}
@@ -2,6 +2,7 @@ package widgets
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 
@@ -30,6 +31,7 @@ type StaffForm struct {
 	isEditing   bool
 	staffID     string
 	currentUser *domain.Staff
+	original    *domain.Staff // Record as loaded into the form, see handleEditConflict
 
 	// Callbacks
 	onSaved     func(*domain.Staff)
@@ -78,10 +80,10 @@ func (sf *StaffForm) SetForEdit(staff *domain.Staff, currentUser *domain.Staff)
 	sf.isEditing = true
 	sf.staffID = staff.ID
 	sf.currentUser = currentUser
+	sf.original = staff
 
 	// Populate form fields
-	sf.nameEntry.SetText(staff.Name)
-	sf.roleSelect.SetSelected(sf.formatRoleForSelect(staff.Role))
+	setFieldValues(sf.conflictFields(), sf.fieldValues(staff))
 
 	sf.saveButton.SetText("更新")
 }
@@ -91,6 +93,7 @@ func (sf *StaffForm) SetForCreate(currentUser *domain.Staff) {
 	sf.isEditing = false
 	sf.staffID = ""
 	sf.currentUser = currentUser
+	sf.original = nil
 
 	sf.clearForm()
 	sf.saveButton.SetText("作成")
@@ -148,6 +151,10 @@ func (sf *StaffForm) handleUpdate() {
 	staff, err := sf.useCase.UpdateStaff(ctx, req)
 	sf.setFormEnabled(true)
 
+	if errors.Is(err, usecase.ErrEditConflict) {
+		sf.handleEditConflict(err)
+		return
+	}
 	if err != nil {
 		sf.showError("更新エラー", err)
 		return
@@ -174,10 +181,45 @@ func (sf *StaffForm) buildUpdateRequest() usecase.UpdateStaffRequest {
 		ID:      sf.staffID,
 		Name:    strings.TrimSpace(sf.nameEntry.Text),
 		Role:    sf.parseRoleFromSelect(sf.roleSelect.Selected),
+		Version: sf.original.Version,
 		ActorID: sf.currentUser.ID,
 	}
 }
 
+// conflictFields binds the form fields compared by the edit conflict dialog
+func (sf *StaffForm) conflictFields() []conflictField {
+	return []conflictField{
+		{label: "職員名", get: func() string { return strings.TrimSpace(sf.nameEntry.Text) }, set: sf.nameEntry.SetText},
+		{label: "ロール", get: func() string { return sf.roleSelect.Selected }, set: sf.roleSelect.SetSelected},
+	}
+}
+
+// fieldValues formats staff in the order of conflictFields
+func (sf *StaffForm) fieldValues(staff *domain.Staff) []string {
+	return []string{staff.Name, sf.formatRoleForSelect(staff.Role)}
+}
+
+// handleEditConflict offers to reload or merge when another staff member
+// saved the record while the form was open
+func (sf *StaffForm) handleEditConflict(err error) {
+	current, ok := storedRecord(err).(*domain.Staff)
+	if !ok || current == nil || sf.original == nil {
+		sf.showError("更新エラー", err)
+		return
+	}
+
+	fields := sf.conflictFields()
+	conflict := newEditConflict(fields, sf.fieldValues(sf.original), sf.fieldValues(current))
+	parent := fyne.CurrentApp().Driver().AllWindows()[0]
+	showEditConflictDialog(parent, conflict, func() {
+		sf.original = current
+		setFieldValues(fields, conflict.theirs)
+	}, func() {
+		sf.original = current
+		showMergeResult(parent, conflict.merge())
+	})
+}
+
 // validateForm validates form input
 func (sf *StaffForm) validateForm() bool {
 	var errors []string
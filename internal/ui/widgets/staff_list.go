@@ -14,12 +14,15 @@ import (
 	"shien-system/internal/adapter/pdf"
 	"shien-system/internal/domain"
 	"shien-system/internal/usecase"
+	"shien-system/internal/validation"
 )
 
 // StaffList provides a widget for managing staff members
 type StaffList struct {
-	useCase    usecase.StaffUseCase
-	pdfService *pdf.PDFService
+	useCase       usecase.StaffUseCase
+	exportUseCase usecase.ExportUseCase
+	currentUser   *domain.Staff
+	pdfService    *pdf.PDFService
 
 	// UI components
 	searchEntry   *widget.Entry
@@ -27,6 +30,7 @@ type StaffList struct {
 	newButton     *widget.Button
 	refreshButton *widget.Button
 	exportButton  *widget.Button
+	sheetButton   *widget.Button
 	roleFilter    *widget.Select
 
 	// Data
@@ -71,6 +75,10 @@ func (s *StaffList) createWidgets() {
 		s.exportToPDF()
 	})
 
+	s.sheetButton = widget.NewButton("Excel・CSV出力", func() {
+		s.exportToSpreadsheet()
+	})
+
 	// Table
 	s.table = widget.NewTable(
 		func() (int, int) { return s.Length(), 6 }, // 6 columns
@@ -212,8 +220,7 @@ func (s *StaffList) applyFilters() {
 
 // matchesSearch checks if staff matches search criteria
 func (s *StaffList) matchesSearch(staff *domain.Staff) bool {
-	searchLower := strings.ToLower(s.currentSearch)
-	return strings.Contains(strings.ToLower(staff.Name), searchLower)
+	return strings.Contains(validation.NormalizeSearch(staff.Name), validation.NormalizeSearch(s.currentSearch))
 }
 
 // matchesRole checks if staff matches role filter
@@ -240,6 +247,7 @@ func (s *StaffList) CreateObject() fyne.CanvasObject {
 		s.roleFilter,
 		s.refreshButton,
 		s.exportButton,
+		s.sheetButton,
 	)
 
 	// Button bar
@@ -338,3 +346,41 @@ func (s *StaffList) exportToPDF() {
 	saveDialog.SetFilter(storage.NewExtensionFileFilter([]string{".pdf"}))
 	saveDialog.Show()
 }
+
+// SetExportUseCase enables spreadsheet export; every export is recorded for currentUser
+func (s *StaffList) SetExportUseCase(exportUseCase usecase.ExportUseCase, currentUser *domain.Staff) {
+	s.exportUseCase = exportUseCase
+	s.currentUser = currentUser
+}
+
+// exportToSpreadsheet exports the filtered staff to CSV or Excel
+func (s *StaffList) exportToSpreadsheet() {
+	staff := s.filteredData
+	exportSpreadsheet(fyne.CurrentApp().Driver().AllWindows()[0], s.exportUseCase, s.currentUser, spreadsheetExport{
+		report: "職員一覧",
+		target: "report:" + pdf.ReportStaffList,
+		table:  usecase.ExportTableStaff,
+		count:  len(staff),
+		cell: func(row int, key string) interface{} {
+			return s.exportCell(staff[row], key)
+		},
+	})
+}
+
+// exportCell returns the value of a spreadsheet column for a staff member
+func (s *StaffList) exportCell(staff *domain.Staff, key string) interface{} {
+	switch key {
+	case "id":
+		return string(staff.ID)
+	case "name":
+		return staff.Name
+	case "role":
+		return s.formatRole(staff.Role)
+	case "created_at":
+		return staff.CreatedAt.Local()
+	case "updated_at":
+		return staff.UpdatedAt.Local()
+	default:
+		return nil
+	}
+}
@@ -23,7 +23,7 @@ func TestAuthUseCase_BruteForceProtection(t *testing.T) {
 	lockoutRepo := NewMockAccountLockoutRepository()
 	configRepo := NewMockRateLimitConfigRepository()
 
-	rateLimitSvc := NewRateLimitService(attemptRepo, lockoutRepo, configRepo, auditRepo)
+	rateLimitSvc := NewRateLimitService(attemptRepo, lockoutRepo, configRepo, NewMockAttackPatternRepository(), auditRepo)
 
 	// Create auth use case with rate limiting
 	authUseCase := NewAuthUseCase(staffRepo, auditRepo, passwordHasher, sessionMgr, rateLimitSvc)
@@ -126,7 +126,7 @@ func TestAuthUseCase_IPBasedRateLimit(t *testing.T) {
 	lockoutRepo := NewMockAccountLockoutRepository()
 	configRepo := NewMockRateLimitConfigRepository()
 
-	rateLimitSvc := NewRateLimitService(attemptRepo, lockoutRepo, configRepo, auditRepo)
+	rateLimitSvc := NewRateLimitService(attemptRepo, lockoutRepo, configRepo, NewMockAttackPatternRepository(), auditRepo)
 	authUseCase := NewAuthUseCase(staffRepo, auditRepo, passwordHasher, sessionMgr, rateLimitSvc)
 
 	ctx := context.Background()
@@ -186,7 +186,7 @@ func TestAuthUseCase_WhitelistedIP(t *testing.T) {
 	lockoutRepo := NewMockAccountLockoutRepository()
 	configRepo := NewMockRateLimitConfigRepository()
 
-	rateLimitSvc := NewRateLimitService(attemptRepo, lockoutRepo, configRepo, auditRepo)
+	rateLimitSvc := NewRateLimitService(attemptRepo, lockoutRepo, configRepo, NewMockAttackPatternRepository(), auditRepo)
 	authUseCase := NewAuthUseCase(staffRepo, auditRepo, passwordHasher, sessionMgr, rateLimitSvc)
 
 	ctx := context.Background()
@@ -239,7 +239,7 @@ func TestAuthUseCase_SuccessfulLoginResetsLockout(t *testing.T) {
 	lockoutRepo := NewMockAccountLockoutRepository()
 	configRepo := NewMockRateLimitConfigRepository()
 
-	rateLimitSvc := NewRateLimitService(attemptRepo, lockoutRepo, configRepo, auditRepo)
+	rateLimitSvc := NewRateLimitService(attemptRepo, lockoutRepo, configRepo, NewMockAttackPatternRepository(), auditRepo)
 	authUseCase := NewAuthUseCase(staffRepo, auditRepo, passwordHasher, sessionMgr, rateLimitSvc)
 
 	ctx := context.Background()
@@ -320,7 +320,7 @@ func TestTimingAttackResistance(t *testing.T) {
 	lockoutRepo := NewMockAccountLockoutRepository()
 	configRepo := NewMockRateLimitConfigRepository()
 
-	rateLimitSvc := NewRateLimitService(attemptRepo, lockoutRepo, configRepo, auditRepo)
+	rateLimitSvc := NewRateLimitService(attemptRepo, lockoutRepo, configRepo, NewMockAttackPatternRepository(), auditRepo)
 	authUseCase := NewAuthUseCase(staffRepo, auditRepo, passwordHasher, sessionMgr, rateLimitSvc)
 
 	ctx := context.Background()
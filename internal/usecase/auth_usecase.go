@@ -8,6 +8,7 @@ import (
 	"github.com/google/uuid"
 
 	"shien-system/internal/domain"
+	"shien-system/internal/validation"
 )
 
 // authUseCase implements AuthUseCase interface
@@ -17,6 +18,8 @@ type authUseCase struct {
 	passwordHasher PasswordHasher
 	sessionMgr     SessionManager
 	rateLimitSvc   *RateLimitService
+
+	useCaseLogger
 }
 
 // NewAuthUseCase creates a new AuthUseCase instance
@@ -94,7 +97,7 @@ func (a *authUseCase) Login(ctx context.Context, req LoginRequest) (*LoginRespon
 	}
 
 	// Find staff by username
-	staff, err := a.staffRepo.GetByExactName(ctx, req.Username)
+	staff, err := a.findStaffByLoginName(ctx, req.Username)
 	if err != nil {
 		if err == domain.ErrNotFound {
 			a.logAuditEvent(ctx, "", "LOGIN_FAILED", "AUTH", req.ClientIP, fmt.Sprintf("User not found: %s", req.Username))
@@ -167,6 +170,10 @@ func (a *authUseCase) ValidateSession(ctx context.Context, sessionID string) (*S
 	// Validate session
 	session, err := a.sessionMgr.ValidateSession(ctx, sessionID)
 	if err != nil {
+		// Keep the reason when the session was ended remotely so the UI can explain it
+		if err == ErrSessionRevoked {
+			return nil, err
+		}
 		return nil, ErrInvalidSession
 	}
 
@@ -259,6 +266,17 @@ func (a *authUseCase) RefreshSession(ctx context.Context, sessionID string) (*Se
 }
 
 // logAuditEvent is a helper function to log audit events
+// findStaffByLoginName looks the name up as staff names are stored, see
+// validation.NormalizeText, and falls back to the name as typed for names
+// saved before normalization
+func (a *authUseCase) findStaffByLoginName(ctx context.Context, username string) (*domain.Staff, error) {
+	staff, err := a.staffRepo.GetByExactName(ctx, validation.NormalizeText(username))
+	if err == domain.ErrNotFound && validation.NormalizeText(username) != username {
+		return a.staffRepo.GetByExactName(ctx, username)
+	}
+	return staff, err
+}
+
 func (a *authUseCase) logAuditEvent(ctx context.Context, actorID domain.ID, action, target, clientIP, details string) {
 	auditLog := &domain.AuditLog{
 		ID:      uuid.New().String(),
@@ -273,7 +291,7 @@ func (a *authUseCase) logAuditEvent(ctx context.Context, actorID domain.ID, acti
 	// For testing purposes, make this synchronous
 	// In production, you might want to make this asynchronous
 	if err := a.auditRepo.Create(ctx, auditLog); err != nil {
-		// In a production system, you might want to log this error somewhere
-		// For now, we'll just ignore audit logging failures
+		// Audit failures must not block authentication
+		a.log().Warn("failed to write audit log", "action", auditLog.Action, "error", err)
 	}
 }
@@ -453,3 +453,27 @@ func TestAuthUseCase_ChangePassword_Success(t *testing.T) {
 	hasher.AssertExpectations(t)
 	auditRepo.AssertExpectations(t)
 }
+
+func TestAuthUseCase_ValidateSession_RevokedSession(t *testing.T) {
+	// Arrange
+	staffRepo := &MockStaffRepository{}
+	auditRepo := &MockAuditLogRepository{}
+	hasher := &MockPasswordHasher{}
+	sessionMgr := &MockSessionManager{}
+
+	authUseCase := NewAuthUseCase(staffRepo, auditRepo, hasher, sessionMgr, nil)
+
+	ctx := context.Background()
+	sessionID := "revoked-session-id"
+
+	sessionMgr.On("ValidateSession", ctx, sessionID).Return(nil, ErrSessionRevoked)
+
+	// Act
+	result, err := authUseCase.ValidateSession(ctx, sessionID)
+
+	// Assert
+	assert.Nil(t, result)
+	assert.Equal(t, ErrSessionRevoked, err)
+
+	sessionMgr.AssertExpectations(t)
+}
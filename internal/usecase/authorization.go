@@ -0,0 +1,29 @@
+package usecase
+
+import (
+	"context"
+
+	"shien-system/internal/domain"
+)
+
+// verifyAdmin loads the actor and requires the administrator role. It is
+// shared by the usecases whose operations are limited to administrators.
+func verifyAdmin(ctx context.Context, staffRepo domain.StaffRepository, actorID domain.ID) (*domain.Staff, error) {
+	actor, err := staffRepo.GetByID(ctx, actorID)
+	if err != nil {
+		if err == domain.ErrNotFound {
+			return nil, ErrUnauthorized
+		}
+		return nil, &UseCaseError{
+			Code:    "INTERNAL_ERROR",
+			Message: "内部エラーが発生しました",
+			Cause:   err,
+		}
+	}
+
+	if actor.Role != domain.RoleAdmin {
+		return nil, ErrUnauthorized
+	}
+
+	return actor, nil
+}
@@ -8,28 +8,39 @@ import (
 
 	"github.com/google/uuid"
 	"shien-system/internal/domain"
+	"shien-system/internal/validation"
 )
 
 // certificateUseCase implements CertificateUseCase interface
 type certificateUseCase struct {
-	certRepo      domain.BenefitCertificateRepository
-	recipientRepo domain.RecipientRepository
-	staffRepo     domain.StaffRepository
-	auditRepo     domain.AuditLogRepository
+	certRepo         domain.BenefitCertificateRepository
+	recipientRepo    domain.RecipientRepository
+	staffRepo        domain.StaffRepository
+	auditRepo        domain.AuditLogRepository
+	municipalityRepo domain.MunicipalityRepository // nil checks the code format only
+	serviceTypeRepo  domain.ServiceTypeRepository  // nil checks the code format only
+
+	useCaseLogger
 }
 
-// NewCertificateUseCase creates a new certificate usecase
+// NewCertificateUseCase creates a new certificate usecase. The master
+// repositories may be nil; municipality and service type codes are then only
+// checked for their format.
 func NewCertificateUseCase(
 	certRepo domain.BenefitCertificateRepository,
 	recipientRepo domain.RecipientRepository,
 	staffRepo domain.StaffRepository,
 	auditRepo domain.AuditLogRepository,
+	municipalityRepo domain.MunicipalityRepository,
+	serviceTypeRepo domain.ServiceTypeRepository,
 ) CertificateUseCase {
 	return &certificateUseCase{
-		certRepo:      certRepo,
-		recipientRepo: recipientRepo,
-		staffRepo:     staffRepo,
-		auditRepo:     auditRepo,
+		certRepo:         certRepo,
+		recipientRepo:    recipientRepo,
+		staffRepo:        staffRepo,
+		auditRepo:        auditRepo,
+		municipalityRepo: municipalityRepo,
+		serviceTypeRepo:  serviceTypeRepo,
 	}
 }
 
@@ -75,15 +86,21 @@ func (uc *certificateUseCase) CreateCertificate(ctx context.Context, req CreateC
 	certificate := &domain.BenefitCertificate{
 		ID:                     domain.ID(uuid.New().String()),
 		RecipientID:            req.RecipientID,
+		CertificateNumber:      req.CertificateNumber,
 		StartDate:              req.StartDate,
 		EndDate:                req.EndDate,
+		MunicipalityCode:       req.MunicipalityCode,
 		Issuer:                 req.Issuer,
+		ServiceCode:            req.ServiceCode,
 		ServiceType:            req.ServiceType,
 		MaxBenefitDaysPerMonth: req.MaxBenefitDaysPerMonth,
 		BenefitDetails:         req.BenefitDetails,
 		CreatedAt:              now,
 		UpdatedAt:              now,
 	}
+	if err := uc.applyMasterData(ctx, certificate); err != nil {
+		return nil, err
+	}
 
 	err = uc.certRepo.Create(ctx, certificate)
 	if err != nil {
@@ -109,6 +126,7 @@ func (uc *certificateUseCase) CreateCertificate(ctx context.Context, req CreateC
 	err = uc.auditRepo.Create(ctx, auditLog)
 	if err != nil {
 		// Log audit failure but don't fail the operation
+		uc.log().Warn("failed to write audit log", "action", auditLog.Action, "error", err)
 	}
 
 	return certificate, nil
@@ -168,22 +186,38 @@ func (uc *certificateUseCase) UpdateCertificate(ctx context.Context, req UpdateC
 		}
 	}
 
+	// Someone else saved the certificate after the form was opened
+	if existing.Version != req.Version {
+		return nil, newEditConflictError(existing)
+	}
+
 	// Update certificate
 	now := time.Now().UTC()
 	certificate := &domain.BenefitCertificate{
 		ID:                     req.ID,
 		RecipientID:            existing.RecipientID, // Cannot change recipient
+		CertificateNumber:      req.CertificateNumber,
 		StartDate:              req.StartDate,
 		EndDate:                req.EndDate,
+		MunicipalityCode:       req.MunicipalityCode,
 		Issuer:                 req.Issuer,
+		ServiceCode:            req.ServiceCode,
 		ServiceType:            req.ServiceType,
 		MaxBenefitDaysPerMonth: req.MaxBenefitDaysPerMonth,
 		BenefitDetails:         req.BenefitDetails,
 		CreatedAt:              existing.CreatedAt, // Preserve original creation time
 		UpdatedAt:              now,
+		Version:                req.Version,
+	}
+	if err := uc.applyMasterData(ctx, certificate); err != nil {
+		return nil, err
 	}
 
 	err = uc.certRepo.Update(ctx, certificate)
+	if err == domain.ErrVersionConflict {
+		current, _ := uc.certRepo.GetByID(ctx, req.ID)
+		return nil, newEditConflictError(current)
+	}
 	if err != nil {
 		return nil, &UseCaseError{
 			Code:    "UPDATE_FAILED",
@@ -207,6 +241,7 @@ func (uc *certificateUseCase) UpdateCertificate(ctx context.Context, req UpdateC
 	err = uc.auditRepo.Create(ctx, auditLog)
 	if err != nil {
 		// Log audit failure but don't fail the operation
+		uc.log().Warn("failed to write audit log", "action", auditLog.Action, "error", err)
 	}
 
 	return certificate, nil
@@ -250,7 +285,9 @@ func (uc *certificateUseCase) DeleteCertificate(ctx context.Context, id domain.I
 			Details: fmt.Sprintf("受給者証を削除しました (サービス種別: %s)", certificate.ServiceType),
 		}
 
-		uc.auditRepo.Create(ctx, auditLog)
+		if err := uc.auditRepo.Create(ctx, auditLog); err != nil {
+			uc.log().Warn("failed to write audit log", "action", auditLog.Action, "error", err)
+		}
 	}
 
 	return nil
@@ -366,14 +403,16 @@ func (uc *certificateUseCase) validateCreateCertificateRequest(req CreateCertifi
 		errors = append(errors, "開始日は終了日より前である必要があります")
 	}
 
-	if strings.TrimSpace(req.Issuer) == "" {
+	if strings.TrimSpace(req.Issuer) == "" && req.MunicipalityCode == "" {
 		errors = append(errors, "発行者は必須です")
 	}
 
-	if strings.TrimSpace(req.ServiceType) == "" {
+	if strings.TrimSpace(req.ServiceType) == "" && req.ServiceCode == "" {
 		errors = append(errors, "サービス種別は必須です")
 	}
 
+	errors = append(errors, validateCertificateCodes(req.CertificateNumber, req.MunicipalityCode, req.ServiceCode)...)
+
 	if req.MaxBenefitDaysPerMonth <= 0 {
 		errors = append(errors, "月間最大給付日数は正の値である必要があります")
 	}
@@ -408,14 +447,16 @@ func (uc *certificateUseCase) validateUpdateCertificateRequest(req UpdateCertifi
 		errors = append(errors, "開始日は終了日より前である必要があります")
 	}
 
-	if strings.TrimSpace(req.Issuer) == "" {
+	if strings.TrimSpace(req.Issuer) == "" && req.MunicipalityCode == "" {
 		errors = append(errors, "発行者は必須です")
 	}
 
-	if strings.TrimSpace(req.ServiceType) == "" {
+	if strings.TrimSpace(req.ServiceType) == "" && req.ServiceCode == "" {
 		errors = append(errors, "サービス種別は必須です")
 	}
 
+	errors = append(errors, validateCertificateCodes(req.CertificateNumber, req.MunicipalityCode, req.ServiceCode)...)
+
 	if req.MaxBenefitDaysPerMonth <= 0 {
 		errors = append(errors, "月間最大給付日数は正の値である必要があります")
 	}
@@ -431,6 +472,106 @@ func (uc *certificateUseCase) validateUpdateCertificateRequest(req UpdateCertifi
 	return nil
 }
 
+// validateCertificateCodes checks the format of the optional certificate
+// number, municipality code and service code
+func validateCertificateCodes(certificateNumber, municipalityCode, serviceCode string) []string {
+	var errors []string
+
+	if certificateNumber != "" && validation.CertificateNumberDigits(certificateNumber) == "" {
+		errors = append(errors, "受給者証番号は10桁の数字である必要があります")
+	}
+
+	if municipalityCode != "" && validation.MunicipalityCodeDigits(municipalityCode) == "" {
+		errors = append(errors, "市町村番号は検査数字を含む6桁の数字である必要があります")
+	}
+
+	if serviceCode != "" && validation.ServiceCodeDigits(serviceCode) == "" {
+		errors = append(errors, "サービス種類コードは2桁の数字である必要があります")
+	}
+
+	return errors
+}
+
+// applyMasterData normalizes the validated codes and takes the issuer and
+// service type names from the masters. A code missing from a master is
+// rejected unless the master is empty, so installations that have not
+// imported the masters keep working with the names entered by staff.
+func (uc *certificateUseCase) applyMasterData(ctx context.Context, certificate *domain.BenefitCertificate) error {
+	if certificate.CertificateNumber != "" {
+		certificate.CertificateNumber = validation.CertificateNumberDigits(certificate.CertificateNumber)
+	}
+
+	var errors []string
+
+	if code := certificate.MunicipalityCode; code != "" {
+		certificate.MunicipalityCode = validation.MunicipalityCodeDigits(code)
+		if uc.municipalityRepo != nil {
+			municipality, err := uc.municipalityRepo.FindByCode(ctx, certificate.MunicipalityCode)
+			switch {
+			case err == nil:
+				certificate.Issuer = municipality.Name
+			case err != domain.ErrNotFound:
+				return uc.masterLookupError(err)
+			default:
+				count, err := uc.municipalityRepo.Count(ctx)
+				if err != nil {
+					return uc.masterLookupError(err)
+				}
+				if count > 0 {
+					errors = append(errors, fmt.Sprintf("市町村番号 %s は市町村マスタに登録されていません", certificate.MunicipalityCode))
+				}
+			}
+		}
+	}
+
+	if code := certificate.ServiceCode; code != "" {
+		certificate.ServiceCode = validation.ServiceCodeDigits(code)
+		if uc.serviceTypeRepo != nil {
+			serviceType, err := uc.serviceTypeRepo.FindByCode(ctx, certificate.ServiceCode)
+			switch {
+			case err == nil:
+				certificate.ServiceType = serviceType.Name
+			case err != domain.ErrNotFound:
+				return uc.masterLookupError(err)
+			default:
+				count, err := uc.serviceTypeRepo.Count(ctx)
+				if err != nil {
+					return uc.masterLookupError(err)
+				}
+				if count > 0 {
+					errors = append(errors, fmt.Sprintf("サービス種類コード %s はサービス種類マスタに登録されていません", certificate.ServiceCode))
+				}
+			}
+		}
+	}
+
+	// A code the masters do not know needs the name entered alongside it
+	if strings.TrimSpace(certificate.Issuer) == "" {
+		errors = append(errors, "発行者は必須です")
+	}
+	if strings.TrimSpace(certificate.ServiceType) == "" {
+		errors = append(errors, "サービス種別は必須です")
+	}
+
+	if len(errors) > 0 {
+		return &UseCaseError{
+			Code:    "VALIDATION_FAILED",
+			Message: "入力値が不正です",
+			Cause:   fmt.Errorf("validation errors: %s", strings.Join(errors, ", ")),
+		}
+	}
+
+	return nil
+}
+
+func (uc *certificateUseCase) masterLookupError(err error) error {
+	return &UseCaseError{
+		Code:    "INTERNAL_ERROR",
+		Message: "内部エラーが発生しました",
+		Cause:   err,
+	}
+}
+
 // Helper functions
 
 func (uc *certificateUseCase) getActorID(ctx context.Context) domain.ID {
@@ -167,7 +167,7 @@ func TestCertificateUseCase_CreateCertificate(t *testing.T) {
 	}
 	mockAuditRepo := &mockAuditLogRepository{}
 
-	usecase := NewCertificateUseCase(mockCertRepo, mockRecipientRepo, mockStaffRepo, mockAuditRepo)
+	usecase := NewCertificateUseCase(mockCertRepo, mockRecipientRepo, mockStaffRepo, mockAuditRepo, nil, nil)
 
 	ctx := context.Background()
 
@@ -222,7 +222,7 @@ func TestCertificateUseCase_CreateCertificate_ValidationError(t *testing.T) {
 	}
 	mockAuditRepo := &mockAuditLogRepository{}
 
-	usecase := NewCertificateUseCase(mockCertRepo, mockRecipientRepo, mockStaffRepo, mockAuditRepo)
+	usecase := NewCertificateUseCase(mockCertRepo, mockRecipientRepo, mockStaffRepo, mockAuditRepo, nil, nil)
 
 	ctx := context.Background()
 
@@ -269,7 +269,7 @@ func TestCertificateUseCase_GetCertificate(t *testing.T) {
 	mockStaffRepo := &mockStaffRepository{}
 	mockAuditRepo := &mockAuditLogRepository{}
 
-	usecase := NewCertificateUseCase(mockCertRepo, mockRecipientRepo, mockStaffRepo, mockAuditRepo)
+	usecase := NewCertificateUseCase(mockCertRepo, mockRecipientRepo, mockStaffRepo, mockAuditRepo, nil, nil)
 
 	ctx := context.Background()
 
@@ -322,7 +322,7 @@ func TestCertificateUseCase_GetExpiringSoon(t *testing.T) {
 	mockStaffRepo := &mockStaffRepository{}
 	mockAuditRepo := &mockAuditLogRepository{}
 
-	usecase := NewCertificateUseCase(mockCertRepo, mockRecipientRepo, mockStaffRepo, mockAuditRepo)
+	usecase := NewCertificateUseCase(mockCertRepo, mockRecipientRepo, mockStaffRepo, mockAuditRepo, nil, nil)
 
 	ctx := context.Background()
 
@@ -361,7 +361,7 @@ func TestCertificateUseCase_ValidateCertificate(t *testing.T) {
 	mockStaffRepo := &mockStaffRepository{}
 	mockAuditRepo := &mockAuditLogRepository{}
 
-	usecase := NewCertificateUseCase(mockCertRepo, mockRecipientRepo, mockStaffRepo, mockAuditRepo)
+	usecase := NewCertificateUseCase(mockCertRepo, mockRecipientRepo, mockStaffRepo, mockAuditRepo, nil, nil)
 
 	ctx := context.Background()
 
@@ -0,0 +1,334 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"shien-system/internal/domain"
+)
+
+// disclosureAuditLogLimit caps audit events fetched per target
+const disclosureAuditLogLimit = 10000
+
+// disclosureIncidentPageSize is the page size used to load every incident report
+const disclosureIncidentPageSize = 500
+
+// disclosureUseCase implements DisclosureUseCase interface
+type disclosureUseCase struct {
+	recipientRepo   domain.RecipientRepository
+	periodRepo      domain.EnrollmentPeriodRepository
+	certificateRepo domain.BenefitCertificateRepository
+	assignmentRepo  domain.StaffAssignmentRepository
+	consentRepo     domain.ConsentRepository
+	contactRepo     domain.EmergencyContactRepository
+	medicalRepo     domain.MedicalRecordRepository
+	incidentRepo    domain.IncidentReportRepository
+	mergeRepo       domain.RecipientMergeRepository
+	staffRepo       domain.StaffRepository
+	auditRepo       domain.AuditLogRepository
+	generator       DisclosureReportGenerator
+
+	useCaseLogger
+}
+
+// NewDisclosureUseCase creates a new disclosure usecase
+func NewDisclosureUseCase(
+	recipientRepo domain.RecipientRepository,
+	periodRepo domain.EnrollmentPeriodRepository,
+	certificateRepo domain.BenefitCertificateRepository,
+	assignmentRepo domain.StaffAssignmentRepository,
+	consentRepo domain.ConsentRepository,
+	contactRepo domain.EmergencyContactRepository,
+	medicalRepo domain.MedicalRecordRepository,
+	incidentRepo domain.IncidentReportRepository,
+	mergeRepo domain.RecipientMergeRepository,
+	staffRepo domain.StaffRepository,
+	auditRepo domain.AuditLogRepository,
+	generator DisclosureReportGenerator,
+) DisclosureUseCase {
+	return &disclosureUseCase{
+		recipientRepo:   recipientRepo,
+		periodRepo:      periodRepo,
+		certificateRepo: certificateRepo,
+		assignmentRepo:  assignmentRepo,
+		consentRepo:     consentRepo,
+		contactRepo:     contactRepo,
+		medicalRepo:     medicalRepo,
+		incidentRepo:    incidentRepo,
+		mergeRepo:       mergeRepo,
+		staffRepo:       staffRepo,
+		auditRepo:       auditRepo,
+		generator:       generator,
+	}
+}
+
+// CreateDisclosurePackage gathers every record tied to a recipient and renders it as PDF and JSON
+func (uc *disclosureUseCase) CreateDisclosurePackage(ctx context.Context, req CreateDisclosureRequest) (*DisclosureResult, error) {
+	if err := uc.validateCreateDisclosureRequest(req); err != nil {
+		return nil, &UseCaseError{
+			Code:    "VALIDATION_FAILED",
+			Message: "入力値が不正です",
+			Cause:   err,
+		}
+	}
+
+	// Only administrators may disclose personal information
+	actor, err := uc.staffRepo.GetByID(ctx, req.ActorID)
+	if err != nil {
+		if err == domain.ErrNotFound {
+			return nil, ErrUnauthorized
+		}
+		return nil, &UseCaseError{
+			Code:    "INTERNAL_ERROR",
+			Message: "内部エラーが発生しました",
+			Cause:   err,
+		}
+	}
+	if actor.Role != domain.RoleAdmin {
+		return nil, ErrUnauthorized
+	}
+
+	pkg, err := uc.collect(ctx, req.RecipientID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	pkg.GeneratedAt = now
+	pkg.GeneratedBy = req.ActorID
+
+	jsonData, err := json.MarshalIndent(pkg, "", "  ")
+	if err != nil {
+		return nil, &UseCaseError{
+			Code:    "EXPORT_FAILED",
+			Message: "開示データの作成に失敗しました",
+			Cause:   err,
+		}
+	}
+
+	pdfData, err := uc.generator.GenerateDisclosureReport(ctx, pkg, jsonData, req.Password)
+	if err != nil {
+		return nil, &UseCaseError{
+			Code:    "EXPORT_FAILED",
+			Message: "開示資料のPDF作成に失敗しました",
+			Cause:   err,
+		}
+	}
+
+	protection := "なし"
+	if req.Password != "" {
+		protection = "あり"
+	}
+
+	// The disclosure itself must be on record before anything leaves the system
+	auditLog := &domain.AuditLog{
+		ID:      domain.ID(uuid.New().String()),
+		ActorID: req.ActorID,
+		Action:  "DISCLOSE",
+		Target:  fmt.Sprintf("recipient:%s", pkg.Recipient.ID),
+		At:      now,
+		IP:      uc.getClientIP(ctx),
+		Details: fmt.Sprintf("利用者「%s」の開示資料を作成しました (理由: %s, パスワード保護: %s, 監査記録%d件)",
+			pkg.Recipient.Name, req.Reason, protection, len(pkg.AuditLogs)),
+	}
+
+	if err := uc.auditRepo.Create(ctx, auditLog); err != nil {
+		return nil, &UseCaseError{
+			Code:    "AUDIT_FAILED",
+			Message: "開示記録の保存に失敗したため、開示資料を出力できません",
+			Cause:   err,
+		}
+	}
+
+	result := &DisclosureResult{
+		Package: pkg,
+		PDF:     pdfData,
+	}
+	// With a password, the JSON only travels inside the protected PDF
+	if req.Password == "" {
+		result.JSON = jsonData
+	}
+
+	return result, nil
+}
+
+// collect loads every record tied to the recipient
+func (uc *disclosureUseCase) collect(ctx context.Context, recipientID domain.ID) (*domain.DisclosurePackage, error) {
+	recipient, err := uc.recipientRepo.GetByID(ctx, recipientID)
+	if err != nil {
+		if err == domain.ErrNotFound {
+			return nil, ErrRecipientNotFound
+		}
+		return nil, uc.retrievalError(err)
+	}
+
+	pkg := &domain.DisclosurePackage{Recipient: *recipient}
+	targets := []string{fmt.Sprintf("recipient:%s", recipientID)}
+
+	periods, err := uc.periodRepo.GetByRecipientID(ctx, recipientID)
+	if err != nil {
+		return nil, uc.retrievalError(err)
+	}
+	for _, period := range periods {
+		pkg.EnrollmentPeriods = append(pkg.EnrollmentPeriods, *period)
+	}
+
+	certificates, err := uc.certificateRepo.GetByRecipientID(ctx, recipientID)
+	if err != nil {
+		return nil, uc.retrievalError(err)
+	}
+	for _, certificate := range certificates {
+		pkg.Certificates = append(pkg.Certificates, *certificate)
+		targets = append(targets, fmt.Sprintf("certificate:%s", certificate.ID))
+	}
+
+	assignments, err := uc.assignmentRepo.GetByRecipientID(ctx, recipientID)
+	if err != nil {
+		return nil, uc.retrievalError(err)
+	}
+	for _, assignment := range assignments {
+		pkg.Assignments = append(pkg.Assignments, *assignment)
+		targets = append(targets, fmt.Sprintf("assignment:%s", assignment.ID))
+	}
+
+	consents, err := uc.consentRepo.GetByRecipientID(ctx, recipientID)
+	if err != nil {
+		return nil, uc.retrievalError(err)
+	}
+	for _, consent := range consents {
+		pkg.Consents = append(pkg.Consents, *consent)
+		targets = append(targets, fmt.Sprintf("consent:%s", consent.ID))
+	}
+
+	contacts, err := uc.contactRepo.GetByRecipientID(ctx, recipientID)
+	if err != nil {
+		return nil, uc.retrievalError(err)
+	}
+	for _, contact := range contacts {
+		pkg.EmergencyContacts = append(pkg.EmergencyContacts, *contact)
+		targets = append(targets, fmt.Sprintf("emergency_contact:%s", contact.ID))
+	}
+
+	medicalRecord, err := uc.medicalRepo.GetByRecipientID(ctx, recipientID)
+	if err == nil {
+		pkg.MedicalRecord = medicalRecord
+		targets = append(targets, fmt.Sprintf("medical_record:%s", recipientID))
+	} else if err != domain.ErrNotFound {
+		return nil, uc.retrievalError(err)
+	}
+
+	incidents, err := uc.incidentsOf(ctx, recipientID)
+	if err != nil {
+		return nil, uc.retrievalError(err)
+	}
+	for _, incident := range incidents {
+		pkg.Incidents = append(pkg.Incidents, *incident)
+		targets = append(targets, fmt.Sprintf("incident:%s", incident.ID))
+	}
+
+	// Merged duplicates keep the removed record, which is also about the recipient
+	merges, err := uc.mergeRepo.GetBySurvivorID(ctx, recipientID)
+	if err != nil {
+		return nil, uc.retrievalError(err)
+	}
+	for _, merge := range merges {
+		pkg.Merges = append(pkg.Merges, *merge)
+	}
+
+	// Audit events concerning the recipient or any of their records
+	seen := make(map[domain.ID]bool)
+	for _, target := range targets {
+		logs, err := uc.auditRepo.GetByTarget(ctx, target, disclosureAuditLogLimit, 0)
+		if err != nil {
+			return nil, uc.retrievalError(err)
+		}
+		for _, log := range logs {
+			if seen[log.ID] {
+				continue
+			}
+			seen[log.ID] = true
+			pkg.AuditLogs = append(pkg.AuditLogs, *log)
+		}
+	}
+	sort.Slice(pkg.AuditLogs, func(i, j int) bool {
+		return pkg.AuditLogs[i].At.Before(pkg.AuditLogs[j].At)
+	})
+
+	return pkg, nil
+}
+
+// incidentsOf loads every incident report involving the recipient, oldest
+// first. Other recipients involved in a report are not disclosed.
+func (uc *disclosureUseCase) incidentsOf(ctx context.Context, recipientID domain.ID) ([]*domain.IncidentReport, error) {
+	filter := domain.IncidentFilter{RecipientID: &recipientID}
+	var incidents []*domain.IncidentReport
+	for offset := 0; ; offset += disclosureIncidentPageSize {
+		page, err := uc.incidentRepo.List(ctx, filter, disclosureIncidentPageSize, offset)
+		if err != nil {
+			return nil, err
+		}
+		for _, incident := range page {
+			incident.RecipientIDs = []domain.ID{recipientID}
+			incidents = append(incidents, incident)
+		}
+		if len(page) < disclosureIncidentPageSize {
+			break
+		}
+	}
+	sort.Slice(incidents, func(i, j int) bool {
+		return incidents[i].OccurredAt.Before(incidents[j].OccurredAt)
+	})
+	return incidents, nil
+}
+
+func (uc *disclosureUseCase) retrievalError(err error) error {
+	return &UseCaseError{
+		Code:    "RETRIEVAL_FAILED",
+		Message: "開示対象データの取得に失敗しました",
+		Cause:   err,
+	}
+}
+
+// Validation functions
+
+func (uc *disclosureUseCase) validateCreateDisclosureRequest(req CreateDisclosureRequest) error {
+	var errors []string
+
+	if req.RecipientID == "" {
+		errors = append(errors, "利用者IDは必須です")
+	}
+
+	if strings.TrimSpace(req.Reason) == "" {
+		errors = append(errors, "開示理由は必須です")
+	}
+
+	if req.Password != "" && len(req.Password) < 8 {
+		errors = append(errors, "パスワードは8文字以上で指定してください")
+	}
+
+	if req.ActorID == "" {
+		errors = append(errors, "実行者IDは必須です")
+	}
+
+	if len(errors) > 0 {
+		return fmt.Errorf("validation errors: %s", strings.Join(errors, ", "))
+	}
+
+	return nil
+}
+
+// Helper functions
+
+func (uc *disclosureUseCase) getClientIP(ctx context.Context) string {
+	if ip := ctx.Value(ContextKeyClientIP); ip != nil {
+		if clientIP, ok := ip.(string); ok {
+			return clientIP
+		}
+	}
+	return "unknown"
+}
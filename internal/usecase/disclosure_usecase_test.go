@@ -0,0 +1,288 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"shien-system/internal/domain"
+)
+
+type mockConsentRepository struct {
+	consents map[domain.ID]*domain.Consent
+}
+
+func (m *mockConsentRepository) Create(ctx context.Context, consent *domain.Consent) error {
+	if m.consents == nil {
+		m.consents = make(map[domain.ID]*domain.Consent)
+	}
+	m.consents[consent.ID] = consent
+	return nil
+}
+
+func (m *mockConsentRepository) GetByID(ctx context.Context, id domain.ID) (*domain.Consent, error) {
+	consent, exists := m.consents[id]
+	if !exists {
+		return nil, domain.ErrNotFound
+	}
+	return consent, nil
+}
+
+func (m *mockConsentRepository) Update(ctx context.Context, consent *domain.Consent) error {
+	m.consents[consent.ID] = consent
+	return nil
+}
+
+func (m *mockConsentRepository) Delete(ctx context.Context, id domain.ID) error {
+	delete(m.consents, id)
+	return nil
+}
+
+func (m *mockConsentRepository) GetByRecipientID(ctx context.Context, recipientID domain.ID) ([]*domain.Consent, error) {
+	var consents []*domain.Consent
+	for _, consent := range m.consents {
+		if consent.RecipientID == recipientID {
+			consents = append(consents, consent)
+		}
+	}
+	return consents, nil
+}
+
+func (m *mockConsentRepository) GetByType(ctx context.Context, consentType string) ([]*domain.Consent, error) {
+	return nil, nil
+}
+
+func (m *mockConsentRepository) GetActiveByRecipientID(ctx context.Context, recipientID domain.ID) ([]*domain.Consent, error) {
+	return nil, nil
+}
+
+func (m *mockConsentRepository) RevokeAllByRecipientID(ctx context.Context, recipientID domain.ID, revokedAt time.Time) error {
+	return nil
+}
+
+func (m *mockConsentRepository) List(ctx context.Context, limit, offset int) ([]*domain.Consent, error) {
+	return nil, nil
+}
+
+func (m *mockConsentRepository) Count(ctx context.Context) (int, error) {
+	return len(m.consents), nil
+}
+
+type mockDisclosureReportGenerator struct {
+	pkg      *domain.DisclosurePackage
+	json     []byte
+	password string
+}
+
+func (m *mockDisclosureReportGenerator) GenerateDisclosureReport(ctx context.Context, pkg *domain.DisclosurePackage, jsonData []byte, password string) ([]byte, error) {
+	m.pkg = pkg
+	m.json = jsonData
+	m.password = password
+	return []byte("%PDF-mock"), nil
+}
+
+func setupDisclosureUseCase(t *testing.T) (DisclosureUseCase, *mockAuditLogRepository, *mockDisclosureReportGenerator) {
+	t.Helper()
+	now := time.Now().UTC().Truncate(time.Second)
+
+	recipientRepo := &mockRecipientRepository{
+		recipients: map[domain.ID]*domain.Recipient{
+			"recipient-001": {ID: "recipient-001", Name: "開示太郎", CreatedAt: now, UpdatedAt: now},
+			"recipient-002": {ID: "recipient-002", Name: "無関係花子", CreatedAt: now, UpdatedAt: now},
+		},
+	}
+	staffRepo := &mockStaffRepository{
+		staff: map[domain.ID]*domain.Staff{
+			"admin-001": {ID: "admin-001", Name: "管理者", Role: domain.RoleAdmin},
+			"staff-001": {ID: "staff-001", Name: "職員", Role: domain.RoleStaff},
+		},
+	}
+	periodRepo := &mockEnrollmentPeriodRepository{}
+	certificateRepo := &mockCertificateRepository{
+		certificates: map[domain.ID]*domain.BenefitCertificate{
+			"cert-001": {ID: "cert-001", RecipientID: "recipient-001", BenefitDetails: "送迎あり"},
+			"cert-002": {ID: "cert-002", RecipientID: "recipient-002"},
+		},
+	}
+	assignmentRepo := &mockStaffAssignmentRepository{
+		assignments: map[domain.ID]*domain.StaffAssignment{
+			"assign-001": {ID: "assign-001", RecipientID: "recipient-001", StaffID: "staff-001"},
+		},
+	}
+	consentRepo := &mockConsentRepository{
+		consents: map[domain.ID]*domain.Consent{
+			"consent-001": {ID: "consent-001", RecipientID: "recipient-001", ConsentType: "個人情報利用"},
+		},
+	}
+	contactRepo := &mockEmergencyContactRepository{
+		contacts: map[domain.ID]*domain.EmergencyContact{
+			"contact-001": {ID: "contact-001", RecipientID: "recipient-001", Priority: 1, Name: "開示花子", Relationship: "母"},
+		},
+	}
+	medicalRepo := &mockMedicalRecordRepository{
+		records: map[domain.ID]*domain.MedicalRecord{
+			"recipient-001": {RecipientID: "recipient-001", Allergies: []domain.Allergy{{Allergen: "そば", Critical: true}}},
+		},
+	}
+	incidentRepo := &mockIncidentReportRepository{
+		reports: map[domain.ID]*domain.IncidentReport{
+			"incident-001": {ID: "incident-001", RecipientIDs: []domain.ID{"recipient-001", "recipient-002"}, OccurredAt: now.Add(-48 * time.Hour)},
+			"incident-002": {ID: "incident-002", RecipientIDs: []domain.ID{"recipient-002"}, OccurredAt: now.Add(-24 * time.Hour)},
+		},
+	}
+	mergeRepo := &mockRecipientMergeRepository{
+		merges: []*domain.RecipientMerge{
+			{ID: "merge-001", SurvivorID: "recipient-001", Merged: domain.Recipient{ID: "recipient-009", Name: "開示 太郎"}, MergedAt: now.Add(-5 * time.Hour)},
+			{ID: "merge-002", SurvivorID: "recipient-002", Merged: domain.Recipient{ID: "recipient-008", Name: "無関係 花子"}, MergedAt: now},
+		},
+	}
+	auditRepo := &mockAuditLogRepository{
+		logs: []*domain.AuditLog{
+			{ID: "log-1", Action: "CREATE", Target: "recipient:recipient-001", At: now.Add(-3 * time.Hour)},
+			{ID: "log-2", Action: "UPDATE", Target: "certificate:cert-001", At: now.Add(-2 * time.Hour)},
+			{ID: "log-3", Action: "ASSIGN", Target: "assignment:assign-001", At: now.Add(-1 * time.Hour)},
+			{ID: "log-6", Action: "CREATE", Target: "emergency_contact:contact-001", At: now.Add(-30 * time.Minute)},
+			{ID: "log-7", Action: "VIEW", Target: "medical_record:recipient-001", At: now.Add(-10 * time.Minute)},
+			{ID: "log-8", Action: "SUBMIT", Target: "incident:incident-001", At: now.Add(-5 * time.Minute)},
+			{ID: "log-9", Action: "SUBMIT", Target: "incident:incident-002", At: now},
+			{ID: "log-4", Action: "CREATE", Target: "recipient:recipient-002", At: now},
+			{ID: "log-5", Action: "UPDATE", Target: "certificate:cert-002", At: now},
+		},
+	}
+	generator := &mockDisclosureReportGenerator{}
+
+	uc := NewDisclosureUseCase(recipientRepo, periodRepo, certificateRepo, assignmentRepo, consentRepo, contactRepo, medicalRepo, incidentRepo, mergeRepo, staffRepo, auditRepo, generator)
+	return uc, auditRepo, generator
+}
+
+func TestDisclosureUseCase_CreateDisclosurePackage(t *testing.T) {
+	uc, auditRepo, generator := setupDisclosureUseCase(t)
+	ctx := context.Background()
+
+	result, err := uc.CreateDisclosurePackage(ctx, CreateDisclosureRequest{
+		RecipientID: "recipient-001",
+		Reason:      "本人からの開示請求",
+		ActorID:     "admin-001",
+	})
+	if err != nil {
+		t.Fatalf("CreateDisclosurePackage() error = %v", err)
+	}
+
+	pkg := result.Package
+	if len(pkg.Certificates) != 1 || len(pkg.Assignments) != 1 || len(pkg.Consents) != 1 || len(pkg.EmergencyContacts) != 1 {
+		t.Errorf("unexpected record counts: certificates=%d assignments=%d consents=%d contacts=%d",
+			len(pkg.Certificates), len(pkg.Assignments), len(pkg.Consents), len(pkg.EmergencyContacts))
+	}
+	if pkg.MedicalRecord == nil || len(pkg.MedicalRecord.Allergies) != 1 {
+		t.Errorf("medical record missing from package: %+v", pkg.MedicalRecord)
+	}
+
+	// Incidents involving the recipient, without the other people involved
+	if len(pkg.Incidents) != 1 || pkg.Incidents[0].ID != "incident-001" {
+		t.Fatalf("Incidents = %+v, want incident-001 only", pkg.Incidents)
+	}
+	if len(pkg.Incidents[0].RecipientIDs) != 1 || pkg.Incidents[0].RecipientIDs[0] != "recipient-001" {
+		t.Errorf("other recipients must not be disclosed: %v", pkg.Incidents[0].RecipientIDs)
+	}
+	if len(pkg.Merges) != 1 || pkg.Merges[0].Merged.Name != "開示 太郎" {
+		t.Errorf("Merges = %+v, want the duplicate merged into the recipient", pkg.Merges)
+	}
+
+	// Only events concerning the recipient and their records, oldest first
+	if len(pkg.AuditLogs) != 6 {
+		t.Fatalf("Expected 6 audit logs, got %d", len(pkg.AuditLogs))
+	}
+	for i, want := range []domain.ID{"log-1", "log-2", "log-3", "log-6", "log-7", "log-8"} {
+		if pkg.AuditLogs[i].ID != want {
+			t.Errorf("AuditLogs[%d] = %s, want %s", i, pkg.AuditLogs[i].ID, want)
+		}
+	}
+
+	if len(result.JSON) == 0 || string(result.PDF) != "%PDF-mock" {
+		t.Error("Expected both JSON and PDF output")
+	}
+	if string(generator.json) != string(result.JSON) {
+		t.Error("JSON should be embedded in the PDF")
+	}
+
+	// The disclosure itself is audit-logged
+	last := auditRepo.logs[len(auditRepo.logs)-1]
+	if last.Action != "DISCLOSE" || last.Target != "recipient:recipient-001" || last.ActorID != "admin-001" {
+		t.Errorf("Unexpected disclosure audit log: %+v", last)
+	}
+}
+
+func TestDisclosureUseCase_CreateDisclosurePackage_PasswordProtected(t *testing.T) {
+	uc, _, generator := setupDisclosureUseCase(t)
+	ctx := context.Background()
+
+	result, err := uc.CreateDisclosurePackage(ctx, CreateDisclosureRequest{
+		RecipientID: "recipient-001",
+		Reason:      "本人からの開示請求",
+		Password:    "disclose-2025",
+		ActorID:     "admin-001",
+	})
+	if err != nil {
+		t.Fatalf("CreateDisclosurePackage() error = %v", err)
+	}
+
+	if generator.password != "disclose-2025" {
+		t.Errorf("password not passed to generator")
+	}
+	if result.JSON != nil {
+		t.Error("plain JSON must not be returned for a password-protected disclosure")
+	}
+}
+
+func TestDisclosureUseCase_CreateDisclosurePackage_Errors(t *testing.T) {
+	ctx := context.Background()
+
+	testCases := []struct {
+		name string
+		req  CreateDisclosureRequest
+		code string
+	}{
+		{"non-admin", CreateDisclosureRequest{RecipientID: "recipient-001", Reason: "開示請求", ActorID: "staff-001"}, "UNAUTHORIZED"},
+		{"unknown actor", CreateDisclosureRequest{RecipientID: "recipient-001", Reason: "開示請求", ActorID: "ghost"}, "UNAUTHORIZED"},
+		{"unknown recipient", CreateDisclosureRequest{RecipientID: "nobody", Reason: "開示請求", ActorID: "admin-001"}, "RECIPIENT_NOT_FOUND"},
+		{"missing reason", CreateDisclosureRequest{RecipientID: "recipient-001", ActorID: "admin-001"}, "VALIDATION_FAILED"},
+		{"short password", CreateDisclosureRequest{RecipientID: "recipient-001", Reason: "開示請求", Password: "short", ActorID: "admin-001"}, "VALIDATION_FAILED"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			uc, auditRepo, _ := setupDisclosureUseCase(t)
+			before := len(auditRepo.logs)
+
+			_, err := uc.CreateDisclosurePackage(ctx, tc.req)
+
+			var useCaseErr *UseCaseError
+			if !errors.As(err, &useCaseErr) || useCaseErr.Code != tc.code {
+				t.Errorf("Expected %s error, got %v", tc.code, err)
+			}
+			if len(auditRepo.logs) != before {
+				t.Error("failed disclosure must not be logged as a disclosure")
+			}
+		})
+	}
+}
+
+func TestDisclosureUseCase_CreateDisclosurePackage_AuditFailure(t *testing.T) {
+	uc, auditRepo, _ := setupDisclosureUseCase(t)
+	auditRepo.nextError = errors.New("disk full")
+
+	result, err := uc.CreateDisclosurePackage(context.Background(), CreateDisclosureRequest{
+		RecipientID: "recipient-001",
+		Reason:      "本人からの開示請求",
+		ActorID:     "admin-001",
+	})
+
+	var useCaseErr *UseCaseError
+	if !errors.As(err, &useCaseErr) || useCaseErr.Code != "AUDIT_FAILED" {
+		t.Errorf("Expected AUDIT_FAILED error, got %v", err)
+	}
+	if result != nil {
+		t.Error("no export may be returned when the disclosure could not be logged")
+	}
+}
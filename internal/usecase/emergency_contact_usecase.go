@@ -0,0 +1,360 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"shien-system/internal/domain"
+	"shien-system/internal/validation"
+)
+
+// emergencyContactUseCase implements EmergencyContactUseCase interface
+type emergencyContactUseCase struct {
+	contactRepo   domain.EmergencyContactRepository
+	recipientRepo domain.RecipientRepository
+	staffRepo     domain.StaffRepository
+	auditRepo     domain.AuditLogRepository
+
+	useCaseLogger
+}
+
+// NewEmergencyContactUseCase creates a new emergency contact usecase
+func NewEmergencyContactUseCase(
+	contactRepo domain.EmergencyContactRepository,
+	recipientRepo domain.RecipientRepository,
+	staffRepo domain.StaffRepository,
+	auditRepo domain.AuditLogRepository,
+) EmergencyContactUseCase {
+	return &emergencyContactUseCase{
+		contactRepo:   contactRepo,
+		recipientRepo: recipientRepo,
+		staffRepo:     staffRepo,
+		auditRepo:     auditRepo,
+	}
+}
+
+// AddEmergencyContact registers a guardian, family member or other contact for a recipient
+func (uc *emergencyContactUseCase) AddEmergencyContact(ctx context.Context, req CreateEmergencyContactRequest) (*domain.EmergencyContact, error) {
+	// Validate input
+	if err := uc.validateCreateEmergencyContactRequest(req); err != nil {
+		return nil, &UseCaseError{
+			Code:    "VALIDATION_FAILED",
+			Message: "入力値が不正です",
+			Cause:   err,
+		}
+	}
+
+	if err := uc.verifyActor(ctx, req.ActorID); err != nil {
+		return nil, err
+	}
+
+	// Verify recipient exists
+	_, err := uc.recipientRepo.GetByID(ctx, req.RecipientID)
+	if err != nil {
+		if err == domain.ErrNotFound {
+			return nil, ErrRecipientNotFound
+		}
+		return nil, &UseCaseError{
+			Code:    "INTERNAL_ERROR",
+			Message: "内部エラーが発生しました",
+			Cause:   err,
+		}
+	}
+
+	// Without an explicit priority the contact is called after the existing ones
+	priority := req.Priority
+	if priority == 0 {
+		existing, err := uc.contactRepo.GetByRecipientID(ctx, req.RecipientID)
+		if err != nil {
+			return nil, &UseCaseError{
+				Code:    "RETRIEVAL_FAILED",
+				Message: "緊急連絡先の取得に失敗しました",
+				Cause:   err,
+			}
+		}
+		priority = 1
+		for _, contact := range existing {
+			if contact.Priority >= priority {
+				priority = contact.Priority + 1
+			}
+		}
+	}
+
+	now := time.Now().UTC()
+	contact := &domain.EmergencyContact{
+		ID:           domain.ID(uuid.New().String()),
+		RecipientID:  req.RecipientID,
+		Priority:     priority,
+		Name:         validation.NormalizeText(req.Name),
+		Relationship: validation.NormalizeText(req.Relationship),
+		IsGuardian:   req.IsGuardian,
+		Phone:        validation.NormalizePhone(req.Phone),
+		MobilePhone:  validation.NormalizePhone(req.MobilePhone),
+		WorkPhone:    validation.NormalizePhone(req.WorkPhone),
+		Email:        validation.NormalizeText(req.Email),
+		Notes:        validation.NormalizeText(req.Notes),
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+
+	if err := uc.contactRepo.Create(ctx, contact); err != nil {
+		return nil, &UseCaseError{
+			Code:    "CREATION_FAILED",
+			Message: "緊急連絡先の登録に失敗しました",
+			Cause:   err,
+		}
+	}
+
+	// Log the action
+	uc.logAction(ctx, req.ActorID, "CREATE", contact, now,
+		fmt.Sprintf("緊急連絡先を登録しました (利用者: %s, 続柄: %s, 優先順位: %d)", contact.RecipientID, contact.Relationship, contact.Priority))
+
+	return contact, nil
+}
+
+// UpdateEmergencyContact updates an emergency contact
+func (uc *emergencyContactUseCase) UpdateEmergencyContact(ctx context.Context, req UpdateEmergencyContactRequest) (*domain.EmergencyContact, error) {
+	// Validate input
+	if err := uc.validateUpdateEmergencyContactRequest(req); err != nil {
+		return nil, &UseCaseError{
+			Code:    "VALIDATION_FAILED",
+			Message: "入力値が不正です",
+			Cause:   err,
+		}
+	}
+
+	if err := uc.verifyActor(ctx, req.ActorID); err != nil {
+		return nil, err
+	}
+
+	existing, err := uc.contactRepo.GetByID(ctx, req.ID)
+	if err != nil {
+		if err == domain.ErrNotFound {
+			return nil, ErrContactNotFound
+		}
+		return nil, &UseCaseError{
+			Code:    "RETRIEVAL_FAILED",
+			Message: "緊急連絡先の取得に失敗しました",
+			Cause:   err,
+		}
+	}
+
+	now := time.Now().UTC()
+	contact := &domain.EmergencyContact{
+		ID:           existing.ID,
+		RecipientID:  existing.RecipientID, // Cannot change recipient
+		Priority:     req.Priority,
+		Name:         validation.NormalizeText(req.Name),
+		Relationship: validation.NormalizeText(req.Relationship),
+		IsGuardian:   req.IsGuardian,
+		Phone:        validation.NormalizePhone(req.Phone),
+		MobilePhone:  validation.NormalizePhone(req.MobilePhone),
+		WorkPhone:    validation.NormalizePhone(req.WorkPhone),
+		Email:        validation.NormalizeText(req.Email),
+		Notes:        validation.NormalizeText(req.Notes),
+		CreatedAt:    existing.CreatedAt, // Preserve original creation time
+		UpdatedAt:    now,
+	}
+
+	if err := uc.contactRepo.Update(ctx, contact); err != nil {
+		return nil, &UseCaseError{
+			Code:    "UPDATE_FAILED",
+			Message: "緊急連絡先の更新に失敗しました",
+			Cause:   err,
+		}
+	}
+
+	// Log the action
+	uc.logAction(ctx, req.ActorID, "UPDATE", contact, now,
+		fmt.Sprintf("緊急連絡先を更新しました (利用者: %s, 続柄: %s, 優先順位: %d)", contact.RecipientID, contact.Relationship, contact.Priority))
+
+	return contact, nil
+}
+
+// DeleteEmergencyContact removes an emergency contact
+func (uc *emergencyContactUseCase) DeleteEmergencyContact(ctx context.Context, req DeleteEmergencyContactRequest) error {
+	if err := uc.verifyActor(ctx, req.ActorID); err != nil {
+		return err
+	}
+
+	contact, err := uc.contactRepo.GetByID(ctx, req.ID)
+	if err != nil {
+		if err == domain.ErrNotFound {
+			return ErrContactNotFound
+		}
+		return &UseCaseError{
+			Code:    "RETRIEVAL_FAILED",
+			Message: "緊急連絡先の取得に失敗しました",
+			Cause:   err,
+		}
+	}
+
+	if err := uc.contactRepo.Delete(ctx, req.ID); err != nil {
+		return &UseCaseError{
+			Code:    "DELETION_FAILED",
+			Message: "緊急連絡先の削除に失敗しました",
+			Cause:   err,
+		}
+	}
+
+	// Log the action
+	uc.logAction(ctx, req.ActorID, "DELETE", contact, time.Now().UTC(),
+		fmt.Sprintf("緊急連絡先を削除しました (利用者: %s, 続柄: %s)", contact.RecipientID, contact.Relationship))
+
+	return nil
+}
+
+// GetEmergencyContacts retrieves the emergency contacts of a recipient in calling order
+func (uc *emergencyContactUseCase) GetEmergencyContacts(ctx context.Context, recipientID domain.ID) ([]*domain.EmergencyContact, error) {
+	// Verify recipient exists
+	_, err := uc.recipientRepo.GetByID(ctx, recipientID)
+	if err != nil {
+		if err == domain.ErrNotFound {
+			return nil, ErrRecipientNotFound
+		}
+		return nil, &UseCaseError{
+			Code:    "INTERNAL_ERROR",
+			Message: "内部エラーが発生しました",
+			Cause:   err,
+		}
+	}
+
+	contacts, err := uc.contactRepo.GetByRecipientID(ctx, recipientID)
+	if err != nil {
+		return nil, &UseCaseError{
+			Code:    "RETRIEVAL_FAILED",
+			Message: "緊急連絡先の取得に失敗しました",
+			Cause:   err,
+		}
+	}
+
+	return contacts, nil
+}
+
+// verifyActor checks that the actor exists and may modify records
+func (uc *emergencyContactUseCase) verifyActor(ctx context.Context, actorID domain.ID) error {
+	actor, err := uc.staffRepo.GetByID(ctx, actorID)
+	if err != nil {
+		if err == domain.ErrNotFound {
+			return ErrUnauthorized
+		}
+		return &UseCaseError{
+			Code:    "INTERNAL_ERROR",
+			Message: "内部エラーが発生しました",
+			Cause:   err,
+		}
+	}
+
+	if actor.Role == domain.RoleReadOnly {
+		return ErrUnauthorized
+	}
+
+	return nil
+}
+
+// logAction records an audit entry for an emergency contact
+func (uc *emergencyContactUseCase) logAction(ctx context.Context, actorID domain.ID, action string, contact *domain.EmergencyContact, at time.Time, details string) {
+	auditLog := &domain.AuditLog{
+		ID:      domain.ID(uuid.New().String()),
+		ActorID: actorID,
+		Action:  action,
+		Target:  fmt.Sprintf("emergency_contact:%s", contact.ID),
+		At:      at,
+		IP:      uc.getClientIP(ctx),
+		Details: details,
+	}
+
+	err := uc.auditRepo.Create(ctx, auditLog)
+	if err != nil {
+		// Log audit failure but don't fail the operation
+		uc.log().Warn("failed to write audit log", "action", auditLog.Action, "error", err)
+	}
+}
+
+// Validation functions
+
+func (uc *emergencyContactUseCase) validateCreateEmergencyContactRequest(req CreateEmergencyContactRequest) error {
+	var errors []string
+
+	if req.RecipientID == "" {
+		errors = append(errors, "利用者IDは必須です")
+	}
+
+	if req.Priority < 0 {
+		errors = append(errors, "優先順位は1以上で指定してください")
+	}
+
+	errors = append(errors, uc.validateContactFields(req.Name, req.Relationship, req.Phone, req.MobilePhone, req.WorkPhone)...)
+
+	if req.ActorID == "" {
+		errors = append(errors, "実行者IDは必須です")
+	}
+
+	if len(errors) > 0 {
+		return fmt.Errorf("validation errors: %s", strings.Join(errors, ", "))
+	}
+
+	return nil
+}
+
+func (uc *emergencyContactUseCase) validateUpdateEmergencyContactRequest(req UpdateEmergencyContactRequest) error {
+	var errors []string
+
+	if req.ID == "" {
+		errors = append(errors, "緊急連絡先IDは必須です")
+	}
+
+	if req.Priority < 1 {
+		errors = append(errors, "優先順位は1以上で指定してください")
+	}
+
+	errors = append(errors, uc.validateContactFields(req.Name, req.Relationship, req.Phone, req.MobilePhone, req.WorkPhone)...)
+
+	if req.ActorID == "" {
+		errors = append(errors, "実行者IDは必須です")
+	}
+
+	if len(errors) > 0 {
+		return fmt.Errorf("validation errors: %s", strings.Join(errors, ", "))
+	}
+
+	return nil
+}
+
+// validateContactFields checks the fields shared by create and update requests
+func (uc *emergencyContactUseCase) validateContactFields(name, relationship, phone, mobilePhone, workPhone string) []string {
+	var errors []string
+
+	if strings.TrimSpace(name) == "" {
+		errors = append(errors, "氏名は必須です")
+	} else if len([]rune(name)) > 100 {
+		errors = append(errors, "氏名は100文字以内で入力してください")
+	}
+
+	if strings.TrimSpace(relationship) == "" {
+		errors = append(errors, "続柄は必須です")
+	} else if len([]rune(relationship)) > 50 {
+		errors = append(errors, "続柄は50文字以内で入力してください")
+	}
+
+	// A contact nobody can call is of no use in an emergency
+	if strings.TrimSpace(phone) == "" && strings.TrimSpace(mobilePhone) == "" && strings.TrimSpace(workPhone) == "" {
+		errors = append(errors, "電話番号を1つ以上入力してください")
+	}
+
+	return errors
+}
+
+// Helper functions
+
+func (uc *emergencyContactUseCase) getClientIP(ctx context.Context) string {
+	if ip := ctx.Value(ContextKeyClientIP); ip != nil {
+		if clientIP, ok := ip.(string); ok {
+			return clientIP
+		}
+	}
+	return "unknown"
+}
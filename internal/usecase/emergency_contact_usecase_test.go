@@ -0,0 +1,233 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+	"time"
+
+	"shien-system/internal/domain"
+)
+
+type mockEmergencyContactRepository struct {
+	contacts map[domain.ID]*domain.EmergencyContact
+}
+
+func (m *mockEmergencyContactRepository) Create(ctx context.Context, contact *domain.EmergencyContact) error {
+	if m.contacts == nil {
+		m.contacts = make(map[domain.ID]*domain.EmergencyContact)
+	}
+	m.contacts[contact.ID] = contact
+	return nil
+}
+
+func (m *mockEmergencyContactRepository) GetByID(ctx context.Context, id domain.ID) (*domain.EmergencyContact, error) {
+	contact, exists := m.contacts[id]
+	if !exists {
+		return nil, domain.ErrNotFound
+	}
+	return contact, nil
+}
+
+func (m *mockEmergencyContactRepository) Update(ctx context.Context, contact *domain.EmergencyContact) error {
+	if _, exists := m.contacts[contact.ID]; !exists {
+		return domain.ErrNotFound
+	}
+	m.contacts[contact.ID] = contact
+	return nil
+}
+
+func (m *mockEmergencyContactRepository) Delete(ctx context.Context, id domain.ID) error {
+	if _, exists := m.contacts[id]; !exists {
+		return domain.ErrNotFound
+	}
+	delete(m.contacts, id)
+	return nil
+}
+
+func (m *mockEmergencyContactRepository) GetByRecipientID(ctx context.Context, recipientID domain.ID) ([]*domain.EmergencyContact, error) {
+	var contacts []*domain.EmergencyContact
+	for _, contact := range m.contacts {
+		if contact.RecipientID == recipientID {
+			contacts = append(contacts, contact)
+		}
+	}
+	sort.Slice(contacts, func(i, j int) bool {
+		return contacts[i].Priority < contacts[j].Priority
+	})
+	return contacts, nil
+}
+
+func setupEmergencyContactUseCase() (EmergencyContactUseCase, *mockEmergencyContactRepository, *mockAuditLogRepository) {
+	now := time.Now().UTC()
+	recipientRepo := &mockRecipientRepository{
+		recipients: map[domain.ID]*domain.Recipient{
+			"recipient-001": {ID: "recipient-001", Name: "連絡太郎", CreatedAt: now, UpdatedAt: now},
+		},
+	}
+	staffRepo := &mockStaffRepository{
+		staff: map[domain.ID]*domain.Staff{
+			"staff-001":    {ID: "staff-001", Name: "職員", Role: domain.RoleStaff},
+			"readonly-001": {ID: "readonly-001", Name: "閲覧者", Role: domain.RoleReadOnly},
+		},
+	}
+	contactRepo := &mockEmergencyContactRepository{}
+	auditRepo := &mockAuditLogRepository{}
+
+	return NewEmergencyContactUseCase(contactRepo, recipientRepo, staffRepo, auditRepo), contactRepo, auditRepo
+}
+
+func TestEmergencyContactUseCase_AddEmergencyContact(t *testing.T) {
+	uc, _, auditRepo := setupEmergencyContactUseCase()
+	ctx := context.Background()
+
+	first, err := uc.AddEmergencyContact(ctx, CreateEmergencyContactRequest{
+		RecipientID:  "recipient-001",
+		Name:         "連絡花子",
+		Relationship: "母",
+		IsGuardian:   true,
+		MobilePhone:  "090-1234-5678",
+		Notes:        "体調不良時は最初に連絡",
+		ActorID:      "staff-001",
+	})
+	if err != nil {
+		t.Fatalf("AddEmergencyContact() error = %v", err)
+	}
+	if first.Priority != 1 {
+		t.Errorf("first contact priority = %d, want 1", first.Priority)
+	}
+
+	// Contacts without an explicit priority are appended
+	second, err := uc.AddEmergencyContact(ctx, CreateEmergencyContactRequest{
+		RecipientID:  "recipient-001",
+		Name:         "連絡次郎",
+		Relationship: "兄",
+		WorkPhone:    "03-1234-5678",
+		ActorID:      "staff-001",
+	})
+	if err != nil {
+		t.Fatalf("AddEmergencyContact() error = %v", err)
+	}
+	if second.Priority != 2 {
+		t.Errorf("second contact priority = %d, want 2", second.Priority)
+	}
+
+	contacts, err := uc.GetEmergencyContacts(ctx, "recipient-001")
+	if err != nil {
+		t.Fatalf("GetEmergencyContacts() error = %v", err)
+	}
+	if len(contacts) != 2 || contacts[0].ID != first.ID {
+		t.Errorf("GetEmergencyContacts() returned unexpected contacts: %+v", contacts)
+	}
+
+	if len(auditRepo.logs) != 2 {
+		t.Fatalf("Expected 2 audit logs, got %d", len(auditRepo.logs))
+	}
+	if auditRepo.logs[0].Action != "CREATE" || auditRepo.logs[0].Target != "emergency_contact:"+first.ID {
+		t.Errorf("Unexpected audit log: %+v", auditRepo.logs[0])
+	}
+}
+
+func TestEmergencyContactUseCase_AddEmergencyContact_Errors(t *testing.T) {
+	ctx := context.Background()
+
+	testCases := []struct {
+		name string
+		req  CreateEmergencyContactRequest
+		code string
+	}{
+		{"missing name", CreateEmergencyContactRequest{RecipientID: "recipient-001", Relationship: "母", Phone: "03-1234-5678", ActorID: "staff-001"}, "VALIDATION_FAILED"},
+		{"missing relationship", CreateEmergencyContactRequest{RecipientID: "recipient-001", Name: "連絡花子", Phone: "03-1234-5678", ActorID: "staff-001"}, "VALIDATION_FAILED"},
+		{"no phone number", CreateEmergencyContactRequest{RecipientID: "recipient-001", Name: "連絡花子", Relationship: "母", Email: "a@example.com", ActorID: "staff-001"}, "VALIDATION_FAILED"},
+		{"read-only actor", CreateEmergencyContactRequest{RecipientID: "recipient-001", Name: "連絡花子", Relationship: "母", Phone: "03-1234-5678", ActorID: "readonly-001"}, "UNAUTHORIZED"},
+		{"unknown recipient", CreateEmergencyContactRequest{RecipientID: "nobody", Name: "連絡花子", Relationship: "母", Phone: "03-1234-5678", ActorID: "staff-001"}, "RECIPIENT_NOT_FOUND"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			uc, contactRepo, _ := setupEmergencyContactUseCase()
+
+			_, err := uc.AddEmergencyContact(ctx, tc.req)
+
+			var useCaseErr *UseCaseError
+			if !errors.As(err, &useCaseErr) || useCaseErr.Code != tc.code {
+				t.Errorf("Expected %s error, got %v", tc.code, err)
+			}
+			if len(contactRepo.contacts) != 0 {
+				t.Error("no contact should be stored")
+			}
+		})
+	}
+}
+
+func TestEmergencyContactUseCase_UpdateAndDelete(t *testing.T) {
+	uc, contactRepo, auditRepo := setupEmergencyContactUseCase()
+	ctx := context.Background()
+
+	contact, err := uc.AddEmergencyContact(ctx, CreateEmergencyContactRequest{
+		RecipientID:  "recipient-001",
+		Name:         "連絡花子",
+		Relationship: "母",
+		Phone:        "03-1234-5678",
+		ActorID:      "staff-001",
+	})
+	if err != nil {
+		t.Fatalf("AddEmergencyContact() error = %v", err)
+	}
+
+	updated, err := uc.UpdateEmergencyContact(ctx, UpdateEmergencyContactRequest{
+		ID:           contact.ID,
+		Priority:     3,
+		Name:         "連絡花子",
+		Relationship: "母（成年後見人）",
+		IsGuardian:   true,
+		Phone:        "03-1234-5678",
+		Notes:        "平日日中は職場へ",
+		ActorID:      "staff-001",
+	})
+	if err != nil {
+		t.Fatalf("UpdateEmergencyContact() error = %v", err)
+	}
+	if updated.RecipientID != "recipient-001" || updated.Priority != 3 || !updated.IsGuardian {
+		t.Errorf("UpdateEmergencyContact() = %+v", updated)
+	}
+	if !updated.CreatedAt.Equal(contact.CreatedAt) {
+		t.Error("CreatedAt should be preserved")
+	}
+
+	_, err = uc.UpdateEmergencyContact(ctx, UpdateEmergencyContactRequest{
+		ID:           contact.ID,
+		Priority:     0,
+		Name:         "連絡花子",
+		Relationship: "母",
+		Phone:        "03-1234-5678",
+		ActorID:      "staff-001",
+	})
+	if err == nil {
+		t.Error("UpdateEmergencyContact() should reject priority 0")
+	}
+
+	if err := uc.DeleteEmergencyContact(ctx, DeleteEmergencyContactRequest{ID: contact.ID, ActorID: "readonly-001"}); err != ErrUnauthorized {
+		t.Errorf("DeleteEmergencyContact() by read-only staff error = %v, want ErrUnauthorized", err)
+	}
+
+	if err := uc.DeleteEmergencyContact(ctx, DeleteEmergencyContactRequest{ID: contact.ID, ActorID: "staff-001"}); err != nil {
+		t.Fatalf("DeleteEmergencyContact() error = %v", err)
+	}
+	if len(contactRepo.contacts) != 0 {
+		t.Error("contact should be deleted")
+	}
+
+	if err := uc.DeleteEmergencyContact(ctx, DeleteEmergencyContactRequest{ID: contact.ID, ActorID: "staff-001"}); err != ErrContactNotFound {
+		t.Errorf("DeleteEmergencyContact() of missing contact error = %v, want ErrContactNotFound", err)
+	}
+
+	actions := make([]string, 0, len(auditRepo.logs))
+	for _, log := range auditRepo.logs {
+		actions = append(actions, log.Action)
+	}
+	if len(actions) != 3 || actions[1] != "UPDATE" || actions[2] != "DELETE" {
+		t.Errorf("audit actions = %v, want [CREATE UPDATE DELETE]", actions)
+	}
+}
@@ -0,0 +1,329 @@
+package usecase
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"shien-system/internal/domain"
+)
+
+// exportAction is the audit action of a PDF export
+const exportAction = "EXPORT_PDF"
+
+// spreadsheetExportAction is the audit action of a CSV or Excel export
+const spreadsheetExportAction = "EXPORT_SPREADSHEET"
+
+// exportTraceLimit caps the export records searched by TraceExport
+const exportTraceLimit = 10000
+
+// exportColumns are the columns each table offers for spreadsheet export, in
+// file order. Sensitive columns hold personal data beyond what identifies a
+// person and are kept from read-only staff.
+var exportColumns = map[ExportTable][]ExportColumn{
+	ExportTableRecipients: {
+		{Key: "name", Label: "氏名"},
+		{Key: "kana", Label: "フリガナ"},
+		{Key: "sex", Label: "性別"},
+		{Key: "birth_date", Label: "生年月日"},
+		{Key: "disability_name", Label: "障害名", Sensitive: true},
+		{Key: "has_disability_id", Label: "障害者手帳", Sensitive: true},
+		{Key: "grade", Label: "等級", Sensitive: true},
+		{Key: "handbooks", Label: "障害者手帳（種類・番号・等級）", Sensitive: true},
+		{Key: "support_category", Label: "障害支援区分", Sensitive: true},
+		{Key: "support_category_until", Label: "障害支援区分の有効期限", Sensitive: true},
+		{Key: "intractable_disease", Label: "指定難病", Sensitive: true},
+		{Key: "postal_code", Label: "郵便番号", Sensitive: true},
+		{Key: "address", Label: "住所", Sensitive: true},
+		{Key: "phone", Label: "電話番号", Sensitive: true},
+		{Key: "email", Label: "メールアドレス", Sensitive: true},
+		{Key: "public_assistance", Label: "生活保護", Sensitive: true},
+		{Key: "admission_date", Label: "利用開始日"},
+		{Key: "discharge_date", Label: "退所日"},
+		{Key: "status", Label: "状態"},
+	},
+	ExportTableCertificates: {
+		{Key: "recipient_name", Label: "利用者名"},
+		{Key: "certificate_number", Label: "受給者証番号", Sensitive: true},
+		{Key: "service_code", Label: "サービス種類コード"},
+		{Key: "service_type", Label: "サービス種別"},
+		{Key: "start_date", Label: "開始日"},
+		{Key: "end_date", Label: "終了日"},
+		{Key: "max_benefit_days", Label: "支給日数"},
+		{Key: "municipality_code", Label: "市町村番号"},
+		{Key: "issuer", Label: "発行者"},
+		{Key: "benefit_details", Label: "給付内容", Sensitive: true},
+		{Key: "status", Label: "状態"},
+	},
+	ExportTableStaff: {
+		{Key: "id", Label: "ID"},
+		{Key: "name", Label: "名前"},
+		{Key: "role", Label: "ロール"},
+		{Key: "created_at", Label: "作成日"},
+		{Key: "updated_at", Label: "更新日"},
+	},
+	ExportTableAuditLogs: {
+		{Key: "at", Label: "日時"},
+		{Key: "actor", Label: "操作者"},
+		{Key: "action", Label: "アクション"},
+		{Key: "target", Label: "対象"},
+		{Key: "ip", Label: "IPアドレス", Sensitive: true},
+		{Key: "details", Label: "詳細", Sensitive: true},
+	},
+}
+
+// exportUseCase implements ExportUseCase interface
+type exportUseCase struct {
+	staffRepo domain.StaffRepository
+	auditRepo domain.AuditLogRepository
+}
+
+// NewExportUseCase creates a new export usecase
+func NewExportUseCase(staffRepo domain.StaffRepository, auditRepo domain.AuditLogRepository) ExportUseCase {
+	return &exportUseCase{
+		staffRepo: staffRepo,
+		auditRepo: auditRepo,
+	}
+}
+
+// RecordExport audit-logs an export with the SHA-256 of the file
+func (uc *exportUseCase) RecordExport(ctx context.Context, req RecordExportRequest) (*ExportRecord, error) {
+	if err := uc.validateRecordExportRequest(req); err != nil {
+		return nil, &UseCaseError{
+			Code:    "VALIDATION_FAILED",
+			Message: "入力値が不正です",
+			Cause:   err,
+		}
+	}
+
+	actor, err := uc.staffRepo.GetByID(ctx, req.ActorID)
+	if err != nil {
+		if err == domain.ErrNotFound {
+			return nil, ErrUnauthorized
+		}
+		return nil, &UseCaseError{
+			Code:    "INTERNAL_ERROR",
+			Message: "内部エラーが発生しました",
+			Cause:   err,
+		}
+	}
+
+	digest := fileDigest(req.Data)
+
+	// The export must be on record before the file is written
+	auditLog := &domain.AuditLog{
+		ID:      domain.ID(uuid.New().String()),
+		ActorID: req.ActorID,
+		Action:  exportAction,
+		Target:  req.Target,
+		At:      time.Now().UTC(),
+		IP:      uc.getClientIP(ctx),
+	}
+
+	if isSpreadsheetFormat(req.Format) {
+		labels, err := exportColumnLabels(req.Table, req.Columns, actor.Role)
+		if err != nil {
+			return nil, err
+		}
+		auditLog.Action = spreadsheetExportAction
+		auditLog.Details = fmt.Sprintf("%sを%sに出力しました (ファイル: %s, 件数: %d, 列: %s, SHA-256: %s)",
+			req.Report, exportFormatName(req.Format), req.FileName, req.Count, strings.Join(labels, "・"), digest)
+	} else {
+		protection := "なし"
+		if req.Protected {
+			protection = "あり"
+		}
+		auditLog.Details = fmt.Sprintf("%sをPDFに出力しました (ファイル: %s, 件数: %d, パスワード保護: %s, SHA-256: %s)",
+			req.Report, req.FileName, req.Count, protection, digest)
+	}
+
+	if err := uc.auditRepo.Create(ctx, auditLog); err != nil {
+		return nil, &UseCaseError{
+			Code:    "AUDIT_FAILED",
+			Message: fmt.Sprintf("出力記録の保存に失敗したため、%sを出力できません", exportFormatName(req.Format)),
+			Cause:   err,
+		}
+	}
+
+	return &ExportRecord{SHA256: digest, AuditLog: auditLog}, nil
+}
+
+// TraceExport finds the exports of a file by its SHA-256, newest first
+func (uc *exportUseCase) TraceExport(ctx context.Context, req TraceExportRequest) ([]*domain.AuditLog, error) {
+	actor, err := uc.staffRepo.GetByID(ctx, req.ActorID)
+	if err != nil {
+		if err == domain.ErrNotFound {
+			return nil, ErrUnauthorized
+		}
+		return nil, &UseCaseError{
+			Code:    "INTERNAL_ERROR",
+			Message: "内部エラーが発生しました",
+			Cause:   err,
+		}
+	}
+	if actor.Role != domain.RoleAdmin {
+		return nil, ErrUnauthorized
+	}
+
+	needle := "SHA-256: " + fileDigest(req.Data)
+	var matches []*domain.AuditLog
+	for _, action := range []string{exportAction, spreadsheetExportAction} {
+		logs, err := uc.auditRepo.GetByAction(ctx, action, exportTraceLimit, 0)
+		if err != nil {
+			return nil, &UseCaseError{
+				Code:    "FETCH_FAILED",
+				Message: "出力記録の取得に失敗しました",
+				Cause:   err,
+			}
+		}
+		for _, log := range logs {
+			if strings.Contains(log.Details, needle) {
+				matches = append(matches, log)
+			}
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].At.After(matches[j].At)
+	})
+	return matches, nil
+}
+
+// SpreadsheetColumns lists the columns of a table the actor may export
+func (uc *exportUseCase) SpreadsheetColumns(ctx context.Context, table ExportTable, actorID domain.ID) ([]ExportColumn, error) {
+	actor, err := uc.staffRepo.GetByID(ctx, actorID)
+	if err != nil {
+		if err == domain.ErrNotFound {
+			return nil, ErrUnauthorized
+		}
+		return nil, &UseCaseError{
+			Code:    "INTERNAL_ERROR",
+			Message: "内部エラーが発生しました",
+			Cause:   err,
+		}
+	}
+
+	columns, ok := exportColumns[table]
+	if !ok {
+		return nil, &UseCaseError{
+			Code:    "VALIDATION_FAILED",
+			Message: "出力できない一覧です",
+		}
+	}
+
+	var allowed []ExportColumn
+	for _, column := range columns {
+		if canExportColumn(column, actor.Role) {
+			allowed = append(allowed, column)
+		}
+	}
+	return allowed, nil
+}
+
+// exportColumnLabels returns the labels of the exported columns, checking
+// that they exist and that the actor may export them
+func exportColumnLabels(table ExportTable, keys []string, role domain.StaffRole) ([]string, error) {
+	byKey := make(map[string]ExportColumn)
+	for _, column := range exportColumns[table] {
+		byKey[column.Key] = column
+	}
+
+	labels := make([]string, 0, len(keys))
+	for _, key := range keys {
+		column, ok := byKey[key]
+		if !ok {
+			return nil, &UseCaseError{
+				Code:    "VALIDATION_FAILED",
+				Message: fmt.Sprintf("出力できない列です: %s", key),
+			}
+		}
+		if !canExportColumn(column, role) {
+			return nil, ErrUnauthorized
+		}
+		labels = append(labels, column.Label)
+	}
+	return labels, nil
+}
+
+// canExportColumn reports whether a role may export a column
+func canExportColumn(column ExportColumn, role domain.StaffRole) bool {
+	return !column.Sensitive || role != domain.RoleReadOnly
+}
+
+func isSpreadsheetFormat(format ExportFormat) bool {
+	return format == ExportFormatCSV || format == ExportFormatXLSX
+}
+
+// exportFormatName returns the name of a format shown to users
+func exportFormatName(format ExportFormat) string {
+	switch format {
+	case ExportFormatCSV:
+		return "CSV"
+	case ExportFormatXLSX:
+		return "Excel"
+	default:
+		return "PDF"
+	}
+}
+
+// fileDigest returns the hex SHA-256 of data
+func fileDigest(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// Validation functions
+
+func (uc *exportUseCase) validateRecordExportRequest(req RecordExportRequest) error {
+	var errors []string
+
+	if strings.TrimSpace(req.Report) == "" {
+		errors = append(errors, "帳票名は必須です")
+	}
+
+	if req.Target == "" {
+		errors = append(errors, "出力対象は必須です")
+	}
+
+	if len(req.Data) == 0 {
+		errors = append(errors, "出力データがありません")
+	}
+
+	if req.ActorID == "" {
+		errors = append(errors, "実行者IDは必須です")
+	}
+
+	switch {
+	case isSpreadsheetFormat(req.Format):
+		if _, ok := exportColumns[req.Table]; !ok {
+			errors = append(errors, "出力する一覧が不正です")
+		}
+		if len(req.Columns) == 0 {
+			errors = append(errors, "出力する列を選択してください")
+		}
+	case req.Format != "" && req.Format != ExportFormatPDF:
+		errors = append(errors, "出力形式が不正です")
+	}
+
+	if len(errors) > 0 {
+		return fmt.Errorf("validation errors: %s", strings.Join(errors, ", "))
+	}
+
+	return nil
+}
+
+// Helper functions
+
+func (uc *exportUseCase) getClientIP(ctx context.Context) string {
+	if ip := ctx.Value(ContextKeyClientIP); ip != nil {
+		if clientIP, ok := ip.(string); ok {
+			return clientIP
+		}
+	}
+	return "unknown"
+}
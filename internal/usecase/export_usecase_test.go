@@ -0,0 +1,204 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"shien-system/internal/domain"
+)
+
+func setupExportUseCase() (ExportUseCase, *mockAuditLogRepository) {
+	staffRepo := &mockStaffRepository{
+		staff: map[domain.ID]*domain.Staff{
+			"admin-001":  {ID: "admin-001", Name: "管理者", Role: domain.RoleAdmin},
+			"staff-001":  {ID: "staff-001", Name: "職員", Role: domain.RoleStaff},
+			"viewer-001": {ID: "viewer-001", Name: "閲覧者", Role: domain.RoleReadOnly},
+		},
+	}
+	auditRepo := &mockAuditLogRepository{}
+	return NewExportUseCase(staffRepo, auditRepo), auditRepo
+}
+
+func TestExportUseCase_RecordExport(t *testing.T) {
+	uc, auditRepo := setupExportUseCase()
+	ctx := context.Background()
+
+	record, err := uc.RecordExport(ctx, RecordExportRequest{
+		Report:    "受給者証一覧",
+		Target:    "report:certificate_list",
+		FileName:  "受給者証一覧.pdf",
+		Data:      []byte("%PDF-1.4 test"),
+		Count:     3,
+		Protected: true,
+		ActorID:   "staff-001",
+	})
+	if err != nil {
+		t.Fatalf("RecordExport() error = %v", err)
+	}
+
+	if len(record.SHA256) != 64 {
+		t.Fatalf("SHA256 = %q, want a hex SHA-256", record.SHA256)
+	}
+	if len(auditRepo.logs) != 1 {
+		t.Fatalf("expected 1 audit log, got %d", len(auditRepo.logs))
+	}
+	log := auditRepo.logs[0]
+	if log.Action != "EXPORT_PDF" || log.Target != "report:certificate_list" || log.ActorID != "staff-001" {
+		t.Errorf("unexpected audit log: %+v", log)
+	}
+	for _, want := range []string{"受給者証一覧.pdf", "件数: 3", "パスワード保護: あり", "SHA-256: " + record.SHA256} {
+		if !strings.Contains(log.Details, want) {
+			t.Errorf("Details = %q, want it to contain %q", log.Details, want)
+		}
+	}
+}
+
+func TestExportUseCase_RecordExport_AuditFailure(t *testing.T) {
+	uc, auditRepo := setupExportUseCase()
+	auditRepo.nextError = errors.New("disk full")
+
+	_, err := uc.RecordExport(context.Background(), RecordExportRequest{
+		Report:  "監査ログ報告書",
+		Target:  "report:audit_log",
+		Data:    []byte("%PDF"),
+		ActorID: "staff-001",
+	})
+	var ucErr *UseCaseError
+	if !errors.As(err, &ucErr) || ucErr.Code != "AUDIT_FAILED" {
+		t.Fatalf("expected AUDIT_FAILED, got %v", err)
+	}
+}
+
+func TestExportUseCase_RecordExport_Validation(t *testing.T) {
+	uc, _ := setupExportUseCase()
+
+	_, err := uc.RecordExport(context.Background(), RecordExportRequest{
+		Report:  "監査ログ報告書",
+		Target:  "report:audit_log",
+		ActorID: "staff-001",
+	})
+	var ucErr *UseCaseError
+	if !errors.As(err, &ucErr) || ucErr.Code != "VALIDATION_FAILED" {
+		t.Fatalf("expected VALIDATION_FAILED for an empty file, got %v", err)
+	}
+
+	_, err = uc.RecordExport(context.Background(), RecordExportRequest{
+		Report:  "監査ログ報告書",
+		Target:  "report:audit_log",
+		Data:    []byte("%PDF"),
+		ActorID: "unknown",
+	})
+	if err != ErrUnauthorized {
+		t.Fatalf("expected ErrUnauthorized for an unknown actor, got %v", err)
+	}
+}
+
+func TestExportUseCase_TraceExport(t *testing.T) {
+	uc, _ := setupExportUseCase()
+	ctx := context.Background()
+
+	leaked := []byte("%PDF leaked")
+	for _, data := range [][]byte{leaked, []byte("%PDF other")} {
+		if _, err := uc.RecordExport(ctx, RecordExportRequest{
+			Report:   "監査ログ報告書",
+			Target:   "report:audit_log",
+			FileName: "監査ログ.pdf",
+			Data:     data,
+			ActorID:  "staff-001",
+		}); err != nil {
+			t.Fatalf("RecordExport() error = %v", err)
+		}
+	}
+
+	logs, err := uc.TraceExport(ctx, TraceExportRequest{Data: leaked, ActorID: "admin-001"})
+	if err != nil {
+		t.Fatalf("TraceExport() error = %v", err)
+	}
+	if len(logs) != 1 || logs[0].ActorID != "staff-001" {
+		t.Fatalf("expected the one export of the leaked file, got %+v", logs)
+	}
+
+	if _, err := uc.TraceExport(ctx, TraceExportRequest{Data: leaked, ActorID: "staff-001"}); err != ErrUnauthorized {
+		t.Errorf("expected ErrUnauthorized for non-admin, got %v", err)
+	}
+}
+
+func TestExportUseCase_RecordSpreadsheetExport(t *testing.T) {
+	uc, auditRepo := setupExportUseCase()
+	ctx := context.Background()
+
+	req := RecordExportRequest{
+		Report:   "利用者一覧",
+		Target:   "report:recipient_list",
+		Format:   ExportFormatXLSX,
+		Table:    ExportTableRecipients,
+		Columns:  []string{"name", "birth_date", "phone"},
+		FileName: "利用者一覧.xlsx",
+		Data:     []byte("PK xlsx"),
+		Count:    12,
+		ActorID:  "staff-001",
+	}
+	record, err := uc.RecordExport(ctx, req)
+	if err != nil {
+		t.Fatalf("RecordExport() error = %v", err)
+	}
+	log := auditRepo.logs[0]
+	if log.Action != "EXPORT_SPREADSHEET" {
+		t.Errorf("Action = %q, want EXPORT_SPREADSHEET", log.Action)
+	}
+	for _, want := range []string{"Excelに出力", "件数: 12", "列: 氏名・生年月日・電話番号", "SHA-256: " + record.SHA256} {
+		if !strings.Contains(log.Details, want) {
+			t.Errorf("Details = %q, want it to contain %q", log.Details, want)
+		}
+	}
+
+	// Spreadsheet exports can be traced like PDFs
+	logs, err := uc.TraceExport(ctx, TraceExportRequest{Data: req.Data, ActorID: "admin-001"})
+	if err != nil || len(logs) != 1 {
+		t.Errorf("TraceExport() = %v, %v, want the spreadsheet export", logs, err)
+	}
+
+	// Read-only staff cannot export sensitive columns
+	req.ActorID = "viewer-001"
+	if _, err := uc.RecordExport(ctx, req); err != ErrUnauthorized {
+		t.Errorf("RecordExport() of phone numbers by read-only staff error = %v, want ErrUnauthorized", err)
+	}
+	req.Columns = []string{"name", "birth_date"}
+	if _, err := uc.RecordExport(ctx, req); err != nil {
+		t.Errorf("RecordExport() of names by read-only staff error = %v", err)
+	}
+
+	req.Columns = []string{"password_hash"}
+	var ucErr *UseCaseError
+	if _, err := uc.RecordExport(ctx, req); !errors.As(err, &ucErr) || ucErr.Code != "VALIDATION_FAILED" {
+		t.Errorf("RecordExport() of an unknown column error = %v, want VALIDATION_FAILED", err)
+	}
+}
+
+func TestExportUseCase_SpreadsheetColumns(t *testing.T) {
+	uc, _ := setupExportUseCase()
+	ctx := context.Background()
+
+	all, err := uc.SpreadsheetColumns(ctx, ExportTableAuditLogs, "staff-001")
+	if err != nil {
+		t.Fatalf("SpreadsheetColumns() error = %v", err)
+	}
+	limited, err := uc.SpreadsheetColumns(ctx, ExportTableAuditLogs, "viewer-001")
+	if err != nil {
+		t.Fatalf("SpreadsheetColumns() error = %v", err)
+	}
+	if len(all) != 6 || len(limited) != 4 {
+		t.Errorf("columns = %d for staff and %d for read-only staff, want 6 and 4", len(all), len(limited))
+	}
+	for _, column := range limited {
+		if column.Sensitive {
+			t.Errorf("read-only staff offered sensitive column %s", column.Key)
+		}
+	}
+
+	if _, err := uc.SpreadsheetColumns(ctx, "passwords", "staff-001"); err == nil {
+		t.Error("SpreadsheetColumns() of an unknown table error = nil")
+	}
+}
@@ -0,0 +1,777 @@
+package usecase
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"shien-system/internal/domain"
+	"shien-system/internal/validation"
+	"shien-system/internal/wareki"
+)
+
+// importAction is the audit action of a committed bulk import
+const importAction = "IMPORT"
+
+// maxImportRows limits one import so that a wrong file cannot hold the
+// database transaction for long
+const maxImportRows = 10000
+
+// recipientImportFields are the columns of a recipient import. The keys are
+// those of validation.FormValidator.ValidateRecipientForm.
+var recipientImportFields = []ImportField{
+	{Key: "name", Label: "氏名", Required: true, Aliases: []string{"名前", "利用者名", "利用者氏名"}},
+	{Key: "kana", Label: "フリガナ", Aliases: []string{"ふりがな", "カナ", "氏名カナ", "氏名フリガナ"}},
+	{Key: "sex", Label: "性別", Required: true},
+	{Key: "birth_date", Label: "生年月日", Required: true},
+	{Key: "disability_name", Label: "障害名"},
+	{Key: "has_disability_id", Label: "障害者手帳", Aliases: []string{"手帳", "手帳の有無", "障害者手帳の有無"}},
+	{Key: "grade", Label: "等級", Aliases: []string{"障害等級"}},
+	{Key: "postal_code", Label: "郵便番号", Aliases: []string{"〒"}},
+	{Key: "prefecture", Label: "都道府県"},
+	{Key: "city", Label: "市区町村"},
+	{Key: "address", Label: "住所", Aliases: []string{"町域・番地", "番地"}},
+	{Key: "phone", Label: "電話番号", Aliases: []string{"電話", "TEL"}},
+	{Key: "email", Label: "メールアドレス", Aliases: []string{"メール", "Email", "E-mail"}},
+	{Key: "public_assistance", Label: "生活保護", Aliases: []string{"生活保護受給"}},
+	{Key: "admission_date", Label: "利用開始日", Aliases: []string{"入所日", "契約日"}},
+}
+
+// certificateImportFields are the columns of a benefit certificate import.
+// Recipients are identified by name and birth date since offices do not
+// know the internal IDs.
+var certificateImportFields = []ImportField{
+	{Key: "recipient_name", Label: "利用者氏名", Required: true, Aliases: []string{"氏名", "利用者名", "名前"}},
+	{Key: "recipient_birth_date", Label: "利用者生年月日", Required: true, Aliases: []string{"生年月日"}},
+	{Key: "certificate_number", Label: "受給者証番号"},
+	{Key: "start_date", Label: "開始日", Required: true, Aliases: []string{"有効期間開始日", "支給決定開始日"}},
+	{Key: "end_date", Label: "終了日", Required: true, Aliases: []string{"有効期間終了日", "支給決定終了日"}},
+	{Key: "municipality_code", Label: "市町村番号", Aliases: []string{"支給決定市町村番号"}},
+	{Key: "issuer", Label: "発行者", Required: true, Aliases: []string{"発行機関", "発行機関名", "市町村"}},
+	{Key: "service_code", Label: "サービス種類コード"},
+	{Key: "service_type", Label: "サービス種別", Required: true},
+	{Key: "max_benefit_days", Label: "最大給付日数", Required: true, Aliases: []string{"月あたりの給付日数上限", "支給量"}},
+	{Key: "benefit_details", Label: "給付内容"},
+}
+
+// Label returns the display name of what the import creates
+func (k ImportKind) Label() string {
+	switch k {
+	case ImportKindRecipients:
+		return "利用者"
+	case ImportKindCertificates:
+		return "受給者証"
+	default:
+		return string(k)
+	}
+}
+
+// WriteErrorReport writes the row errors as CSV. The file starts with a BOM
+// so that Excel opens it as UTF-8.
+func (r *ImportResult) WriteErrorReport(w io.Writer) error {
+	if _, err := io.WriteString(w, "\ufeff"); err != nil {
+		return err
+	}
+
+	writer := csv.NewWriter(w)
+	if err := writer.Write([]string{"行", "項目", "エラー内容"}); err != nil {
+		return err
+	}
+	for _, rowErr := range r.Errors {
+		if err := writer.Write([]string{strconv.Itoa(rowErr.Line), rowErr.Field, rowErr.Message}); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// importUseCase implements ImportUseCase interface
+type importUseCase struct {
+	tx            domain.Transactional
+	reader        ImportTableReader
+	recipientRepo domain.RecipientRepository
+	periodRepo    domain.EnrollmentPeriodRepository
+	certRepo      domain.BenefitCertificateRepository
+	postalRepo    domain.PostalCodeRepository // nil checks the postal code format only
+	staffRepo     domain.StaffRepository
+	auditRepo     domain.AuditLogRepository
+
+	useCaseLogger
+}
+
+// NewImportUseCase creates a new bulk import usecase
+func NewImportUseCase(
+	tx domain.Transactional,
+	reader ImportTableReader,
+	recipientRepo domain.RecipientRepository,
+	periodRepo domain.EnrollmentPeriodRepository,
+	certRepo domain.BenefitCertificateRepository,
+	postalRepo domain.PostalCodeRepository,
+	staffRepo domain.StaffRepository,
+	auditRepo domain.AuditLogRepository,
+) ImportUseCase {
+	return &importUseCase{
+		tx:            tx,
+		reader:        reader,
+		recipientRepo: recipientRepo,
+		periodRepo:    periodRepo,
+		certRepo:      certRepo,
+		postalRepo:    postalRepo,
+		staffRepo:     staffRepo,
+		auditRepo:     auditRepo,
+	}
+}
+
+// importPlan is a validated file: the records to save and the rows rejected
+type importPlan struct {
+	result       *ImportResult
+	recipients   []*domain.Recipient
+	certificates []*domain.BenefitCertificate
+}
+
+// reject records the errors of a row that will not be imported
+func (p *importPlan) reject(errs []ImportRowError, duplicate bool) {
+	p.result.Errors = append(p.result.Errors, errs...)
+	p.result.InvalidRows++
+	if duplicate {
+		p.result.Duplicates++
+	}
+}
+
+// ReadFile reads an uploaded file and suggests a column for each field
+func (uc *importUseCase) ReadFile(ctx context.Context, req ReadImportFileRequest) (*ImportFile, error) {
+	fields, err := importFields(req.Kind)
+	if err != nil {
+		return nil, err
+	}
+	if req.Source == nil {
+		return nil, &UseCaseError{
+			Code:    "VALIDATION_FAILED",
+			Message: "入力値が不正です",
+			Cause:   fmt.Errorf("import file is required"),
+		}
+	}
+
+	if _, err := verifyAdmin(ctx, uc.staffRepo, req.ActorID); err != nil {
+		return nil, err
+	}
+
+	rows, err := uc.reader.Read(req.Source, req.FileName)
+	if err != nil {
+		return nil, &UseCaseError{
+			Code:    "INVALID_IMPORT_FILE",
+			Message: "ファイルを読み込めませんでした。CSV（UTF-8またはShift_JIS）かExcel（.xlsx）のファイルを選択してください",
+			Cause:   err,
+		}
+	}
+	if len(rows) < 2 {
+		return nil, &UseCaseError{
+			Code:    "INVALID_IMPORT_FILE",
+			Message: "見出し行とデータ行のあるファイルを選択してください",
+			Cause:   fmt.Errorf("file has %d rows", len(rows)),
+		}
+	}
+	if len(rows)-1 > maxImportRows {
+		return nil, &UseCaseError{
+			Code:    "INVALID_IMPORT_FILE",
+			Message: fmt.Sprintf("一度に取り込めるのは%d行までです。ファイルを分けてください", maxImportRows),
+			Cause:   fmt.Errorf("file has %d data rows", len(rows)-1),
+		}
+	}
+
+	return &ImportFile{
+		Kind:     req.Kind,
+		FileName: req.FileName,
+		Header:   rows[0],
+		Rows:     rows[1:],
+		Fields:   fields,
+		Mapping:  suggestImportMapping(fields, rows[0]),
+	}, nil
+}
+
+// DryRun validates every row and looks for duplicates without saving anything
+func (uc *importUseCase) DryRun(ctx context.Context, req ImportRequest) (*ImportResult, error) {
+	if err := uc.validateImportRequest(req); err != nil {
+		return nil, err
+	}
+	if _, err := verifyAdmin(ctx, uc.staffRepo, req.ActorID); err != nil {
+		return nil, err
+	}
+
+	plan, err := uc.plan(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return plan.result, nil
+}
+
+// Import validates every row and saves the valid ones in one transaction
+func (uc *importUseCase) Import(ctx context.Context, req ImportRequest) (*ImportResult, error) {
+	if err := uc.validateImportRequest(req); err != nil {
+		return nil, err
+	}
+	if _, err := verifyAdmin(ctx, uc.staffRepo, req.ActorID); err != nil {
+		return nil, err
+	}
+
+	plan, err := uc.plan(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	result := plan.result
+	if (result.InvalidRows > 0 && !req.SkipInvalidRows) || result.ValidRows() == 0 {
+		return result, nil
+	}
+
+	now := time.Now().UTC()
+	err = uc.tx.WithTransaction(ctx, func(ctx context.Context) error {
+		for _, recipient := range plan.recipients {
+			if err := uc.recipientRepo.Create(ctx, recipient); err != nil {
+				return fmt.Errorf("failed to create recipient: %w", err)
+			}
+			period := &domain.EnrollmentPeriod{
+				ID:            domain.ID(uuid.New().String()),
+				RecipientID:   recipient.ID,
+				AdmissionDate: *recipient.AdmissionDate,
+				CreatedAt:     now,
+				UpdatedAt:     now,
+			}
+			if err := uc.periodRepo.Create(ctx, period); err != nil {
+				return fmt.Errorf("failed to create enrollment period: %w", err)
+			}
+		}
+		for _, certificate := range plan.certificates {
+			if err := uc.certRepo.Create(ctx, certificate); err != nil {
+				return fmt.Errorf("failed to create certificate: %w", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, &UseCaseError{
+			Code:    "IMPORT_FAILED",
+			Message: "取り込みに失敗しました。データは登録されていません",
+			Cause:   err,
+		}
+	}
+
+	result.Imported = result.ValidRows()
+	result.Committed = true
+
+	// One entry for the whole file instead of one per row
+	auditLog := &domain.AuditLog{
+		ID:      domain.ID(uuid.New().String()),
+		ActorID: req.ActorID,
+		Action:  importAction,
+		Target:  string(result.Kind),
+		At:      now,
+		IP:      uc.getClientIP(ctx),
+		Details: fmt.Sprintf("%sを一括取込しました（ファイル: %s、登録 %d件、取込対象外 %d件、うち重複 %d件）",
+			result.Kind.Label(), result.FileName, result.Imported, result.InvalidRows, result.Duplicates),
+	}
+	if err := uc.auditRepo.Create(ctx, auditLog); err != nil {
+		// Log audit failure but don't fail the operation
+		uc.log().Warn("failed to write audit log", "action", auditLog.Action, "error", err)
+	}
+
+	return result, nil
+}
+
+// plan validates the rows of the file
+func (uc *importUseCase) plan(ctx context.Context, req ImportRequest) (*importPlan, error) {
+	plan := &importPlan{result: &ImportResult{
+		Kind:     req.File.Kind,
+		FileName: req.File.FileName,
+	}}
+
+	var err error
+	switch req.File.Kind {
+	case ImportKindRecipients:
+		err = uc.planRecipients(ctx, req, plan)
+	case ImportKindCertificates:
+		err = uc.planCertificates(ctx, req, plan)
+	}
+	if err != nil {
+		return nil, &UseCaseError{
+			Code:    "RETRIEVAL_FAILED",
+			Message: "登録済みのデータを確認できませんでした",
+			Cause:   err,
+		}
+	}
+	return plan, nil
+}
+
+// planRecipients validates recipient rows like the recipient form does and
+// rejects rows whose person is already registered or appears earlier in the
+// file, including the near-matches the duplicate finder would report
+func (uc *importUseCase) planRecipients(ctx context.Context, req ImportRequest, plan *importPlan) error {
+	index, err := uc.loadRecipientIndex(ctx)
+	if err != nil {
+		return err
+	}
+
+	formValidator := validation.NewFormValidator()
+	if uc.postalRepo != nil {
+		formValidator.SetPostalCodeDictionary(func(code string) bool {
+			known, err := postalCodeKnown(ctx, uc.postalRepo, code)
+			return err != nil || known // The save itself reports lookup failures
+		})
+	}
+
+	now := time.Now().UTC()
+	forEachImportRow(req, func(line int, data map[string]string) {
+		errs := requiredImportErrors(line, recipientImportFields, data)
+		errs = append(errs, formImportErrors(line, errs, formValidator.ValidateRecipientForm(data))...)
+
+		createReq, convErrs := recipientRequestFromRow(line, data)
+		if len(errs) > 0 || len(convErrs) > 0 {
+			plan.reject(append(errs, convErrs...), false)
+			return
+		}
+		createReq.ActorID = req.ActorID
+		normalizeCreateRecipientRequest(&createReq)
+		recipient := newRecipient(createReq, now)
+
+		for _, match := range index.identical(recipient) {
+			if match.line == 0 {
+				plan.reject([]ImportRowError{{Line: line, Message: "同じ氏名・生年月日の利用者が登録済みです"}}, true)
+			} else {
+				plan.reject([]ImportRowError{{Line: line, Message: fmt.Sprintf("%d行目と同じ氏名・生年月日です", match.line)}}, true)
+			}
+			return
+		}
+		if match, reasons := index.similar(recipient); match != nil {
+			reason := strings.Join(reasons, "・")
+			if match.line == 0 {
+				plan.reject([]ImportRowError{{Line: line, Message: fmt.Sprintf("登録済みの利用者と同一人物の可能性があります（%s）", reason)}}, true)
+			} else {
+				plan.reject([]ImportRowError{{Line: line, Message: fmt.Sprintf("%d行目と同一人物の可能性があります（%s）", match.line, reason)}}, true)
+			}
+			return
+		}
+
+		index.add(recipient, line)
+		plan.recipients = append(plan.recipients, recipient)
+	}, plan.result)
+
+	return nil
+}
+
+// planCertificates validates certificate rows like the certificate form does,
+// finds the recipient of each row and rejects certificates already registered
+func (uc *importUseCase) planCertificates(ctx context.Context, req ImportRequest, plan *importPlan) error {
+	index, err := uc.loadRecipientIndex(ctx)
+	if err != nil {
+		return err
+	}
+
+	formValidator := validation.NewFormValidator()
+	existing := make(map[domain.ID][]*domain.BenefitCertificate)
+	seen := make(map[string]int) // Certificate key → line of the first row
+	now := time.Now().UTC()
+
+	var lookupErr error
+	forEachImportRow(req, func(line int, data map[string]string) {
+		if lookupErr != nil {
+			return
+		}
+
+		errs := requiredImportErrors(line, certificateImportFields, data)
+		errs = append(errs, formImportErrors(line, errs, formValidator.ValidateCertificateForm(data))...)
+
+		birthDate, err := wareki.Parse(data["recipient_birth_date"])
+		if data["recipient_birth_date"] != "" && err != nil {
+			errs = append(errs, ImportRowError{Line: line, Field: "利用者生年月日", Message: "日付の形式が正しくありません"})
+		}
+		if len(errs) > 0 {
+			plan.reject(errs, false)
+			return
+		}
+
+		matches := index.identical(&domain.Recipient{Name: validation.NormalizeText(data["recipient_name"]), BirthDate: birthDate})
+		switch len(matches) {
+		case 0:
+			plan.reject([]ImportRowError{{Line: line, Field: "利用者氏名", Message: "該当する利用者が登録されていません"}}, false)
+			return
+		case 1:
+		default:
+			plan.reject([]ImportRowError{{Line: line, Field: "利用者氏名", Message: "同じ氏名・生年月日の利用者が複数登録されています"}}, false)
+			return
+		}
+		recipient := matches[0].recipient
+
+		startDate, _ := wareki.Parse(data["start_date"])
+		endDate, _ := wareki.Parse(data["end_date"])
+		maxDays, _ := strconv.Atoi(validation.NormalizeText(data["max_benefit_days"]))
+		certificate := &domain.BenefitCertificate{
+			ID:                     domain.ID(uuid.New().String()),
+			RecipientID:            recipient.ID,
+			CertificateNumber:      validation.CertificateNumberDigits(data["certificate_number"]),
+			StartDate:              startDate,
+			EndDate:                endDate,
+			MunicipalityCode:       validation.MunicipalityCodeDigits(data["municipality_code"]),
+			Issuer:                 data["issuer"],
+			ServiceCode:            validation.ServiceCodeDigits(data["service_code"]),
+			ServiceType:            data["service_type"],
+			MaxBenefitDaysPerMonth: maxDays,
+			BenefitDetails:         data["benefit_details"],
+			CreatedAt:              now,
+			UpdatedAt:              now,
+		}
+
+		registered, ok := existing[recipient.ID]
+		if !ok {
+			if registered, lookupErr = uc.certRepo.GetByRecipientID(ctx, recipient.ID); lookupErr != nil {
+				return
+			}
+			existing[recipient.ID] = registered
+		}
+		key := certificateIdentity(certificate)
+		for _, other := range registered {
+			if certificateIdentity(other) == key {
+				plan.reject([]ImportRowError{{Line: line, Message: "同じ利用者・開始日・サービス種別の受給者証が登録済みです"}}, true)
+				return
+			}
+		}
+		if first, ok := seen[key]; ok {
+			plan.reject([]ImportRowError{{Line: line, Message: fmt.Sprintf("%d行目と同じ受給者証です", first)}}, true)
+			return
+		}
+		seen[key] = line
+
+		plan.certificates = append(plan.certificates, certificate)
+	}, plan.result)
+
+	return lookupErr
+}
+
+// loadRecipientIndex loads every registered recipient, decrypted, into an index
+func (uc *importUseCase) loadRecipientIndex(ctx context.Context) (*recipientIndex, error) {
+	const pageSize = 100
+
+	index := newRecipientIndex()
+	for offset := 0; ; offset += pageSize {
+		recipients, err := uc.recipientRepo.List(ctx, pageSize, offset)
+		if err != nil {
+			return nil, err
+		}
+		for _, recipient := range recipients {
+			index.add(recipient, 0)
+		}
+		if len(recipients) < pageSize {
+			return index, nil
+		}
+	}
+}
+
+// validateImportRequest requires a file of a known kind with every required field mapped
+func (uc *importUseCase) validateImportRequest(req ImportRequest) error {
+	if req.File == nil {
+		return &UseCaseError{
+			Code:    "VALIDATION_FAILED",
+			Message: "入力値が不正です",
+			Cause:   fmt.Errorf("import file is required"),
+		}
+	}
+
+	fields, err := importFields(req.File.Kind)
+	if err != nil {
+		return err
+	}
+
+	var missing []string
+	for _, field := range fields {
+		if col, ok := req.Mapping[field.Key]; field.Required && (!ok || col < 0 || col >= len(req.File.Header)) {
+			missing = append(missing, field.Label)
+		}
+	}
+	if len(missing) > 0 {
+		return &UseCaseError{
+			Code:    "VALIDATION_FAILED",
+			Message: fmt.Sprintf("必須項目の列を選択してください: %s", strings.Join(missing, "、")),
+			Cause:   fmt.Errorf("unmapped required fields: %v", missing),
+		}
+	}
+	return nil
+}
+
+func (uc *importUseCase) getClientIP(ctx context.Context) string {
+	if ip := ctx.Value(ContextKeyClientIP); ip != nil {
+		if clientIP, ok := ip.(string); ok {
+			return clientIP
+		}
+	}
+	return "unknown"
+}
+
+// importFields returns the fields of an import kind
+func importFields(kind ImportKind) ([]ImportField, error) {
+	switch kind {
+	case ImportKindRecipients:
+		return recipientImportFields, nil
+	case ImportKindCertificates:
+		return certificateImportFields, nil
+	default:
+		return nil, &UseCaseError{
+			Code:    "VALIDATION_FAILED",
+			Message: "入力値が不正です",
+			Cause:   fmt.Errorf("unknown import kind %q", kind),
+		}
+	}
+}
+
+// suggestImportMapping matches the header against the field labels and aliases
+func suggestImportMapping(fields []ImportField, header []string) map[string]int {
+	mapping := make(map[string]int)
+	used := make(map[int]bool)
+	for _, field := range fields {
+		names := append([]string{field.Label, field.Key}, field.Aliases...)
+		for col, heading := range header {
+			if used[col] {
+				continue
+			}
+			if matchesImportHeading(heading, names) {
+				mapping[field.Key] = col
+				used[col] = true
+				break
+			}
+		}
+	}
+	return mapping
+}
+
+// matchesImportHeading compares headings ignoring width, case, spaces and
+// required-field marks such as "氏名 ※"
+func matchesImportHeading(heading string, names []string) bool {
+	simplify := func(s string) string {
+		s = strings.ToLower(validation.NormalizeText(s))
+		s = strings.Trim(s, "*※ ")
+		return strings.ReplaceAll(s, " ", "")
+	}
+
+	heading = simplify(heading)
+	for _, name := range names {
+		if heading == simplify(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// forEachImportRow calls fn with the mapped values of every data row that is
+// not blank and counts those rows in result
+func forEachImportRow(req ImportRequest, fn func(line int, data map[string]string), result *ImportResult) {
+	for i, row := range req.File.Rows {
+		data := make(map[string]string, len(req.Mapping))
+		blank := true
+		for key, col := range req.Mapping {
+			if col < 0 || col >= len(row) {
+				continue
+			}
+			data[key] = strings.TrimSpace(row[col])
+			if data[key] != "" {
+				blank = false
+			}
+		}
+		if blank {
+			continue
+		}
+
+		result.TotalRows++
+		fn(i+2, data) // Line 1 is the header
+	}
+}
+
+// requiredImportErrors reports the required fields a row leaves empty
+func requiredImportErrors(line int, fields []ImportField, data map[string]string) []ImportRowError {
+	var errs []ImportRowError
+	for _, field := range fields {
+		if field.Required && data[field.Key] == "" {
+			errs = append(errs, ImportRowError{Line: line, Field: field.Label, Message: "必須項目です"})
+		}
+	}
+	return errs
+}
+
+// formImportErrors converts form validation errors, leaving out fields
+// already reported as missing
+func formImportErrors(line int, reported []ImportRowError, validationErrors validation.ValidationErrors) []ImportRowError {
+	missing := make(map[string]bool, len(reported))
+	for _, rowErr := range reported {
+		missing[rowErr.Field] = true
+	}
+
+	var errs []ImportRowError
+	for _, validationErr := range validationErrors {
+		if !missing[validationErr.Field] {
+			errs = append(errs, ImportRowError{Line: line, Field: validationErr.Field, Message: validationErr.Message})
+		}
+	}
+	return errs
+}
+
+// recipientRequestFromRow converts the text of a row into a create request.
+// Values the form validator has already rejected are left zero.
+func recipientRequestFromRow(line int, data map[string]string) (CreateRecipientRequest, []ImportRowError) {
+	var errs []ImportRowError
+	req := CreateRecipientRequest{
+		Name:           data["name"],
+		Kana:           data["kana"],
+		DisabilityName: data["disability_name"],
+		Grade:          data["grade"],
+		PostalCode:     data["postal_code"],
+		Prefecture:     data["prefecture"],
+		City:           data["city"],
+		Phone:          data["phone"],
+		Email:          data["email"],
+	}
+
+	// The address column holds the street when the file has the other address
+	// components, and the full address otherwise
+	if req.Prefecture != "" || req.City != "" {
+		req.Street = data["address"]
+	} else {
+		req.Address = data["address"]
+	}
+
+	if data["sex"] != "" {
+		sex, ok := parseImportSex(data["sex"])
+		if !ok {
+			errs = append(errs, ImportRowError{Line: line, Field: "性別", Message: "男性・女性・その他・未設定のいずれかを入力してください"})
+		}
+		req.Sex = sex
+	}
+
+	for _, flag := range []struct {
+		key, label string
+		value      *bool
+	}{
+		{"has_disability_id", "障害者手帳", &req.HasDisabilityID},
+		{"public_assistance", "生活保護", &req.PublicAssistance},
+	} {
+		value, ok := parseImportBool(data[flag.key])
+		if !ok {
+			errs = append(errs, ImportRowError{Line: line, Field: flag.label, Message: "「有」または「無」を入力してください"})
+		}
+		*flag.value = value
+	}
+
+	if birthDate, err := wareki.Parse(data["birth_date"]); err == nil {
+		req.BirthDate = birthDate
+	}
+	if admissionDate, err := wareki.Parse(data["admission_date"]); err == nil && data["admission_date"] != "" {
+		req.AdmissionDate = &admissionDate
+	}
+
+	return req, errs
+}
+
+// parseImportSex accepts the select labels of the recipient form and common abbreviations
+func parseImportSex(value string) (domain.Sex, bool) {
+	switch strings.ToLower(validation.NormalizeText(value)) {
+	case "男性", "男", "m", "male":
+		return domain.SexMale, true
+	case "女性", "女", "f", "female":
+		return domain.SexFemale, true
+	case "その他", "other":
+		return domain.SexOther, true
+	case "未設定", "不明", "na":
+		return domain.SexNA, true
+	default:
+		return "", false
+	}
+}
+
+// parseImportBool accepts the usual ways a yes/no column is filled in; an
+// empty cell is no
+func parseImportBool(value string) (bool, bool) {
+	switch strings.ToLower(validation.NormalizeText(value)) {
+	case "有", "あり", "有り", "はい", "○", "〇", "1", "true", "yes", "y":
+		return true, true
+	case "", "無", "なし", "無し", "いいえ", "×", "-", "0", "false", "no", "n":
+		return false, true
+	default:
+		return false, false
+	}
+}
+
+// recipientIdentity is the name and birth date that identify a person in a
+// file. Spaces in the name are ignored and kanji variants are folded as the
+// duplicate finder does; the name must already be normalized.
+func recipientIdentity(name string, birthDate time.Time) string {
+	return kanjiVariants.Replace(strings.Join(strings.Fields(name), "")) + "|" + birthDate.Format("2006-01-02")
+}
+
+// indexedRecipient is a recipient known to an import, either registered
+// (line 0) or planned from an earlier line of the file
+type indexedRecipient struct {
+	recipient *domain.Recipient
+	keys      duplicateKeys
+	line      int
+}
+
+// recipientIndex looks up the recipients an imported row may duplicate
+type recipientIndex struct {
+	byIdentity map[string][]*indexedRecipient
+	byBucket   map[string][]*indexedRecipient
+}
+
+func newRecipientIndex() *recipientIndex {
+	return &recipientIndex{
+		byIdentity: make(map[string][]*indexedRecipient),
+		byBucket:   make(map[string][]*indexedRecipient),
+	}
+}
+
+func (ix *recipientIndex) add(recipient *domain.Recipient, line int) {
+	entry := &indexedRecipient{recipient: recipient, keys: newDuplicateKeys(recipient), line: line}
+
+	identity := recipientIdentity(recipient.Name, recipient.BirthDate)
+	ix.byIdentity[identity] = append(ix.byIdentity[identity], entry)
+	for _, bucket := range entry.keys.buckets() {
+		ix.byBucket[bucket] = append(ix.byBucket[bucket], entry)
+	}
+}
+
+// identical returns the recipients with the same name and birth date
+func (ix *recipientIndex) identical(recipient *domain.Recipient) []*indexedRecipient {
+	return ix.byIdentity[recipientIdentity(recipient.Name, recipient.BirthDate)]
+}
+
+// similar returns the recipient the duplicate finder would most likely pair
+// with the given one, such as the same reading and birth date under another
+// spelling of the name, and the reasons for the match
+func (ix *recipientIndex) similar(recipient *domain.Recipient) (*indexedRecipient, []string) {
+	keys := newDuplicateKeys(recipient)
+
+	var best *indexedRecipient
+	var bestScore int
+	var bestReasons []string
+	compared := make(map[*indexedRecipient]bool)
+	for _, bucket := range keys.buckets() {
+		for _, entry := range ix.byBucket[bucket] {
+			if compared[entry] {
+				continue
+			}
+			compared[entry] = true
+
+			score, reasons := scoreDuplicate(keys, entry.keys)
+			if score >= defaultDuplicateScore && score > bestScore {
+				best, bestScore, bestReasons = entry, score, reasons
+			}
+		}
+	}
+	return best, bestReasons
+}
+
+// certificateIdentity identifies a certificate by recipient, start date and service type
+func certificateIdentity(cert *domain.BenefitCertificate) string {
+	return string(cert.RecipientID) + "|" + cert.StartDate.Format("2006-01-02") + "|" +
+		validation.NormalizeText(cert.ServiceType)
+}
@@ -0,0 +1,283 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"shien-system/internal/domain"
+)
+
+// mockTransactional runs the function without a real transaction
+type mockTransactional struct {
+	calls int
+}
+
+func (m *mockTransactional) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	m.calls++
+	return fn(ctx)
+}
+
+// importTableFunc adapts a function to ImportTableReader
+type importTableFunc func(source io.Reader, fileName string) ([][]string, error)
+
+func (f importTableFunc) Read(source io.Reader, fileName string) ([][]string, error) {
+	return f(source, fileName)
+}
+
+type importTestEnv struct {
+	uc            ImportUseCase
+	tx            *mockTransactional
+	recipientRepo *mockRecipientRepository
+	periodRepo    *mockEnrollmentPeriodRepository
+	certRepo      *mockCertificateRepository
+	auditRepo     *mockAuditLogRepository
+}
+
+func setupImportUseCase(rows [][]string) *importTestEnv {
+	env := &importTestEnv{
+		tx: &mockTransactional{},
+		recipientRepo: &mockRecipientRepository{recipients: map[domain.ID]*domain.Recipient{
+			"recipient-001": {ID: "recipient-001", Name: "山田 太郎", BirthDate: time.Date(1980, 4, 1, 0, 0, 0, 0, time.UTC)},
+		}},
+		periodRepo: &mockEnrollmentPeriodRepository{},
+		certRepo:   &mockCertificateRepository{},
+		auditRepo:  &mockAuditLogRepository{},
+	}
+	staffRepo := &mockStaffRepository{
+		staff: map[domain.ID]*domain.Staff{
+			"admin-001": {ID: "admin-001", Name: "管理者", Role: domain.RoleAdmin},
+			"staff-001": {ID: "staff-001", Name: "職員", Role: domain.RoleStaff},
+		},
+	}
+	reader := importTableFunc(func(source io.Reader, fileName string) ([][]string, error) {
+		return rows, nil
+	})
+	env.uc = NewImportUseCase(env.tx, reader, env.recipientRepo, env.periodRepo, env.certRepo, nil, staffRepo, env.auditRepo)
+	return env
+}
+
+func (env *importTestEnv) readFile(t *testing.T, kind ImportKind) *ImportFile {
+	t.Helper()
+	file, err := env.uc.ReadFile(context.Background(), ReadImportFileRequest{
+		Kind:     kind,
+		Source:   strings.NewReader(""),
+		FileName: "import.csv",
+		ActorID:  "admin-001",
+	})
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	return file
+}
+
+func TestImportUseCase_ImportRecipients(t *testing.T) {
+	env := setupImportUseCase([][]string{
+		{"氏名 ※", "ﾌﾘｶﾞﾅ", "性別", "生年月日", "利用開始日", "生活保護", "電話番号"},
+		{"佐藤 花子", "さとう はなこ", "女", "S60.8.20", "2025/04/01", "有", "０３－１２３４－５６７８"},
+		{"山田　太郎", "ヤマダタロウ", "男性", "1980-04-01", "", "", ""},
+		{"鈴木一郎", "", "不詳", "2090/01/01", "", "はい", ""},
+		{"", "", "", "", "", "", ""},
+		{"佐藤花子", "", "女性", "1985/08/20", "", "", ""},
+	})
+	ctx := context.Background()
+
+	file := env.readFile(t, ImportKindRecipients)
+	for key, col := range map[string]int{"name": 0, "kana": 1, "sex": 2, "birth_date": 3, "admission_date": 4, "public_assistance": 5, "phone": 6} {
+		if file.Mapping[key] != col {
+			t.Errorf("suggested column for %s = %d, want %d", key, file.Mapping[key], col)
+		}
+	}
+	req := ImportRequest{File: file, Mapping: file.Mapping, ActorID: "admin-001"}
+
+	result, err := env.uc.DryRun(ctx, req)
+	if err != nil {
+		t.Fatalf("DryRun() error = %v", err)
+	}
+	if result.TotalRows != 4 || result.ValidRows() != 1 || result.Duplicates != 2 {
+		t.Errorf("DryRun() = %d rows, %d valid, %d duplicates, want 4, 1, 2",
+			result.TotalRows, result.ValidRows(), result.Duplicates)
+	}
+	lines := make(map[int][]string)
+	for _, rowErr := range result.Errors {
+		lines[rowErr.Line] = append(lines[rowErr.Line], rowErr.Field)
+	}
+	if len(lines[3]) != 1 || len(lines[4]) != 2 || len(lines[6]) != 1 || len(lines[2]) != 0 {
+		t.Errorf("errors by line = %v, want duplicate on 3, sex and birth date on 4, duplicate on 6", lines)
+	}
+	if len(env.recipientRepo.recipients) != 1 || env.tx.calls != 0 {
+		t.Fatal("dry run saved data")
+	}
+
+	// Rows with errors block the import unless they are skipped
+	result, err = env.uc.Import(ctx, req)
+	if err != nil {
+		t.Fatalf("Import() error = %v", err)
+	}
+	if result.Committed || len(env.recipientRepo.recipients) != 1 {
+		t.Fatal("Import() saved data although rows have errors")
+	}
+
+	req.SkipInvalidRows = true
+	result, err = env.uc.Import(ctx, req)
+	if err != nil {
+		t.Fatalf("Import() error = %v", err)
+	}
+	if !result.Committed || result.Imported != 1 || env.tx.calls != 1 {
+		t.Errorf("Import() = %+v, want one row committed in one transaction", result)
+	}
+
+	var imported *domain.Recipient
+	for id, recipient := range env.recipientRepo.recipients {
+		if id != "recipient-001" {
+			imported = recipient
+		}
+	}
+	if imported == nil || imported.Kana != "サトウ ハナコ" || imported.Phone != "03-1234-5678" ||
+		imported.Sex != domain.SexFemale || !imported.PublicAssistance || imported.BirthDate.Year() != 1985 {
+		t.Errorf("imported recipient = %+v, want normalized values", imported)
+	}
+	if len(env.periodRepo.periods) != 1 {
+		t.Errorf("enrollment periods = %d, want 1", len(env.periodRepo.periods))
+	}
+	if len(env.auditRepo.logs) != 1 || env.auditRepo.logs[0].Action != importAction {
+		t.Errorf("audit logs = %+v, want a single %s entry", env.auditRepo.logs, importAction)
+	}
+
+	var report bytes.Buffer
+	if err := result.WriteErrorReport(&report); err != nil {
+		t.Fatalf("WriteErrorReport() error = %v", err)
+	}
+	if !strings.HasPrefix(report.String(), "\ufeff行,項目,エラー内容\n") || strings.Count(report.String(), "\n") != 5 {
+		t.Errorf("error report = %q, want a header and four errors", report.String())
+	}
+}
+
+func TestImportUseCase_ImportRecipients_NearMatches(t *testing.T) {
+	env := setupImportUseCase([][]string{
+		{"氏名", "フリガナ", "性別", "生年月日", "電話番号"},
+		{"山田 太朗", "ヤマダ タロウ", "男", "1980/04/01", ""},
+		{"佐々木 花子", "ササキ ハナコ", "女", "1990/01/01", "090-1111-2222"},
+		{"佐佐木 花子", "ささき はなこ", "女", "1990/01/01", ""},
+		{"髙橋 一郎", "", "男", "1975/05/05", ""},
+		{"鈴木 次郎", "スズキ ジロウ", "男", "1970/02/02", "09011112222"},
+	})
+	env.recipientRepo.recipients["recipient-001"].Kana = "ヤマダ タロウ"
+	env.recipientRepo.recipients["recipient-002"] = &domain.Recipient{
+		ID: "recipient-002", Name: "高橋 一郎", BirthDate: time.Date(1975, 5, 5, 0, 0, 0, 0, time.UTC),
+	}
+	ctx := context.Background()
+
+	file := env.readFile(t, ImportKindRecipients)
+	result, err := env.uc.DryRun(ctx, ImportRequest{File: file, Mapping: file.Mapping, ActorID: "admin-001"})
+	if err != nil {
+		t.Fatalf("DryRun() error = %v", err)
+	}
+
+	// Only 佐々木 and 鈴木 are new: the others share the reading and birth
+	// date or differ only by a kanji variant, and a shared phone alone is not enough
+	if result.ValidRows() != 2 || result.Duplicates != 3 {
+		t.Errorf("DryRun() = %d valid, %d duplicates, want 2, 3", result.ValidRows(), result.Duplicates)
+	}
+	messages := make(map[int]string)
+	for _, rowErr := range result.Errors {
+		messages[rowErr.Line] = rowErr.Message
+	}
+	if !strings.Contains(messages[2], "登録済みの利用者と同一人物の可能性があります") || !strings.Contains(messages[2], "フリガナが一致") {
+		t.Errorf("line 2 error = %q, want a near-match with the registered recipient", messages[2])
+	}
+	if !strings.Contains(messages[4], "3行目と同一人物の可能性があります") {
+		t.Errorf("line 4 error = %q, want a near-match with line 3", messages[4])
+	}
+	if messages[5] != "同じ氏名・生年月日の利用者が登録済みです" {
+		t.Errorf("line 5 error = %q, want the kanji variant treated as the same name", messages[5])
+	}
+}
+
+func TestImportUseCase_ImportCertificates(t *testing.T) {
+	env := setupImportUseCase([][]string{
+		{"氏名", "生年月日", "開始日", "終了日", "発行者", "サービス種別", "最大給付日数", "受給者証番号", "市町村番号"},
+		{"山田太郎", "1980/04/01", "令和7年4月1日", "令和8年3月31日", "渋谷区", "生活介護", "２２", "１２３４５６７８９０", "１３１１３０"},
+		{"山田 太郎", "1980/04/01", "2025/04/01", "2026/03/31", "渋谷区", "生活介護", "22", "", ""},
+		{"田中次郎", "1990/01/01", "2025/04/01", "2026/03/31", "渋谷区", "生活介護", "22", "", ""},
+		{"山田太郎", "1980/04/01", "2025/04/01", "2024/03/31", "", "就労継続支援B型", "40", "", "131136"},
+	})
+	ctx := context.Background()
+
+	file := env.readFile(t, ImportKindCertificates)
+	result, err := env.uc.Import(ctx, ImportRequest{File: file, Mapping: file.Mapping, SkipInvalidRows: true, ActorID: "admin-001"})
+	if err != nil {
+		t.Fatalf("Import() error = %v", err)
+	}
+	if result.Imported != 1 || result.Duplicates != 1 || result.InvalidRows != 3 {
+		t.Errorf("Import() = %+v, want 1 imported, 3 rejected of which 1 duplicate", result)
+	}
+	if len(env.certRepo.certificates) != 1 {
+		t.Fatalf("certificates = %d, want 1", len(env.certRepo.certificates))
+	}
+	for _, cert := range env.certRepo.certificates {
+		if cert.RecipientID != "recipient-001" || cert.MaxBenefitDaysPerMonth != 22 || cert.StartDate.Format("2006-01-02") != "2025-04-01" {
+			t.Errorf("certificate = %+v, want recipient-001 from 2025-04-01 with 22 days", cert)
+		}
+		if cert.CertificateNumber != "1234567890" || cert.MunicipalityCode != "131130" {
+			t.Errorf("certificate codes = %q, %q, want half-width digits", cert.CertificateNumber, cert.MunicipalityCode)
+		}
+	}
+
+	// Importing the same file again finds every certificate registered
+	result, err = env.uc.DryRun(ctx, ImportRequest{File: file, Mapping: file.Mapping, ActorID: "admin-001"})
+	if err != nil {
+		t.Fatalf("DryRun() error = %v", err)
+	}
+	if result.Duplicates != 2 || result.ValidRows() != 0 {
+		t.Errorf("DryRun() after import = %+v, want both 山田 rows as duplicates", result)
+	}
+}
+
+func TestImportUseCase_RequiresAdminAndMappedFields(t *testing.T) {
+	env := setupImportUseCase([][]string{{"名前", "誕生日"}, {"佐藤花子", "1985/08/20"}})
+	ctx := context.Background()
+
+	_, err := env.uc.ReadFile(ctx, ReadImportFileRequest{Kind: ImportKindRecipients, Source: strings.NewReader(""), FileName: "import.csv", ActorID: "staff-001"})
+	if !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("ReadFile() by staff error = %v, want ErrUnauthorized", err)
+	}
+
+	file := env.readFile(t, ImportKindRecipients)
+	_, err = env.uc.DryRun(ctx, ImportRequest{File: file, Mapping: file.Mapping, ActorID: "admin-001"})
+	var ucErr *UseCaseError
+	if !errors.As(err, &ucErr) || ucErr.Code != "VALIDATION_FAILED" || !strings.Contains(ucErr.Message, "生年月日") {
+		t.Errorf("DryRun() with unmapped fields error = %v, want VALIDATION_FAILED naming 生年月日", err)
+	}
+}
+
+func TestImportUseCase_FailedSaveReportsImportFailed(t *testing.T) {
+	env := setupImportUseCase([][]string{{"氏名", "性別", "生年月日"}, {"佐藤花子", "女性", "1985/08/20"}})
+	file := env.readFile(t, ImportKindRecipients)
+
+	// List succeeds, then Create fails inside the transaction
+	failing := &failingCreateRecipientRepository{mockRecipientRepository: env.recipientRepo}
+	env.uc.(*importUseCase).recipientRepo = failing
+
+	_, err := env.uc.Import(context.Background(), ImportRequest{File: file, Mapping: file.Mapping, ActorID: "admin-001"})
+	var ucErr *UseCaseError
+	if !errors.As(err, &ucErr) || ucErr.Code != "IMPORT_FAILED" {
+		t.Errorf("Import() error = %v, want IMPORT_FAILED", err)
+	}
+	if len(env.auditRepo.logs) != 0 {
+		t.Error("audit entry written for a failed import")
+	}
+}
+
+// failingCreateRecipientRepository fails every Create
+type failingCreateRecipientRepository struct {
+	*mockRecipientRepository
+}
+
+func (m *failingCreateRecipientRepository) Create(ctx context.Context, recipient *domain.Recipient) error {
+	return errors.New("disk full")
+}
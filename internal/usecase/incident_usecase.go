@@ -0,0 +1,649 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"shien-system/internal/domain"
+)
+
+// incidentStatisticsPageSize is the page size used when counting a month's reports
+const incidentStatisticsPageSize = 500
+
+// incidentUseCase implements IncidentUseCase interface
+type incidentUseCase struct {
+	incidentRepo  domain.IncidentReportRepository
+	recipientRepo domain.RecipientRepository
+	staffRepo     domain.StaffRepository
+	auditRepo     domain.AuditLogRepository
+
+	useCaseLogger
+}
+
+// NewIncidentUseCase creates a new incident usecase
+func NewIncidentUseCase(
+	incidentRepo domain.IncidentReportRepository,
+	recipientRepo domain.RecipientRepository,
+	staffRepo domain.StaffRepository,
+	auditRepo domain.AuditLogRepository,
+) IncidentUseCase {
+	return &incidentUseCase{
+		incidentRepo:  incidentRepo,
+		recipientRepo: recipientRepo,
+		staffRepo:     staffRepo,
+		auditRepo:     auditRepo,
+	}
+}
+
+// CreateIncident records a new report as a draft
+func (uc *incidentUseCase) CreateIncident(ctx context.Context, req CreateIncidentRequest) (*domain.IncidentReport, error) {
+	// Validate input
+	if err := uc.validateIncidentFields(req.OccurredAt, req.Location, req.Category, req.Severity, req.Description, req.Response, req.Prevention, req.ActorID); err != nil {
+		return nil, &UseCaseError{
+			Code:    "VALIDATION_FAILED",
+			Message: "入力値が不正です",
+			Cause:   err,
+		}
+	}
+
+	if _, err := uc.verifyWriter(ctx, req.ActorID); err != nil {
+		return nil, err
+	}
+
+	if err := uc.verifyParticipants(ctx, req.RecipientIDs, req.StaffIDs); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	report := &domain.IncidentReport{
+		ID:           domain.ID(uuid.New().String()),
+		OccurredAt:   req.OccurredAt.UTC(),
+		Location:     strings.TrimSpace(req.Location),
+		RecipientIDs: uniqueIDs(req.RecipientIDs),
+		StaffIDs:     uniqueIDs(req.StaffIDs),
+		Category:     req.Category,
+		Severity:     req.Severity,
+		Description:  strings.TrimSpace(req.Description),
+		Response:     strings.TrimSpace(req.Response),
+		Prevention:   strings.TrimSpace(req.Prevention),
+		Status:       domain.IncidentStatusDraft,
+		ReportedBy:   req.ActorID,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+
+	if err := uc.incidentRepo.Create(ctx, report); err != nil {
+		return nil, &UseCaseError{
+			Code:    "CREATION_FAILED",
+			Message: "事故・ヒヤリハット報告の作成に失敗しました",
+			Cause:   err,
+		}
+	}
+
+	uc.logAction(ctx, req.ActorID, "CREATE", report, now, "報告を作成しました")
+
+	return report, nil
+}
+
+// UpdateIncident edits a draft report. Only the reporter or an administrator may edit it.
+func (uc *incidentUseCase) UpdateIncident(ctx context.Context, req UpdateIncidentRequest) (*domain.IncidentReport, error) {
+	// Validate input
+	if err := uc.validateIncidentFields(req.OccurredAt, req.Location, req.Category, req.Severity, req.Description, req.Response, req.Prevention, req.ActorID); err != nil {
+		return nil, &UseCaseError{
+			Code:    "VALIDATION_FAILED",
+			Message: "入力値が不正です",
+			Cause:   err,
+		}
+	}
+
+	actor, err := uc.verifyWriter(ctx, req.ActorID)
+	if err != nil {
+		return nil, err
+	}
+
+	report, err := uc.getReport(ctx, req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if report.Status != domain.IncidentStatusDraft {
+		return nil, ErrInvalidIncidentStatus
+	}
+
+	if actor.Role != domain.RoleAdmin && report.ReportedBy != actor.ID {
+		return nil, ErrUnauthorized
+	}
+
+	if err := uc.verifyParticipants(ctx, req.RecipientIDs, req.StaffIDs); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	report.OccurredAt = req.OccurredAt.UTC()
+	report.Location = strings.TrimSpace(req.Location)
+	report.RecipientIDs = uniqueIDs(req.RecipientIDs)
+	report.StaffIDs = uniqueIDs(req.StaffIDs)
+	report.Category = req.Category
+	report.Severity = req.Severity
+	report.Description = strings.TrimSpace(req.Description)
+	report.Response = strings.TrimSpace(req.Response)
+	report.Prevention = strings.TrimSpace(req.Prevention)
+	report.UpdatedAt = now
+
+	if err := uc.saveReport(ctx, report); err != nil {
+		return nil, err
+	}
+
+	uc.logAction(ctx, req.ActorID, "UPDATE", report, now, "報告を更新しました")
+
+	return report, nil
+}
+
+// SubmitIncident submits a draft report for review
+func (uc *incidentUseCase) SubmitIncident(ctx context.Context, req IncidentWorkflowRequest) (*domain.IncidentReport, error) {
+	actor, err := uc.verifyWriter(ctx, req.ActorID)
+	if err != nil {
+		return nil, err
+	}
+
+	report, err := uc.getReport(ctx, req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if report.Status != domain.IncidentStatusDraft {
+		return nil, ErrInvalidIncidentStatus
+	}
+
+	if actor.Role != domain.RoleAdmin && report.ReportedBy != actor.ID {
+		return nil, ErrUnauthorized
+	}
+
+	// The municipal report form requires the response and preventive measures
+	var errors []string
+	if report.Response == "" {
+		errors = append(errors, "提出前に対応内容を入力してください")
+	}
+	if report.Prevention == "" {
+		errors = append(errors, "提出前に再発防止策を入力してください")
+	}
+	if len(errors) > 0 {
+		return nil, &UseCaseError{
+			Code:    "VALIDATION_FAILED",
+			Message: "入力値が不正です",
+			Cause:   fmt.Errorf("validation errors: %s", strings.Join(errors, ", ")),
+		}
+	}
+
+	now := time.Now().UTC()
+	report.Status = domain.IncidentStatusSubmitted
+	report.SubmittedAt = &now
+	report.UpdatedAt = now
+
+	if err := uc.saveReport(ctx, report); err != nil {
+		return nil, err
+	}
+
+	uc.logAction(ctx, req.ActorID, "SUBMIT", report, now, "報告を提出しました")
+
+	return report, nil
+}
+
+// ReviewIncident marks a submitted report as reviewed
+func (uc *incidentUseCase) ReviewIncident(ctx context.Context, req IncidentWorkflowRequest) (*domain.IncidentReport, error) {
+	if err := validateReviewComment(req.Comment, false); err != nil {
+		return nil, err
+	}
+
+	actor, err := uc.verifyWriter(ctx, req.ActorID)
+	if err != nil {
+		return nil, err
+	}
+
+	report, err := uc.getReport(ctx, req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if report.Status != domain.IncidentStatusSubmitted {
+		return nil, ErrInvalidIncidentStatus
+	}
+
+	// A second person must check every report, administrators included
+	if report.ReportedBy == actor.ID {
+		return nil, ErrUnauthorized
+	}
+
+	now := time.Now().UTC()
+	report.Status = domain.IncidentStatusReviewed
+	report.ReviewedBy = &actor.ID
+	report.ReviewedAt = &now
+	if comment := strings.TrimSpace(req.Comment); comment != "" {
+		report.ReviewComment = comment
+	}
+	report.UpdatedAt = now
+
+	if err := uc.saveReport(ctx, report); err != nil {
+		return nil, err
+	}
+
+	uc.logAction(ctx, req.ActorID, "REVIEW", report, now, "報告を確認しました")
+
+	return report, nil
+}
+
+// ApproveIncident approves a reviewed report. Only administrators other than
+// the reporter and the reviewer may approve.
+func (uc *incidentUseCase) ApproveIncident(ctx context.Context, req IncidentWorkflowRequest) (*domain.IncidentReport, error) {
+	if err := validateReviewComment(req.Comment, false); err != nil {
+		return nil, err
+	}
+
+	actor, err := uc.verifyWriter(ctx, req.ActorID)
+	if err != nil {
+		return nil, err
+	}
+
+	if actor.Role != domain.RoleAdmin {
+		return nil, ErrUnauthorized
+	}
+
+	report, err := uc.getReport(ctx, req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if report.Status != domain.IncidentStatusReviewed {
+		return nil, ErrInvalidIncidentStatus
+	}
+
+	// Approval is a separate check from reporting and reviewing
+	if report.ReportedBy == actor.ID || (report.ReviewedBy != nil && *report.ReviewedBy == actor.ID) {
+		return nil, ErrUnauthorized
+	}
+
+	now := time.Now().UTC()
+	report.Status = domain.IncidentStatusApproved
+	report.ApprovedBy = &actor.ID
+	report.ApprovedAt = &now
+	if comment := strings.TrimSpace(req.Comment); comment != "" {
+		report.ReviewComment = comment
+	}
+	report.UpdatedAt = now
+
+	if err := uc.saveReport(ctx, report); err != nil {
+		return nil, err
+	}
+
+	uc.logAction(ctx, req.ActorID, "APPROVE", report, now, "報告を承認しました")
+
+	return report, nil
+}
+
+// ReturnIncident sends a submitted or reviewed report back to draft.
+// Reviewed reports can only be returned by an administrator.
+func (uc *incidentUseCase) ReturnIncident(ctx context.Context, req IncidentWorkflowRequest) (*domain.IncidentReport, error) {
+	if err := validateReviewComment(req.Comment, true); err != nil {
+		return nil, err
+	}
+
+	actor, err := uc.verifyWriter(ctx, req.ActorID)
+	if err != nil {
+		return nil, err
+	}
+
+	report, err := uc.getReport(ctx, req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	switch report.Status {
+	case domain.IncidentStatusSubmitted:
+		if report.ReportedBy == actor.ID {
+			return nil, ErrUnauthorized
+		}
+	case domain.IncidentStatusReviewed:
+		if actor.Role != domain.RoleAdmin {
+			return nil, ErrUnauthorized
+		}
+	default:
+		return nil, ErrInvalidIncidentStatus
+	}
+
+	now := time.Now().UTC()
+	report.Status = domain.IncidentStatusDraft
+	report.SubmittedAt = nil
+	report.ReviewedBy = nil
+	report.ReviewedAt = nil
+	report.ReviewComment = strings.TrimSpace(req.Comment)
+	report.UpdatedAt = now
+
+	if err := uc.saveReport(ctx, report); err != nil {
+		return nil, err
+	}
+
+	uc.logAction(ctx, req.ActorID, "RETURN", report, now, "報告を差し戻しました")
+
+	return report, nil
+}
+
+// GetIncident retrieves a report by ID
+func (uc *incidentUseCase) GetIncident(ctx context.Context, id domain.ID, actorID domain.ID) (*domain.IncidentReport, error) {
+	if _, err := uc.verifyActor(ctx, actorID); err != nil {
+		return nil, err
+	}
+
+	return uc.getReport(ctx, id)
+}
+
+// ListIncidents retrieves reports matching the filter, newest first
+func (uc *incidentUseCase) ListIncidents(ctx context.Context, req ListIncidentsRequest) ([]*domain.IncidentReport, error) {
+	if _, err := uc.verifyActor(ctx, req.ActorID); err != nil {
+		return nil, err
+	}
+
+	limit := req.Limit
+	if limit <= 0 {
+		limit = 50
+	}
+
+	reports, err := uc.incidentRepo.List(ctx, req.Filter, limit, req.Offset)
+	if err != nil {
+		return nil, &UseCaseError{
+			Code:    "RETRIEVAL_FAILED",
+			Message: "事故・ヒヤリハット報告の取得に失敗しました",
+			Cause:   err,
+		}
+	}
+
+	return reports, nil
+}
+
+// GetMonthlyStatistics counts the reports that occurred in a calendar month,
+// using the local time zone for the month boundaries
+func (uc *incidentUseCase) GetMonthlyStatistics(ctx context.Context, year int, month time.Month, actorID domain.ID) (*domain.IncidentStatistics, error) {
+	if month < time.January || month > time.December {
+		return nil, &UseCaseError{
+			Code:    "VALIDATION_FAILED",
+			Message: "入力値が不正です",
+			Cause:   fmt.Errorf("validation errors: 月は1から12の範囲で指定してください"),
+		}
+	}
+
+	if _, err := uc.verifyActor(ctx, actorID); err != nil {
+		return nil, err
+	}
+
+	from := time.Date(year, month, 1, 0, 0, 0, 0, time.Local)
+	to := from.AddDate(0, 1, 0)
+	filter := domain.IncidentFilter{OccurredFrom: &from, OccurredTo: &to}
+
+	stats := &domain.IncidentStatistics{
+		Year:       year,
+		Month:      month,
+		ByCategory: make(map[domain.IncidentCategory]int),
+		BySeverity: make(map[domain.IncidentSeverity]int),
+	}
+
+	for offset := 0; ; offset += incidentStatisticsPageSize {
+		reports, err := uc.incidentRepo.List(ctx, filter, incidentStatisticsPageSize, offset)
+		if err != nil {
+			return nil, &UseCaseError{
+				Code:    "RETRIEVAL_FAILED",
+				Message: "事故・ヒヤリハット報告の取得に失敗しました",
+				Cause:   err,
+			}
+		}
+
+		for _, report := range reports {
+			stats.Total++
+			stats.ByCategory[report.Category]++
+			stats.BySeverity[report.Severity]++
+			if report.Severity.IsAccident() {
+				stats.Accidents++
+			} else {
+				stats.NearMisses++
+			}
+		}
+
+		if len(reports) < incidentStatisticsPageSize {
+			break
+		}
+	}
+
+	return stats, nil
+}
+
+// verifyActor checks that the actor exists
+func (uc *incidentUseCase) verifyActor(ctx context.Context, actorID domain.ID) (*domain.Staff, error) {
+	actor, err := uc.staffRepo.GetByID(ctx, actorID)
+	if err != nil {
+		if err == domain.ErrNotFound {
+			return nil, ErrUnauthorized
+		}
+		return nil, &UseCaseError{
+			Code:    "INTERNAL_ERROR",
+			Message: "内部エラーが発生しました",
+			Cause:   err,
+		}
+	}
+	return actor, nil
+}
+
+// verifyWriter checks that the actor exists and may write reports
+func (uc *incidentUseCase) verifyWriter(ctx context.Context, actorID domain.ID) (*domain.Staff, error) {
+	actor, err := uc.verifyActor(ctx, actorID)
+	if err != nil {
+		return nil, err
+	}
+
+	if actor.Role != domain.RoleAdmin && actor.Role != domain.RoleStaff {
+		return nil, ErrUnauthorized
+	}
+
+	return actor, nil
+}
+
+// verifyParticipants checks that every involved recipient and staff member exists
+func (uc *incidentUseCase) verifyParticipants(ctx context.Context, recipientIDs, staffIDs []domain.ID) error {
+	for _, recipientID := range recipientIDs {
+		if _, err := uc.recipientRepo.GetByID(ctx, recipientID); err != nil {
+			if err == domain.ErrNotFound {
+				return ErrRecipientNotFound
+			}
+			return &UseCaseError{
+				Code:    "INTERNAL_ERROR",
+				Message: "内部エラーが発生しました",
+				Cause:   err,
+			}
+		}
+	}
+
+	for _, staffID := range staffIDs {
+		if _, err := uc.staffRepo.GetByID(ctx, staffID); err != nil {
+			if err == domain.ErrNotFound {
+				return ErrStaffNotFound
+			}
+			return &UseCaseError{
+				Code:    "INTERNAL_ERROR",
+				Message: "内部エラーが発生しました",
+				Cause:   err,
+			}
+		}
+	}
+
+	return nil
+}
+
+// getReport retrieves a report and maps a missing one to ErrIncidentNotFound
+func (uc *incidentUseCase) getReport(ctx context.Context, id domain.ID) (*domain.IncidentReport, error) {
+	report, err := uc.incidentRepo.GetByID(ctx, id)
+	if err != nil {
+		if err == domain.ErrNotFound {
+			return nil, ErrIncidentNotFound
+		}
+		return nil, &UseCaseError{
+			Code:    "RETRIEVAL_FAILED",
+			Message: "事故・ヒヤリハット報告の取得に失敗しました",
+			Cause:   err,
+		}
+	}
+	return report, nil
+}
+
+// saveReport persists changes to an existing report
+func (uc *incidentUseCase) saveReport(ctx context.Context, report *domain.IncidentReport) error {
+	if err := uc.incidentRepo.Update(ctx, report); err != nil {
+		if err == domain.ErrNotFound {
+			return ErrIncidentNotFound
+		}
+		return &UseCaseError{
+			Code:    "UPDATE_FAILED",
+			Message: "事故・ヒヤリハット報告の更新に失敗しました",
+			Cause:   err,
+		}
+	}
+	return nil
+}
+
+// logAction records an audit entry for an incident report. The free-text
+// description is left out because it usually names recipients.
+func (uc *incidentUseCase) logAction(ctx context.Context, actorID domain.ID, action string, report *domain.IncidentReport, at time.Time, details string) {
+	auditLog := &domain.AuditLog{
+		ID:      domain.ID(uuid.New().String()),
+		ActorID: actorID,
+		Action:  action,
+		Target:  fmt.Sprintf("incident:%s", report.ID),
+		At:      at,
+		IP:      uc.getClientIP(ctx),
+		Details: fmt.Sprintf("%s (%s / %s, 利用者: %d名)", details, report.Category.Label(), report.Severity.Label(), len(report.RecipientIDs)),
+	}
+
+	err := uc.auditRepo.Create(ctx, auditLog)
+	if err != nil {
+		// Log audit failure but don't fail the operation
+		uc.log().Warn("failed to write audit log", "action", auditLog.Action, "error", err)
+	}
+}
+
+// Validation functions
+
+func (uc *incidentUseCase) validateIncidentFields(
+	occurredAt time.Time,
+	location string,
+	category domain.IncidentCategory,
+	severity domain.IncidentSeverity,
+	description, response, prevention string,
+	actorID domain.ID,
+) error {
+	var errors []string
+
+	if occurredAt.IsZero() {
+		errors = append(errors, "発生日時は必須です")
+	} else if occurredAt.After(time.Now().Add(5 * time.Minute)) {
+		errors = append(errors, "発生日時に未来の日時は指定できません")
+	}
+
+	if len([]rune(location)) > 200 {
+		errors = append(errors, "発生場所は200文字以内で入力してください")
+	}
+
+	if !isValidIncidentCategory(category) {
+		errors = append(errors, "事故の種類が不正です")
+	}
+
+	if !isValidIncidentSeverity(severity) {
+		errors = append(errors, "程度の区分が不正です")
+	}
+
+	if strings.TrimSpace(description) == "" {
+		errors = append(errors, "発生状況は必須です")
+	} else if len([]rune(description)) > 2000 {
+		errors = append(errors, "発生状況は2000文字以内で入力してください")
+	}
+
+	if len([]rune(response)) > 2000 {
+		errors = append(errors, "対応内容は2000文字以内で入力してください")
+	}
+
+	if len([]rune(prevention)) > 2000 {
+		errors = append(errors, "再発防止策は2000文字以内で入力してください")
+	}
+
+	if actorID == "" {
+		errors = append(errors, "実行者IDは必須です")
+	}
+
+	if len(errors) > 0 {
+		return fmt.Errorf("validation errors: %s", strings.Join(errors, ", "))
+	}
+
+	return nil
+}
+
+func validateReviewComment(comment string, required bool) error {
+	var errors []string
+
+	if required && strings.TrimSpace(comment) == "" {
+		errors = append(errors, "差し戻し理由を入力してください")
+	}
+
+	if len([]rune(comment)) > 1000 {
+		errors = append(errors, "コメントは1000文字以内で入力してください")
+	}
+
+	if len(errors) > 0 {
+		return &UseCaseError{
+			Code:    "VALIDATION_FAILED",
+			Message: "入力値が不正です",
+			Cause:   fmt.Errorf("validation errors: %s", strings.Join(errors, ", ")),
+		}
+	}
+
+	return nil
+}
+
+// Helper functions
+
+func isValidIncidentCategory(category domain.IncidentCategory) bool {
+	for _, c := range domain.IncidentCategories {
+		if c == category {
+			return true
+		}
+	}
+	return false
+}
+
+func isValidIncidentSeverity(severity domain.IncidentSeverity) bool {
+	for _, s := range domain.IncidentSeverities {
+		if s == severity {
+			return true
+		}
+	}
+	return false
+}
+
+func uniqueIDs(ids []domain.ID) []domain.ID {
+	seen := make(map[domain.ID]bool, len(ids))
+	unique := make([]domain.ID, 0, len(ids))
+	for _, id := range ids {
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		unique = append(unique, id)
+	}
+	return unique
+}
+
+func (uc *incidentUseCase) getClientIP(ctx context.Context) string {
+	if ip := ctx.Value(ContextKeyClientIP); ip != nil {
+		if clientIP, ok := ip.(string); ok {
+			return clientIP
+		}
+	}
+	return "unknown"
+}
@@ -0,0 +1,409 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"shien-system/internal/domain"
+)
+
+type mockIncidentReportRepository struct {
+	reports map[domain.ID]*domain.IncidentReport
+}
+
+func (m *mockIncidentReportRepository) Create(ctx context.Context, report *domain.IncidentReport) error {
+	if m.reports == nil {
+		m.reports = make(map[domain.ID]*domain.IncidentReport)
+	}
+	copied := *report
+	m.reports[report.ID] = &copied
+	return nil
+}
+
+func (m *mockIncidentReportRepository) GetByID(ctx context.Context, id domain.ID) (*domain.IncidentReport, error) {
+	report, exists := m.reports[id]
+	if !exists {
+		return nil, domain.ErrNotFound
+	}
+	copied := *report
+	return &copied, nil
+}
+
+func (m *mockIncidentReportRepository) Update(ctx context.Context, report *domain.IncidentReport) error {
+	if _, exists := m.reports[report.ID]; !exists {
+		return domain.ErrNotFound
+	}
+	copied := *report
+	m.reports[report.ID] = &copied
+	return nil
+}
+
+func (m *mockIncidentReportRepository) List(ctx context.Context, filter domain.IncidentFilter, limit, offset int) ([]*domain.IncidentReport, error) {
+	var result []*domain.IncidentReport
+	for _, report := range m.reports {
+		if filter.OccurredFrom != nil && report.OccurredAt.Before(*filter.OccurredFrom) {
+			continue
+		}
+		if filter.OccurredTo != nil && !report.OccurredAt.Before(*filter.OccurredTo) {
+			continue
+		}
+		if filter.Status != nil && report.Status != *filter.Status {
+			continue
+		}
+		if filter.RecipientID != nil && !slices.Contains(report.RecipientIDs, *filter.RecipientID) {
+			continue
+		}
+		copied := *report
+		result = append(result, &copied)
+	}
+
+	if offset >= len(result) {
+		return nil, nil
+	}
+	end := offset + limit
+	if end > len(result) {
+		end = len(result)
+	}
+	return result[offset:end], nil
+}
+
+func setupIncidentUseCase() (IncidentUseCase, *mockIncidentReportRepository, *mockAuditLogRepository) {
+	now := time.Now().UTC()
+	recipientRepo := &mockRecipientRepository{
+		recipients: map[domain.ID]*domain.Recipient{
+			"recipient-001": {ID: "recipient-001", Name: "事故太郎", CreatedAt: now, UpdatedAt: now},
+		},
+	}
+	staffRepo := &mockStaffRepository{
+		staff: map[domain.ID]*domain.Staff{
+			"staff-001":    {ID: "staff-001", Name: "報告者", Role: domain.RoleStaff},
+			"staff-002":    {ID: "staff-002", Name: "確認者", Role: domain.RoleStaff},
+			"admin-001":    {ID: "admin-001", Name: "管理者", Role: domain.RoleAdmin},
+			"admin-002":    {ID: "admin-002", Name: "承認者", Role: domain.RoleAdmin},
+			"readonly-001": {ID: "readonly-001", Name: "閲覧者", Role: domain.RoleReadOnly},
+		},
+	}
+	incidentRepo := &mockIncidentReportRepository{}
+	auditRepo := &mockAuditLogRepository{}
+
+	return NewIncidentUseCase(incidentRepo, recipientRepo, staffRepo, auditRepo), incidentRepo, auditRepo
+}
+
+func validCreateIncidentRequest() CreateIncidentRequest {
+	return CreateIncidentRequest{
+		OccurredAt:   time.Now().Add(-2 * time.Hour),
+		Location:     "食堂",
+		RecipientIDs: []domain.ID{"recipient-001", "recipient-001"},
+		StaffIDs:     []domain.ID{"staff-001"},
+		Category:     domain.IncidentCategoryChoking,
+		Severity:     domain.IncidentSeverityNearMiss,
+		Description:  "昼食時にむせ込みがあった",
+		Response:     "背部叩打で回復",
+		Prevention:   "刻み食に変更する",
+		ActorID:      "staff-001",
+	}
+}
+
+func TestIncidentUseCase_Workflow(t *testing.T) {
+	uc, _, auditRepo := setupIncidentUseCase()
+	ctx := context.Background()
+
+	report, err := uc.CreateIncident(ctx, validCreateIncidentRequest())
+	if err != nil {
+		t.Fatalf("CreateIncident() error = %v", err)
+	}
+	if report.Status != domain.IncidentStatusDraft || report.ReportedBy != "staff-001" {
+		t.Errorf("CreateIncident() = %+v", report)
+	}
+	if len(report.RecipientIDs) != 1 {
+		t.Errorf("duplicate recipients should be removed, got %v", report.RecipientIDs)
+	}
+
+	if _, err := uc.SubmitIncident(ctx, IncidentWorkflowRequest{ID: report.ID, ActorID: "staff-001"}); err != nil {
+		t.Fatalf("SubmitIncident() error = %v", err)
+	}
+
+	// Reporters cannot review their own report
+	if _, err := uc.ReviewIncident(ctx, IncidentWorkflowRequest{ID: report.ID, ActorID: "staff-001"}); err != ErrUnauthorized {
+		t.Errorf("ReviewIncident() by reporter error = %v, want ErrUnauthorized", err)
+	}
+
+	// Approval requires a review first
+	if _, err := uc.ApproveIncident(ctx, IncidentWorkflowRequest{ID: report.ID, ActorID: "admin-001"}); err != ErrInvalidIncidentStatus {
+		t.Errorf("ApproveIncident() before review error = %v, want ErrInvalidIncidentStatus", err)
+	}
+
+	reviewed, err := uc.ReviewIncident(ctx, IncidentWorkflowRequest{ID: report.ID, Comment: "確認しました", ActorID: "staff-002"})
+	if err != nil {
+		t.Fatalf("ReviewIncident() error = %v", err)
+	}
+	if reviewed.ReviewedBy == nil || *reviewed.ReviewedBy != "staff-002" {
+		t.Errorf("ReviewedBy = %v, want staff-002", reviewed.ReviewedBy)
+	}
+
+	if _, err := uc.ApproveIncident(ctx, IncidentWorkflowRequest{ID: report.ID, ActorID: "staff-002"}); err != ErrUnauthorized {
+		t.Errorf("ApproveIncident() by staff error = %v, want ErrUnauthorized", err)
+	}
+
+	approved, err := uc.ApproveIncident(ctx, IncidentWorkflowRequest{ID: report.ID, ActorID: "admin-001"})
+	if err != nil {
+		t.Fatalf("ApproveIncident() error = %v", err)
+	}
+	if approved.Status != domain.IncidentStatusApproved || approved.ApprovedAt == nil {
+		t.Errorf("ApproveIncident() = %+v", approved)
+	}
+
+	// Approved reports are frozen
+	update := UpdateIncidentRequest{
+		ID:          report.ID,
+		OccurredAt:  report.OccurredAt,
+		Category:    report.Category,
+		Severity:    report.Severity,
+		Description: "書き換え",
+		ActorID:     "admin-001",
+	}
+	if _, err := uc.UpdateIncident(ctx, update); err != ErrInvalidIncidentStatus {
+		t.Errorf("UpdateIncident() after approval error = %v, want ErrInvalidIncidentStatus", err)
+	}
+
+	actions := make([]string, 0, len(auditRepo.logs))
+	for _, log := range auditRepo.logs {
+		actions = append(actions, log.Action)
+		if log.Target != "incident:"+report.ID {
+			t.Errorf("unexpected audit target %q", log.Target)
+		}
+	}
+	want := []string{"CREATE", "SUBMIT", "REVIEW", "APPROVE"}
+	if len(actions) != len(want) {
+		t.Fatalf("audit actions = %v, want %v", actions, want)
+	}
+	for i := range want {
+		if actions[i] != want[i] {
+			t.Errorf("audit actions = %v, want %v", actions, want)
+			break
+		}
+	}
+}
+
+func TestIncidentUseCase_ReturnIncident(t *testing.T) {
+	uc, _, auditRepo := setupIncidentUseCase()
+	ctx := context.Background()
+
+	report, err := uc.CreateIncident(ctx, validCreateIncidentRequest())
+	if err != nil {
+		t.Fatalf("CreateIncident() error = %v", err)
+	}
+	if _, err := uc.SubmitIncident(ctx, IncidentWorkflowRequest{ID: report.ID, ActorID: "staff-001"}); err != nil {
+		t.Fatalf("SubmitIncident() error = %v", err)
+	}
+
+	var useCaseErr *UseCaseError
+	_, err = uc.ReturnIncident(ctx, IncidentWorkflowRequest{ID: report.ID, ActorID: "staff-002"})
+	if !errors.As(err, &useCaseErr) || useCaseErr.Code != "VALIDATION_FAILED" {
+		t.Errorf("ReturnIncident() without comment error = %v, want VALIDATION_FAILED", err)
+	}
+
+	returned, err := uc.ReturnIncident(ctx, IncidentWorkflowRequest{ID: report.ID, Comment: "再発防止策を具体的に", ActorID: "staff-002"})
+	if err != nil {
+		t.Fatalf("ReturnIncident() error = %v", err)
+	}
+	if returned.Status != domain.IncidentStatusDraft || returned.SubmittedAt != nil {
+		t.Errorf("ReturnIncident() = %+v", returned)
+	}
+	if returned.ReviewComment != "再発防止策を具体的に" {
+		t.Errorf("ReviewComment = %q", returned.ReviewComment)
+	}
+	if last := auditRepo.logs[len(auditRepo.logs)-1]; last.Action != "RETURN" {
+		t.Errorf("audit action = %q, want RETURN", last.Action)
+	}
+}
+
+func TestIncidentUseCase_Permissions(t *testing.T) {
+	uc, incidentRepo, _ := setupIncidentUseCase()
+	ctx := context.Background()
+
+	req := validCreateIncidentRequest()
+	req.ActorID = "readonly-001"
+	if _, err := uc.CreateIncident(ctx, req); err != ErrUnauthorized {
+		t.Errorf("CreateIncident() by read-only staff error = %v, want ErrUnauthorized", err)
+	}
+
+	report, err := uc.CreateIncident(ctx, validCreateIncidentRequest())
+	if err != nil {
+		t.Fatalf("CreateIncident() error = %v", err)
+	}
+
+	// Other staff cannot edit someone else's draft
+	_, err = uc.UpdateIncident(ctx, UpdateIncidentRequest{
+		ID:          report.ID,
+		OccurredAt:  report.OccurredAt,
+		Category:    report.Category,
+		Severity:    report.Severity,
+		Description: "別職員による編集",
+		ActorID:     "staff-002",
+	})
+	if err != ErrUnauthorized {
+		t.Errorf("UpdateIncident() by other staff error = %v, want ErrUnauthorized", err)
+	}
+
+	// Read-only staff can still view reports
+	if _, err := uc.GetIncident(ctx, report.ID, "readonly-001"); err != nil {
+		t.Errorf("GetIncident() by read-only staff error = %v", err)
+	}
+
+	if _, err := uc.GetIncident(ctx, "missing", "staff-001"); err != ErrIncidentNotFound {
+		t.Errorf("GetIncident() missing error = %v, want ErrIncidentNotFound", err)
+	}
+
+	if len(incidentRepo.reports) != 1 {
+		t.Errorf("expected 1 stored report, got %d", len(incidentRepo.reports))
+	}
+}
+
+func TestIncidentUseCase_CreateIncident_ValidationErrors(t *testing.T) {
+	ctx := context.Background()
+
+	testCases := []struct {
+		name   string
+		modify func(req *CreateIncidentRequest)
+	}{
+		{"missing occurred at", func(req *CreateIncidentRequest) { req.OccurredAt = time.Time{} }},
+		{"future occurred at", func(req *CreateIncidentRequest) { req.OccurredAt = time.Now().Add(24 * time.Hour) }},
+		{"unknown category", func(req *CreateIncidentRequest) { req.Category = "unknown" }},
+		{"unknown severity", func(req *CreateIncidentRequest) { req.Severity = "" }},
+		{"missing description", func(req *CreateIncidentRequest) { req.Description = "  " }},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			uc, incidentRepo, _ := setupIncidentUseCase()
+			req := validCreateIncidentRequest()
+			tc.modify(&req)
+
+			_, err := uc.CreateIncident(ctx, req)
+
+			var useCaseErr *UseCaseError
+			if !errors.As(err, &useCaseErr) || useCaseErr.Code != "VALIDATION_FAILED" {
+				t.Errorf("Expected VALIDATION_FAILED error, got %v", err)
+			}
+			if len(incidentRepo.reports) != 0 {
+				t.Error("no report should be stored")
+			}
+		})
+	}
+
+	uc, _, _ := setupIncidentUseCase()
+	req := validCreateIncidentRequest()
+	req.RecipientIDs = []domain.ID{"missing"}
+	if _, err := uc.CreateIncident(ctx, req); err != ErrRecipientNotFound {
+		t.Errorf("CreateIncident() with unknown recipient error = %v, want ErrRecipientNotFound", err)
+	}
+}
+
+func TestIncidentUseCase_GetMonthlyStatistics(t *testing.T) {
+	uc, incidentRepo, _ := setupIncidentUseCase()
+	ctx := context.Background()
+
+	incidentRepo.reports = map[domain.ID]*domain.IncidentReport{
+		"i1": {ID: "i1", OccurredAt: time.Date(2025, 4, 1, 9, 0, 0, 0, time.Local), Category: domain.IncidentCategoryFall, Severity: domain.IncidentSeverityNearMiss},
+		"i2": {ID: "i2", OccurredAt: time.Date(2025, 4, 15, 9, 0, 0, 0, time.Local), Category: domain.IncidentCategoryFall, Severity: domain.IncidentSeverityModerate},
+		"i3": {ID: "i3", OccurredAt: time.Date(2025, 4, 30, 23, 0, 0, 0, time.Local), Category: domain.IncidentCategoryMedication, Severity: domain.IncidentSeverityMinor},
+		"i4": {ID: "i4", OccurredAt: time.Date(2025, 5, 1, 0, 0, 0, 0, time.Local), Category: domain.IncidentCategoryFall, Severity: domain.IncidentSeverityNearMiss},
+	}
+
+	stats, err := uc.GetMonthlyStatistics(ctx, 2025, time.April, "readonly-001")
+	if err != nil {
+		t.Fatalf("GetMonthlyStatistics() error = %v", err)
+	}
+
+	if stats.Total != 3 || stats.NearMisses != 1 || stats.Accidents != 2 {
+		t.Errorf("totals = %d/%d/%d, want 3/1/2", stats.Total, stats.NearMisses, stats.Accidents)
+	}
+	if stats.ByCategory[domain.IncidentCategoryFall] != 2 || stats.ByCategory[domain.IncidentCategoryMedication] != 1 {
+		t.Errorf("ByCategory = %v", stats.ByCategory)
+	}
+	if stats.BySeverity[domain.IncidentSeverityModerate] != 1 {
+		t.Errorf("BySeverity = %v", stats.BySeverity)
+	}
+
+	if _, err := uc.GetMonthlyStatistics(ctx, 2025, 13, "staff-001"); err == nil {
+		t.Error("expected error for invalid month")
+	}
+}
+
+func TestIncidentUseCase_AdminCannotReviewOwnReport(t *testing.T) {
+	uc, _, _ := setupIncidentUseCase()
+	ctx := context.Background()
+
+	req := validCreateIncidentRequest()
+	req.ActorID = "admin-001"
+	report, err := uc.CreateIncident(ctx, req)
+	if err != nil {
+		t.Fatalf("CreateIncident() error = %v", err)
+	}
+	if _, err := uc.SubmitIncident(ctx, IncidentWorkflowRequest{ID: report.ID, ActorID: "admin-001"}); err != nil {
+		t.Fatalf("SubmitIncident() error = %v", err)
+	}
+
+	// The second-person check applies to administrators as well
+	if _, err := uc.ReviewIncident(ctx, IncidentWorkflowRequest{ID: report.ID, ActorID: "admin-001"}); err != ErrUnauthorized {
+		t.Errorf("ReviewIncident() by reporting admin error = %v, want ErrUnauthorized", err)
+	}
+	if _, err := uc.ReturnIncident(ctx, IncidentWorkflowRequest{ID: report.ID, Comment: "修正", ActorID: "admin-001"}); err != ErrUnauthorized {
+		t.Errorf("ReturnIncident() by reporting admin error = %v, want ErrUnauthorized", err)
+	}
+	if _, err := uc.ReviewIncident(ctx, IncidentWorkflowRequest{ID: report.ID, ActorID: "staff-001"}); err != nil {
+		t.Errorf("ReviewIncident() by another staff member error = %v", err)
+	}
+}
+
+func TestIncidentUseCase_ReporterCannotApprove(t *testing.T) {
+	uc, _, _ := setupIncidentUseCase()
+	ctx := context.Background()
+
+	req := validCreateIncidentRequest()
+	req.ActorID = "admin-001"
+	report, err := uc.CreateIncident(ctx, req)
+	if err != nil {
+		t.Fatalf("CreateIncident() error = %v", err)
+	}
+	if _, err := uc.SubmitIncident(ctx, IncidentWorkflowRequest{ID: report.ID, ActorID: "admin-001"}); err != nil {
+		t.Fatalf("SubmitIncident() error = %v", err)
+	}
+	if _, err := uc.ReviewIncident(ctx, IncidentWorkflowRequest{ID: report.ID, ActorID: "staff-002"}); err != nil {
+		t.Fatalf("ReviewIncident() error = %v", err)
+	}
+
+	if _, err := uc.ApproveIncident(ctx, IncidentWorkflowRequest{ID: report.ID, ActorID: "admin-001"}); err != ErrUnauthorized {
+		t.Errorf("ApproveIncident() by reporting admin error = %v, want ErrUnauthorized", err)
+	}
+	if _, err := uc.ApproveIncident(ctx, IncidentWorkflowRequest{ID: report.ID, ActorID: "admin-002"}); err != nil {
+		t.Errorf("ApproveIncident() by another admin error = %v", err)
+	}
+}
+
+func TestIncidentUseCase_ReviewerCannotApprove(t *testing.T) {
+	uc, _, _ := setupIncidentUseCase()
+	ctx := context.Background()
+
+	report, err := uc.CreateIncident(ctx, validCreateIncidentRequest())
+	if err != nil {
+		t.Fatalf("CreateIncident() error = %v", err)
+	}
+	if _, err := uc.SubmitIncident(ctx, IncidentWorkflowRequest{ID: report.ID, ActorID: "staff-001"}); err != nil {
+		t.Fatalf("SubmitIncident() error = %v", err)
+	}
+	if _, err := uc.ReviewIncident(ctx, IncidentWorkflowRequest{ID: report.ID, ActorID: "admin-001"}); err != nil {
+		t.Fatalf("ReviewIncident() error = %v", err)
+	}
+
+	if _, err := uc.ApproveIncident(ctx, IncidentWorkflowRequest{ID: report.ID, ActorID: "admin-001"}); err != ErrUnauthorized {
+		t.Errorf("ApproveIncident() by reviewing admin error = %v, want ErrUnauthorized", err)
+	}
+	if _, err := uc.ApproveIncident(ctx, IncidentWorkflowRequest{ID: report.ID, ActorID: "admin-002"}); err != nil {
+		t.Errorf("ApproveIncident() by another admin error = %v", err)
+	}
+}
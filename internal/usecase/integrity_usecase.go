@@ -0,0 +1,249 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"shien-system/internal/domain"
+)
+
+// maxVerifiedBackupCandidates limits how many backups are validated when
+// looking for the newest one that is intact
+const maxVerifiedBackupCandidates = 20
+
+// integrityUseCase implements IntegrityUseCase interface
+type integrityUseCase struct {
+	inspector IntegrityInspector
+	backups   BackupRestorer
+	staffRepo domain.StaffRepository
+	auditRepo domain.AuditLogRepository
+
+	useCaseLogger
+}
+
+// NewIntegrityUseCase creates a new database integrity usecase. backups may be
+// nil when the backup service is unavailable; restoring is then refused.
+func NewIntegrityUseCase(
+	inspector IntegrityInspector,
+	backups BackupRestorer,
+	staffRepo domain.StaffRepository,
+	auditRepo domain.AuditLogRepository,
+) IntegrityUseCase {
+	return &integrityUseCase{
+		inspector: inspector,
+		backups:   backups,
+		staffRepo: staffRepo,
+		auditRepo: auditRepo,
+	}
+}
+
+// RunCheck runs every check and records the outcome in the audit log
+func (uc *integrityUseCase) RunCheck(ctx context.Context, actorID domain.ID) (*IntegrityReport, error) {
+	if _, err := verifyAdmin(ctx, uc.staffRepo, actorID); err != nil {
+		return nil, err
+	}
+
+	report := &IntegrityReport{CheckedAt: time.Now()}
+
+	var err error
+	if report.IntegrityErrors, err = uc.inspector.IntegrityCheck(ctx); err != nil {
+		return nil, uc.checkError("integrity_check", err)
+	}
+	if report.ForeignKeyViolations, err = uc.inspector.ForeignKeyCheck(ctx); err != nil {
+		return nil, uc.checkError("foreign_key_check", err)
+	}
+	if report.UndecryptableRows, report.CheckedValues, err = uc.inspector.FindUndecryptableRows(ctx); err != nil {
+		return nil, uc.checkError("decryption", err)
+	}
+	if report.Orphans, err = uc.inspector.FindOrphanedRows(ctx); err != nil {
+		return nil, uc.checkError("orphans", err)
+	}
+
+	if !report.Healthy() {
+		uc.log().Warn("database integrity check found problems",
+			"integrity_errors", len(report.IntegrityErrors),
+			"foreign_key_violations", len(report.ForeignKeyViolations),
+			"undecryptable_rows", len(report.UndecryptableRows),
+			"orphan_checks", len(report.Orphans))
+	}
+	uc.logAction(ctx, actorID, "INTEGRITY_CHECK", describeIntegrityReport(report))
+
+	return report, nil
+}
+
+// QuarantineUndecryptable moves the rows that currently fail to decrypt. The
+// rows are found again rather than taken from an earlier report.
+func (uc *integrityUseCase) QuarantineUndecryptable(ctx context.Context, req QuarantineRowsRequest) (int, error) {
+	reason := strings.TrimSpace(req.Reason)
+	if errs := validateSecurityReason(reason); len(errs) > 0 {
+		return 0, securityValidationError(errs)
+	}
+
+	if _, err := verifyAdmin(ctx, uc.staffRepo, req.ActorID); err != nil {
+		return 0, err
+	}
+
+	rows, _, err := uc.inspector.FindUndecryptableRows(ctx)
+	if err != nil {
+		return 0, uc.checkError("decryption", err)
+	}
+	if len(rows) == 0 {
+		return 0, ErrNothingToQuarantine
+	}
+
+	moved, err := uc.inspector.QuarantineRows(ctx, rows, reason, req.ActorID)
+	if err != nil {
+		return 0, &UseCaseError{
+			Code:    "QUARANTINE_FAILED",
+			Message: "復号できない行の隔離に失敗しました",
+			Cause:   err,
+		}
+	}
+
+	uc.logAction(ctx, req.ActorID, "INTEGRITY_QUARANTINE",
+		fmt.Sprintf("%d undecryptable row(s) quarantined with %d dependent row(s) [%s]: %s",
+			len(rows), moved-len(rows), describeUndecryptableTables(rows), reason))
+
+	return moved, nil
+}
+
+// RestoreLastVerifiedBackup validates backups newest first and restores the first intact one
+func (uc *integrityUseCase) RestoreLastVerifiedBackup(ctx context.Context, req RestoreVerifiedBackupRequest) (*RestoreBackupResponse, error) {
+	reason := strings.TrimSpace(req.Reason)
+	if errs := validateSecurityReason(reason); len(errs) > 0 {
+		return nil, securityValidationError(errs)
+	}
+
+	if _, err := verifyAdmin(ctx, uc.staffRepo, req.ActorID); err != nil {
+		return nil, err
+	}
+
+	if uc.backups == nil {
+		return nil, ErrNoVerifiedBackup
+	}
+
+	list, err := uc.backups.ListBackups(ctx, ListBackupsRequest{Limit: maxVerifiedBackupCandidates})
+	if err != nil {
+		return nil, &UseCaseError{
+			Code:    "FETCH_FAILED",
+			Message: "バックアップ一覧の取得に失敗しました",
+			Cause:   err,
+		}
+	}
+
+	candidates := append([]BackupInfo(nil), list.Backups...)
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].CreatedAt.After(candidates[j].CreatedAt)
+	})
+
+	for _, candidate := range candidates {
+		validation, err := uc.backups.ValidateBackup(ctx, ValidateBackupRequest{BackupID: candidate.ID})
+		if err != nil {
+			uc.log().Warn("backup could not be validated, trying an older one", "backup_id", candidate.ID, "error", err)
+			continue
+		}
+		if !validation.Valid {
+			uc.log().Warn("backup failed validation, trying an older one", "backup_id", candidate.ID, "validation_errors", validation.Error)
+			continue
+		}
+
+		resp, err := uc.backups.RestoreBackup(ctx, RestoreBackupRequest{
+			BackupID:  candidate.ID,
+			ActorID:   string(req.ActorID),
+			Overwrite: true,
+		})
+		if err != nil {
+			return nil, &UseCaseError{
+				Code:    "RESTORE_FAILED",
+				Message: "バックアップからの復元に失敗しました",
+				Cause:   err,
+			}
+		}
+
+		uc.logAction(ctx, req.ActorID, "INTEGRITY_RESTORE",
+			fmt.Sprintf("Restored verified backup %s created %s: %s",
+				candidate.ID, candidate.CreatedAt.Format(time.RFC3339), reason))
+		return resp, nil
+	}
+
+	return nil, ErrNoVerifiedBackup
+}
+
+// Helper methods
+
+// describeIntegrityReport summarizes a report for the audit log
+func describeIntegrityReport(report *IntegrityReport) string {
+	if report.Healthy() {
+		return fmt.Sprintf("Integrity check passed, %d encrypted value(s) verified", report.CheckedValues)
+	}
+
+	orphans := 0
+	for _, orphan := range report.Orphans {
+		orphans += orphan.Count
+	}
+
+	return fmt.Sprintf("Integrity check found problems: %d integrity error(s), %d foreign key violation(s), "+
+		"%d undecryptable row(s) of %d encrypted value(s), %d orphaned row(s)",
+		len(report.IntegrityErrors), len(report.ForeignKeyViolations),
+		len(report.UndecryptableRows), report.CheckedValues, orphans)
+}
+
+// describeUndecryptableTables counts rows per table, e.g. "recipients=2, consents=1"
+func describeUndecryptableTables(rows []UndecryptableRow) string {
+	counts := make(map[string]int)
+	for _, row := range rows {
+		counts[row.Table]++
+	}
+
+	tables := make([]string, 0, len(counts))
+	for table := range counts {
+		tables = append(tables, table)
+	}
+	sort.Strings(tables)
+
+	parts := make([]string, len(tables))
+	for i, table := range tables {
+		parts[i] = fmt.Sprintf("%s=%d", table, counts[table])
+	}
+	return strings.Join(parts, ", ")
+}
+
+func (uc *integrityUseCase) checkError(check string, err error) error {
+	return &UseCaseError{
+		Code:    "INTEGRITY_CHECK_FAILED",
+		Message: "整合性チェックを実行できませんでした",
+		Cause:   fmt.Errorf("%s: %w", check, err),
+	}
+}
+
+// logAction records an integrity check or repair as a security event
+func (uc *integrityUseCase) logAction(ctx context.Context, actorID domain.ID, action, details string) {
+	auditLog := &domain.AuditLog{
+		ID:      domain.ID(uuid.New().String()),
+		ActorID: actorID,
+		Action:  action,
+		Target:  securityAuditTarget,
+		At:      time.Now(),
+		IP:      uc.getClientIP(ctx),
+		Details: details,
+	}
+
+	err := uc.auditRepo.Create(ctx, auditLog)
+	if err != nil {
+		// Log audit failure but don't fail the operation
+		uc.log().Warn("failed to write audit log", "action", auditLog.Action, "error", err)
+	}
+}
+
+func (uc *integrityUseCase) getClientIP(ctx context.Context) string {
+	if ip := ctx.Value(ContextKeyClientIP); ip != nil {
+		if clientIP, ok := ip.(string); ok {
+			return clientIP
+		}
+	}
+	return "unknown"
+}
@@ -0,0 +1,193 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"shien-system/internal/domain"
+)
+
+// fakeIntegrityInspector returns fixed findings and records quarantines
+type fakeIntegrityInspector struct {
+	integrityErrors []string
+	undecryptable   []UndecryptableRow
+	orphans         []OrphanedRows
+	quarantined     []UndecryptableRow
+	dependents      int
+}
+
+func (f *fakeIntegrityInspector) IntegrityCheck(ctx context.Context) ([]string, error) {
+	return f.integrityErrors, nil
+}
+
+func (f *fakeIntegrityInspector) ForeignKeyCheck(ctx context.Context) ([]ForeignKeyViolation, error) {
+	return nil, nil
+}
+
+func (f *fakeIntegrityInspector) FindUndecryptableRows(ctx context.Context) ([]UndecryptableRow, int, error) {
+	return f.undecryptable, 42, nil
+}
+
+func (f *fakeIntegrityInspector) FindOrphanedRows(ctx context.Context) ([]OrphanedRows, error) {
+	return f.orphans, nil
+}
+
+func (f *fakeIntegrityInspector) QuarantineRows(ctx context.Context, rows []UndecryptableRow, reason string, actorID domain.ID) (int, error) {
+	f.quarantined = append(f.quarantined, rows...)
+	f.undecryptable = nil
+	return len(rows) + f.dependents, nil
+}
+
+// fakeBackupRestorer serves a fixed backup list; IDs in invalid fail validation
+type fakeBackupRestorer struct {
+	backups  []BackupInfo
+	invalid  map[string]bool
+	restored string
+}
+
+func (f *fakeBackupRestorer) ListBackups(ctx context.Context, req ListBackupsRequest) (*ListBackupsResponse, error) {
+	return &ListBackupsResponse{Backups: f.backups, Total: len(f.backups)}, nil
+}
+
+func (f *fakeBackupRestorer) ValidateBackup(ctx context.Context, req ValidateBackupRequest) (*ValidateBackupResponse, error) {
+	if f.invalid[req.BackupID] {
+		return &ValidateBackupResponse{BackupID: req.BackupID, Valid: false, Error: "checksum mismatch"}, nil
+	}
+	return &ValidateBackupResponse{BackupID: req.BackupID, Valid: true}, nil
+}
+
+func (f *fakeBackupRestorer) RestoreBackup(ctx context.Context, req RestoreBackupRequest) (*RestoreBackupResponse, error) {
+	if !req.Overwrite {
+		return nil, errors.New("restore must overwrite the damaged database")
+	}
+	f.restored = req.BackupID
+	return &RestoreBackupResponse{Success: true, BackupID: req.BackupID}, nil
+}
+
+func newTestIntegrityUseCase(inspector *fakeIntegrityInspector, backups BackupRestorer) (IntegrityUseCase, *mockAuditLogRepository) {
+	auditRepo := &mockAuditLogRepository{}
+	staffRepo := &mockStaffRepository{staff: map[domain.ID]*domain.Staff{
+		"admin-001": {ID: "admin-001", Name: "管理者", Role: domain.RoleAdmin},
+		"staff-001": {ID: "staff-001", Name: "職員", Role: domain.RoleStaff},
+	}}
+	return NewIntegrityUseCase(inspector, backups, staffRepo, auditRepo), auditRepo
+}
+
+func TestIntegrityUseCase_RunCheck(t *testing.T) {
+	inspector := &fakeIntegrityInspector{}
+	uc, auditRepo := newTestIntegrityUseCase(inspector, nil)
+	ctx := context.Background()
+
+	if _, err := uc.RunCheck(ctx, "staff-001"); err != ErrUnauthorized {
+		t.Errorf("RunCheck() by staff = %v, want ErrUnauthorized", err)
+	}
+
+	report, err := uc.RunCheck(ctx, "admin-001")
+	if err != nil {
+		t.Fatalf("RunCheck() error = %v", err)
+	}
+	if !report.Healthy() || report.CheckedValues != 42 {
+		t.Errorf("unexpected report: %+v", report)
+	}
+
+	inspector.undecryptable = []UndecryptableRow{{Table: "recipients", RowID: 3, Key: "r-003", Columns: []string{"name_cipher"}}}
+	inspector.orphans = []OrphanedRows{{Check: "削除された職員への担当割当", Table: "staff_assignments", Count: 2}}
+	report, err = uc.RunCheck(ctx, "admin-001")
+	if err != nil {
+		t.Fatalf("RunCheck() error = %v", err)
+	}
+	if report.Healthy() || report.NeedsRestore() {
+		t.Errorf("report should need repairs but not a restore: %+v", report)
+	}
+
+	if len(auditRepo.logs) != 2 {
+		t.Fatalf("expected 2 audit entries, got %d", len(auditRepo.logs))
+	}
+	last := auditRepo.logs[1]
+	if last.Action != "INTEGRITY_CHECK" || last.Target != securityAuditTarget ||
+		!strings.Contains(last.Details, "1 undecryptable row(s)") || !strings.Contains(last.Details, "2 orphaned row(s)") {
+		t.Errorf("unexpected audit entry: %+v", last)
+	}
+}
+
+func TestIntegrityUseCase_QuarantineUndecryptable(t *testing.T) {
+	inspector := &fakeIntegrityInspector{dependents: 3}
+	uc, auditRepo := newTestIntegrityUseCase(inspector, nil)
+	ctx := context.Background()
+
+	if _, err := uc.QuarantineUndecryptable(ctx, QuarantineRowsRequest{Reason: "鍵の紛失", ActorID: "admin-001"}); err != ErrNothingToQuarantine {
+		t.Errorf("QuarantineUndecryptable() with nothing to move = %v", err)
+	}
+
+	inspector.undecryptable = []UndecryptableRow{
+		{Table: "recipients", RowID: 3},
+		{Table: "consents", RowID: 8},
+	}
+	if _, err := uc.QuarantineUndecryptable(ctx, QuarantineRowsRequest{ActorID: "admin-001"}); err == nil {
+		t.Error("a reason should be required")
+	}
+	if _, err := uc.QuarantineUndecryptable(ctx, QuarantineRowsRequest{Reason: "鍵の紛失", ActorID: "staff-001"}); err != ErrUnauthorized {
+		t.Errorf("QuarantineUndecryptable() by staff = %v, want ErrUnauthorized", err)
+	}
+
+	moved, err := uc.QuarantineUndecryptable(ctx, QuarantineRowsRequest{Reason: "鍵の紛失", ActorID: "admin-001"})
+	if err != nil {
+		t.Fatalf("QuarantineUndecryptable() error = %v", err)
+	}
+	if moved != 5 || len(inspector.quarantined) != 2 {
+		t.Errorf("moved %d rows, quarantined %v", moved, inspector.quarantined)
+	}
+
+	last := auditRepo.logs[len(auditRepo.logs)-1]
+	if last.Action != "INTEGRITY_QUARANTINE" || !strings.Contains(last.Details, "consents=1, recipients=1") ||
+		!strings.Contains(last.Details, "3 dependent row(s)") {
+		t.Errorf("unexpected audit entry: %+v", last)
+	}
+}
+
+func TestIntegrityUseCase_RestoreLastVerifiedBackup(t *testing.T) {
+	now := time.Now()
+	backups := &fakeBackupRestorer{
+		backups: []BackupInfo{
+			{ID: "backup-old", CreatedAt: now.Add(-48 * time.Hour)},
+			{ID: "backup-newest", CreatedAt: now.Add(-1 * time.Hour)},
+			{ID: "backup-middle", CreatedAt: now.Add(-24 * time.Hour)},
+		},
+		invalid: map[string]bool{"backup-newest": true},
+	}
+	uc, auditRepo := newTestIntegrityUseCase(&fakeIntegrityInspector{}, backups)
+	ctx := context.Background()
+
+	var buf bytes.Buffer
+	uc.(LoggerSetter).SetLogger(slog.New(slog.NewTextHandler(&buf, nil)))
+
+	resp, err := uc.RestoreLastVerifiedBackup(ctx, RestoreVerifiedBackupRequest{Reason: "データベース破損", ActorID: "admin-001"})
+	if err != nil {
+		t.Fatalf("RestoreLastVerifiedBackup() error = %v", err)
+	}
+	if backups.restored != "backup-middle" || resp.BackupID != "backup-middle" {
+		t.Errorf("restored %q, want the newest valid backup", backups.restored)
+	}
+	if output := buf.String(); !strings.Contains(output, "backup_id=backup-newest") || !strings.Contains(output, "checksum mismatch") {
+		t.Errorf("rejected backup not logged with its validation errors: %q", output)
+	}
+	last := auditRepo.logs[len(auditRepo.logs)-1]
+	if last.Action != "INTEGRITY_RESTORE" || !strings.Contains(last.Details, "backup-middle") {
+		t.Errorf("unexpected audit entry: %+v", last)
+	}
+
+	backups.invalid = map[string]bool{"backup-old": true, "backup-newest": true, "backup-middle": true}
+	if _, err := uc.RestoreLastVerifiedBackup(ctx, RestoreVerifiedBackupRequest{Reason: "データベース破損", ActorID: "admin-001"}); err != ErrNoVerifiedBackup {
+		t.Errorf("RestoreLastVerifiedBackup() without valid backups = %v, want ErrNoVerifiedBackup", err)
+	}
+
+	noBackups, _ := newTestIntegrityUseCase(&fakeIntegrityInspector{}, nil)
+	if _, err := noBackups.RestoreLastVerifiedBackup(ctx, RestoreVerifiedBackupRequest{Reason: "データベース破損", ActorID: "admin-001"}); err != ErrNoVerifiedBackup {
+		t.Errorf("RestoreLastVerifiedBackup() without a backup service = %v, want ErrNoVerifiedBackup", err)
+	}
+}
@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"context"
+	"io"
 	"time"
 
 	"shien-system/internal/domain"
@@ -32,6 +33,71 @@ type RecipientUseCase interface {
 
 	// UnassignStaff removes staff assignment from a recipient
 	UnassignStaff(ctx context.Context, req UnassignStaffRequest) error
+
+	// GetRecipientsEnrolledOn retrieves recipients who were enrolled on the given date
+	GetRecipientsEnrolledOn(ctx context.Context, date time.Time) ([]*domain.Recipient, error)
+
+	// GetEnrollmentPeriods retrieves the enrollment history of a recipient, oldest first
+	GetEnrollmentPeriods(ctx context.Context, recipientID domain.ID) ([]*domain.EnrollmentPeriod, error)
+
+	// AdmitRecipient opens a new enrollment period for a discharged recipient
+	AdmitRecipient(ctx context.Context, req AdmitRecipientRequest) (*domain.EnrollmentPeriod, error)
+
+	// DischargeRecipient closes the open enrollment period of a recipient
+	DischargeRecipient(ctx context.Context, req DischargeRecipientRequest) (*domain.EnrollmentPeriod, error)
+}
+
+// RecipientMergeUseCase finds recipients registered more than once and merges
+// them (administrators only). Names are encrypted, so the comparison runs on
+// decrypted values in memory.
+type RecipientMergeUseCase interface {
+	// FindDuplicates compares every recipient by normalized kana, birth date,
+	// phone number and name and returns the likely duplicates, highest score first
+	FindDuplicates(ctx context.Context, req FindDuplicatesRequest) ([]*DuplicateCandidate, error)
+
+	// MergeRecipients moves the records of the duplicate to the surviving
+	// recipient, keeps the duplicate's data in the merge history and deletes
+	// it, all in one transaction (MERGE_RECIPIENT)
+	MergeRecipients(ctx context.Context, req MergeRecipientsRequest) (*domain.RecipientMerge, error)
+
+	// GetMergeHistory returns the recipients merged into a recipient, newest first
+	GetMergeHistory(ctx context.Context, recipientID domain.ID, actorID domain.ID) ([]*domain.RecipientMerge, error)
+}
+
+// PostalCodeUseCase looks up addresses in the offline postal code dictionary
+type PostalCodeUseCase interface {
+	// LookupAddress returns the dictionary entries for a postal code in any
+	// accepted format; none when the code is unknown
+	LookupAddress(ctx context.Context, code string) ([]*domain.PostalAddress, error)
+
+	// IsKnownPostalCode reports whether the code is in the dictionary. Every
+	// code is accepted while no dictionary has been imported.
+	IsKnownPostalCode(ctx context.Context, code string) (bool, error)
+
+	// ImportDictionary replaces the dictionary with Japan Post's KEN_ALL.CSV
+	// and returns the number of entries (administrators only, POSTAL_CODES_IMPORTED)
+	ImportDictionary(ctx context.Context, req ImportPostalCodesRequest) (int, error)
+
+	// DictionarySize returns the number of dictionary entries
+	DictionarySize(ctx context.Context) (int, error)
+}
+
+// MasterDataUseCase manages the municipality and service type masters used
+// to code benefit certificates
+type MasterDataUseCase interface {
+	// ListMunicipalities returns the municipality master ordered by code
+	ListMunicipalities(ctx context.Context) ([]*domain.Municipality, error)
+
+	// ListServiceTypes returns the service type master ordered by code
+	ListServiceTypes(ctx context.Context) ([]*domain.ServiceType, error)
+
+	// ImportMunicipalities replaces the municipality master with a CSV file and
+	// returns the number of entries (administrators only, MUNICIPALITIES_IMPORTED)
+	ImportMunicipalities(ctx context.Context, req ImportMasterDataRequest) (int, error)
+
+	// ImportServiceTypes replaces the service type master with a CSV file and
+	// returns the number of entries (administrators only, SERVICE_TYPES_IMPORTED)
+	ImportServiceTypes(ctx context.Context, req ImportMasterDataRequest) (int, error)
 }
 
 // StaffUseCase defines business operations for staff management
@@ -56,6 +122,9 @@ type StaffUseCase interface {
 
 	// GetAssignments retrieves assignments for a staff member
 	GetAssignments(ctx context.Context, staffID domain.ID) ([]*domain.StaffAssignment, error)
+
+	// UpdateDateStyle changes how dates are displayed to the actor (西暦 or 和暦)
+	UpdateDateStyle(ctx context.Context, req UpdateDateStyleRequest) (*domain.Staff, error)
 }
 
 // CertificateUseCase defines business operations for benefit certificate management
@@ -82,6 +151,165 @@ type CertificateUseCase interface {
 	ValidateCertificate(ctx context.Context, certificateID domain.ID, date time.Time) (*ValidationResult, error)
 }
 
+// EmergencyContactUseCase defines business operations for recipients' emergency contacts
+type EmergencyContactUseCase interface {
+	// AddEmergencyContact registers a guardian, family member or other contact for a recipient
+	AddEmergencyContact(ctx context.Context, req CreateEmergencyContactRequest) (*domain.EmergencyContact, error)
+
+	// UpdateEmergencyContact updates an emergency contact
+	UpdateEmergencyContact(ctx context.Context, req UpdateEmergencyContactRequest) (*domain.EmergencyContact, error)
+
+	// DeleteEmergencyContact removes an emergency contact
+	DeleteEmergencyContact(ctx context.Context, req DeleteEmergencyContactRequest) error
+
+	// GetEmergencyContacts retrieves the emergency contacts of a recipient in calling order
+	GetEmergencyContacts(ctx context.Context, recipientID domain.ID) ([]*domain.EmergencyContact, error)
+}
+
+// MedicalRecordUseCase defines business operations for recipients' medical information.
+// Read-only staff can neither view nor edit medical records.
+type MedicalRecordUseCase interface {
+	// GetMedicalRecord retrieves the medical record of a recipient
+	GetMedicalRecord(ctx context.Context, recipientID domain.ID, actorID domain.ID) (*domain.MedicalRecord, error)
+
+	// SaveMedicalRecord creates or replaces the medical record of a recipient
+	SaveMedicalRecord(ctx context.Context, req SaveMedicalRecordRequest) (*domain.MedicalRecord, error)
+}
+
+// IncidentUseCase defines business operations for accident and near-miss reports.
+// Reports move draft → submitted → reviewed → approved; a returned report goes back to draft.
+type IncidentUseCase interface {
+	// CreateIncident records a new report as a draft
+	CreateIncident(ctx context.Context, req CreateIncidentRequest) (*domain.IncidentReport, error)
+
+	// UpdateIncident edits a draft report
+	UpdateIncident(ctx context.Context, req UpdateIncidentRequest) (*domain.IncidentReport, error)
+
+	// SubmitIncident submits a draft report for review
+	SubmitIncident(ctx context.Context, req IncidentWorkflowRequest) (*domain.IncidentReport, error)
+
+	// ReviewIncident marks a submitted report as reviewed; reporters cannot review their own reports
+	ReviewIncident(ctx context.Context, req IncidentWorkflowRequest) (*domain.IncidentReport, error)
+
+	// ApproveIncident approves a reviewed report (administrators only)
+	ApproveIncident(ctx context.Context, req IncidentWorkflowRequest) (*domain.IncidentReport, error)
+
+	// ReturnIncident sends a submitted or reviewed report back to draft with a comment
+	ReturnIncident(ctx context.Context, req IncidentWorkflowRequest) (*domain.IncidentReport, error)
+
+	// GetIncident retrieves a report by ID
+	GetIncident(ctx context.Context, id domain.ID, actorID domain.ID) (*domain.IncidentReport, error)
+
+	// ListIncidents retrieves reports matching the filter, newest first
+	ListIncidents(ctx context.Context, req ListIncidentsRequest) ([]*domain.IncidentReport, error)
+
+	// GetMonthlyStatistics counts the reports of a month by category and severity
+	GetMonthlyStatistics(ctx context.Context, year int, month time.Month, actorID domain.ID) (*domain.IncidentStatistics, error)
+}
+
+// NotificationUseCase defines business operations for the in-app notification center
+type NotificationUseCase interface {
+	// RefreshNotifications runs the periodic checks, raising new notifications and resolving
+	// those whose condition no longer holds
+	RefreshNotifications(ctx context.Context) (*NotificationRefreshResult, error)
+
+	// ListNotifications retrieves the open notifications visible to the actor, newest first
+	ListNotifications(ctx context.Context, req ListNotificationsRequest) ([]*domain.Notification, error)
+
+	// CountUnread counts the open notifications the actor has not read
+	CountUnread(ctx context.Context, actorID domain.ID) (int, error)
+
+	// MarkRead marks a notification as read by the actor
+	MarkRead(ctx context.Context, id domain.ID, actorID domain.ID) error
+
+	// MarkAllRead marks every open notification visible to the actor as read
+	MarkAllRead(ctx context.Context, actorID domain.ID) error
+
+	// AcknowledgeNotification records that the actor has dealt with a notification
+	AcknowledgeNotification(ctx context.Context, req AcknowledgeNotificationRequest) error
+}
+
+// SecurityUseCase defines the administrator security dashboard operations
+type SecurityUseCase interface {
+	// GetDashboard gathers lockouts, recent failed logins, detected attack patterns and security events
+	GetDashboard(ctx context.Context, actorID domain.ID) (*SecurityDashboard, error)
+
+	// UnlockLockout lifts a lockout before it expires
+	UnlockLockout(ctx context.Context, req UnlockLockoutRequest) error
+
+	// CreateManualLockout locks a username and/or IP address for a fixed duration
+	CreateManualLockout(ctx context.Context, req ManualLockoutRequest) (*domain.AccountLockout, error)
+
+	// UpdateAttackPatternStatus blocks or resolves a detected attack pattern.
+	// Blocking locks out every source IP of the pattern.
+	UpdateAttackPatternStatus(ctx context.Context, req UpdateAttackPatternStatusRequest) error
+}
+
+// SessionUseCase lets staff see and end their login sessions; administrators can
+// manage the sessions of every user
+type SessionUseCase interface {
+	// ListSessions returns the actor's own sessions, or all sessions for administrators
+	ListSessions(ctx context.Context, req ListSessionsRequest) ([]*SessionSummary, error)
+
+	// RevokeSession ends a single session (SESSION_REVOKED)
+	RevokeSession(ctx context.Context, req RevokeSessionRequest) error
+
+	// RevokeAllSessions logs a user out everywhere (SESSIONS_REVOKED_ALL)
+	RevokeAllSessions(ctx context.Context, req RevokeAllSessionsRequest) (int, error)
+}
+
+// LogUseCase lets administrators read the application log
+type LogUseCase interface {
+	// ListEntries returns log entries newest first
+	ListEntries(ctx context.Context, req ListLogEntriesRequest) ([]*LogEntry, error)
+}
+
+// IntegrityUseCase checks the database for corruption and offers guided repairs
+// to administrators. Every check and repair is audit-logged.
+type IntegrityUseCase interface {
+	// RunCheck runs the SQLite checks, decrypts every encrypted column and looks for orphaned rows
+	RunCheck(ctx context.Context, actorID domain.ID) (*IntegrityReport, error)
+
+	// QuarantineUndecryptable moves rows that no longer decrypt into quarantine
+	// and returns the number of rows moved, including dependent rows
+	QuarantineUndecryptable(ctx context.Context, req QuarantineRowsRequest) (int, error)
+
+	// RestoreLastVerifiedBackup restores the newest backup that passes validation
+	RestoreLastVerifiedBackup(ctx context.Context, req RestoreVerifiedBackupRequest) (*RestoreBackupResponse, error)
+}
+
+// ImportUseCase imports recipients and benefit certificates in bulk from CSV
+// and Excel files (administrators only). Rows are validated like the edit
+// forms and saved in one transaction with a single IMPORT audit entry.
+type ImportUseCase interface {
+	// ReadFile reads an uploaded file and suggests a column for each field
+	ReadFile(ctx context.Context, req ReadImportFileRequest) (*ImportFile, error)
+
+	// DryRun validates every row and looks for duplicates without saving anything
+	DryRun(ctx context.Context, req ImportRequest) (*ImportResult, error)
+
+	// Import validates every row and saves the valid ones in one transaction.
+	// Nothing is saved while rows have errors unless SkipInvalidRows is set;
+	// the result tells whether the import was committed.
+	Import(ctx context.Context, req ImportRequest) (*ImportResult, error)
+}
+
+// RateLimitPolicyUseCase keeps the rate limit policy in config.yaml and the database in step
+type RateLimitPolicyUseCase interface {
+	// SyncFromConfig reconciles the stored policy with the config file at startup.
+	// A policy edited by an administrator is kept and the differences are reported as conflicts.
+	SyncFromConfig(ctx context.Context, fromFile *domain.RateLimitConfig) (*RateLimitSyncReport, error)
+
+	// GetPolicy returns the effective policy and its differences from the config file
+	GetPolicy(ctx context.Context, actorID domain.ID) (*RateLimitPolicy, error)
+
+	// UpdatePolicy changes the effective policy; it takes precedence over the config file
+	UpdatePolicy(ctx context.Context, req UpdateRateLimitPolicyRequest) (*domain.RateLimitConfig, error)
+
+	// ApplyConfigFile replaces the effective policy with the config file values
+	ApplyConfigFile(ctx context.Context, req ApplyRateLimitConfigRequest) (*domain.RateLimitConfig, error)
+}
+
 // AuditUseCase defines business operations for audit log management
 type AuditUseCase interface {
 	// LogAction records an audit log entry
@@ -97,6 +325,26 @@ type AuditUseCase interface {
 	GetAuditLogsByActor(ctx context.Context, actorID domain.ID, limit, offset int) ([]*domain.AuditLog, error)
 }
 
+// DisclosureUseCase defines business operations for personal information disclosure requests
+type DisclosureUseCase interface {
+	// CreateDisclosurePackage gathers every record tied to a recipient and renders it as PDF and JSON
+	CreateDisclosurePackage(ctx context.Context, req CreateDisclosureRequest) (*DisclosureResult, error)
+}
+
+// ExportUseCase keeps a record of personal data leaving the application as files
+type ExportUseCase interface {
+	// RecordExport audit-logs an export with the SHA-256 of the file (EXPORT_PDF,
+	// or EXPORT_SPREADSHEET for CSV and Excel). The file must not be written when
+	// recording fails. Read-only staff cannot export sensitive columns.
+	RecordExport(ctx context.Context, req RecordExportRequest) (*ExportRecord, error)
+
+	// SpreadsheetColumns lists the columns of a table the actor may export to CSV or Excel
+	SpreadsheetColumns(ctx context.Context, table ExportTable, actorID domain.ID) ([]ExportColumn, error)
+
+	// TraceExport finds the exports of a file by its SHA-256 (administrators only)
+	TraceExport(ctx context.Context, req TraceExportRequest) ([]*domain.AuditLog, error)
+}
+
 // BackupUseCase defines business operations for backup management
 // BackupUseCase interface moved to backup_usecase.go to avoid duplication
 
@@ -127,6 +375,29 @@ type PasswordHasher interface {
 	CheckPassword(hashedPassword, password string) error
 }
 
+// DisclosureReportGenerator renders a disclosure package as a PDF document
+type DisclosureReportGenerator interface {
+	// GenerateDisclosureReport renders the package, embedding the JSON export as an attachment.
+	// A non-empty password encrypts the PDF and its attachment.
+	GenerateDisclosureReport(ctx context.Context, pkg *domain.DisclosurePackage, jsonData []byte, password string) ([]byte, error)
+}
+
+// PostalDictionaryParser reads a postal code dictionary file such as KEN_ALL.CSV
+type PostalDictionaryParser interface {
+	Parse(r io.Reader) ([]*domain.PostalAddress, error)
+}
+
+// MasterDataParser reads the municipality and service type master CSV files
+type MasterDataParser interface {
+	ParseMunicipalities(r io.Reader) ([]*domain.Municipality, error)
+	ParseServiceTypes(r io.Reader) ([]*domain.ServiceType, error)
+}
+
+// ImportTableReader reads the rows of an uploaded CSV or XLSX file, header included
+type ImportTableReader interface {
+	Read(source io.Reader, fileName string) ([][]string, error)
+}
+
 // SessionManager defines interface for session management
 type SessionManager interface {
 	// CreateSession creates a new session for a user
@@ -145,6 +416,66 @@ type SessionManager interface {
 	CleanupExpiredSessions(ctx context.Context) error
 }
 
+// SessionRegistry is implemented by session managers that can list and revoke
+// sessions other than the caller's own
+type SessionRegistry interface {
+	// ListSessions returns the active sessions, only those of userID when it is not empty
+	ListSessions(ctx context.Context, userID domain.ID) ([]*Session, error)
+
+	// RevokeSession ends a session; its next ValidateSession fails with ErrSessionRevoked
+	RevokeSession(ctx context.Context, sessionID, reason string) error
+
+	// RevokeUserSessions ends every session of a user except keepSessionID and
+	// returns the number of sessions ended
+	RevokeUserSessions(ctx context.Context, userID domain.ID, keepSessionID, reason string) (int, error)
+}
+
+// Logger is the structured logger used by the usecases; *slog.Logger satisfies it.
+// Arguments after the message are alternating keys and values.
+type Logger interface {
+	Debug(msg string, args ...any)
+	Info(msg string, args ...any)
+	Warn(msg string, args ...any)
+	Error(msg string, args ...any)
+}
+
+// LoggerSetter is implemented by usecases that accept the application logger
+type LoggerSetter interface {
+	SetLogger(logger Logger)
+}
+
+// LogReader reads entries back from the application log
+type LogReader interface {
+	ReadEntries(ctx context.Context, query LogQuery) ([]*LogEntry, error)
+}
+
+// IntegrityInspector runs the low-level database checks for IntegrityUseCase
+type IntegrityInspector interface {
+	// IntegrityCheck returns the problems reported by PRAGMA integrity_check; none means ok
+	IntegrityCheck(ctx context.Context) ([]string, error)
+
+	// ForeignKeyCheck returns the rows reported by PRAGMA foreign_key_check
+	ForeignKeyCheck(ctx context.Context) ([]ForeignKeyViolation, error)
+
+	// FindUndecryptableRows decrypts every *_cipher column with the current key and
+	// returns the rows that fail, along with the number of values checked
+	FindUndecryptableRows(ctx context.Context) ([]UndecryptableRow, int, error)
+
+	// FindOrphanedRows counts rows whose owner no longer exists
+	FindOrphanedRows(ctx context.Context) ([]OrphanedRows, error)
+
+	// QuarantineRows moves rows and the rows that depend on them into quarantine
+	// and returns the number of rows moved
+	QuarantineRows(ctx context.Context, rows []UndecryptableRow, reason string, actorID domain.ID) (int, error)
+}
+
+// BackupRestorer lists, validates and restores backups; *BackupUseCase satisfies it
+type BackupRestorer interface {
+	ListBackups(ctx context.Context, req ListBackupsRequest) (*ListBackupsResponse, error)
+	ValidateBackup(ctx context.Context, req ValidateBackupRequest) (*ValidateBackupResponse, error)
+	RestoreBackup(ctx context.Context, req RestoreBackupRequest) (*RestoreBackupResponse, error)
+}
+
 // CSRFProtectedSessionManager extends SessionManager with CSRF protection
 type CSRFProtectedSessionManager interface {
 	SessionManager
@@ -155,6 +486,37 @@ type CSRFProtectedSessionManager interface {
 
 // Request/Response types for usecase operations
 
+type FindDuplicatesRequest struct {
+	MinScore int       // Candidates below this score are left out; 0 uses the default of 60
+	ActorID  domain.ID // Must be an administrator
+}
+
+// DuplicateCandidate is a pair of recipients that may be the same person.
+// First is the older record, suggested as the one to keep.
+type DuplicateCandidate struct {
+	First   *domain.Recipient
+	Second  *domain.Recipient
+	Score   int      // 0-100
+	Reasons []string // What matched, e.g. 生年月日が一致
+}
+
+type MergeRecipientsRequest struct {
+	SurvivorID domain.ID // Recipient kept
+	MergedID   domain.ID // Duplicate moved into the survivor and deleted
+	Reason     string
+	ActorID    domain.ID // Must be an administrator
+}
+
+type ImportPostalCodesRequest struct {
+	Source  io.Reader // KEN_ALL.CSV, Shift_JIS or UTF-8
+	ActorID domain.ID // Must be an administrator
+}
+
+type ImportMasterDataRequest struct {
+	Source  io.Reader // CSV, Shift_JIS or UTF-8
+	ActorID domain.ID // Must be an administrator
+}
+
 type CreateRecipientRequest struct {
 	Name             string
 	Kana             string
@@ -163,12 +525,20 @@ type CreateRecipientRequest struct {
 	DisabilityName   string
 	HasDisabilityID  bool
 	Grade            string
-	Address          string
+	Address          string // Ignored when any address component below is set
+	PostalCode       string
+	Prefecture       string
+	City             string
+	Street           string
 	Phone            string
 	Email            string
 	PublicAssistance bool
-	AdmissionDate    *time.Time
-	ActorID          domain.ID // For audit logging
+	// Structured disability data; HasDisabilityID is implied by any handbook
+	Handbooks          []domain.DisabilityHandbook
+	SupportCategory    *domain.SupportCategory
+	IntractableDisease *domain.IntractableDisease
+	AdmissionDate      *time.Time
+	ActorID            domain.ID // For audit logging
 }
 
 type UpdateRecipientRequest struct {
@@ -180,13 +550,22 @@ type UpdateRecipientRequest struct {
 	DisabilityName   string
 	HasDisabilityID  bool
 	Grade            string
-	Address          string
+	Address          string // Ignored when any address component below is set
+	PostalCode       string
+	Prefecture       string
+	City             string
+	Street           string
 	Phone            string
 	Email            string
 	PublicAssistance bool
-	AdmissionDate    *time.Time
-	DischargeDate    *time.Time
-	ActorID          domain.ID // For audit logging
+	// Structured disability data; HasDisabilityID is implied by any handbook
+	Handbooks          []domain.DisabilityHandbook
+	SupportCategory    *domain.SupportCategory
+	IntractableDisease *domain.IntractableDisease
+	AdmissionDate      *time.Time
+	DischargeDate      *time.Time
+	Version            int       // Version the edit is based on, see ErrEditConflict
+	ActorID            domain.ID // For audit logging
 }
 
 type ListRecipientsRequest struct {
@@ -220,6 +599,18 @@ type UnassignStaffRequest struct {
 	ActorID      domain.ID // For audit logging
 }
 
+type AdmitRecipientRequest struct {
+	RecipientID   domain.ID
+	AdmissionDate time.Time
+	ActorID       domain.ID // For audit logging
+}
+
+type DischargeRecipientRequest struct {
+	RecipientID   domain.ID
+	DischargeDate time.Time
+	ActorID       domain.ID // For audit logging
+}
+
 type CreateStaffRequest struct {
 	Name    string
 	Role    domain.StaffRole
@@ -230,9 +621,15 @@ type UpdateStaffRequest struct {
 	ID      domain.ID
 	Name    string
 	Role    domain.StaffRole
+	Version int       // Version the edit is based on, see ErrEditConflict
 	ActorID domain.ID // For audit logging
 }
 
+type UpdateDateStyleRequest struct {
+	DateStyle string    // wareki.StyleGregorian or wareki.StyleJapanese
+	ActorID   domain.ID // The staff member whose preference changes
+}
+
 type ListStaffRequest struct {
 	Limit    int
 	Offset   int
@@ -253,9 +650,12 @@ type PaginatedStaff struct {
 
 type CreateCertificateRequest struct {
 	RecipientID            domain.ID
+	CertificateNumber      string // 受給者証番号
 	StartDate              time.Time
 	EndDate                time.Time
+	MunicipalityCode       string // 市町村番号; Issuer is taken from the master when it is known
 	Issuer                 string
+	ServiceCode            string // サービス種類コード; ServiceType is taken from the master when it is known
 	ServiceType            string
 	MaxBenefitDaysPerMonth int
 	BenefitDetails         string
@@ -264,12 +664,16 @@ type CreateCertificateRequest struct {
 
 type UpdateCertificateRequest struct {
 	ID                     domain.ID
+	CertificateNumber      string
 	StartDate              time.Time
 	EndDate                time.Time
+	MunicipalityCode       string
 	Issuer                 string
+	ServiceCode            string
 	ServiceType            string
 	MaxBenefitDaysPerMonth int
 	BenefitDetails         string
+	Version                int       // Version the edit is based on, see ErrEditConflict
 	ActorID                domain.ID // For audit logging
 }
 
@@ -279,6 +683,422 @@ type ValidationResult struct {
 	ExpiresAt *time.Time
 }
 
+type CreateEmergencyContactRequest struct {
+	RecipientID  domain.ID
+	Priority     int // 0 appends the contact after the existing ones
+	Name         string
+	Relationship string
+	IsGuardian   bool
+	Phone        string
+	MobilePhone  string
+	WorkPhone    string
+	Email        string
+	Notes        string
+	ActorID      domain.ID // For audit logging
+}
+
+type UpdateEmergencyContactRequest struct {
+	ID           domain.ID
+	Priority     int
+	Name         string
+	Relationship string
+	IsGuardian   bool
+	Phone        string
+	MobilePhone  string
+	WorkPhone    string
+	Email        string
+	Notes        string
+	ActorID      domain.ID // For audit logging
+}
+
+type DeleteEmergencyContactRequest struct {
+	ID      domain.ID
+	ActorID domain.ID // For audit logging
+}
+
+type SaveMedicalRecordRequest struct {
+	RecipientID         domain.ID
+	Medications         []domain.Medication
+	Allergies           []domain.Allergy
+	HasEpilepsy         bool
+	SeizureProtocol     string
+	HospitalName        string
+	HospitalPhone       string
+	DoctorName          string
+	InsuranceType       string
+	InsurerNumber       string
+	InsuredSymbol       string
+	InsuredNumber       string
+	InsuranceValidUntil *time.Time
+	Notes               string
+	ActorID             domain.ID // For audit logging
+}
+
+type CreateIncidentRequest struct {
+	OccurredAt   time.Time
+	Location     string
+	RecipientIDs []domain.ID
+	StaffIDs     []domain.ID
+	Category     domain.IncidentCategory
+	Severity     domain.IncidentSeverity
+	Description  string
+	Response     string
+	Prevention   string
+	ActorID      domain.ID // Recorded as the reporter and for audit logging
+}
+
+type UpdateIncidentRequest struct {
+	ID           domain.ID
+	OccurredAt   time.Time
+	Location     string
+	RecipientIDs []domain.ID
+	StaffIDs     []domain.ID
+	Category     domain.IncidentCategory
+	Severity     domain.IncidentSeverity
+	Description  string
+	Response     string
+	Prevention   string
+	ActorID      domain.ID // For audit logging
+}
+
+type IncidentWorkflowRequest struct {
+	ID      domain.ID
+	Comment string    // Review comment; required when returning a report
+	ActorID domain.ID // For audit logging
+}
+
+type ListIncidentsRequest struct {
+	Filter  domain.IncidentFilter
+	Limit   int
+	Offset  int
+	ActorID domain.ID
+}
+
+type ListNotificationsRequest struct {
+	UnreadOnly bool
+	Limit      int
+	Offset     int
+	ActorID    domain.ID
+}
+
+type AcknowledgeNotificationRequest struct {
+	ID      domain.ID
+	Comment string    // Optional note on how it was handled, recorded in the audit log
+	ActorID domain.ID // For audit logging
+}
+
+// NotificationRefreshResult summarizes one run of the notification checks
+type NotificationRefreshResult struct {
+	Created  int
+	Resolved int
+}
+
+// NotificationSettings configures the notification checks
+type NotificationSettings struct {
+	// CertificateExpiryDays are the look-ahead thresholds for certificate expiry, e.g. 30, 60 and 90 days
+	CertificateExpiryDays []int
+}
+
+// SecurityDashboard is a snapshot of the brute force protection state
+type SecurityDashboard struct {
+	ActiveLockouts []*domain.AccountLockout
+	LockoutHistory []*domain.AccountLockout // Most recent lockouts including lifted and expired ones
+	RecentFailures []*domain.LoginAttempt   // Failed logins of the last 24 hours
+	AttackPatterns []*domain.AttackPattern
+	Events         []*domain.AuditLog // Security events, newest first
+	GeneratedAt    time.Time
+}
+
+type UnlockLockoutRequest struct {
+	LockoutID domain.ID
+	Reason    string
+	ActorID   domain.ID // For audit logging
+}
+
+type ManualLockoutRequest struct {
+	Username        string // Either Username or IPAddress is required
+	IPAddress       string
+	Reason          string
+	DurationMinutes int
+	ActorID         domain.ID // For audit logging
+}
+
+type UpdateAttackPatternStatusRequest struct {
+	PatternID       domain.ID
+	Status          string // blocked or resolved
+	Reason          string
+	DurationMinutes int       // Lockout duration of the source IPs when blocking
+	ActorID         domain.ID // For audit logging
+}
+
+// SessionSummary is a session as shown on the session management screen
+type SessionSummary struct {
+	ID             string
+	UserID         domain.ID
+	UserName       string
+	UserRole       domain.StaffRole
+	CreatedAt      time.Time
+	LastAccessedAt time.Time
+	ExpiresAt      time.Time
+	ClientIP       string
+	UserAgent      string
+	Current        bool // The session of the requesting window
+}
+
+type ListSessionsRequest struct {
+	UserID           domain.ID // Administrators only; empty lists every user
+	CurrentSessionID string    // Marks the caller's own session
+	ActorID          domain.ID
+}
+
+type RevokeSessionRequest struct {
+	SessionID string
+	Reason    string    // Required when ending another user's session
+	ActorID   domain.ID // For audit logging
+}
+
+type RevokeAllSessionsRequest struct {
+	UserID        domain.ID
+	KeepSessionID string // Session left open, e.g. the caller's own when logging out other devices
+	Reason        string
+	ActorID       domain.ID // For audit logging
+}
+
+// LogEntry is one record of the application log
+type LogEntry struct {
+	Time    time.Time
+	Level   string // DEBUG, INFO, WARN or ERROR
+	Message string
+	Attrs   map[string]string
+}
+
+// LogQuery filters the application log
+type LogQuery struct {
+	MinLevel string    // Lowest level shown; empty shows every level
+	Text     string    // Case-insensitive match on the message and attributes
+	Since    time.Time // Zero reads the whole log
+	Limit    int       // Maximum number of entries; zero uses the default
+}
+
+type ListLogEntriesRequest struct {
+	Query   LogQuery
+	ActorID domain.ID
+}
+
+// IntegrityReport is the result of a database integrity check
+type IntegrityReport struct {
+	CheckedAt            time.Time
+	IntegrityErrors      []string // PRAGMA integrity_check; empty when the file is sound
+	ForeignKeyViolations []ForeignKeyViolation
+	UndecryptableRows    []UndecryptableRow
+	Orphans              []OrphanedRows
+	CheckedValues        int // Encrypted values decrypted successfully or not
+}
+
+// Healthy reports whether the check found nothing to repair
+func (r *IntegrityReport) Healthy() bool {
+	return len(r.IntegrityErrors) == 0 && len(r.ForeignKeyViolations) == 0 &&
+		len(r.UndecryptableRows) == 0 && len(r.Orphans) == 0
+}
+
+// NeedsRestore reports whether the file itself is damaged, which only a backup can repair
+func (r *IntegrityReport) NeedsRestore() bool {
+	return len(r.IntegrityErrors) > 0
+}
+
+// ForeignKeyViolation is a row that references a missing parent row
+type ForeignKeyViolation struct {
+	Table  string
+	RowID  int64
+	Parent string
+}
+
+// UndecryptableRow is a row with encrypted columns the current key cannot decrypt
+type UndecryptableRow struct {
+	Table   string
+	RowID   int64
+	Key     string   // Primary key value, for display
+	Columns []string // Columns that failed to decrypt
+}
+
+// OrphanedRows counts rows whose owner no longer exists, e.g. assignments to deleted staff
+type OrphanedRows struct {
+	Check string // Description of the relation
+	Table string
+	Count int
+}
+
+type QuarantineRowsRequest struct {
+	Reason  string
+	ActorID domain.ID
+}
+
+type RestoreVerifiedBackupRequest struct {
+	Reason  string
+	ActorID domain.ID
+}
+
+// ImportKind is what a bulk import creates
+type ImportKind string
+
+const (
+	ImportKindRecipients   ImportKind = "recipients"
+	ImportKindCertificates ImportKind = "certificates"
+)
+
+// ImportField is a value that can be taken from a column of the file
+type ImportField struct {
+	Key      string   // validation.FormValidator key
+	Label    string   // Display name, also matched against the header
+	Required bool     // Rows without a value are rejected
+	Aliases  []string // Other headings that suggest the column
+}
+
+type ReadImportFileRequest struct {
+	Kind     ImportKind
+	Source   io.Reader
+	FileName string    // .csv (UTF-8 or Shift_JIS) or .xlsx
+	ActorID  domain.ID // Must be an administrator
+}
+
+// ImportFile is an uploaded file ready for column mapping
+type ImportFile struct {
+	Kind     ImportKind
+	FileName string
+	Header   []string
+	Rows     [][]string // Data rows, the header excluded
+	Fields   []ImportField
+	Mapping  map[string]int // Suggested column index per field key
+}
+
+type ImportRequest struct {
+	File            *ImportFile
+	Mapping         map[string]int // Column index per field key; unmapped fields stay empty
+	SkipInvalidRows bool           // Save the valid rows even when other rows have errors
+	ActorID         domain.ID      // Must be an administrator
+}
+
+// ImportRowError is a problem found in one row of the file
+type ImportRowError struct {
+	Line    int    // Line in the file; the header is line 1
+	Field   string // Field label; empty when the problem concerns the whole row
+	Message string
+}
+
+// ImportResult summarizes a dry run or an import
+type ImportResult struct {
+	Kind        ImportKind
+	FileName    string
+	TotalRows   int
+	InvalidRows int // Rows with at least one error, duplicates included
+	Duplicates  int // Rows matching an existing record or an earlier row
+	Imported    int // Rows saved; zero for a dry run
+	Committed   bool
+	Errors      []ImportRowError
+}
+
+// ValidRows returns the number of rows that can be imported
+func (r *ImportResult) ValidRows() int {
+	return r.TotalRows - r.InvalidRows
+}
+
+// RateLimitFieldDiff is one rate limit setting that differs between two policies
+type RateLimitFieldDiff struct {
+	Field    string // config.yaml key, e.g. max_attempts_per_ip
+	Label    string // Display name
+	Current  string // Effective value
+	Proposed string // Config file or newly entered value
+}
+
+// RateLimitSyncReport describes what happened when the config file was synchronized
+type RateLimitSyncReport struct {
+	Source      string               // Source of the effective policy after the sync
+	Applied     bool                 // The config file values were written to the database
+	Changes     []RateLimitFieldDiff // Settings changed by the sync
+	Conflicts   []RateLimitFieldDiff // Administrator settings that differ from the config file
+	FileChanged bool                 // config.yaml changed since the administrator last edited the policy
+}
+
+// RateLimitPolicy is the effective rate limit policy as shown on the admin screen
+type RateLimitPolicy struct {
+	Effective *domain.RateLimitConfig
+	File      *domain.RateLimitConfig // nil until the config file has been synchronized
+	Conflicts []RateLimitFieldDiff
+}
+
+type UpdateRateLimitPolicyRequest struct {
+	Policy  domain.RateLimitConfig // Only the policy values are used
+	Reason  string
+	ActorID domain.ID // For audit logging
+}
+
+type ApplyRateLimitConfigRequest struct {
+	Reason  string
+	ActorID domain.ID // For audit logging
+}
+
+type CreateDisclosureRequest struct {
+	RecipientID domain.ID
+	Password    string    // Optional; protects the PDF and withholds the plain JSON
+	Reason      string    // e.g. 本人からの開示請求
+	ActorID     domain.ID // For audit logging
+}
+
+type DisclosureResult struct {
+	Package *domain.DisclosurePackage
+	PDF     []byte
+	JSON    []byte // nil when password protected; the JSON is then only embedded in the PDF
+}
+
+type RecordExportRequest struct {
+	Report    string       // Report name, e.g. 監査ログ報告書
+	Target    string       // Audit target, e.g. recipient:<id> or report:audit_log
+	Format    ExportFormat // Empty for PDF
+	Table     ExportTable  // Exported table, spreadsheets only
+	Columns   []string     // Keys of the exported columns, spreadsheets only
+	FileName  string       // Name of the written file
+	Data      []byte       // File contents
+	Count     int          // Number of records in the file
+	Protected bool         // Password protected
+	ActorID   domain.ID    // For audit logging
+}
+
+// ExportFormat is the file format of an export
+type ExportFormat string
+
+const (
+	ExportFormatPDF  ExportFormat = "pdf"
+	ExportFormatCSV  ExportFormat = "csv"
+	ExportFormatXLSX ExportFormat = "xlsx"
+)
+
+// ExportTable is a list that can be exported to CSV or Excel
+type ExportTable string
+
+const (
+	ExportTableRecipients   ExportTable = "recipients"
+	ExportTableCertificates ExportTable = "certificates"
+	ExportTableStaff        ExportTable = "staff"
+	ExportTableAuditLogs    ExportTable = "audit_logs"
+)
+
+// ExportColumn is a column offered for spreadsheet export
+type ExportColumn struct {
+	Key       string
+	Label     string
+	Sensitive bool // Personal data read-only staff cannot export
+}
+
+type ExportRecord struct {
+	SHA256   string // Hex digest of the file
+	AuditLog *domain.AuditLog
+}
+
+type TraceExportRequest struct {
+	Data    []byte    // Contents of the file found outside the office
+	ActorID domain.ID // Must be an administrator
+}
+
 type LogActionRequest struct {
 	ActorID domain.ID
 	Action  string
@@ -422,18 +1242,34 @@ const (
 
 // Common errors for usecase layer
 var (
-	ErrUnauthorized        = &UseCaseError{Code: "UNAUTHORIZED", Message: "操作する権限がありません"}
-	ErrValidationFailed    = &UseCaseError{Code: "VALIDATION_FAILED", Message: "入力値が不正です"}
-	ErrRecipientNotFound   = &UseCaseError{Code: "RECIPIENT_NOT_FOUND", Message: "利用者が見つかりません"}
-	ErrStaffNotFound       = &UseCaseError{Code: "STAFF_NOT_FOUND", Message: "職員が見つかりません"}
-	ErrCertificateNotFound = &UseCaseError{Code: "CERTIFICATE_NOT_FOUND", Message: "受給者証が見つかりません"}
-	ErrAssignmentExists    = &UseCaseError{Code: "ASSIGNMENT_EXISTS", Message: "既に担当者が割り当てられています"}
-	ErrCannotDeleteStaff   = &UseCaseError{Code: "CANNOT_DELETE_STAFF", Message: "担当中のため職員を削除できません"}
+	ErrUnauthorized          = &UseCaseError{Code: "UNAUTHORIZED", Message: "操作する権限がありません"}
+	ErrValidationFailed      = &UseCaseError{Code: "VALIDATION_FAILED", Message: "入力値が不正です"}
+	ErrRecipientNotFound     = &UseCaseError{Code: "RECIPIENT_NOT_FOUND", Message: "利用者が見つかりません"}
+	ErrStaffNotFound         = &UseCaseError{Code: "STAFF_NOT_FOUND", Message: "職員が見つかりません"}
+	ErrCertificateNotFound   = &UseCaseError{Code: "CERTIFICATE_NOT_FOUND", Message: "受給者証が見つかりません"}
+	ErrAssignmentExists      = &UseCaseError{Code: "ASSIGNMENT_EXISTS", Message: "既に担当者が割り当てられています"}
+	ErrCannotDeleteStaff     = &UseCaseError{Code: "CANNOT_DELETE_STAFF", Message: "担当中のため職員を削除できません"}
+	ErrAlreadyEnrolled       = &UseCaseError{Code: "ALREADY_ENROLLED", Message: "既に在籍中です"}
+	ErrNotEnrolled           = &UseCaseError{Code: "NOT_ENROLLED", Message: "在籍中の期間がありません"}
+	ErrContactNotFound       = &UseCaseError{Code: "CONTACT_NOT_FOUND", Message: "緊急連絡先が見つかりません"}
+	ErrMedicalRecordNotFound = &UseCaseError{Code: "MEDICAL_RECORD_NOT_FOUND", Message: "医療情報が登録されていません"}
+	ErrIncidentNotFound      = &UseCaseError{Code: "INCIDENT_NOT_FOUND", Message: "事故・ヒヤリハット報告が見つかりません"}
+	ErrInvalidIncidentStatus = &UseCaseError{Code: "INVALID_INCIDENT_STATUS", Message: "現在の状態ではこの操作を行えません"}
+	ErrNotificationNotFound  = &UseCaseError{Code: "NOTIFICATION_NOT_FOUND", Message: "通知が見つかりません"}
+	ErrLockoutNotFound       = &UseCaseError{Code: "LOCKOUT_NOT_FOUND", Message: "ロックアウトが見つかりません"}
+	ErrAttackPatternNotFound = &UseCaseError{Code: "ATTACK_PATTERN_NOT_FOUND", Message: "攻撃パターンが見つかりません"}
+	ErrInvalidPatternStatus  = &UseCaseError{Code: "INVALID_PATTERN_STATUS", Message: "現在の状態ではこの操作を行えません"}
+	ErrConfigFileNotLoaded   = &UseCaseError{Code: "CONFIG_FILE_NOT_LOADED", Message: "config.yaml のレート制限設定が読み込まれていません"}
+	ErrNoVerifiedBackup      = &UseCaseError{Code: "NO_VERIFIED_BACKUP", Message: "検証に成功したバックアップがありません"}
+	ErrNothingToQuarantine   = &UseCaseError{Code: "NOTHING_TO_QUARANTINE", Message: "復号できない行はありません"}
+	ErrEditConflict          = &UseCaseError{Code: "EDIT_CONFLICT", Message: "編集中に他の職員が更新しました"}
 
 	// Authentication related errors
 	ErrInvalidCredentials = &UseCaseError{Code: "INVALID_CREDENTIALS", Message: "ユーザー名またはパスワードが正しくありません"}
 	ErrInvalidSession     = &UseCaseError{Code: "INVALID_SESSION", Message: "セッションが無効です"}
 	ErrSessionExpired     = &UseCaseError{Code: "SESSION_EXPIRED", Message: "セッションの有効期限が切れています"}
+	ErrSessionRevoked     = &UseCaseError{Code: "SESSION_REVOKED", Message: "セッションは終了されました。再度ログインしてください"}
+	ErrSessionNotFound    = &UseCaseError{Code: "SESSION_NOT_FOUND", Message: "セッションが見つかりません"}
 	ErrInvalidPassword    = &UseCaseError{Code: "INVALID_PASSWORD", Message: "パスワードが正しくありません"}
 	ErrWeakPassword       = &UseCaseError{Code: "WEAK_PASSWORD", Message: "パスワードが安全でありません"}
 	ErrPasswordRequired   = &UseCaseError{Code: "PASSWORD_REQUIRED", Message: "パスワードは必須です"}
@@ -468,3 +1304,29 @@ func (e *UseCaseError) Error() string {
 func (e *UseCaseError) Unwrap() error {
 	return e.Cause
 }
+
+// EditConflictError is the cause of ErrEditConflict. Current holds the stored
+// record (*domain.Recipient, *domain.BenefitCertificate or *domain.Staff) so
+// the form can show which fields changed underneath the user. The pointer is
+// nil when the record could not be reloaded.
+type EditConflictError struct {
+	Current interface{}
+}
+
+func (e *EditConflictError) Error() string {
+	return "record was updated by someone else"
+}
+
+// Is makes errors.Is(err, ErrEditConflict) hold for every conflict
+func (e *EditConflictError) Is(target error) bool {
+	return target == ErrEditConflict
+}
+
+// newEditConflictError reports that the record was saved by someone else
+func newEditConflictError(current interface{}) error {
+	return &UseCaseError{
+		Code:    ErrEditConflict.Code,
+		Message: ErrEditConflict.Message,
+		Cause:   &EditConflictError{Current: current},
+	}
+}
@@ -0,0 +1,49 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+
+	"shien-system/internal/domain"
+)
+
+// maxLogEntries bounds how many entries the log viewer loads at once
+const maxLogEntries = 2000
+
+// logUseCase implements LogUseCase interface
+type logUseCase struct {
+	reader    LogReader
+	staffRepo domain.StaffRepository
+}
+
+// NewLogUseCase creates a new log viewer usecase
+func NewLogUseCase(reader LogReader, staffRepo domain.StaffRepository) LogUseCase {
+	return &logUseCase{
+		reader:    reader,
+		staffRepo: staffRepo,
+	}
+}
+
+// ListEntries returns log entries newest first (administrators only)
+func (uc *logUseCase) ListEntries(ctx context.Context, req ListLogEntriesRequest) ([]*LogEntry, error) {
+	if _, err := verifyAdmin(ctx, uc.staffRepo, req.ActorID); err != nil {
+		return nil, err
+	}
+
+	query := req.Query
+	query.Text = strings.TrimSpace(query.Text)
+	if query.Limit <= 0 || query.Limit > maxLogEntries {
+		query.Limit = maxLogEntries
+	}
+
+	entries, err := uc.reader.ReadEntries(ctx, query)
+	if err != nil {
+		return nil, &UseCaseError{
+			Code:    "FETCH_FAILED",
+			Message: "ログの読み込みに失敗しました",
+			Cause:   err,
+		}
+	}
+
+	return entries, nil
+}
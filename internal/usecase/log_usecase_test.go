@@ -0,0 +1,75 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"shien-system/internal/domain"
+)
+
+// fakeLogReader returns fixed entries and records the last query
+type fakeLogReader struct {
+	entries   []*LogEntry
+	lastQuery LogQuery
+	err       error
+}
+
+func (f *fakeLogReader) ReadEntries(ctx context.Context, query LogQuery) ([]*LogEntry, error) {
+	f.lastQuery = query
+	return f.entries, f.err
+}
+
+func TestLogUseCase_ListEntries(t *testing.T) {
+	reader := &fakeLogReader{entries: []*LogEntry{{Level: "WARN", Message: "failed to write audit log"}}}
+	staffRepo := &mockStaffRepository{staff: map[domain.ID]*domain.Staff{
+		"admin-001": {ID: "admin-001", Name: "管理者", Role: domain.RoleAdmin},
+		"staff-001": {ID: "staff-001", Name: "職員", Role: domain.RoleStaff},
+	}}
+	uc := NewLogUseCase(reader, staffRepo)
+	ctx := context.Background()
+
+	if _, err := uc.ListEntries(ctx, ListLogEntriesRequest{ActorID: "staff-001"}); err != ErrUnauthorized {
+		t.Errorf("ListEntries() by staff = %v, want ErrUnauthorized", err)
+	}
+
+	entries, err := uc.ListEntries(ctx, ListLogEntriesRequest{
+		Query:   LogQuery{MinLevel: "warn", Text: "  audit  "},
+		ActorID: "admin-001",
+	})
+	if err != nil {
+		t.Fatalf("ListEntries() error = %v", err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("expected 1 entry, got %d", len(entries))
+	}
+	if reader.lastQuery.Text != "audit" || reader.lastQuery.MinLevel != "warn" || reader.lastQuery.Limit != maxLogEntries {
+		t.Errorf("unexpected query passed to reader: %+v", reader.lastQuery)
+	}
+
+	reader.err = errors.New("disk error")
+	if _, err := uc.ListEntries(ctx, ListLogEntriesRequest{ActorID: "admin-001"}); err == nil {
+		t.Error("ListEntries() should report reader errors")
+	}
+}
+
+func TestUseCaseLogger_LogsAuditFailures(t *testing.T) {
+	uc, _, auditRepo := newTestSessionUseCase()
+
+	var buf bytes.Buffer
+	uc.(LoggerSetter).SetLogger(slog.New(slog.NewTextHandler(&buf, nil)))
+
+	auditRepo.nextError = errors.New("database is locked")
+	if err := uc.RevokeSession(context.Background(), RevokeSessionRequest{SessionID: "staff-session-2", ActorID: "staff-001"}); err != nil {
+		t.Fatalf("RevokeSession() error = %v", err)
+	}
+
+	output := buf.String()
+	if !strings.Contains(output, "level=WARN") || !strings.Contains(output, "action=SESSION_REVOKED") ||
+		!strings.Contains(output, "database is locked") {
+		t.Errorf("audit failure not logged: %q", output)
+	}
+}
@@ -0,0 +1,21 @@
+package usecase
+
+import "log/slog"
+
+// useCaseLogger is embedded by the usecases so the application logger can be
+// set after construction. Until then messages go to slog's default logger.
+type useCaseLogger struct {
+	logger Logger
+}
+
+// SetLogger sets the logger used by the usecase
+func (l *useCaseLogger) SetLogger(logger Logger) {
+	l.logger = logger
+}
+
+func (l *useCaseLogger) log() Logger {
+	if l.logger == nil {
+		return slog.Default()
+	}
+	return l.logger
+}
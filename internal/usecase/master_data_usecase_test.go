@@ -0,0 +1,222 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"shien-system/internal/domain"
+)
+
+// mockMunicipalityRepository keeps the municipality master in memory
+type mockMunicipalityRepository struct {
+	municipalities []*domain.Municipality
+}
+
+func (m *mockMunicipalityRepository) List(ctx context.Context) ([]*domain.Municipality, error) {
+	return m.municipalities, nil
+}
+
+func (m *mockMunicipalityRepository) FindByCode(ctx context.Context, code string) (*domain.Municipality, error) {
+	for _, municipality := range m.municipalities {
+		if municipality.Code == code {
+			return municipality, nil
+		}
+	}
+	return nil, domain.ErrNotFound
+}
+
+func (m *mockMunicipalityRepository) ReplaceAll(ctx context.Context, municipalities []*domain.Municipality) error {
+	m.municipalities = municipalities
+	return nil
+}
+
+func (m *mockMunicipalityRepository) Count(ctx context.Context) (int, error) {
+	return len(m.municipalities), nil
+}
+
+// mockServiceTypeRepository keeps the service type master in memory
+type mockServiceTypeRepository struct {
+	serviceTypes []*domain.ServiceType
+}
+
+func (m *mockServiceTypeRepository) List(ctx context.Context) ([]*domain.ServiceType, error) {
+	return m.serviceTypes, nil
+}
+
+func (m *mockServiceTypeRepository) FindByCode(ctx context.Context, code string) (*domain.ServiceType, error) {
+	for _, serviceType := range m.serviceTypes {
+		if serviceType.Code == code {
+			return serviceType, nil
+		}
+	}
+	return nil, domain.ErrNotFound
+}
+
+func (m *mockServiceTypeRepository) ReplaceAll(ctx context.Context, serviceTypes []*domain.ServiceType) error {
+	m.serviceTypes = serviceTypes
+	return nil
+}
+
+func (m *mockServiceTypeRepository) Count(ctx context.Context) (int, error) {
+	return len(m.serviceTypes), nil
+}
+
+// mockMasterDataParser returns fixed entries or an error
+type mockMasterDataParser struct {
+	municipalities []*domain.Municipality
+	serviceTypes   []*domain.ServiceType
+	err            error
+	parsed         int
+}
+
+func (m *mockMasterDataParser) ParseMunicipalities(r io.Reader) ([]*domain.Municipality, error) {
+	m.parsed++
+	return m.municipalities, m.err
+}
+
+func (m *mockMasterDataParser) ParseServiceTypes(r io.Reader) ([]*domain.ServiceType, error) {
+	m.parsed++
+	return m.serviceTypes, m.err
+}
+
+func setupMasterDataUseCase(parser *mockMasterDataParser) (MasterDataUseCase, *mockMunicipalityRepository, *mockServiceTypeRepository, *mockAuditLogRepository) {
+	municipalityRepo := &mockMunicipalityRepository{}
+	serviceTypeRepo := &mockServiceTypeRepository{}
+	staffRepo := &mockStaffRepository{
+		staff: map[domain.ID]*domain.Staff{
+			"admin-001": {ID: "admin-001", Name: "管理者", Role: domain.RoleAdmin},
+			"staff-001": {ID: "staff-001", Name: "職員", Role: domain.RoleStaff},
+		},
+	}
+	auditRepo := &mockAuditLogRepository{}
+	uc := NewMasterDataUseCase(municipalityRepo, serviceTypeRepo, parser, staffRepo, auditRepo)
+	return uc, municipalityRepo, serviceTypeRepo, auditRepo
+}
+
+func TestMasterDataUseCase_ImportAndList(t *testing.T) {
+	parser := &mockMasterDataParser{
+		municipalities: []*domain.Municipality{{Code: "131016", Prefecture: "東京都", Name: "千代田区"}},
+		serviceTypes:   []*domain.ServiceType{{Code: "22", Name: "生活介護"}, {Code: "46", Name: "就労継続支援B型"}},
+	}
+	uc, _, _, auditRepo := setupMasterDataUseCase(parser)
+	ctx := context.Background()
+
+	count, err := uc.ImportMunicipalities(ctx, ImportMasterDataRequest{Source: strings.NewReader("csv"), ActorID: "admin-001"})
+	if err != nil || count != 1 {
+		t.Fatalf("ImportMunicipalities() = %d, %v, want 1", count, err)
+	}
+	count, err = uc.ImportServiceTypes(ctx, ImportMasterDataRequest{Source: strings.NewReader("csv"), ActorID: "admin-001"})
+	if err != nil || count != 2 {
+		t.Fatalf("ImportServiceTypes() = %d, %v, want 2", count, err)
+	}
+
+	if len(auditRepo.logs) != 2 || auditRepo.logs[0].Action != municipalitiesImportAction ||
+		auditRepo.logs[1].Action != serviceTypesImportAction {
+		t.Errorf("audit logs = %+v, want one entry per import", auditRepo.logs)
+	}
+
+	if municipalities, err := uc.ListMunicipalities(ctx); err != nil || len(municipalities) != 1 || municipalities[0].Name != "千代田区" {
+		t.Errorf("ListMunicipalities() = %+v, %v", municipalities, err)
+	}
+	if serviceTypes, err := uc.ListServiceTypes(ctx); err != nil || len(serviceTypes) != 2 {
+		t.Errorf("ListServiceTypes() = %+v, %v", serviceTypes, err)
+	}
+}
+
+func TestMasterDataUseCase_ImportRequiresAdmin(t *testing.T) {
+	parser := &mockMasterDataParser{}
+	uc, _, _, _ := setupMasterDataUseCase(parser)
+
+	_, err := uc.ImportMunicipalities(context.Background(), ImportMasterDataRequest{Source: strings.NewReader("csv"), ActorID: "staff-001"})
+	if !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("ImportMunicipalities() error = %v, want ErrUnauthorized", err)
+	}
+	if parser.parsed != 0 {
+		t.Error("file parsed for a non-administrator")
+	}
+}
+
+func TestMasterDataUseCase_ImportKeepsMasterOnParseError(t *testing.T) {
+	parser := &mockMasterDataParser{err: errors.New("line 2: invalid service type code")}
+	uc, _, serviceTypeRepo, _ := setupMasterDataUseCase(parser)
+	serviceTypeRepo.serviceTypes = []*domain.ServiceType{{Code: "22", Name: "生活介護"}}
+
+	_, err := uc.ImportServiceTypes(context.Background(), ImportMasterDataRequest{Source: strings.NewReader("csv"), ActorID: "admin-001"})
+	var ucErr *UseCaseError
+	if !errors.As(err, &ucErr) || ucErr.Code != "INVALID_MASTER_FILE" {
+		t.Errorf("ImportServiceTypes() error = %v, want INVALID_MASTER_FILE", err)
+	}
+	if len(serviceTypeRepo.serviceTypes) != 1 {
+		t.Error("master replaced although the file could not be read")
+	}
+}
+
+func TestCertificateUseCase_CreateCertificateMasterData(t *testing.T) {
+	municipalityRepo := &mockMunicipalityRepository{}
+	serviceTypeRepo := &mockServiceTypeRepository{}
+	recipientRepo := &mockRecipientRepository{
+		recipients: map[domain.ID]*domain.Recipient{"recipient-001": {ID: "recipient-001", Name: "テスト利用者"}},
+	}
+	staffRepo := &mockStaffRepository{
+		staff: map[domain.ID]*domain.Staff{"staff-001": {ID: "staff-001", Role: domain.RoleStaff}},
+	}
+	uc := NewCertificateUseCase(&mockCertificateRepository{}, recipientRepo, staffRepo, &mockAuditLogRepository{},
+		municipalityRepo, serviceTypeRepo)
+	ctx := context.Background()
+
+	request := func(municipalityCode, issuer, serviceCode, serviceType string) CreateCertificateRequest {
+		return CreateCertificateRequest{
+			RecipientID:            "recipient-001",
+			CertificateNumber:      "１２３４５６７８９０",
+			StartDate:              time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC),
+			EndDate:                time.Date(2027, 3, 31, 0, 0, 0, 0, time.UTC),
+			MunicipalityCode:       municipalityCode,
+			Issuer:                 issuer,
+			ServiceCode:            serviceCode,
+			ServiceType:            serviceType,
+			MaxBenefitDaysPerMonth: 22,
+			ActorID:                "staff-001",
+		}
+	}
+	validationFailed := func(err error) bool {
+		var ucErr *UseCaseError
+		return errors.As(err, &ucErr) && ucErr.Code == "VALIDATION_FAILED"
+	}
+
+	// Before the masters are imported, well-formed codes are kept with the names entered
+	certificate, err := uc.CreateCertificate(ctx, request("141003", "横浜市", "46", "就労継続支援B型"))
+	if err != nil {
+		t.Fatalf("CreateCertificate() without masters error = %v", err)
+	}
+	if certificate.CertificateNumber != "1234567890" || certificate.MunicipalityCode != "141003" || certificate.Issuer != "横浜市" {
+		t.Errorf("certificate = %+v", certificate)
+	}
+	if _, err := uc.CreateCertificate(ctx, request("141003", "", "46", "就労継続支援B型")); !validationFailed(err) {
+		t.Errorf("unknown code without a name: error = %v, want VALIDATION_FAILED", err)
+	}
+
+	// Wrong check digit
+	if _, err := uc.CreateCertificate(ctx, request("141004", "横浜市", "46", "就労継続支援B型")); !validationFailed(err) {
+		t.Errorf("wrong check digit: error = %v, want VALIDATION_FAILED", err)
+	}
+
+	municipalityRepo.municipalities = []*domain.Municipality{{Code: "131016", Prefecture: "東京都", Name: "千代田区"}}
+	serviceTypeRepo.serviceTypes = []*domain.ServiceType{{Code: "46", Name: "就労継続支援B型"}}
+
+	// Names come from the masters once they are imported
+	certificate, err = uc.CreateCertificate(ctx, request("１３１０１６", "", "46", ""))
+	if err != nil {
+		t.Fatalf("CreateCertificate() with masters error = %v", err)
+	}
+	if certificate.MunicipalityCode != "131016" || certificate.Issuer != "千代田区" || certificate.ServiceType != "就労継続支援B型" {
+		t.Errorf("certificate = %+v, want names from the masters", certificate)
+	}
+
+	if _, err := uc.CreateCertificate(ctx, request("141003", "横浜市", "46", "")); !validationFailed(err) {
+		t.Errorf("code missing from the master: error = %v, want VALIDATION_FAILED", err)
+	}
+}
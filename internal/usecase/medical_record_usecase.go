@@ -0,0 +1,302 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"shien-system/internal/domain"
+	"shien-system/internal/validation"
+)
+
+// medicalRecordUseCase implements MedicalRecordUseCase interface
+type medicalRecordUseCase struct {
+	medicalRepo   domain.MedicalRecordRepository
+	recipientRepo domain.RecipientRepository
+	staffRepo     domain.StaffRepository
+	auditRepo     domain.AuditLogRepository
+
+	useCaseLogger
+}
+
+// NewMedicalRecordUseCase creates a new medical record usecase
+func NewMedicalRecordUseCase(
+	medicalRepo domain.MedicalRecordRepository,
+	recipientRepo domain.RecipientRepository,
+	staffRepo domain.StaffRepository,
+	auditRepo domain.AuditLogRepository,
+) MedicalRecordUseCase {
+	return &medicalRecordUseCase{
+		medicalRepo:   medicalRepo,
+		recipientRepo: recipientRepo,
+		staffRepo:     staffRepo,
+		auditRepo:     auditRepo,
+	}
+}
+
+// GetMedicalRecord retrieves the medical record of a recipient.
+// Every access is audit-logged because the record holds health information.
+func (uc *medicalRecordUseCase) GetMedicalRecord(ctx context.Context, recipientID domain.ID, actorID domain.ID) (*domain.MedicalRecord, error) {
+	if err := uc.verifyActor(ctx, actorID); err != nil {
+		return nil, err
+	}
+
+	if err := uc.verifyRecipient(ctx, recipientID); err != nil {
+		return nil, err
+	}
+
+	record, err := uc.medicalRepo.GetByRecipientID(ctx, recipientID)
+	if err != nil {
+		if err == domain.ErrNotFound {
+			return nil, ErrMedicalRecordNotFound
+		}
+		return nil, &UseCaseError{
+			Code:    "RETRIEVAL_FAILED",
+			Message: "医療情報の取得に失敗しました",
+			Cause:   err,
+		}
+	}
+
+	// Log the access
+	uc.logAction(ctx, actorID, "VIEW", recipientID, time.Now().UTC(), "医療情報を閲覧しました")
+
+	return record, nil
+}
+
+// SaveMedicalRecord creates or replaces the medical record of a recipient
+func (uc *medicalRecordUseCase) SaveMedicalRecord(ctx context.Context, req SaveMedicalRecordRequest) (*domain.MedicalRecord, error) {
+	// Validate input
+	if err := uc.validateSaveMedicalRecordRequest(req); err != nil {
+		return nil, &UseCaseError{
+			Code:    "VALIDATION_FAILED",
+			Message: "入力値が不正です",
+			Cause:   err,
+		}
+	}
+
+	if err := uc.verifyActor(ctx, req.ActorID); err != nil {
+		return nil, err
+	}
+
+	if err := uc.verifyRecipient(ctx, req.RecipientID); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	createdAt := now
+	action := "CREATE"
+
+	existing, err := uc.medicalRepo.GetByRecipientID(ctx, req.RecipientID)
+	if err == nil {
+		createdAt = existing.CreatedAt // Preserve original creation time
+		action = "UPDATE"
+	} else if err != domain.ErrNotFound {
+		return nil, &UseCaseError{
+			Code:    "RETRIEVAL_FAILED",
+			Message: "医療情報の取得に失敗しました",
+			Cause:   err,
+		}
+	}
+
+	record := &domain.MedicalRecord{
+		RecipientID:         req.RecipientID,
+		Medications:         trimMedications(req.Medications),
+		Allergies:           trimAllergies(req.Allergies),
+		HasEpilepsy:         req.HasEpilepsy,
+		SeizureProtocol:     validation.NormalizeText(req.SeizureProtocol),
+		HospitalName:        validation.NormalizeText(req.HospitalName),
+		HospitalPhone:       validation.NormalizePhone(req.HospitalPhone),
+		DoctorName:          validation.NormalizeText(req.DoctorName),
+		InsuranceType:       validation.NormalizeText(req.InsuranceType),
+		InsurerNumber:       validation.NormalizeText(req.InsurerNumber),
+		InsuredSymbol:       validation.NormalizeText(req.InsuredSymbol),
+		InsuredNumber:       validation.NormalizeText(req.InsuredNumber),
+		InsuranceValidUntil: req.InsuranceValidUntil,
+		Notes:               validation.NormalizeText(req.Notes),
+		UpdatedBy:           req.ActorID,
+		CreatedAt:           createdAt,
+		UpdatedAt:           now,
+	}
+
+	if err := uc.medicalRepo.Save(ctx, record); err != nil {
+		return nil, &UseCaseError{
+			Code:    "SAVE_FAILED",
+			Message: "医療情報の保存に失敗しました",
+			Cause:   err,
+		}
+	}
+
+	// Log the action without the health details themselves
+	uc.logAction(ctx, req.ActorID, action, record.RecipientID, now,
+		fmt.Sprintf("医療情報を保存しました (服薬: %d件, アレルギー: %d件, 重篤なアレルギー: %d件)",
+			len(record.Medications), len(record.Allergies), len(record.CriticalAllergies())))
+
+	return record, nil
+}
+
+// verifyActor checks that the actor exists and may access medical information
+func (uc *medicalRecordUseCase) verifyActor(ctx context.Context, actorID domain.ID) error {
+	actor, err := uc.staffRepo.GetByID(ctx, actorID)
+	if err != nil {
+		if err == domain.ErrNotFound {
+			return ErrUnauthorized
+		}
+		return &UseCaseError{
+			Code:    "INTERNAL_ERROR",
+			Message: "内部エラーが発生しました",
+			Cause:   err,
+		}
+	}
+
+	if actor.Role != domain.RoleAdmin && actor.Role != domain.RoleStaff {
+		return ErrUnauthorized
+	}
+
+	return nil
+}
+
+// verifyRecipient checks that the recipient exists
+func (uc *medicalRecordUseCase) verifyRecipient(ctx context.Context, recipientID domain.ID) error {
+	_, err := uc.recipientRepo.GetByID(ctx, recipientID)
+	if err != nil {
+		if err == domain.ErrNotFound {
+			return ErrRecipientNotFound
+		}
+		return &UseCaseError{
+			Code:    "INTERNAL_ERROR",
+			Message: "内部エラーが発生しました",
+			Cause:   err,
+		}
+	}
+	return nil
+}
+
+// logAction records an audit entry for a recipient's medical record
+func (uc *medicalRecordUseCase) logAction(ctx context.Context, actorID domain.ID, action string, recipientID domain.ID, at time.Time, details string) {
+	auditLog := &domain.AuditLog{
+		ID:      domain.ID(uuid.New().String()),
+		ActorID: actorID,
+		Action:  action,
+		Target:  fmt.Sprintf("medical_record:%s", recipientID),
+		At:      at,
+		IP:      uc.getClientIP(ctx),
+		Details: details,
+	}
+
+	err := uc.auditRepo.Create(ctx, auditLog)
+	if err != nil {
+		// Log audit failure but don't fail the operation
+		uc.log().Warn("failed to write audit log", "action", auditLog.Action, "error", err)
+	}
+}
+
+// Validation functions
+
+func (uc *medicalRecordUseCase) validateSaveMedicalRecordRequest(req SaveMedicalRecordRequest) error {
+	var errors []string
+
+	if req.RecipientID == "" {
+		errors = append(errors, "利用者IDは必須です")
+	}
+
+	for i, medication := range req.Medications {
+		if strings.TrimSpace(medication.Name) == "" {
+			errors = append(errors, fmt.Sprintf("服薬%d件目の薬剤名は必須です", i+1))
+		} else if len([]rune(medication.Name)) > 100 {
+			errors = append(errors, fmt.Sprintf("服薬%d件目の薬剤名は100文字以内で入力してください", i+1))
+		}
+	}
+
+	for i, allergy := range req.Allergies {
+		if strings.TrimSpace(allergy.Allergen) == "" {
+			errors = append(errors, fmt.Sprintf("アレルギー%d件目のアレルゲンは必須です", i+1))
+		} else if len([]rune(allergy.Allergen)) > 100 {
+			errors = append(errors, fmt.Sprintf("アレルギー%d件目のアレルゲンは100文字以内で入力してください", i+1))
+		}
+	}
+
+	// Staff must know what to do when a seizure occurs
+	if req.HasEpilepsy && strings.TrimSpace(req.SeizureProtocol) == "" {
+		errors = append(errors, "てんかんがある場合は発作時の対応を入力してください")
+	}
+
+	if len([]rune(req.SeizureProtocol)) > 1000 {
+		errors = append(errors, "発作時の対応は1000文字以内で入力してください")
+	}
+
+	if len([]rune(req.HospitalName)) > 200 {
+		errors = append(errors, "医療機関名は200文字以内で入力してください")
+	}
+
+	if len([]rune(req.DoctorName)) > 100 {
+		errors = append(errors, "主治医名は100文字以内で入力してください")
+	}
+
+	// 保険者番号 is 6 digits for national health insurance and 8 digits otherwise
+	if insurerNumber := validation.NormalizeText(req.InsurerNumber); insurerNumber != "" {
+		if !isDigits(insurerNumber) || (len(insurerNumber) != 6 && len(insurerNumber) != 8) {
+			errors = append(errors, "保険者番号は6桁または8桁の数字で入力してください")
+		}
+	}
+
+	if len([]rune(req.Notes)) > 1000 {
+		errors = append(errors, "備考は1000文字以内で入力してください")
+	}
+
+	if req.ActorID == "" {
+		errors = append(errors, "実行者IDは必須です")
+	}
+
+	if len(errors) > 0 {
+		return fmt.Errorf("validation errors: %s", strings.Join(errors, ", "))
+	}
+
+	return nil
+}
+
+// Helper functions
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
+
+func trimMedications(medications []domain.Medication) []domain.Medication {
+	trimmed := make([]domain.Medication, 0, len(medications))
+	for _, medication := range medications {
+		trimmed = append(trimmed, domain.Medication{
+			Name:   strings.TrimSpace(medication.Name),
+			Dose:   strings.TrimSpace(medication.Dose),
+			Timing: strings.TrimSpace(medication.Timing),
+			Notes:  strings.TrimSpace(medication.Notes),
+		})
+	}
+	return trimmed
+}
+
+func trimAllergies(allergies []domain.Allergy) []domain.Allergy {
+	trimmed := make([]domain.Allergy, 0, len(allergies))
+	for _, allergy := range allergies {
+		trimmed = append(trimmed, domain.Allergy{
+			Allergen: strings.TrimSpace(allergy.Allergen),
+			Reaction: strings.TrimSpace(allergy.Reaction),
+			Critical: allergy.Critical,
+		})
+	}
+	return trimmed
+}
+
+func (uc *medicalRecordUseCase) getClientIP(ctx context.Context) string {
+	if ip := ctx.Value(ContextKeyClientIP); ip != nil {
+		if clientIP, ok := ip.(string); ok {
+			return clientIP
+		}
+	}
+	return "unknown"
+}
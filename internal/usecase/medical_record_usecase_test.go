@@ -0,0 +1,164 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"shien-system/internal/domain"
+)
+
+type mockMedicalRecordRepository struct {
+	records map[domain.ID]*domain.MedicalRecord
+}
+
+func (m *mockMedicalRecordRepository) GetByRecipientID(ctx context.Context, recipientID domain.ID) (*domain.MedicalRecord, error) {
+	record, exists := m.records[recipientID]
+	if !exists {
+		return nil, domain.ErrNotFound
+	}
+	return record, nil
+}
+
+func (m *mockMedicalRecordRepository) Save(ctx context.Context, record *domain.MedicalRecord) error {
+	if m.records == nil {
+		m.records = make(map[domain.ID]*domain.MedicalRecord)
+	}
+	m.records[record.RecipientID] = record
+	return nil
+}
+
+func (m *mockMedicalRecordRepository) Delete(ctx context.Context, recipientID domain.ID) error {
+	if _, exists := m.records[recipientID]; !exists {
+		return domain.ErrNotFound
+	}
+	delete(m.records, recipientID)
+	return nil
+}
+
+func setupMedicalRecordUseCase() (MedicalRecordUseCase, *mockMedicalRecordRepository, *mockAuditLogRepository) {
+	now := time.Now().UTC()
+	recipientRepo := &mockRecipientRepository{
+		recipients: map[domain.ID]*domain.Recipient{
+			"recipient-001": {ID: "recipient-001", Name: "医療太郎", CreatedAt: now, UpdatedAt: now},
+		},
+	}
+	staffRepo := &mockStaffRepository{
+		staff: map[domain.ID]*domain.Staff{
+			"staff-001":    {ID: "staff-001", Name: "職員", Role: domain.RoleStaff},
+			"readonly-001": {ID: "readonly-001", Name: "閲覧者", Role: domain.RoleReadOnly},
+		},
+	}
+	medicalRepo := &mockMedicalRecordRepository{}
+	auditRepo := &mockAuditLogRepository{}
+
+	return NewMedicalRecordUseCase(medicalRepo, recipientRepo, staffRepo, auditRepo), medicalRepo, auditRepo
+}
+
+func TestMedicalRecordUseCase_SaveAndGet(t *testing.T) {
+	uc, _, auditRepo := setupMedicalRecordUseCase()
+	ctx := context.Background()
+
+	if _, err := uc.GetMedicalRecord(ctx, "recipient-001", "staff-001"); err != ErrMedicalRecordNotFound {
+		t.Fatalf("GetMedicalRecord() before save error = %v, want ErrMedicalRecordNotFound", err)
+	}
+
+	created, err := uc.SaveMedicalRecord(ctx, SaveMedicalRecordRequest{
+		RecipientID:     "recipient-001",
+		Medications:     []domain.Medication{{Name: " デパケンR ", Dose: "200mg", Timing: "朝夕食後"}},
+		Allergies:       []domain.Allergy{{Allergen: "そば", Reaction: "アナフィラキシー", Critical: true}},
+		HasEpilepsy:     true,
+		SeizureProtocol: "5分以上続く場合は救急要請",
+		InsurerNumber:   "138123",
+		ActorID:         "staff-001",
+	})
+	if err != nil {
+		t.Fatalf("SaveMedicalRecord() error = %v", err)
+	}
+	if created.Medications[0].Name != "デパケンR" {
+		t.Errorf("medication name should be trimmed, got %q", created.Medications[0].Name)
+	}
+	if created.UpdatedBy != "staff-001" {
+		t.Errorf("UpdatedBy = %v, want staff-001", created.UpdatedBy)
+	}
+
+	updated, err := uc.SaveMedicalRecord(ctx, SaveMedicalRecordRequest{
+		RecipientID:  "recipient-001",
+		HospitalName: "支援中央病院",
+		ActorID:      "staff-001",
+	})
+	if err != nil {
+		t.Fatalf("SaveMedicalRecord() update error = %v", err)
+	}
+	if !updated.CreatedAt.Equal(created.CreatedAt) {
+		t.Error("CreatedAt should be preserved on update")
+	}
+
+	retrieved, err := uc.GetMedicalRecord(ctx, "recipient-001", "staff-001")
+	if err != nil {
+		t.Fatalf("GetMedicalRecord() error = %v", err)
+	}
+	if retrieved.HospitalName != "支援中央病院" || len(retrieved.Allergies) != 0 {
+		t.Errorf("GetMedicalRecord() = %+v", retrieved)
+	}
+
+	actions := make([]string, 0, len(auditRepo.logs))
+	for _, log := range auditRepo.logs {
+		actions = append(actions, log.Action)
+		if log.Target != "medical_record:recipient-001" {
+			t.Errorf("unexpected audit target %q", log.Target)
+		}
+	}
+	if len(actions) != 3 || actions[0] != "CREATE" || actions[1] != "UPDATE" || actions[2] != "VIEW" {
+		t.Errorf("audit actions = %v, want [CREATE UPDATE VIEW]", actions)
+	}
+}
+
+func TestMedicalRecordUseCase_ReadOnlyStaffDenied(t *testing.T) {
+	uc, medicalRepo, _ := setupMedicalRecordUseCase()
+	ctx := context.Background()
+
+	medicalRepo.records = map[domain.ID]*domain.MedicalRecord{
+		"recipient-001": {RecipientID: "recipient-001", HospitalName: "支援中央病院"},
+	}
+
+	if _, err := uc.GetMedicalRecord(ctx, "recipient-001", "readonly-001"); err != ErrUnauthorized {
+		t.Errorf("GetMedicalRecord() by read-only staff error = %v, want ErrUnauthorized", err)
+	}
+
+	_, err := uc.SaveMedicalRecord(ctx, SaveMedicalRecordRequest{RecipientID: "recipient-001", ActorID: "readonly-001"})
+	if err != ErrUnauthorized {
+		t.Errorf("SaveMedicalRecord() by read-only staff error = %v, want ErrUnauthorized", err)
+	}
+}
+
+func TestMedicalRecordUseCase_SaveMedicalRecord_ValidationErrors(t *testing.T) {
+	ctx := context.Background()
+
+	testCases := []struct {
+		name string
+		req  SaveMedicalRecordRequest
+	}{
+		{"medication without name", SaveMedicalRecordRequest{RecipientID: "recipient-001", Medications: []domain.Medication{{Dose: "1錠"}}, ActorID: "staff-001"}},
+		{"allergy without allergen", SaveMedicalRecordRequest{RecipientID: "recipient-001", Allergies: []domain.Allergy{{Reaction: "発疹"}}, ActorID: "staff-001"}},
+		{"epilepsy without protocol", SaveMedicalRecordRequest{RecipientID: "recipient-001", HasEpilepsy: true, ActorID: "staff-001"}},
+		{"invalid insurer number", SaveMedicalRecordRequest{RecipientID: "recipient-001", InsurerNumber: "12345", ActorID: "staff-001"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			uc, medicalRepo, _ := setupMedicalRecordUseCase()
+
+			_, err := uc.SaveMedicalRecord(ctx, tc.req)
+
+			var useCaseErr *UseCaseError
+			if !errors.As(err, &useCaseErr) || useCaseErr.Code != "VALIDATION_FAILED" {
+				t.Errorf("Expected VALIDATION_FAILED error, got %v", err)
+			}
+			if len(medicalRepo.records) != 0 {
+				t.Error("no record should be stored")
+			}
+		})
+	}
+}
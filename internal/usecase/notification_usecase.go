@@ -0,0 +1,596 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"shien-system/internal/domain"
+)
+
+// notificationPageSize is the page size used when scanning staff assignments and recipients
+const notificationPageSize = 500
+
+// defaultCertificateExpiryDays are used when no thresholds are configured
+var defaultCertificateExpiryDays = []int{30, 60, 90}
+
+// notificationUseCase implements NotificationUseCase interface
+type notificationUseCase struct {
+	notificationRepo domain.NotificationRepository
+	certRepo         domain.BenefitCertificateRepository
+	recipientRepo    domain.RecipientRepository
+	assignmentRepo   domain.StaffAssignmentRepository
+	lockoutRepo      domain.AccountLockoutRepository
+	staffRepo        domain.StaffRepository
+	auditRepo        domain.AuditLogRepository
+	expiryDays       []int
+
+	useCaseLogger
+}
+
+// NewNotificationUseCase creates a new notification usecase
+func NewNotificationUseCase(
+	notificationRepo domain.NotificationRepository,
+	certRepo domain.BenefitCertificateRepository,
+	recipientRepo domain.RecipientRepository,
+	assignmentRepo domain.StaffAssignmentRepository,
+	lockoutRepo domain.AccountLockoutRepository,
+	staffRepo domain.StaffRepository,
+	auditRepo domain.AuditLogRepository,
+	settings NotificationSettings,
+) NotificationUseCase {
+	expiryDays := make([]int, 0, len(settings.CertificateExpiryDays))
+	for _, days := range settings.CertificateExpiryDays {
+		if days > 0 {
+			expiryDays = append(expiryDays, days)
+		}
+	}
+	if len(expiryDays) == 0 {
+		expiryDays = append(expiryDays, defaultCertificateExpiryDays...)
+	}
+	sort.Ints(expiryDays)
+
+	return &notificationUseCase{
+		notificationRepo: notificationRepo,
+		certRepo:         certRepo,
+		recipientRepo:    recipientRepo,
+		assignmentRepo:   assignmentRepo,
+		lockoutRepo:      lockoutRepo,
+		staffRepo:        staffRepo,
+		auditRepo:        auditRepo,
+		expiryDays:       expiryDays,
+	}
+}
+
+// RefreshNotifications runs the periodic checks. A check that fails leaves its
+// open notifications untouched; the other checks still run.
+func (uc *notificationUseCase) RefreshNotifications(ctx context.Context) (*NotificationRefreshResult, error) {
+	now := time.Now()
+	result := &NotificationRefreshResult{}
+
+	checks := []struct {
+		kind  domain.NotificationKind
+		check func(ctx context.Context, now time.Time) ([]*domain.Notification, error)
+	}{
+		{domain.NotificationKindCertificateExpiring, uc.checkExpiringCertificates},
+		{domain.NotificationKindDischargedAssigned, uc.checkDischargedAssignments},
+		{domain.NotificationKindAccountLocked, uc.checkLockedAccounts},
+		{domain.NotificationKindDisabilityRenewal, uc.checkDisabilityRenewals},
+	}
+
+	var failures []string
+	for _, c := range checks {
+		notifications, err := c.check(ctx, now)
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %v", c.kind, err))
+			continue
+		}
+
+		activeKeys := make([]string, 0, len(notifications))
+		for _, notification := range notifications {
+			notification.ID = domain.ID(uuid.New().String())
+			notification.Kind = c.kind
+			notification.CreatedAt = now.UTC()
+
+			created, err := uc.notificationRepo.CreateIfAbsent(ctx, notification)
+			if err != nil {
+				return result, &UseCaseError{
+					Code:    "CREATION_FAILED",
+					Message: "通知の作成に失敗しました",
+					Cause:   err,
+				}
+			}
+			if created {
+				result.Created++
+			}
+			activeKeys = append(activeKeys, notification.DedupKey)
+		}
+
+		resolved, err := uc.notificationRepo.ResolveStale(ctx, c.kind, activeKeys, now.UTC())
+		if err != nil {
+			return result, &UseCaseError{
+				Code:    "UPDATE_FAILED",
+				Message: "通知の更新に失敗しました",
+				Cause:   err,
+			}
+		}
+		result.Resolved += resolved
+	}
+
+	if len(failures) > 0 {
+		return result, &UseCaseError{
+			Code:    "CHECK_FAILED",
+			Message: "通知の確認処理に失敗しました",
+			Cause:   fmt.Errorf("checks failed: %s", strings.Join(failures, ", ")),
+		}
+	}
+
+	return result, nil
+}
+
+// ListNotifications retrieves the open notifications visible to the actor, newest first
+func (uc *notificationUseCase) ListNotifications(ctx context.Context, req ListNotificationsRequest) ([]*domain.Notification, error) {
+	actor, err := uc.verifyActor(ctx, req.ActorID)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := req.Limit
+	if limit <= 0 {
+		limit = 50
+	}
+
+	notifications, err := uc.notificationRepo.ListForUser(ctx, actor.ID, audiencesFor(actor), req.UnreadOnly, limit, req.Offset)
+	if err != nil {
+		return nil, &UseCaseError{
+			Code:    "RETRIEVAL_FAILED",
+			Message: "通知の取得に失敗しました",
+			Cause:   err,
+		}
+	}
+
+	return notifications, nil
+}
+
+// CountUnread counts the open notifications the actor has not read
+func (uc *notificationUseCase) CountUnread(ctx context.Context, actorID domain.ID) (int, error) {
+	actor, err := uc.verifyActor(ctx, actorID)
+	if err != nil {
+		return 0, err
+	}
+
+	count, err := uc.notificationRepo.CountUnread(ctx, actor.ID, audiencesFor(actor))
+	if err != nil {
+		return 0, &UseCaseError{
+			Code:    "RETRIEVAL_FAILED",
+			Message: "通知の取得に失敗しました",
+			Cause:   err,
+		}
+	}
+
+	return count, nil
+}
+
+// MarkRead marks a notification as read by the actor
+func (uc *notificationUseCase) MarkRead(ctx context.Context, id domain.ID, actorID domain.ID) error {
+	actor, err := uc.verifyActor(ctx, actorID)
+	if err != nil {
+		return err
+	}
+
+	if _, err := uc.getVisibleNotification(ctx, id, actor); err != nil {
+		return err
+	}
+
+	if err := uc.notificationRepo.MarkRead(ctx, id, actor.ID, time.Now().UTC()); err != nil {
+		return &UseCaseError{
+			Code:    "UPDATE_FAILED",
+			Message: "通知の更新に失敗しました",
+			Cause:   err,
+		}
+	}
+
+	return nil
+}
+
+// MarkAllRead marks every open notification visible to the actor as read
+func (uc *notificationUseCase) MarkAllRead(ctx context.Context, actorID domain.ID) error {
+	actor, err := uc.verifyActor(ctx, actorID)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.notificationRepo.MarkAllRead(ctx, actor.ID, audiencesFor(actor), time.Now().UTC()); err != nil {
+		return &UseCaseError{
+			Code:    "UPDATE_FAILED",
+			Message: "通知の更新に失敗しました",
+			Cause:   err,
+		}
+	}
+
+	return nil
+}
+
+// AcknowledgeNotification records that the actor has dealt with a notification
+func (uc *notificationUseCase) AcknowledgeNotification(ctx context.Context, req AcknowledgeNotificationRequest) error {
+	comment := strings.TrimSpace(req.Comment)
+	if len([]rune(comment)) > 500 {
+		return &UseCaseError{
+			Code:    "VALIDATION_FAILED",
+			Message: "入力値が不正です",
+			Cause:   fmt.Errorf("validation errors: %s", "コメントは500文字以内で入力してください"),
+		}
+	}
+
+	actor, err := uc.verifyActor(ctx, req.ActorID)
+	if err != nil {
+		return err
+	}
+
+	notification, err := uc.getVisibleNotification(ctx, req.ID, actor)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	if err := uc.notificationRepo.Acknowledge(ctx, notification.ID, actor.ID, now); err != nil {
+		return &UseCaseError{
+			Code:    "UPDATE_FAILED",
+			Message: "通知の更新に失敗しました",
+			Cause:   err,
+		}
+	}
+
+	details := fmt.Sprintf("%s: %s", notification.Kind.Label(), notification.Title)
+	if comment != "" {
+		details += fmt.Sprintf(" (対応: %s)", comment)
+	}
+	uc.logAction(ctx, actor.ID, "ACKNOWLEDGE", notification.ID, now, details)
+
+	return nil
+}
+
+// Checks
+
+// checkExpiringCertificates raises one notification per certificate and threshold
+// bucket, so a certificate is reported again as it crosses each threshold.
+// Certificates that have already been renewed are skipped.
+func (uc *notificationUseCase) checkExpiringCertificates(ctx context.Context, now time.Time) ([]*domain.Notification, error) {
+	maxDays := uc.expiryDays[len(uc.expiryDays)-1]
+	certificates, err := uc.certRepo.GetExpiringSoon(ctx, time.Duration(maxDays)*24*time.Hour)
+	if err != nil {
+		return nil, err
+	}
+
+	today := notificationDate(now)
+	recipientCertificates := make(map[domain.ID][]*domain.BenefitCertificate)
+
+	var notifications []*domain.Notification
+	for _, cert := range certificates {
+		endDate := notificationDate(cert.EndDate)
+		if endDate.Before(today) {
+			continue // Already expired
+		}
+		daysLeft := int(endDate.Sub(today).Hours() / 24)
+
+		bucket, severity := uc.expiryBucket(daysLeft)
+
+		others, ok := recipientCertificates[cert.RecipientID]
+		if !ok {
+			others, err = uc.certRepo.GetByRecipientID(ctx, cert.RecipientID)
+			if err != nil {
+				return nil, err
+			}
+			recipientCertificates[cert.RecipientID] = others
+		}
+		if isRenewed(cert, others) {
+			continue
+		}
+
+		recipient, err := uc.recipientRepo.GetByID(ctx, cert.RecipientID)
+		if err != nil {
+			if err == domain.ErrNotFound {
+				continue
+			}
+			return nil, err
+		}
+
+		notifications = append(notifications, &domain.Notification{
+			Severity:   severity,
+			Audience:   domain.NotificationAudienceAll,
+			Title:      fmt.Sprintf("受給者証の有効期限が%d日以内です", bucket),
+			Message:    fmt.Sprintf("%sさんの受給者証（%s）は%sに有効期限を迎えます（残り%d日）。", recipient.Name, cert.ServiceType, cert.EndDate.Format("2006/01/02"), daysLeft),
+			TargetType: "certificate",
+			TargetID:   cert.ID,
+			DedupKey:   fmt.Sprintf("%s:%s:%d", domain.NotificationKindCertificateExpiring, cert.ID, bucket),
+		})
+	}
+
+	return notifications, nil
+}
+
+// checkDischargedAssignments finds discharged recipients that still have assigned staff
+func (uc *notificationUseCase) checkDischargedAssignments(ctx context.Context, now time.Time) ([]*domain.Notification, error) {
+	assignedStaff := make(map[domain.ID]int)
+	var recipientIDs []domain.ID
+
+	for offset := 0; ; offset += notificationPageSize {
+		assignments, err := uc.assignmentRepo.List(ctx, notificationPageSize, offset)
+		if err != nil {
+			return nil, err
+		}
+		for _, assignment := range assignments {
+			if assignment.UnassignedAt != nil {
+				continue
+			}
+			if _, seen := assignedStaff[assignment.RecipientID]; !seen {
+				recipientIDs = append(recipientIDs, assignment.RecipientID)
+			}
+			assignedStaff[assignment.RecipientID]++
+		}
+		if len(assignments) < notificationPageSize {
+			break
+		}
+	}
+
+	today := notificationDate(now)
+
+	var notifications []*domain.Notification
+	for _, recipientID := range recipientIDs {
+		recipient, err := uc.recipientRepo.GetByID(ctx, recipientID)
+		if err != nil {
+			if err == domain.ErrNotFound {
+				continue
+			}
+			return nil, err
+		}
+
+		if recipient.DischargeDate == nil || notificationDate(*recipient.DischargeDate).After(today) {
+			continue
+		}
+
+		notifications = append(notifications, &domain.Notification{
+			Severity:   domain.NotificationSeverityWarning,
+			Audience:   domain.NotificationAudienceAll,
+			Title:      "退所済みの利用者に担当者が割り当てられています",
+			Message:    fmt.Sprintf("%sさんは%sに退所していますが、担当者が%d名残っています。担当を解除してください。", recipient.Name, recipient.DischargeDate.Format("2006/01/02"), assignedStaff[recipientID]),
+			TargetType: "recipient",
+			TargetID:   recipient.ID,
+			DedupKey:   fmt.Sprintf("%s:%s", domain.NotificationKindDischargedAssigned, recipient.ID),
+		})
+	}
+
+	return notifications, nil
+}
+
+// checkDisabilityRenewals raises one notification per handbook, support
+// category and intractable disease designation that is due for renewal, using
+// the same thresholds as certificates. Discharged recipients are skipped.
+func (uc *notificationUseCase) checkDisabilityRenewals(ctx context.Context, now time.Time) ([]*domain.Notification, error) {
+	maxDays := uc.expiryDays[len(uc.expiryDays)-1]
+	today := notificationDate(now)
+
+	var notifications []*domain.Notification
+	for offset := 0; ; offset += notificationPageSize {
+		recipients, err := uc.recipientRepo.List(ctx, notificationPageSize, offset)
+		if err != nil {
+			return nil, err
+		}
+		for _, recipient := range recipients {
+			if recipient.DischargeDate != nil && !notificationDate(*recipient.DischargeDate).After(today) {
+				continue
+			}
+
+			for _, deadline := range disabilityDeadlines(recipient) {
+				due := notificationDate(deadline.date)
+				if due.Before(today) {
+					continue // Already expired
+				}
+				daysLeft := int(due.Sub(today).Hours() / 24)
+				if daysLeft > maxDays {
+					continue
+				}
+				bucket, severity := uc.expiryBucket(daysLeft)
+
+				notifications = append(notifications, &domain.Notification{
+					Severity:   severity,
+					Audience:   domain.NotificationAudienceAll,
+					Title:      fmt.Sprintf("%sの期限が%d日以内です", deadline.label, bucket),
+					Message:    fmt.Sprintf("%sさんの%sは%sに期限を迎えます（残り%d日）。更新の手続きを確認してください。", recipient.Name, deadline.label, deadline.date.Format("2006/01/02"), daysLeft),
+					TargetType: "recipient",
+					TargetID:   recipient.ID,
+					DedupKey:   fmt.Sprintf("%s:%s:%s:%d", domain.NotificationKindDisabilityRenewal, recipient.ID, deadline.key, bucket),
+				})
+			}
+		}
+		if len(recipients) < notificationPageSize {
+			break
+		}
+	}
+
+	return notifications, nil
+}
+
+// checkLockedAccounts reports lockouts that are still in effect to administrators
+func (uc *notificationUseCase) checkLockedAccounts(ctx context.Context, now time.Time) ([]*domain.Notification, error) {
+	lockouts, err := uc.lockoutRepo.GetActiveLockouts(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var notifications []*domain.Notification
+	for _, lockout := range lockouts {
+		if lockout.Duration > 0 && lockout.LockedAt.Add(time.Duration(lockout.Duration)*time.Second).Before(now) {
+			continue // Expired but not yet cleaned up
+		}
+
+		subject := lockout.Username
+		if subject == "" {
+			subject = "IP " + lockout.IPAddress
+		}
+
+		notifications = append(notifications, &domain.Notification{
+			Severity:   domain.NotificationSeverityCritical,
+			Audience:   domain.NotificationAudienceAdmin,
+			Title:      "アカウントがロックされています",
+			Message:    fmt.Sprintf("%sが%sからロックされています（理由: %s）。", subject, lockout.LockedAt.Local().Format("2006/01/02 15:04"), lockout.Reason),
+			TargetType: "lockout",
+			TargetID:   lockout.ID,
+			DedupKey:   fmt.Sprintf("%s:%s", domain.NotificationKindAccountLocked, lockout.ID),
+		})
+	}
+
+	return notifications, nil
+}
+
+// Helper functions
+
+// expiryBucket returns the smallest configured threshold covering daysLeft
+// and its severity: the nearest threshold is critical, the next a warning
+func (uc *notificationUseCase) expiryBucket(daysLeft int) (int, domain.NotificationSeverity) {
+	bucket := uc.expiryDays[len(uc.expiryDays)-1]
+	bucketIndex := len(uc.expiryDays) - 1
+	for i, days := range uc.expiryDays {
+		if daysLeft <= days {
+			bucket, bucketIndex = days, i
+			break
+		}
+	}
+
+	switch bucketIndex {
+	case 0:
+		return bucket, domain.NotificationSeverityCritical
+	case 1:
+		return bucket, domain.NotificationSeverityWarning
+	default:
+		return bucket, domain.NotificationSeverityInfo
+	}
+}
+
+// disabilityDeadline is a renewal date of a recipient's disability data
+type disabilityDeadline struct {
+	key   string // Distinguishes the deadlines of one recipient in the dedup key
+	label string
+	date  time.Time
+}
+
+// disabilityDeadlines lists the renewal dates recorded for the recipient
+func disabilityDeadlines(recipient *domain.Recipient) []disabilityDeadline {
+	var deadlines []disabilityDeadline
+	for _, handbook := range recipient.Handbooks {
+		if handbook.RenewalDate != nil {
+			deadlines = append(deadlines, disabilityDeadline{
+				key:   "handbook-" + string(handbook.Type),
+				label: handbook.Type.Label(),
+				date:  *handbook.RenewalDate,
+			})
+		}
+	}
+	if recipient.SupportCategory != nil {
+		deadlines = append(deadlines, disabilityDeadline{
+			key:   "support_category",
+			label: "障害支援区分",
+			date:  recipient.SupportCategory.ValidUntil,
+		})
+	}
+	if recipient.IntractableDisease != nil && recipient.IntractableDisease.ValidUntil != nil {
+		deadlines = append(deadlines, disabilityDeadline{
+			key:   "intractable_disease",
+			label: "指定難病の医療受給者証",
+			date:  *recipient.IntractableDisease.ValidUntil,
+		})
+	}
+	return deadlines
+}
+
+// isRenewed reports whether the recipient has a certificate ending after cert
+func isRenewed(cert *domain.BenefitCertificate, certificates []*domain.BenefitCertificate) bool {
+	for _, other := range certificates {
+		if other.ID != cert.ID && other.EndDate.After(cert.EndDate) {
+			return true
+		}
+	}
+	return false
+}
+
+// notificationDate truncates a time to its local calendar date
+func notificationDate(t time.Time) time.Time {
+	local := t.Local()
+	return time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.Local)
+}
+
+// audiencesFor returns the notification audiences a staff member belongs to
+func audiencesFor(actor *domain.Staff) []domain.NotificationAudience {
+	if actor.Role == domain.RoleAdmin {
+		return []domain.NotificationAudience{domain.NotificationAudienceAll, domain.NotificationAudienceAdmin}
+	}
+	return []domain.NotificationAudience{domain.NotificationAudienceAll}
+}
+
+// getVisibleNotification retrieves an open notification the actor is allowed to see
+func (uc *notificationUseCase) getVisibleNotification(ctx context.Context, id domain.ID, actor *domain.Staff) (*domain.Notification, error) {
+	notification, err := uc.notificationRepo.GetByID(ctx, id)
+	if err != nil {
+		if err == domain.ErrNotFound {
+			return nil, ErrNotificationNotFound
+		}
+		return nil, &UseCaseError{
+			Code:    "RETRIEVAL_FAILED",
+			Message: "通知の取得に失敗しました",
+			Cause:   err,
+		}
+	}
+
+	if notification.Audience == domain.NotificationAudienceAdmin && actor.Role != domain.RoleAdmin {
+		return nil, ErrUnauthorized
+	}
+
+	return notification, nil
+}
+
+// verifyActor checks that the actor exists
+func (uc *notificationUseCase) verifyActor(ctx context.Context, actorID domain.ID) (*domain.Staff, error) {
+	actor, err := uc.staffRepo.GetByID(ctx, actorID)
+	if err != nil {
+		if err == domain.ErrNotFound {
+			return nil, ErrUnauthorized
+		}
+		return nil, &UseCaseError{
+			Code:    "INTERNAL_ERROR",
+			Message: "内部エラーが発生しました",
+			Cause:   err,
+		}
+	}
+	return actor, nil
+}
+
+// logAction records an audit log entry for a notification
+func (uc *notificationUseCase) logAction(ctx context.Context, actorID domain.ID, action string, notificationID domain.ID, at time.Time, details string) {
+	auditLog := &domain.AuditLog{
+		ID:      domain.ID(uuid.New().String()),
+		ActorID: actorID,
+		Action:  action,
+		Target:  fmt.Sprintf("notification:%s", notificationID),
+		At:      at,
+		IP:      uc.getClientIP(ctx),
+		Details: details,
+	}
+
+	err := uc.auditRepo.Create(ctx, auditLog)
+	if err != nil {
+		// Log audit failure but don't fail the operation
+		uc.log().Warn("failed to write audit log", "action", auditLog.Action, "error", err)
+	}
+}
+
+func (uc *notificationUseCase) getClientIP(ctx context.Context) string {
+	if ip := ctx.Value(ContextKeyClientIP); ip != nil {
+		if clientIP, ok := ip.(string); ok {
+			return clientIP
+		}
+	}
+	return "unknown"
+}
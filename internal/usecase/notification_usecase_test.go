@@ -0,0 +1,339 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"shien-system/internal/domain"
+)
+
+type mockNotificationReceipt struct {
+	readAt, acknowledgedAt *time.Time
+}
+
+type mockNotificationRepository struct {
+	notifications []*domain.Notification
+	receipts      map[string]*mockNotificationReceipt // notification ID + "/" + staff ID
+}
+
+func (m *mockNotificationRepository) CreateIfAbsent(ctx context.Context, notification *domain.Notification) (bool, error) {
+	for _, existing := range m.notifications {
+		if existing.DedupKey == notification.DedupKey && existing.ResolvedAt == nil {
+			return false, nil
+		}
+	}
+	copied := *notification
+	m.notifications = append(m.notifications, &copied)
+	return true, nil
+}
+
+func (m *mockNotificationRepository) GetByID(ctx context.Context, id domain.ID) (*domain.Notification, error) {
+	for _, notification := range m.notifications {
+		if notification.ID == id {
+			copied := *notification
+			return &copied, nil
+		}
+	}
+	return nil, domain.ErrNotFound
+}
+
+func (m *mockNotificationRepository) ResolveStale(ctx context.Context, kind domain.NotificationKind, activeKeys []string, resolvedAt time.Time) (int, error) {
+	active := make(map[string]bool)
+	for _, key := range activeKeys {
+		active[key] = true
+	}
+	resolved := 0
+	for _, notification := range m.notifications {
+		if notification.Kind == kind && notification.ResolvedAt == nil && !active[notification.DedupKey] {
+			at := resolvedAt
+			notification.ResolvedAt = &at
+			resolved++
+		}
+	}
+	return resolved, nil
+}
+
+func (m *mockNotificationRepository) ListForUser(ctx context.Context, userID domain.ID, audiences []domain.NotificationAudience, unreadOnly bool, limit, offset int) ([]*domain.Notification, error) {
+	var result []*domain.Notification
+	for _, notification := range m.notifications {
+		if notification.ResolvedAt != nil || !containsAudience(audiences, notification.Audience) {
+			continue
+		}
+		copied := *notification
+		if receipt, ok := m.receipts[string(notification.ID)+"/"+string(userID)]; ok {
+			copied.ReadAt = receipt.readAt
+			copied.AcknowledgedAt = receipt.acknowledgedAt
+		}
+		if unreadOnly && copied.ReadAt != nil {
+			continue
+		}
+		result = append(result, &copied)
+	}
+	return result, nil
+}
+
+func (m *mockNotificationRepository) CountUnread(ctx context.Context, userID domain.ID, audiences []domain.NotificationAudience) (int, error) {
+	unread, _ := m.ListForUser(ctx, userID, audiences, true, 0, 0)
+	return len(unread), nil
+}
+
+func (m *mockNotificationRepository) receipt(id, userID domain.ID) *mockNotificationReceipt {
+	if m.receipts == nil {
+		m.receipts = make(map[string]*mockNotificationReceipt)
+	}
+	key := string(id) + "/" + string(userID)
+	if _, ok := m.receipts[key]; !ok {
+		m.receipts[key] = &mockNotificationReceipt{}
+	}
+	return m.receipts[key]
+}
+
+func (m *mockNotificationRepository) MarkRead(ctx context.Context, id domain.ID, userID domain.ID, readAt time.Time) error {
+	receipt := m.receipt(id, userID)
+	if receipt.readAt == nil {
+		receipt.readAt = &readAt
+	}
+	return nil
+}
+
+func (m *mockNotificationRepository) MarkAllRead(ctx context.Context, userID domain.ID, audiences []domain.NotificationAudience, readAt time.Time) error {
+	notifications, _ := m.ListForUser(ctx, userID, audiences, true, 0, 0)
+	for _, notification := range notifications {
+		m.MarkRead(ctx, notification.ID, userID, readAt)
+	}
+	return nil
+}
+
+func (m *mockNotificationRepository) Acknowledge(ctx context.Context, id domain.ID, userID domain.ID, acknowledgedAt time.Time) error {
+	m.MarkRead(ctx, id, userID, acknowledgedAt)
+	receipt := m.receipt(id, userID)
+	if receipt.acknowledgedAt == nil {
+		receipt.acknowledgedAt = &acknowledgedAt
+	}
+	return nil
+}
+
+func containsAudience(audiences []domain.NotificationAudience, audience domain.NotificationAudience) bool {
+	for _, a := range audiences {
+		if a == audience {
+			return true
+		}
+	}
+	return false
+}
+
+type notificationTestFixture struct {
+	uc               NotificationUseCase
+	notificationRepo *mockNotificationRepository
+	certRepo         *mockCertificateRepository
+	recipientRepo    *mockRecipientRepository
+	assignmentRepo   *mockStaffAssignmentRepository
+	lockoutRepo      *MockAccountLockoutRepository
+	auditRepo        *mockAuditLogRepository
+}
+
+func setupNotificationUseCase() *notificationTestFixture {
+	now := time.Now()
+	discharged := now.AddDate(0, 0, -3)
+	recipientRepo := &mockRecipientRepository{
+		recipients: map[domain.ID]*domain.Recipient{
+			"recipient-001": {ID: "recipient-001", Name: "期限太郎"},
+			"recipient-002": {ID: "recipient-002", Name: "更新花子"},
+			"recipient-003": {ID: "recipient-003", Name: "退所次郎", DischargeDate: &discharged},
+		},
+	}
+	staffRepo := &mockStaffRepository{
+		staff: map[domain.ID]*domain.Staff{
+			"staff-001": {ID: "staff-001", Name: "職員", Role: domain.RoleStaff},
+			"admin-001": {ID: "admin-001", Name: "管理者", Role: domain.RoleAdmin},
+		},
+	}
+	f := &notificationTestFixture{
+		notificationRepo: &mockNotificationRepository{},
+		recipientRepo:    recipientRepo,
+		certRepo: &mockCertificateRepository{
+			certificates: map[domain.ID]*domain.BenefitCertificate{
+				"cert-001": {ID: "cert-001", RecipientID: "recipient-001", ServiceType: "生活介護", EndDate: now.AddDate(0, 0, 20)},
+				"cert-002": {ID: "cert-002", RecipientID: "recipient-002", ServiceType: "就労継続支援B型", EndDate: now.AddDate(0, 0, 45)},
+				// cert-002 has already been renewed
+				"cert-003": {ID: "cert-003", RecipientID: "recipient-002", ServiceType: "就労継続支援B型", EndDate: now.AddDate(1, 0, 0)},
+				// Long expired certificates are not reported
+				"cert-004": {ID: "cert-004", RecipientID: "recipient-001", ServiceType: "生活介護", EndDate: now.AddDate(-1, 0, 0)},
+			},
+		},
+		assignmentRepo: &mockStaffAssignmentRepository{
+			assignments: map[domain.ID]*domain.StaffAssignment{
+				"assignment-001": {ID: "assignment-001", RecipientID: "recipient-003", StaffID: "staff-001"},
+				"assignment-002": {ID: "assignment-002", RecipientID: "recipient-001", StaffID: "staff-001"},
+			},
+		},
+		lockoutRepo: NewMockAccountLockoutRepository(),
+		auditRepo:   &mockAuditLogRepository{},
+	}
+	f.lockoutRepo.Create(context.Background(), &domain.AccountLockout{
+		ID: "lockout-001", Username: "tanaka", LockedAt: now.Add(-time.Minute), Duration: 1800, Reason: "too many failed attempts",
+	})
+
+	f.uc = NewNotificationUseCase(f.notificationRepo, f.certRepo, recipientRepo, f.assignmentRepo, f.lockoutRepo, staffRepo, f.auditRepo,
+		NotificationSettings{CertificateExpiryDays: []int{90, 30, 60}})
+	return f
+}
+
+func TestNotificationUseCase_RefreshNotifications(t *testing.T) {
+	f := setupNotificationUseCase()
+	ctx := context.Background()
+
+	result, err := f.uc.RefreshNotifications(ctx)
+	if err != nil {
+		t.Fatalf("RefreshNotifications() error = %v", err)
+	}
+	if result.Created != 3 || result.Resolved != 0 {
+		t.Fatalf("RefreshNotifications() = %+v, want 3 created", result)
+	}
+
+	byKind := make(map[domain.NotificationKind]*domain.Notification)
+	for _, notification := range f.notificationRepo.notifications {
+		byKind[notification.Kind] = notification
+	}
+	cert := byKind[domain.NotificationKindCertificateExpiring]
+	if cert == nil || cert.TargetID != "cert-001" || cert.Severity != domain.NotificationSeverityCritical {
+		t.Errorf("certificate notification = %+v", cert)
+	}
+	if cert != nil && cert.DedupKey != "certificate_expiring:cert-001:30" {
+		t.Errorf("DedupKey = %s", cert.DedupKey)
+	}
+	if discharged := byKind[domain.NotificationKindDischargedAssigned]; discharged == nil || discharged.TargetID != "recipient-003" {
+		t.Errorf("discharged notification = %+v", discharged)
+	}
+	if locked := byKind[domain.NotificationKindAccountLocked]; locked == nil || locked.Audience != domain.NotificationAudienceAdmin {
+		t.Errorf("lockout notification = %+v", locked)
+	}
+
+	// Running again does not duplicate open notifications
+	result, err = f.uc.RefreshNotifications(ctx)
+	if err != nil || result.Created != 0 {
+		t.Fatalf("second RefreshNotifications() = %+v, %v", result, err)
+	}
+
+	// Renewing the certificate and unlocking the account resolves their notifications
+	f.certRepo.certificates["cert-005"] = &domain.BenefitCertificate{ID: "cert-005", RecipientID: "recipient-001", EndDate: time.Now().AddDate(1, 0, 0)}
+	f.lockoutRepo.Unlock(ctx, "lockout-001", time.Now())
+	result, err = f.uc.RefreshNotifications(ctx)
+	if err != nil || result.Resolved != 2 {
+		t.Fatalf("RefreshNotifications() after changes = %+v, %v", result, err)
+	}
+}
+
+func TestNotificationUseCase_RefreshNotifications_DisabilityRenewals(t *testing.T) {
+	f := setupNotificationUseCase()
+	ctx := context.Background()
+	now := time.Now()
+
+	soon, later, past := now.AddDate(0, 0, 10), now.AddDate(0, 0, 50), now.AddDate(0, 0, -5)
+	f.recipientRepo.recipients["recipient-001"].Handbooks = []domain.DisabilityHandbook{
+		{Type: domain.HandbookTypeIntellectual, Number: "第1号", Grade: "B1", RenewalDate: &soon},
+		{Type: domain.HandbookTypePhysical, Number: "第2号", Grade: "2級"}, // Does not expire
+	}
+	f.recipientRepo.recipients["recipient-002"].SupportCategory = &domain.SupportCategory{
+		Level: 3, ValidFrom: now.AddDate(-3, 0, 0), ValidUntil: later,
+	}
+	f.recipientRepo.recipients["recipient-002"].IntractableDisease = &domain.IntractableDisease{Name: "パーキンソン病", ValidUntil: &past}
+	// Discharged recipients are not reminded
+	f.recipientRepo.recipients["recipient-003"].SupportCategory = &domain.SupportCategory{
+		Level: 2, ValidFrom: now.AddDate(-3, 0, 0), ValidUntil: soon,
+	}
+
+	if _, err := f.uc.RefreshNotifications(ctx); err != nil {
+		t.Fatalf("RefreshNotifications() error = %v", err)
+	}
+
+	renewals := make(map[string]*domain.Notification)
+	for _, notification := range f.notificationRepo.notifications {
+		if notification.Kind == domain.NotificationKindDisabilityRenewal {
+			renewals[notification.DedupKey] = notification
+		}
+	}
+	if len(renewals) != 2 {
+		t.Fatalf("renewal notifications = %d, want 2: %v", len(renewals), renewals)
+	}
+	handbook := renewals["disability_renewal:recipient-001:handbook-intellectual:30"]
+	if handbook == nil || handbook.Severity != domain.NotificationSeverityCritical || handbook.TargetID != "recipient-001" {
+		t.Errorf("handbook notification = %+v", handbook)
+	}
+	if handbook != nil && handbook.Title != "療育手帳の期限が30日以内です" {
+		t.Errorf("Title = %q", handbook.Title)
+	}
+	category := renewals["disability_renewal:recipient-002:support_category:60"]
+	if category == nil || category.Severity != domain.NotificationSeverityWarning {
+		t.Errorf("support category notification = %+v", category)
+	}
+
+	// Recording the renewed certification resolves the notification
+	f.recipientRepo.recipients["recipient-002"].SupportCategory.ValidUntil = now.AddDate(3, 0, 0)
+	result, err := f.uc.RefreshNotifications(ctx)
+	if err != nil || result.Resolved != 1 {
+		t.Fatalf("RefreshNotifications() after renewal = %+v, %v", result, err)
+	}
+}
+
+func TestNotificationUseCase_ReadAndAcknowledge(t *testing.T) {
+	f := setupNotificationUseCase()
+	ctx := context.Background()
+
+	if _, err := f.uc.RefreshNotifications(ctx); err != nil {
+		t.Fatalf("RefreshNotifications() error = %v", err)
+	}
+
+	// Lockout notifications are only visible to administrators
+	staffList, err := f.uc.ListNotifications(ctx, ListNotificationsRequest{ActorID: "staff-001"})
+	if err != nil || len(staffList) != 2 {
+		t.Fatalf("ListNotifications() staff = %d, %v", len(staffList), err)
+	}
+	if count, _ := f.uc.CountUnread(ctx, "admin-001"); count != 3 {
+		t.Errorf("CountUnread() admin = %d, want 3", count)
+	}
+
+	var lockoutID domain.ID
+	for _, notification := range f.notificationRepo.notifications {
+		if notification.Kind == domain.NotificationKindAccountLocked {
+			lockoutID = notification.ID
+		}
+	}
+	if err := f.uc.MarkRead(ctx, lockoutID, "staff-001"); err != ErrUnauthorized {
+		t.Errorf("MarkRead() by staff error = %v, want ErrUnauthorized", err)
+	}
+	if err := f.uc.MarkRead(ctx, "missing", "staff-001"); err != ErrNotificationNotFound {
+		t.Errorf("MarkRead() missing error = %v, want ErrNotificationNotFound", err)
+	}
+
+	if err := f.uc.MarkRead(ctx, staffList[0].ID, "staff-001"); err != nil {
+		t.Fatalf("MarkRead() error = %v", err)
+	}
+	if count, _ := f.uc.CountUnread(ctx, "staff-001"); count != 1 {
+		t.Errorf("CountUnread() staff = %d, want 1", count)
+	}
+	// Read state is per user
+	if count, _ := f.uc.CountUnread(ctx, "admin-001"); count != 3 {
+		t.Errorf("CountUnread() admin after staff read = %d, want 3", count)
+	}
+
+	err = f.uc.AcknowledgeNotification(ctx, AcknowledgeNotificationRequest{ID: lockoutID, Comment: "本人確認のうえ解除済み", ActorID: "admin-001"})
+	if err != nil {
+		t.Fatalf("AcknowledgeNotification() error = %v", err)
+	}
+	if len(f.auditRepo.logs) != 1 || f.auditRepo.logs[0].Action != "ACKNOWLEDGE" || f.auditRepo.logs[0].Target != "notification:"+string(lockoutID) {
+		t.Errorf("audit logs = %+v", f.auditRepo.logs)
+	}
+
+	if err := f.uc.MarkAllRead(ctx, "admin-001"); err != nil {
+		t.Fatalf("MarkAllRead() error = %v", err)
+	}
+	if count, _ := f.uc.CountUnread(ctx, "admin-001"); count != 0 {
+		t.Errorf("CountUnread() admin after MarkAllRead = %d, want 0", count)
+	}
+
+	if _, err := f.uc.ListNotifications(ctx, ListNotificationsRequest{ActorID: "unknown"}); err != ErrUnauthorized {
+		t.Errorf("ListNotifications() unknown actor error = %v, want ErrUnauthorized", err)
+	}
+}
@@ -0,0 +1,165 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"shien-system/internal/domain"
+	"shien-system/internal/validation"
+)
+
+// postalCodeImportAction is the audit action of a dictionary import
+const postalCodeImportAction = "POSTAL_CODES_IMPORTED"
+
+// postalCodeUseCase implements PostalCodeUseCase interface
+type postalCodeUseCase struct {
+	postalRepo domain.PostalCodeRepository
+	parser     PostalDictionaryParser
+	staffRepo  domain.StaffRepository
+	auditRepo  domain.AuditLogRepository
+
+	useCaseLogger
+}
+
+// NewPostalCodeUseCase creates a new postal code usecase
+func NewPostalCodeUseCase(
+	postalRepo domain.PostalCodeRepository,
+	parser PostalDictionaryParser,
+	staffRepo domain.StaffRepository,
+	auditRepo domain.AuditLogRepository,
+) PostalCodeUseCase {
+	return &postalCodeUseCase{
+		postalRepo: postalRepo,
+		parser:     parser,
+		staffRepo:  staffRepo,
+		auditRepo:  auditRepo,
+	}
+}
+
+// LookupAddress returns the dictionary entries for a postal code
+func (uc *postalCodeUseCase) LookupAddress(ctx context.Context, code string) ([]*domain.PostalAddress, error) {
+	digits := validation.PostalCodeDigits(code)
+	if digits == "" {
+		return nil, nil
+	}
+
+	addresses, err := uc.postalRepo.FindByCode(ctx, digits)
+	if err != nil {
+		return nil, &UseCaseError{
+			Code:    "RETRIEVAL_FAILED",
+			Message: "郵便番号の検索に失敗しました",
+			Cause:   err,
+		}
+	}
+
+	return addresses, nil
+}
+
+// IsKnownPostalCode reports whether the code is in the dictionary
+func (uc *postalCodeUseCase) IsKnownPostalCode(ctx context.Context, code string) (bool, error) {
+	known, err := postalCodeKnown(ctx, uc.postalRepo, code)
+	if err != nil {
+		return false, &UseCaseError{
+			Code:    "RETRIEVAL_FAILED",
+			Message: "郵便番号の検索に失敗しました",
+			Cause:   err,
+		}
+	}
+	return known, nil
+}
+
+// ImportDictionary parses KEN_ALL.CSV and replaces the dictionary
+func (uc *postalCodeUseCase) ImportDictionary(ctx context.Context, req ImportPostalCodesRequest) (int, error) {
+	if req.Source == nil {
+		return 0, &UseCaseError{
+			Code:    "VALIDATION_FAILED",
+			Message: "入力値が不正です",
+			Cause:   fmt.Errorf("postal code file is required"),
+		}
+	}
+
+	if _, err := verifyAdmin(ctx, uc.staffRepo, req.ActorID); err != nil {
+		return 0, err
+	}
+
+	addresses, err := uc.parser.Parse(req.Source)
+	if err != nil {
+		return 0, &UseCaseError{
+			Code:    "INVALID_POSTAL_CODE_FILE",
+			Message: "郵便番号データ（KEN_ALL.CSV）を読み込めませんでした",
+			Cause:   err,
+		}
+	}
+
+	if err := uc.postalRepo.ReplaceAll(ctx, addresses); err != nil {
+		return 0, &UseCaseError{
+			Code:    "IMPORT_FAILED",
+			Message: "郵便番号辞書の更新に失敗しました",
+			Cause:   err,
+		}
+	}
+
+	auditLog := &domain.AuditLog{
+		ID:      domain.ID(uuid.New().String()),
+		ActorID: req.ActorID,
+		Action:  postalCodeImportAction,
+		Target:  "postal_codes",
+		At:      time.Now(),
+		IP:      uc.getClientIP(ctx),
+		Details: fmt.Sprintf("郵便番号辞書を取り込みました（%d件）", len(addresses)),
+	}
+	if err := uc.auditRepo.Create(ctx, auditLog); err != nil {
+		// Log audit failure but don't fail the operation
+		uc.log().Warn("failed to write audit log", "action", auditLog.Action, "error", err)
+	}
+
+	return len(addresses), nil
+}
+
+// DictionarySize returns the number of dictionary entries
+func (uc *postalCodeUseCase) DictionarySize(ctx context.Context) (int, error) {
+	count, err := uc.postalRepo.Count(ctx)
+	if err != nil {
+		return 0, &UseCaseError{
+			Code:    "RETRIEVAL_FAILED",
+			Message: "郵便番号辞書の件数を取得できませんでした",
+			Cause:   err,
+		}
+	}
+	return count, nil
+}
+
+func (uc *postalCodeUseCase) getClientIP(ctx context.Context) string {
+	if ip := ctx.Value(ContextKeyClientIP); ip != nil {
+		if clientIP, ok := ip.(string); ok {
+			return clientIP
+		}
+	}
+	return "unknown"
+}
+
+// postalCodeKnown reports whether code is in the dictionary. Malformed codes
+// are unknown; every well-formed code is known while the dictionary is empty,
+// so installations that have not imported KEN_ALL.CSV keep working.
+func postalCodeKnown(ctx context.Context, repo domain.PostalCodeRepository, code string) (bool, error) {
+	digits := validation.PostalCodeDigits(code)
+	if digits == "" {
+		return false, nil
+	}
+
+	addresses, err := repo.FindByCode(ctx, digits)
+	if err != nil {
+		return false, err
+	}
+	if len(addresses) > 0 {
+		return true, nil
+	}
+
+	count, err := repo.Count(ctx)
+	if err != nil {
+		return false, err
+	}
+	return count == 0, nil
+}
@@ -0,0 +1,169 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"shien-system/internal/domain"
+)
+
+// mockPostalCodeRepository keeps the dictionary in memory
+type mockPostalCodeRepository struct {
+	addresses []*domain.PostalAddress
+}
+
+func (m *mockPostalCodeRepository) FindByCode(ctx context.Context, code string) ([]*domain.PostalAddress, error) {
+	var found []*domain.PostalAddress
+	for _, address := range m.addresses {
+		if address.Code == code {
+			found = append(found, address)
+		}
+	}
+	return found, nil
+}
+
+func (m *mockPostalCodeRepository) ReplaceAll(ctx context.Context, addresses []*domain.PostalAddress) error {
+	m.addresses = addresses
+	return nil
+}
+
+func (m *mockPostalCodeRepository) Count(ctx context.Context) (int, error) {
+	return len(m.addresses), nil
+}
+
+// postalParserFunc adapts a function to PostalDictionaryParser
+type postalParserFunc func(r io.Reader) ([]*domain.PostalAddress, error)
+
+func (f postalParserFunc) Parse(r io.Reader) ([]*domain.PostalAddress, error) {
+	return f(r)
+}
+
+func setupPostalCodeUseCase(parser PostalDictionaryParser) (PostalCodeUseCase, *mockPostalCodeRepository, *mockAuditLogRepository) {
+	postalRepo := &mockPostalCodeRepository{}
+	staffRepo := &mockStaffRepository{
+		staff: map[domain.ID]*domain.Staff{
+			"admin-001": {ID: "admin-001", Name: "管理者", Role: domain.RoleAdmin},
+			"staff-001": {ID: "staff-001", Name: "職員", Role: domain.RoleStaff},
+		},
+	}
+	auditRepo := &mockAuditLogRepository{}
+	return NewPostalCodeUseCase(postalRepo, parser, staffRepo, auditRepo), postalRepo, auditRepo
+}
+
+func TestPostalCodeUseCase_ImportAndLookup(t *testing.T) {
+	parser := postalParserFunc(func(r io.Reader) ([]*domain.PostalAddress, error) {
+		return []*domain.PostalAddress{
+			{Code: "1000001", Prefecture: "東京都", City: "千代田区", Town: "千代田"},
+		}, nil
+	})
+	uc, _, auditRepo := setupPostalCodeUseCase(parser)
+	ctx := context.Background()
+
+	// Every well-formed code passes until a dictionary is imported
+	if known, err := uc.IsKnownPostalCode(ctx, "999-9999"); err != nil || !known {
+		t.Errorf("IsKnownPostalCode() before import = %v, %v, want true", known, err)
+	}
+
+	count, err := uc.ImportDictionary(ctx, ImportPostalCodesRequest{Source: strings.NewReader("csv"), ActorID: "admin-001"})
+	if err != nil {
+		t.Fatalf("ImportDictionary() error = %v", err)
+	}
+	if count != 1 {
+		t.Errorf("ImportDictionary() = %d, want 1", count)
+	}
+	if len(auditRepo.logs) != 1 || auditRepo.logs[0].Action != postalCodeImportAction {
+		t.Errorf("audit logs = %+v, want one %s entry", auditRepo.logs, postalCodeImportAction)
+	}
+
+	addresses, err := uc.LookupAddress(ctx, "〒１００－０００１")
+	if err != nil {
+		t.Fatalf("LookupAddress() error = %v", err)
+	}
+	if len(addresses) != 1 || addresses[0].Town != "千代田" {
+		t.Errorf("LookupAddress() = %+v, want 千代田", addresses)
+	}
+	if addresses, err := uc.LookupAddress(ctx, "100"); err != nil || len(addresses) != 0 {
+		t.Errorf("LookupAddress() of a partial code = %v, %v, want none", addresses, err)
+	}
+
+	for code, want := range map[string]bool{"100-0001": true, "999-9999": false, "12-34": false} {
+		if known, err := uc.IsKnownPostalCode(ctx, code); err != nil || known != want {
+			t.Errorf("IsKnownPostalCode(%q) = %v, %v, want %v", code, known, err, want)
+		}
+	}
+}
+
+func TestPostalCodeUseCase_ImportRequiresAdmin(t *testing.T) {
+	parser := postalParserFunc(func(r io.Reader) ([]*domain.PostalAddress, error) {
+		t.Error("file parsed for a non-administrator")
+		return nil, nil
+	})
+	uc, _, _ := setupPostalCodeUseCase(parser)
+
+	_, err := uc.ImportDictionary(context.Background(), ImportPostalCodesRequest{Source: strings.NewReader("csv"), ActorID: "staff-001"})
+	if !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("ImportDictionary() error = %v, want ErrUnauthorized", err)
+	}
+}
+
+func TestPostalCodeUseCase_ImportKeepsDictionaryOnParseError(t *testing.T) {
+	parser := postalParserFunc(func(r io.Reader) ([]*domain.PostalAddress, error) {
+		return nil, errors.New("line 3: invalid postal code")
+	})
+	uc, postalRepo, _ := setupPostalCodeUseCase(parser)
+	postalRepo.addresses = []*domain.PostalAddress{{Code: "1000001"}}
+
+	_, err := uc.ImportDictionary(context.Background(), ImportPostalCodesRequest{Source: strings.NewReader("csv"), ActorID: "admin-001"})
+	var ucErr *UseCaseError
+	if !errors.As(err, &ucErr) || ucErr.Code != "INVALID_POSTAL_CODE_FILE" {
+		t.Errorf("ImportDictionary() error = %v, want INVALID_POSTAL_CODE_FILE", err)
+	}
+	if len(postalRepo.addresses) != 1 {
+		t.Error("dictionary replaced although the file could not be read")
+	}
+}
+
+func TestRecipientUseCase_CreateRecipientPostalAddress(t *testing.T) {
+	postalRepo := &mockPostalCodeRepository{addresses: []*domain.PostalAddress{
+		{Code: "1500002", Prefecture: "東京都", City: "渋谷区", Town: "渋谷"},
+	}}
+	staffRepo := &mockStaffRepository{
+		staff: map[domain.ID]*domain.Staff{"staff-001": {ID: "staff-001", Role: domain.RoleStaff}},
+	}
+	uc := NewRecipientUseCase(&mockTransactional{}, &mockRecipientRepository{}, staffRepo, &mockStaffAssignmentRepository{},
+		&mockEnrollmentPeriodRepository{}, &mockAuditLogRepository{}, postalRepo)
+	ctx := context.Background()
+
+	req := CreateRecipientRequest{
+		Name:       "テスト利用者",
+		Sex:        domain.SexMale,
+		BirthDate:  time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC),
+		Address:    "古い住所",
+		PostalCode: "１５００００２",
+		Prefecture: "東京都",
+		City:       "渋谷区",
+		Street:     "渋谷１－１－１",
+		ActorID:    "staff-001",
+	}
+	recipient, err := uc.CreateRecipient(ctx, req)
+	if err != nil {
+		t.Fatalf("CreateRecipient() error = %v", err)
+	}
+	if recipient.PostalCode != "150-0002" {
+		t.Errorf("PostalCode = %q, want 150-0002", recipient.PostalCode)
+	}
+	if recipient.Address != "東京都渋谷区渋谷1-1-1" {
+		t.Errorf("Address = %q, want the components joined", recipient.Address)
+	}
+
+	req.PostalCode = "999-9999"
+	_, err = uc.CreateRecipient(ctx, req)
+	var ucErr *UseCaseError
+	if !errors.As(err, &ucErr) || ucErr.Code != "VALIDATION_FAILED" {
+		t.Errorf("CreateRecipient() with an unknown postal code error = %v, want VALIDATION_FAILED", err)
+	}
+}
@@ -0,0 +1,311 @@
+package usecase
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"shien-system/internal/domain"
+)
+
+// rateLimitPolicyField describes one setting of the rate limit policy. The
+// same table drives hashing, diffing and copying so they never disagree.
+type rateLimitPolicyField struct {
+	key   string
+	label string
+	value func(c *domain.RateLimitConfig) string
+}
+
+var rateLimitPolicyFields = []rateLimitPolicyField{
+	{"enabled", "レート制限", func(c *domain.RateLimitConfig) string { return strconv.FormatBool(c.Enabled) }},
+	{"max_attempts_per_ip", "IPごとの試行上限", func(c *domain.RateLimitConfig) string { return strconv.Itoa(c.MaxAttemptsPerIP) }},
+	{"max_attempts_per_user", "ユーザーごとの試行上限", func(c *domain.RateLimitConfig) string { return strconv.Itoa(c.MaxAttemptsPerUser) }},
+	{"window_size_minutes", "集計期間（分）", func(c *domain.RateLimitConfig) string { return strconv.Itoa(c.WindowSizeMinutes) }},
+	{"lockout_duration_minutes", "ロック時間（分）", func(c *domain.RateLimitConfig) string { return strconv.Itoa(c.LockoutDurationMinutes) }},
+	{"backoff_multiplier", "延長倍率", func(c *domain.RateLimitConfig) string { return strconv.FormatFloat(c.BackoffMultiplier, 'g', -1, 64) }},
+	{"max_lockout_hours", "最大ロック時間（時間）", func(c *domain.RateLimitConfig) string { return strconv.Itoa(c.MaxLockoutHours) }},
+	{"whitelist_ips", "許可リスト", func(c *domain.RateLimitConfig) string { return strings.Join(c.WhitelistIPs, ",") }},
+	{"enable_progressive_lockout", "段階的ロック", func(c *domain.RateLimitConfig) string { return strconv.FormatBool(c.EnableProgressiveLockout) }},
+}
+
+// rateLimitPolicyUseCase implements RateLimitPolicyUseCase interface
+type rateLimitPolicyUseCase struct {
+	configRepo domain.RateLimitConfigRepository
+	staffRepo  domain.StaffRepository
+	auditRepo  domain.AuditLogRepository
+
+	mu         sync.RWMutex
+	fileConfig *domain.RateLimitConfig // Values read from config.yaml at startup
+
+	useCaseLogger
+}
+
+// NewRateLimitPolicyUseCase creates a new rate limit policy usecase
+func NewRateLimitPolicyUseCase(
+	configRepo domain.RateLimitConfigRepository,
+	staffRepo domain.StaffRepository,
+	auditRepo domain.AuditLogRepository,
+) RateLimitPolicyUseCase {
+	return &rateLimitPolicyUseCase{
+		configRepo: configRepo,
+		staffRepo:  staffRepo,
+		auditRepo:  auditRepo,
+	}
+}
+
+// SyncFromConfig seeds or updates the stored policy from the config file unless an
+// administrator has taken it over, in which case the differences are reported
+func (uc *rateLimitPolicyUseCase) SyncFromConfig(ctx context.Context, fromFile *domain.RateLimitConfig) (*RateLimitSyncReport, error) {
+	if errs := fromFile.Validate(); len(errs) > 0 {
+		return nil, securityValidationError(errs)
+	}
+
+	file := &domain.RateLimitConfig{}
+	copyRateLimitPolicy(file, fromFile)
+	file.Source = domain.RateLimitSourceConfig
+	file.ConfigHash = rateLimitPolicyHash(file)
+
+	uc.mu.Lock()
+	uc.fileConfig = file
+	uc.mu.Unlock()
+
+	current, err := uc.configRepo.Get(ctx)
+	if err != nil {
+		return nil, uc.fetchError(err)
+	}
+
+	diffs := diffRateLimitPolicy(current, file)
+	report := &RateLimitSyncReport{Source: current.Source}
+
+	if current.Source == domain.RateLimitSourceAdmin {
+		report.Conflicts = diffs
+		report.FileChanged = current.ConfigHash != file.ConfigHash
+		return report, nil
+	}
+
+	if current.Source == domain.RateLimitSourceConfig && current.ConfigHash == file.ConfigHash && len(diffs) == 0 {
+		return report, nil
+	}
+
+	copyRateLimitPolicy(current, file)
+	current.Source = domain.RateLimitSourceConfig
+	current.ConfigHash = file.ConfigHash
+	current.UpdatedBy = ""
+
+	if err := uc.configRepo.Update(ctx, current); err != nil {
+		return nil, uc.updateError(err)
+	}
+
+	report.Source = current.Source
+	report.Applied = true
+	report.Changes = diffs
+	return report, nil
+}
+
+// GetPolicy returns the effective policy for the admin screen
+func (uc *rateLimitPolicyUseCase) GetPolicy(ctx context.Context, actorID domain.ID) (*RateLimitPolicy, error) {
+	if _, err := verifyAdmin(ctx, uc.staffRepo, actorID); err != nil {
+		return nil, err
+	}
+
+	current, err := uc.configRepo.Get(ctx)
+	if err != nil {
+		return nil, uc.fetchError(err)
+	}
+
+	policy := &RateLimitPolicy{Effective: current}
+	if file := uc.loadedFileConfig(); file != nil {
+		policy.File = file
+		policy.Conflicts = diffRateLimitPolicy(current, file)
+	}
+
+	return policy, nil
+}
+
+// UpdatePolicy stores the policy entered by an administrator
+func (uc *rateLimitPolicyUseCase) UpdatePolicy(ctx context.Context, req UpdateRateLimitPolicyRequest) (*domain.RateLimitConfig, error) {
+	reason := strings.TrimSpace(req.Reason)
+
+	errs := req.Policy.Validate()
+	errs = append(errs, validateSecurityReason(reason)...)
+	if len(errs) > 0 {
+		return nil, securityValidationError(errs)
+	}
+
+	actor, err := verifyAdmin(ctx, uc.staffRepo, req.ActorID)
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := uc.configRepo.Get(ctx)
+	if err != nil {
+		return nil, uc.fetchError(err)
+	}
+
+	diffs := diffRateLimitPolicy(current, &req.Policy)
+	if len(diffs) == 0 {
+		return nil, securityValidationError([]string{"変更された項目がありません"})
+	}
+
+	copyRateLimitPolicy(current, &req.Policy)
+	current.Source = domain.RateLimitSourceAdmin
+	current.UpdatedBy = actor.ID
+	// Remember which config file the administrator overrode so a later edit of
+	// config.yaml can be reported
+	if file := uc.loadedFileConfig(); file != nil {
+		current.ConfigHash = file.ConfigHash
+	}
+
+	if err := uc.configRepo.Update(ctx, current); err != nil {
+		return nil, uc.updateError(err)
+	}
+
+	uc.logAction(ctx, actor.ID, "RATE_LIMIT_POLICY_UPDATED",
+		fmt.Sprintf("Rate limit policy updated (%s): %s", describeRateLimitDiffs(diffs), reason))
+
+	return current, nil
+}
+
+// ApplyConfigFile hands the policy back to config.yaml
+func (uc *rateLimitPolicyUseCase) ApplyConfigFile(ctx context.Context, req ApplyRateLimitConfigRequest) (*domain.RateLimitConfig, error) {
+	reason := strings.TrimSpace(req.Reason)
+	if errs := validateSecurityReason(reason); len(errs) > 0 {
+		return nil, securityValidationError(errs)
+	}
+
+	actor, err := verifyAdmin(ctx, uc.staffRepo, req.ActorID)
+	if err != nil {
+		return nil, err
+	}
+
+	file := uc.loadedFileConfig()
+	if file == nil {
+		return nil, ErrConfigFileNotLoaded
+	}
+
+	current, err := uc.configRepo.Get(ctx)
+	if err != nil {
+		return nil, uc.fetchError(err)
+	}
+
+	diffs := diffRateLimitPolicy(current, file)
+	copyRateLimitPolicy(current, file)
+	current.Source = domain.RateLimitSourceConfig
+	current.ConfigHash = file.ConfigHash
+	current.UpdatedBy = actor.ID
+
+	if err := uc.configRepo.Update(ctx, current); err != nil {
+		return nil, uc.updateError(err)
+	}
+
+	uc.logAction(ctx, actor.ID, "RATE_LIMIT_POLICY_FILE_APPLIED",
+		fmt.Sprintf("Rate limit policy reset to config file (%s): %s", describeRateLimitDiffs(diffs), reason))
+
+	return current, nil
+}
+
+// Helper methods
+
+func (uc *rateLimitPolicyUseCase) loadedFileConfig() *domain.RateLimitConfig {
+	uc.mu.RLock()
+	defer uc.mu.RUnlock()
+	return uc.fileConfig
+}
+
+// copyRateLimitPolicy copies the policy values but not the identity or provenance
+func copyRateLimitPolicy(dst, src *domain.RateLimitConfig) {
+	dst.Enabled = src.Enabled
+	dst.MaxAttemptsPerIP = src.MaxAttemptsPerIP
+	dst.MaxAttemptsPerUser = src.MaxAttemptsPerUser
+	dst.WindowSizeMinutes = src.WindowSizeMinutes
+	dst.LockoutDurationMinutes = src.LockoutDurationMinutes
+	dst.BackoffMultiplier = src.BackoffMultiplier
+	dst.MaxLockoutHours = src.MaxLockoutHours
+	dst.WhitelistIPs = append([]string{}, src.WhitelistIPs...)
+	dst.EnableProgressiveLockout = src.EnableProgressiveLockout
+}
+
+func rateLimitPolicyHash(c *domain.RateLimitConfig) string {
+	h := sha256.New()
+	for _, field := range rateLimitPolicyFields {
+		fmt.Fprintf(h, "%s=%s\n", field.key, field.value(c))
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func diffRateLimitPolicy(current, proposed *domain.RateLimitConfig) []RateLimitFieldDiff {
+	var diffs []RateLimitFieldDiff
+	for _, field := range rateLimitPolicyFields {
+		currentValue, proposedValue := field.value(current), field.value(proposed)
+		if currentValue != proposedValue {
+			diffs = append(diffs, RateLimitFieldDiff{
+				Field:    field.key,
+				Label:    field.label,
+				Current:  currentValue,
+				Proposed: proposedValue,
+			})
+		}
+	}
+	return diffs
+}
+
+func describeRateLimitDiffs(diffs []RateLimitFieldDiff) string {
+	if len(diffs) == 0 {
+		return "no changes"
+	}
+	parts := make([]string, len(diffs))
+	for i, diff := range diffs {
+		parts[i] = fmt.Sprintf("%s: %s -> %s", diff.Field, diff.Current, diff.Proposed)
+	}
+	return strings.Join(parts, "; ")
+}
+
+func (uc *rateLimitPolicyUseCase) fetchError(err error) error {
+	return &UseCaseError{
+		Code:    "FETCH_FAILED",
+		Message: "レート制限設定の取得に失敗しました",
+		Cause:   err,
+	}
+}
+
+func (uc *rateLimitPolicyUseCase) updateError(err error) error {
+	return &UseCaseError{
+		Code:    "UPDATE_FAILED",
+		Message: "レート制限設定の更新に失敗しました",
+		Cause:   err,
+	}
+}
+
+// logAction records a policy change as a security event
+func (uc *rateLimitPolicyUseCase) logAction(ctx context.Context, actorID domain.ID, action, details string) {
+	auditLog := &domain.AuditLog{
+		ID:      domain.ID(uuid.New().String()),
+		ActorID: actorID,
+		Action:  action,
+		Target:  securityAuditTarget,
+		At:      time.Now(),
+		IP:      uc.getClientIP(ctx),
+		Details: details,
+	}
+
+	err := uc.auditRepo.Create(ctx, auditLog)
+	if err != nil {
+		// Log audit failure but don't fail the operation
+		uc.log().Warn("failed to write audit log", "action", auditLog.Action, "error", err)
+	}
+}
+
+func (uc *rateLimitPolicyUseCase) getClientIP(ctx context.Context) string {
+	if ip := ctx.Value(ContextKeyClientIP); ip != nil {
+		if clientIP, ok := ip.(string); ok {
+			return clientIP
+		}
+	}
+	return "unknown"
+}
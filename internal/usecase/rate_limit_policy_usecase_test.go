@@ -0,0 +1,145 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"shien-system/internal/domain"
+)
+
+func newTestRateLimitPolicyUseCase() (RateLimitPolicyUseCase, *MockRateLimitConfigRepository, *mockAuditLogRepository) {
+	configRepo := NewMockRateLimitConfigRepository()
+	configRepo.config.Source = domain.RateLimitSourceDefault
+	auditRepo := &mockAuditLogRepository{}
+	staffRepo := &mockStaffRepository{staff: map[domain.ID]*domain.Staff{
+		"admin-001": {ID: "admin-001", Name: "管理者", Role: domain.RoleAdmin},
+		"staff-001": {ID: "staff-001", Name: "職員", Role: domain.RoleStaff},
+	}}
+
+	return NewRateLimitPolicyUseCase(configRepo, staffRepo, auditRepo), configRepo, auditRepo
+}
+
+func fileRateLimitConfig() *domain.RateLimitConfig {
+	return &domain.RateLimitConfig{
+		Enabled:                  true,
+		MaxAttemptsPerIP:         10,
+		MaxAttemptsPerUser:       3,
+		WindowSizeMinutes:        15,
+		LockoutDurationMinutes:   30,
+		BackoffMultiplier:        2.0,
+		MaxLockoutHours:          24,
+		WhitelistIPs:             []string{"127.0.0.1"},
+		EnableProgressiveLockout: true,
+	}
+}
+
+func TestRateLimitPolicyUseCase_SyncFromConfig(t *testing.T) {
+	uc, configRepo, _ := newTestRateLimitPolicyUseCase()
+	ctx := context.Background()
+
+	invalid := fileRateLimitConfig()
+	invalid.BackoffMultiplier = 0
+	if _, err := uc.SyncFromConfig(ctx, invalid); err == nil {
+		t.Fatal("SyncFromConfig() should reject an invalid config file")
+	}
+
+	// The default policy is replaced by the config file
+	report, err := uc.SyncFromConfig(ctx, fileRateLimitConfig())
+	if err != nil {
+		t.Fatalf("SyncFromConfig() error = %v", err)
+	}
+	if !report.Applied || len(report.Changes) != 1 || report.Changes[0].Field != "max_attempts_per_ip" {
+		t.Errorf("unexpected seed report: %+v", report)
+	}
+	if configRepo.config.Source != domain.RateLimitSourceConfig || configRepo.config.MaxAttemptsPerIP != 10 {
+		t.Errorf("stored config = %+v", configRepo.config)
+	}
+
+	// Syncing the same file again changes nothing
+	report, err = uc.SyncFromConfig(ctx, fileRateLimitConfig())
+	if err != nil || report.Applied {
+		t.Errorf("second SyncFromConfig() = %+v, %v", report, err)
+	}
+
+	// Once an administrator owns the policy, file edits are reported instead of applied
+	configRepo.config.Source = domain.RateLimitSourceAdmin
+	configRepo.config.MaxAttemptsPerUser = 5
+
+	edited := fileRateLimitConfig()
+	edited.WindowSizeMinutes = 30
+	report, err = uc.SyncFromConfig(ctx, edited)
+	if err != nil {
+		t.Fatalf("SyncFromConfig() error = %v", err)
+	}
+	if report.Applied || !report.FileChanged || len(report.Conflicts) != 2 {
+		t.Errorf("unexpected conflict report: %+v", report)
+	}
+	if configRepo.config.WindowSizeMinutes != 15 {
+		t.Errorf("admin policy was overwritten: %+v", configRepo.config)
+	}
+}
+
+func TestRateLimitPolicyUseCase_UpdateAndApplyConfigFile(t *testing.T) {
+	uc, configRepo, auditRepo := newTestRateLimitPolicyUseCase()
+	ctx := context.Background()
+
+	if _, err := uc.ApplyConfigFile(ctx, ApplyRateLimitConfigRequest{Reason: "戻す", ActorID: "admin-001"}); err != ErrConfigFileNotLoaded {
+		t.Errorf("ApplyConfigFile() before sync = %v, want ErrConfigFileNotLoaded", err)
+	}
+	if _, err := uc.SyncFromConfig(ctx, fileRateLimitConfig()); err != nil {
+		t.Fatalf("SyncFromConfig() error = %v", err)
+	}
+
+	policy := *fileRateLimitConfig()
+	policy.Enabled = false
+	policy.WhitelistIPs = []string{"127.0.0.1", "192.168.0.0/24"}
+
+	if _, err := uc.UpdatePolicy(ctx, UpdateRateLimitPolicyRequest{Policy: policy, Reason: "検証", ActorID: "staff-001"}); err != ErrUnauthorized {
+		t.Errorf("UpdatePolicy() by staff = %v, want ErrUnauthorized", err)
+	}
+	if _, err := uc.UpdatePolicy(ctx, UpdateRateLimitPolicyRequest{Policy: policy, ActorID: "admin-001"}); err == nil {
+		t.Error("UpdatePolicy() without a reason should fail")
+	}
+
+	updated, err := uc.UpdatePolicy(ctx, UpdateRateLimitPolicyRequest{Policy: policy, Reason: "施設内ネットワークを許可", ActorID: "admin-001"})
+	if err != nil {
+		t.Fatalf("UpdatePolicy() error = %v", err)
+	}
+	if updated.Enabled || updated.Source != domain.RateLimitSourceAdmin || updated.UpdatedBy != "admin-001" {
+		t.Errorf("UpdatePolicy() = %+v", updated)
+	}
+
+	if _, err := uc.UpdatePolicy(ctx, UpdateRateLimitPolicyRequest{Policy: policy, Reason: "再実行", ActorID: "admin-001"}); err == nil {
+		t.Error("UpdatePolicy() without changes should fail")
+	}
+
+	current, err := uc.GetPolicy(ctx, "admin-001")
+	if err != nil {
+		t.Fatalf("GetPolicy() error = %v", err)
+	}
+	if current.File == nil || len(current.Conflicts) != 2 {
+		t.Errorf("GetPolicy() conflicts = %+v", current.Conflicts)
+	}
+
+	applied, err := uc.ApplyConfigFile(ctx, ApplyRateLimitConfigRequest{Reason: "設定ファイルに戻す", ActorID: "admin-001"})
+	if err != nil {
+		t.Fatalf("ApplyConfigFile() error = %v", err)
+	}
+	if !applied.Enabled || applied.Source != domain.RateLimitSourceConfig || len(applied.WhitelistIPs) != 1 {
+		t.Errorf("ApplyConfigFile() = %+v", applied)
+	}
+	if configRepo.config.Source != domain.RateLimitSourceConfig {
+		t.Errorf("stored source = %s", configRepo.config.Source)
+	}
+
+	if len(auditRepo.logs) != 2 {
+		t.Fatalf("expected 2 audit logs, got %d", len(auditRepo.logs))
+	}
+	if auditRepo.logs[0].Action != "RATE_LIMIT_POLICY_UPDATED" || !strings.Contains(auditRepo.logs[0].Details, "enabled: true -> false") {
+		t.Errorf("unexpected update audit log: %+v", auditRepo.logs[0])
+	}
+	if auditRepo.logs[1].Action != "RATE_LIMIT_POLICY_FILE_APPLIED" || auditRepo.logs[1].Target != "SECURITY" {
+		t.Errorf("unexpected apply audit log: %+v", auditRepo.logs[1])
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"sort"
 	"strings"
 	"time"
 
@@ -17,20 +18,39 @@ type RateLimitService struct {
 	attemptRepo domain.LoginAttemptRepository
 	lockoutRepo domain.AccountLockoutRepository
 	configRepo  domain.RateLimitConfigRepository
+	patternRepo domain.AttackPatternRepository
 	auditRepo   domain.AuditLogRepository
+
+	useCaseLogger
 }
 
+// Thresholds of the attack pattern detector
+const (
+	// minDistributedSourceIPs is the number of distinct IPs failing against one username
+	// that is treated as a distributed brute force attack
+	minDistributedSourceIPs = 3
+
+	// minStuffingTargetUsernames is the number of distinct usernames failing from one IP
+	// that is treated as credential stuffing
+	minStuffingTargetUsernames = 5
+
+	// maxAnalyzedAttempts bounds the failed attempts loaded per detection run
+	maxAnalyzedAttempts = 10000
+)
+
 // NewRateLimitService creates a new RateLimitService instance
 func NewRateLimitService(
 	attemptRepo domain.LoginAttemptRepository,
 	lockoutRepo domain.AccountLockoutRepository,
 	configRepo domain.RateLimitConfigRepository,
+	patternRepo domain.AttackPatternRepository,
 	auditRepo domain.AuditLogRepository,
 ) *RateLimitService {
 	return &RateLimitService{
 		attemptRepo: attemptRepo,
 		lockoutRepo: lockoutRepo,
 		configRepo:  configRepo,
+		patternRepo: patternRepo,
 		auditRepo:   auditRepo,
 	}
 }
@@ -91,6 +111,15 @@ func (s *RateLimitService) CheckLoginAttempt(ctx context.Context, ipAddress, use
 		}, nil
 	}
 
+	// Existing lockouts stay in force, but no new ones are created while
+	// rate limiting is switched off
+	if !config.Enabled {
+		return &LoginAttemptResult{
+			Allowed: true,
+			Reason:  "rate_limit_disabled",
+		}, nil
+	}
+
 	// Check recent failure rates
 	windowStart := time.Now().Add(-time.Duration(config.WindowSizeMinutes) * time.Minute)
 
@@ -218,13 +247,34 @@ func (s *RateLimitService) DetectAttackPatterns(ctx context.Context) error {
 	// Analyze recent login attempts for patterns
 	windowStart := time.Now().Add(-time.Duration(config.WindowSizeMinutes*2) * time.Minute)
 
+	failures, err := s.attemptRepo.GetRecentFailures(ctx, windowStart, maxAnalyzedAttempts)
+	if err != nil {
+		return fmt.Errorf("failed to get recent failures: %w", err)
+	}
+
+	// Whitelisted addresses never count towards a pattern
+	var candidates []*domain.LoginAttempt
+	for _, attempt := range failures {
+		if !s.isWhitelistedIP(attempt.IPAddress, config.WhitelistIPs) {
+			candidates = append(candidates, attempt)
+		}
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	unresolved, err := s.patternRepo.GetUnresolved(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get unresolved attack patterns: %w", err)
+	}
+
 	// Detect distributed brute force attacks (multiple IPs, same username)
-	if err := s.detectDistributedAttacks(ctx, windowStart); err != nil {
+	if err := s.detectDistributedAttacks(ctx, candidates, unresolved, config); err != nil {
 		return fmt.Errorf("failed to detect distributed attacks: %w", err)
 	}
 
 	// Detect credential stuffing (same IP, multiple usernames)
-	if err := s.detectCredentialStuffing(ctx, windowStart); err != nil {
+	if err := s.detectCredentialStuffing(ctx, candidates, unresolved); err != nil {
 		return fmt.Errorf("failed to detect credential stuffing: %w", err)
 	}
 
@@ -339,20 +389,183 @@ func (s *RateLimitService) calculateProgressiveLockoutDuration(ctx context.Conte
 	return progressiveDuration
 }
 
-func (s *RateLimitService) detectDistributedAttacks(ctx context.Context, since time.Time) error {
-	// This is a placeholder for distributed attack detection logic
-	// In a real implementation, you would analyze login attempts to find patterns
-	// like multiple IPs targeting the same username
+func (s *RateLimitService) detectDistributedAttacks(ctx context.Context, failures []*domain.LoginAttempt, unresolved []*domain.AttackPattern, config *domain.RateLimitConfig) error {
+	// Group the failures by target username
+	sourcesByUsername := make(map[string]map[string]bool)
+	countByUsername := make(map[string]int)
+	for _, attempt := range failures {
+		if attempt.Username == "" {
+			continue
+		}
+		if sourcesByUsername[attempt.Username] == nil {
+			sourcesByUsername[attempt.Username] = make(map[string]bool)
+		}
+		sourcesByUsername[attempt.Username][attempt.IPAddress] = true
+		countByUsername[attempt.Username]++
+	}
+
+	for username, sources := range sourcesByUsername {
+		count := countByUsername[username]
+		if len(sources) < minDistributedSourceIPs || count < config.MaxAttemptsPerUser {
+			continue
+		}
+
+		ips := sortedKeys(sources)
+		existing := findAttackPattern(unresolved, func(p *domain.AttackPattern) bool {
+			return p.PatternType == domain.AttackPatternTypeDistributed &&
+				len(p.TargetUsernames) == 1 && p.TargetUsernames[0] == username
+		})
+
+		pattern := &domain.AttackPattern{
+			PatternType:     domain.AttackPatternTypeDistributed,
+			SourceIP:        strings.Join(ips, ","),
+			TargetUsernames: []string{username},
+			AttemptsCount:   count,
+			Severity:        attackSeverity(len(ips), minDistributedSourceIPs),
+			Details:         fmt.Sprintf("%d failed attempts against %s from %d IP addresses", count, username, len(ips)),
+		}
+		if err := s.recordAttackPattern(ctx, existing, pattern); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *RateLimitService) detectCredentialStuffing(ctx context.Context, failures []*domain.LoginAttempt, unresolved []*domain.AttackPattern) error {
+	// Group the failures by source IP
+	usernamesByIP := make(map[string]map[string]bool)
+	countByIP := make(map[string]int)
+	for _, attempt := range failures {
+		if attempt.IPAddress == "" || attempt.Username == "" {
+			continue
+		}
+		if usernamesByIP[attempt.IPAddress] == nil {
+			usernamesByIP[attempt.IPAddress] = make(map[string]bool)
+		}
+		usernamesByIP[attempt.IPAddress][attempt.Username] = true
+		countByIP[attempt.IPAddress]++
+	}
+
+	for ip, usernames := range usernamesByIP {
+		if len(usernames) < minStuffingTargetUsernames {
+			continue
+		}
+
+		targets := sortedKeys(usernames)
+		existing := findAttackPattern(unresolved, func(p *domain.AttackPattern) bool {
+			return p.PatternType == domain.AttackPatternTypeCredentialStuffing && p.SourceIP == ip
+		})
+
+		pattern := &domain.AttackPattern{
+			PatternType:     domain.AttackPatternTypeCredentialStuffing,
+			SourceIP:        ip,
+			TargetUsernames: targets,
+			AttemptsCount:   countByIP[ip],
+			Severity:        attackSeverity(len(targets), minStuffingTargetUsernames),
+			Details:         fmt.Sprintf("%d failed attempts against %d usernames", countByIP[ip], len(targets)),
+		}
+		if err := s.recordAttackPattern(ctx, existing, pattern); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// recordAttackPattern persists a detection, refreshing the unresolved pattern it continues if any
+func (s *RateLimitService) recordAttackPattern(ctx context.Context, existing, detected *domain.AttackPattern) error {
+	now := time.Now()
+
+	if existing != nil {
+		existing.SourceIP = mergeCSV(existing.SourceIP, detected.SourceIP)
+		existing.TargetUsernames = mergeSortedUnique(existing.TargetUsernames, detected.TargetUsernames)
+		if detected.AttemptsCount > existing.AttemptsCount {
+			existing.AttemptsCount = detected.AttemptsCount
+		}
+		if severityRank(detected.Severity) > severityRank(existing.Severity) {
+			existing.Severity = detected.Severity
+		}
+		existing.LastDetectedAt = now
+		existing.Details = detected.Details
+
+		if err := s.patternRepo.Update(ctx, existing); err != nil {
+			return fmt.Errorf("failed to update attack pattern: %w", err)
+		}
+		return nil
+	}
+
+	detected.ID = uuid.New().String()
+	detected.FirstDetectedAt = now
+	detected.LastDetectedAt = now
+	detected.Status = domain.AttackPatternStatusActive
+
+	if err := s.patternRepo.Create(ctx, detected); err != nil {
+		return fmt.Errorf("failed to create attack pattern: %w", err)
+	}
+
+	s.logSecurityEvent(ctx, "", "ATTACK_PATTERN_DETECTED", detected.SourceIP,
+		fmt.Sprintf("%s (%s): %s", detected.PatternType, detected.Severity, detected.Details))
+
 	return nil
 }
 
-func (s *RateLimitService) detectCredentialStuffing(ctx context.Context, since time.Time) error {
-	// This is a placeholder for credential stuffing detection logic
-	// In a real implementation, you would analyze login attempts to find patterns
-	// like the same IP trying multiple usernames
+// attackSeverity grades a detection by how far its spread exceeds the detection threshold
+func attackSeverity(spread, threshold int) string {
+	switch {
+	case spread >= threshold*4:
+		return domain.AttackSeverityCritical
+	case spread >= threshold*2:
+		return domain.AttackSeverityHigh
+	default:
+		return domain.AttackSeverityMedium
+	}
+}
+
+// severityRank orders attack severities for comparison
+func severityRank(severity string) int {
+	switch severity {
+	case domain.AttackSeverityCritical:
+		return 3
+	case domain.AttackSeverityHigh:
+		return 2
+	case domain.AttackSeverityMedium:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func findAttackPattern(patterns []*domain.AttackPattern, match func(*domain.AttackPattern) bool) *domain.AttackPattern {
+	for _, pattern := range patterns {
+		if match(pattern) {
+			return pattern
+		}
+	}
 	return nil
 }
 
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func mergeSortedUnique(current, detected []string) []string {
+	set := make(map[string]bool, len(current)+len(detected))
+	for _, username := range append(append([]string{}, current...), detected...) {
+		set[username] = true
+	}
+	return sortedKeys(set)
+}
+
+func mergeCSV(current, detected string) string {
+	return strings.Join(mergeSortedUnique(strings.Split(current, ","), strings.Split(detected, ",")), ",")
+}
+
 func (s *RateLimitService) logSecurityEvent(ctx context.Context, actorID, action, ipAddress, details string) {
 	auditLog := &domain.AuditLog{
 		ID:      uuid.New().String(),
@@ -367,7 +580,7 @@ func (s *RateLimitService) logSecurityEvent(ctx context.Context, actorID, action
 	// Asynchronously log the security event
 	go func() {
 		if err := s.auditRepo.Create(context.Background(), auditLog); err != nil {
-			// In production, you might want to log this error to a different system
+			s.log().Warn("failed to write audit log", "action", auditLog.Action, "error", err)
 		}
 	}()
 }
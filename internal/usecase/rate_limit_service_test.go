@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"context"
+	"strings"
 	"testing"
 	"time"
 
@@ -74,6 +75,18 @@ func (m *MockLoginAttemptRepository) GetFailedAttempts(ctx context.Context, ipAd
 	return result, nil
 }
 
+func (m *MockLoginAttemptRepository) GetRecentFailures(ctx context.Context, since time.Time, limit int) ([]*domain.LoginAttempt, error) {
+	var result []*domain.LoginAttempt
+	for _, attempts := range m.attempts {
+		for _, attempt := range attempts {
+			if !attempt.Success && attempt.AttemptedAt.After(since) && len(result) < limit {
+				result = append(result, attempt)
+			}
+		}
+	}
+	return result, nil
+}
+
 func (m *MockLoginAttemptRepository) CountRecentFailures(ctx context.Context, ipAddress, username string, since time.Time) (int, error) {
 	count := 0
 	for _, attempts := range m.attempts {
@@ -203,6 +216,90 @@ func (m *MockAccountLockoutRepository) Count(ctx context.Context) (int, error) {
 	return len(m.lockouts), nil
 }
 
+// MockAttackPatternRepository for testing
+type MockAttackPatternRepository struct {
+	patterns map[string]*domain.AttackPattern
+}
+
+func NewMockAttackPatternRepository() *MockAttackPatternRepository {
+	return &MockAttackPatternRepository{
+		patterns: make(map[string]*domain.AttackPattern),
+	}
+}
+
+func (m *MockAttackPatternRepository) Create(ctx context.Context, pattern *domain.AttackPattern) error {
+	if pattern.ID == "" {
+		pattern.ID = uuid.New().String()
+	}
+	m.patterns[pattern.ID] = pattern
+	return nil
+}
+
+func (m *MockAttackPatternRepository) GetByID(ctx context.Context, id domain.ID) (*domain.AttackPattern, error) {
+	if pattern, exists := m.patterns[id]; exists {
+		return pattern, nil
+	}
+	return nil, domain.ErrNotFound
+}
+
+func (m *MockAttackPatternRepository) GetActiveByIP(ctx context.Context, sourceIP string) ([]*domain.AttackPattern, error) {
+	var result []*domain.AttackPattern
+	for _, pattern := range m.patterns {
+		if pattern.SourceIP == sourceIP && pattern.Status == domain.AttackPatternStatusActive {
+			result = append(result, pattern)
+		}
+	}
+	return result, nil
+}
+
+func (m *MockAttackPatternRepository) GetByTimeRange(ctx context.Context, start, end time.Time) ([]*domain.AttackPattern, error) {
+	var result []*domain.AttackPattern
+	for _, pattern := range m.patterns {
+		if !pattern.FirstDetectedAt.Before(start) && !pattern.FirstDetectedAt.After(end) {
+			result = append(result, pattern)
+		}
+	}
+	return result, nil
+}
+
+func (m *MockAttackPatternRepository) GetUnresolved(ctx context.Context) ([]*domain.AttackPattern, error) {
+	var result []*domain.AttackPattern
+	for _, pattern := range m.patterns {
+		if pattern.Status != domain.AttackPatternStatusResolved {
+			result = append(result, pattern)
+		}
+	}
+	return result, nil
+}
+
+func (m *MockAttackPatternRepository) Update(ctx context.Context, pattern *domain.AttackPattern) error {
+	if _, exists := m.patterns[pattern.ID]; !exists {
+		return domain.ErrNotFound
+	}
+	m.patterns[pattern.ID] = pattern
+	return nil
+}
+
+func (m *MockAttackPatternRepository) UpdateStatus(ctx context.Context, id domain.ID, status string) error {
+	if pattern, exists := m.patterns[id]; exists {
+		pattern.Status = status
+		return nil
+	}
+	return domain.ErrNotFound
+}
+
+func (m *MockAttackPatternRepository) List(ctx context.Context, limit, offset int) ([]*domain.AttackPattern, error) {
+	var result []*domain.AttackPattern
+	for _, pattern := range m.patterns {
+		result = append(result, pattern)
+	}
+	return result, nil
+}
+
+func (m *MockAttackPatternRepository) Count(ctx context.Context) (int, error) {
+	return len(m.patterns), nil
+}
+
 // MockRateLimitConfigRepository for testing
 type MockRateLimitConfigRepository struct {
 	config *domain.RateLimitConfig
@@ -212,6 +309,7 @@ func NewMockRateLimitConfigRepository() *MockRateLimitConfigRepository {
 	return &MockRateLimitConfigRepository{
 		config: &domain.RateLimitConfig{
 			ID:                       "test-config",
+			Enabled:                  true,
 			MaxAttemptsPerIP:         5,
 			MaxAttemptsPerUser:       3,
 			WindowSizeMinutes:        15,
@@ -247,7 +345,7 @@ func TestRateLimitService_CheckLoginAttempt_Allowed(t *testing.T) {
 	configRepo := NewMockRateLimitConfigRepository()
 	auditRepo := &MockAuditLogRepository{}
 
-	service := NewRateLimitService(attemptRepo, lockoutRepo, configRepo, auditRepo)
+	service := NewRateLimitService(attemptRepo, lockoutRepo, configRepo, NewMockAttackPatternRepository(), auditRepo)
 
 	ctx := context.Background()
 	result, err := service.CheckLoginAttempt(ctx, "192.168.1.1", "testuser", "TestAgent")
@@ -271,7 +369,7 @@ func TestRateLimitService_CheckLoginAttempt_WhitelistedIP(t *testing.T) {
 	configRepo := NewMockRateLimitConfigRepository()
 	auditRepo := &MockAuditLogRepository{}
 
-	service := NewRateLimitService(attemptRepo, lockoutRepo, configRepo, auditRepo)
+	service := NewRateLimitService(attemptRepo, lockoutRepo, configRepo, NewMockAttackPatternRepository(), auditRepo)
 
 	ctx := context.Background()
 	result, err := service.CheckLoginAttempt(ctx, "127.0.0.1", "testuser", "TestAgent")
@@ -295,7 +393,7 @@ func TestRateLimitService_CheckLoginAttempt_IPLockout(t *testing.T) {
 	configRepo := NewMockRateLimitConfigRepository()
 	auditRepo := &MockAuditLogRepository{}
 
-	service := NewRateLimitService(attemptRepo, lockoutRepo, configRepo, auditRepo)
+	service := NewRateLimitService(attemptRepo, lockoutRepo, configRepo, NewMockAttackPatternRepository(), auditRepo)
 
 	ctx := context.Background()
 
@@ -329,13 +427,62 @@ func TestRateLimitService_CheckLoginAttempt_IPLockout(t *testing.T) {
 	}
 }
 
+func TestRateLimitService_CheckLoginAttempt_Disabled(t *testing.T) {
+	attemptRepo := NewMockLoginAttemptRepository()
+	lockoutRepo := NewMockAccountLockoutRepository()
+	configRepo := NewMockRateLimitConfigRepository()
+	configRepo.config.Enabled = false
+	auditRepo := &MockAuditLogRepository{}
+
+	service := NewRateLimitService(attemptRepo, lockoutRepo, configRepo, NewMockAttackPatternRepository(), auditRepo)
+
+	ctx := context.Background()
+	ipAddress := "192.168.1.100"
+
+	for i := 0; i < 6; i++ {
+		attemptRepo.Create(ctx, &domain.LoginAttempt{
+			IPAddress:   ipAddress,
+			Username:    "testuser",
+			Success:     false,
+			AttemptedAt: time.Now(),
+		})
+	}
+
+	result, err := service.CheckLoginAttempt(ctx, ipAddress, "testuser", "TestAgent")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !result.Allowed || result.Reason != "rate_limit_disabled" {
+		t.Errorf("Expected disabled rate limit to allow login, got %+v", result)
+	}
+	if len(lockoutRepo.lockouts) != 0 {
+		t.Errorf("Expected no lockouts while disabled, got %d", len(lockoutRepo.lockouts))
+	}
+
+	// Lockouts created by an administrator are still enforced
+	lockoutRepo.Create(ctx, &domain.AccountLockout{
+		ID:          "manual-1",
+		Username:    "testuser",
+		LockoutType: domain.LockoutTypeManual,
+		LockedAt:    time.Now(),
+		Duration:    3600,
+	})
+	result, err = service.CheckLoginAttempt(ctx, ipAddress, "testuser", "TestAgent")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if result.Allowed || result.Reason != "account_locked" {
+		t.Errorf("Expected manual lockout to be enforced, got %+v", result)
+	}
+}
+
 func TestRateLimitService_RecordLoginAttempt(t *testing.T) {
 	attemptRepo := NewMockLoginAttemptRepository()
 	lockoutRepo := NewMockAccountLockoutRepository()
 	configRepo := NewMockRateLimitConfigRepository()
 	auditRepo := &MockAuditLogRepository{}
 
-	service := NewRateLimitService(attemptRepo, lockoutRepo, configRepo, auditRepo)
+	service := NewRateLimitService(attemptRepo, lockoutRepo, configRepo, NewMockAttackPatternRepository(), auditRepo)
 
 	ctx := context.Background()
 
@@ -365,7 +512,7 @@ func TestRateLimitService_ManualLockout(t *testing.T) {
 	configRepo := NewMockRateLimitConfigRepository()
 	auditRepo := &MockAuditLogRepository{}
 
-	service := NewRateLimitService(attemptRepo, lockoutRepo, configRepo, auditRepo)
+	service := NewRateLimitService(attemptRepo, lockoutRepo, configRepo, NewMockAttackPatternRepository(), auditRepo)
 
 	ctx := context.Background()
 
@@ -399,7 +546,7 @@ func TestRateLimitService_UnlockAccount(t *testing.T) {
 	configRepo := NewMockRateLimitConfigRepository()
 	auditRepo := &MockAuditLogRepository{}
 
-	service := NewRateLimitService(attemptRepo, lockoutRepo, configRepo, auditRepo)
+	service := NewRateLimitService(attemptRepo, lockoutRepo, configRepo, NewMockAttackPatternRepository(), auditRepo)
 
 	ctx := context.Background()
 
@@ -431,3 +578,68 @@ func TestRateLimitService_UnlockAccount(t *testing.T) {
 		t.Errorf("Expected no active lockout after unlock")
 	}
 }
+
+func TestRateLimitService_DetectAttackPatterns(t *testing.T) {
+	attemptRepo := NewMockLoginAttemptRepository()
+	lockoutRepo := NewMockAccountLockoutRepository()
+	configRepo := NewMockRateLimitConfigRepository()
+	patternRepo := NewMockAttackPatternRepository()
+	auditRepo := &mockAuditLogRepository{}
+
+	service := NewRateLimitService(attemptRepo, lockoutRepo, configRepo, patternRepo, auditRepo)
+	ctx := context.Background()
+	now := time.Now()
+
+	// One IP trying many usernames
+	for _, username := range []string{"u1", "u2", "u3", "u4", "u5", "u6"} {
+		attemptRepo.Create(ctx, &domain.LoginAttempt{IPAddress: "198.51.100.7", Username: username, AttemptedAt: now})
+	}
+	// Many IPs trying one username
+	for _, ip := range []string{"192.0.2.1", "192.0.2.2", "192.0.2.3"} {
+		attemptRepo.Create(ctx, &domain.LoginAttempt{IPAddress: ip, Username: "admin", AttemptedAt: now})
+	}
+	// Whitelisted IPs are ignored
+	for _, username := range []string{"w1", "w2", "w3", "w4", "w5"} {
+		attemptRepo.Create(ctx, &domain.LoginAttempt{IPAddress: "127.0.0.1", Username: username, AttemptedAt: now})
+	}
+
+	if err := service.DetectAttackPatterns(ctx); err != nil {
+		t.Fatalf("DetectAttackPatterns() error = %v", err)
+	}
+
+	if len(patternRepo.patterns) != 2 {
+		t.Fatalf("expected 2 patterns, got %d", len(patternRepo.patterns))
+	}
+
+	var stuffing, distributed *domain.AttackPattern
+	for _, pattern := range patternRepo.patterns {
+		switch pattern.PatternType {
+		case domain.AttackPatternTypeCredentialStuffing:
+			stuffing = pattern
+		case domain.AttackPatternTypeDistributed:
+			distributed = pattern
+		}
+	}
+
+	if stuffing == nil || stuffing.SourceIP != "198.51.100.7" || len(stuffing.TargetUsernames) != 6 {
+		t.Errorf("unexpected credential stuffing pattern: %+v", stuffing)
+	}
+	if stuffing != nil && (stuffing.Status != domain.AttackPatternStatusActive || stuffing.Severity != domain.AttackSeverityMedium) {
+		t.Errorf("unexpected status or severity: %s, %s", stuffing.Status, stuffing.Severity)
+	}
+	if distributed == nil || distributed.SourceIP != "192.0.2.1,192.0.2.2,192.0.2.3" || distributed.AttemptsCount != 3 {
+		t.Errorf("unexpected distributed pattern: %+v", distributed)
+	}
+
+	// A repeated run refreshes the open patterns instead of creating new ones
+	attemptRepo.Create(ctx, &domain.LoginAttempt{IPAddress: "192.0.2.4", Username: "admin", AttemptedAt: now})
+	if err := service.DetectAttackPatterns(ctx); err != nil {
+		t.Fatalf("DetectAttackPatterns() error = %v", err)
+	}
+	if len(patternRepo.patterns) != 2 {
+		t.Fatalf("expected patterns to be updated in place, got %d", len(patternRepo.patterns))
+	}
+	if distributed != nil && (distributed.AttemptsCount != 4 || !strings.HasSuffix(distributed.SourceIP, "192.0.2.4")) {
+		t.Errorf("distributed pattern not refreshed: %+v", distributed)
+	}
+}
@@ -0,0 +1,400 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+	"shien-system/internal/domain"
+	"shien-system/internal/validation"
+)
+
+// mergeRecipientAction is the audit action of a recipient merge
+const mergeRecipientAction = "MERGE_RECIPIENT"
+
+// Scores of the duplicate finder. The reading and the birth date weigh most;
+// a matching name only counts when the kana differs or is missing.
+const (
+	duplicateScoreKana     = 40
+	duplicateScoreName     = 30
+	duplicateScoreBirth    = 40
+	duplicateScorePhone    = 20
+	defaultDuplicateScore  = 60
+	maxDuplicateCandidates = 500 // Keeps the review list usable when the phone is shared, e.g. a group home
+)
+
+// kanjiVariants folds the itaiji that registration forms mix up most, so
+// 髙橋 and 高橋 or 渡邊 and 渡辺 compare equal
+var kanjiVariants = strings.NewReplacer(
+	"髙", "高", "﨑", "崎", "嵜", "崎", "濵", "浜", "濱", "浜",
+	"邊", "辺", "邉", "辺", "澤", "沢", "齋", "斎", "齊", "斉",
+	"廣", "広", "國", "国", "櫻", "桜", "淺", "浅", "關", "関", "德", "徳",
+	"藪", "薮", "冨", "富", "眞", "真", "惠", "恵", "榮", "栄", "龍", "竜",
+	"實", "実", "壽", "寿", "穗", "穂", "峯", "峰", "嶋", "島", "嶌", "島",
+	"槇", "槙", "𠮷", "吉", "舘", "館",
+)
+
+// recipientMergeUseCase implements RecipientMergeUseCase interface
+type recipientMergeUseCase struct {
+	tx            domain.Transactional
+	recipientRepo domain.RecipientRepository
+	mergeRepo     domain.RecipientMergeRepository
+	periodRepo    domain.EnrollmentPeriodRepository
+	medicalRepo   domain.MedicalRecordRepository
+	staffRepo     domain.StaffRepository
+	auditRepo     domain.AuditLogRepository
+
+	useCaseLogger
+}
+
+// NewRecipientMergeUseCase creates a new duplicate finder and merge usecase
+func NewRecipientMergeUseCase(
+	tx domain.Transactional,
+	recipientRepo domain.RecipientRepository,
+	mergeRepo domain.RecipientMergeRepository,
+	periodRepo domain.EnrollmentPeriodRepository,
+	medicalRepo domain.MedicalRecordRepository,
+	staffRepo domain.StaffRepository,
+	auditRepo domain.AuditLogRepository,
+) RecipientMergeUseCase {
+	return &recipientMergeUseCase{
+		tx:            tx,
+		recipientRepo: recipientRepo,
+		mergeRepo:     mergeRepo,
+		periodRepo:    periodRepo,
+		medicalRepo:   medicalRepo,
+		staffRepo:     staffRepo,
+		auditRepo:     auditRepo,
+	}
+}
+
+// duplicateKeys are the normalized values compared by the finder; empty
+// when the recipient has no such value
+type duplicateKeys struct {
+	kana  string
+	name  string
+	birth string
+	phone string
+}
+
+func newDuplicateKeys(recipient *domain.Recipient) duplicateKeys {
+	keys := duplicateKeys{
+		kana:  strings.ReplaceAll(validation.NormalizeKana(recipient.Kana), " ", ""),
+		name:  kanjiVariants.Replace(strings.ReplaceAll(validation.NormalizeText(recipient.Name), " ", "")),
+		phone: strings.Map(keepDigit, validation.NormalizePhone(recipient.Phone)),
+	}
+	if !recipient.BirthDate.IsZero() {
+		keys.birth = recipient.BirthDate.Format("2006-01-02")
+	}
+	return keys
+}
+
+// buckets are the values a recipient is grouped by, so that only recipients
+// sharing at least one of them need to be scored
+func (k duplicateKeys) buckets() []string {
+	var buckets []string
+	for _, key := range []struct{ prefix, value string }{
+		{"kana:", k.kana}, {"name:", k.name}, {"birth:", k.birth}, {"phone:", k.phone},
+	} {
+		if key.value != "" {
+			buckets = append(buckets, key.prefix+key.value)
+		}
+	}
+	return buckets
+}
+
+func keepDigit(r rune) rune {
+	if unicode.IsDigit(r) {
+		return r
+	}
+	return -1
+}
+
+// FindDuplicates compares every recipient and returns the likely duplicates
+func (uc *recipientMergeUseCase) FindDuplicates(ctx context.Context, req FindDuplicatesRequest) ([]*DuplicateCandidate, error) {
+	if _, err := verifyAdmin(ctx, uc.staffRepo, req.ActorID); err != nil {
+		return nil, err
+	}
+	minScore := req.MinScore
+	if minScore <= 0 {
+		minScore = defaultDuplicateScore
+	}
+
+	recipients, err := uc.listRecipients(ctx)
+	if err != nil {
+		return nil, &UseCaseError{
+			Code:    "RETRIEVAL_FAILED",
+			Message: "利用者の取得に失敗しました",
+			Cause:   err,
+		}
+	}
+
+	// Only recipients sharing at least one value are compared, so the
+	// finder does not need to score every pair
+	keys := make([]duplicateKeys, len(recipients))
+	buckets := make(map[string][]int)
+	for i, recipient := range recipients {
+		keys[i] = newDuplicateKeys(recipient)
+		for _, bucket := range keys[i].buckets() {
+			buckets[bucket] = append(buckets[bucket], i)
+		}
+	}
+
+	compared := make(map[[2]int]bool)
+	var candidates []*DuplicateCandidate
+	for _, members := range buckets {
+		for a := 0; a < len(members); a++ {
+			for b := a + 1; b < len(members); b++ {
+				pair := [2]int{members[a], members[b]}
+				if pair[0] > pair[1] {
+					pair[0], pair[1] = pair[1], pair[0]
+				}
+				if compared[pair] {
+					continue
+				}
+				compared[pair] = true
+
+				score, reasons := scoreDuplicate(keys[pair[0]], keys[pair[1]])
+				if score < minScore {
+					continue
+				}
+				first, second := recipients[pair[0]], recipients[pair[1]]
+				if second.CreatedAt.Before(first.CreatedAt) {
+					first, second = second, first
+				}
+				candidates = append(candidates, &DuplicateCandidate{First: first, Second: second, Score: score, Reasons: reasons})
+			}
+		}
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].Score != candidates[j].Score {
+			return candidates[i].Score > candidates[j].Score
+		}
+		if candidates[i].First.Kana != candidates[j].First.Kana {
+			return candidates[i].First.Kana < candidates[j].First.Kana
+		}
+		return candidates[i].Second.ID < candidates[j].Second.ID
+	})
+	if len(candidates) > maxDuplicateCandidates {
+		candidates = candidates[:maxDuplicateCandidates]
+	}
+
+	return candidates, nil
+}
+
+// scoreDuplicate scores how likely two recipients are the same person
+func scoreDuplicate(a, b duplicateKeys) (int, []string) {
+	score := 0
+	var reasons []string
+	switch {
+	case a.kana != "" && a.kana == b.kana:
+		score += duplicateScoreKana
+		reasons = append(reasons, "フリガナが一致")
+	case a.name != "" && a.name == b.name:
+		score += duplicateScoreName
+		reasons = append(reasons, "氏名が一致")
+	}
+	if a.birth != "" && a.birth == b.birth {
+		score += duplicateScoreBirth
+		reasons = append(reasons, "生年月日が一致")
+	}
+	if a.phone != "" && a.phone == b.phone {
+		score += duplicateScorePhone
+		reasons = append(reasons, "電話番号が一致")
+	}
+	if score > 100 {
+		score = 100
+	}
+	return score, reasons
+}
+
+// MergeRecipients merges the duplicate into the surviving recipient
+func (uc *recipientMergeUseCase) MergeRecipients(ctx context.Context, req MergeRecipientsRequest) (*domain.RecipientMerge, error) {
+	if err := uc.validateMergeRequest(req); err != nil {
+		return nil, err
+	}
+	if _, err := verifyAdmin(ctx, uc.staffRepo, req.ActorID); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	var merge *domain.RecipientMerge
+	var survivor *domain.Recipient
+	err := uc.tx.WithTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		if survivor, err = uc.recipientRepo.GetByID(ctx, req.SurvivorID); err != nil {
+			return err
+		}
+		merged, err := uc.recipientRepo.GetByID(ctx, req.MergedID)
+		if err != nil {
+			return err
+		}
+		medical, err := uc.medicalRepo.GetByRecipientID(ctx, req.MergedID)
+		if err != nil && err != domain.ErrNotFound {
+			return fmt.Errorf("failed to get medical record: %w", err)
+		}
+
+		merge = &domain.RecipientMerge{
+			ID:            domain.ID(uuid.New().String()),
+			SurvivorID:    survivor.ID,
+			Merged:        *merged,
+			MergedMedical: medical,
+			Reason:        validation.NormalizeText(req.Reason),
+			MergedBy:      req.ActorID,
+			MergedAt:      now,
+		}
+		if err := uc.mergeRepo.Merge(ctx, merge); err != nil {
+			return err
+		}
+
+		fillBlankFields(survivor, merged)
+
+		// The enrollment periods of both records now belong to the survivor
+		latest, err := uc.periodRepo.GetLatestByRecipientID(ctx, survivor.ID)
+		if err != nil && err != domain.ErrNotFound {
+			return fmt.Errorf("failed to get enrollment period: %w", err)
+		}
+		if latest != nil {
+			admissionDate := latest.AdmissionDate
+			survivor.AdmissionDate = &admissionDate
+			survivor.DischargeDate = latest.DischargeDate
+		}
+
+		survivor.UpdatedAt = now
+		return uc.recipientRepo.Update(ctx, survivor)
+	})
+	if err != nil {
+		if err == domain.ErrNotFound {
+			return nil, ErrRecipientNotFound
+		}
+		return nil, &UseCaseError{
+			Code:    "MERGE_FAILED",
+			Message: "利用者の統合に失敗しました。データは変更されていません",
+			Cause:   err,
+		}
+	}
+
+	moved := merge.Moved
+	auditLog := &domain.AuditLog{
+		ID:      domain.ID(uuid.New().String()),
+		ActorID: req.ActorID,
+		Action:  mergeRecipientAction,
+		Target:  fmt.Sprintf("recipient:%s", survivor.ID),
+		At:      now,
+		IP:      uc.getClientIP(ctx),
+		Details: fmt.Sprintf("利用者「%s」に重複登録の利用者「%s」(ID: %s) を統合しました (受給者証 %d件、担当 %d件、同意 %d件、在籍期間 %d件、緊急連絡先 %d件、事故報告 %d件、理由: %s)",
+			survivor.Name, merge.Merged.Name, merge.Merged.ID, moved.Certificates, moved.Assignments, moved.Consents,
+			moved.EnrollmentPeriods, moved.EmergencyContacts, moved.Incidents, merge.Reason),
+	}
+	if err := uc.auditRepo.Create(ctx, auditLog); err != nil {
+		// Log audit failure but don't fail the operation
+		uc.log().Warn("failed to write audit log", "action", auditLog.Action, "error", err)
+	}
+
+	return merge, nil
+}
+
+// fillBlankFields copies the duplicate's values into fields the survivor
+// left empty. Values the survivor already has are never overwritten.
+func fillBlankFields(survivor, merged *domain.Recipient) {
+	fill := func(dst *string, src string) {
+		if *dst == "" {
+			*dst = src
+		}
+	}
+	fill(&survivor.Kana, merged.Kana)
+	fill(&survivor.DisabilityName, merged.DisabilityName)
+	fill(&survivor.Grade, merged.Grade)
+	fill(&survivor.Phone, merged.Phone)
+	fill(&survivor.Email, merged.Email)
+	if survivor.ComposeAddress() == "" {
+		survivor.Address = merged.Address
+		survivor.Prefecture = merged.Prefecture
+		survivor.City = merged.City
+		survivor.Street = merged.Street
+		fill(&survivor.PostalCode, merged.PostalCode)
+	}
+	for _, handbook := range merged.Handbooks {
+		if survivor.Handbook(handbook.Type) == nil {
+			survivor.Handbooks = append(survivor.Handbooks, handbook)
+			survivor.HasDisabilityID = true
+		}
+	}
+	if survivor.SupportCategory == nil {
+		survivor.SupportCategory = merged.SupportCategory
+	}
+	if survivor.IntractableDisease == nil {
+		survivor.IntractableDisease = merged.IntractableDisease
+	}
+}
+
+// GetMergeHistory returns the recipients merged into a recipient
+func (uc *recipientMergeUseCase) GetMergeHistory(ctx context.Context, recipientID domain.ID, actorID domain.ID) ([]*domain.RecipientMerge, error) {
+	if _, err := verifyAdmin(ctx, uc.staffRepo, actorID); err != nil {
+		return nil, err
+	}
+
+	merges, err := uc.mergeRepo.GetBySurvivorID(ctx, recipientID)
+	if err != nil {
+		return nil, &UseCaseError{
+			Code:    "RETRIEVAL_FAILED",
+			Message: "統合履歴の取得に失敗しました",
+			Cause:   err,
+		}
+	}
+	return merges, nil
+}
+
+// listRecipients loads every recipient, decrypted
+func (uc *recipientMergeUseCase) listRecipients(ctx context.Context) ([]*domain.Recipient, error) {
+	const pageSize = 100
+
+	var all []*domain.Recipient
+	for offset := 0; ; offset += pageSize {
+		recipients, err := uc.recipientRepo.List(ctx, pageSize, offset)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, recipients...)
+		if len(recipients) < pageSize {
+			return all, nil
+		}
+	}
+}
+
+// validateMergeRequest requires two different recipients and a reason
+func (uc *recipientMergeUseCase) validateMergeRequest(req MergeRecipientsRequest) error {
+	if req.SurvivorID == "" || req.MergedID == "" {
+		return &UseCaseError{
+			Code:    "VALIDATION_FAILED",
+			Message: "統合する利用者を選択してください",
+		}
+	}
+	if req.SurvivorID == req.MergedID {
+		return &UseCaseError{
+			Code:    "VALIDATION_FAILED",
+			Message: "同じ利用者どうしは統合できません",
+		}
+	}
+	if validation.NormalizeText(req.Reason) == "" {
+		return &UseCaseError{
+			Code:    "VALIDATION_FAILED",
+			Message: "統合の理由を入力してください",
+		}
+	}
+	return nil
+}
+
+func (uc *recipientMergeUseCase) getClientIP(ctx context.Context) string {
+	if ip := ctx.Value(ContextKeyClientIP); ip != nil {
+		if clientIP, ok := ip.(string); ok {
+			return clientIP
+		}
+	}
+	return "unknown"
+}
@@ -0,0 +1,272 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"shien-system/internal/domain"
+)
+
+// mockRecipientMergeRepository moves the enrollment periods and deletes the
+// merged recipient like the database implementation
+type mockRecipientMergeRepository struct {
+	recipientRepo *mockRecipientRepository
+	periodRepo    *mockEnrollmentPeriodRepository
+	merges        []*domain.RecipientMerge
+	nextError     error
+}
+
+func (m *mockRecipientMergeRepository) Merge(ctx context.Context, merge *domain.RecipientMerge) error {
+	if m.nextError != nil {
+		err := m.nextError
+		m.nextError = nil
+		return err
+	}
+	if _, exists := m.recipientRepo.recipients[merge.Merged.ID]; !exists {
+		return domain.ErrNotFound
+	}
+	for _, period := range m.periodRepo.periods {
+		if period.RecipientID == merge.Merged.ID {
+			period.RecipientID = merge.SurvivorID
+			merge.Moved.EnrollmentPeriods++
+		}
+	}
+	delete(m.recipientRepo.recipients, merge.Merged.ID)
+	m.merges = append(m.merges, merge)
+	return nil
+}
+
+func (m *mockRecipientMergeRepository) GetBySurvivorID(ctx context.Context, survivorID domain.ID) ([]*domain.RecipientMerge, error) {
+	var merges []*domain.RecipientMerge
+	for i := len(m.merges) - 1; i >= 0; i-- {
+		if m.merges[i].SurvivorID == survivorID {
+			merges = append(merges, m.merges[i])
+		}
+	}
+	return merges, nil
+}
+
+type recipientMergeTestEnv struct {
+	uc            RecipientMergeUseCase
+	recipientRepo *mockRecipientRepository
+	mergeRepo     *mockRecipientMergeRepository
+	auditRepo     *mockAuditLogRepository
+}
+
+func setupRecipientMergeUseCase() *recipientMergeTestEnv {
+	older := time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)
+	newer := time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+	birth := time.Date(1980, 4, 1, 0, 0, 0, 0, time.UTC)
+	env := &recipientMergeTestEnv{
+		recipientRepo: &mockRecipientRepository{recipients: map[domain.ID]*domain.Recipient{
+			"recipient-001": {ID: "recipient-001", Name: "髙橋 太郎", Kana: "タカハシ タロウ", BirthDate: birth, CreatedAt: older},
+			// Registered again with the common kanji, hiragana and the phone number
+			"recipient-002": {ID: "recipient-002", Name: "高橋太郎", Kana: "たかはし たろう", BirthDate: birth,
+				Phone: "０９０ー１２３４ー５６７８", Email: "taro@example.com", CreatedAt: newer,
+				SupportCategory: &domain.SupportCategory{Level: 3, ValidFrom: older, ValidUntil: newer}},
+			// Same name, different person
+			"recipient-003": {ID: "recipient-003", Name: "高橋 太郎", BirthDate: time.Date(1995, 1, 1, 0, 0, 0, 0, time.UTC), CreatedAt: older},
+			// Shares only the phone number
+			"recipient-004": {ID: "recipient-004", Name: "高橋 花子", Kana: "タカハシ ハナコ", BirthDate: time.Date(1982, 5, 5, 0, 0, 0, 0, time.UTC),
+				Phone: "090-1234-5678", CreatedAt: older},
+		}},
+		auditRepo: &mockAuditLogRepository{},
+	}
+	admission := time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+	periodRepo := &mockEnrollmentPeriodRepository{periods: map[domain.ID]*domain.EnrollmentPeriod{
+		"period-002": {ID: "period-002", RecipientID: "recipient-002", AdmissionDate: admission},
+	}}
+	env.mergeRepo = &mockRecipientMergeRepository{recipientRepo: env.recipientRepo, periodRepo: periodRepo}
+	staffRepo := &mockStaffRepository{
+		staff: map[domain.ID]*domain.Staff{
+			"admin-001": {ID: "admin-001", Name: "管理者", Role: domain.RoleAdmin},
+			"staff-001": {ID: "staff-001", Name: "職員", Role: domain.RoleStaff},
+		},
+	}
+	medicalRepo := &mockMedicalRecordRepository{}
+	env.uc = NewRecipientMergeUseCase(&mockTransactional{}, env.recipientRepo, env.mergeRepo, periodRepo, medicalRepo, staffRepo, env.auditRepo)
+	return env
+}
+
+func TestRecipientMergeUseCase_FindDuplicates(t *testing.T) {
+	env := setupRecipientMergeUseCase()
+	ctx := context.Background()
+
+	candidates, err := env.uc.FindDuplicates(ctx, FindDuplicatesRequest{ActorID: "admin-001"})
+	if err != nil {
+		t.Fatalf("FindDuplicates() error = %v", err)
+	}
+	if len(candidates) != 1 {
+		t.Fatalf("FindDuplicates() = %d candidates, want 1", len(candidates))
+	}
+	got := candidates[0]
+	if got.First.ID != "recipient-001" || got.Second.ID != "recipient-002" {
+		t.Errorf("pair = %s, %s; want the older record first", got.First.ID, got.Second.ID)
+	}
+	if got.Score != 80 {
+		t.Errorf("Score = %d, want 80", got.Score)
+	}
+	if want := []string{"フリガナが一致", "生年月日が一致"}; !reflect.DeepEqual(got.Reasons, want) {
+		t.Errorf("Reasons = %v, want %v", got.Reasons, want)
+	}
+
+	// A lower threshold also lists the same name with another birth date
+	// and the shared phone number
+	candidates, err = env.uc.FindDuplicates(ctx, FindDuplicatesRequest{MinScore: 20, ActorID: "admin-001"})
+	if err != nil {
+		t.Fatalf("FindDuplicates() error = %v", err)
+	}
+	if len(candidates) != 4 {
+		t.Errorf("FindDuplicates(MinScore: 20) = %d candidates, want 4", len(candidates))
+	}
+
+	if _, err := env.uc.FindDuplicates(ctx, FindDuplicatesRequest{ActorID: "staff-001"}); err != ErrUnauthorized {
+		t.Errorf("FindDuplicates() by staff error = %v, want ErrUnauthorized", err)
+	}
+}
+
+func TestScoreDuplicate(t *testing.T) {
+	tests := []struct {
+		name  string
+		a, b  *domain.Recipient
+		score int
+	}{
+		{
+			name:  "kana, birth date and phone",
+			a:     &domain.Recipient{Kana: "ヤマダ タロウ", BirthDate: time.Date(1980, 4, 1, 0, 0, 0, 0, time.UTC), Phone: "03-1234-5678"},
+			b:     &domain.Recipient{Kana: "ﾔﾏﾀﾞﾀﾛｳ", BirthDate: time.Date(1980, 4, 1, 0, 0, 0, 0, time.UTC), Phone: "0312345678"},
+			score: 100,
+		},
+		{
+			name:  "kanji variant without kana",
+			a:     &domain.Recipient{Name: "渡邊 一郎", BirthDate: time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC)},
+			b:     &domain.Recipient{Name: "渡辺一郎", BirthDate: time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC)},
+			score: 70,
+		},
+		{
+			name:  "nothing in common",
+			a:     &domain.Recipient{Name: "山田 太郎", Kana: "ヤマダ タロウ"},
+			b:     &domain.Recipient{Name: "佐藤 花子", Kana: "サトウ ハナコ"},
+			score: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if score, _ := scoreDuplicate(newDuplicateKeys(tt.a), newDuplicateKeys(tt.b)); score != tt.score {
+				t.Errorf("scoreDuplicate() = %d, want %d", score, tt.score)
+			}
+		})
+	}
+}
+
+func TestRecipientMergeUseCase_MergeRecipients(t *testing.T) {
+	env := setupRecipientMergeUseCase()
+	ctx := context.Background()
+
+	merge, err := env.uc.MergeRecipients(ctx, MergeRecipientsRequest{
+		SurvivorID: "recipient-001",
+		MergedID:   "recipient-002",
+		Reason:     "異体字で二重登録",
+		ActorID:    "admin-001",
+	})
+	if err != nil {
+		t.Fatalf("MergeRecipients() error = %v", err)
+	}
+	if merge.Merged.ID != "recipient-002" || merge.Merged.Name != "高橋太郎" || merge.MergedBy != "admin-001" {
+		t.Errorf("merge = %+v", merge)
+	}
+
+	if _, exists := env.recipientRepo.recipients["recipient-002"]; exists {
+		t.Error("merged recipient still exists")
+	}
+	survivor := env.recipientRepo.recipients["recipient-001"]
+	if survivor.Name != "髙橋 太郎" {
+		t.Errorf("survivor name = %q, want it unchanged", survivor.Name)
+	}
+	if survivor.Phone != "０９０ー１２３４ー５６７８" || survivor.Email != "taro@example.com" {
+		t.Errorf("blank fields were not filled from the duplicate: %+v", survivor)
+	}
+	if survivor.SupportCategory == nil || survivor.SupportCategory.Level != 3 {
+		t.Errorf("SupportCategory = %+v, want the duplicate's certification", survivor.SupportCategory)
+	}
+	if survivor.AdmissionDate == nil || !survivor.AdmissionDate.Equal(time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("AdmissionDate = %v, want the moved enrollment period", survivor.AdmissionDate)
+	}
+
+	if len(env.auditRepo.logs) != 1 {
+		t.Fatalf("audit logs = %d, want 1", len(env.auditRepo.logs))
+	}
+	log := env.auditRepo.logs[0]
+	if log.Action != mergeRecipientAction || log.Target != "recipient:recipient-001" {
+		t.Errorf("audit log = %s %s", log.Action, log.Target)
+	}
+	if !strings.Contains(log.Details, "在籍期間 1件") || !strings.Contains(log.Details, "異体字で二重登録") {
+		t.Errorf("audit details = %q", log.Details)
+	}
+
+	history, err := env.uc.GetMergeHistory(ctx, "recipient-001", "admin-001")
+	if err != nil {
+		t.Fatalf("GetMergeHistory() error = %v", err)
+	}
+	if len(history) != 1 || history[0].Merged.Email != "taro@example.com" {
+		t.Errorf("GetMergeHistory() = %v", history)
+	}
+}
+
+func TestRecipientMergeUseCase_MergeRecipientsErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		req      MergeRecipientsRequest
+		repoErr  error
+		wantCode string
+	}{
+		{
+			name:     "same recipient",
+			req:      MergeRecipientsRequest{SurvivorID: "recipient-001", MergedID: "recipient-001", Reason: "重複", ActorID: "admin-001"},
+			wantCode: "VALIDATION_FAILED",
+		},
+		{
+			name:     "no reason",
+			req:      MergeRecipientsRequest{SurvivorID: "recipient-001", MergedID: "recipient-002", Reason: "　", ActorID: "admin-001"},
+			wantCode: "VALIDATION_FAILED",
+		},
+		{
+			name:     "staff",
+			req:      MergeRecipientsRequest{SurvivorID: "recipient-001", MergedID: "recipient-002", Reason: "重複", ActorID: "staff-001"},
+			wantCode: "UNAUTHORIZED",
+		},
+		{
+			name:     "unknown recipient",
+			req:      MergeRecipientsRequest{SurvivorID: "recipient-001", MergedID: "recipient-999", Reason: "重複", ActorID: "admin-001"},
+			wantCode: "RECIPIENT_NOT_FOUND",
+		},
+		{
+			name:     "repository failure",
+			req:      MergeRecipientsRequest{SurvivorID: "recipient-001", MergedID: "recipient-002", Reason: "重複", ActorID: "admin-001"},
+			repoErr:  errors.New("disk full"),
+			wantCode: "MERGE_FAILED",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := setupRecipientMergeUseCase()
+			env.mergeRepo.nextError = tt.repoErr
+
+			_, err := env.uc.MergeRecipients(context.Background(), tt.req)
+			var ucErr *UseCaseError
+			if !errors.As(err, &ucErr) || ucErr.Code != tt.wantCode {
+				t.Fatalf("MergeRecipients() error = %v, want %s", err, tt.wantCode)
+			}
+			if len(env.recipientRepo.recipients) != 4 {
+				t.Error("recipients changed after a failed merge")
+			}
+			if len(env.auditRepo.logs) != 0 {
+				t.Error("failed merge was audited")
+			}
+		})
+	}
+}
@@ -2,39 +2,55 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"shien-system/internal/domain"
+	"shien-system/internal/validation"
 )
 
 // recipientUseCase implements RecipientUseCase interface
 type recipientUseCase struct {
+	tx             domain.Transactional
 	recipientRepo  domain.RecipientRepository
 	staffRepo      domain.StaffRepository
 	assignmentRepo domain.StaffAssignmentRepository
+	periodRepo     domain.EnrollmentPeriodRepository
 	auditRepo      domain.AuditLogRepository
+	postalRepo     domain.PostalCodeRepository
+
+	useCaseLogger
 }
 
-// NewRecipientUseCase creates a new recipient usecase
+// NewRecipientUseCase creates a new recipient usecase. postalRepo may be nil;
+// postal codes are then checked for format only.
 func NewRecipientUseCase(
+	tx domain.Transactional,
 	recipientRepo domain.RecipientRepository,
 	staffRepo domain.StaffRepository,
 	assignmentRepo domain.StaffAssignmentRepository,
+	periodRepo domain.EnrollmentPeriodRepository,
 	auditRepo domain.AuditLogRepository,
+	postalRepo domain.PostalCodeRepository,
 ) RecipientUseCase {
 	return &recipientUseCase{
+		tx:             tx,
 		recipientRepo:  recipientRepo,
 		staffRepo:      staffRepo,
 		assignmentRepo: assignmentRepo,
+		periodRepo:     periodRepo,
 		auditRepo:      auditRepo,
+		postalRepo:     postalRepo,
 	}
 }
 
 // CreateRecipient creates a new recipient with audit logging
 func (uc *recipientUseCase) CreateRecipient(ctx context.Context, req CreateRecipientRequest) (*domain.Recipient, error) {
+	normalizeCreateRecipientRequest(&req)
+
 	// Validate input
 	if err := uc.validateCreateRecipientRequest(req); err != nil {
 		return nil, &UseCaseError{
@@ -43,6 +59,9 @@ func (uc *recipientUseCase) CreateRecipient(ctx context.Context, req CreateRecip
 			Cause:   err,
 		}
 	}
+	if err := uc.checkPostalCode(ctx, req.PostalCode); err != nil {
+		return nil, err
+	}
 
 	// Verify actor exists
 	_, err := uc.staffRepo.GetByID(ctx, req.ActorID)
@@ -59,31 +78,37 @@ func (uc *recipientUseCase) CreateRecipient(ctx context.Context, req CreateRecip
 
 	// Create recipient
 	now := time.Now().UTC()
-	recipient := &domain.Recipient{
-		ID:               domain.ID(uuid.New().String()),
-		Name:             req.Name,
-		Kana:             req.Kana,
-		Sex:              req.Sex,
-		BirthDate:        req.BirthDate,
-		DisabilityName:   req.DisabilityName,
-		HasDisabilityID:  req.HasDisabilityID,
-		Grade:            req.Grade,
-		Address:          req.Address,
-		Phone:            req.Phone,
-		Email:            req.Email,
-		PublicAssistance: req.PublicAssistance,
-		AdmissionDate:    req.AdmissionDate,
-		CreatedAt:        now,
-		UpdatedAt:        now,
-	}
-
-	err = uc.recipientRepo.Create(ctx, recipient)
-	if err != nil {
-		return nil, &UseCaseError{
-			Code:    "CREATION_FAILED",
-			Message: "利用者の作成に失敗しました",
-			Cause:   err,
+	recipient := newRecipient(req, now)
+
+	// The recipient and its first enrollment period are written together
+	err = uc.tx.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.recipientRepo.Create(ctx, recipient); err != nil {
+			return &UseCaseError{
+				Code:    "CREATION_FAILED",
+				Message: "利用者の作成に失敗しました",
+				Cause:   err,
+			}
 		}
+
+		// Open the first enrollment period
+		period := &domain.EnrollmentPeriod{
+			ID:            domain.ID(uuid.New().String()),
+			RecipientID:   recipient.ID,
+			AdmissionDate: *recipient.AdmissionDate,
+			CreatedAt:     now,
+			UpdatedAt:     now,
+		}
+		if err := uc.periodRepo.Create(ctx, period); err != nil {
+			return &UseCaseError{
+				Code:    "CREATION_FAILED",
+				Message: "在籍期間の作成に失敗しました",
+				Cause:   err,
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	// Log the action
@@ -100,7 +125,7 @@ func (uc *recipientUseCase) CreateRecipient(ctx context.Context, req CreateRecip
 	err = uc.auditRepo.Create(ctx, auditLog)
 	if err != nil {
 		// Log audit failure but don't fail the operation
-		// In production, this should be logged to monitoring system
+		uc.log().Warn("failed to write audit log", "action", auditLog.Action, "error", err)
 	}
 
 	return recipient, nil
@@ -125,6 +150,8 @@ func (uc *recipientUseCase) GetRecipient(ctx context.Context, id domain.ID) (*do
 
 // UpdateRecipient updates recipient information with audit logging
 func (uc *recipientUseCase) UpdateRecipient(ctx context.Context, req UpdateRecipientRequest) (*domain.Recipient, error) {
+	normalizeUpdateRecipientRequest(&req)
+
 	// Validate input
 	if err := uc.validateUpdateRecipientRequest(req); err != nil {
 		return nil, &UseCaseError{
@@ -133,6 +160,9 @@ func (uc *recipientUseCase) UpdateRecipient(ctx context.Context, req UpdateRecip
 			Cause:   err,
 		}
 	}
+	if err := uc.checkPostalCode(ctx, req.PostalCode); err != nil {
+		return nil, err
+	}
 
 	// Verify actor exists
 	_, err := uc.staffRepo.GetByID(ctx, req.ActorID)
@@ -160,34 +190,70 @@ func (uc *recipientUseCase) UpdateRecipient(ctx context.Context, req UpdateRecip
 		}
 	}
 
+	// Someone else saved the recipient after the form was opened
+	if existing.Version != req.Version {
+		return nil, newEditConflictError(existing)
+	}
+
 	// Update recipient
 	now := time.Now().UTC()
 	recipient := &domain.Recipient{
-		ID:               req.ID,
-		Name:             req.Name,
-		Kana:             req.Kana,
-		Sex:              req.Sex,
-		BirthDate:        req.BirthDate,
-		DisabilityName:   req.DisabilityName,
-		HasDisabilityID:  req.HasDisabilityID,
-		Grade:            req.Grade,
-		Address:          req.Address,
-		Phone:            req.Phone,
-		Email:            req.Email,
-		PublicAssistance: req.PublicAssistance,
-		AdmissionDate:    req.AdmissionDate,
-		DischargeDate:    req.DischargeDate,
-		CreatedAt:        existing.CreatedAt, // Preserve original creation time
-		UpdatedAt:        now,
-	}
-
-	err = uc.recipientRepo.Update(ctx, recipient)
-	if err != nil {
-		return nil, &UseCaseError{
-			Code:    "UPDATE_FAILED",
-			Message: "利用者の更新に失敗しました",
-			Cause:   err,
+		ID:                 req.ID,
+		Name:               req.Name,
+		Kana:               req.Kana,
+		Sex:                req.Sex,
+		BirthDate:          req.BirthDate,
+		DisabilityName:     req.DisabilityName,
+		HasDisabilityID:    req.HasDisabilityID || len(req.Handbooks) > 0,
+		Grade:              req.Grade,
+		Address:            req.Address,
+		PostalCode:         req.PostalCode,
+		Prefecture:         req.Prefecture,
+		City:               req.City,
+		Street:             req.Street,
+		Phone:              req.Phone,
+		Email:              req.Email,
+		PublicAssistance:   req.PublicAssistance,
+		Handbooks:          req.Handbooks,
+		SupportCategory:    req.SupportCategory,
+		IntractableDisease: req.IntractableDisease,
+		AdmissionDate:      req.AdmissionDate,
+		DischargeDate:      req.DischargeDate,
+		CreatedAt:          existing.CreatedAt, // Preserve original creation time
+		UpdatedAt:          now,
+		Version:            req.Version,
+	}
+	recipient.Address = recipient.ComposeAddress()
+
+	// The recipient and its enrollment history are written together
+	err = uc.tx.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.recipientRepo.Update(ctx, recipient); err != nil {
+			if errors.Is(err, domain.ErrVersionConflict) {
+				return err
+			}
+			return &UseCaseError{
+				Code:    "UPDATE_FAILED",
+				Message: "利用者の更新に失敗しました",
+				Cause:   err,
+			}
 		}
+
+		// Keep the enrollment history in step with the admission/discharge dates
+		if err := uc.syncEnrollmentPeriod(ctx, recipient, now); err != nil {
+			return &UseCaseError{
+				Code:    "UPDATE_FAILED",
+				Message: "在籍期間の更新に失敗しました",
+				Cause:   err,
+			}
+		}
+		return nil
+	})
+	if errors.Is(err, domain.ErrVersionConflict) {
+		current, _ := uc.recipientRepo.GetByID(ctx, req.ID)
+		return nil, newEditConflictError(current)
+	}
+	if err != nil {
+		return nil, err
 	}
 
 	// Log the action
@@ -204,6 +270,7 @@ func (uc *recipientUseCase) UpdateRecipient(ctx context.Context, req UpdateRecip
 	err = uc.auditRepo.Create(ctx, auditLog)
 	if err != nil {
 		// Log audit failure but don't fail the operation
+		uc.log().Warn("failed to write audit log", "action", auditLog.Action, "error", err)
 	}
 
 	return recipient, nil
@@ -270,7 +337,9 @@ func (uc *recipientUseCase) DeleteRecipient(ctx context.Context, id domain.ID) e
 			Details: fmt.Sprintf("利用者「%s」を削除しました", recipient.Name),
 		}
 
-		uc.auditRepo.Create(ctx, auditLog)
+		if err := uc.auditRepo.Create(ctx, auditLog); err != nil {
+			uc.log().Warn("failed to write audit log", "action", auditLog.Action, "error", err)
+		}
 	}
 
 	return nil
@@ -308,8 +377,7 @@ func (uc *recipientUseCase) ListRecipients(ctx context.Context, req ListRecipien
 
 // GetActiveRecipients retrieves all currently active recipients
 func (uc *recipientUseCase) GetActiveRecipients(ctx context.Context) ([]*domain.Recipient, error) {
-	// Get all active recipients (no pagination for this method)
-	recipients, err := uc.recipientRepo.GetActive(ctx, 1000, 0) // Large limit for all active
+	recipients, err := uc.listEnrolledOn(ctx, time.Now())
 	if err != nil {
 		return nil, &UseCaseError{
 			Code:    "RETRIEVAL_FAILED",
@@ -402,7 +470,9 @@ func (uc *recipientUseCase) AssignStaff(ctx context.Context, req AssignStaffRequ
 		Details: fmt.Sprintf("担当者を割り当てました (役割: %s)", req.Role),
 	}
 
-	uc.auditRepo.Create(ctx, auditLog)
+	if err := uc.auditRepo.Create(ctx, auditLog); err != nil {
+		uc.log().Warn("failed to write audit log", "action", auditLog.Action, "error", err)
+	}
 
 	return nil
 }
@@ -478,13 +548,469 @@ func (uc *recipientUseCase) UnassignStaff(ctx context.Context, req UnassignStaff
 		Details: "担当者の割り当てを解除しました",
 	}
 
-	uc.auditRepo.Create(ctx, auditLog)
+	if err := uc.auditRepo.Create(ctx, auditLog); err != nil {
+		uc.log().Warn("failed to write audit log", "action", auditLog.Action, "error", err)
+	}
 
 	return nil
 }
 
+// GetRecipientsEnrolledOn retrieves recipients who were enrolled on the given date
+func (uc *recipientUseCase) GetRecipientsEnrolledOn(ctx context.Context, date time.Time) ([]*domain.Recipient, error) {
+	if date.IsZero() {
+		return nil, &UseCaseError{
+			Code:    "VALIDATION_FAILED",
+			Message: "基準日は必須です",
+		}
+	}
+
+	recipients, err := uc.listEnrolledOn(ctx, date)
+	if err != nil {
+		return nil, &UseCaseError{
+			Code:    "RETRIEVAL_FAILED",
+			Message: "在籍者の取得に失敗しました",
+			Cause:   err,
+		}
+	}
+
+	return recipients, nil
+}
+
+// enrolledPageSize is the number of recipients read per GetActive call when
+// listing every recipient enrolled on a date
+const enrolledPageSize = 500
+
+// listEnrolledOn pages through GetActive until every recipient enrolled on
+// date has been read
+func (uc *recipientUseCase) listEnrolledOn(ctx context.Context, date time.Time) ([]*domain.Recipient, error) {
+	var recipients []*domain.Recipient
+	for offset := 0; ; offset += enrolledPageSize {
+		page, err := uc.recipientRepo.GetActive(ctx, date, enrolledPageSize, offset)
+		if err != nil {
+			return nil, err
+		}
+		recipients = append(recipients, page...)
+		if len(page) < enrolledPageSize {
+			return recipients, nil
+		}
+	}
+}
+
+// GetEnrollmentPeriods retrieves the enrollment history of a recipient, oldest first
+func (uc *recipientUseCase) GetEnrollmentPeriods(ctx context.Context, recipientID domain.ID) ([]*domain.EnrollmentPeriod, error) {
+	_, err := uc.recipientRepo.GetByID(ctx, recipientID)
+	if err != nil {
+		if err == domain.ErrNotFound {
+			return nil, ErrRecipientNotFound
+		}
+		return nil, &UseCaseError{
+			Code:    "RETRIEVAL_FAILED",
+			Message: "利用者の取得に失敗しました",
+			Cause:   err,
+		}
+	}
+
+	periods, err := uc.periodRepo.GetByRecipientID(ctx, recipientID)
+	if err != nil {
+		return nil, &UseCaseError{
+			Code:    "RETRIEVAL_FAILED",
+			Message: "在籍履歴の取得に失敗しました",
+			Cause:   err,
+		}
+	}
+
+	return periods, nil
+}
+
+// AdmitRecipient opens a new enrollment period for a discharged recipient
+func (uc *recipientUseCase) AdmitRecipient(ctx context.Context, req AdmitRecipientRequest) (*domain.EnrollmentPeriod, error) {
+	if err := uc.validateAdmitRecipientRequest(req); err != nil {
+		return nil, &UseCaseError{
+			Code:    "VALIDATION_FAILED",
+			Message: "入力値が不正です",
+			Cause:   err,
+		}
+	}
+
+	recipient, err := uc.getRecipientForEnrollment(ctx, req.ActorID, req.RecipientID)
+	if err != nil {
+		return nil, err
+	}
+
+	latest, err := uc.periodRepo.GetLatestByRecipientID(ctx, req.RecipientID)
+	if err != nil && err != domain.ErrNotFound {
+		return nil, &UseCaseError{
+			Code:    "INTERNAL_ERROR",
+			Message: "内部エラーが発生しました",
+			Cause:   err,
+		}
+	}
+	if latest != nil {
+		if latest.IsOpen() {
+			return nil, ErrAlreadyEnrolled
+		}
+		if !truncateDate(req.AdmissionDate).After(truncateDate(*latest.DischargeDate)) {
+			return nil, &UseCaseError{
+				Code:    "VALIDATION_FAILED",
+				Message: "再入所日は前回の退所日より後の日付を指定してください",
+			}
+		}
+	}
+
+	now := time.Now().UTC()
+	period := &domain.EnrollmentPeriod{
+		ID:            domain.ID(uuid.New().String()),
+		RecipientID:   req.RecipientID,
+		AdmissionDate: req.AdmissionDate,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+
+	// The period and the recipient's mirrored dates are written together
+	err = uc.tx.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.periodRepo.Create(ctx, period); err != nil {
+			return &UseCaseError{
+				Code:    "CREATION_FAILED",
+				Message: "在籍期間の作成に失敗しました",
+				Cause:   err,
+			}
+		}
+
+		// Mirror the latest period on the recipient
+		admissionDate := req.AdmissionDate
+		recipient.AdmissionDate = &admissionDate
+		recipient.DischargeDate = nil
+		recipient.UpdatedAt = now
+		if err := uc.recipientRepo.Update(ctx, recipient); err != nil {
+			if errors.Is(err, domain.ErrVersionConflict) {
+				return err
+			}
+			return &UseCaseError{
+				Code:    "UPDATE_FAILED",
+				Message: "利用者の更新に失敗しました",
+				Cause:   err,
+			}
+		}
+		return nil
+	})
+	if errors.Is(err, domain.ErrVersionConflict) {
+		current, _ := uc.recipientRepo.GetByID(ctx, req.RecipientID)
+		return nil, newEditConflictError(current)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	auditLog := &domain.AuditLog{
+		ID:      domain.ID(uuid.New().String()),
+		ActorID: req.ActorID,
+		Action:  "ADMIT",
+		Target:  fmt.Sprintf("recipient:%s", recipient.ID),
+		At:      now,
+		IP:      uc.getClientIP(ctx),
+		Details: fmt.Sprintf("利用者「%s」の入所を登録しました (入所日: %s)", recipient.Name, req.AdmissionDate.Format("2006-01-02")),
+	}
+
+	if err := uc.auditRepo.Create(ctx, auditLog); err != nil {
+		uc.log().Warn("failed to write audit log", "action", auditLog.Action, "error", err)
+	}
+
+	return period, nil
+}
+
+// DischargeRecipient closes the open enrollment period of a recipient
+func (uc *recipientUseCase) DischargeRecipient(ctx context.Context, req DischargeRecipientRequest) (*domain.EnrollmentPeriod, error) {
+	if err := uc.validateDischargeRecipientRequest(req); err != nil {
+		return nil, &UseCaseError{
+			Code:    "VALIDATION_FAILED",
+			Message: "入力値が不正です",
+			Cause:   err,
+		}
+	}
+
+	recipient, err := uc.getRecipientForEnrollment(ctx, req.ActorID, req.RecipientID)
+	if err != nil {
+		return nil, err
+	}
+
+	period, err := uc.periodRepo.GetOpenByRecipientID(ctx, req.RecipientID)
+	if err != nil {
+		if err == domain.ErrNotFound {
+			return nil, ErrNotEnrolled
+		}
+		return nil, &UseCaseError{
+			Code:    "INTERNAL_ERROR",
+			Message: "内部エラーが発生しました",
+			Cause:   err,
+		}
+	}
+
+	if truncateDate(req.DischargeDate).Before(truncateDate(period.AdmissionDate)) {
+		return nil, &UseCaseError{
+			Code:    "VALIDATION_FAILED",
+			Message: "退所日は入所日以降の日付を指定してください",
+		}
+	}
+
+	now := time.Now().UTC()
+	dischargeDate := req.DischargeDate
+	period.DischargeDate = &dischargeDate
+	period.UpdatedAt = now
+
+	// The period and the recipient's mirrored dates are written together
+	err = uc.tx.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.periodRepo.Update(ctx, period); err != nil {
+			return &UseCaseError{
+				Code:    "UPDATE_FAILED",
+				Message: "在籍期間の更新に失敗しました",
+				Cause:   err,
+			}
+		}
+
+		// Mirror the latest period on the recipient
+		admissionDate := period.AdmissionDate
+		recipient.AdmissionDate = &admissionDate
+		recipient.DischargeDate = &dischargeDate
+		recipient.UpdatedAt = now
+		if err := uc.recipientRepo.Update(ctx, recipient); err != nil {
+			if errors.Is(err, domain.ErrVersionConflict) {
+				return err
+			}
+			return &UseCaseError{
+				Code:    "UPDATE_FAILED",
+				Message: "利用者の更新に失敗しました",
+				Cause:   err,
+			}
+		}
+		return nil
+	})
+	if errors.Is(err, domain.ErrVersionConflict) {
+		current, _ := uc.recipientRepo.GetByID(ctx, req.RecipientID)
+		return nil, newEditConflictError(current)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	auditLog := &domain.AuditLog{
+		ID:      domain.ID(uuid.New().String()),
+		ActorID: req.ActorID,
+		Action:  "DISCHARGE",
+		Target:  fmt.Sprintf("recipient:%s", recipient.ID),
+		At:      now,
+		IP:      uc.getClientIP(ctx),
+		Details: fmt.Sprintf("利用者「%s」の退所を登録しました (退所日: %s)", recipient.Name, req.DischargeDate.Format("2006-01-02")),
+	}
+
+	if err := uc.auditRepo.Create(ctx, auditLog); err != nil {
+		uc.log().Warn("failed to write audit log", "action", auditLog.Action, "error", err)
+	}
+
+	return period, nil
+}
+
+// getRecipientForEnrollment verifies the actor and loads the recipient for admission/discharge
+func (uc *recipientUseCase) getRecipientForEnrollment(ctx context.Context, actorID, recipientID domain.ID) (*domain.Recipient, error) {
+	_, err := uc.staffRepo.GetByID(ctx, actorID)
+	if err != nil {
+		if err == domain.ErrNotFound {
+			return nil, ErrUnauthorized
+		}
+		return nil, &UseCaseError{
+			Code:    "INTERNAL_ERROR",
+			Message: "内部エラーが発生しました",
+			Cause:   err,
+		}
+	}
+
+	recipient, err := uc.recipientRepo.GetByID(ctx, recipientID)
+	if err != nil {
+		if err == domain.ErrNotFound {
+			return nil, ErrRecipientNotFound
+		}
+		return nil, &UseCaseError{
+			Code:    "RETRIEVAL_FAILED",
+			Message: "利用者の取得に失敗しました",
+			Cause:   err,
+		}
+	}
+
+	return recipient, nil
+}
+
+// syncEnrollmentPeriod reflects the recipient's admission/discharge dates onto its latest period.
+// A new period is opened when the admission date is after the previous discharge (re-admission),
+// so that earlier periods are kept as history.
+func (uc *recipientUseCase) syncEnrollmentPeriod(ctx context.Context, recipient *domain.Recipient, now time.Time) error {
+	if recipient.AdmissionDate == nil {
+		return nil
+	}
+
+	latest, err := uc.periodRepo.GetLatestByRecipientID(ctx, recipient.ID)
+	if err != nil && err != domain.ErrNotFound {
+		return err
+	}
+
+	reAdmitted := latest != nil && !latest.IsOpen() &&
+		truncateDate(*recipient.AdmissionDate).After(truncateDate(*latest.DischargeDate))
+
+	if latest == nil || reAdmitted {
+		return uc.periodRepo.Create(ctx, &domain.EnrollmentPeriod{
+			ID:            domain.ID(uuid.New().String()),
+			RecipientID:   recipient.ID,
+			AdmissionDate: *recipient.AdmissionDate,
+			DischargeDate: recipient.DischargeDate,
+			CreatedAt:     now,
+			UpdatedAt:     now,
+		})
+	}
+
+	latest.AdmissionDate = *recipient.AdmissionDate
+	latest.DischargeDate = recipient.DischargeDate
+	latest.UpdatedAt = now
+	return uc.periodRepo.Update(ctx, latest)
+}
+
 // Validation functions
 
+// checkPostalCode rejects postal codes that are malformed or, when the
+// dictionary is available, not in it
+func (uc *recipientUseCase) checkPostalCode(ctx context.Context, code string) error {
+	if code == "" {
+		return nil
+	}
+	if validation.PostalCodeDigits(code) == "" {
+		return &UseCaseError{
+			Code:    "VALIDATION_FAILED",
+			Message: "入力値が不正です",
+			Cause:   fmt.Errorf("郵便番号は7桁の数字で入力してください: %s", code),
+		}
+	}
+	if uc.postalRepo == nil {
+		return nil
+	}
+
+	known, err := postalCodeKnown(ctx, uc.postalRepo, code)
+	if err != nil {
+		return &UseCaseError{
+			Code:    "INTERNAL_ERROR",
+			Message: "内部エラーが発生しました",
+			Cause:   err,
+		}
+	}
+	if !known {
+		return &UseCaseError{
+			Code:    "VALIDATION_FAILED",
+			Message: "入力値が不正です",
+			Cause:   fmt.Errorf("郵便番号辞書に登録されていない郵便番号です: %s", code),
+		}
+	}
+
+	return nil
+}
+
+// recipientFields points at the recipient fields that create and update
+// requests share, so both are normalized by the same rules
+type recipientFields struct {
+	name, kana, disabilityName, grade, address         *string
+	postalCode, prefecture, city, street, phone, email *string
+	handbooks                                          *[]domain.DisabilityHandbook
+	intractableDisease                                 **domain.IntractableDisease
+}
+
+// normalize stores text the way it is searched, see validation.NormalizeText
+func (f recipientFields) normalize() {
+	for _, text := range []*string{f.name, f.disabilityName, f.grade, f.address,
+		f.prefecture, f.city, f.street, f.email} {
+		*text = validation.NormalizeText(*text)
+	}
+	*f.kana = validation.NormalizeKana(*f.kana)
+	*f.postalCode = validation.NormalizePostalCode(*f.postalCode)
+	*f.phone = validation.NormalizePhone(*f.phone)
+	*f.handbooks = normalizeHandbooks(*f.handbooks)
+	*f.intractableDisease = normalizeIntractableDisease(*f.intractableDisease)
+}
+
+func normalizeCreateRecipientRequest(req *CreateRecipientRequest) {
+	recipientFields{
+		name: &req.Name, kana: &req.Kana, disabilityName: &req.DisabilityName,
+		grade: &req.Grade, address: &req.Address, postalCode: &req.PostalCode,
+		prefecture: &req.Prefecture, city: &req.City, street: &req.Street,
+		phone: &req.Phone, email: &req.Email, handbooks: &req.Handbooks,
+		intractableDisease: &req.IntractableDisease,
+	}.normalize()
+}
+
+func normalizeUpdateRecipientRequest(req *UpdateRecipientRequest) {
+	recipientFields{
+		name: &req.Name, kana: &req.Kana, disabilityName: &req.DisabilityName,
+		grade: &req.Grade, address: &req.Address, postalCode: &req.PostalCode,
+		prefecture: &req.Prefecture, city: &req.City, street: &req.Street,
+		phone: &req.Phone, email: &req.Email, handbooks: &req.Handbooks,
+		intractableDisease: &req.IntractableDisease,
+	}.normalize()
+}
+
+// normalizeHandbooks returns a normalized copy of the handbooks
+func normalizeHandbooks(handbooks []domain.DisabilityHandbook) []domain.DisabilityHandbook {
+	if len(handbooks) == 0 {
+		return nil
+	}
+	normalized := make([]domain.DisabilityHandbook, len(handbooks))
+	for i, handbook := range handbooks {
+		handbook.Number = validation.NormalizeText(handbook.Number)
+		handbook.Grade = validation.NormalizeText(handbook.Grade)
+		normalized[i] = handbook
+	}
+	return normalized
+}
+
+// normalizeIntractableDisease returns a normalized copy of the designation
+func normalizeIntractableDisease(disease *domain.IntractableDisease) *domain.IntractableDisease {
+	if disease == nil {
+		return nil
+	}
+	normalized := *disease
+	normalized.Name = validation.NormalizeText(normalized.Name)
+	return &normalized
+}
+
+// newRecipient builds a new recipient from a normalized request
+func newRecipient(req CreateRecipientRequest, now time.Time) *domain.Recipient {
+	recipient := &domain.Recipient{
+		ID:                 domain.ID(uuid.New().String()),
+		Name:               req.Name,
+		Kana:               req.Kana,
+		Sex:                req.Sex,
+		BirthDate:          req.BirthDate,
+		DisabilityName:     req.DisabilityName,
+		HasDisabilityID:    req.HasDisabilityID || len(req.Handbooks) > 0,
+		Grade:              req.Grade,
+		Address:            req.Address,
+		PostalCode:         req.PostalCode,
+		Prefecture:         req.Prefecture,
+		City:               req.City,
+		Street:             req.Street,
+		Phone:              req.Phone,
+		Email:              req.Email,
+		PublicAssistance:   req.PublicAssistance,
+		Handbooks:          req.Handbooks,
+		SupportCategory:    req.SupportCategory,
+		IntractableDisease: req.IntractableDisease,
+		AdmissionDate:      req.AdmissionDate,
+		CreatedAt:          now,
+		UpdatedAt:          now,
+	}
+	// Recipients registered without an admission date are enrolled from the
+	// day they are registered, as migration 0005 did for existing rows
+	if recipient.AdmissionDate == nil {
+		registered := truncateDate(now.Local())
+		recipient.AdmissionDate = &registered
+	}
+	recipient.Address = recipient.ComposeAddress()
+	return recipient
+}
+
 func (uc *recipientUseCase) validateCreateRecipientRequest(req CreateRecipientRequest) error {
 	var errors []string
 
@@ -500,6 +1026,8 @@ func (uc *recipientUseCase) validateCreateRecipientRequest(req CreateRecipientRe
 		errors = append(errors, "生年月日は必須です")
 	}
 
+	errors = append(errors, validateDisability(req.Handbooks, req.SupportCategory, req.IntractableDisease)...)
+
 	if req.ActorID == "" {
 		errors = append(errors, "実行者IDは必須です")
 	}
@@ -530,6 +1058,16 @@ func (uc *recipientUseCase) validateUpdateRecipientRequest(req UpdateRecipientRe
 		errors = append(errors, "生年月日は必須です")
 	}
 
+	errors = append(errors, validateDisability(req.Handbooks, req.SupportCategory, req.IntractableDisease)...)
+
+	if req.DischargeDate != nil {
+		if req.AdmissionDate == nil {
+			errors = append(errors, "退所日を設定する場合は入所日も必須です")
+		} else if truncateDate(*req.DischargeDate).Before(truncateDate(*req.AdmissionDate)) {
+			errors = append(errors, "退所日は入所日以降の日付を指定してください")
+		}
+	}
+
 	if req.ActorID == "" {
 		errors = append(errors, "実行者IDは必須です")
 	}
@@ -541,6 +1079,47 @@ func (uc *recipientUseCase) validateUpdateRecipientRequest(req UpdateRecipientRe
 	return nil
 }
 
+// validateDisability checks the structured disability data shared by the
+// create and update requests
+func validateDisability(handbooks []domain.DisabilityHandbook, category *domain.SupportCategory, disease *domain.IntractableDisease) []string {
+	var errors []string
+
+	seen := make(map[domain.HandbookType]bool)
+	for _, handbook := range handbooks {
+		label := handbook.Type.Label()
+		switch handbook.Type {
+		case domain.HandbookTypePhysical, domain.HandbookTypeIntellectual, domain.HandbookTypeMental:
+		default:
+			errors = append(errors, fmt.Sprintf("手帳の種類が不正です: %s", handbook.Type))
+			continue
+		}
+		if seen[handbook.Type] {
+			errors = append(errors, fmt.Sprintf("%sが重複しています", label))
+		}
+		seen[handbook.Type] = true
+		if handbook.Number == "" {
+			errors = append(errors, fmt.Sprintf("%sの番号は必須です", label))
+		}
+	}
+
+	if category != nil {
+		if category.Level < domain.SupportCategoryNone || category.Level > domain.SupportCategoryMax {
+			errors = append(errors, fmt.Sprintf("障害支援区分は%d〜%dまたは非該当で指定してください", domain.SupportCategoryMin, domain.SupportCategoryMax))
+		}
+		if category.ValidFrom.IsZero() || category.ValidUntil.IsZero() {
+			errors = append(errors, "障害支援区分の有効期間は必須です")
+		} else if truncateDate(category.ValidUntil).Before(truncateDate(category.ValidFrom)) {
+			errors = append(errors, "障害支援区分の有効期間の終了日は開始日以降の日付を指定してください")
+		}
+	}
+
+	if disease != nil && disease.Name == "" {
+		errors = append(errors, "指定難病の疾病名は必須です")
+	}
+
+	return errors
+}
+
 func (uc *recipientUseCase) validateAssignStaffRequest(req AssignStaffRequest) error {
 	var errors []string
 
@@ -567,8 +1146,59 @@ func (uc *recipientUseCase) validateAssignStaffRequest(req AssignStaffRequest) e
 	return nil
 }
 
+func (uc *recipientUseCase) validateAdmitRecipientRequest(req AdmitRecipientRequest) error {
+	var errors []string
+
+	if req.RecipientID == "" {
+		errors = append(errors, "利用者IDは必須です")
+	}
+
+	if req.AdmissionDate.IsZero() {
+		errors = append(errors, "入所日は必須です")
+	}
+
+	if req.ActorID == "" {
+		errors = append(errors, "実行者IDは必須です")
+	}
+
+	if len(errors) > 0 {
+		return fmt.Errorf("validation errors: %s", strings.Join(errors, ", "))
+	}
+
+	return nil
+}
+
+func (uc *recipientUseCase) validateDischargeRecipientRequest(req DischargeRecipientRequest) error {
+	var errors []string
+
+	if req.RecipientID == "" {
+		errors = append(errors, "利用者IDは必須です")
+	}
+
+	if req.DischargeDate.IsZero() {
+		errors = append(errors, "退所日は必須です")
+	}
+
+	if req.ActorID == "" {
+		errors = append(errors, "実行者IDは必須です")
+	}
+
+	if len(errors) > 0 {
+		return fmt.Errorf("validation errors: %s", strings.Join(errors, ", "))
+	}
+
+	return nil
+}
+
 // Helper functions
 
+// truncateDate drops the time-of-day part so that dates are compared by
+// calendar day in their own location, as the repositories compare them
+func truncateDate(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
 func (uc *recipientUseCase) getActorID(ctx context.Context) domain.ID {
 	if actorID := ctx.Value(ContextKeyUserID); actorID != nil {
 		if id, ok := actorID.(string); ok {
@@ -3,6 +3,8 @@ package usecase
 import (
 	"context"
 	"errors"
+	"fmt"
+	"sort"
 	"strings"
 	"testing"
 	"time"
@@ -126,7 +128,7 @@ func (m *mockRecipientRepository) GetByStaffID(ctx context.Context, staffID doma
 	return []*domain.Recipient{}, nil
 }
 
-func (m *mockRecipientRepository) GetActive(ctx context.Context, limit, offset int) ([]*domain.Recipient, error) {
+func (m *mockRecipientRepository) GetActive(ctx context.Context, asOf time.Time, limit, offset int) ([]*domain.Recipient, error) {
 	if m.nextError != nil {
 		err := m.nextError
 		m.nextError = nil
@@ -134,10 +136,14 @@ func (m *mockRecipientRepository) GetActive(ctx context.Context, limit, offset i
 	}
 	var activeRecipients []*domain.Recipient
 	for _, recipient := range m.recipients {
-		if recipient.DischargeDate == nil {
+		if isEnrolledOn(recipient, asOf) {
 			activeRecipients = append(activeRecipients, recipient)
 		}
 	}
+	// Page in a stable order, as the repository does
+	sort.Slice(activeRecipients, func(i, j int) bool {
+		return activeRecipients[i].ID < activeRecipients[j].ID
+	})
 	// Apply pagination
 	start := offset
 	if start > len(activeRecipients) {
@@ -150,7 +156,7 @@ func (m *mockRecipientRepository) GetActive(ctx context.Context, limit, offset i
 	return activeRecipients[start:end], nil
 }
 
-func (m *mockRecipientRepository) CountActive(ctx context.Context) (int, error) {
+func (m *mockRecipientRepository) CountActive(ctx context.Context, asOf time.Time) (int, error) {
 	if m.nextError != nil {
 		err := m.nextError
 		m.nextError = nil
@@ -158,7 +164,7 @@ func (m *mockRecipientRepository) CountActive(ctx context.Context) (int, error)
 	}
 	count := 0
 	for _, recipient := range m.recipients {
-		if recipient.DischargeDate == nil {
+		if isEnrolledOn(recipient, asOf) {
 			count++
 		}
 	}
@@ -174,6 +180,92 @@ func (m *mockRecipientRepository) Count(ctx context.Context) (int, error) {
 	return len(m.recipients), nil
 }
 
+// isEnrolledOn approximates the enrollment period lookup using the recipient's latest dates
+func isEnrolledOn(recipient *domain.Recipient, asOf time.Time) bool {
+	if recipient.AdmissionDate != nil && truncateDate(*recipient.AdmissionDate).After(truncateDate(asOf)) {
+		return false
+	}
+	return recipient.DischargeDate == nil || !truncateDate(*recipient.DischargeDate).Before(truncateDate(asOf))
+}
+
+type mockEnrollmentPeriodRepository struct {
+	periods   map[domain.ID]*domain.EnrollmentPeriod
+	nextError error
+}
+
+func (m *mockEnrollmentPeriodRepository) Create(ctx context.Context, period *domain.EnrollmentPeriod) error {
+	if m.nextError != nil {
+		err := m.nextError
+		m.nextError = nil
+		return err
+	}
+	if m.periods == nil {
+		m.periods = make(map[domain.ID]*domain.EnrollmentPeriod)
+	}
+	m.periods[period.ID] = period
+	return nil
+}
+
+func (m *mockEnrollmentPeriodRepository) GetByID(ctx context.Context, id domain.ID) (*domain.EnrollmentPeriod, error) {
+	period, exists := m.periods[id]
+	if !exists {
+		return nil, domain.ErrNotFound
+	}
+	return period, nil
+}
+
+func (m *mockEnrollmentPeriodRepository) Update(ctx context.Context, period *domain.EnrollmentPeriod) error {
+	if m.nextError != nil {
+		err := m.nextError
+		m.nextError = nil
+		return err
+	}
+	if _, exists := m.periods[period.ID]; !exists {
+		return domain.ErrNotFound
+	}
+	m.periods[period.ID] = period
+	return nil
+}
+
+func (m *mockEnrollmentPeriodRepository) Delete(ctx context.Context, id domain.ID) error {
+	if _, exists := m.periods[id]; !exists {
+		return domain.ErrNotFound
+	}
+	delete(m.periods, id)
+	return nil
+}
+
+func (m *mockEnrollmentPeriodRepository) GetByRecipientID(ctx context.Context, recipientID domain.ID) ([]*domain.EnrollmentPeriod, error) {
+	var periods []*domain.EnrollmentPeriod
+	for _, period := range m.periods {
+		if period.RecipientID == recipientID {
+			periods = append(periods, period)
+		}
+	}
+	sort.Slice(periods, func(i, j int) bool {
+		return periods[i].AdmissionDate.Before(periods[j].AdmissionDate)
+	})
+	return periods, nil
+}
+
+func (m *mockEnrollmentPeriodRepository) GetOpenByRecipientID(ctx context.Context, recipientID domain.ID) (*domain.EnrollmentPeriod, error) {
+	periods, _ := m.GetByRecipientID(ctx, recipientID)
+	for i := len(periods) - 1; i >= 0; i-- {
+		if periods[i].IsOpen() {
+			return periods[i], nil
+		}
+	}
+	return nil, domain.ErrNotFound
+}
+
+func (m *mockEnrollmentPeriodRepository) GetLatestByRecipientID(ctx context.Context, recipientID domain.ID) (*domain.EnrollmentPeriod, error) {
+	periods, _ := m.GetByRecipientID(ctx, recipientID)
+	if len(periods) == 0 {
+		return nil, domain.ErrNotFound
+	}
+	return periods[len(periods)-1], nil
+}
+
 type mockStaffAssignmentRepository struct {
 	assignments map[domain.ID]*domain.StaffAssignment
 	nextError   error
@@ -457,11 +549,23 @@ func (m *mockAuditLogRepository) GetByActorID(ctx context.Context, actorID domai
 }
 
 func (m *mockAuditLogRepository) GetByAction(ctx context.Context, action string, limit, offset int) ([]*domain.AuditLog, error) {
-	return nil, nil
+	var logs []*domain.AuditLog
+	for _, log := range m.logs {
+		if log.Action == action {
+			logs = append(logs, log)
+		}
+	}
+	return logs, nil
 }
 
 func (m *mockAuditLogRepository) GetByTarget(ctx context.Context, target string, limit, offset int) ([]*domain.AuditLog, error) {
-	return nil, nil
+	var logs []*domain.AuditLog
+	for _, log := range m.logs {
+		if log.Target == target {
+			logs = append(logs, log)
+		}
+	}
+	return logs, nil
 }
 
 func (m *mockAuditLogRepository) GetByTimeRange(ctx context.Context, start, end time.Time, limit, offset int) ([]*domain.AuditLog, error) {
@@ -492,9 +596,10 @@ func TestRecipientUseCase_CreateRecipient(t *testing.T) {
 		},
 	}
 	mockAssignmentRepo := &mockStaffAssignmentRepository{}
+	mockPeriodRepo := &mockEnrollmentPeriodRepository{}
 	mockAuditRepo := &mockAuditLogRepository{}
 
-	usecase := NewRecipientUseCase(mockRecipientRepo, mockStaffRepo, mockAssignmentRepo, mockAuditRepo)
+	usecase := NewRecipientUseCase(&mockTransactional{}, mockRecipientRepo, mockStaffRepo, mockAssignmentRepo, mockPeriodRepo, mockAuditRepo, nil)
 
 	ctx := context.Background()
 	now := time.Now().UTC().Truncate(time.Second)
@@ -551,6 +656,72 @@ func TestRecipientUseCase_CreateRecipient(t *testing.T) {
 	}
 }
 
+func TestRecipientUseCase_CreateRecipient_PeriodInTransaction(t *testing.T) {
+	mockStaffRepo := &mockStaffRepository{
+		staff: map[domain.ID]*domain.Staff{
+			"staff-001": {ID: "staff-001", Name: "テスト職員", Role: domain.RoleStaff},
+		},
+	}
+	tx := &mockTransactional{}
+	mockPeriodRepo := &mockEnrollmentPeriodRepository{nextError: errors.New("disk full")}
+	mockAuditRepo := &mockAuditLogRepository{}
+	usecase := NewRecipientUseCase(tx, &mockRecipientRepository{}, mockStaffRepo, &mockStaffAssignmentRepository{}, mockPeriodRepo, mockAuditRepo, nil)
+
+	admission := time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)
+	_, err := usecase.CreateRecipient(context.Background(), CreateRecipientRequest{
+		Name:          "テスト利用者",
+		Sex:           domain.SexMale,
+		BirthDate:     time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC),
+		AdmissionDate: &admission,
+		ActorID:       "staff-001",
+	})
+
+	// The failed period write must roll back the recipient as well
+	var useCaseErr *UseCaseError
+	if !errors.As(err, &useCaseErr) || useCaseErr.Code != "CREATION_FAILED" {
+		t.Fatalf("CreateRecipient() error = %v, want CREATION_FAILED", err)
+	}
+	if tx.calls != 1 {
+		t.Errorf("WithTransaction called %d times, want 1", tx.calls)
+	}
+	if len(mockAuditRepo.logs) != 0 {
+		t.Errorf("Expected no audit log for a failed creation, got %d", len(mockAuditRepo.logs))
+	}
+}
+
+func TestRecipientUseCase_CreateRecipient_WithoutAdmissionDate(t *testing.T) {
+	mockStaffRepo := &mockStaffRepository{
+		staff: map[domain.ID]*domain.Staff{
+			"staff-001": {ID: "staff-001", Name: "テスト職員", Role: domain.RoleStaff},
+		},
+	}
+	mockPeriodRepo := &mockEnrollmentPeriodRepository{}
+	usecase := NewRecipientUseCase(&mockTransactional{}, &mockRecipientRepository{}, mockStaffRepo, &mockStaffAssignmentRepository{}, mockPeriodRepo, &mockAuditLogRepository{}, nil)
+
+	recipient, err := usecase.CreateRecipient(context.Background(), CreateRecipientRequest{
+		Name:      "テスト利用者",
+		Sex:       domain.SexMale,
+		BirthDate: time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC),
+		ActorID:   "staff-001",
+	})
+	if err != nil {
+		t.Fatalf("CreateRecipient() error = %v", err)
+	}
+
+	// Without an admission date the recipient is enrolled from today
+	today := truncateDate(time.Now())
+	if recipient.AdmissionDate == nil || !recipient.AdmissionDate.Equal(today) {
+		t.Errorf("AdmissionDate = %v, want %v", recipient.AdmissionDate, today)
+	}
+	period, err := mockPeriodRepo.GetOpenByRecipientID(context.Background(), recipient.ID)
+	if err != nil {
+		t.Fatalf("expected an open enrollment period, got %v", err)
+	}
+	if !period.AdmissionDate.Equal(today) {
+		t.Errorf("period AdmissionDate = %v, want %v", period.AdmissionDate, today)
+	}
+}
+
 func TestRecipientUseCase_CreateRecipient_ValidationError(t *testing.T) {
 	mockRecipientRepo := &mockRecipientRepository{}
 	mockStaffRepo := &mockStaffRepository{
@@ -563,9 +734,10 @@ func TestRecipientUseCase_CreateRecipient_ValidationError(t *testing.T) {
 		},
 	}
 	mockAssignmentRepo := &mockStaffAssignmentRepository{}
+	mockPeriodRepo := &mockEnrollmentPeriodRepository{}
 	mockAuditRepo := &mockAuditLogRepository{}
 
-	usecase := NewRecipientUseCase(mockRecipientRepo, mockStaffRepo, mockAssignmentRepo, mockAuditRepo)
+	usecase := NewRecipientUseCase(&mockTransactional{}, mockRecipientRepo, mockStaffRepo, mockAssignmentRepo, mockPeriodRepo, mockAuditRepo, nil)
 
 	ctx := context.Background()
 
@@ -587,6 +759,85 @@ func TestRecipientUseCase_CreateRecipient_ValidationError(t *testing.T) {
 	}
 }
 
+func TestRecipientUseCase_CreateRecipient_DisabilityClassification(t *testing.T) {
+	mockStaffRepo := &mockStaffRepository{
+		staff: map[domain.ID]*domain.Staff{
+			"staff-001": {ID: "staff-001", Name: "テスト職員", Role: domain.RoleStaff},
+		},
+	}
+	usecase := NewRecipientUseCase(&mockTransactional{}, &mockRecipientRepository{}, mockStaffRepo, &mockStaffAssignmentRepository{},
+		&mockEnrollmentPeriodRepository{}, &mockAuditLogRepository{}, nil)
+	ctx := context.Background()
+
+	validFrom := time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)
+	validUntil := time.Date(2028, 3, 31, 0, 0, 0, 0, time.UTC)
+	base := CreateRecipientRequest{
+		Name:      "テスト利用者",
+		Sex:       domain.SexFemale,
+		BirthDate: time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC),
+		ActorID:   "staff-001",
+	}
+
+	req := base
+	req.Handbooks = []domain.DisabilityHandbook{{Type: domain.HandbookTypeMental, Number: "第１２３号", Grade: "２級"}}
+	req.SupportCategory = &domain.SupportCategory{Level: 4, ValidFrom: validFrom, ValidUntil: validUntil}
+	req.IntractableDisease = &domain.IntractableDisease{Name: "潰瘍性大腸炎"}
+	recipient, err := usecase.CreateRecipient(ctx, req)
+	if err != nil {
+		t.Fatalf("CreateRecipient() error = %v", err)
+	}
+	if !recipient.HasDisabilityID {
+		t.Error("HasDisabilityID = false, want true when a handbook is registered")
+	}
+	if got := recipient.Handbooks[0]; got.Number != "第123号" || got.Grade != "2級" {
+		t.Errorf("handbook = %+v, want normalized digits", got)
+	}
+	if recipient.SupportLevelOn(time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)) != 4 {
+		t.Errorf("SupportCategory = %+v", recipient.SupportCategory)
+	}
+
+	invalid := []struct {
+		name   string
+		modify func(*CreateRecipientRequest)
+	}{
+		{"handbook without number", func(r *CreateRecipientRequest) {
+			r.Handbooks = []domain.DisabilityHandbook{{Type: domain.HandbookTypePhysical, Grade: "1級"}}
+		}},
+		{"duplicate handbook type", func(r *CreateRecipientRequest) {
+			r.Handbooks = []domain.DisabilityHandbook{
+				{Type: domain.HandbookTypePhysical, Number: "1"},
+				{Type: domain.HandbookTypePhysical, Number: "2"},
+			}
+		}},
+		{"unknown handbook type", func(r *CreateRecipientRequest) {
+			r.Handbooks = []domain.DisabilityHandbook{{Type: "other", Number: "1"}}
+		}},
+		{"support category out of range", func(r *CreateRecipientRequest) {
+			r.SupportCategory = &domain.SupportCategory{Level: 7, ValidFrom: validFrom, ValidUntil: validUntil}
+		}},
+		{"support category without validity", func(r *CreateRecipientRequest) {
+			r.SupportCategory = &domain.SupportCategory{Level: 2}
+		}},
+		{"support category ending before it starts", func(r *CreateRecipientRequest) {
+			r.SupportCategory = &domain.SupportCategory{Level: 2, ValidFrom: validUntil, ValidUntil: validFrom}
+		}},
+		{"intractable disease without name", func(r *CreateRecipientRequest) {
+			r.IntractableDisease = &domain.IntractableDisease{Name: "　"}
+		}},
+	}
+	for _, tt := range invalid {
+		t.Run(tt.name, func(t *testing.T) {
+			req := base
+			tt.modify(&req)
+			_, err := usecase.CreateRecipient(ctx, req)
+			var useCaseErr *UseCaseError
+			if !errors.As(err, &useCaseErr) || useCaseErr.Code != "VALIDATION_FAILED" {
+				t.Errorf("CreateRecipient() error = %v, want VALIDATION_FAILED", err)
+			}
+		})
+	}
+}
+
 func TestRecipientUseCase_GetRecipient(t *testing.T) {
 	now := time.Now().UTC().Truncate(time.Second)
 	existingRecipient := &domain.Recipient{
@@ -607,9 +858,10 @@ func TestRecipientUseCase_GetRecipient(t *testing.T) {
 	}
 	mockStaffRepo := &mockStaffRepository{}
 	mockAssignmentRepo := &mockStaffAssignmentRepository{}
+	mockPeriodRepo := &mockEnrollmentPeriodRepository{}
 	mockAuditRepo := &mockAuditLogRepository{}
 
-	usecase := NewRecipientUseCase(mockRecipientRepo, mockStaffRepo, mockAssignmentRepo, mockAuditRepo)
+	usecase := NewRecipientUseCase(&mockTransactional{}, mockRecipientRepo, mockStaffRepo, mockAssignmentRepo, mockPeriodRepo, mockAuditRepo, nil)
 
 	ctx := context.Background()
 
@@ -636,9 +888,10 @@ func TestRecipientUseCase_GetRecipient_NotFound(t *testing.T) {
 	}
 	mockStaffRepo := &mockStaffRepository{}
 	mockAssignmentRepo := &mockStaffAssignmentRepository{}
+	mockPeriodRepo := &mockEnrollmentPeriodRepository{}
 	mockAuditRepo := &mockAuditLogRepository{}
 
-	usecase := NewRecipientUseCase(mockRecipientRepo, mockStaffRepo, mockAssignmentRepo, mockAuditRepo)
+	usecase := NewRecipientUseCase(&mockTransactional{}, mockRecipientRepo, mockStaffRepo, mockAssignmentRepo, mockPeriodRepo, mockAuditRepo, nil)
 
 	ctx := context.Background()
 
@@ -678,9 +931,10 @@ func TestRecipientUseCase_AssignStaff(t *testing.T) {
 		},
 	}
 	mockAssignmentRepo := &mockStaffAssignmentRepository{}
+	mockPeriodRepo := &mockEnrollmentPeriodRepository{}
 	mockAuditRepo := &mockAuditLogRepository{}
 
-	usecase := NewRecipientUseCase(mockRecipientRepo, mockStaffRepo, mockAssignmentRepo, mockAuditRepo)
+	usecase := NewRecipientUseCase(&mockTransactional{}, mockRecipientRepo, mockStaffRepo, mockAssignmentRepo, mockPeriodRepo, mockAuditRepo, nil)
 
 	ctx := context.Background()
 
@@ -707,3 +961,345 @@ func TestRecipientUseCase_AssignStaff(t *testing.T) {
 		t.Errorf("Expected 1 audit log, got %d", len(mockAuditRepo.logs))
 	}
 }
+
+func TestRecipientUseCase_DischargeAndReadmit(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Second)
+	admission := time.Date(2022, 4, 1, 0, 0, 0, 0, time.UTC)
+	existingRecipient := &domain.Recipient{
+		ID:            "recipient-001",
+		Name:          "テスト利用者",
+		AdmissionDate: &admission,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+
+	mockRecipientRepo := &mockRecipientRepository{
+		recipients: map[domain.ID]*domain.Recipient{
+			"recipient-001": existingRecipient,
+		},
+	}
+	mockStaffRepo := &mockStaffRepository{
+		staff: map[domain.ID]*domain.Staff{
+			"staff-001": {ID: "staff-001", Name: "テスト職員", Role: domain.RoleStaff},
+		},
+	}
+	mockAssignmentRepo := &mockStaffAssignmentRepository{}
+	mockPeriodRepo := &mockEnrollmentPeriodRepository{
+		periods: map[domain.ID]*domain.EnrollmentPeriod{
+			"period-001": {ID: "period-001", RecipientID: "recipient-001", AdmissionDate: admission, CreatedAt: now, UpdatedAt: now},
+		},
+	}
+	mockAuditRepo := &mockAuditLogRepository{}
+	tx := &mockTransactional{}
+
+	usecase := NewRecipientUseCase(tx, mockRecipientRepo, mockStaffRepo, mockAssignmentRepo, mockPeriodRepo, mockAuditRepo, nil)
+
+	ctx := context.Background()
+
+	// Admitting an enrolled recipient again is rejected
+	_, err := usecase.AdmitRecipient(ctx, AdmitRecipientRequest{
+		RecipientID:   "recipient-001",
+		AdmissionDate: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		ActorID:       "staff-001",
+	})
+	if err != ErrAlreadyEnrolled {
+		t.Errorf("AdmitRecipient() error = %v, want ErrAlreadyEnrolled", err)
+	}
+
+	// Discharge before admission is rejected
+	_, err = usecase.DischargeRecipient(ctx, DischargeRecipientRequest{
+		RecipientID:   "recipient-001",
+		DischargeDate: time.Date(2022, 3, 31, 0, 0, 0, 0, time.UTC),
+		ActorID:       "staff-001",
+	})
+	var useCaseErr *UseCaseError
+	if !errors.As(err, &useCaseErr) || useCaseErr.Code != "VALIDATION_FAILED" {
+		t.Errorf("Expected VALIDATION_FAILED error, got %v", err)
+	}
+
+	discharge := time.Date(2023, 3, 31, 0, 0, 0, 0, time.UTC)
+	period, err := usecase.DischargeRecipient(ctx, DischargeRecipientRequest{
+		RecipientID:   "recipient-001",
+		DischargeDate: discharge,
+		ActorID:       "staff-001",
+	})
+	if err != nil {
+		t.Fatalf("DischargeRecipient() error = %v", err)
+	}
+	if period.DischargeDate == nil || !period.DischargeDate.Equal(discharge) {
+		t.Errorf("DischargeDate = %v, want %v", period.DischargeDate, discharge)
+	}
+	if existingRecipient.DischargeDate == nil {
+		t.Error("Recipient DischargeDate should mirror the closed period")
+	}
+
+	// Re-admission on or before the last discharge is rejected
+	_, err = usecase.AdmitRecipient(ctx, AdmitRecipientRequest{
+		RecipientID:   "recipient-001",
+		AdmissionDate: discharge,
+		ActorID:       "staff-001",
+	})
+	if !errors.As(err, &useCaseErr) || useCaseErr.Code != "VALIDATION_FAILED" {
+		t.Errorf("Expected VALIDATION_FAILED error, got %v", err)
+	}
+
+	readmission := time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)
+	_, err = usecase.AdmitRecipient(ctx, AdmitRecipientRequest{
+		RecipientID:   "recipient-001",
+		AdmissionDate: readmission,
+		ActorID:       "staff-001",
+	})
+	if err != nil {
+		t.Fatalf("AdmitRecipient() error = %v", err)
+	}
+
+	periods, err := usecase.GetEnrollmentPeriods(ctx, "recipient-001")
+	if err != nil {
+		t.Fatalf("GetEnrollmentPeriods() error = %v", err)
+	}
+	if len(periods) != 2 {
+		t.Fatalf("Expected 2 enrollment periods, got %d", len(periods))
+	}
+	if !periods[1].IsOpen() || !periods[1].AdmissionDate.Equal(readmission) {
+		t.Errorf("Latest period = %+v, want open period from %v", periods[1], readmission)
+	}
+	if existingRecipient.DischargeDate != nil || !existingRecipient.AdmissionDate.Equal(readmission) {
+		t.Error("Recipient dates should mirror the re-opened period")
+	}
+
+	// One audit entry per successful discharge/admission
+	if len(mockAuditRepo.logs) != 2 {
+		t.Errorf("Expected 2 audit logs, got %d", len(mockAuditRepo.logs))
+	}
+	// The period and the recipient are written in one transaction each time
+	if tx.calls != 2 {
+		t.Errorf("Expected 2 transactions, got %d", tx.calls)
+	}
+}
+
+func TestRecipientUseCase_UpdateRecipient_EditConflict(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Second)
+	stored := &domain.Recipient{
+		ID:        "recipient-001",
+		Name:      "テスト利用者",
+		Sex:       domain.SexFemale,
+		BirthDate: time.Date(1985, 5, 15, 0, 0, 0, 0, time.UTC),
+		Phone:     "090-0000-0000",
+		CreatedAt: now,
+		UpdatedAt: now,
+		Version:   2,
+	}
+	mockRecipientRepo := &mockRecipientRepository{
+		recipients: map[domain.ID]*domain.Recipient{"recipient-001": stored},
+	}
+	mockStaffRepo := &mockStaffRepository{
+		staff: map[domain.ID]*domain.Staff{
+			"staff-001": {ID: "staff-001", Name: "テスト職員", Role: domain.RoleStaff},
+		},
+	}
+	mockAuditRepo := &mockAuditLogRepository{}
+	usecase := NewRecipientUseCase(&mockTransactional{}, mockRecipientRepo, mockStaffRepo, &mockStaffAssignmentRepository{}, &mockEnrollmentPeriodRepository{}, mockAuditRepo, nil)
+
+	_, err := usecase.UpdateRecipient(context.Background(), UpdateRecipientRequest{
+		ID:        "recipient-001",
+		Name:      "テスト利用者",
+		Sex:       domain.SexFemale,
+		BirthDate: stored.BirthDate,
+		Phone:     "080-1111-1111",
+		Version:   1,
+		ActorID:   "staff-001",
+	})
+	if !errors.Is(err, ErrEditConflict) {
+		t.Fatalf("UpdateRecipient() error = %v, want ErrEditConflict", err)
+	}
+	var conflict *EditConflictError
+	if !errors.As(err, &conflict) {
+		t.Fatal("conflict error should carry the stored record")
+	}
+	if current, ok := conflict.Current.(*domain.Recipient); !ok || current.Version != 2 {
+		t.Errorf("Current = %#v, want the stored recipient", conflict.Current)
+	}
+	if mockRecipientRepo.recipients["recipient-001"].Phone != "090-0000-0000" {
+		t.Error("conflicting update must not be written")
+	}
+	if len(mockAuditRepo.logs) != 0 {
+		t.Error("a rejected update must not be audit-logged")
+	}
+}
+
+// conflictingRecipientRepository fails every update as if another user
+// saved the recipient first
+type conflictingRecipientRepository struct {
+	*mockRecipientRepository
+}
+
+func (m conflictingRecipientRepository) Update(ctx context.Context, recipient *domain.Recipient) error {
+	return fmt.Errorf("update recipient: %w", domain.ErrVersionConflict)
+}
+
+func TestRecipientUseCase_AdmitDischarge_EditConflict(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Second)
+	admission := time.Date(2022, 4, 1, 0, 0, 0, 0, time.UTC)
+	discharge := time.Date(2023, 3, 31, 0, 0, 0, 0, time.UTC)
+	recipients := map[domain.ID]*domain.Recipient{
+		"enrolled":   {ID: "enrolled", Name: "在籍中", AdmissionDate: &admission, Version: 3},
+		"discharged": {ID: "discharged", Name: "退所済", AdmissionDate: &admission, DischargeDate: &discharge, Version: 3},
+	}
+	mockStaffRepo := &mockStaffRepository{
+		staff: map[domain.ID]*domain.Staff{
+			"staff-001": {ID: "staff-001", Name: "テスト職員", Role: domain.RoleStaff},
+		},
+	}
+	mockPeriodRepo := &mockEnrollmentPeriodRepository{
+		periods: map[domain.ID]*domain.EnrollmentPeriod{
+			"period-001": {ID: "period-001", RecipientID: "enrolled", AdmissionDate: admission, CreatedAt: now, UpdatedAt: now},
+			"period-002": {ID: "period-002", RecipientID: "discharged", AdmissionDate: admission, DischargeDate: &discharge, CreatedAt: now, UpdatedAt: now},
+		},
+	}
+	mockAuditRepo := &mockAuditLogRepository{}
+	recipientRepo := conflictingRecipientRepository{&mockRecipientRepository{recipients: recipients}}
+	usecase := NewRecipientUseCase(&mockTransactional{}, recipientRepo, mockStaffRepo, &mockStaffAssignmentRepository{}, mockPeriodRepo, mockAuditRepo, nil)
+
+	ctx := context.Background()
+	_, err := usecase.DischargeRecipient(ctx, DischargeRecipientRequest{
+		RecipientID:   "enrolled",
+		DischargeDate: time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC),
+		ActorID:       "staff-001",
+	})
+	if !errors.Is(err, ErrEditConflict) {
+		t.Errorf("DischargeRecipient() error = %v, want ErrEditConflict", err)
+	}
+
+	_, err = usecase.AdmitRecipient(ctx, AdmitRecipientRequest{
+		RecipientID:   "discharged",
+		AdmissionDate: time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC),
+		ActorID:       "staff-001",
+	})
+	if !errors.Is(err, ErrEditConflict) {
+		t.Errorf("AdmitRecipient() error = %v, want ErrEditConflict", err)
+	}
+	var conflict *EditConflictError
+	if !errors.As(err, &conflict) || conflict.Current.(*domain.Recipient).Version != 3 {
+		t.Error("conflict error should carry the stored recipient")
+	}
+	if len(mockAuditRepo.logs) != 0 {
+		t.Errorf("Expected no audit logs for conflicting changes, got %d", len(mockAuditRepo.logs))
+	}
+}
+
+func TestRecipientUseCase_UpdateRecipient_KeepsEnrollmentHistory(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Second)
+	admission := time.Date(2020, 4, 1, 0, 0, 0, 0, time.UTC)
+	discharge := time.Date(2021, 3, 31, 0, 0, 0, 0, time.UTC)
+	existingRecipient := &domain.Recipient{
+		ID:            "recipient-001",
+		Name:          "テスト利用者",
+		Sex:           domain.SexFemale,
+		BirthDate:     time.Date(1985, 5, 15, 0, 0, 0, 0, time.UTC),
+		AdmissionDate: &admission,
+		DischargeDate: &discharge,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+
+	mockRecipientRepo := &mockRecipientRepository{
+		recipients: map[domain.ID]*domain.Recipient{
+			"recipient-001": existingRecipient,
+		},
+	}
+	mockStaffRepo := &mockStaffRepository{
+		staff: map[domain.ID]*domain.Staff{
+			"staff-001": {ID: "staff-001", Name: "テスト職員", Role: domain.RoleStaff},
+		},
+	}
+	mockAssignmentRepo := &mockStaffAssignmentRepository{}
+	mockPeriodRepo := &mockEnrollmentPeriodRepository{
+		periods: map[domain.ID]*domain.EnrollmentPeriod{
+			"period-001": {ID: "period-001", RecipientID: "recipient-001", AdmissionDate: admission, DischargeDate: &discharge, CreatedAt: now, UpdatedAt: now},
+		},
+	}
+	mockAuditRepo := &mockAuditLogRepository{}
+
+	tx := &mockTransactional{}
+	usecase := NewRecipientUseCase(tx, mockRecipientRepo, mockStaffRepo, mockAssignmentRepo, mockPeriodRepo, mockAuditRepo, nil)
+
+	ctx := context.Background()
+
+	// Editing the admission date to after the last discharge records a re-admission
+	readmission := time.Date(2022, 4, 1, 0, 0, 0, 0, time.UTC)
+	_, err := usecase.UpdateRecipient(ctx, UpdateRecipientRequest{
+		ID:            "recipient-001",
+		Name:          "テスト利用者",
+		Sex:           domain.SexFemale,
+		BirthDate:     existingRecipient.BirthDate,
+		AdmissionDate: &readmission,
+		ActorID:       "staff-001",
+	})
+	if err != nil {
+		t.Fatalf("UpdateRecipient() error = %v", err)
+	}
+	if tx.calls != 1 {
+		t.Errorf("WithTransaction called %d times, want the recipient and period written together", tx.calls)
+	}
+
+	periods, _ := mockPeriodRepo.GetByRecipientID(ctx, "recipient-001")
+	if len(periods) != 2 {
+		t.Fatalf("Expected 2 enrollment periods, got %d", len(periods))
+	}
+	if periods[0].DischargeDate == nil || !periods[0].DischargeDate.Equal(discharge) {
+		t.Error("Previous enrollment period should be kept unchanged")
+	}
+
+	// Enrolled on a date within the re-opened period
+	enrolled, err := usecase.GetRecipientsEnrolledOn(ctx, time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("GetRecipientsEnrolledOn() error = %v", err)
+	}
+	if len(enrolled) != 1 {
+		t.Errorf("Expected 1 enrolled recipient, got %d", len(enrolled))
+	}
+
+	// Discharge date before admission date is rejected
+	invalidDischarge := time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC)
+	_, err = usecase.UpdateRecipient(ctx, UpdateRecipientRequest{
+		ID:            "recipient-001",
+		Name:          "テスト利用者",
+		Sex:           domain.SexFemale,
+		BirthDate:     existingRecipient.BirthDate,
+		AdmissionDate: &readmission,
+		DischargeDate: &invalidDischarge,
+		ActorID:       "staff-001",
+	})
+	var useCaseErr *UseCaseError
+	if !errors.As(err, &useCaseErr) || useCaseErr.Code != "VALIDATION_FAILED" {
+		t.Errorf("Expected VALIDATION_FAILED error, got %v", err)
+	}
+}
+
+func TestRecipientUseCase_GetRecipientsEnrolledOn_PagesPastOnePage(t *testing.T) {
+	admission := time.Date(2022, 4, 1, 0, 0, 0, 0, time.UTC)
+	mockRecipientRepo := &mockRecipientRepository{recipients: map[domain.ID]*domain.Recipient{}}
+	total := enrolledPageSize*2 + 1
+	for i := 0; i < total; i++ {
+		id := domain.ID(fmt.Sprintf("recipient-%04d", i))
+		mockRecipientRepo.recipients[id] = &domain.Recipient{ID: id, Name: "在籍者", AdmissionDate: &admission}
+	}
+
+	usecase := NewRecipientUseCase(&mockTransactional{}, mockRecipientRepo, &mockStaffRepository{}, &mockStaffAssignmentRepository{}, &mockEnrollmentPeriodRepository{}, &mockAuditLogRepository{}, nil)
+
+	enrolled, err := usecase.GetRecipientsEnrolledOn(context.Background(), time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("GetRecipientsEnrolledOn() error = %v", err)
+	}
+	if len(enrolled) != total {
+		t.Errorf("Expected %d enrolled recipients, got %d", total, len(enrolled))
+	}
+
+	active, err := usecase.GetActiveRecipients(context.Background())
+	if err != nil {
+		t.Fatalf("GetActiveRecipients() error = %v", err)
+	}
+	if len(active) != total {
+		t.Errorf("Expected %d active recipients, got %d", total, len(active))
+	}
+}
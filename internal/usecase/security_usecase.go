@@ -0,0 +1,332 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"shien-system/internal/domain"
+)
+
+// Limits of the security dashboard
+const (
+	securityHistoryLimit       = 100
+	securityFailureLimit       = 200
+	securityFailureWindow      = 24 * time.Hour
+	maxManualLockoutMinutes    = 30 * 24 * 60
+	maxSecurityReasonLength    = 500
+	securityAuditTarget        = "SECURITY"
+	defaultPatternBlockMinutes = 24 * 60
+)
+
+// securityUseCase implements SecurityUseCase interface
+type securityUseCase struct {
+	lockoutRepo domain.AccountLockoutRepository
+	attemptRepo domain.LoginAttemptRepository
+	patternRepo domain.AttackPatternRepository
+	staffRepo   domain.StaffRepository
+	auditRepo   domain.AuditLogRepository
+
+	useCaseLogger
+}
+
+// NewSecurityUseCase creates a new security dashboard usecase
+func NewSecurityUseCase(
+	lockoutRepo domain.AccountLockoutRepository,
+	attemptRepo domain.LoginAttemptRepository,
+	patternRepo domain.AttackPatternRepository,
+	staffRepo domain.StaffRepository,
+	auditRepo domain.AuditLogRepository,
+) SecurityUseCase {
+	return &securityUseCase{
+		lockoutRepo: lockoutRepo,
+		attemptRepo: attemptRepo,
+		patternRepo: patternRepo,
+		staffRepo:   staffRepo,
+		auditRepo:   auditRepo,
+	}
+}
+
+// GetDashboard gathers the security state for administrators
+func (uc *securityUseCase) GetDashboard(ctx context.Context, actorID domain.ID) (*SecurityDashboard, error) {
+	if _, err := verifyAdmin(ctx, uc.staffRepo, actorID); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	dashboard := &SecurityDashboard{GeneratedAt: now}
+
+	var err error
+	if dashboard.ActiveLockouts, err = uc.lockoutRepo.GetActiveLockouts(ctx); err != nil {
+		return nil, uc.fetchError("アクティブなロックアウト", err)
+	}
+	if dashboard.LockoutHistory, err = uc.lockoutRepo.List(ctx, securityHistoryLimit, 0); err != nil {
+		return nil, uc.fetchError("ロックアウト履歴", err)
+	}
+	if dashboard.RecentFailures, err = uc.attemptRepo.GetRecentFailures(ctx, now.Add(-securityFailureWindow), securityFailureLimit); err != nil {
+		return nil, uc.fetchError("ログイン失敗履歴", err)
+	}
+	if dashboard.AttackPatterns, err = uc.patternRepo.List(ctx, securityHistoryLimit, 0); err != nil {
+		return nil, uc.fetchError("攻撃パターン", err)
+	}
+	if dashboard.Events, err = uc.auditRepo.GetByTarget(ctx, securityAuditTarget, securityHistoryLimit, 0); err != nil {
+		return nil, uc.fetchError("セキュリティイベント", err)
+	}
+
+	return dashboard, nil
+}
+
+// UnlockLockout lifts an active lockout
+func (uc *securityUseCase) UnlockLockout(ctx context.Context, req UnlockLockoutRequest) error {
+	reason := strings.TrimSpace(req.Reason)
+	if errs := validateSecurityReason(reason); len(errs) > 0 {
+		return securityValidationError(errs)
+	}
+
+	actor, err := verifyAdmin(ctx, uc.staffRepo, req.ActorID)
+	if err != nil {
+		return err
+	}
+
+	lockout, err := uc.lockoutRepo.GetByID(ctx, req.LockoutID)
+	if err != nil {
+		if err == domain.ErrNotFound {
+			return ErrLockoutNotFound
+		}
+		return uc.fetchError("ロックアウト", err)
+	}
+
+	if lockout.UnlockedAt != nil {
+		return securityValidationError([]string{"このロックアウトは既に解除されています"})
+	}
+
+	now := time.Now()
+	if err := uc.lockoutRepo.Unlock(ctx, lockout.ID, now); err != nil {
+		return &UseCaseError{
+			Code:    "UPDATE_FAILED",
+			Message: "ロックアウトの解除に失敗しました",
+			Cause:   err,
+		}
+	}
+
+	uc.logAction(ctx, actor.ID, "MANUAL_UNLOCK_PERFORMED", lockout.IPAddress, now,
+		fmt.Sprintf("Manual unlock of %s: %s", describeLockout(lockout), reason))
+
+	return nil
+}
+
+// CreateManualLockout locks a username and/or IP address
+func (uc *securityUseCase) CreateManualLockout(ctx context.Context, req ManualLockoutRequest) (*domain.AccountLockout, error) {
+	username := strings.TrimSpace(req.Username)
+	ipAddress := strings.TrimSpace(req.IPAddress)
+	reason := strings.TrimSpace(req.Reason)
+
+	var errs []string
+	if username == "" && ipAddress == "" {
+		errs = append(errs, "ユーザー名またはIPアドレスを入力してください")
+	}
+	if ipAddress != "" && net.ParseIP(ipAddress) == nil {
+		errs = append(errs, "IPアドレスの形式が正しくありません")
+	}
+	errs = append(errs, validateLockoutDuration(req.DurationMinutes)...)
+	errs = append(errs, validateSecurityReason(reason)...)
+	if len(errs) > 0 {
+		return nil, securityValidationError(errs)
+	}
+
+	actor, err := verifyAdmin(ctx, uc.staffRepo, req.ActorID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	lockout := &domain.AccountLockout{
+		ID:          uuid.New().String(),
+		Username:    username,
+		IPAddress:   ipAddress,
+		LockoutType: domain.LockoutTypeManual,
+		LockedAt:    now,
+		Reason:      reason,
+		Duration:    req.DurationMinutes * 60,
+	}
+
+	if err := uc.lockoutRepo.Create(ctx, lockout); err != nil {
+		return nil, &UseCaseError{
+			Code:    "CREATE_FAILED",
+			Message: "ロックアウトの作成に失敗しました",
+			Cause:   err,
+		}
+	}
+
+	uc.logAction(ctx, actor.ID, "MANUAL_LOCKOUT_CREATED", ipAddress, now,
+		fmt.Sprintf("Manual lockout of %s for %d minutes: %s", describeLockout(lockout), req.DurationMinutes, reason))
+
+	return lockout, nil
+}
+
+// UpdateAttackPatternStatus moves an attack pattern from active to blocked or resolved,
+// or from blocked to resolved
+func (uc *securityUseCase) UpdateAttackPatternStatus(ctx context.Context, req UpdateAttackPatternStatusRequest) error {
+	reason := strings.TrimSpace(req.Reason)
+
+	var errs []string
+	if req.Status != domain.AttackPatternStatusBlocked && req.Status != domain.AttackPatternStatusResolved {
+		errs = append(errs, "状態は blocked または resolved を指定してください")
+	}
+	durationMinutes := req.DurationMinutes
+	if req.Status == domain.AttackPatternStatusBlocked {
+		if durationMinutes == 0 {
+			durationMinutes = defaultPatternBlockMinutes
+		}
+		errs = append(errs, validateLockoutDuration(durationMinutes)...)
+	}
+	errs = append(errs, validateSecurityReason(reason)...)
+	if len(errs) > 0 {
+		return securityValidationError(errs)
+	}
+
+	actor, err := verifyAdmin(ctx, uc.staffRepo, req.ActorID)
+	if err != nil {
+		return err
+	}
+
+	pattern, err := uc.patternRepo.GetByID(ctx, req.PatternID)
+	if err != nil {
+		if err == domain.ErrNotFound {
+			return ErrAttackPatternNotFound
+		}
+		return uc.fetchError("攻撃パターン", err)
+	}
+
+	allowed := pattern.Status == domain.AttackPatternStatusActive ||
+		(pattern.Status == domain.AttackPatternStatusBlocked && req.Status == domain.AttackPatternStatusResolved)
+	if !allowed {
+		return ErrInvalidPatternStatus
+	}
+
+	now := time.Now()
+	if req.Status == domain.AttackPatternStatusBlocked {
+		// Lock out every source so the pattern stops at the login screen
+		for _, ip := range strings.Split(pattern.SourceIP, ",") {
+			ip = strings.TrimSpace(ip)
+			if ip == "" {
+				continue
+			}
+			lockout := &domain.AccountLockout{
+				ID:          uuid.New().String(),
+				IPAddress:   ip,
+				LockoutType: domain.LockoutTypeManual,
+				LockedAt:    now,
+				Reason:      fmt.Sprintf("Blocked attack pattern %s: %s", pattern.ID, reason),
+				Duration:    durationMinutes * 60,
+			}
+			if err := uc.lockoutRepo.Create(ctx, lockout); err != nil {
+				return &UseCaseError{
+					Code:    "CREATE_FAILED",
+					Message: "ロックアウトの作成に失敗しました",
+					Cause:   err,
+				}
+			}
+		}
+	}
+
+	if err := uc.patternRepo.UpdateStatus(ctx, pattern.ID, req.Status); err != nil {
+		return &UseCaseError{
+			Code:    "UPDATE_FAILED",
+			Message: "攻撃パターンの更新に失敗しました",
+			Cause:   err,
+		}
+	}
+
+	action := "ATTACK_PATTERN_RESOLVED"
+	if req.Status == domain.AttackPatternStatusBlocked {
+		action = "ATTACK_PATTERN_BLOCKED"
+	}
+	uc.logAction(ctx, actor.ID, action, pattern.SourceIP, now,
+		fmt.Sprintf("%s pattern %s (%s -> %s): %s", pattern.PatternType, pattern.ID, pattern.Status, req.Status, reason))
+
+	return nil
+}
+
+// Helper methods
+
+func validateSecurityReason(reason string) []string {
+	if reason == "" {
+		return []string{"理由を入力してください"}
+	}
+	if len([]rune(reason)) > maxSecurityReasonLength {
+		return []string{fmt.Sprintf("理由は%d文字以内で入力してください", maxSecurityReasonLength)}
+	}
+	return nil
+}
+
+func validateLockoutDuration(minutes int) []string {
+	if minutes < 1 || minutes > maxManualLockoutMinutes {
+		return []string{fmt.Sprintf("ロック時間は1〜%d分で指定してください", maxManualLockoutMinutes)}
+	}
+	return nil
+}
+
+func securityValidationError(errs []string) error {
+	return &UseCaseError{
+		Code:    "VALIDATION_FAILED",
+		Message: "入力値が不正です",
+		Cause:   fmt.Errorf("validation errors: %s", strings.Join(errs, ", ")),
+	}
+}
+
+// describeLockout names the subject of a lockout for audit details
+func describeLockout(lockout *domain.AccountLockout) string {
+	switch {
+	case lockout.Username != "" && lockout.IPAddress != "":
+		return fmt.Sprintf("%s (%s)", lockout.Username, lockout.IPAddress)
+	case lockout.Username != "":
+		return lockout.Username
+	default:
+		return lockout.IPAddress
+	}
+}
+
+func (uc *securityUseCase) fetchError(what string, err error) error {
+	return &UseCaseError{
+		Code:    "FETCH_FAILED",
+		Message: what + "の取得に失敗しました",
+		Cause:   err,
+	}
+}
+
+// logAction records a security event in the audit log. The IP is the subject of the
+// event when there is one, otherwise the client IP.
+func (uc *securityUseCase) logAction(ctx context.Context, actorID domain.ID, action, ipAddress string, at time.Time, details string) {
+	if ipAddress == "" {
+		ipAddress = uc.getClientIP(ctx)
+	}
+
+	auditLog := &domain.AuditLog{
+		ID:      domain.ID(uuid.New().String()),
+		ActorID: actorID,
+		Action:  action,
+		Target:  securityAuditTarget,
+		At:      at,
+		IP:      ipAddress,
+		Details: details,
+	}
+
+	err := uc.auditRepo.Create(ctx, auditLog)
+	if err != nil {
+		// Log audit failure but don't fail the operation
+		uc.log().Warn("failed to write audit log", "action", auditLog.Action, "error", err)
+	}
+}
+
+func (uc *securityUseCase) getClientIP(ctx context.Context) string {
+	if ip := ctx.Value(ContextKeyClientIP); ip != nil {
+		if clientIP, ok := ip.(string); ok {
+			return clientIP
+		}
+	}
+	return "unknown"
+}
@@ -0,0 +1,163 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"shien-system/internal/domain"
+)
+
+func newTestSecurityUseCase() (SecurityUseCase, *MockAccountLockoutRepository, *MockAttackPatternRepository, *mockAuditLogRepository) {
+	lockoutRepo := NewMockAccountLockoutRepository()
+	patternRepo := NewMockAttackPatternRepository()
+	auditRepo := &mockAuditLogRepository{}
+	staffRepo := &mockStaffRepository{staff: map[domain.ID]*domain.Staff{
+		"admin-001": {ID: "admin-001", Name: "管理者", Role: domain.RoleAdmin},
+		"staff-001": {ID: "staff-001", Name: "職員", Role: domain.RoleStaff},
+	}}
+
+	uc := NewSecurityUseCase(lockoutRepo, NewMockLoginAttemptRepository(), patternRepo, staffRepo, auditRepo)
+	return uc, lockoutRepo, patternRepo, auditRepo
+}
+
+func TestSecurityUseCase_ManualLockoutAndUnlock(t *testing.T) {
+	uc, lockoutRepo, _, auditRepo := newTestSecurityUseCase()
+	ctx := context.Background()
+
+	// Only administrators can use the dashboard
+	if _, err := uc.GetDashboard(ctx, "staff-001"); err != ErrUnauthorized {
+		t.Fatalf("GetDashboard() by staff = %v, want ErrUnauthorized", err)
+	}
+
+	_, err := uc.CreateManualLockout(ctx, ManualLockoutRequest{
+		IPAddress:       "not-an-ip",
+		Reason:          "調査のため",
+		DurationMinutes: 60,
+		ActorID:         "admin-001",
+	})
+	if useCaseErr, ok := err.(*UseCaseError); !ok || useCaseErr.Code != "VALIDATION_FAILED" {
+		t.Fatalf("CreateManualLockout() invalid IP = %v", err)
+	}
+
+	lockout, err := uc.CreateManualLockout(ctx, ManualLockoutRequest{
+		Username:        "tanaka",
+		Reason:          "退職手続き中のため",
+		DurationMinutes: 60,
+		ActorID:         "admin-001",
+	})
+	if err != nil {
+		t.Fatalf("CreateManualLockout() error = %v", err)
+	}
+	if lockout.LockoutType != domain.LockoutTypeManual || lockout.Duration != 3600 {
+		t.Errorf("unexpected lockout: %+v", lockout)
+	}
+
+	dashboard, err := uc.GetDashboard(ctx, "admin-001")
+	if err != nil {
+		t.Fatalf("GetDashboard() error = %v", err)
+	}
+	if len(dashboard.ActiveLockouts) != 1 || len(dashboard.Events) != 1 {
+		t.Errorf("dashboard lockouts = %d, events = %d", len(dashboard.ActiveLockouts), len(dashboard.Events))
+	}
+
+	if err := uc.UnlockLockout(ctx, UnlockLockoutRequest{LockoutID: lockout.ID, ActorID: "admin-001"}); err == nil {
+		t.Error("UnlockLockout() without a reason should fail")
+	}
+	if err := uc.UnlockLockout(ctx, UnlockLockoutRequest{LockoutID: lockout.ID, Reason: "本人確認済み", ActorID: "admin-001"}); err != nil {
+		t.Fatalf("UnlockLockout() error = %v", err)
+	}
+	if lockoutRepo.lockouts[lockout.ID].UnlockedAt == nil {
+		t.Error("lockout should be unlocked")
+	}
+	if err := uc.UnlockLockout(ctx, UnlockLockoutRequest{LockoutID: lockout.ID, Reason: "再実行", ActorID: "admin-001"}); err == nil {
+		t.Error("unlocking twice should fail")
+	}
+	if err := uc.UnlockLockout(ctx, UnlockLockoutRequest{LockoutID: "missing", Reason: "再実行", ActorID: "admin-001"}); err != ErrLockoutNotFound {
+		t.Errorf("UnlockLockout() missing = %v, want ErrLockoutNotFound", err)
+	}
+
+	if len(auditRepo.logs) != 2 || auditRepo.logs[1].Action != "MANUAL_UNLOCK_PERFORMED" || auditRepo.logs[1].Target != "SECURITY" {
+		t.Errorf("unexpected audit logs: %+v", auditRepo.logs)
+	}
+}
+
+func TestSecurityUseCase_UpdateAttackPatternStatus(t *testing.T) {
+	uc, lockoutRepo, patternRepo, auditRepo := newTestSecurityUseCase()
+	ctx := context.Background()
+	now := time.Now()
+
+	pattern := &domain.AttackPattern{
+		ID:              "pattern-001",
+		PatternType:     domain.AttackPatternTypeDistributed,
+		SourceIP:        "192.0.2.1,192.0.2.2",
+		TargetUsernames: []string{"admin"},
+		AttemptsCount:   6,
+		FirstDetectedAt: now,
+		LastDetectedAt:  now,
+		Severity:        domain.AttackSeverityMedium,
+		Status:          domain.AttackPatternStatusActive,
+	}
+	patternRepo.Create(ctx, pattern)
+
+	err := uc.UpdateAttackPatternStatus(ctx, UpdateAttackPatternStatusRequest{
+		PatternID: pattern.ID,
+		Status:    domain.AttackPatternStatusBlocked,
+		Reason:    "外部からの総当たり",
+		ActorID:   "admin-001",
+	})
+	if err != nil {
+		t.Fatalf("UpdateAttackPatternStatus() block error = %v", err)
+	}
+	if pattern.Status != domain.AttackPatternStatusBlocked {
+		t.Errorf("status = %s, want blocked", pattern.Status)
+	}
+
+	// Every source IP is locked out for the default duration
+	if len(lockoutRepo.lockouts) != 2 {
+		t.Fatalf("expected 2 IP lockouts, got %d", len(lockoutRepo.lockouts))
+	}
+	for _, lockout := range lockoutRepo.lockouts {
+		if lockout.Username != "" || lockout.Duration != defaultPatternBlockMinutes*60 {
+			t.Errorf("unexpected lockout: %+v", lockout)
+		}
+	}
+
+	// Blocked patterns can only be resolved
+	err = uc.UpdateAttackPatternStatus(ctx, UpdateAttackPatternStatusRequest{
+		PatternID: pattern.ID,
+		Status:    domain.AttackPatternStatusBlocked,
+		Reason:    "再実行",
+		ActorID:   "admin-001",
+	})
+	if err != ErrInvalidPatternStatus {
+		t.Errorf("blocking twice = %v, want ErrInvalidPatternStatus", err)
+	}
+
+	err = uc.UpdateAttackPatternStatus(ctx, UpdateAttackPatternStatusRequest{
+		PatternID: pattern.ID,
+		Status:    domain.AttackPatternStatusResolved,
+		Reason:    "収束を確認",
+		ActorID:   "admin-001",
+	})
+	if err != nil {
+		t.Fatalf("UpdateAttackPatternStatus() resolve error = %v", err)
+	}
+	if pattern.Status != domain.AttackPatternStatusResolved {
+		t.Errorf("status = %s, want resolved", pattern.Status)
+	}
+
+	if len(auditRepo.logs) != 2 || auditRepo.logs[0].Action != "ATTACK_PATTERN_BLOCKED" || auditRepo.logs[1].Action != "ATTACK_PATTERN_RESOLVED" {
+		t.Errorf("unexpected audit logs: %+v", auditRepo.logs)
+	}
+
+	err = uc.UpdateAttackPatternStatus(ctx, UpdateAttackPatternStatusRequest{
+		PatternID: pattern.ID,
+		Status:    domain.AttackPatternStatusResolved,
+		Reason:    "確認",
+		ActorID:   "staff-001",
+	})
+	if err != ErrUnauthorized {
+		t.Errorf("UpdateAttackPatternStatus() by staff = %v, want ErrUnauthorized", err)
+	}
+}
@@ -0,0 +1,264 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"shien-system/internal/domain"
+)
+
+// defaultSelfRevokeReason is recorded when staff end one of their own sessions
+const defaultSelfRevokeReason = "本人による終了"
+
+// sessionUseCase implements SessionUseCase interface
+type sessionUseCase struct {
+	registry  SessionRegistry
+	staffRepo domain.StaffRepository
+	auditRepo domain.AuditLogRepository
+
+	useCaseLogger
+}
+
+// NewSessionUseCase creates a new session management usecase
+func NewSessionUseCase(
+	registry SessionRegistry,
+	staffRepo domain.StaffRepository,
+	auditRepo domain.AuditLogRepository,
+) SessionUseCase {
+	return &sessionUseCase{
+		registry:  registry,
+		staffRepo: staffRepo,
+		auditRepo: auditRepo,
+	}
+}
+
+// ListSessions returns the actor's own sessions, or all sessions for administrators
+func (uc *sessionUseCase) ListSessions(ctx context.Context, req ListSessionsRequest) ([]*SessionSummary, error) {
+	actor, err := uc.getActor(ctx, req.ActorID)
+	if err != nil {
+		return nil, err
+	}
+
+	userID := req.UserID
+	if actor.Role != domain.RoleAdmin {
+		if userID != "" && userID != actor.ID {
+			return nil, ErrUnauthorized
+		}
+		userID = actor.ID
+	}
+
+	sessions, err := uc.registry.ListSessions(ctx, userID)
+	if err != nil {
+		return nil, uc.fetchError(err)
+	}
+
+	staffCache := map[domain.ID]*domain.Staff{actor.ID: actor}
+	summaries := make([]*SessionSummary, 0, len(sessions))
+	for _, session := range sessions {
+		summary := &SessionSummary{
+			ID:             session.ID,
+			UserID:         session.UserID,
+			UserRole:       session.UserRole,
+			CreatedAt:      session.CreatedAt,
+			LastAccessedAt: session.LastAccessedAt,
+			ExpiresAt:      session.ExpiresAt,
+			ClientIP:       session.ClientIP,
+			UserAgent:      session.UserAgent,
+			Current:        req.CurrentSessionID != "" && session.ID == req.CurrentSessionID,
+		}
+
+		staff, cached := staffCache[session.UserID]
+		if !cached {
+			// A deleted staff member still shows up by ID
+			staff, _ = uc.staffRepo.GetByID(ctx, session.UserID)
+			staffCache[session.UserID] = staff
+		}
+		if staff != nil {
+			summary.UserName = staff.Name
+		}
+
+		summaries = append(summaries, summary)
+	}
+
+	return summaries, nil
+}
+
+// RevokeSession ends a single session
+func (uc *sessionUseCase) RevokeSession(ctx context.Context, req RevokeSessionRequest) error {
+	if strings.TrimSpace(req.SessionID) == "" {
+		return ErrSessionNotFound
+	}
+
+	actor, err := uc.getActor(ctx, req.ActorID)
+	if err != nil {
+		return err
+	}
+
+	owner, err := uc.findSessionOwner(ctx, actor, req.SessionID)
+	if err != nil {
+		return err
+	}
+
+	reason, err := uc.revokeReason(actor, owner, req.Reason)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.registry.RevokeSession(ctx, req.SessionID, reason); err != nil {
+		if err == ErrSessionNotFound {
+			return err
+		}
+		return uc.revokeError(err)
+	}
+
+	uc.logAction(ctx, actor.ID, "SESSION_REVOKED",
+		fmt.Sprintf("Session %s of user %s revoked: %s", shortSessionID(req.SessionID), owner, reason))
+
+	return nil
+}
+
+// RevokeAllSessions logs a user out everywhere except KeepSessionID
+func (uc *sessionUseCase) RevokeAllSessions(ctx context.Context, req RevokeAllSessionsRequest) (int, error) {
+	actor, err := uc.getActor(ctx, req.ActorID)
+	if err != nil {
+		return 0, err
+	}
+
+	userID := req.UserID
+	if userID == "" {
+		userID = actor.ID
+	}
+	if userID != actor.ID && actor.Role != domain.RoleAdmin {
+		return 0, ErrUnauthorized
+	}
+
+	reason, err := uc.revokeReason(actor, userID, req.Reason)
+	if err != nil {
+		return 0, err
+	}
+
+	count, err := uc.registry.RevokeUserSessions(ctx, userID, req.KeepSessionID, reason)
+	if err != nil {
+		return 0, uc.revokeError(err)
+	}
+
+	details := fmt.Sprintf("%d session(s) of user %s revoked: %s", count, userID, reason)
+	if req.KeepSessionID != "" {
+		details = fmt.Sprintf("%d session(s) of user %s revoked, kept %s: %s",
+			count, userID, shortSessionID(req.KeepSessionID), reason)
+	}
+	uc.logAction(ctx, actor.ID, "SESSIONS_REVOKED_ALL", details)
+
+	return count, nil
+}
+
+// Helper methods
+
+// findSessionOwner returns the user of an active session. Staff can only see
+// their own sessions, so another user's session is reported as not found.
+func (uc *sessionUseCase) findSessionOwner(ctx context.Context, actor *domain.Staff, sessionID string) (domain.ID, error) {
+	userID := actor.ID
+	if actor.Role == domain.RoleAdmin {
+		userID = ""
+	}
+
+	sessions, err := uc.registry.ListSessions(ctx, userID)
+	if err != nil {
+		return "", uc.fetchError(err)
+	}
+
+	for _, session := range sessions {
+		if session.ID == sessionID {
+			return session.UserID, nil
+		}
+	}
+
+	return "", ErrSessionNotFound
+}
+
+// revokeReason requires a reason when ending another user's sessions and
+// falls back to a default when staff end their own
+func (uc *sessionUseCase) revokeReason(actor *domain.Staff, owner domain.ID, reason string) (string, error) {
+	reason = strings.TrimSpace(reason)
+	if owner == actor.ID && reason == "" {
+		return defaultSelfRevokeReason, nil
+	}
+
+	if errs := validateSecurityReason(reason); len(errs) > 0 {
+		return "", securityValidationError(errs)
+	}
+
+	return reason, nil
+}
+
+// shortSessionID keeps session identifiers out of the audit log in full
+func shortSessionID(sessionID string) string {
+	if len(sessionID) <= 8 {
+		return sessionID
+	}
+	return sessionID[:8] + "…"
+}
+
+func (uc *sessionUseCase) fetchError(err error) error {
+	return &UseCaseError{
+		Code:    "FETCH_FAILED",
+		Message: "セッション一覧の取得に失敗しました",
+		Cause:   err,
+	}
+}
+
+func (uc *sessionUseCase) revokeError(err error) error {
+	return &UseCaseError{
+		Code:    "REVOKE_FAILED",
+		Message: "セッションの終了に失敗しました",
+		Cause:   err,
+	}
+}
+
+// getActor loads the staff member performing the operation
+func (uc *sessionUseCase) getActor(ctx context.Context, actorID domain.ID) (*domain.Staff, error) {
+	actor, err := uc.staffRepo.GetByID(ctx, actorID)
+	if err != nil {
+		if err == domain.ErrNotFound {
+			return nil, ErrUnauthorized
+		}
+		return nil, &UseCaseError{
+			Code:    "INTERNAL_ERROR",
+			Message: "内部エラーが発生しました",
+			Cause:   err,
+		}
+	}
+
+	return actor, nil
+}
+
+// logAction records a session change as a security event
+func (uc *sessionUseCase) logAction(ctx context.Context, actorID domain.ID, action, details string) {
+	auditLog := &domain.AuditLog{
+		ID:      domain.ID(uuid.New().String()),
+		ActorID: actorID,
+		Action:  action,
+		Target:  securityAuditTarget,
+		At:      time.Now(),
+		IP:      uc.getClientIP(ctx),
+		Details: details,
+	}
+
+	err := uc.auditRepo.Create(ctx, auditLog)
+	if err != nil {
+		// Log audit failure but don't fail the operation
+		uc.log().Warn("failed to write audit log", "action", auditLog.Action, "error", err)
+	}
+}
+
+func (uc *sessionUseCase) getClientIP(ctx context.Context) string {
+	if ip := ctx.Value(ContextKeyClientIP); ip != nil {
+		if clientIP, ok := ip.(string); ok {
+			return clientIP
+		}
+	}
+	return "unknown"
+}
@@ -0,0 +1,158 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"shien-system/internal/domain"
+)
+
+// fakeSessionRegistry keeps sessions in a slice for SessionUseCase tests
+type fakeSessionRegistry struct {
+	sessions []*Session
+}
+
+func (f *fakeSessionRegistry) ListSessions(ctx context.Context, userID domain.ID) ([]*Session, error) {
+	var result []*Session
+	for _, session := range f.sessions {
+		if session.IsActive && (userID == "" || session.UserID == userID) {
+			result = append(result, session)
+		}
+	}
+	return result, nil
+}
+
+func (f *fakeSessionRegistry) RevokeSession(ctx context.Context, sessionID, reason string) error {
+	for _, session := range f.sessions {
+		if session.ID == sessionID && session.IsActive {
+			session.IsActive = false
+			session.InvalidationReason = reason
+			return nil
+		}
+	}
+	return ErrSessionNotFound
+}
+
+func (f *fakeSessionRegistry) RevokeUserSessions(ctx context.Context, userID domain.ID, keepSessionID, reason string) (int, error) {
+	count := 0
+	for _, session := range f.sessions {
+		if session.UserID == userID && session.ID != keepSessionID && session.IsActive {
+			session.IsActive = false
+			session.InvalidationReason = reason
+			count++
+		}
+	}
+	return count, nil
+}
+
+func newTestSessionUseCase() (SessionUseCase, *fakeSessionRegistry, *mockAuditLogRepository) {
+	now := time.Now()
+	registry := &fakeSessionRegistry{sessions: []*Session{
+		{ID: "admin-session-1", UserID: "admin-001", UserRole: domain.RoleAdmin, CreatedAt: now, LastAccessedAt: now, IsActive: true},
+		{ID: "staff-session-1", UserID: "staff-001", UserRole: domain.RoleStaff, CreatedAt: now, LastAccessedAt: now, IsActive: true},
+		{ID: "staff-session-2", UserID: "staff-001", UserRole: domain.RoleStaff, CreatedAt: now, LastAccessedAt: now, IsActive: true},
+		{ID: "staff-session-3", UserID: "staff-002", UserRole: domain.RoleStaff, CreatedAt: now, LastAccessedAt: now, IsActive: true},
+	}}
+	auditRepo := &mockAuditLogRepository{}
+	staffRepo := &mockStaffRepository{staff: map[domain.ID]*domain.Staff{
+		"admin-001": {ID: "admin-001", Name: "管理者", Role: domain.RoleAdmin},
+		"staff-001": {ID: "staff-001", Name: "職員", Role: domain.RoleStaff},
+		"staff-002": {ID: "staff-002", Name: "職員2", Role: domain.RoleStaff},
+	}}
+
+	return NewSessionUseCase(registry, staffRepo, auditRepo), registry, auditRepo
+}
+
+func TestSessionUseCase_ListSessions(t *testing.T) {
+	uc, _, _ := newTestSessionUseCase()
+	ctx := context.Background()
+
+	all, err := uc.ListSessions(ctx, ListSessionsRequest{ActorID: "admin-001", CurrentSessionID: "admin-session-1"})
+	if err != nil {
+		t.Fatalf("ListSessions() error = %v", err)
+	}
+	if len(all) != 4 {
+		t.Fatalf("admin should see 4 sessions, got %d", len(all))
+	}
+	if !all[0].Current || all[0].UserName != "管理者" || all[1].UserName != "職員" {
+		t.Errorf("unexpected summaries: %+v, %+v", all[0], all[1])
+	}
+
+	own, err := uc.ListSessions(ctx, ListSessionsRequest{ActorID: "staff-001"})
+	if err != nil {
+		t.Fatalf("ListSessions() error = %v", err)
+	}
+	if len(own) != 2 {
+		t.Errorf("staff should see only their 2 sessions, got %d", len(own))
+	}
+
+	if _, err := uc.ListSessions(ctx, ListSessionsRequest{UserID: "staff-002", ActorID: "staff-001"}); err != ErrUnauthorized {
+		t.Errorf("ListSessions() of another user = %v, want ErrUnauthorized", err)
+	}
+}
+
+func TestSessionUseCase_RevokeSession(t *testing.T) {
+	uc, registry, auditRepo := newTestSessionUseCase()
+	ctx := context.Background()
+
+	// Staff cannot see, and therefore cannot end, other users' sessions
+	if err := uc.RevokeSession(ctx, RevokeSessionRequest{SessionID: "staff-session-3", ActorID: "staff-001"}); err != ErrSessionNotFound {
+		t.Errorf("RevokeSession() of another user = %v, want ErrSessionNotFound", err)
+	}
+
+	// Ending one's own session needs no reason
+	if err := uc.RevokeSession(ctx, RevokeSessionRequest{SessionID: "staff-session-2", ActorID: "staff-001"}); err != nil {
+		t.Fatalf("RevokeSession() error = %v", err)
+	}
+	if registry.sessions[2].IsActive || registry.sessions[2].InvalidationReason != defaultSelfRevokeReason {
+		t.Errorf("session not revoked: %+v", registry.sessions[2])
+	}
+
+	// Administrators must give a reason for other users' sessions
+	if err := uc.RevokeSession(ctx, RevokeSessionRequest{SessionID: "staff-session-3", ActorID: "admin-001"}); err == nil {
+		t.Error("RevokeSession() without a reason should fail")
+	}
+	if err := uc.RevokeSession(ctx, RevokeSessionRequest{SessionID: "staff-session-3", Reason: "端末紛失", ActorID: "admin-001"}); err != nil {
+		t.Fatalf("RevokeSession() error = %v", err)
+	}
+
+	if len(auditRepo.logs) != 2 {
+		t.Fatalf("expected 2 audit logs, got %d", len(auditRepo.logs))
+	}
+	if auditRepo.logs[1].Action != "SESSION_REVOKED" || auditRepo.logs[1].Target != "SECURITY" ||
+		!strings.Contains(auditRepo.logs[1].Details, "端末紛失") || strings.Contains(auditRepo.logs[1].Details, "staff-session-3") {
+		t.Errorf("unexpected audit log: %+v", auditRepo.logs[1])
+	}
+}
+
+func TestSessionUseCase_RevokeAllSessions(t *testing.T) {
+	uc, registry, auditRepo := newTestSessionUseCase()
+	ctx := context.Background()
+
+	if _, err := uc.RevokeAllSessions(ctx, RevokeAllSessionsRequest{UserID: "staff-002", Reason: "検証", ActorID: "staff-001"}); err != ErrUnauthorized {
+		t.Errorf("RevokeAllSessions() by staff = %v, want ErrUnauthorized", err)
+	}
+
+	// Logging out other devices keeps the current session
+	count, err := uc.RevokeAllSessions(ctx, RevokeAllSessionsRequest{KeepSessionID: "staff-session-1", ActorID: "staff-001"})
+	if err != nil {
+		t.Fatalf("RevokeAllSessions() error = %v", err)
+	}
+	if count != 1 || !registry.sessions[1].IsActive || registry.sessions[2].IsActive {
+		t.Errorf("RevokeAllSessions() = %d, sessions %+v", count, registry.sessions)
+	}
+
+	count, err = uc.RevokeAllSessions(ctx, RevokeAllSessionsRequest{UserID: "staff-001", Reason: "パスワード変更", ActorID: "admin-001"})
+	if err != nil {
+		t.Fatalf("RevokeAllSessions() error = %v", err)
+	}
+	if count != 1 || registry.sessions[1].IsActive {
+		t.Errorf("RevokeAllSessions() = %d, sessions %+v", count, registry.sessions)
+	}
+
+	if len(auditRepo.logs) != 2 || auditRepo.logs[1].Action != "SESSIONS_REVOKED_ALL" {
+		t.Errorf("unexpected audit logs: %+v", auditRepo.logs)
+	}
+}
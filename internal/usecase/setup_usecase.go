@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"shien-system/internal/domain"
+	"shien-system/internal/validation"
 )
 
 type SetupUseCase interface {
@@ -15,6 +16,8 @@ type setupUseCase struct {
 	staffRepo      domain.StaffRepository
 	auditRepo      domain.AuditLogRepository
 	passwordHasher domain.PasswordHasher
+
+	useCaseLogger
 }
 
 func NewSetupUseCase(
@@ -54,7 +57,7 @@ func (u *setupUseCase) CreateInitialAdmin(ctx context.Context, name, password st
 	// Create admin user
 	admin := &domain.Staff{
 		ID:           "admin-001",
-		Name:         name,
+		Name:         validation.NormalizeText(name),
 		Role:         domain.RoleAdmin,
 		PasswordHash: hashedPassword,
 	}
@@ -73,7 +76,7 @@ func (u *setupUseCase) CreateInitialAdmin(ctx context.Context, name, password st
 
 	if err := u.auditRepo.Create(ctx, audit); err != nil {
 		// Non-critical error, just log it
-		fmt.Printf("Warning: failed to create audit log: %v\n", err)
+		u.log().Warn("failed to write audit log", "action", audit.Action, "error", err)
 	}
 
 	return nil
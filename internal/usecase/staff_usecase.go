@@ -8,6 +8,8 @@ import (
 
 	"github.com/google/uuid"
 	"shien-system/internal/domain"
+	"shien-system/internal/validation"
+	"shien-system/internal/wareki"
 )
 
 // staffUseCase implements StaffUseCase interface
@@ -15,6 +17,8 @@ type staffUseCase struct {
 	staffRepo      domain.StaffRepository
 	assignmentRepo domain.StaffAssignmentRepository
 	auditRepo      domain.AuditLogRepository
+
+	useCaseLogger
 }
 
 // NewStaffUseCase creates a new staff usecase
@@ -32,6 +36,8 @@ func NewStaffUseCase(
 
 // CreateStaff creates a new staff member with validation
 func (uc *staffUseCase) CreateStaff(ctx context.Context, req CreateStaffRequest) (*domain.Staff, error) {
+	req.Name = validation.NormalizeText(req.Name)
+
 	// Validate input
 	if err := uc.validateCreateStaffRequest(req); err != nil {
 		return nil, &UseCaseError{
@@ -65,6 +71,7 @@ func (uc *staffUseCase) CreateStaff(ctx context.Context, req CreateStaffRequest)
 		ID:        domain.ID(uuid.New().String()),
 		Name:      req.Name,
 		Role:      req.Role,
+		DateStyle: string(wareki.StyleGregorian),
 		CreatedAt: now,
 		UpdatedAt: now,
 	}
@@ -92,6 +99,7 @@ func (uc *staffUseCase) CreateStaff(ctx context.Context, req CreateStaffRequest)
 	err = uc.auditRepo.Create(ctx, auditLog)
 	if err != nil {
 		// Log audit failure but don't fail the operation
+		uc.log().Warn("failed to write audit log", "action", auditLog.Action, "error", err)
 	}
 
 	return staff, nil
@@ -116,6 +124,8 @@ func (uc *staffUseCase) GetStaff(ctx context.Context, id domain.ID) (*domain.Sta
 
 // UpdateStaff updates staff information
 func (uc *staffUseCase) UpdateStaff(ctx context.Context, req UpdateStaffRequest) (*domain.Staff, error) {
+	req.Name = validation.NormalizeText(req.Name)
+
 	// Validate input
 	if err := uc.validateUpdateStaffRequest(req); err != nil {
 		return nil, &UseCaseError{
@@ -156,17 +166,29 @@ func (uc *staffUseCase) UpdateStaff(ctx context.Context, req UpdateStaffRequest)
 		return nil, ErrUnauthorized
 	}
 
+	// Someone else saved the staff member after the form was opened
+	if existing.Version != req.Version {
+		return nil, newEditConflictError(existing)
+	}
+
 	// Update staff
 	now := time.Now().UTC()
 	staff := &domain.Staff{
-		ID:        req.ID,
-		Name:      req.Name,
-		Role:      req.Role,
-		CreatedAt: existing.CreatedAt, // Preserve original creation time
-		UpdatedAt: now,
+		ID:           req.ID,
+		Name:         req.Name,
+		Role:         req.Role,
+		PasswordHash: existing.PasswordHash, // The form does not edit the password
+		DateStyle:    existing.DateStyle,    // Changed only by its owner, see UpdateDateStyle
+		CreatedAt:    existing.CreatedAt,    // Preserve original creation time
+		UpdatedAt:    now,
+		Version:      req.Version,
 	}
 
 	err = uc.staffRepo.Update(ctx, staff)
+	if err == domain.ErrVersionConflict {
+		current, _ := uc.staffRepo.GetByID(ctx, req.ID)
+		return nil, newEditConflictError(current)
+	}
 	if err != nil {
 		return nil, &UseCaseError{
 			Code:    "UPDATE_FAILED",
@@ -189,6 +211,7 @@ func (uc *staffUseCase) UpdateStaff(ctx context.Context, req UpdateStaffRequest)
 	err = uc.auditRepo.Create(ctx, auditLog)
 	if err != nil {
 		// Log audit failure but don't fail the operation
+		uc.log().Warn("failed to write audit log", "action", auditLog.Action, "error", err)
 	}
 
 	return staff, nil
@@ -247,7 +270,9 @@ func (uc *staffUseCase) DeleteStaff(ctx context.Context, id domain.ID) error {
 			Details: fmt.Sprintf("職員「%s」を削除しました", staff.Name),
 		}
 
-		uc.auditRepo.Create(ctx, auditLog)
+		if err := uc.auditRepo.Create(ctx, auditLog); err != nil {
+			uc.log().Warn("failed to write audit log", "action", auditLog.Action, "error", err)
+		}
 	}
 
 	return nil
@@ -349,6 +374,63 @@ func (uc *staffUseCase) GetAssignments(ctx context.Context, staffID domain.ID) (
 	return assignments, nil
 }
 
+// UpdateDateStyle changes the actor's own date display preference.
+// Any role may change it; input always accepts both 西暦 and 和暦.
+func (uc *staffUseCase) UpdateDateStyle(ctx context.Context, req UpdateDateStyleRequest) (*domain.Staff, error) {
+	style, err := wareki.ParseStyle(req.DateStyle)
+	if err != nil {
+		return nil, &UseCaseError{
+			Code:    "VALIDATION_FAILED",
+			Message: "日付の表示形式が不正です",
+			Cause:   err,
+		}
+	}
+
+	staff, err := uc.staffRepo.GetByID(ctx, req.ActorID)
+	if err != nil {
+		if err == domain.ErrNotFound {
+			return nil, ErrUnauthorized
+		}
+		return nil, &UseCaseError{
+			Code:    "INTERNAL_ERROR",
+			Message: "内部エラーが発生しました",
+			Cause:   err,
+		}
+	}
+
+	if staff.DateStyle == string(style) {
+		return staff, nil
+	}
+
+	now := time.Now().UTC()
+	staff.DateStyle = string(style)
+	staff.UpdatedAt = now
+
+	if err := uc.staffRepo.Update(ctx, staff); err != nil {
+		return nil, &UseCaseError{
+			Code:    "UPDATE_FAILED",
+			Message: "日付の表示形式の保存に失敗しました",
+			Cause:   err,
+		}
+	}
+
+	auditLog := &domain.AuditLog{
+		ID:      domain.ID(uuid.New().String()),
+		ActorID: req.ActorID,
+		Action:  "UPDATE",
+		Target:  fmt.Sprintf("staff:%s", staff.ID),
+		At:      now,
+		IP:      uc.getClientIP(ctx),
+		Details: fmt.Sprintf("日付の表示形式を%sに変更しました", style.Label()),
+	}
+
+	if err := uc.auditRepo.Create(ctx, auditLog); err != nil {
+		uc.log().Warn("failed to write audit log", "action", auditLog.Action, "error", err)
+	}
+
+	return staff, nil
+}
+
 // Validation functions
 
 func (uc *staffUseCase) validateCreateStaffRequest(req CreateStaffRequest) error {
@@ -217,6 +217,55 @@ func TestStaffUseCase_UpdateStaff(t *testing.T) {
 	}
 }
 
+func TestStaffUseCase_UpdateStaff_EditConflict(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Second)
+	mockStaffRepo := &mockStaffRepository{
+		staff: map[domain.ID]*domain.Staff{
+			"staff-001": {ID: "staff-001", Name: "別の職員が変更", Role: domain.RoleStaff, PasswordHash: "hash", CreatedAt: now, UpdatedAt: now, Version: 3},
+			"admin-001": {ID: "admin-001", Name: "管理者", Role: domain.RoleAdmin},
+		},
+	}
+	usecase := NewStaffUseCase(mockStaffRepo, &mockStaffAssignmentRepository{}, &mockAuditLogRepository{})
+	ctx := context.Background()
+
+	// The form was opened at version 2
+	_, err := usecase.UpdateStaff(ctx, UpdateStaffRequest{
+		ID:      "staff-001",
+		Name:    "更新後職員",
+		Role:    domain.RoleStaff,
+		Version: 2,
+		ActorID: "admin-001",
+	})
+	if !errors.Is(err, ErrEditConflict) {
+		t.Fatalf("UpdateStaff() error = %v, want ErrEditConflict", err)
+	}
+	var conflict *EditConflictError
+	if !errors.As(err, &conflict) {
+		t.Fatal("conflict error should carry the stored record")
+	}
+	if current, ok := conflict.Current.(*domain.Staff); !ok || current.Name != "別の職員が変更" {
+		t.Errorf("Current = %#v, want the stored staff", conflict.Current)
+	}
+	if mockStaffRepo.staff["staff-001"].Name != "別の職員が変更" {
+		t.Error("conflicting update must not be written")
+	}
+
+	// Retrying on the current version succeeds and keeps the password
+	staff, err := usecase.UpdateStaff(ctx, UpdateStaffRequest{
+		ID:      "staff-001",
+		Name:    "更新後職員",
+		Role:    domain.RoleStaff,
+		Version: 3,
+		ActorID: "admin-001",
+	})
+	if err != nil {
+		t.Fatalf("UpdateStaff() error = %v", err)
+	}
+	if staff.PasswordHash != "hash" {
+		t.Error("updating the profile must not clear the password hash")
+	}
+}
+
 func TestStaffUseCase_DeleteStaff_WithActiveAssignments(t *testing.T) {
 	existingStaff := &domain.Staff{
 		ID:   "staff-001",
@@ -398,3 +447,42 @@ func TestStaffUseCase_GetAssignments(t *testing.T) {
 		}
 	}
 }
+
+func TestStaffUseCase_UpdateDateStyle(t *testing.T) {
+	mockStaffRepo := &mockStaffRepository{
+		staff: map[domain.ID]*domain.Staff{
+			"staff-001": {
+				ID:        "staff-001",
+				Name:      "一般職員",
+				Role:      domain.RoleStaff,
+				DateStyle: "gregorian",
+			},
+		},
+	}
+	mockAuditRepo := &mockAuditLogRepository{}
+
+	usecase := NewStaffUseCase(mockStaffRepo, &mockStaffAssignmentRepository{}, mockAuditRepo)
+
+	ctx := context.Background()
+
+	staff, err := usecase.UpdateDateStyle(ctx, UpdateDateStyleRequest{DateStyle: "wareki", ActorID: "staff-001"})
+	if err != nil {
+		t.Fatalf("UpdateDateStyle() error = %v", err)
+	}
+	if staff.DateStyle != "wareki" || mockStaffRepo.staff["staff-001"].DateStyle != "wareki" {
+		t.Errorf("DateStyle = %q, want wareki", staff.DateStyle)
+	}
+	if len(mockAuditRepo.logs) != 1 {
+		t.Errorf("Expected 1 audit log, got %d", len(mockAuditRepo.logs))
+	}
+
+	_, err = usecase.UpdateDateStyle(ctx, UpdateDateStyleRequest{DateStyle: "heisei", ActorID: "staff-001"})
+	var ucErr *UseCaseError
+	if !errors.As(err, &ucErr) || ucErr.Code != "VALIDATION_FAILED" {
+		t.Errorf("UpdateDateStyle() error = %v, want VALIDATION_FAILED", err)
+	}
+
+	if _, err := usecase.UpdateDateStyle(ctx, UpdateDateStyleRequest{DateStyle: "wareki", ActorID: "missing"}); err != ErrUnauthorized {
+		t.Errorf("UpdateDateStyle() error = %v, want ErrUnauthorized", err)
+	}
+}
@@ -0,0 +1,94 @@
+package validation
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// prefectures are the 47 prefectures an address starts with
+var prefectures = []string{
+	"北海道", "青森県", "岩手県", "宮城県", "秋田県", "山形県", "福島県",
+	"茨城県", "栃木県", "群馬県", "埼玉県", "千葉県", "東京都", "神奈川県",
+	"新潟県", "富山県", "石川県", "福井県", "山梨県", "長野県", "岐阜県",
+	"静岡県", "愛知県", "三重県", "滋賀県", "京都府", "大阪府", "兵庫県",
+	"奈良県", "和歌山県", "鳥取県", "島根県", "岡山県", "広島県", "山口県",
+	"徳島県", "香川県", "愛媛県", "高知県", "福岡県", "佐賀県", "長崎県",
+	"熊本県", "大分県", "宮崎県", "鹿児島県", "沖縄県",
+}
+
+// leadingPostalCode matches a postal code written before the address, with
+// or without 〒 and the hyphen
+var leadingPostalCode = regexp.MustCompile(`^〒?\s*([0-9]{3}-?[0-9]{4})(\s+|$)`)
+
+// maxCountyLength is the longest county (郡) name, in characters, that
+// SplitCity looks for before a town or village
+const maxCountyLength = 6
+
+// AddressParts is an address split into the fields of the recipient form
+type AddressParts struct {
+	PostalCode string // 123-4567, or empty
+	Prefecture string
+	City       string
+	Street     string
+}
+
+// SplitAddress splits an address typed into one field, such as
+// "〒150-0001 東京都渋谷区神宮前1-1-1", into the postal code and prefecture it
+// starts with and the rest, which is left in Street; see SplitCity for the
+// municipality. The address is normalized like NormalizeText first, and
+// Prefecture+City+Street gives back that address without the postal code
+// and the spaces between the parts.
+func SplitAddress(address string) AddressParts {
+	rest := strings.ReplaceAll(NormalizeText(address), "\n", " ")
+
+	var parts AddressParts
+	if m := leadingPostalCode.FindStringSubmatch(hyphenVariants.Replace(rest)); m != nil {
+		parts.PostalCode = NormalizePostalCode(m[1])
+		// The match has the same length in the original text, since every
+		// hyphen variant is a single rune replaced by one
+		rest = string([]rune(rest)[utf8.RuneCountInString(m[0]):])
+	}
+
+	for _, prefecture := range prefectures {
+		if strings.HasPrefix(rest, prefecture) {
+			parts.Prefecture = prefecture
+			rest = strings.TrimSpace(strings.TrimPrefix(rest, prefecture))
+			break
+		}
+	}
+
+	parts.Street = rest
+	return parts
+}
+
+// SplitCity moves the municipality at the start of Street into City. cities
+// are the municipality names that may follow the prefecture, such as those
+// of the postal code dictionary; the longest one Street starts with is used,
+// also after a county (郡) that the names leave out. It reports whether a
+// municipality was found.
+func (p *AddressParts) SplitCity(cities []string) bool {
+	county := ""
+	if i := strings.Index(p.Street, "郡"); i > 0 && utf8.RuneCountInString(p.Street[:i]) <= maxCountyLength {
+		county = p.Street[:i+len("郡")]
+	}
+
+	best := ""
+	for _, city := range cities {
+		if city == "" {
+			continue
+		}
+		for _, candidate := range []string{city, county + city} {
+			if len(candidate) > len(best) && strings.HasPrefix(p.Street, candidate) {
+				best = candidate
+			}
+		}
+	}
+	if best == "" {
+		return false
+	}
+
+	p.City = best
+	p.Street = strings.TrimSpace(strings.TrimPrefix(p.Street, best))
+	return true
+}
@@ -0,0 +1,51 @@
+package validation
+
+import "testing"
+
+func TestSplitAddress(t *testing.T) {
+	tests := []struct {
+		input string
+		want  AddressParts
+	}{
+		{"〒150-0001 東京都渋谷区神宮前1-1-1", AddressParts{PostalCode: "150-0001", Prefecture: "東京都", Street: "渋谷区神宮前1-1-1"}},
+		{"１５００００１　東京都 渋谷区神宮前１－１－１", AddressParts{PostalCode: "150-0001", Prefecture: "東京都", Street: "渋谷区神宮前1-1-1"}},
+		{"〒１５０ー０００１ 神奈川県横浜市中区山下町1", AddressParts{PostalCode: "150-0001", Prefecture: "神奈川県", Street: "横浜市中区山下町1"}},
+		{"渋谷区神宮前1-1-1", AddressParts{Street: "渋谷区神宮前1-1-1"}},
+		{"1500001", AddressParts{PostalCode: "150-0001"}},
+		{"", AddressParts{}},
+	}
+
+	for _, tt := range tests {
+		if got := SplitAddress(tt.input); got != tt.want {
+			t.Errorf("SplitAddress(%q) = %+v, want %+v", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestAddressParts_SplitCity(t *testing.T) {
+	tests := []struct {
+		street     string
+		cities     []string
+		wantCity   string
+		wantStreet string
+	}{
+		// The longest name wins, so a 政令指定都市 keeps its ward
+		{"横浜市中区山下町1", []string{"横浜市", "横浜市中区"}, "横浜市中区", "山下町1"},
+		// Names that a greedy regular expression would cut short
+		{"東村山市本町1-2", []string{"東村山市"}, "東村山市", "本町1-2"},
+		{"大町市大町1", []string{"大町市"}, "大町市", "大町1"},
+		// The municipality master lists towns without their county
+		{"西多摩郡奥多摩町氷川1", []string{"奥多摩町"}, "西多摩郡奥多摩町", "氷川1"},
+		{"渋谷区神宮前1-1-1", []string{"新宿区"}, "", "渋谷区神宮前1-1-1"},
+		{"渋谷区神宮前1-1-1", nil, "", "渋谷区神宮前1-1-1"},
+	}
+
+	for _, tt := range tests {
+		parts := AddressParts{Prefecture: "東京都", Street: tt.street}
+		found := parts.SplitCity(tt.cities)
+		if found != (tt.wantCity != "") || parts.City != tt.wantCity || parts.Street != tt.wantStreet {
+			t.Errorf("SplitCity(%q, %v) = %v, %q, %q, want %q, %q",
+				tt.street, tt.cities, found, parts.City, parts.Street, tt.wantCity, tt.wantStreet)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package validation
+
+import "strings"
+
+// certificateNumberLength is the number of digits of a 受給者証番号
+const certificateNumberLength = 10
+
+// CertificateNumberDigits returns the ten digits of a 受給者証番号 entered
+// with full-width digits, hyphens or spaces, or "" when s is not one
+func CertificateNumberDigits(s string) string {
+	digits := codeDigits(s)
+	if len(digits) != certificateNumberLength || !isDigits(digits) {
+		return ""
+	}
+	return digits
+}
+
+// MunicipalityCodeDigits returns a six-digit 市町村番号 whose last digit is
+// the check digit of the first five, or "" when s is not one
+func MunicipalityCodeDigits(s string) string {
+	digits := codeDigits(s)
+	if len(digits) != 6 || !isDigits(digits) {
+		return ""
+	}
+	if digits[5] != MunicipalityCheckDigit(digits[:5]) {
+		return ""
+	}
+	return digits
+}
+
+// MunicipalityCheckDigit computes the check digit of a five-digit local
+// government code: the digits are weighted 6, 5, 4, 3 and 2, and the check
+// digit is the last digit of 11 minus the sum modulo 11 (13101 → 6)
+func MunicipalityCheckDigit(code string) byte {
+	sum := 0
+	for i := 0; i < 5 && i < len(code); i++ {
+		sum += int(code[i]-'0') * (6 - i)
+	}
+	return byte('0' + (11-sum%11)%10)
+}
+
+// ServiceCodeDigits returns a two-digit サービス種類コード, or "" when s is not one
+func ServiceCodeDigits(s string) string {
+	digits := codeDigits(s)
+	if len(digits) != 2 || !isDigits(digits) {
+		return ""
+	}
+	return digits
+}
+
+// codeDigits normalizes s like NormalizePhone and drops hyphens
+func codeDigits(s string) string {
+	return strings.ReplaceAll(NormalizePhone(s), "-", "")
+}
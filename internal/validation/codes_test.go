@@ -0,0 +1,81 @@
+package validation
+
+import "testing"
+
+func TestMunicipalityCheckDigit(t *testing.T) {
+	tests := []struct {
+		code string
+		want byte
+	}{
+		{"13101", '6'}, // 千代田区
+		{"01100", '2'}, // 札幌市
+		{"14100", '3'}, // 横浜市
+		{"27100", '4'}, // 大阪市
+		{"47201", '8'}, // 那覇市
+	}
+
+	for _, tt := range tests {
+		if got := MunicipalityCheckDigit(tt.code); got != tt.want {
+			t.Errorf("MunicipalityCheckDigit(%q) = %q, want %q", tt.code, got, tt.want)
+		}
+	}
+}
+
+func TestMunicipalityCodeDigits(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"131016", "131016"},
+		{"１３１０１６", "131016"},
+		{" 131016 ", "131016"},
+		{"131017", ""}, // Wrong check digit
+		{"13101", ""},
+		{"13101a", ""},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		if got := MunicipalityCodeDigits(tt.input); got != tt.want {
+			t.Errorf("MunicipalityCodeDigits(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestCertificateNumberDigits(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"1234567890", "1234567890"},
+		{"１２３４５６７８９０", "1234567890"},
+		{"12345-67890", "1234567890"},
+		{"123456789", ""},
+		{"12345678901", ""},
+		{"12345678AB", ""},
+	}
+
+	for _, tt := range tests {
+		if got := CertificateNumberDigits(tt.input); got != tt.want {
+			t.Errorf("CertificateNumberDigits(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestServiceCodeDigits(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"46", "46"},
+		{"４６", "46"},
+		{"4", ""},
+		{"460", ""},
+	}
+
+	for _, tt := range tests {
+		if got := ServiceCodeDigits(tt.input); got != tt.want {
+			t.Errorf("ServiceCodeDigits(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
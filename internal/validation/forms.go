@@ -1,15 +1,23 @@
 package validation
 
 import (
+	"regexp"
 	"strings"
 	"time"
+
+	"shien-system/internal/wareki"
 )
 
 // FormValidator provides form-specific validation functions
 type FormValidator struct {
-	validator *Validator
+	validator   *Validator
+	postalCodes PostalCodeDictionary
 }
 
+// PostalCodeDictionary reports whether a seven-digit postal code (no hyphen)
+// is in the postal code dictionary
+type PostalCodeDictionary func(code string) bool
+
 // NewFormValidator creates a new form validator
 func NewFormValidator() *FormValidator {
 	return &FormValidator{
@@ -17,10 +25,17 @@ func NewFormValidator() *FormValidator {
 	}
 }
 
+// SetPostalCodeDictionary makes ValidateRecipientForm reject postal codes
+// that are not in the dictionary. Without one only the format is checked.
+func (fv *FormValidator) SetPostalCodeDictionary(dictionary PostalCodeDictionary) {
+	fv.postalCodes = dictionary
+}
+
 // ValidateRecipientForm validates recipient form inputs
 func (fv *FormValidator) ValidateRecipientForm(data map[string]string) ValidationErrors {
 	var errors ValidationErrors
 	v := fv.validator
+	data = NormalizeFormData(data)
 	
 	// Name validation
 	if err := v.ValidateRequired("氏名", data["name"]); err != nil {
@@ -58,7 +73,7 @@ func (fv *FormValidator) ValidateRecipientForm(data map[string]string) Validatio
 			errors = append(errors, *err)
 		} else {
 			// Additional validation: birth date should not be in the future
-			if birthDate, parseErr := time.Parse("2006-01-02", data["birth_date"]); parseErr == nil {
+			if birthDate, parseErr := wareki.Parse(data["birth_date"]); parseErr == nil {
 				if birthDate.After(time.Now()) {
 					errors = append(errors, ValidationError{
 						Field:   "生年月日",
@@ -89,12 +104,31 @@ func (fv *FormValidator) ValidateRecipientForm(data map[string]string) Validatio
 		}
 	}
 	
+	// Postal code validation
+	if data["postal_code"] != "" {
+		if err := v.ValidatePostalCode("郵便番号", data["postal_code"]); err != nil {
+			errors = append(errors, *err)
+		} else if fv.postalCodes != nil && !fv.postalCodes(PostalCodeDigits(data["postal_code"])) {
+			errors = append(errors, ValidationError{
+				Field:   "郵便番号",
+				Message: "郵便番号辞書に登録されていない郵便番号です",
+			})
+		}
+	}
+
 	// Address validation
-	if data["address"] != "" {
-		if err := v.ValidateLength("住所", data["address"], 1, 500); err != nil {
+	for _, field := range []struct{ key, label string }{
+		{"prefecture", "都道府県"},
+		{"city", "市区町村"},
+		{"address", "住所"},
+	} {
+		if data[field.key] == "" {
+			continue
+		}
+		if err := v.ValidateLength(field.label, data[field.key], 1, 500); err != nil {
 			errors = append(errors, *err)
 		}
-		if err := v.ValidateNotContainXSS("住所", data["address"]); err != nil {
+		if err := v.ValidateNotContainXSS(field.label, data[field.key]); err != nil {
 			errors = append(errors, *err)
 		}
 	}
@@ -124,8 +158,8 @@ func (fv *FormValidator) ValidateRecipientForm(data map[string]string) Validatio
 		
 		// Discharge date should be after admission date
 		if data["admission_date"] != "" && data["discharge_date"] != "" {
-			admissionDate, admissionErr := time.Parse("2006-01-02", data["admission_date"])
-			dischargeDate, dischargeErr := time.Parse("2006-01-02", data["discharge_date"])
+			admissionDate, admissionErr := wareki.Parse(data["admission_date"])
+			dischargeDate, dischargeErr := wareki.Parse(data["discharge_date"])
 			
 			if admissionErr == nil && dischargeErr == nil {
 				if dischargeDate.Before(admissionDate) {
@@ -145,6 +179,7 @@ func (fv *FormValidator) ValidateRecipientForm(data map[string]string) Validatio
 func (fv *FormValidator) ValidateStaffForm(data map[string]string) ValidationErrors {
 	var errors ValidationErrors
 	v := fv.validator
+	data = NormalizeFormData(data)
 	
 	// Name validation
 	if err := v.ValidateRequired("職員名", data["name"]); err != nil {
@@ -188,6 +223,7 @@ func (fv *FormValidator) ValidateStaffForm(data map[string]string) ValidationErr
 func (fv *FormValidator) ValidateCertificateForm(data map[string]string) ValidationErrors {
 	var errors ValidationErrors
 	v := fv.validator
+	data = NormalizeFormData(data)
 	
 	// Start date validation
 	if err := v.ValidateRequired("開始日", data["start_date"]); err != nil {
@@ -208,8 +244,8 @@ func (fv *FormValidator) ValidateCertificateForm(data map[string]string) Validat
 		
 		// End date should be after start date
 		if data["start_date"] != "" && data["end_date"] != "" {
-			startDate, startErr := time.Parse("2006-01-02", data["start_date"])
-			endDate, endErr := time.Parse("2006-01-02", data["end_date"])
+			startDate, startErr := wareki.Parse(data["start_date"])
+			endDate, endErr := wareki.Parse(data["end_date"])
 			
 			if startErr == nil && endErr == nil {
 				if endDate.Before(startDate) {
@@ -222,6 +258,17 @@ func (fv *FormValidator) ValidateCertificateForm(data map[string]string) Validat
 		}
 	}
 	
+	// Coded fields required for billing
+	if err := v.ValidateCertificateNumber("受給者証番号", data["certificate_number"]); err != nil {
+		errors = append(errors, *err)
+	}
+	if err := v.ValidateMunicipalityCode("市町村番号", data["municipality_code"]); err != nil {
+		errors = append(errors, *err)
+	}
+	if err := v.ValidateServiceCode("サービス種類コード", data["service_code"]); err != nil {
+		errors = append(errors, *err)
+	}
+
 	// Issuer validation
 	if data["issuer"] != "" {
 		if err := v.ValidateLength("発行者", data["issuer"], 1, 200); err != nil {
@@ -252,10 +299,220 @@ func (fv *FormValidator) ValidateCertificateForm(data map[string]string) Validat
 	return errors
 }
 
+// ValidateEmergencyContactForm validates emergency contact form inputs
+func (fv *FormValidator) ValidateEmergencyContactForm(data map[string]string) ValidationErrors {
+	var errors ValidationErrors
+	v := fv.validator
+	data = NormalizeFormData(data)
+
+	// Priority validation
+	if data["priority"] != "" {
+		if err := v.ValidateIntegerRange("連絡順", data["priority"], 1, 99); err != nil {
+			errors = append(errors, *err)
+		}
+	}
+
+	// Name validation
+	if err := v.ValidateRequired("氏名", data["name"]); err != nil {
+		errors = append(errors, *err)
+	} else {
+		if err := v.ValidateLength("氏名", data["name"], 1, 100); err != nil {
+			errors = append(errors, *err)
+		}
+		if err := v.ValidateNotContainXSS("氏名", data["name"]); err != nil {
+			errors = append(errors, *err)
+		}
+	}
+
+	// Relationship validation
+	if err := v.ValidateRequired("続柄", data["relationship"]); err != nil {
+		errors = append(errors, *err)
+	} else {
+		if err := v.ValidateLength("続柄", data["relationship"], 1, 50); err != nil {
+			errors = append(errors, *err)
+		}
+		if err := v.ValidateNotContainXSS("続柄", data["relationship"]); err != nil {
+			errors = append(errors, *err)
+		}
+	}
+
+	// Phone validation - at least one number is required
+	phoneFields := []struct {
+		key   string
+		label string
+	}{
+		{"phone", "自宅電話"},
+		{"mobile_phone", "携帯電話"},
+		{"work_phone", "勤務先電話"},
+	}
+	hasPhone := false
+	for _, field := range phoneFields {
+		if data[field.key] == "" {
+			continue
+		}
+		hasPhone = true
+		if err := v.ValidatePhoneNumber(field.label, data[field.key]); err != nil {
+			errors = append(errors, *err)
+		}
+	}
+	if !hasPhone {
+		errors = append(errors, ValidationError{
+			Field:   "電話番号",
+			Message: "電話番号を1つ以上入力してください",
+		})
+	}
+
+	// Email validation
+	if data["email"] != "" {
+		if err := v.ValidateEmail("メール", data["email"]); err != nil {
+			errors = append(errors, *err)
+		}
+	}
+
+	// Notes validation
+	if data["notes"] != "" {
+		if err := v.ValidateLength("備考", data["notes"], 0, 500); err != nil {
+			errors = append(errors, *err)
+		}
+		if err := v.ValidateNotContainXSS("備考", data["notes"]); err != nil {
+			errors = append(errors, *err)
+		}
+	}
+
+	return errors
+}
+
+// ValidateMedicalRecordForm validates medical record form inputs
+func (fv *FormValidator) ValidateMedicalRecordForm(data map[string]string) ValidationErrors {
+	var errors ValidationErrors
+	v := fv.validator
+	data = NormalizeFormData(data)
+
+	// Seizure protocol is required when epilepsy is flagged
+	if data["has_epilepsy"] == "true" {
+		if err := v.ValidateRequired("発作時の対応", data["seizure_protocol"]); err != nil {
+			errors = append(errors, *err)
+		}
+	}
+	if data["seizure_protocol"] != "" {
+		if err := v.ValidateLength("発作時の対応", data["seizure_protocol"], 0, 1000); err != nil {
+			errors = append(errors, *err)
+		}
+		if err := v.ValidateNotContainXSS("発作時の対応", data["seizure_protocol"]); err != nil {
+			errors = append(errors, *err)
+		}
+	}
+
+	// Hospital phone validation
+	if data["hospital_phone"] != "" {
+		if err := v.ValidatePhoneNumber("医療機関電話番号", data["hospital_phone"]); err != nil {
+			errors = append(errors, *err)
+		}
+	}
+
+	// Insurer number validation (6 digits for national health insurance, 8 otherwise)
+	if insurerNumber := data["insurer_number"]; insurerNumber != "" {
+		if !regexp.MustCompile(`^\d{6}(\d{2})?$`).MatchString(insurerNumber) {
+			errors = append(errors, ValidationError{
+				Field:   "保険者番号",
+				Message: "保険者番号は6桁または8桁の数字で入力してください",
+			})
+		}
+	}
+
+	// Insurance card expiry validation
+	if data["insurance_valid_until"] != "" {
+		if err := v.ValidateDate("保険証有効期限", data["insurance_valid_until"]); err != nil {
+			errors = append(errors, *err)
+		}
+	}
+
+	// Notes validation
+	if data["notes"] != "" {
+		if err := v.ValidateLength("備考", data["notes"], 0, 1000); err != nil {
+			errors = append(errors, *err)
+		}
+		if err := v.ValidateNotContainXSS("備考", data["notes"]); err != nil {
+			errors = append(errors, *err)
+		}
+	}
+
+	return errors
+}
+
+// ValidateIncidentForm validates accident and near-miss report form inputs
+func (fv *FormValidator) ValidateIncidentForm(data map[string]string) ValidationErrors {
+	var errors ValidationErrors
+	v := fv.validator
+	data = NormalizeFormData(data)
+
+	// Occurred date and time validation
+	if err := v.ValidateRequired("発生日", data["occurred_date"]); err != nil {
+		errors = append(errors, *err)
+	} else if err := v.ValidateDate("発生日", data["occurred_date"]); err != nil {
+		errors = append(errors, *err)
+	}
+	if err := v.ValidateRequired("発生時刻", data["occurred_time"]); err != nil {
+		errors = append(errors, *err)
+	} else if _, err := time.Parse("15:04", data["occurred_time"]); err != nil {
+		errors = append(errors, ValidationError{
+			Field:   "発生時刻",
+			Message: "時刻はHH:MM形式で入力してください",
+		})
+	}
+
+	// Category and severity are chosen from lists
+	if err := v.ValidateRequired("事故の種別", data["category"]); err != nil {
+		errors = append(errors, *err)
+	}
+	if err := v.ValidateRequired("程度", data["severity"]); err != nil {
+		errors = append(errors, *err)
+	}
+
+	// Location validation
+	if data["location"] != "" {
+		if err := v.ValidateLength("発生場所", data["location"], 0, 200); err != nil {
+			errors = append(errors, *err)
+		}
+		if err := v.ValidateNotContainXSS("発生場所", data["location"]); err != nil {
+			errors = append(errors, *err)
+		}
+	}
+
+	// Description validation
+	if err := v.ValidateRequired("発生時の状況", data["description"]); err != nil {
+		errors = append(errors, *err)
+	}
+
+	// Free text validation
+	textFields := []struct {
+		key   string
+		label string
+	}{
+		{"description", "発生時の状況"},
+		{"response", "発生時の対応"},
+		{"prevention", "再発防止策"},
+	}
+	for _, field := range textFields {
+		if data[field.key] == "" {
+			continue
+		}
+		if err := v.ValidateLength(field.label, data[field.key], 0, 2000); err != nil {
+			errors = append(errors, *err)
+		}
+		if err := v.ValidateNotContainXSS(field.label, data[field.key]); err != nil {
+			errors = append(errors, *err)
+		}
+	}
+
+	return errors
+}
+
 // ValidateLoginForm validates login form inputs
 func (fv *FormValidator) ValidateLoginForm(data map[string]string) ValidationErrors {
 	var errors ValidationErrors
 	v := fv.validator
+	data = NormalizeFormData(data)
 	
 	// Username validation
 	if err := v.ValidateRequired("ユーザー名", data["username"]); err != nil {
@@ -293,6 +550,7 @@ func (fv *FormValidator) ValidateLoginForm(data map[string]string) ValidationErr
 func (fv *FormValidator) ValidateSetupForm(data map[string]string) ValidationErrors {
 	var errors ValidationErrors
 	v := fv.validator
+	data = NormalizeFormData(data)
 	
 	// Admin name validation
 	if err := v.ValidateRequired("管理者名", data["admin_name"]); err != nil {
@@ -0,0 +1,117 @@
+package validation
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Normalization rules shared by forms, usecases and search. Every function
+// is idempotent, so values can be normalized again on each layer.
+
+// NormalizeText applies NFKC (full-width ASCII to half-width, half-width
+// katakana to full-width), collapses runs of spaces and tabs into one space
+// and trims every line. Line breaks are kept for multi-line fields.
+func NormalizeText(s string) string {
+	lines := strings.Split(norm.NFKC.String(s), "\n")
+	for i, line := range lines {
+		lines[i] = strings.Join(strings.Fields(line), " ")
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+// NormalizeKana normalizes text and converts hiragana to katakana, so
+// "さとう たろう", "ｻﾄｳ ﾀﾛｳ" and "サトウ　タロウ" all become "サトウ タロウ"
+func NormalizeKana(s string) string {
+	return strings.Map(hiraganaToKatakana, NormalizeText(s))
+}
+
+// hiraganaToKatakana maps ぁ-ゖ and ゝゞ to the katakana of the same sound
+func hiraganaToKatakana(r rune) rune {
+	if (r >= 'ぁ' && r <= 'ゖ') || r == 'ゝ' || r == 'ゞ' {
+		return r + ('ァ' - 'ぁ')
+	}
+	return r
+}
+
+// hyphenVariants are dashes people type in phone numbers and postal codes
+var hyphenVariants = strings.NewReplacer(
+	"‐", "-", // U+2010 hyphen
+	"‑", "-", // U+2011 non-breaking hyphen
+	"‒", "-", // U+2012 figure dash
+	"–", "-", // U+2013 en dash
+	"—", "-", // U+2014 em dash
+	"―", "-", // U+2015 horizontal bar
+	"−", "-", // U+2212 minus sign
+	"ー", "-", // U+30FC prolonged sound mark (ｰ becomes this under NFKC)
+)
+
+// NormalizePhone converts full-width digits and hyphen variants and removes
+// spaces: "０３ー１２３４ー５６７８" becomes "03-1234-5678"
+func NormalizePhone(s string) string {
+	s = hyphenVariants.Replace(norm.NFKC.String(s))
+	return strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) {
+			return -1
+		}
+		return r
+	}, s)
+}
+
+// NormalizePostalCode normalizes like NormalizePhone, drops a leading 〒 and
+// writes seven digits as 123-4567
+func NormalizePostalCode(s string) string {
+	s = strings.TrimPrefix(NormalizePhone(s), "〒")
+	if digits := strings.ReplaceAll(s, "-", ""); len(digits) == 7 && isDigits(digits) {
+		return digits[:3] + "-" + digits[3:]
+	}
+	return s
+}
+
+// PostalCodeDigits returns the seven digits of a postal code as stored in
+// the postal code dictionary, or "" when s is not a postal code
+func PostalCodeDigits(s string) string {
+	digits := strings.ReplaceAll(NormalizePostalCode(s), "-", "")
+	if len(digits) != 7 || !isDigits(digits) {
+		return ""
+	}
+	return digits
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// NormalizeSearch is the key used to compare names and search terms: kana
+// normalization plus lower case, so "ﾔﾏﾀﾞ", "やまだ" and "ヤマダ" match
+func NormalizeSearch(s string) string {
+	return strings.ToLower(NormalizeKana(s))
+}
+
+// NormalizeFormData returns a copy of form data with every value normalized
+// for its field: kana fields as kana, phone and postal code fields as
+// numbers, passwords untouched and everything else as text
+func NormalizeFormData(data map[string]string) map[string]string {
+	normalized := make(map[string]string, len(data))
+	for key, value := range data {
+		switch {
+		case strings.Contains(key, "password"):
+			normalized[key] = value
+		case strings.Contains(key, "kana"):
+			normalized[key] = NormalizeKana(value)
+		case strings.Contains(key, "phone"):
+			normalized[key] = NormalizePhone(value)
+		case strings.Contains(key, "postal_code"):
+			normalized[key] = NormalizePostalCode(value)
+		default:
+			normalized[key] = NormalizeText(value)
+		}
+	}
+	return normalized
+}
@@ -0,0 +1,116 @@
+package validation
+
+import "testing"
+
+func TestNormalizeText(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"  山田　　太郎 ", "山田 太郎"},
+		{"ＡＢＣ１２３", "ABC123"},
+		{"ｻﾄｳ ﾀﾞｲｽｹ", "サトウ ダイスケ"},
+		{"一行目　\n\t二行目", "一行目\n二行目"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		if got := NormalizeText(tt.input); got != tt.want {
+			t.Errorf("NormalizeText(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestNormalizeKana(t *testing.T) {
+	for _, input := range []string{"さとう たろう", "ｻﾄｳ ﾀﾛｳ", "サトウ　タロウ", " さトう  ﾀろう "} {
+		if got := NormalizeKana(input); got != "サトウ タロウ" {
+			t.Errorf("NormalizeKana(%q) = %q", input, got)
+		}
+	}
+	if got := NormalizeKana("ゔぁいおりん ゞ"); got != "ヴァイオリン ヾ" {
+		t.Errorf("NormalizeKana() = %q", got)
+	}
+}
+
+func TestNormalizePhone(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"０３－１２３４－５６７８", "03-1234-5678"},
+		{"090ー1234ｰ5678", "090-1234-5678"},
+		{"03 1234 5678", "0312345678"},
+		{"03‐1234−5678", "03-1234-5678"},
+	}
+
+	for _, tt := range tests {
+		if got := NormalizePhone(tt.input); got != tt.want {
+			t.Errorf("NormalizePhone(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestNormalizePostalCode(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"〒１５０－０００１", "150-0001"},
+		{"1500001", "150-0001"},
+		{"150ー0001", "150-0001"},
+		{"150", "150"},
+	}
+
+	for _, tt := range tests {
+		if got := NormalizePostalCode(tt.input); got != tt.want {
+			t.Errorf("NormalizePostalCode(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestNormalizeSearch(t *testing.T) {
+	want := NormalizeSearch("ヤマダ")
+	for _, input := range []string{"ﾔﾏﾀﾞ", "やまだ", " ヤマダ "} {
+		if got := NormalizeSearch(input); got != want {
+			t.Errorf("NormalizeSearch(%q) = %q, want %q", input, got, want)
+		}
+	}
+	if NormalizeSearch("ＡＢＣ") != "abc" {
+		t.Errorf("NormalizeSearch should fold case and width")
+	}
+}
+
+func TestNormalizeFormData(t *testing.T) {
+	data := map[string]string{
+		"kana":             "やまだ はなこ",
+		"phone":            "０９０－１２３４－５６７８",
+		"password":         " ｐａｓｓ ",
+		"confirm_password": " ｐａｓｓ ",
+		"name":             "山田　花子",
+	}
+	got := NormalizeFormData(data)
+
+	if got["kana"] != "ヤマダ ハナコ" || got["phone"] != "090-1234-5678" || got["name"] != "山田 花子" {
+		t.Errorf("NormalizeFormData() = %v", got)
+	}
+	if got["password"] != data["password"] || got["confirm_password"] != data["confirm_password"] {
+		t.Error("passwords must not be normalized")
+	}
+	if data["kana"] != "やまだ はなこ" {
+		t.Error("NormalizeFormData must not modify its input")
+	}
+}
+
+func TestFormValidator_NormalizesBeforeValidating(t *testing.T) {
+	fv := NewFormValidator()
+	errors := fv.ValidateRecipientForm(map[string]string{
+		"name":       "山田 花子",
+		"kana":       "やまだ はなこ",
+		"sex":        "女性",
+		"birth_date": "1990-01-01",
+		"phone":      "０９０－１２３４－５６７８",
+	})
+	if len(errors) != 0 {
+		t.Errorf("ValidateRecipientForm() = %v", errors)
+	}
+}
@@ -1,14 +1,16 @@
 package validation
 
 import (
+	"errors"
 	"fmt"
 	"net/mail"
 	"regexp"
 	"strconv"
 	"strings"
-	"time"
 	"unicode"
 	"unicode/utf8"
+
+	"shien-system/internal/wareki"
 )
 
 // ValidationError represents a validation error
@@ -97,6 +99,9 @@ func (v *Validator) ValidatePhoneNumber(field, value string) *ValidationError {
 		return nil // Empty phone is allowed unless required
 	}
 	
+	// Full-width digits and dash variants are accepted
+	value = NormalizePhone(value)
+
 	// Remove common separators
 	cleaned := strings.ReplaceAll(value, "-", "")
 	cleaned = strings.ReplaceAll(cleaned, " ", "")
@@ -123,17 +128,87 @@ func (v *Validator) ValidatePhoneNumber(field, value string) *ValidationError {
 	return nil
 }
 
-// ValidateDate validates date format (YYYY-MM-DD)
+// ValidatePostalCode validates a seven-digit postal code (100-0001, 〒1000001, １００－０００１)
+func (v *Validator) ValidatePostalCode(field, value string) *ValidationError {
+	if value == "" {
+		return nil // Empty postal code is allowed unless required
+	}
+
+	if PostalCodeDigits(value) == "" {
+		return &ValidationError{
+			Field:   field,
+			Message: "郵便番号は7桁の数字で入力してください（例：100-0001）",
+		}
+	}
+
+	return nil
+}
+
+// ValidateCertificateNumber validates a ten-digit 受給者証番号
+func (v *Validator) ValidateCertificateNumber(field, value string) *ValidationError {
+	if value == "" {
+		return nil // Empty number is allowed unless required
+	}
+
+	if CertificateNumberDigits(value) == "" {
+		return &ValidationError{
+			Field:   field,
+			Message: "受給者証番号は10桁の数字で入力してください",
+		}
+	}
+
+	return nil
+}
+
+// ValidateMunicipalityCode validates a six-digit 市町村番号 including its check digit
+func (v *Validator) ValidateMunicipalityCode(field, value string) *ValidationError {
+	if value == "" {
+		return nil // Empty code is allowed unless required
+	}
+
+	if MunicipalityCodeDigits(value) == "" {
+		return &ValidationError{
+			Field:   field,
+			Message: "市町村番号は検査数字を含む6桁の数字で入力してください（例：131016）",
+		}
+	}
+
+	return nil
+}
+
+// ValidateServiceCode validates a two-digit サービス種類コード
+func (v *Validator) ValidateServiceCode(field, value string) *ValidationError {
+	if value == "" {
+		return nil // Empty code is allowed unless required
+	}
+
+	if ServiceCodeDigits(value) == "" {
+		return &ValidationError{
+			Field:   field,
+			Message: "サービス種類コードは2桁の数字で入力してください",
+		}
+	}
+
+	return nil
+}
+
+// ValidateDate validates a Gregorian or Japanese era date (2025/04/01, 令和7年4月1日, R7.4.1)
 func (v *Validator) ValidateDate(field, value string) *ValidationError {
 	if value == "" {
 		return nil // Empty date is allowed unless required
 	}
 	
-	// Try to parse as YYYY-MM-DD
-	if _, err := time.Parse("2006-01-02", value); err != nil {
+	if _, err := wareki.Parse(value); err != nil {
+		var rangeErr *wareki.RangeError
+		if errors.As(err, &rangeErr) {
+			return &ValidationError{
+				Field:   field,
+				Message: rangeErr.Error(),
+			}
+		}
 		return &ValidationError{
 			Field:   field,
-			Message: "日付はYYYY-MM-DD形式で入力してください",
+			Message: "日付は2025/04/01 または 令和7年4月1日（R7.4.1）の形式で入力してください",
 		}
 	}
 	
@@ -202,15 +277,17 @@ func (v *Validator) ValidateJapaneseName(field, value string) *ValidationError {
 	return nil
 }
 
-// ValidateKana validates katakana format
+// ValidateKana validates katakana format. Hiragana and half-width katakana
+// are accepted as NormalizeKana converts them.
 func (v *Validator) ValidateKana(field, value string) *ValidationError {
+	value = NormalizeKana(value)
 	if value == "" {
 		return nil // Empty kana allowed unless required
 	}
 	
-	// Check for valid katakana characters and spaces
+	// Check for valid katakana characters, the long vowel mark and spaces
 	for _, r := range value {
-		if !unicode.Is(unicode.Katakana, r) && r != ' ' && r != '　' {
+		if !unicode.Is(unicode.Katakana, r) && r != 'ー' && r != ' ' {
 			return &ValidationError{
 				Field:   field,
 				Message: "カタカナで入力してください",
@@ -69,6 +69,8 @@ func TestValidator_ValidatePhoneNumber(t *testing.T) {
 		{"invalid phone", "123", true},
 		{"phone with letters", "03-abcd-5678", true},
 		{"phone with invalid format", "3-1234-5678", true},
+		{"full-width digits", "０３－１２３４－５６７８", false},
+		{"dash variants", "090ー1234‐5678", false},
 	}
 	
 	for _, tt := range tests {
@@ -81,6 +83,85 @@ func TestValidator_ValidatePhoneNumber(t *testing.T) {
 	}
 }
 
+func TestValidator_ValidatePostalCode(t *testing.T) {
+	v := NewValidator()
+
+	tests := []struct {
+		name     string
+		code     string
+		hasError bool
+	}{
+		{"with hyphen", "100-0001", false},
+		{"without hyphen", "1000001", false},
+		{"postal mark", "〒100-0001", false},
+		{"full-width", "１００－０００１", false},
+		{"empty", "", false}, // Empty is allowed
+		{"too short", "100-001", true},
+		{"letters", "100-000A", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := v.ValidatePostalCode("postal_code", tt.code)
+			if (err != nil) != tt.hasError {
+				t.Errorf("ValidatePostalCode() error = %v, hasError %v", err, tt.hasError)
+			}
+		})
+	}
+}
+
+func TestFormValidator_ValidateRecipientFormPostalCode(t *testing.T) {
+	data := func(code string) map[string]string {
+		return map[string]string{"name": "田中太郎", "birth_date": "1990-05-15", "postal_code": code}
+	}
+
+	fv := NewFormValidator()
+	if errs := fv.ValidateRecipientForm(data("999-9999")); len(errs) != 0 {
+		t.Errorf("without a dictionary only the format is checked, got %v", errs)
+	}
+
+	var looked []string
+	fv.SetPostalCodeDictionary(func(code string) bool {
+		looked = append(looked, code)
+		return code == "1000001"
+	})
+	if errs := fv.ValidateRecipientForm(data("〒１００－０００１")); len(errs) != 0 {
+		t.Errorf("known postal code rejected: %v", errs)
+	}
+	if errs := fv.ValidateRecipientForm(data("999-9999")); len(errs) != 1 {
+		t.Errorf("unknown postal code: got %v, want one error", errs)
+	}
+	if errs := fv.ValidateRecipientForm(data("12-34")); len(errs) != 1 {
+		t.Errorf("malformed postal code: got %v, want one error", errs)
+	}
+	if len(looked) != 2 || looked[0] != "1000001" {
+		t.Errorf("dictionary consulted with %v, want digits of the two well-formed codes", looked)
+	}
+}
+
+func TestFormValidator_ValidateCertificateFormCodes(t *testing.T) {
+	data := func(number, municipality, service string) map[string]string {
+		return map[string]string{
+			"start_date":         "2026-04-01",
+			"end_date":           "2027-03-31",
+			"certificate_number": number,
+			"municipality_code":  municipality,
+			"service_code":       service,
+		}
+	}
+
+	fv := NewFormValidator()
+	if errs := fv.ValidateCertificateForm(data("１２３４５６７８９０", "131016", "46")); len(errs) != 0 {
+		t.Errorf("valid codes rejected: %v", errs)
+	}
+	if errs := fv.ValidateCertificateForm(data("", "", "")); len(errs) != 0 {
+		t.Errorf("codes are optional, got %v", errs)
+	}
+	if errs := fv.ValidateCertificateForm(data("12345", "131017", "460")); len(errs) != 3 {
+		t.Errorf("malformed codes: got %v, want three errors", errs)
+	}
+}
+
 func TestValidator_ValidateDate(t *testing.T) {
 	v := NewValidator()
 	
@@ -94,6 +175,10 @@ func TestValidator_ValidateDate(t *testing.T) {
 		{"invalid format", "31-12-2023", true},
 		{"invalid date", "2023-13-01", true},
 		{"invalid day", "2023-02-30", true},
+		{"slashes", "2023/12/31", false},
+		{"japanese era", "令和5年12月31日", false},
+		{"era abbreviation", "R5.12.31", false},
+		{"outside era", "平成32年1月1日", true},
 	}
 	
 	for _, tt := range tests {
@@ -145,7 +230,9 @@ func TestValidator_ValidateKana(t *testing.T) {
 		{"katakana", "タナカ", false},
 		{"katakana with space", "タナカ タロウ", false},
 		{"empty", "", false}, // Empty is allowed
-		{"hiragana", "たなか", true},
+		{"hiragana", "たなか", false},
+		{"half-width katakana", "ﾀﾅｶ ﾀﾛｳ", false},
+		{"long vowel mark", "サトー", false},
 		{"kanji", "田中", true},
 		{"mixed", "タナカ太郎", true},
 	}
@@ -292,6 +379,155 @@ func TestFormValidator_ValidateLoginForm(t *testing.T) {
 	}
 }
 
+func TestFormValidator_ValidateEmergencyContactForm(t *testing.T) {
+	fv := NewFormValidator()
+
+	tests := []struct {
+		name       string
+		data       map[string]string
+		errorCount int
+	}{
+		{
+			"valid contact",
+			map[string]string{
+				"name":         "田中花子",
+				"relationship": "母",
+				"mobile_phone": "090-1234-5678",
+			},
+			0,
+		},
+		{
+			"no phone number",
+			map[string]string{
+				"name":         "田中花子",
+				"relationship": "母",
+				"email":        "hanako@example.com",
+			},
+			1,
+		},
+		{
+			"invalid priority and phone",
+			map[string]string{
+				"priority":     "0",
+				"name":         "田中花子",
+				"relationship": "母",
+				"phone":        "abc",
+			},
+			2,
+		},
+		{
+			"missing name and relationship",
+			map[string]string{
+				"phone": "03-1234-5678",
+			},
+			2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errors := fv.ValidateEmergencyContactForm(tt.data)
+			if len(errors) != tt.errorCount {
+				t.Errorf("ValidateEmergencyContactForm() error count = %v, want %v: %v", len(errors), tt.errorCount, errors)
+			}
+		})
+	}
+}
+
+func TestFormValidator_ValidateMedicalRecordForm(t *testing.T) {
+	fv := NewFormValidator()
+
+	tests := []struct {
+		name       string
+		data       map[string]string
+		errorCount int
+	}{
+		{
+			"valid record",
+			map[string]string{
+				"has_epilepsy":          "true",
+				"seizure_protocol":      "5分以上続く場合は救急要請",
+				"hospital_phone":        "03-1111-2222",
+				"insurer_number":        "06138123",
+				"insurance_valid_until": "2026/03/31",
+			},
+			0,
+		},
+		{
+			"epilepsy without protocol",
+			map[string]string{
+				"has_epilepsy": "true",
+			},
+			1,
+		},
+		{
+			"invalid insurer number and expiry",
+			map[string]string{
+				"insurer_number":        "12345",
+				"insurance_valid_until": "2026/02/30",
+			},
+			2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errors := fv.ValidateMedicalRecordForm(tt.data)
+			if len(errors) != tt.errorCount {
+				t.Errorf("ValidateMedicalRecordForm() error count = %v, want %v: %v", len(errors), tt.errorCount, errors)
+			}
+		})
+	}
+}
+
+func TestFormValidator_ValidateIncidentForm(t *testing.T) {
+	fv := NewFormValidator()
+
+	tests := []struct {
+		name       string
+		data       map[string]string
+		errorCount int
+	}{
+		{
+			"valid report",
+			map[string]string{
+				"occurred_date": "2025/04/10",
+				"occurred_time": "14:30",
+				"category":      "fall",
+				"severity":      "near_miss",
+				"location":      "作業室",
+				"description":   "椅子から立ち上がる際にふらついた",
+			},
+			0,
+		},
+		{
+			"missing required fields",
+			map[string]string{},
+			5,
+		},
+		{
+			"invalid date and time",
+			map[string]string{
+				"occurred_date": "平成37年4月10日",
+				"occurred_time": "2時半",
+				"category":      "fall",
+				"severity":      "minor",
+				"description":   "転倒",
+			},
+			2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errors := fv.ValidateIncidentForm(tt.data)
+			if len(errors) != tt.errorCount {
+				t.Errorf("ValidateIncidentForm() error count = %v, want %v: %v", len(errors), tt.errorCount, errors)
+			}
+		})
+	}
+}
+
 func TestFormValidator_SanitizeInput(t *testing.T) {
 	fv := NewFormValidator()
 	
@@ -0,0 +1,72 @@
+package wareki
+
+import (
+	"fmt"
+	"time"
+)
+
+// Style is a date display preference
+type Style string
+
+const (
+	StyleGregorian Style = "gregorian" // 2025/04/01
+	StyleJapanese  Style = "wareki"    // 令和7年4月1日
+)
+
+// Styles lists the display styles in the order they are offered
+var Styles = []Style{StyleGregorian, StyleJapanese}
+
+// ParseStyle parses a configured style; empty means StyleGregorian
+func ParseStyle(s string) (Style, error) {
+	switch Style(s) {
+	case "", StyleGregorian:
+		return StyleGregorian, nil
+	case StyleJapanese:
+		return StyleJapanese, nil
+	default:
+		return "", fmt.Errorf("unknown date style %q (want %q or %q)", s, StyleGregorian, StyleJapanese)
+	}
+}
+
+// StyleFromLabel returns the style shown as label; unknown labels are StyleGregorian
+func StyleFromLabel(label string) Style {
+	for _, style := range Styles {
+		if style.Label() == label {
+			return style
+		}
+	}
+	return StyleGregorian
+}
+
+// Label returns the name of the style shown to users
+func (s Style) Label() string {
+	if s == StyleJapanese {
+		return "和暦（令和7年4月1日）"
+	}
+	return "西暦（2025/04/01）"
+}
+
+// Format formats a date for screens: 2025/04/01 or 令和7年4月1日.
+// Both forms are accepted by Parse.
+func (s Style) Format(t time.Time) string {
+	if s == StyleJapanese {
+		return Format(t)
+	}
+	return t.Format("2006/01/02")
+}
+
+// FormatLong formats a date for documents: 2025年04月01日 or 令和7年4月1日
+func (s Style) FormatLong(t time.Time) string {
+	if s == StyleJapanese {
+		return Format(t)
+	}
+	return t.Format("2006年01月02日")
+}
+
+// FormatShort formats a date for table cells: 2025/04/01 or R7.4.1
+func (s Style) FormatShort(t time.Time) string {
+	if s == StyleJapanese {
+		return FormatShort(t)
+	}
+	return t.Format("2006/01/02")
+}
@@ -0,0 +1,195 @@
+// Package wareki converts dates between the Gregorian calendar and the
+// Japanese era calendar (和暦) from Meiji to Reiwa.
+//
+// Dates before the adoption of the Gregorian calendar (明治6年) are treated as
+// proleptic Gregorian dates; the lunisolar calendar is not supported. Meiji
+// therefore starts on 1868-01-01, so 明治元年1月1日 is that Gregorian date
+// (the era was proclaimed in October but applied from the start of that year).
+package wareki
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Era is a Japanese era (元号)
+type Era struct {
+	Name   string    // 令和
+	Letter string    // R, used in abbreviations such as R7.4.1
+	Start  time.Time // First day of the era (UTC midnight)
+}
+
+// eras lists the supported eras, newest first
+var eras = []Era{
+	{Name: "令和", Letter: "R", Start: date(2019, 5, 1)},
+	{Name: "平成", Letter: "H", Start: date(1989, 1, 8)},
+	{Name: "昭和", Letter: "S", Start: date(1926, 12, 25)},
+	{Name: "大正", Letter: "T", Start: date(1912, 7, 30)},
+	{Name: "明治", Letter: "M", Start: date(1868, 1, 1)},
+}
+
+// Eras returns the supported eras, oldest first
+func Eras() []Era {
+	result := make([]Era, len(eras))
+	for i, era := range eras {
+		result[len(eras)-1-i] = era
+	}
+	return result
+}
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// End returns the last day of the era; the zero time for the current era
+func (e Era) End() time.Time {
+	for i, era := range eras {
+		if era.Name == e.Name && i > 0 {
+			return eras[i-1].Start.AddDate(0, 0, -1)
+		}
+	}
+	return time.Time{}
+}
+
+// EraOf returns the era of t and the year within it (1 for 元年).
+// ok is false for dates before Meiji.
+func EraOf(t time.Time) (era Era, year int, ok bool) {
+	day := date(t.Year(), t.Month(), t.Day())
+	for _, e := range eras {
+		if !day.Before(e.Start) {
+			return e, t.Year() - e.Start.Year() + 1, true
+		}
+	}
+	return Era{}, 0, false
+}
+
+// Format formats t as 令和7年4月1日, using 元年 for the first year.
+// Dates before Meiji are formatted as 1867年12月31日.
+func Format(t time.Time) string {
+	era, year, ok := EraOf(t)
+	if !ok {
+		return fmt.Sprintf("%d年%d月%d日", t.Year(), t.Month(), t.Day())
+	}
+	yearText := strconv.Itoa(year)
+	if year == 1 {
+		yearText = "元"
+	}
+	return fmt.Sprintf("%s%s年%d月%d日", era.Name, yearText, t.Month(), t.Day())
+}
+
+// FormatShort formats t as R7.4.1. Dates before Meiji are formatted as 1867.12.31.
+func FormatShort(t time.Time) string {
+	era, year, ok := EraOf(t)
+	if !ok {
+		return fmt.Sprintf("%d.%d.%d", t.Year(), t.Month(), t.Day())
+	}
+	return fmt.Sprintf("%s%d.%d.%d", era.Letter, year, t.Month(), t.Day())
+}
+
+// RangeError reports an era date that does not exist, such as 平成31年5月1日
+type RangeError struct {
+	Era  Era
+	Year int
+}
+
+func (e *RangeError) Error() string {
+	if end := e.Era.End(); !end.IsZero() {
+		return fmt.Sprintf("%s%d年の日付は存在しません（%sは%d年%d月%d日まで）",
+			e.Era.Name, e.Year, e.Era.Name, end.Year(), end.Month(), end.Day())
+	}
+	start := e.Era.Start
+	return fmt.Sprintf("%s%d年の日付は存在しません（%sは%d年%d月%d日から）",
+		e.Era.Name, e.Year, e.Era.Name, start.Year(), start.Month(), start.Day())
+}
+
+var (
+	// 令和7年4月1日, 令和元年5月1日
+	eraNamePattern = regexp.MustCompile(`^(明治|大正|昭和|平成|令和)(元|\d{1,2})年(\d{1,2})月(\d{1,2})日?$`)
+	// R7.4.1, S45/3/2, h1-1-8, 令7.4.1
+	eraLetterPattern = regexp.MustCompile(`^([MTSHRmtshr明大昭平令])(元|\d{1,2})[./\-年](\d{1,2})[./\-月](\d{1,2})日?$`)
+	// 2025-04-01, 2025/4/1, 2025.4.1, 2025年4月1日
+	gregorianPattern = regexp.MustCompile(`^(\d{4})[./\-年](\d{1,2})[./\-月](\d{1,2})日?$`)
+)
+
+// Parse parses a Gregorian or Japanese era date and returns it at UTC
+// midnight. Accepted forms include 2025-04-01, 2025/4/1, 2025年4月1日,
+// 令和7年4月1日, 令和元年5月1日, R7.4.1, S45/3/2 and H1-1-8. Full-width
+// digits and letters are accepted, and spaces are ignored.
+func Parse(s string) (time.Time, error) {
+	text := normalize(s)
+
+	if m := gregorianPattern.FindStringSubmatch(text); m != nil {
+		year, _ := strconv.Atoi(m[1])
+		return validDate(s, year, m[2], m[3])
+	}
+
+	var eraKey, yearText, month, day string
+	if m := eraNamePattern.FindStringSubmatch(text); m != nil {
+		eraKey, yearText, month, day = m[1], m[2], m[3], m[4]
+	} else if m := eraLetterPattern.FindStringSubmatch(text); m != nil {
+		eraKey, yearText, month, day = m[1], m[2], m[3], m[4]
+	} else {
+		return time.Time{}, fmt.Errorf("日付の形式が正しくありません: %q", s)
+	}
+
+	era, ok := lookupEra(eraKey)
+	if !ok {
+		return time.Time{}, fmt.Errorf("元号が正しくありません: %q", s)
+	}
+	eraYear := 1
+	if yearText != "元" {
+		eraYear, _ = strconv.Atoi(yearText)
+		if eraYear < 1 {
+			return time.Time{}, fmt.Errorf("年が正しくありません: %q", s)
+		}
+	}
+
+	t, err := validDate(s, era.Start.Year()+eraYear-1, month, day)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if t.Before(era.Start) || (!era.End().IsZero() && t.After(era.End())) {
+		return time.Time{}, &RangeError{Era: era, Year: eraYear}
+	}
+	return t, nil
+}
+
+// validDate builds the date and rejects days such as February 30
+func validDate(input string, year int, monthText, dayText string) (time.Time, error) {
+	month, _ := strconv.Atoi(monthText)
+	day, _ := strconv.Atoi(dayText)
+	t := date(year, time.Month(month), day)
+	if month < 1 || month > 12 || t.Month() != time.Month(month) || t.Day() != day {
+		return time.Time{}, fmt.Errorf("存在しない日付です: %q", input)
+	}
+	return t, nil
+}
+
+// lookupEra finds an era by name, letter or first kanji
+func lookupEra(key string) (Era, bool) {
+	for _, era := range eras {
+		if key == era.Name || strings.EqualFold(key, era.Letter) || strings.HasPrefix(era.Name, key) {
+			return era, true
+		}
+	}
+	return Era{}, false
+}
+
+// normalize converts full-width ASCII to half-width and removes spaces
+func normalize(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r == ' ' || r == '　' || r == '\t':
+			continue
+		case r >= '！' && r <= '～':
+			b.WriteRune(r - '！' + '!')
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
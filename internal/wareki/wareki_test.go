@@ -0,0 +1,141 @@
+package wareki
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	testCases := []struct {
+		input string
+		want  time.Time
+	}{
+		{"2025-04-01", date(2025, 4, 1)},
+		{"2025/4/1", date(2025, 4, 1)},
+		{"2025.04.01", date(2025, 4, 1)},
+		{"2025年4月1日", date(2025, 4, 1)},
+		{"令和7年4月1日", date(2025, 4, 1)},
+		{"令和元年5月1日", date(2019, 5, 1)},
+		{"R7.4.1", date(2025, 4, 1)},
+		{"r7/4/1", date(2025, 4, 1)},
+		{"S45/3/2", date(1970, 3, 2)},
+		{"H1-1-8", date(1989, 1, 8)},
+		{"昭64.1.7", date(1989, 1, 7)},
+		{"T1.7.30", date(1912, 7, 30)},
+		{"明治45年7月29日", date(1912, 7, 29)},
+		{"Ｒ７．４．１", date(2025, 4, 1)},
+		{" 令和 7 年 4 月 1 日 ", date(2025, 4, 1)},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			got, err := Parse(tc.input)
+			if err != nil {
+				t.Fatalf("Parse(%q) error = %v", tc.input, err)
+			}
+			if !got.Equal(tc.want) {
+				t.Errorf("Parse(%q) = %v, want %v", tc.input, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	for _, input := range []string{"", "R7", "令和7年2月30日", "2025-13-01", "X7.4.1", "R0.4.1", "abc"} {
+		if _, err := Parse(input); err == nil {
+			t.Errorf("Parse(%q) expected an error", input)
+		}
+	}
+}
+
+func TestParse_EraBoundaries(t *testing.T) {
+	testCases := []string{
+		"平成31年5月1日",  // 令和元年5月1日
+		"H1.1.7",     // 昭和64年1月7日
+		"令和元年4月30日",  // 平成31年4月30日
+		"昭和1年12月24日", // 大正15年12月24日
+	}
+
+	for _, input := range testCases {
+		_, err := Parse(input)
+		var rangeErr *RangeError
+		if !errors.As(err, &rangeErr) {
+			t.Errorf("Parse(%q) error = %v, want a RangeError", input, err)
+		}
+	}
+
+	if _, err := Parse("平成31年4月30日"); err != nil {
+		t.Errorf("the last day of Heisei must parse: %v", err)
+	}
+	// Era dates are proleptic Gregorian, so 明治元年1月1日 is 1868-01-01
+	if got, err := Parse("明治元年1月1日"); err != nil || !got.Equal(date(1868, 1, 1)) {
+		t.Errorf("the first day of Meiji must parse: %v, %v", got, err)
+	}
+}
+
+func TestFormat(t *testing.T) {
+	testCases := []struct {
+		date  time.Time
+		long  string
+		short string
+	}{
+		{date(2025, 4, 1), "令和7年4月1日", "R7.4.1"},
+		{date(2019, 5, 1), "令和元年5月1日", "R1.5.1"},
+		{date(2019, 4, 30), "平成31年4月30日", "H31.4.30"},
+		{date(1989, 1, 7), "昭和64年1月7日", "S64.1.7"},
+		{date(1926, 12, 25), "昭和元年12月25日", "S1.12.25"},
+		{date(1912, 7, 30), "大正元年7月30日", "T1.7.30"},
+		{date(1900, 1, 1), "明治33年1月1日", "M33.1.1"},
+		{date(1868, 10, 23), "明治元年10月23日", "M1.10.23"},
+		{date(1868, 1, 1), "明治元年1月1日", "M1.1.1"},
+		{date(1867, 12, 31), "1867年12月31日", "1867.12.31"},
+		{date(1850, 1, 1), "1850年1月1日", "1850.1.1"},
+	}
+
+	for _, tc := range testCases {
+		if got := Format(tc.date); got != tc.long {
+			t.Errorf("Format(%v) = %q, want %q", tc.date, got, tc.long)
+		}
+		if got := FormatShort(tc.date); got != tc.short {
+			t.Errorf("FormatShort(%v) = %q, want %q", tc.date, got, tc.short)
+		}
+	}
+}
+
+func TestFormat_RoundTrip(t *testing.T) {
+	for day := date(1873, 1, 1); day.Year() < 2030; day = day.AddDate(0, 0, 97) {
+		for _, text := range []string{Format(day), FormatShort(day)} {
+			got, err := Parse(text)
+			if err != nil || !got.Equal(day) {
+				t.Fatalf("Parse(%q) = %v, %v; want %v", text, got, err, day)
+			}
+		}
+	}
+}
+
+func TestStyle(t *testing.T) {
+	day := date(2025, 4, 1)
+	if got := StyleGregorian.Format(day); got != "2025/04/01" {
+		t.Errorf("StyleGregorian.Format = %q", got)
+	}
+	if got := StyleGregorian.FormatLong(day); got != "2025年04月01日" {
+		t.Errorf("StyleGregorian.FormatLong = %q", got)
+	}
+	if got := StyleJapanese.Format(day); got != "令和7年4月1日" {
+		t.Errorf("StyleJapanese.Format = %q", got)
+	}
+	if got := StyleJapanese.FormatShort(day); got != "R7.4.1" {
+		t.Errorf("StyleJapanese.FormatShort = %q", got)
+	}
+
+	if style, err := ParseStyle(""); err != nil || style != StyleGregorian {
+		t.Errorf("ParseStyle(\"\") = %q, %v", style, err)
+	}
+	if _, err := ParseStyle("heisei"); err == nil {
+		t.Error("ParseStyle should reject unknown styles")
+	}
+	if got := StyleFromLabel(StyleJapanese.Label()); got != StyleJapanese {
+		t.Errorf("StyleFromLabel = %q", got)
+	}
+}
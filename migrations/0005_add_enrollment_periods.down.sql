@@ -0,0 +1,3 @@
+-- 入退所期間テーブルを削除する（利用者の入退所日は recipients に残る。
+-- 0005 で登録日を補った入所日もそのまま残す）
+DROP TABLE enrollment_periods;
@@ -0,0 +1,39 @@
+-- 在籍期間（入所〜退所）テーブル
+-- 同じ利用者が退所後に再入所した場合も、期間ごとに1行として履歴を保持する
+CREATE TABLE enrollment_periods (
+    id TEXT PRIMARY KEY,
+    recipient_id TEXT NOT NULL REFERENCES recipients(id) ON DELETE CASCADE,
+    admission_date TEXT NOT NULL,
+    discharge_date TEXT, -- NULL の場合は在籍中
+    created_at TEXT NOT NULL,
+    updated_at TEXT NOT NULL,
+    CHECK (discharge_date IS NULL OR date(discharge_date) >= date(admission_date))
+);
+
+CREATE INDEX idx_enrollment_periods_recipient ON enrollment_periods(recipient_id);
+CREATE INDEX idx_enrollment_periods_dates ON enrollment_periods(admission_date, discharge_date);
+
+-- 入所日が未設定の利用者は登録日から在籍しているものとし、登録日を入所日とする
+-- （新規登録・一括取込で入所日を省略した場合と同じ扱い。入所日が退所日より後に
+-- なる不整合データは退所日に揃える）
+UPDATE recipients
+SET admission_date = MIN(created_at, COALESCE(discharge_date, created_at))
+WHERE admission_date IS NULL;
+
+-- 既存の recipients.admission_date / discharge_date から初期の在籍期間を作成
+-- ID はアプリケーションと同じ UUID v4 形式で生成する
+INSERT INTO enrollment_periods (id, recipient_id, admission_date, discharge_date, created_at, updated_at)
+SELECT
+    lower(printf('%s-%s-4%s-%s%s-%s',
+        hex(randomblob(4)),
+        hex(randomblob(2)),
+        substr(hex(randomblob(2)), 2),
+        substr('89ab', 1 + abs(random()) % 4, 1),
+        substr(hex(randomblob(2)), 2),
+        hex(randomblob(6)))),
+    id,
+    MIN(admission_date, COALESCE(discharge_date, admission_date)),
+    discharge_date,
+    created_at,
+    updated_at
+FROM recipients;
@@ -0,0 +1,2 @@
+-- 緊急連絡先テーブルを削除する
+DROP TABLE emergency_contacts;
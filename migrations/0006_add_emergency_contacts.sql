@@ -0,0 +1,19 @@
+-- 緊急連絡先（保護者・家族・後見人など）テーブル（暗号化フィールド）
+-- priority は連絡する順番（1が最優先）
+CREATE TABLE emergency_contacts (
+    id TEXT PRIMARY KEY,
+    recipient_id TEXT NOT NULL REFERENCES recipients(id) ON DELETE CASCADE,
+    priority INTEGER NOT NULL CHECK (priority >= 1),
+    name_cipher BLOB NOT NULL,
+    relationship_cipher BLOB NOT NULL,
+    is_guardian INTEGER NOT NULL DEFAULT 0,
+    phone_cipher BLOB,
+    mobile_phone_cipher BLOB,
+    work_phone_cipher BLOB,
+    email_cipher BLOB,
+    notes_cipher BLOB,
+    created_at TEXT NOT NULL,
+    updated_at TEXT NOT NULL
+);
+
+CREATE INDEX idx_emergency_contacts_recipient ON emergency_contacts(recipient_id, priority);
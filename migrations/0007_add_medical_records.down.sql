@@ -0,0 +1,2 @@
+-- 医療・健康情報テーブルを削除する
+DROP TABLE medical_records;
@@ -0,0 +1,21 @@
+-- 医療・健康情報テーブル（利用者ごとに1件、暗号化フィールド）
+-- 服薬・アレルギーは一覧をJSONにまとめて暗号化して保存する
+CREATE TABLE medical_records (
+    recipient_id TEXT PRIMARY KEY REFERENCES recipients(id) ON DELETE CASCADE,
+    medications_cipher BLOB,
+    allergies_cipher BLOB,
+    has_epilepsy INTEGER NOT NULL DEFAULT 0,
+    seizure_protocol_cipher BLOB,
+    hospital_name_cipher BLOB,
+    hospital_phone_cipher BLOB,
+    doctor_name_cipher BLOB,
+    insurance_type_cipher BLOB,
+    insurer_number_cipher BLOB,
+    insured_symbol_cipher BLOB,
+    insured_number_cipher BLOB,
+    insurance_valid_until TEXT,
+    notes_cipher BLOB,
+    updated_by TEXT REFERENCES staff(id),
+    created_at TEXT NOT NULL,
+    updated_at TEXT NOT NULL
+);
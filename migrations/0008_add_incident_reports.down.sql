@@ -0,0 +1,4 @@
+-- 事故・ヒヤリハット報告のテーブルを削除する
+DROP TABLE incident_staff;
+DROP TABLE incident_recipients;
+DROP TABLE incident_reports;
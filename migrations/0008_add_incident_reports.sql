@@ -0,0 +1,41 @@
+-- 事故・ヒヤリハット報告テーブル（暗号化フィールド）
+-- 提出→確認→承認のワークフローを status で管理する
+CREATE TABLE incident_reports (
+    id TEXT PRIMARY KEY,
+    occurred_at TEXT NOT NULL,
+    location_cipher BLOB,
+    category TEXT NOT NULL CHECK (category IN ('fall','medication','choking','missing','injury','conflict','transport','infection','privacy','other')),
+    severity TEXT NOT NULL CHECK (severity IN ('near_miss','minor','moderate','severe')),
+    description_cipher BLOB,
+    response_cipher BLOB,
+    prevention_cipher BLOB,
+    status TEXT NOT NULL DEFAULT 'draft' CHECK (status IN ('draft','submitted','reviewed','approved')),
+    reported_by TEXT NOT NULL REFERENCES staff(id),
+    submitted_at TEXT,
+    reviewed_by TEXT REFERENCES staff(id),
+    reviewed_at TEXT,
+    review_comment_cipher BLOB,
+    approved_by TEXT REFERENCES staff(id),
+    approved_at TEXT,
+    created_at TEXT NOT NULL,
+    updated_at TEXT NOT NULL
+);
+
+CREATE INDEX idx_incident_reports_occurred_at ON incident_reports(occurred_at);
+CREATE INDEX idx_incident_reports_status ON incident_reports(status);
+
+-- 関係した利用者（報告書は記録として残すため利用者削除時も連鎖削除しない）
+CREATE TABLE incident_recipients (
+    incident_id TEXT NOT NULL REFERENCES incident_reports(id) ON DELETE CASCADE,
+    recipient_id TEXT NOT NULL REFERENCES recipients(id),
+    PRIMARY KEY (incident_id, recipient_id)
+);
+
+CREATE INDEX idx_incident_recipients_recipient ON incident_recipients(recipient_id);
+
+-- 関係・立ち会った職員
+CREATE TABLE incident_staff (
+    incident_id TEXT NOT NULL REFERENCES incident_reports(id) ON DELETE CASCADE,
+    staff_id TEXT NOT NULL REFERENCES staff(id),
+    PRIMARY KEY (incident_id, staff_id)
+);
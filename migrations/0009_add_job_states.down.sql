@@ -0,0 +1,2 @@
+-- バックグラウンドジョブの実行状態テーブルを削除する
+DROP TABLE job_states;
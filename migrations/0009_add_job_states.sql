@@ -0,0 +1,11 @@
+-- バックグラウンドジョブの実行状態（前回・次回実行日時、失敗回数）
+CREATE TABLE job_states (
+    name TEXT PRIMARY KEY,
+    last_run_at TEXT,
+    last_success_at TEXT,
+    last_duration_ms INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT NOT NULL DEFAULT '',
+    consecutive_failures INTEGER NOT NULL DEFAULT 0,
+    next_run_at TEXT,
+    updated_at TEXT NOT NULL
+);
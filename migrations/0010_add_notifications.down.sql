@@ -0,0 +1,3 @@
+-- 通知テーブルを削除する
+DROP TABLE notification_receipts;
+DROP TABLE notifications;
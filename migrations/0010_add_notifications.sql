@@ -0,0 +1,27 @@
+-- アプリ内通知（受給者証期限・退所者の担当・アカウントロック）
+-- 同じ状態に対する未解決の通知は dedup_key で1件に保つ
+CREATE TABLE notifications (
+    id TEXT PRIMARY KEY,
+    kind TEXT NOT NULL CHECK (kind IN ('certificate_expiring','discharged_assigned','account_locked')),
+    severity TEXT NOT NULL CHECK (severity IN ('info','warning','critical')),
+    audience TEXT NOT NULL DEFAULT 'all' CHECK (audience IN ('all','admin')),
+    title TEXT NOT NULL,
+    message_cipher BLOB,
+    target_type TEXT NOT NULL DEFAULT '',
+    target_id TEXT NOT NULL DEFAULT '',
+    dedup_key TEXT NOT NULL,
+    created_at TEXT NOT NULL,
+    resolved_at TEXT
+);
+
+CREATE UNIQUE INDEX idx_notifications_open_dedup_key ON notifications(dedup_key) WHERE resolved_at IS NULL;
+CREATE INDEX idx_notifications_kind ON notifications(kind);
+
+-- 職員ごとの既読・確認状態
+CREATE TABLE notification_receipts (
+    notification_id TEXT NOT NULL REFERENCES notifications(id) ON DELETE CASCADE,
+    staff_id TEXT NOT NULL REFERENCES staff(id) ON DELETE CASCADE,
+    read_at TEXT,
+    acknowledged_at TEXT,
+    PRIMARY KEY (notification_id, staff_id)
+);
@@ -0,0 +1,30 @@
+-- レート制限ポリシーの出所と有効フラグを削除する
+-- DROP COLUMN は SQLite 3.35 以降でしか使えず、SQLCipher ビルドに同梱の SQLite では
+-- 失敗するため、0003 の定義でテーブルを作り直す
+CREATE TABLE rate_limit_config_old (
+    id TEXT PRIMARY KEY,
+    max_attempts_per_ip INTEGER NOT NULL DEFAULT 5,
+    max_attempts_per_user INTEGER NOT NULL DEFAULT 3,
+    window_size_minutes INTEGER NOT NULL DEFAULT 15,
+    lockout_duration_minutes INTEGER NOT NULL DEFAULT 30,
+    backoff_multiplier REAL NOT NULL DEFAULT 2.0,
+    max_lockout_hours INTEGER NOT NULL DEFAULT 24,
+    whitelist_ips TEXT, -- JSON array of whitelisted IPs
+    enable_progressive_lockout BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TEXT NOT NULL,
+    updated_at TEXT NOT NULL
+);
+
+INSERT INTO rate_limit_config_old (
+    id, max_attempts_per_ip, max_attempts_per_user, window_size_minutes,
+    lockout_duration_minutes, backoff_multiplier, max_lockout_hours,
+    whitelist_ips, enable_progressive_lockout, created_at, updated_at
+)
+SELECT
+    id, max_attempts_per_ip, max_attempts_per_user, window_size_minutes,
+    lockout_duration_minutes, backoff_multiplier, max_lockout_hours,
+    whitelist_ips, enable_progressive_lockout, created_at, updated_at
+FROM rate_limit_config;
+
+DROP TABLE rate_limit_config;
+ALTER TABLE rate_limit_config_old RENAME TO rate_limit_config;
@@ -0,0 +1,7 @@
+-- レート制限ポリシーの出所と有効フラグ
+-- source: default=初期値, config=config.yaml から同期, admin=管理画面で変更
+-- config_hash: 最後に参照した config.yaml の rate_limit セクションのハッシュ
+ALTER TABLE rate_limit_config ADD COLUMN enabled BOOLEAN NOT NULL DEFAULT TRUE;
+ALTER TABLE rate_limit_config ADD COLUMN source TEXT NOT NULL DEFAULT 'default' CHECK (source IN ('default','config','admin'));
+ALTER TABLE rate_limit_config ADD COLUMN config_hash TEXT NOT NULL DEFAULT '';
+ALTER TABLE rate_limit_config ADD COLUMN updated_by TEXT;
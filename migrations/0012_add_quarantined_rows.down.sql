@@ -0,0 +1,2 @@
+-- 隔離テーブルを削除する（隔離した行も失われる）
+DROP TABLE quarantined_rows;
@@ -0,0 +1,15 @@
+-- 整合性チェックで復号できなかった行の退避先
+-- row_data: 元の行のJSON（BLOB列はBase64）。旧い鍵が見つかれば復元できるよう暗号文のまま保存する
+-- parent_id: 親行の隔離に伴って一緒に移動した行の場合、その親の隔離ID
+CREATE TABLE quarantined_rows (
+    id TEXT PRIMARY KEY,
+    source_table TEXT NOT NULL,
+    source_rowid INTEGER NOT NULL,
+    row_data TEXT NOT NULL,
+    reason TEXT NOT NULL,
+    parent_id TEXT,
+    quarantined_by TEXT NOT NULL,
+    quarantined_at TEXT NOT NULL
+);
+
+CREATE INDEX idx_quarantined_rows_source ON quarantined_rows(source_table, quarantined_at);
@@ -0,0 +1,87 @@
+-- 行バージョンを削除する
+-- DROP COLUMN は SQLite 3.35 以降でしか使えず、SQLCipher ビルドに同梱の SQLite では
+-- 失敗するため、0001 の定義でテーブルを作り直す。参照元の行が連鎖削除されないよう、
+-- ロールバック中は外部キーが無効になる
+CREATE TABLE staff_old (
+    id TEXT PRIMARY KEY,
+    name TEXT NOT NULL,
+    role TEXT NOT NULL CHECK (role IN ('admin', 'staff', 'readonly')),
+    password_hash TEXT NOT NULL,
+    created_at TEXT NOT NULL,
+    updated_at TEXT NOT NULL
+);
+
+INSERT INTO staff_old (id, name, role, password_hash, created_at, updated_at)
+SELECT id, name, role, password_hash, created_at, updated_at
+FROM staff;
+
+DROP TABLE staff;
+ALTER TABLE staff_old RENAME TO staff;
+
+CREATE TABLE benefit_certificates_old (
+    id TEXT PRIMARY KEY,
+    recipient_id TEXT NOT NULL REFERENCES recipients(id) ON DELETE CASCADE,
+    start_date TEXT NOT NULL,
+    end_date TEXT NOT NULL,
+    issuer_cipher BLOB,
+    service_type_cipher BLOB,
+    max_benefit_days_per_month_cipher BLOB,
+    benefit_details_cipher BLOB,
+    created_at TEXT NOT NULL,
+    updated_at TEXT NOT NULL
+);
+
+INSERT INTO benefit_certificates_old (
+    id, recipient_id, start_date, end_date, issuer_cipher, service_type_cipher,
+    max_benefit_days_per_month_cipher, benefit_details_cipher, created_at, updated_at
+)
+SELECT
+    id, recipient_id, start_date, end_date, issuer_cipher, service_type_cipher,
+    max_benefit_days_per_month_cipher, benefit_details_cipher, created_at, updated_at
+FROM benefit_certificates;
+
+DROP TABLE benefit_certificates;
+ALTER TABLE benefit_certificates_old RENAME TO benefit_certificates;
+
+CREATE INDEX idx_certificates_recipient ON benefit_certificates(recipient_id);
+CREATE INDEX idx_certificates_date_range ON benefit_certificates(start_date, end_date);
+CREATE INDEX idx_certificates_expiry ON benefit_certificates(end_date);
+
+CREATE TABLE recipients_old (
+    id TEXT PRIMARY KEY,
+    name_cipher BLOB NOT NULL,
+    kana_cipher BLOB,
+    sex_cipher BLOB NOT NULL,
+    birth_date_cipher BLOB NOT NULL,
+    disability_name_cipher BLOB,
+    has_disability_id_cipher BLOB NOT NULL,
+    grade_cipher BLOB,
+    address_cipher BLOB,
+    phone_cipher BLOB,
+    email_cipher BLOB,
+    public_assistance_cipher BLOB NOT NULL,
+    admission_date TEXT,
+    discharge_date TEXT,
+    created_at TEXT NOT NULL,
+    updated_at TEXT NOT NULL
+);
+
+INSERT INTO recipients_old (
+    id, name_cipher, kana_cipher, sex_cipher, birth_date_cipher,
+    disability_name_cipher, has_disability_id_cipher, grade_cipher,
+    address_cipher, phone_cipher, email_cipher, public_assistance_cipher,
+    admission_date, discharge_date, created_at, updated_at
+)
+SELECT
+    id, name_cipher, kana_cipher, sex_cipher, birth_date_cipher,
+    disability_name_cipher, has_disability_id_cipher, grade_cipher,
+    address_cipher, phone_cipher, email_cipher, public_assistance_cipher,
+    admission_date, discharge_date, created_at, updated_at
+FROM recipients;
+
+DROP TABLE recipients;
+ALTER TABLE recipients_old RENAME TO recipients;
+
+CREATE INDEX idx_recipients_name_search ON recipients(name_cipher);
+CREATE INDEX idx_recipients_created_at ON recipients(created_at);
+CREATE INDEX idx_recipients_discharge_status ON recipients(discharge_date);
@@ -0,0 +1,5 @@
+-- 楽観的排他制御のための行バージョン
+-- 更新のたびに 1 増やし、UPDATE は読み込んだ時点のバージョンと一致する場合のみ成功する
+ALTER TABLE recipients ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE benefit_certificates ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE staff ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
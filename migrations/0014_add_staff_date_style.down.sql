@@ -0,0 +1,19 @@
+-- 日付の表示形式を削除する
+-- SQLCipher ビルドに同梱の SQLite には DROP COLUMN がないため、0013 適用後の定義で
+-- テーブルを作り直す
+CREATE TABLE staff_old (
+    id TEXT PRIMARY KEY,
+    name TEXT NOT NULL,
+    role TEXT NOT NULL CHECK (role IN ('admin', 'staff', 'readonly')),
+    password_hash TEXT NOT NULL,
+    created_at TEXT NOT NULL,
+    updated_at TEXT NOT NULL,
+    version INTEGER NOT NULL DEFAULT 1
+);
+
+INSERT INTO staff_old (id, name, role, password_hash, created_at, updated_at, version)
+SELECT id, name, role, password_hash, created_at, updated_at, version
+FROM staff;
+
+DROP TABLE staff;
+ALTER TABLE staff_old RENAME TO staff;
@@ -0,0 +1,4 @@
+-- 職員ごとの日付の表示形式（gregorian: 西暦、wareki: 和暦）
+-- 入力はどちらの形式でも受け付け、表示のみこの設定に従う
+ALTER TABLE staff ADD COLUMN date_style TEXT NOT NULL DEFAULT 'gregorian'
+    CHECK (date_style IN ('gregorian', 'wareki'));
@@ -0,0 +1,45 @@
+-- 郵便番号辞書と利用者住所の構成要素を削除する
+-- 住所全体は address_cipher に残る。SQLCipher ビルドに同梱の SQLite には DROP COLUMN が
+-- ないため、0014 適用後の定義でテーブルを作り直す
+CREATE TABLE recipients_old (
+    id TEXT PRIMARY KEY,
+    name_cipher BLOB NOT NULL,
+    kana_cipher BLOB,
+    sex_cipher BLOB NOT NULL,
+    birth_date_cipher BLOB NOT NULL,
+    disability_name_cipher BLOB,
+    has_disability_id_cipher BLOB NOT NULL,
+    grade_cipher BLOB,
+    address_cipher BLOB,
+    phone_cipher BLOB,
+    email_cipher BLOB,
+    public_assistance_cipher BLOB NOT NULL,
+    admission_date TEXT,
+    discharge_date TEXT,
+    created_at TEXT NOT NULL,
+    updated_at TEXT NOT NULL,
+    version INTEGER NOT NULL DEFAULT 1
+);
+
+INSERT INTO recipients_old (
+    id, name_cipher, kana_cipher, sex_cipher, birth_date_cipher,
+    disability_name_cipher, has_disability_id_cipher, grade_cipher,
+    address_cipher, phone_cipher, email_cipher, public_assistance_cipher,
+    admission_date, discharge_date, created_at, updated_at, version
+)
+SELECT
+    id, name_cipher, kana_cipher, sex_cipher, birth_date_cipher,
+    disability_name_cipher, has_disability_id_cipher, grade_cipher,
+    address_cipher, phone_cipher, email_cipher, public_assistance_cipher,
+    admission_date, discharge_date, created_at, updated_at, version
+FROM recipients;
+
+DROP TABLE recipients;
+ALTER TABLE recipients_old RENAME TO recipients;
+
+CREATE INDEX idx_recipients_name_search ON recipients(name_cipher);
+CREATE INDEX idx_recipients_created_at ON recipients(created_at);
+CREATE INDEX idx_recipients_discharge_status ON recipients(discharge_date);
+
+DROP INDEX IF EXISTS idx_postal_codes_code;
+DROP TABLE IF EXISTS postal_codes;
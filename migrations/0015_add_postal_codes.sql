@@ -0,0 +1,20 @@
+-- 郵便番号辞書（日本郵便 KEN_ALL.CSV から取り込む）
+-- 公開データのため暗号化しない。1つの郵便番号に複数の町域が対応することがある
+CREATE TABLE postal_codes (
+    code TEXT NOT NULL CHECK (length(code) = 7),
+    prefecture TEXT NOT NULL,
+    city TEXT NOT NULL,
+    town TEXT NOT NULL DEFAULT '',
+    prefecture_kana TEXT NOT NULL DEFAULT '',
+    city_kana TEXT NOT NULL DEFAULT '',
+    town_kana TEXT NOT NULL DEFAULT ''
+);
+
+CREATE INDEX idx_postal_codes_code ON postal_codes(code);
+
+-- 利用者住所の郵便番号と構成要素（他の個人情報と同じく暗号化して保存する）
+-- 既存の利用者は address_cipher のみを持ち、これらは NULL のまま
+ALTER TABLE recipients ADD COLUMN postal_code_cipher BLOB;
+ALTER TABLE recipients ADD COLUMN prefecture_cipher BLOB;
+ALTER TABLE recipients ADD COLUMN city_cipher BLOB;
+ALTER TABLE recipients ADD COLUMN street_cipher BLOB;
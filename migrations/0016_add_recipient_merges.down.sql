@@ -0,0 +1,3 @@
+-- 利用者の統合履歴を削除する（統合で削除した利用者の内容も失われる）
+DROP INDEX IF EXISTS idx_recipient_merges_survivor;
+DROP TABLE IF EXISTS recipient_merges;
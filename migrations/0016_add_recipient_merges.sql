@@ -0,0 +1,15 @@
+-- 重複登録された利用者の統合履歴
+-- merged_data_cipher: 統合で削除した利用者（と医療情報）の統合前の内容（JSON を暗号化）
+-- 統合先の利用者が削除されても履歴は残すため、survivor_id に外部キーを付けない
+CREATE TABLE recipient_merges (
+    id TEXT PRIMARY KEY,
+    survivor_id TEXT NOT NULL,
+    merged_id TEXT NOT NULL,
+    merged_data_cipher BLOB NOT NULL,
+    moved_counts TEXT NOT NULL DEFAULT '{}',
+    reason_cipher BLOB,
+    merged_by TEXT NOT NULL REFERENCES staff(id),
+    merged_at TEXT NOT NULL
+);
+
+CREATE INDEX idx_recipient_merges_survivor ON recipient_merges(survivor_id, merged_at);
@@ -0,0 +1,81 @@
+-- 手帳・障害支援区分・難病の列と更新期限の通知を削除する
+-- SQLCipher ビルドに同梱の SQLite には DROP COLUMN がないため、利用者テーブルも
+-- 0016 適用後の定義で作り直す
+CREATE TABLE notifications_old (
+    id TEXT PRIMARY KEY,
+    kind TEXT NOT NULL CHECK (kind IN ('certificate_expiring','discharged_assigned','account_locked')),
+    severity TEXT NOT NULL CHECK (severity IN ('info','warning','critical')),
+    audience TEXT NOT NULL DEFAULT 'all' CHECK (audience IN ('all','admin')),
+    title TEXT NOT NULL,
+    message_cipher BLOB,
+    target_type TEXT NOT NULL DEFAULT '',
+    target_id TEXT NOT NULL DEFAULT '',
+    dedup_key TEXT NOT NULL,
+    created_at TEXT NOT NULL,
+    resolved_at TEXT
+);
+INSERT INTO notifications_old SELECT * FROM notifications WHERE kind <> 'disability_renewal';
+
+CREATE TABLE notification_receipts_old (
+    notification_id TEXT NOT NULL REFERENCES notifications_old(id) ON DELETE CASCADE,
+    staff_id TEXT NOT NULL REFERENCES staff(id) ON DELETE CASCADE,
+    read_at TEXT,
+    acknowledged_at TEXT,
+    PRIMARY KEY (notification_id, staff_id)
+);
+INSERT INTO notification_receipts_old
+    SELECT * FROM notification_receipts WHERE notification_id IN (SELECT id FROM notifications_old);
+
+DROP TABLE notification_receipts;
+DROP TABLE notifications;
+ALTER TABLE notifications_old RENAME TO notifications;
+ALTER TABLE notification_receipts_old RENAME TO notification_receipts;
+
+CREATE UNIQUE INDEX idx_notifications_open_dedup_key ON notifications(dedup_key) WHERE resolved_at IS NULL;
+CREATE INDEX idx_notifications_kind ON notifications(kind);
+
+CREATE TABLE recipients_old (
+    id TEXT PRIMARY KEY,
+    name_cipher BLOB NOT NULL,
+    kana_cipher BLOB,
+    sex_cipher BLOB NOT NULL,
+    birth_date_cipher BLOB NOT NULL,
+    disability_name_cipher BLOB,
+    has_disability_id_cipher BLOB NOT NULL,
+    grade_cipher BLOB,
+    address_cipher BLOB,
+    phone_cipher BLOB,
+    email_cipher BLOB,
+    public_assistance_cipher BLOB NOT NULL,
+    admission_date TEXT,
+    discharge_date TEXT,
+    created_at TEXT NOT NULL,
+    updated_at TEXT NOT NULL,
+    version INTEGER NOT NULL DEFAULT 1,
+    postal_code_cipher BLOB,
+    prefecture_cipher BLOB,
+    city_cipher BLOB,
+    street_cipher BLOB
+);
+
+INSERT INTO recipients_old (
+    id, name_cipher, kana_cipher, sex_cipher, birth_date_cipher,
+    disability_name_cipher, has_disability_id_cipher, grade_cipher,
+    address_cipher, phone_cipher, email_cipher, public_assistance_cipher,
+    admission_date, discharge_date, created_at, updated_at, version,
+    postal_code_cipher, prefecture_cipher, city_cipher, street_cipher
+)
+SELECT
+    id, name_cipher, kana_cipher, sex_cipher, birth_date_cipher,
+    disability_name_cipher, has_disability_id_cipher, grade_cipher,
+    address_cipher, phone_cipher, email_cipher, public_assistance_cipher,
+    admission_date, discharge_date, created_at, updated_at, version,
+    postal_code_cipher, prefecture_cipher, city_cipher, street_cipher
+FROM recipients;
+
+DROP TABLE recipients;
+ALTER TABLE recipients_old RENAME TO recipients;
+
+CREATE INDEX idx_recipients_name_search ON recipients(name_cipher);
+CREATE INDEX idx_recipients_created_at ON recipients(created_at);
+CREATE INDEX idx_recipients_discharge_status ON recipients(discharge_date);
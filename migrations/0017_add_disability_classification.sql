@@ -0,0 +1,40 @@
+-- 障害者手帳（身体・療育・精神）、障害支援区分、指定難病を構造化して保存する
+-- いずれも JSON を暗号化して保存し、登録がない場合は NULL
+-- 既存の disability_name_cipher・grade_cipher・has_disability_id_cipher は残す
+ALTER TABLE recipients ADD COLUMN handbooks_cipher BLOB;
+ALTER TABLE recipients ADD COLUMN support_category_cipher BLOB;
+ALTER TABLE recipients ADD COLUMN intractable_disease_cipher BLOB;
+
+-- 手帳・障害支援区分・難病の更新期限の通知を追加する
+-- kind の CHECK 制約を変えるため、通知と既読状態のテーブルを作り直す
+CREATE TABLE notifications_new (
+    id TEXT PRIMARY KEY,
+    kind TEXT NOT NULL CHECK (kind IN ('certificate_expiring','discharged_assigned','account_locked','disability_renewal')),
+    severity TEXT NOT NULL CHECK (severity IN ('info','warning','critical')),
+    audience TEXT NOT NULL DEFAULT 'all' CHECK (audience IN ('all','admin')),
+    title TEXT NOT NULL,
+    message_cipher BLOB,
+    target_type TEXT NOT NULL DEFAULT '',
+    target_id TEXT NOT NULL DEFAULT '',
+    dedup_key TEXT NOT NULL,
+    created_at TEXT NOT NULL,
+    resolved_at TEXT
+);
+INSERT INTO notifications_new SELECT * FROM notifications;
+
+CREATE TABLE notification_receipts_new (
+    notification_id TEXT NOT NULL REFERENCES notifications_new(id) ON DELETE CASCADE,
+    staff_id TEXT NOT NULL REFERENCES staff(id) ON DELETE CASCADE,
+    read_at TEXT,
+    acknowledged_at TEXT,
+    PRIMARY KEY (notification_id, staff_id)
+);
+INSERT INTO notification_receipts_new SELECT * FROM notification_receipts;
+
+DROP TABLE notification_receipts;
+DROP TABLE notifications;
+ALTER TABLE notifications_new RENAME TO notifications;
+ALTER TABLE notification_receipts_new RENAME TO notification_receipts;
+
+CREATE UNIQUE INDEX idx_notifications_open_dedup_key ON notifications(dedup_key) WHERE resolved_at IS NULL;
+CREATE INDEX idx_notifications_kind ON notifications(kind);
@@ -0,0 +1,35 @@
+-- 受給者証の番号・コード列と市町村・サービス種類マスタを削除する
+-- 発行者・サービス種別の名称は issuer_cipher・service_type_cipher に残る。SQLCipher ビルドに
+-- 同梱の SQLite には DROP COLUMN がないため、0017 適用後の定義でテーブルを作り直す
+CREATE TABLE benefit_certificates_old (
+    id TEXT PRIMARY KEY,
+    recipient_id TEXT NOT NULL REFERENCES recipients(id) ON DELETE CASCADE,
+    start_date TEXT NOT NULL,
+    end_date TEXT NOT NULL,
+    issuer_cipher BLOB,
+    service_type_cipher BLOB,
+    max_benefit_days_per_month_cipher BLOB,
+    benefit_details_cipher BLOB,
+    created_at TEXT NOT NULL,
+    updated_at TEXT NOT NULL,
+    version INTEGER NOT NULL DEFAULT 1
+);
+
+INSERT INTO benefit_certificates_old (
+    id, recipient_id, start_date, end_date, issuer_cipher, service_type_cipher,
+    max_benefit_days_per_month_cipher, benefit_details_cipher, created_at, updated_at, version
+)
+SELECT
+    id, recipient_id, start_date, end_date, issuer_cipher, service_type_cipher,
+    max_benefit_days_per_month_cipher, benefit_details_cipher, created_at, updated_at, version
+FROM benefit_certificates;
+
+DROP TABLE benefit_certificates;
+ALTER TABLE benefit_certificates_old RENAME TO benefit_certificates;
+
+CREATE INDEX idx_certificates_recipient ON benefit_certificates(recipient_id);
+CREATE INDEX idx_certificates_date_range ON benefit_certificates(start_date, end_date);
+CREATE INDEX idx_certificates_expiry ON benefit_certificates(end_date);
+
+DROP TABLE IF EXISTS service_types;
+DROP TABLE IF EXISTS municipalities;
@@ -0,0 +1,19 @@
+-- 市町村マスタ（市町村番号は検査数字を含む6桁。CSV から取り込む）
+-- 公開データのため暗号化しない
+CREATE TABLE municipalities (
+    code TEXT PRIMARY KEY CHECK (length(code) = 6),
+    prefecture TEXT NOT NULL DEFAULT '',
+    name TEXT NOT NULL
+);
+
+-- サービス種類マスタ（サービス種類コードは2桁。CSV から取り込む）
+CREATE TABLE service_types (
+    code TEXT PRIMARY KEY CHECK (length(code) = 2),
+    name TEXT NOT NULL
+);
+
+-- 受給者証番号・市町村番号・サービス種類コード（他の受給者証の項目と同じく暗号化して保存する）
+-- 既存の受給者証は発行者・サービス種別の名称のみを持ち、これらは NULL のまま
+ALTER TABLE benefit_certificates ADD COLUMN certificate_number_cipher BLOB;
+ALTER TABLE benefit_certificates ADD COLUMN municipality_code_cipher BLOB;
+ALTER TABLE benefit_certificates ADD COLUMN service_code_cipher BLOB;
@@ -0,0 +1,13 @@
+// Package migrations embeds the SQL schema migrations into the binary so a
+// packaged application does not depend on a migrations folder next to it.
+//
+// Files are named NNNN_name.sql. An optional NNNN_name.down.sql reverts the
+// migration of the same version.
+package migrations
+
+import "embed"
+
+// FS holds every migration file
+//
+//go:embed *.sql
+var FS embed.FS